@@ -86,6 +86,265 @@ func (BlobStatus) EnumDescriptor() ([]byte, []int) {
 	return file_disperser_disperser_proto_rawDescGZIP(), []int{0}
 }
 
+type AuthenticatedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*AuthenticatedRequest_DisperseRequest
+	//	*AuthenticatedRequest_AuthenticationData
+	Payload isAuthenticatedRequest_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *AuthenticatedRequest) Reset() {
+	*x = AuthenticatedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticatedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticatedRequest) ProtoMessage() {}
+
+func (x *AuthenticatedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticatedRequest.ProtoReflect.Descriptor instead.
+func (*AuthenticatedRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *AuthenticatedRequest) GetPayload() isAuthenticatedRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *AuthenticatedRequest) GetDisperseRequest() *DisperseBlobRequest {
+	if x, ok := x.GetPayload().(*AuthenticatedRequest_DisperseRequest); ok {
+		return x.DisperseRequest
+	}
+	return nil
+}
+
+func (x *AuthenticatedRequest) GetAuthenticationData() *AuthenticationData {
+	if x, ok := x.GetPayload().(*AuthenticatedRequest_AuthenticationData); ok {
+		return x.AuthenticationData
+	}
+	return nil
+}
+
+type isAuthenticatedRequest_Payload interface {
+	isAuthenticatedRequest_Payload()
+}
+
+type AuthenticatedRequest_DisperseRequest struct {
+	DisperseRequest *DisperseBlobRequest `protobuf:"bytes,1,opt,name=disperse_request,json=disperseRequest,proto3,oneof"`
+}
+
+type AuthenticatedRequest_AuthenticationData struct {
+	AuthenticationData *AuthenticationData `protobuf:"bytes,2,opt,name=authentication_data,json=authenticationData,proto3,oneof"`
+}
+
+func (*AuthenticatedRequest_DisperseRequest) isAuthenticatedRequest_Payload() {}
+
+func (*AuthenticatedRequest_AuthenticationData) isAuthenticatedRequest_Payload() {}
+
+type AuthenticatedReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*AuthenticatedReply_BlobAuthHeader
+	//	*AuthenticatedReply_DisperseReply
+	Payload isAuthenticatedReply_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *AuthenticatedReply) Reset() {
+	*x = AuthenticatedReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticatedReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticatedReply) ProtoMessage() {}
+
+func (x *AuthenticatedReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticatedReply.ProtoReflect.Descriptor instead.
+func (*AuthenticatedReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *AuthenticatedReply) GetPayload() isAuthenticatedReply_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *AuthenticatedReply) GetBlobAuthHeader() *BlobAuthHeader {
+	if x, ok := x.GetPayload().(*AuthenticatedReply_BlobAuthHeader); ok {
+		return x.BlobAuthHeader
+	}
+	return nil
+}
+
+func (x *AuthenticatedReply) GetDisperseReply() *DisperseBlobReply {
+	if x, ok := x.GetPayload().(*AuthenticatedReply_DisperseReply); ok {
+		return x.DisperseReply
+	}
+	return nil
+}
+
+type isAuthenticatedReply_Payload interface {
+	isAuthenticatedReply_Payload()
+}
+
+type AuthenticatedReply_BlobAuthHeader struct {
+	BlobAuthHeader *BlobAuthHeader `protobuf:"bytes,1,opt,name=blob_auth_header,json=blobAuthHeader,proto3,oneof"`
+}
+
+type AuthenticatedReply_DisperseReply struct {
+	DisperseReply *DisperseBlobReply `protobuf:"bytes,2,opt,name=disperse_reply,json=disperseReply,proto3,oneof"`
+}
+
+func (*AuthenticatedReply_BlobAuthHeader) isAuthenticatedReply_Payload() {}
+
+func (*AuthenticatedReply_DisperseReply) isAuthenticatedReply_Payload() {}
+
+// BlobAuthHeader contains the challenge that the client signs to authenticate the dispersal.
+// The client signs keccak256(challenge_parameter as 4 big-endian bytes || keccak256(data))
+// with the key of its account, which binds the signature to both the challenge and the blob.
+type BlobAuthHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChallengeParameter uint32 `protobuf:"varint,1,opt,name=challenge_parameter,json=challengeParameter,proto3" json:"challenge_parameter,omitempty"`
+}
+
+func (x *BlobAuthHeader) Reset() {
+	*x = BlobAuthHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobAuthHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobAuthHeader) ProtoMessage() {}
+
+func (x *BlobAuthHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobAuthHeader.ProtoReflect.Descriptor instead.
+func (*BlobAuthHeader) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlobAuthHeader) GetChallengeParameter() uint32 {
+	if x != nil {
+		return x.ChallengeParameter
+	}
+	return 0
+}
+
+// AuthenticationData contains the signature of the BlobAuthHeader, as a 65-byte [R || S || V]
+// ECDSA signature with V being 0 or 1.
+type AuthenticationData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthenticationData []byte `protobuf:"bytes,1,opt,name=authentication_data,json=authenticationData,proto3" json:"authentication_data,omitempty"`
+}
+
+func (x *AuthenticationData) Reset() {
+	*x = AuthenticationData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticationData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticationData) ProtoMessage() {}
+
+func (x *AuthenticationData) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticationData.ProtoReflect.Descriptor instead.
+func (*AuthenticationData) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthenticationData) GetAuthenticationData() []byte {
+	if x != nil {
+		return x.AuthenticationData
+	}
+	return nil
+}
+
 type DisperseBlobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -100,12 +359,16 @@ type DisperseBlobRequest struct {
 	// The disperser will ensure that the encoded blobs for each quorum are all processed
 	// within the same batch.
 	SecurityParams []*SecurityParams `protobuf:"bytes,2,rep,name=security_params,json=securityParams,proto3" json:"security_params,omitempty"`
+	// The account ID of the client. This should be a hex-encoded string of the ECDSA public key
+	// corresponding to the key used by the client to sign the BlobAuthHeader.
+	// It is only used by DisperseBlobAuthenticated.
+	AccountId string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 }
 
 func (x *DisperseBlobRequest) Reset() {
 	*x = DisperseBlobRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[0]
+		mi := &file_disperser_disperser_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -118,7 +381,7 @@ func (x *DisperseBlobRequest) String() string {
 func (*DisperseBlobRequest) ProtoMessage() {}
 
 func (x *DisperseBlobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[0]
+	mi := &file_disperser_disperser_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -131,7 +394,7 @@ func (x *DisperseBlobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisperseBlobRequest.ProtoReflect.Descriptor instead.
 func (*DisperseBlobRequest) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{0}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DisperseBlobRequest) GetData() []byte {
@@ -148,6 +411,13 @@ func (x *DisperseBlobRequest) GetSecurityParams() []*SecurityParams {
 	return nil
 }
 
+func (x *DisperseBlobRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
 type DisperseBlobReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -169,7 +439,7 @@ type DisperseBlobReply struct {
 func (x *DisperseBlobReply) Reset() {
 	*x = DisperseBlobReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[1]
+		mi := &file_disperser_disperser_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -182,7 +452,7 @@ func (x *DisperseBlobReply) String() string {
 func (*DisperseBlobReply) ProtoMessage() {}
 
 func (x *DisperseBlobReply) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[1]
+	mi := &file_disperser_disperser_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +465,7 @@ func (x *DisperseBlobReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisperseBlobReply.ProtoReflect.Descriptor instead.
 func (*DisperseBlobReply) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{1}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DisperseBlobReply) GetResult() BlobStatus {
@@ -224,7 +494,7 @@ type BlobStatusRequest struct {
 func (x *BlobStatusRequest) Reset() {
 	*x = BlobStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[2]
+		mi := &file_disperser_disperser_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -237,7 +507,7 @@ func (x *BlobStatusRequest) String() string {
 func (*BlobStatusRequest) ProtoMessage() {}
 
 func (x *BlobStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[2]
+	mi := &file_disperser_disperser_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,7 +520,7 @@ func (x *BlobStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobStatusRequest.ProtoReflect.Descriptor instead.
 func (*BlobStatusRequest) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{2}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BlobStatusRequest) GetRequestId() []byte {
@@ -274,7 +544,7 @@ type BlobStatusReply struct {
 func (x *BlobStatusReply) Reset() {
 	*x = BlobStatusReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[3]
+		mi := &file_disperser_disperser_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -287,7 +557,7 @@ func (x *BlobStatusReply) String() string {
 func (*BlobStatusReply) ProtoMessage() {}
 
 func (x *BlobStatusReply) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[3]
+	mi := &file_disperser_disperser_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +570,7 @@ func (x *BlobStatusReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobStatusReply.ProtoReflect.Descriptor instead.
 func (*BlobStatusReply) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{3}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *BlobStatusReply) GetStatus() BlobStatus {
@@ -330,7 +600,7 @@ type RetrieveBlobRequest struct {
 func (x *RetrieveBlobRequest) Reset() {
 	*x = RetrieveBlobRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[4]
+		mi := &file_disperser_disperser_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -343,7 +613,7 @@ func (x *RetrieveBlobRequest) String() string {
 func (*RetrieveBlobRequest) ProtoMessage() {}
 
 func (x *RetrieveBlobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[4]
+	mi := &file_disperser_disperser_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,7 +626,7 @@ func (x *RetrieveBlobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetrieveBlobRequest.ProtoReflect.Descriptor instead.
 func (*RetrieveBlobRequest) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{4}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RetrieveBlobRequest) GetBatchHeaderHash() []byte {
@@ -385,7 +655,7 @@ type RetrieveBlobReply struct {
 func (x *RetrieveBlobReply) Reset() {
 	*x = RetrieveBlobReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[5]
+		mi := &file_disperser_disperser_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -398,7 +668,7 @@ func (x *RetrieveBlobReply) String() string {
 func (*RetrieveBlobReply) ProtoMessage() {}
 
 func (x *RetrieveBlobReply) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[5]
+	mi := &file_disperser_disperser_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +681,7 @@ func (x *RetrieveBlobReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetrieveBlobReply.ProtoReflect.Descriptor instead.
 func (*RetrieveBlobReply) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{5}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RetrieveBlobReply) GetData() []byte {
@@ -421,6 +691,187 @@ func (x *RetrieveBlobReply) GetData() []byte {
 	return nil
 }
 
+// QuorumParamsRequest selects the quorums to return the params of.
+type QuorumParamsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The IDs of the quorums. All the quorums registered on EigenLayer are returned if empty.
+	QuorumIds []uint32 `protobuf:"varint,1,rep,packed,name=quorum_ids,json=quorumIds,proto3" json:"quorum_ids,omitempty"`
+}
+
+func (x *QuorumParamsRequest) Reset() {
+	*x = QuorumParamsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumParamsRequest) ProtoMessage() {}
+
+func (x *QuorumParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumParamsRequest.ProtoReflect.Descriptor instead.
+func (*QuorumParamsRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QuorumParamsRequest) GetQuorumIds() []uint32 {
+	if x != nil {
+		return x.QuorumIds
+	}
+	return nil
+}
+
+type QuorumParamsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuorumParams []*QuorumParams `protobuf:"bytes,1,rep,name=quorum_params,json=quorumParams,proto3" json:"quorum_params,omitempty"`
+	// The max size of the data of a blob in bytes.
+	MaxBlobSize uint32 `protobuf:"varint,2,opt,name=max_blob_size,json=maxBlobSize,proto3" json:"max_blob_size,omitempty"`
+}
+
+func (x *QuorumParamsReply) Reset() {
+	*x = QuorumParamsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumParamsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumParamsReply) ProtoMessage() {}
+
+func (x *QuorumParamsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumParamsReply.ProtoReflect.Descriptor instead.
+func (*QuorumParamsReply) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QuorumParamsReply) GetQuorumParams() []*QuorumParams {
+	if x != nil {
+		return x.QuorumParams
+	}
+	return nil
+}
+
+func (x *QuorumParamsReply) GetMaxBlobSize() uint32 {
+	if x != nil {
+		return x.MaxBlobSize
+	}
+	return 0
+}
+
+// QuorumParams are the limits on the SecurityParams of the dispersals to a quorum.
+type QuorumParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuorumId uint32 `protobuf:"varint,1,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// The min percentage points by which the quorum_threshold must exceed the
+	// adversary_threshold, i.e. quorum_threshold >= adversary_threshold + threshold_margin.
+	ThresholdMargin uint32 `protobuf:"varint,2,opt,name=threshold_margin,json=thresholdMargin,proto3" json:"threshold_margin,omitempty"`
+	// The min adversary_threshold.
+	MinAdversaryThreshold uint32 `protobuf:"varint,3,opt,name=min_adversary_threshold,json=minAdversaryThreshold,proto3" json:"min_adversary_threshold,omitempty"`
+	// The max quorum_threshold.
+	MaxQuorumThreshold uint32 `protobuf:"varint,4,opt,name=max_quorum_threshold,json=maxQuorumThreshold,proto3" json:"max_quorum_threshold,omitempty"`
+}
+
+func (x *QuorumParams) Reset() {
+	*x = QuorumParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_disperser_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuorumParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuorumParams) ProtoMessage() {}
+
+func (x *QuorumParams) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_disperser_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuorumParams.ProtoReflect.Descriptor instead.
+func (*QuorumParams) Descriptor() ([]byte, []int) {
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QuorumParams) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *QuorumParams) GetThresholdMargin() uint32 {
+	if x != nil {
+		return x.ThresholdMargin
+	}
+	return 0
+}
+
+func (x *QuorumParams) GetMinAdversaryThreshold() uint32 {
+	if x != nil {
+		return x.MinAdversaryThreshold
+	}
+	return 0
+}
+
+func (x *QuorumParams) GetMaxQuorumThreshold() uint32 {
+	if x != nil {
+		return x.MaxQuorumThreshold
+	}
+	return 0
+}
+
 // SecurityParams contains the security parameters for a given quorum.
 type SecurityParams struct {
 	state         protoimpl.MessageState
@@ -446,9 +897,9 @@ type SecurityParams struct {
 	// the dispersal request to fail may be higher (liveness for dispersal).
 	//
 	// Requires:
-	//
-	//	1 <= quorum_threshld <= 100
-	//	quorum_threshld > adversary_threshold.
+	//     1 <= quorum_threshld <= 100
+	//     quorum_threshld >= adversary_threshold + threshold_margin, where the threshold_margin
+	//     of the quorum is 10 by default, see GetQuorumParams.
 	//
 	// Note: The adversary_threshold and quorum_threshold will directly influence the
 	// cost of encoding for the blob to be dispersed, roughly by a factor of
@@ -460,7 +911,7 @@ type SecurityParams struct {
 func (x *SecurityParams) Reset() {
 	*x = SecurityParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[6]
+		mi := &file_disperser_disperser_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -473,7 +924,7 @@ func (x *SecurityParams) String() string {
 func (*SecurityParams) ProtoMessage() {}
 
 func (x *SecurityParams) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[6]
+	mi := &file_disperser_disperser_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,7 +937,7 @@ func (x *SecurityParams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecurityParams.ProtoReflect.Descriptor instead.
 func (*SecurityParams) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{6}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SecurityParams) GetQuorumId() uint32 {
@@ -523,7 +974,7 @@ type BlobInfo struct {
 func (x *BlobInfo) Reset() {
 	*x = BlobInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[7]
+		mi := &file_disperser_disperser_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -536,7 +987,7 @@ func (x *BlobInfo) String() string {
 func (*BlobInfo) ProtoMessage() {}
 
 func (x *BlobInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[7]
+	mi := &file_disperser_disperser_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -549,7 +1000,7 @@ func (x *BlobInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobInfo.ProtoReflect.Descriptor instead.
 func (*BlobInfo) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{7}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BlobInfo) GetBlobHeader() *BlobHeader {
@@ -582,7 +1033,7 @@ type BlobHeader struct {
 func (x *BlobHeader) Reset() {
 	*x = BlobHeader{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[8]
+		mi := &file_disperser_disperser_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -595,7 +1046,7 @@ func (x *BlobHeader) String() string {
 func (*BlobHeader) ProtoMessage() {}
 
 func (x *BlobHeader) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[8]
+	mi := &file_disperser_disperser_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +1059,7 @@ func (x *BlobHeader) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobHeader.ProtoReflect.Descriptor instead.
 func (*BlobHeader) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{8}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BlobHeader) GetCommitment() []byte {
@@ -657,7 +1108,7 @@ type BlobQuorumParam struct {
 func (x *BlobQuorumParam) Reset() {
 	*x = BlobQuorumParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[9]
+		mi := &file_disperser_disperser_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -670,7 +1121,7 @@ func (x *BlobQuorumParam) String() string {
 func (*BlobQuorumParam) ProtoMessage() {}
 
 func (x *BlobQuorumParam) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[9]
+	mi := &file_disperser_disperser_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -683,7 +1134,7 @@ func (x *BlobQuorumParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobQuorumParam.ProtoReflect.Descriptor instead.
 func (*BlobQuorumParam) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{9}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *BlobQuorumParam) GetQuorumNumber() uint32 {
@@ -735,20 +1186,18 @@ type BlobVerificationProof struct {
 	InclusionProof []byte `protobuf:"bytes,4,opt,name=inclusion_proof,json=inclusionProof,proto3" json:"inclusion_proof,omitempty"`
 	// indexes of quorums in BatchHeader.quorum_numbers that match the quorums in BlobHeader.blob_quorum_params
 	// Ex. BlobHeader.blob_quorum_params = [
-	//
-	//	{
+	// 	{
 	//		quorum_number = 0,
-	//		...
-	//	},
-	//	{
+	// 		...
+	// 	},
+	// 	{
 	//		quorum_number = 3,
-	//		...
-	//	},
-	//	{
+	// 		...
+	// 	},
+	// 	{
 	//		quorum_number = 5,
-	//		...
-	//	},
-	//
+	// 		...
+	// 	},
 	// ]
 	// BatchHeader.quorum_numbers = [0, 5, 3] => 0x000503
 	// Then, quorum_indexes = [0, 2, 1] => 0x000201
@@ -758,7 +1207,7 @@ type BlobVerificationProof struct {
 func (x *BlobVerificationProof) Reset() {
 	*x = BlobVerificationProof{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[10]
+		mi := &file_disperser_disperser_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -771,7 +1220,7 @@ func (x *BlobVerificationProof) String() string {
 func (*BlobVerificationProof) ProtoMessage() {}
 
 func (x *BlobVerificationProof) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[10]
+	mi := &file_disperser_disperser_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -784,7 +1233,7 @@ func (x *BlobVerificationProof) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobVerificationProof.ProtoReflect.Descriptor instead.
 func (*BlobVerificationProof) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{10}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BlobVerificationProof) GetBatchId() uint32 {
@@ -830,8 +1279,8 @@ type BatchMetadata struct {
 	BatchHeader *BatchHeader `protobuf:"bytes,1,opt,name=batch_header,json=batchHeader,proto3" json:"batch_header,omitempty"`
 	// The hash of all public keys of the operators that did not sign the batch.
 	SignatoryRecordHash []byte `protobuf:"bytes,2,opt,name=signatory_record_hash,json=signatoryRecordHash,proto3" json:"signatory_record_hash,omitempty"`
-	// The gas fee of confirming this batch. It's the bytes representation of
-	// a big.Int value.
+	// The fee payment paid by users for dispersing this batch. It's the bytes
+	// representation of a big.Int value.
 	Fee []byte `protobuf:"bytes,3,opt,name=fee,proto3" json:"fee,omitempty"`
 	// The Ethereum block number at which the batch is confirmed onchain.
 	ConfirmationBlockNumber uint32 `protobuf:"varint,4,opt,name=confirmation_block_number,json=confirmationBlockNumber,proto3" json:"confirmation_block_number,omitempty"`
@@ -844,7 +1293,7 @@ type BatchMetadata struct {
 func (x *BatchMetadata) Reset() {
 	*x = BatchMetadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[11]
+		mi := &file_disperser_disperser_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -857,7 +1306,7 @@ func (x *BatchMetadata) String() string {
 func (*BatchMetadata) ProtoMessage() {}
 
 func (x *BatchMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[11]
+	mi := &file_disperser_disperser_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -870,7 +1319,7 @@ func (x *BatchMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchMetadata.ProtoReflect.Descriptor instead.
 func (*BatchMetadata) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{11}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *BatchMetadata) GetBatchHeader() *BatchHeader {
@@ -929,7 +1378,7 @@ type BatchHeader struct {
 func (x *BatchHeader) Reset() {
 	*x = BatchHeader{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_disperser_disperser_proto_msgTypes[12]
+		mi := &file_disperser_disperser_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -942,7 +1391,7 @@ func (x *BatchHeader) String() string {
 func (*BatchHeader) ProtoMessage() {}
 
 func (x *BatchHeader) ProtoReflect() protoreflect.Message {
-	mi := &file_disperser_disperser_proto_msgTypes[12]
+	mi := &file_disperser_disperser_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,7 +1404,7 @@ func (x *BatchHeader) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchHeader.ProtoReflect.Descriptor instead.
 func (*BatchHeader) Descriptor() ([]byte, []int) {
-	return file_disperser_disperser_proto_rawDescGZIP(), []int{12}
+	return file_disperser_disperser_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BatchHeader) GetBatchRoot() []byte {
@@ -991,153 +1440,221 @@ var File_disperser_disperser_proto protoreflect.FileDescriptor
 var file_disperser_disperser_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2f, 0x64, 0x69, 0x73, 0x70,
 	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x69, 0x73,
-	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x22, 0x6d, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72,
-	0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x42, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x69, 0x73,
-	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x61, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
-	0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73,
-	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x62,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x69, 0x0a, 0x0f,
-	0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27,
-	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64,
-	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a,
-	0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c,
-	0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x27, 0x0a, 0x11, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12,
-	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x22, 0x89, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x49, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f,
-	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x12, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
-	0x6f, 0x6c, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68,
-	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x9c,
-	0x01, 0x0a, 0x08, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x0b, 0x62,
-	0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f,
-	0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x17, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x76, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x15, 0x62, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x97, 0x01,
-	0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a,
-	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
-	0x64, 0x61, 0x74, 0x61, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x48, 0x0a,
-	0x12, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x70, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x92, 0x02, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x62,
-	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x44, 0x0a, 0x1e, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x22, 0xc0, 0x01, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x4b, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x13,
+	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x12, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xad, 0x01, 0x0a, 0x12, 0x41, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x45, 0x0a, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x41, 0x75, 0x74, 0x68, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x62, 0x41, 0x75, 0x74,
+	0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52,
+	0x0d, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x41, 0x0a, 0x0e, 0x42, 0x6c, 0x6f,
+	0x62, 0x41, 0x75, 0x74, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x13, 0x63,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x22, 0x45, 0x0a, 0x12,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x12, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x22, 0x8c, 0x01, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x42, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x49, 0x64, 0x22, 0x61, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x69, 0x0a, 0x0f, 0x42, 0x6c, 0x6f,
+	0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x69,
+	0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x22, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f,
+	0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x27, 0x0a, 0x11, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x34, 0x0a, 0x13, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x49, 0x64, 0x73, 0x22, 0x75, 0x0a, 0x11, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3c, 0x0a, 0x0d, 0x71, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f,
+	0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xc0, 0x01, 0x0a,
+	0x0c, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x4d,
+	0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x64, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6d, 0x69, 0x6e, 0x41, 0x64, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x30, 0x0a,
+	0x14, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78,
+	0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22,
+	0x89, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x12,
+	0x2f, 0x0a, 0x13, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x61, 0x64,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x9c, 0x01, 0x0a, 0x08,
+	0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x58, 0x0a, 0x17, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x52, 0x15, 0x62, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x97, 0x01, 0x0a, 0x0a, 0x42,
+	0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x64, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x48, 0x0a, 0x12, 0x62, 0x6c,
+	0x6f, 0x62, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x52, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x22, 0x92, 0x02, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x62, 0x51, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x44, 0x0a,
+	0x1e, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1c, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73, 0x61, 0x72, 0x79,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68,
 	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
-	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1c, 0x61, 0x64, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x72, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65,
-	0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x71, 0x75, 0x6f,
-	0x72, 0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64,
-	0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65,
-	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xe2, 0x01, 0x0a,
-	0x15, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49,
-	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x12, 0x3f, 0x0a, 0x0e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65,
-	0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
-	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
-	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75,
-	0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65,
-	0x73, 0x22, 0xf8, 0x01, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x69, 0x73, 0x70,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x32,
-	0x0a, 0x15, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x66, 0x65, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0xc5, 0x01, 0x0a,
-	0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
-	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x71,
-	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x73, 0x69, 0x67,
-	0x6e, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67,
-	0x6e, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x34,
-	0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x2a, 0x70, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
-	0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
-	0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a,
-	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x49,
-	0x4e, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x49, 0x4e, 0x53,
-	0x55, 0x46, 0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54,
-	0x55, 0x52, 0x45, 0x53, 0x10, 0x05, 0x32, 0xf8, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65,
-	0x72, 0x73, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
-	0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
-	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
-	0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f,
-	0x62, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
-	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65,
-	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64,
-	0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x69, 0x73, 0x70, 0x65,
-	0x72, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x6f,
+	0x64, 0x65, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xe2, 0x01, 0x0a, 0x15, 0x42, 0x6c,
+	0x6f, 0x62, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3f, 0x0a,
+	0x0e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x27,
+	0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x22, 0xf8,
+	0x01, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x39, 0x0a, 0x0c, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x15, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x66, 0x65,
+	0x65, 0x12, 0x3a, 0x0a, 0x19, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a,
+	0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0xc5, 0x01, 0x0a, 0x0b, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12,
+	0x3a, 0x0a, 0x19, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x17, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x2a, 0x70, 0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a,
+	0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09,
+	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x49, 0x4e, 0x41, 0x4c,
+	0x49, 0x5a, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x49, 0x4e, 0x53, 0x55, 0x46, 0x46,
+	0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54, 0x55, 0x52, 0x45,
+	0x53, 0x10, 0x05, 0x32, 0xac, 0x03, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x12, 0x4e, 0x0a, 0x0c, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x5f, 0x0a, 0x19, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1f,
+	0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x4e, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12,
+	0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x51, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e,
+	0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1153,44 +1670,60 @@ func file_disperser_disperser_proto_rawDescGZIP() []byte {
 }
 
 var file_disperser_disperser_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_disperser_disperser_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_disperser_disperser_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_disperser_disperser_proto_goTypes = []interface{}{
 	(BlobStatus)(0),               // 0: disperser.BlobStatus
-	(*DisperseBlobRequest)(nil),   // 1: disperser.DisperseBlobRequest
-	(*DisperseBlobReply)(nil),     // 2: disperser.DisperseBlobReply
-	(*BlobStatusRequest)(nil),     // 3: disperser.BlobStatusRequest
-	(*BlobStatusReply)(nil),       // 4: disperser.BlobStatusReply
-	(*RetrieveBlobRequest)(nil),   // 5: disperser.RetrieveBlobRequest
-	(*RetrieveBlobReply)(nil),     // 6: disperser.RetrieveBlobReply
-	(*SecurityParams)(nil),        // 7: disperser.SecurityParams
-	(*BlobInfo)(nil),              // 8: disperser.BlobInfo
-	(*BlobHeader)(nil),            // 9: disperser.BlobHeader
-	(*BlobQuorumParam)(nil),       // 10: disperser.BlobQuorumParam
-	(*BlobVerificationProof)(nil), // 11: disperser.BlobVerificationProof
-	(*BatchMetadata)(nil),         // 12: disperser.BatchMetadata
-	(*BatchHeader)(nil),           // 13: disperser.BatchHeader
+	(*AuthenticatedRequest)(nil),  // 1: disperser.AuthenticatedRequest
+	(*AuthenticatedReply)(nil),    // 2: disperser.AuthenticatedReply
+	(*BlobAuthHeader)(nil),        // 3: disperser.BlobAuthHeader
+	(*AuthenticationData)(nil),    // 4: disperser.AuthenticationData
+	(*DisperseBlobRequest)(nil),   // 5: disperser.DisperseBlobRequest
+	(*DisperseBlobReply)(nil),     // 6: disperser.DisperseBlobReply
+	(*BlobStatusRequest)(nil),     // 7: disperser.BlobStatusRequest
+	(*BlobStatusReply)(nil),       // 8: disperser.BlobStatusReply
+	(*RetrieveBlobRequest)(nil),   // 9: disperser.RetrieveBlobRequest
+	(*RetrieveBlobReply)(nil),     // 10: disperser.RetrieveBlobReply
+	(*QuorumParamsRequest)(nil),   // 11: disperser.QuorumParamsRequest
+	(*QuorumParamsReply)(nil),     // 12: disperser.QuorumParamsReply
+	(*QuorumParams)(nil),          // 13: disperser.QuorumParams
+	(*SecurityParams)(nil),        // 14: disperser.SecurityParams
+	(*BlobInfo)(nil),              // 15: disperser.BlobInfo
+	(*BlobHeader)(nil),            // 16: disperser.BlobHeader
+	(*BlobQuorumParam)(nil),       // 17: disperser.BlobQuorumParam
+	(*BlobVerificationProof)(nil), // 18: disperser.BlobVerificationProof
+	(*BatchMetadata)(nil),         // 19: disperser.BatchMetadata
+	(*BatchHeader)(nil),           // 20: disperser.BatchHeader
 }
 var file_disperser_disperser_proto_depIdxs = []int32{
-	7,  // 0: disperser.DisperseBlobRequest.security_params:type_name -> disperser.SecurityParams
-	0,  // 1: disperser.DisperseBlobReply.result:type_name -> disperser.BlobStatus
-	0,  // 2: disperser.BlobStatusReply.status:type_name -> disperser.BlobStatus
-	8,  // 3: disperser.BlobStatusReply.info:type_name -> disperser.BlobInfo
-	9,  // 4: disperser.BlobInfo.blob_header:type_name -> disperser.BlobHeader
-	11, // 5: disperser.BlobInfo.blob_verification_proof:type_name -> disperser.BlobVerificationProof
-	10, // 6: disperser.BlobHeader.blob_quorum_params:type_name -> disperser.BlobQuorumParam
-	12, // 7: disperser.BlobVerificationProof.batch_metadata:type_name -> disperser.BatchMetadata
-	13, // 8: disperser.BatchMetadata.batch_header:type_name -> disperser.BatchHeader
-	1,  // 9: disperser.Disperser.DisperseBlob:input_type -> disperser.DisperseBlobRequest
-	3,  // 10: disperser.Disperser.GetBlobStatus:input_type -> disperser.BlobStatusRequest
-	5,  // 11: disperser.Disperser.RetrieveBlob:input_type -> disperser.RetrieveBlobRequest
-	2,  // 12: disperser.Disperser.DisperseBlob:output_type -> disperser.DisperseBlobReply
-	4,  // 13: disperser.Disperser.GetBlobStatus:output_type -> disperser.BlobStatusReply
-	6,  // 14: disperser.Disperser.RetrieveBlob:output_type -> disperser.RetrieveBlobReply
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	5,  // 0: disperser.AuthenticatedRequest.disperse_request:type_name -> disperser.DisperseBlobRequest
+	4,  // 1: disperser.AuthenticatedRequest.authentication_data:type_name -> disperser.AuthenticationData
+	3,  // 2: disperser.AuthenticatedReply.blob_auth_header:type_name -> disperser.BlobAuthHeader
+	6,  // 3: disperser.AuthenticatedReply.disperse_reply:type_name -> disperser.DisperseBlobReply
+	14, // 4: disperser.DisperseBlobRequest.security_params:type_name -> disperser.SecurityParams
+	0,  // 5: disperser.DisperseBlobReply.result:type_name -> disperser.BlobStatus
+	0,  // 6: disperser.BlobStatusReply.status:type_name -> disperser.BlobStatus
+	15, // 7: disperser.BlobStatusReply.info:type_name -> disperser.BlobInfo
+	13, // 8: disperser.QuorumParamsReply.quorum_params:type_name -> disperser.QuorumParams
+	16, // 9: disperser.BlobInfo.blob_header:type_name -> disperser.BlobHeader
+	18, // 10: disperser.BlobInfo.blob_verification_proof:type_name -> disperser.BlobVerificationProof
+	17, // 11: disperser.BlobHeader.blob_quorum_params:type_name -> disperser.BlobQuorumParam
+	19, // 12: disperser.BlobVerificationProof.batch_metadata:type_name -> disperser.BatchMetadata
+	20, // 13: disperser.BatchMetadata.batch_header:type_name -> disperser.BatchHeader
+	5,  // 14: disperser.Disperser.DisperseBlob:input_type -> disperser.DisperseBlobRequest
+	1,  // 15: disperser.Disperser.DisperseBlobAuthenticated:input_type -> disperser.AuthenticatedRequest
+	7,  // 16: disperser.Disperser.GetBlobStatus:input_type -> disperser.BlobStatusRequest
+	9,  // 17: disperser.Disperser.RetrieveBlob:input_type -> disperser.RetrieveBlobRequest
+	11, // 18: disperser.Disperser.GetQuorumParams:input_type -> disperser.QuorumParamsRequest
+	6,  // 19: disperser.Disperser.DisperseBlob:output_type -> disperser.DisperseBlobReply
+	2,  // 20: disperser.Disperser.DisperseBlobAuthenticated:output_type -> disperser.AuthenticatedReply
+	8,  // 21: disperser.Disperser.GetBlobStatus:output_type -> disperser.BlobStatusReply
+	10, // 22: disperser.Disperser.RetrieveBlob:output_type -> disperser.RetrieveBlobReply
+	12, // 23: disperser.Disperser.GetQuorumParams:output_type -> disperser.QuorumParamsReply
+	19, // [19:24] is the sub-list for method output_type
+	14, // [14:19] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_disperser_disperser_proto_init() }
@@ -1200,7 +1733,7 @@ func file_disperser_disperser_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_disperser_disperser_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisperseBlobRequest); i {
+			switch v := v.(*AuthenticatedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1212,7 +1745,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DisperseBlobReply); i {
+			switch v := v.(*AuthenticatedReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1224,7 +1757,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobStatusRequest); i {
+			switch v := v.(*BlobAuthHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1236,7 +1769,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobStatusReply); i {
+			switch v := v.(*AuthenticationData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1248,7 +1781,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetrieveBlobRequest); i {
+			switch v := v.(*DisperseBlobRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1260,7 +1793,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetrieveBlobReply); i {
+			switch v := v.(*DisperseBlobReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1272,7 +1805,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecurityParams); i {
+			switch v := v.(*BlobStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1284,7 +1817,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobInfo); i {
+			switch v := v.(*BlobStatusReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1296,7 +1829,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobHeader); i {
+			switch v := v.(*RetrieveBlobRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1308,7 +1841,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobQuorumParam); i {
+			switch v := v.(*RetrieveBlobReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1320,7 +1853,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobVerificationProof); i {
+			switch v := v.(*QuorumParamsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1332,7 +1865,7 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchMetadata); i {
+			switch v := v.(*QuorumParamsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1344,6 +1877,90 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 		file_disperser_disperser_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuorumParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecurityParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobQuorumParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobVerificationProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_disperser_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BatchHeader); i {
 			case 0:
 				return &v.state
@@ -1356,13 +1973,21 @@ func file_disperser_disperser_proto_init() {
 			}
 		}
 	}
+	file_disperser_disperser_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*AuthenticatedRequest_DisperseRequest)(nil),
+		(*AuthenticatedRequest_AuthenticationData)(nil),
+	}
+	file_disperser_disperser_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*AuthenticatedReply_BlobAuthHeader)(nil),
+		(*AuthenticatedReply_DisperseReply)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_disperser_disperser_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   13,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Disperser_DisperseBlob_FullMethodName  = "/disperser.Disperser/DisperseBlob"
-	Disperser_GetBlobStatus_FullMethodName = "/disperser.Disperser/GetBlobStatus"
-	Disperser_RetrieveBlob_FullMethodName  = "/disperser.Disperser/RetrieveBlob"
+	Disperser_DisperseBlob_FullMethodName              = "/disperser.Disperser/DisperseBlob"
+	Disperser_DisperseBlobAuthenticated_FullMethodName = "/disperser.Disperser/DisperseBlobAuthenticated"
+	Disperser_GetBlobStatus_FullMethodName             = "/disperser.Disperser/GetBlobStatus"
+	Disperser_RetrieveBlob_FullMethodName              = "/disperser.Disperser/RetrieveBlob"
+	Disperser_GetQuorumParams_FullMethodName           = "/disperser.Disperser/GetQuorumParams"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -33,6 +35,17 @@ type DisperserClient interface {
 	// is accepted. The client could use GetBlobStatus() API to poll the the
 	// processing status of the blob.
 	DisperseBlob(ctx context.Context, in *DisperseBlobRequest, opts ...grpc.CallOption) (*DisperseBlobReply, error)
+	// DisperseBlobAuthenticated is similar to DisperseBlob, except that it requires the
+	// client to authenticate itself via the AuthenticationData message. The protocol is as follows:
+	// 1. The client sends an AuthenticatedRequest with the DisperseBlobRequest message
+	// 2. The Disperser sends back a BlobAuthHeader message containing information for the client to
+	//    verify and sign.
+	// 3. The client verifies the BlobAuthHeader and sends back the signed BlobAuthHeader in an
+	//    AuthenticationData message.
+	// 4. The Disperser verifies the signature and returns a DisperseBlobReply message.
+	// The Disperser fails the stream with Unauthenticated if the signature is invalid, and with
+	// PermissionDenied if the account is not allowed to disperse.
+	DisperseBlobAuthenticated(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobAuthenticatedClient, error)
 	// This API is meant to be polled for the blob status.
 	GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error)
 	// This retrieves the requested blob from the Disperser's backend.
@@ -42,6 +55,9 @@ type DisperserClient interface {
 	// The blob should have been initially dispersed via this Disperser service
 	// for this API to work.
 	RetrieveBlob(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobReply, error)
+	// GetQuorumParams returns the limits the Disperser enforces on the security params of
+	// the dispersals to each of the quorums, so that clients can construct valid requests.
+	GetQuorumParams(ctx context.Context, in *QuorumParamsRequest, opts ...grpc.CallOption) (*QuorumParamsReply, error)
 }
 
 type disperserClient struct {
@@ -61,6 +77,37 @@ func (c *disperserClient) DisperseBlob(ctx context.Context, in *DisperseBlobRequ
 	return out, nil
 }
 
+func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobAuthenticatedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[0], Disperser_DisperseBlobAuthenticated_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserDisperseBlobAuthenticatedClient{stream}
+	return x, nil
+}
+
+type Disperser_DisperseBlobAuthenticatedClient interface {
+	Send(*AuthenticatedRequest) error
+	Recv() (*AuthenticatedReply, error)
+	grpc.ClientStream
+}
+
+type disperserDisperseBlobAuthenticatedClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserDisperseBlobAuthenticatedClient) Send(m *AuthenticatedRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobAuthenticatedClient) Recv() (*AuthenticatedReply, error) {
+	m := new(AuthenticatedReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error) {
 	out := new(BlobStatusReply)
 	err := c.cc.Invoke(ctx, Disperser_GetBlobStatus_FullMethodName, in, out, opts...)
@@ -79,6 +126,15 @@ func (c *disperserClient) RetrieveBlob(ctx context.Context, in *RetrieveBlobRequ
 	return out, nil
 }
 
+func (c *disperserClient) GetQuorumParams(ctx context.Context, in *QuorumParamsRequest, opts ...grpc.CallOption) (*QuorumParamsReply, error) {
+	out := new(QuorumParamsReply)
+	err := c.cc.Invoke(ctx, Disperser_GetQuorumParams_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -88,6 +144,17 @@ type DisperserServer interface {
 	// is accepted. The client could use GetBlobStatus() API to poll the the
 	// processing status of the blob.
 	DisperseBlob(context.Context, *DisperseBlobRequest) (*DisperseBlobReply, error)
+	// DisperseBlobAuthenticated is similar to DisperseBlob, except that it requires the
+	// client to authenticate itself via the AuthenticationData message. The protocol is as follows:
+	// 1. The client sends an AuthenticatedRequest with the DisperseBlobRequest message
+	// 2. The Disperser sends back a BlobAuthHeader message containing information for the client to
+	//    verify and sign.
+	// 3. The client verifies the BlobAuthHeader and sends back the signed BlobAuthHeader in an
+	//    AuthenticationData message.
+	// 4. The Disperser verifies the signature and returns a DisperseBlobReply message.
+	// The Disperser fails the stream with Unauthenticated if the signature is invalid, and with
+	// PermissionDenied if the account is not allowed to disperse.
+	DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error
 	// This API is meant to be polled for the blob status.
 	GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error)
 	// This retrieves the requested blob from the Disperser's backend.
@@ -97,6 +164,9 @@ type DisperserServer interface {
 	// The blob should have been initially dispersed via this Disperser service
 	// for this API to work.
 	RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error)
+	// GetQuorumParams returns the limits the Disperser enforces on the security params of
+	// the dispersals to each of the quorums, so that clients can construct valid requests.
+	GetQuorumParams(context.Context, *QuorumParamsRequest) (*QuorumParamsReply, error)
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -107,12 +177,18 @@ type UnimplementedDisperserServer struct {
 func (UnimplementedDisperserServer) DisperseBlob(context.Context, *DisperseBlobRequest) (*DisperseBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DisperseBlob not implemented")
 }
+func (UnimplementedDisperserServer) DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error {
+	return status.Errorf(codes.Unimplemented, "method DisperseBlobAuthenticated not implemented")
+}
 func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
 }
 func (UnimplementedDisperserServer) RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlob not implemented")
 }
+func (UnimplementedDisperserServer) GetQuorumParams(context.Context, *QuorumParamsRequest) (*QuorumParamsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuorumParams not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -144,6 +220,32 @@ func _Disperser_DisperseBlob_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_DisperseBlobAuthenticated_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisperserServer).DisperseBlobAuthenticated(&disperserDisperseBlobAuthenticatedServer{stream})
+}
+
+type Disperser_DisperseBlobAuthenticatedServer interface {
+	Send(*AuthenticatedReply) error
+	Recv() (*AuthenticatedRequest, error)
+	grpc.ServerStream
+}
+
+type disperserDisperseBlobAuthenticatedServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserDisperseBlobAuthenticatedServer) Send(m *AuthenticatedReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobAuthenticatedServer) Recv() (*AuthenticatedRequest, error) {
+	m := new(AuthenticatedRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlobStatusRequest)
 	if err := dec(in); err != nil {
@@ -180,6 +282,24 @@ func _Disperser_RetrieveBlob_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetQuorumParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuorumParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetQuorumParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetQuorumParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetQuorumParams(ctx, req.(*QuorumParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,7 +319,18 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveBlob",
 			Handler:    _Disperser_RetrieveBlob_Handler,
 		},
+		{
+			MethodName: "GetQuorumParams",
+			Handler:    _Disperser_GetQuorumParams_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DisperseBlobAuthenticated",
+			Handler:       _Disperser_DisperseBlobAuthenticated_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "disperser/disperser.proto",
 }
@@ -20,6 +20,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// The priority of a retrieval. Under load, the retrievals waiting for the memory budget of the
+// Retriever, which bounds the concurrent fetches and decodings of the chunks of the blobs, are
+// admitted by priority, and in the order of arrival within a priority. The retrievals of a lower
+// priority may thus wait longer, as long as retrievals of a higher priority keep arriving.
+// The priorities have no effect on a Retriever without a memory budget. The priority of a request
+// isn't authenticated, so the HIGH one is only granted by the Retrievers configured to allow it,
+// and the others retrieve the HIGH requests at the NORMAL priority. CheckBlobIntegrity always
+// retrieves at the LOW priority.
+type RetrievalPriority int32
+
+const (
+	// The default priority, admitted after the HIGH retrievals and before the LOW ones.
+	RetrievalPriority_NORMAL RetrievalPriority = 0
+	// For the latency-critical retrievals, e.g. the syncing of a rollup.
+	RetrievalPriority_HIGH RetrievalPriority = 1
+	// For the background retrievals, e.g. archival.
+	RetrievalPriority_LOW RetrievalPriority = 2
+)
+
+// Enum value maps for RetrievalPriority.
+var (
+	RetrievalPriority_name = map[int32]string{
+		0: "NORMAL",
+		1: "HIGH",
+		2: "LOW",
+	}
+	RetrievalPriority_value = map[string]int32{
+		"NORMAL": 0,
+		"HIGH":   1,
+		"LOW":    2,
+	}
+)
+
+func (x RetrievalPriority) Enum() *RetrievalPriority {
+	p := new(RetrievalPriority)
+	*p = x
+	return p
+}
+
+func (x RetrievalPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetrievalPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_retriever_retriever_proto_enumTypes[0].Descriptor()
+}
+
+func (RetrievalPriority) Type() protoreflect.EnumType {
+	return &file_retriever_retriever_proto_enumTypes[0]
+}
+
+func (x RetrievalPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RetrievalPriority.Descriptor instead.
+func (RetrievalPriority) EnumDescriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{0}
+}
+
 type BlobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -33,10 +93,30 @@ type BlobRequest struct {
 	// ordered list of blobs).
 	BlobIndex uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
 	// The Ethereum block number at which the batch for this blob was constructed.
+	// It's optional, and if set it must be the reference block number of the batch confirmed onchain,
+	// otherwise the request fails with InvalidArgument.
 	ReferenceBlockNumber uint32 `protobuf:"varint,3,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
 	// Which quorum of the blob this is requesting for (note a blob can participate in
 	// multiple quorums).
 	QuorumId uint32 `protobuf:"varint,4,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// The offset in bytes of the range of the blob to return. Defaults to the start of the blob.
+	Offset uint32 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The length in bytes of the range of the blob to return. If 0, the range extends to the end of the blob.
+	// The range must be within the blob, otherwise the request fails with InvalidArgument.
+	// Note that the blob has to be fully reconstructed before the range is extracted, so requesting a range
+	// only reduces the size of the reply, not the cost of the retrieval.
+	Length uint32 `protobuf:"varint,6,opt,name=length,proto3" json:"length,omitempty"`
+	// If true, the reply lists the operators whose chunks were used to reconstruct the blob.
+	IncludeOperators bool `protobuf:"varint,7,opt,name=include_operators,json=includeOperators,proto3" json:"include_operators,omitempty"`
+	// If true, the reply carries the proof that the header of the blob is included in the batch.
+	IncludeInclusionProof bool `protobuf:"varint,8,opt,name=include_inclusion_proof,json=includeInclusionProof,proto3" json:"include_inclusion_proof,omitempty"`
+	// If true, the reply carries the diagnostics of the chunks of the retrieval, e.g. to debug slow or failing
+	// reconstructions. Every chunk is verified on its own, so verbose retrievals are much heavier, and are rejected
+	// with FAILED_PRECONDITION unless they are enabled on the retriever.
+	// If the retrieval fails, the diagnostics are in the details of the error status.
+	Verbose bool `protobuf:"varint,9,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	// The priority of the retrieval, see RetrievalPriority. Defaults to NORMAL.
+	Priority RetrievalPriority `protobuf:"varint,10,opt,name=priority,proto3,enum=retriever.RetrievalPriority" json:"priority,omitempty"`
 }
 
 func (x *BlobRequest) Reset() {
@@ -99,13 +179,65 @@ func (x *BlobRequest) GetQuorumId() uint32 {
 	return 0
 }
 
+func (x *BlobRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *BlobRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *BlobRequest) GetIncludeOperators() bool {
+	if x != nil {
+		return x.IncludeOperators
+	}
+	return false
+}
+
+func (x *BlobRequest) GetIncludeInclusionProof() bool {
+	if x != nil {
+		return x.IncludeInclusionProof
+	}
+	return false
+}
+
+func (x *BlobRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
+func (x *BlobRequest) GetPriority() RetrievalPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return RetrievalPriority_NORMAL
+}
+
 type BlobReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The blob retrieved and reconstructed from the EigenDA Nodes per BlobRequest.
+	// The blob retrieved and reconstructed from the EigenDA Nodes per BlobRequest,
+	// or the requested range of it.
 	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The operators whose chunks were used to reconstruct the blob, in the order in which
+	// they replied. Only set if BlobRequest.include_operators is true. Operators that were
+	// contacted but failed to return their chunks are not included.
+	Operators []*OperatorContribution `protobuf:"bytes,2,rep,name=operators,proto3" json:"operators,omitempty"`
+	// The proof that the header of the blob is included in the batch, which the Retriever verified
+	// before reconstructing the blob. Only set if BlobRequest.include_inclusion_proof is true.
+	InclusionProof *BlobInclusionProof `protobuf:"bytes,3,opt,name=inclusion_proof,json=inclusionProof,proto3" json:"inclusion_proof,omitempty"`
+	// The diagnostics of the chunks of the retrieval. Only set if BlobRequest.verbose is true.
+	Diagnostics *RetrievalDiagnostics `protobuf:"bytes,4,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
 func (x *BlobReply) Reset() {
@@ -147,82 +279,1874 @@ func (x *BlobReply) GetData() []byte {
 	return nil
 }
 
-var File_retriever_retriever_proto protoreflect.FileDescriptor
+func (x *BlobReply) GetOperators() []*OperatorContribution {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
 
-var file_retriever_retriever_proto_rawDesc = []byte{
-	0x0a, 0x19, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2f, 0x72, 0x65, 0x74, 0x72,
-	0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x72, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x22, 0xab, 0x01, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61,
-	0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62,
-	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75,
-	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72,
-	0x75, 0x6d, 0x49, 0x64, 0x22, 0x1f, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0x4b, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76,
-	0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c,
-	0x6f, 0x62, 0x12, 0x16, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42,
-	0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e,
-	0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x74, 0x72,
-	0x69, 0x65, 0x76, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *BlobReply) GetInclusionProof() *BlobInclusionProof {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
 }
 
-var (
-	file_retriever_retriever_proto_rawDescOnce sync.Once
-	file_retriever_retriever_proto_rawDescData = file_retriever_retriever_proto_rawDesc
-)
+func (x *BlobReply) GetDiagnostics() *RetrievalDiagnostics {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
 
-func file_retriever_retriever_proto_rawDescGZIP() []byte {
-	file_retriever_retriever_proto_rawDescOnce.Do(func() {
-		file_retriever_retriever_proto_rawDescData = protoimpl.X.CompressGZIP(file_retriever_retriever_proto_rawDescData)
-	})
-	return file_retriever_retriever_proto_rawDescData
+type BlobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the ReducedBatchHeader of the batch of the blobs, see BlobRequest.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// The Ethereum block number the operator state is read at, see BlobRequest.
+	ReferenceBlockNumber uint32 `protobuf:"varint,2,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// The blobs of the batch to retrieve.
+	Blobs []*BatchBlob `protobuf:"bytes,3,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// The priority of the retrievals of the blobs, see RetrievalPriority. Defaults to NORMAL.
+	Priority RetrievalPriority `protobuf:"varint,4,opt,name=priority,proto3,enum=retriever.RetrievalPriority" json:"priority,omitempty"`
 }
 
-var file_retriever_retriever_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_retriever_retriever_proto_goTypes = []interface{}{
-	(*BlobRequest)(nil), // 0: retriever.BlobRequest
-	(*BlobReply)(nil),   // 1: retriever.BlobReply
+func (x *BlobsRequest) Reset() {
+	*x = BlobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_retriever_retriever_proto_depIdxs = []int32{
-	0, // 0: retriever.Retriever.RetrieveBlob:input_type -> retriever.BlobRequest
-	1, // 1: retriever.Retriever.RetrieveBlob:output_type -> retriever.BlobReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *BlobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_retriever_retriever_proto_init() }
-func file_retriever_retriever_proto_init() {
-	if File_retriever_retriever_proto != nil {
-		return
+func (*BlobsRequest) ProtoMessage() {}
+
+func (x *BlobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_retriever_retriever_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobsRequest.ProtoReflect.Descriptor instead.
+func (*BlobsRequest) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlobsRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+func (x *BlobsRequest) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *BlobsRequest) GetBlobs() []*BatchBlob {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+func (x *BlobsRequest) GetPriority() RetrievalPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return RetrievalPriority_NORMAL
+}
+
+// A blob of a batch, and the quorum its chunks are retrieved from.
+type BatchBlob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Which blob in the batch this is.
+	BlobIndex uint32 `protobuf:"varint,1,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// Which quorum of the blob to retrieve the chunks from.
+	QuorumId uint32 `protobuf:"varint,2,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+}
+
+func (x *BatchBlob) Reset() {
+	*x = BatchBlob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchBlob) ProtoMessage() {}
+
+func (x *BatchBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_retriever_retriever_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobReply); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchBlob.ProtoReflect.Descriptor instead.
+func (*BatchBlob) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchBlob) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *BatchBlob) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+// The outcome of the retrieval of one of the blobs of a BlobsRequest.
+type BlobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The position of the blob in the blobs of the BlobsRequest.
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The index of the blob in the batch.
+	BlobIndex uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The quorum the chunks of the blob were retrieved from.
+	QuorumId uint32 `protobuf:"varint,3,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// The reconstructed blob, if it was retrieved.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// The gRPC status code of the failure of the retrieval of the blob, or 0 (OK) if it was retrieved, e.g. NotFound
+	// if no EigenDA Node stores it.
+	Code uint32 `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+	// Why the retrieval of the blob failed, if it did.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BlobsReply) Reset() {
+	*x = BlobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobsReply) ProtoMessage() {}
+
+func (x *BlobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobsReply.ProtoReflect.Descriptor instead.
+func (*BlobsReply) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlobsReply) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BlobsReply) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *BlobsReply) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *BlobsReply) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BlobsReply) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BlobsReply) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BlobCertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ABI encoding of the BlobHeader and the BlobVerificationProof defined onchain, i.e.
+	// abi.encode(blobHeader, blobVerificationProof) as passed to EigenDABlobUtils.verifyBlob, see:
+	// https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDABlobUtils.sol
+	// The request fails with InvalidArgument if the cert isn't in that encoding, if its batch isn't confirmed
+	// onchain with the metadata of the cert, or if the blob header isn't included in the batch.
+	// The chunks are retrieved from the first quorum of the blob header.
+	Cert []byte `protobuf:"bytes,1,opt,name=cert,proto3" json:"cert,omitempty"`
+	// The priority of the retrieval, see RetrievalPriority. Defaults to NORMAL.
+	Priority RetrievalPriority `protobuf:"varint,2,opt,name=priority,proto3,enum=retriever.RetrievalPriority" json:"priority,omitempty"`
+}
+
+func (x *BlobCertRequest) Reset() {
+	*x = BlobCertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobCertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobCertRequest) ProtoMessage() {}
+
+func (x *BlobCertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobCertRequest.ProtoReflect.Descriptor instead.
+func (*BlobCertRequest) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlobCertRequest) GetCert() []byte {
+	if x != nil {
+		return x.Cert
+	}
+	return nil
+}
+
+func (x *BlobCertRequest) GetPriority() RetrievalPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return RetrievalPriority_NORMAL
+}
+
+type IntegrityCheckRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the ReducedBatchHeader of the batch of the blob, see BlobRequest.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// Which blob in the batch to check.
+	BlobIndex uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The Ethereum block number the operator state is read at, see BlobRequest.
+	ReferenceBlockNumber uint32 `protobuf:"varint,3,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// Which quorum of the blob to retrieve the chunks from.
+	QuorumId uint32 `protobuf:"varint,4,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+}
+
+func (x *IntegrityCheckRequest) Reset() {
+	*x = IntegrityCheckRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrityCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityCheckRequest) ProtoMessage() {}
+
+func (x *IntegrityCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityCheckRequest.ProtoReflect.Descriptor instead.
+func (*IntegrityCheckRequest) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IntegrityCheckRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+func (x *IntegrityCheckRequest) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *IntegrityCheckRequest) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *IntegrityCheckRequest) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+type IntegrityCheckReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the blob was reconstructed from the chunks of the operators and matched its commitment.
+	Verified bool `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	// Why the check failed if it did, e.g. too few operators returned their chunks, or the
+	// reconstructed blob didn't match its commitment.
+	Failure string `protobuf:"bytes,2,opt,name=failure,proto3" json:"failure,omitempty"`
+	// The length in bytes of the reconstructed blob if the check succeeded.
+	BlobLength uint32 `protobuf:"varint,3,opt,name=blob_length,json=blobLength,proto3" json:"blob_length,omitempty"`
+	// The time in milliseconds the retrieval and the verification of the blob took.
+	LatencyMs uint64 `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+}
+
+func (x *IntegrityCheckReply) Reset() {
+	*x = IntegrityCheckReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrityCheckReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityCheckReply) ProtoMessage() {}
+
+func (x *IntegrityCheckReply) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityCheckReply.ProtoReflect.Descriptor instead.
+func (*IntegrityCheckReply) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IntegrityCheckReply) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *IntegrityCheckReply) GetFailure() string {
+	if x != nil {
+		return x.Failure
+	}
+	return ""
+}
+
+func (x *IntegrityCheckReply) GetBlobLength() uint32 {
+	if x != nil {
+		return x.BlobLength
+	}
+	return 0
+}
+
+func (x *IntegrityCheckReply) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+// The Merkle proof of the header of a blob against the root of the blob headers of its batch.
+// It can be verified without trusting the Retriever:
+//  1. the keccak256 hash of the blob header is the leaf of the proof at the index,
+//  2. hashing the leaf with the hashes of the proof yields the batch root, as in
+//     EigenDABlobUtils.verifyBlob, and
+//  3. the keccak256 hash of the ABI-encoded ReducedBatchHeader (batch_root, reference_block_number)
+//     is the batch_header_hash of the BlobRequest, which identifies the batch confirmed onchain.
+type BlobInclusionProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ABI encoding of the BlobHeader defined onchain, see:
+	// https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol
+	// It holds the commitment of the blob, which the reconstructed blob was checked against.
+	BlobHeader []byte `protobuf:"bytes,1,opt,name=blob_header,json=blobHeader,proto3" json:"blob_header,omitempty"`
+	// The sibling hashes from the leaf to the root of the Merkle tree.
+	Hashes [][]byte `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	// The index of the leaf, i.e. of the blob in the batch.
+	Index uint32 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// The root of the Merkle tree of the blob headers of the batch, as confirmed onchain.
+	BatchRoot []byte `protobuf:"bytes,4,opt,name=batch_root,json=batchRoot,proto3" json:"batch_root,omitempty"`
+	// The reference block number of the batch, as confirmed onchain.
+	ReferenceBlockNumber uint32 `protobuf:"varint,5,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+}
+
+func (x *BlobInclusionProof) Reset() {
+	*x = BlobInclusionProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobInclusionProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobInclusionProof) ProtoMessage() {}
+
+func (x *BlobInclusionProof) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobInclusionProof.ProtoReflect.Descriptor instead.
+func (*BlobInclusionProof) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlobInclusionProof) GetBlobHeader() []byte {
+	if x != nil {
+		return x.BlobHeader
+	}
+	return nil
+}
+
+func (x *BlobInclusionProof) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *BlobInclusionProof) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BlobInclusionProof) GetBatchRoot() []byte {
+	if x != nil {
+		return x.BatchRoot
+	}
+	return nil
+}
+
+func (x *BlobInclusionProof) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+// The chunks an EigenDA Node supplied for the reconstruction of a blob.
+type OperatorContribution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the operator.
+	OperatorId []byte `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	// The number of chunks the operator returned.
+	NumChunks uint32 `protobuf:"varint,2,opt,name=num_chunks,json=numChunks,proto3" json:"num_chunks,omitempty"`
+	// The time in milliseconds the operator took to return its chunks.
+	LatencyMs uint64 `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+}
+
+func (x *OperatorContribution) Reset() {
+	*x = OperatorContribution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorContribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorContribution) ProtoMessage() {}
+
+func (x *OperatorContribution) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorContribution.ProtoReflect.Descriptor instead.
+func (*OperatorContribution) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OperatorContribution) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *OperatorContribution) GetNumChunks() uint32 {
+	if x != nil {
+		return x.NumChunks
+	}
+	return 0
+}
+
+func (x *OperatorContribution) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+// The chunks an EigenDA Node returned for a retrieval, or its failure to return them. Only the replies received
+// before the blob was reconstructed are described.
+type RetrievalDiagnostics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The chunks returned by the operators, in the order in which the operators replied.
+	Chunks []*ChunkDiagnostic `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	// The operators contacted that failed to return their chunks.
+	OperatorFailures []*OperatorFailure `protobuf:"bytes,2,rep,name=operator_failures,json=operatorFailures,proto3" json:"operator_failures,omitempty"`
+	// The time in milliseconds the decoding of the blob from the chunks took, including the retries.
+	ReconstructionLatencyMs uint64 `protobuf:"varint,3,opt,name=reconstruction_latency_ms,json=reconstructionLatencyMs,proto3" json:"reconstruction_latency_ms,omitempty"`
+}
+
+func (x *RetrievalDiagnostics) Reset() {
+	*x = RetrievalDiagnostics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetrievalDiagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrievalDiagnostics) ProtoMessage() {}
+
+func (x *RetrievalDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrievalDiagnostics.ProtoReflect.Descriptor instead.
+func (*RetrievalDiagnostics) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RetrievalDiagnostics) GetChunks() []*ChunkDiagnostic {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *RetrievalDiagnostics) GetOperatorFailures() []*OperatorFailure {
+	if x != nil {
+		return x.OperatorFailures
+	}
+	return nil
+}
+
+func (x *RetrievalDiagnostics) GetReconstructionLatencyMs() uint64 {
+	if x != nil {
+		return x.ReconstructionLatencyMs
+	}
+	return 0
+}
+
+type ChunkDiagnostic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the operator that returned the chunk.
+	OperatorId []byte `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	// The index of the chunk in the encoded blob.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// The time in milliseconds the operator took to return its chunks, which are returned together.
+	LatencyMs uint64 `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// Whether the chunk passed its proof against the commitment of the blob.
+	ProofVerified bool `protobuf:"varint,4,opt,name=proof_verified,json=proofVerified,proto3" json:"proof_verified,omitempty"`
+	// Whether the chunk was used in the reconstruction of the blob.
+	Used bool `protobuf:"varint,5,opt,name=used,proto3" json:"used,omitempty"`
+}
+
+func (x *ChunkDiagnostic) Reset() {
+	*x = ChunkDiagnostic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChunkDiagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkDiagnostic) ProtoMessage() {}
+
+func (x *ChunkDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkDiagnostic.ProtoReflect.Descriptor instead.
+func (*ChunkDiagnostic) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChunkDiagnostic) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *ChunkDiagnostic) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ChunkDiagnostic) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ChunkDiagnostic) GetProofVerified() bool {
+	if x != nil {
+		return x.ProofVerified
+	}
+	return false
+}
+
+func (x *ChunkDiagnostic) GetUsed() bool {
+	if x != nil {
+		return x.Used
+	}
+	return false
+}
+
+type OperatorFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the operator.
+	OperatorId []byte `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	// The time in milliseconds until the operator failed.
+	LatencyMs uint64 `protobuf:"varint,2,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// Why the operator returned no chunks.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *OperatorFailure) Reset() {
+	*x = OperatorFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorFailure) ProtoMessage() {}
+
+func (x *OperatorFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorFailure.ProtoReflect.Descriptor instead.
+func (*OperatorFailure) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OperatorFailure) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *OperatorFailure) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *OperatorFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{13}
+}
+
+type GetVersionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version of the Retriever, e.g. the git tag it was built from.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// The git commit the Retriever was built from.
+	GitCommit string `protobuf:"bytes,2,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	// The time the Retriever was built at, in RFC 3339 format.
+	BuildTime string `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	// The versions of the encoding of the blobs the Retriever can decode.
+	EncodingVersions []uint32 `protobuf:"varint,4,rep,packed,name=encoding_versions,json=encodingVersions,proto3" json:"encoding_versions,omitempty"`
+}
+
+func (x *GetVersionReply) Reset() {
+	*x = GetVersionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVersionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionReply) ProtoMessage() {}
+
+func (x *GetVersionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionReply.ProtoReflect.Descriptor instead.
+func (*GetVersionReply) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetVersionReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetVersionReply) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *GetVersionReply) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *GetVersionReply) GetEncodingVersions() []uint32 {
+	if x != nil {
+		return x.EncodingVersions
+	}
+	return nil
+}
+
+type ChunksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the ReducedBatchHeader of the batch of the blob, see BlobRequest.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// Which blob in the batch the chunks are of.
+	BlobIndex uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// Which quorum of the blob the chunks are of.
+	QuorumId uint32 `protobuf:"varint,3,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+}
+
+func (x *ChunksRequest) Reset() {
+	*x = ChunksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunksRequest) ProtoMessage() {}
+
+func (x *ChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunksRequest.ProtoReflect.Descriptor instead.
+func (*ChunksRequest) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChunksRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+func (x *ChunksRequest) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *ChunksRequest) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+type ChunksReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The cached chunks, each in the serialization of the chunks returned by the EigenDA Nodes.
+	Chunks [][]byte `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	// The indices of the chunks in the encoded blob, one for each chunk.
+	Indices []uint32 `protobuf:"varint,2,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (x *ChunksReply) Reset() {
+	*x = ChunksReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChunksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunksReply) ProtoMessage() {}
+
+func (x *ChunksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunksReply.ProtoReflect.Descriptor instead.
+func (*ChunksReply) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChunksReply) GetChunks() [][]byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *ChunksReply) GetIndices() []uint32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+type BlobAssignmentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The hash of the ReducedBatchHeader of the batch of the blob, see BlobRequest.
+	BatchHeaderHash []byte `protobuf:"bytes,1,opt,name=batch_header_hash,json=batchHeaderHash,proto3" json:"batch_header_hash,omitempty"`
+	// Which blob in the batch the assignment is of.
+	BlobIndex uint32 `protobuf:"varint,2,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The Ethereum block number the operator state is read at, see BlobRequest.
+	ReferenceBlockNumber uint32 `protobuf:"varint,3,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// Which quorum of the blob the assignment is of.
+	QuorumId uint32 `protobuf:"varint,4,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+}
+
+func (x *BlobAssignmentsRequest) Reset() {
+	*x = BlobAssignmentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobAssignmentsRequest) ProtoMessage() {}
+
+func (x *BlobAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*BlobAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BlobAssignmentsRequest) GetBatchHeaderHash() []byte {
+	if x != nil {
+		return x.BatchHeaderHash
+	}
+	return nil
+}
+
+func (x *BlobAssignmentsRequest) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *BlobAssignmentsRequest) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *BlobAssignmentsRequest) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+type BlobAssignmentsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The chunks assigned to each operator of the quorum, in the order of the indices of their chunks.
+	Operators []*OperatorAssignment `protobuf:"bytes,1,rep,name=operators,proto3" json:"operators,omitempty"`
+	// The total number of chunks of the encoded blob.
+	TotalChunks uint32 `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	// The length of the chunks in symbols.
+	ChunkLength uint32 `protobuf:"varint,3,opt,name=chunk_length,json=chunkLength,proto3" json:"chunk_length,omitempty"`
+	// The length of the blob in symbols.
+	BlobLength uint32 `protobuf:"varint,4,opt,name=blob_length,json=blobLength,proto3" json:"blob_length,omitempty"`
+	// The number of chunks the blob can be reconstructed from.
+	NumChunksNeeded uint32 `protobuf:"varint,5,opt,name=num_chunks_needed,json=numChunksNeeded,proto3" json:"num_chunks_needed,omitempty"`
+	// The Ethereum block number the operator state was read at.
+	ReferenceBlockNumber uint32 `protobuf:"varint,6,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+}
+
+func (x *BlobAssignmentsReply) Reset() {
+	*x = BlobAssignmentsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobAssignmentsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobAssignmentsReply) ProtoMessage() {}
+
+func (x *BlobAssignmentsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobAssignmentsReply.ProtoReflect.Descriptor instead.
+func (*BlobAssignmentsReply) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BlobAssignmentsReply) GetOperators() []*OperatorAssignment {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+func (x *BlobAssignmentsReply) GetTotalChunks() uint32 {
+	if x != nil {
+		return x.TotalChunks
+	}
+	return 0
+}
+
+func (x *BlobAssignmentsReply) GetChunkLength() uint32 {
+	if x != nil {
+		return x.ChunkLength
+	}
+	return 0
+}
+
+func (x *BlobAssignmentsReply) GetBlobLength() uint32 {
+	if x != nil {
+		return x.BlobLength
+	}
+	return 0
+}
+
+func (x *BlobAssignmentsReply) GetNumChunksNeeded() uint32 {
+	if x != nil {
+		return x.NumChunksNeeded
+	}
+	return 0
+}
+
+func (x *BlobAssignmentsReply) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+// The chunks of a blob assigned to an EigenDA Node.
+type OperatorAssignment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the operator.
+	OperatorId []byte `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	// The index of the first chunk of the operator in the encoded blob.
+	StartIndex uint32 `protobuf:"varint,2,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	// The number of chunks assigned to the operator, which are the ones following the start index.
+	NumChunks uint32 `protobuf:"varint,3,opt,name=num_chunks,json=numChunks,proto3" json:"num_chunks,omitempty"`
+}
+
+func (x *OperatorAssignment) Reset() {
+	*x = OperatorAssignment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_retriever_retriever_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorAssignment) ProtoMessage() {}
+
+func (x *OperatorAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_retriever_retriever_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorAssignment.ProtoReflect.Descriptor instead.
+func (*OperatorAssignment) Descriptor() ([]byte, []int) {
+	return file_retriever_retriever_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OperatorAssignment) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *OperatorAssignment) GetStartIndex() uint32 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *OperatorAssignment) GetNumChunks() uint32 {
+	if x != nil {
+		return x.NumChunks
+	}
+	return 0
+}
+
+var File_retriever_retriever_proto protoreflect.FileDescriptor
+
+var file_retriever_retriever_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2f, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x72, 0x65, 0x74,
+	0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x22, 0x94, 0x03, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x12, 0x36, 0x0a, 0x17, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x15, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x49, 0x6e, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x62, 0x6f, 0x73, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x65, 0x72, 0x62,
+	0x6f, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0xe9, 0x01,
+	0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x3d, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x46,
+	0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f,
+	0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f,
+	0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x41, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61,
+	0x6c, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x0b, 0x64, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x0c, 0x42, 0x6c,
+	0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05,
+	0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x74,
+	0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x22, 0x47, 0x0a, 0x09, 0x42, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6c, 0x6f, 0x62, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b,
+	0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x22, 0x9c, 0x01, 0x0a, 0x0a,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x5f, 0x0a, 0x0f, 0x42, 0x6c,
+	0x6f, 0x62, 0x43, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72,
+	0x74, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0xb5, 0x01, 0x0a, 0x15,
+	0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x49, 0x64, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74,
+	0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d,
+	0x73, 0x22, 0xb8, 0x01, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x62,
+	0x6c, 0x6f, 0x62, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x75, 0x0a, 0x14,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4d, 0x73, 0x22, 0xcf, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61,
+	0x6c, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x32, 0x0a, 0x06,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73,
+	0x12, 0x47, 0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x72, 0x65,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4d, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x0f, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x44,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x22, 0x67, 0x0a, 0x0f, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x69, 0x74, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x69, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x10, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x77, 0x0a, 0x0d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d,
+	0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a,
+	0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x0b, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x16,
+	0x42, 0x6c, 0x6f, 0x62, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x49, 0x64, 0x22, 0x9c, 0x02, 0x0a, 0x14, 0x42, 0x6c, 0x6f, 0x62, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3b, 0x0a,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x5f,
+	0x6e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6e, 0x75,
+	0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x34, 0x0a,
+	0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x22, 0x75, 0x0a, 0x12, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x41,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x6e,
+	0x75, 0x6d, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x6e, 0x75, 0x6d, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x2a, 0x32, 0x0a, 0x11, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48,
+	0x49, 0x47, 0x48, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x57, 0x10, 0x02, 0x32, 0x9d,
+	0x04, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0c,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x16, 0x2e, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72,
+	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x12,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x20, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x49,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x14, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x65, 0x72, 0x74, 0x12, 0x1a,
+	0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x43,
+	0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x65, 0x74,
+	0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x48, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x18, 0x2e, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a,
+	0x0d, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x17,
+	0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x5a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x2e, 0x72, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x31,
+	0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79,
+	0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65,
+	0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_retriever_retriever_proto_rawDescOnce sync.Once
+	file_retriever_retriever_proto_rawDescData = file_retriever_retriever_proto_rawDesc
+)
+
+func file_retriever_retriever_proto_rawDescGZIP() []byte {
+	file_retriever_retriever_proto_rawDescOnce.Do(func() {
+		file_retriever_retriever_proto_rawDescData = protoimpl.X.CompressGZIP(file_retriever_retriever_proto_rawDescData)
+	})
+	return file_retriever_retriever_proto_rawDescData
+}
+
+var file_retriever_retriever_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_retriever_retriever_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_retriever_retriever_proto_goTypes = []interface{}{
+	(RetrievalPriority)(0),         // 0: retriever.RetrievalPriority
+	(*BlobRequest)(nil),            // 1: retriever.BlobRequest
+	(*BlobReply)(nil),              // 2: retriever.BlobReply
+	(*BlobsRequest)(nil),           // 3: retriever.BlobsRequest
+	(*BatchBlob)(nil),              // 4: retriever.BatchBlob
+	(*BlobsReply)(nil),             // 5: retriever.BlobsReply
+	(*BlobCertRequest)(nil),        // 6: retriever.BlobCertRequest
+	(*IntegrityCheckRequest)(nil),  // 7: retriever.IntegrityCheckRequest
+	(*IntegrityCheckReply)(nil),    // 8: retriever.IntegrityCheckReply
+	(*BlobInclusionProof)(nil),     // 9: retriever.BlobInclusionProof
+	(*OperatorContribution)(nil),   // 10: retriever.OperatorContribution
+	(*RetrievalDiagnostics)(nil),   // 11: retriever.RetrievalDiagnostics
+	(*ChunkDiagnostic)(nil),        // 12: retriever.ChunkDiagnostic
+	(*OperatorFailure)(nil),        // 13: retriever.OperatorFailure
+	(*GetVersionRequest)(nil),      // 14: retriever.GetVersionRequest
+	(*GetVersionReply)(nil),        // 15: retriever.GetVersionReply
+	(*ChunksRequest)(nil),          // 16: retriever.ChunksRequest
+	(*ChunksReply)(nil),            // 17: retriever.ChunksReply
+	(*BlobAssignmentsRequest)(nil), // 18: retriever.BlobAssignmentsRequest
+	(*BlobAssignmentsReply)(nil),   // 19: retriever.BlobAssignmentsReply
+	(*OperatorAssignment)(nil),     // 20: retriever.OperatorAssignment
+}
+var file_retriever_retriever_proto_depIdxs = []int32{
+	0,  // 0: retriever.BlobRequest.priority:type_name -> retriever.RetrievalPriority
+	10, // 1: retriever.BlobReply.operators:type_name -> retriever.OperatorContribution
+	9,  // 2: retriever.BlobReply.inclusion_proof:type_name -> retriever.BlobInclusionProof
+	11, // 3: retriever.BlobReply.diagnostics:type_name -> retriever.RetrievalDiagnostics
+	4,  // 4: retriever.BlobsRequest.blobs:type_name -> retriever.BatchBlob
+	0,  // 5: retriever.BlobsRequest.priority:type_name -> retriever.RetrievalPriority
+	0,  // 6: retriever.BlobCertRequest.priority:type_name -> retriever.RetrievalPriority
+	12, // 7: retriever.RetrievalDiagnostics.chunks:type_name -> retriever.ChunkDiagnostic
+	13, // 8: retriever.RetrievalDiagnostics.operator_failures:type_name -> retriever.OperatorFailure
+	20, // 9: retriever.BlobAssignmentsReply.operators:type_name -> retriever.OperatorAssignment
+	1,  // 10: retriever.Retriever.RetrieveBlob:input_type -> retriever.BlobRequest
+	7,  // 11: retriever.Retriever.CheckBlobIntegrity:input_type -> retriever.IntegrityCheckRequest
+	6,  // 12: retriever.Retriever.RetrieveBlobFromCert:input_type -> retriever.BlobCertRequest
+	14, // 13: retriever.Retriever.GetVersion:input_type -> retriever.GetVersionRequest
+	16, // 14: retriever.Retriever.GetChunks:input_type -> retriever.ChunksRequest
+	3,  // 15: retriever.Retriever.RetrieveBlobs:input_type -> retriever.BlobsRequest
+	18, // 16: retriever.Retriever.GetBlobAssignments:input_type -> retriever.BlobAssignmentsRequest
+	2,  // 17: retriever.Retriever.RetrieveBlob:output_type -> retriever.BlobReply
+	8,  // 18: retriever.Retriever.CheckBlobIntegrity:output_type -> retriever.IntegrityCheckReply
+	2,  // 19: retriever.Retriever.RetrieveBlobFromCert:output_type -> retriever.BlobReply
+	15, // 20: retriever.Retriever.GetVersion:output_type -> retriever.GetVersionReply
+	17, // 21: retriever.Retriever.GetChunks:output_type -> retriever.ChunksReply
+	5,  // 22: retriever.Retriever.RetrieveBlobs:output_type -> retriever.BlobsReply
+	19, // 23: retriever.Retriever.GetBlobAssignments:output_type -> retriever.BlobAssignmentsReply
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_retriever_retriever_proto_init() }
+func file_retriever_retriever_proto_init() {
+	if File_retriever_retriever_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_retriever_retriever_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchBlob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobCertRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegrityCheckRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegrityCheckReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobInclusionProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorContribution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrievalDiagnostics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChunkDiagnostic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVersionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChunksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChunksReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobAssignmentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobAssignmentsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_retriever_retriever_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorAssignment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -239,13 +2163,14 @@ func file_retriever_retriever_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_retriever_retriever_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_retriever_retriever_proto_goTypes,
 		DependencyIndexes: file_retriever_retriever_proto_depIdxs,
+		EnumInfos:         file_retriever_retriever_proto_enumTypes,
 		MessageInfos:      file_retriever_retriever_proto_msgTypes,
 	}.Build()
 	File_retriever_retriever_proto = out.File
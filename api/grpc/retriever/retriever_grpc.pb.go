@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Retriever_RetrieveBlob_FullMethodName = "/retriever.Retriever/RetrieveBlob"
+	Retriever_RetrieveBlob_FullMethodName         = "/retriever.Retriever/RetrieveBlob"
+	Retriever_CheckBlobIntegrity_FullMethodName   = "/retriever.Retriever/CheckBlobIntegrity"
+	Retriever_RetrieveBlobFromCert_FullMethodName = "/retriever.Retriever/RetrieveBlobFromCert"
+	Retriever_GetVersion_FullMethodName           = "/retriever.Retriever/GetVersion"
+	Retriever_GetChunks_FullMethodName            = "/retriever.Retriever/GetChunks"
+	Retriever_RetrieveBlobs_FullMethodName        = "/retriever.Retriever/RetrieveBlobs"
+	Retriever_GetBlobAssignments_FullMethodName   = "/retriever.Retriever/GetBlobAssignments"
 )
 
 // RetrieverClient is the client API for Retriever service.
@@ -29,6 +35,40 @@ type RetrieverClient interface {
 	// This fans out request to EigenDA Nodes to retrieve the chunks and returns the
 	// reconstructed original blob in response.
 	RetrieveBlob(ctx context.Context, in *BlobRequest, opts ...grpc.CallOption) (*BlobReply, error)
+	// CheckBlobIntegrity retrieves and reconstructs the blob like RetrieveBlob, and verifies it
+	// against its commitment, but only returns whether it succeeded, without the blob. It's meant
+	// for monitoring that blobs remain retrievable at a fraction of the bandwidth of a retrieval.
+	// The check fails like RetrieveBlob if the batch can't be looked up, with Unavailable if the
+	// operator state can't be read, and with the status of its context if it's canceled, as these
+	// say nothing of the blob.
+	CheckBlobIntegrity(ctx context.Context, in *IntegrityCheckRequest, opts ...grpc.CallOption) (*IntegrityCheckReply, error)
+	// RetrieveBlobFromCert retrieves the blob of the cert that the rollups submit to the contracts, once the
+	// Retriever verified that the batch of the cert was confirmed onchain and that the blob is included in it.
+	// The batch, the blob index, the reference block and the quorum of the retrieval are the ones of the cert.
+	// See clients.NewBlobCert for the cert of the BlobInfo returned by the Disperser.
+	RetrieveBlobFromCert(ctx context.Context, in *BlobCertRequest, opts ...grpc.CallOption) (*BlobReply, error)
+	// GetVersion returns the build info of the Retriever, so that the rollouts of new
+	// versions can be verified across the instances.
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error)
+	// GetChunks returns the chunks of a blob that the Retriever verified against their proofs in its past
+	// retrievals and still caches, so that the Retrievers of a cluster can fetch from each other the chunks that
+	// the EigenDA Nodes fail to return. It doesn't contact the EigenDA Nodes. The chunks aren't trusted: the
+	// Retriever requesting them verifies them against the commitment of the blob before using them.
+	// It fails with NotFound if no chunk of the blob is cached, and with Unimplemented if the Retriever doesn't
+	// cache chunks.
+	GetChunks(ctx context.Context, in *ChunksRequest, opts ...grpc.CallOption) (*ChunksReply, error)
+	// RetrieveBlobs retrieves several blobs of a confirmed batch together, e.g. to replay the history of a rollup,
+	// reading the operator state once and fetching the chunks of all the blobs through the same connections to the
+	// EigenDA Nodes. Each blob is streamed back as soon as it's reconstructed, in any order, with its own status, so
+	// that a blob that fails doesn't fail the others. RetrieveBlob retrieves its blob the same way. The request fails
+	// with InvalidArgument if it has no blob or if its batch is invalid, as for RetrieveBlob.
+	RetrieveBlobs(ctx context.Context, in *BlobsRequest, opts ...grpc.CallOption) (Retriever_RetrieveBlobsClient, error)
+	// GetBlobAssignments returns the assignment of the chunks of a blob to the EigenDA Nodes of a quorum, which the
+	// Retriever computes from the operator state and the encoding of the blob the same way as for its retrievals,
+	// without fetching any chunk. Only the header of the blob is fetched from the EigenDA Nodes, and verified against
+	// the batch confirmed onchain. It's meant for the tools that analyze how the chunks are distributed across the
+	// EigenDA Nodes.
+	GetBlobAssignments(ctx context.Context, in *BlobAssignmentsRequest, opts ...grpc.CallOption) (*BlobAssignmentsReply, error)
 }
 
 type retrieverClient struct {
@@ -48,6 +88,83 @@ func (c *retrieverClient) RetrieveBlob(ctx context.Context, in *BlobRequest, opt
 	return out, nil
 }
 
+func (c *retrieverClient) CheckBlobIntegrity(ctx context.Context, in *IntegrityCheckRequest, opts ...grpc.CallOption) (*IntegrityCheckReply, error) {
+	out := new(IntegrityCheckReply)
+	err := c.cc.Invoke(ctx, Retriever_CheckBlobIntegrity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *retrieverClient) RetrieveBlobFromCert(ctx context.Context, in *BlobCertRequest, opts ...grpc.CallOption) (*BlobReply, error) {
+	out := new(BlobReply)
+	err := c.cc.Invoke(ctx, Retriever_RetrieveBlobFromCert_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *retrieverClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error) {
+	out := new(GetVersionReply)
+	err := c.cc.Invoke(ctx, Retriever_GetVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *retrieverClient) GetChunks(ctx context.Context, in *ChunksRequest, opts ...grpc.CallOption) (*ChunksReply, error) {
+	out := new(ChunksReply)
+	err := c.cc.Invoke(ctx, Retriever_GetChunks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *retrieverClient) RetrieveBlobs(ctx context.Context, in *BlobsRequest, opts ...grpc.CallOption) (Retriever_RetrieveBlobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Retriever_ServiceDesc.Streams[0], Retriever_RetrieveBlobs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &retrieverRetrieveBlobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Retriever_RetrieveBlobsClient interface {
+	Recv() (*BlobsReply, error)
+	grpc.ClientStream
+}
+
+type retrieverRetrieveBlobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *retrieverRetrieveBlobsClient) Recv() (*BlobsReply, error) {
+	m := new(BlobsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *retrieverClient) GetBlobAssignments(ctx context.Context, in *BlobAssignmentsRequest, opts ...grpc.CallOption) (*BlobAssignmentsReply, error) {
+	out := new(BlobAssignmentsReply)
+	err := c.cc.Invoke(ctx, Retriever_GetBlobAssignments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RetrieverServer is the server API for Retriever service.
 // All implementations must embed UnimplementedRetrieverServer
 // for forward compatibility
@@ -55,6 +172,40 @@ type RetrieverServer interface {
 	// This fans out request to EigenDA Nodes to retrieve the chunks and returns the
 	// reconstructed original blob in response.
 	RetrieveBlob(context.Context, *BlobRequest) (*BlobReply, error)
+	// CheckBlobIntegrity retrieves and reconstructs the blob like RetrieveBlob, and verifies it
+	// against its commitment, but only returns whether it succeeded, without the blob. It's meant
+	// for monitoring that blobs remain retrievable at a fraction of the bandwidth of a retrieval.
+	// The check fails like RetrieveBlob if the batch can't be looked up, with Unavailable if the
+	// operator state can't be read, and with the status of its context if it's canceled, as these
+	// say nothing of the blob.
+	CheckBlobIntegrity(context.Context, *IntegrityCheckRequest) (*IntegrityCheckReply, error)
+	// RetrieveBlobFromCert retrieves the blob of the cert that the rollups submit to the contracts, once the
+	// Retriever verified that the batch of the cert was confirmed onchain and that the blob is included in it.
+	// The batch, the blob index, the reference block and the quorum of the retrieval are the ones of the cert.
+	// See clients.NewBlobCert for the cert of the BlobInfo returned by the Disperser.
+	RetrieveBlobFromCert(context.Context, *BlobCertRequest) (*BlobReply, error)
+	// GetVersion returns the build info of the Retriever, so that the rollouts of new
+	// versions can be verified across the instances.
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error)
+	// GetChunks returns the chunks of a blob that the Retriever verified against their proofs in its past
+	// retrievals and still caches, so that the Retrievers of a cluster can fetch from each other the chunks that
+	// the EigenDA Nodes fail to return. It doesn't contact the EigenDA Nodes. The chunks aren't trusted: the
+	// Retriever requesting them verifies them against the commitment of the blob before using them.
+	// It fails with NotFound if no chunk of the blob is cached, and with Unimplemented if the Retriever doesn't
+	// cache chunks.
+	GetChunks(context.Context, *ChunksRequest) (*ChunksReply, error)
+	// RetrieveBlobs retrieves several blobs of a confirmed batch together, e.g. to replay the history of a rollup,
+	// reading the operator state once and fetching the chunks of all the blobs through the same connections to the
+	// EigenDA Nodes. Each blob is streamed back as soon as it's reconstructed, in any order, with its own status, so
+	// that a blob that fails doesn't fail the others. RetrieveBlob retrieves its blob the same way. The request fails
+	// with InvalidArgument if it has no blob or if its batch is invalid, as for RetrieveBlob.
+	RetrieveBlobs(*BlobsRequest, Retriever_RetrieveBlobsServer) error
+	// GetBlobAssignments returns the assignment of the chunks of a blob to the EigenDA Nodes of a quorum, which the
+	// Retriever computes from the operator state and the encoding of the blob the same way as for its retrievals,
+	// without fetching any chunk. Only the header of the blob is fetched from the EigenDA Nodes, and verified against
+	// the batch confirmed onchain. It's meant for the tools that analyze how the chunks are distributed across the
+	// EigenDA Nodes.
+	GetBlobAssignments(context.Context, *BlobAssignmentsRequest) (*BlobAssignmentsReply, error)
 	mustEmbedUnimplementedRetrieverServer()
 }
 
@@ -65,6 +216,24 @@ type UnimplementedRetrieverServer struct {
 func (UnimplementedRetrieverServer) RetrieveBlob(context.Context, *BlobRequest) (*BlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlob not implemented")
 }
+func (UnimplementedRetrieverServer) CheckBlobIntegrity(context.Context, *IntegrityCheckRequest) (*IntegrityCheckReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckBlobIntegrity not implemented")
+}
+func (UnimplementedRetrieverServer) RetrieveBlobFromCert(context.Context, *BlobCertRequest) (*BlobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlobFromCert not implemented")
+}
+func (UnimplementedRetrieverServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedRetrieverServer) GetChunks(context.Context, *ChunksRequest) (*ChunksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
+}
+func (UnimplementedRetrieverServer) RetrieveBlobs(*BlobsRequest, Retriever_RetrieveBlobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method RetrieveBlobs not implemented")
+}
+func (UnimplementedRetrieverServer) GetBlobAssignments(context.Context, *BlobAssignmentsRequest) (*BlobAssignmentsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobAssignments not implemented")
+}
 func (UnimplementedRetrieverServer) mustEmbedUnimplementedRetrieverServer() {}
 
 // UnsafeRetrieverServer may be embedded to opt out of forward compatibility for this service.
@@ -96,6 +265,117 @@ func _Retriever_RetrieveBlob_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Retriever_CheckBlobIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegrityCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrieverServer).CheckBlobIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retriever_CheckBlobIntegrity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrieverServer).CheckBlobIntegrity(ctx, req.(*IntegrityCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Retriever_RetrieveBlobFromCert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobCertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrieverServer).RetrieveBlobFromCert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retriever_RetrieveBlobFromCert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrieverServer).RetrieveBlobFromCert(ctx, req.(*BlobCertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Retriever_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrieverServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retriever_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrieverServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Retriever_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrieverServer).GetChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retriever_GetChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrieverServer).GetChunks(ctx, req.(*ChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Retriever_RetrieveBlobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RetrieverServer).RetrieveBlobs(m, &retrieverRetrieveBlobsServer{stream})
+}
+
+type Retriever_RetrieveBlobsServer interface {
+	Send(*BlobsReply) error
+	grpc.ServerStream
+}
+
+type retrieverRetrieveBlobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *retrieverRetrieveBlobsServer) Send(m *BlobsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Retriever_GetBlobAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobAssignmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrieverServer).GetBlobAssignments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retriever_GetBlobAssignments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrieverServer).GetBlobAssignments(ctx, req.(*BlobAssignmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Retriever_ServiceDesc is the grpc.ServiceDesc for Retriever service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -107,7 +387,33 @@ var Retriever_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveBlob",
 			Handler:    _Retriever_RetrieveBlob_Handler,
 		},
+		{
+			MethodName: "CheckBlobIntegrity",
+			Handler:    _Retriever_CheckBlobIntegrity_Handler,
+		},
+		{
+			MethodName: "RetrieveBlobFromCert",
+			Handler:    _Retriever_RetrieveBlobFromCert_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _Retriever_GetVersion_Handler,
+		},
+		{
+			MethodName: "GetChunks",
+			Handler:    _Retriever_GetChunks_Handler,
+		},
+		{
+			MethodName: "GetBlobAssignments",
+			Handler:    _Retriever_GetBlobAssignments_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RetrieveBlobs",
+			Handler:       _Retriever_RetrieveBlobs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "retriever/retriever.proto",
 }
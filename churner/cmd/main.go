@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -9,9 +10,13 @@ import (
 	pb "github.com/Layr-Labs/eigenda/api/grpc/churner"
 	"github.com/Layr-Labs/eigenda/churner"
 	"github.com/Layr-Labs/eigenda/churner/flags"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
@@ -21,29 +26,30 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
-var (
-	Version   = ""
-	GitCommit = ""
-	GitDate   = ""
-)
-
 func main() {
 	app := cli.NewApp()
-	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	version.Configure(app)
 	app.Name = "churner"
 	app.Usage = "EigenDA Churner"
 	app.Description = "Service manages contract registrations, facilitates operator removal, and gathers deregistration information from operators."
 	app.Flags = flags.Flags
+	configfile.Enable(app)
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return churner.NewConfig(ctx)
+	})
 	app.Action = run
 	if err := app.Run(os.Args); err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func run(ctx *cli.Context) error {
 	log.Println("Initializing churner")
+	config, err := churner.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
 	hostname := "0.0.0.0"
 	port := ctx.String(flags.GrpcPortFlag.Name)
 	addr := fmt.Sprintf("%s:%s", hostname, port)
@@ -53,15 +59,31 @@ func run(ctx *cli.Context) error {
 		log.Fatalln("could not start tcp listener", err)
 	}
 
+	logger, err := logging.GetLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+	logging.CycleLevelOnSignal(logger)
+
+	creds, err := grpcsec.ServerOption(context.Background(), config.TLSConfig, logger)
+	if err != nil {
+		return err
+	}
 	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300)
 	gs := grpc.NewServer(
 		opt,
+		creds,
 		grpc.ChainUnaryInterceptor(),
 	)
 
-	config := churner.NewConfig(ctx)
-	logger, err := logging.GetLogger(config.LoggerConfig)
-	if err != nil {
+	logger.Info("Starting churner", version.LogFields()...)
+	profiling.PublishBuildInfo()
+	profiling.Publish("config", map[string]any{
+		"grpc_port":                 port,
+		"graph_url":                 config.GraphUrl,
+		"per_public_key_rate_limit": config.PerPublicKeyRateLimit.String(),
+	})
+	if err := profiling.Start(context.Background(), config.ProfilingConfig, logger); err != nil {
 		return err
 	}
 
@@ -81,6 +103,7 @@ func run(ctx *cli.Context) error {
 	querier := graphql.NewClient(config.GraphUrl, nil)
 	indexer := thegraph.NewIndexedChainState(cs, querier, logger)
 	metrics := churner.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	metrics.EnableProfiling(config.ProfilingConfig)
 
 	cn, err := churner.NewChurner(config, indexer, tx, logger, metrics)
 	if err != nil {
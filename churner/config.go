@@ -5,7 +5,10 @@ import (
 
 	"github.com/Layr-Labs/eigenda/churner/flags"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/validation"
 	"github.com/urfave/cli"
 )
 
@@ -14,6 +17,9 @@ type Config struct {
 	LoggerConfig    logging.Config
 	GraphUrl        string
 	MetricsConfig   MetricsConfig
+	ProfilingConfig profiling.Config
+	// TLSConfig is nil if the gRPC server is plaintext
+	TLSConfig *grpcsec.Config
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -21,7 +27,18 @@ type Config struct {
 	PerPublicKeyRateLimit time.Duration
 }
 
-func NewConfig(ctx *cli.Context) *Config {
+// maxPerPublicKeyRateLimit bounds the interval between the requests of an operator, beyond which it would be locked
+// out of the churner for good by a misconfiguration
+const maxPerPublicKeyRateLimit = 7 * 24 * time.Hour
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := grpcsec.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
 	return &Config{
 		EthClientConfig:               geth.ReadEthClientConfig(ctx),
 		LoggerConfig:                  logging.ReadCLIConfig(ctx, flags.FlagPrefix),
@@ -29,9 +46,25 @@ func NewConfig(ctx *cli.Context) *Config {
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		PerPublicKeyRateLimit:         ctx.GlobalDuration(flags.PerPublicKeyRateLimit.Name),
+		ProfilingConfig:               profiling.ReadCLIConfig(ctx, flags.FlagPrefix),
+		TLSConfig:                     tlsConfig,
 		MetricsConfig: MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
+	}, nil
+}
+
+// validateFlags checks the ports, the contract addresses and the rate limit of the operator public keys
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Port(flags.GrpcPortFlag.Name, ctx.GlobalString(flags.GrpcPortFlag.Name)))
+	if ctx.GlobalBool(flags.EnableMetrics.Name) {
+		v.Add(validation.Port(flags.MetricsHTTPPort.Name, ctx.GlobalString(flags.MetricsHTTPPort.Name)))
 	}
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+	v.Add(validation.Range(flags.PerPublicKeyRateLimit.Name, ctx.GlobalDuration(flags.PerPublicKeyRateLimit.Name), 0, maxPerPublicKeyRateLimit))
+	v.Add(grpcsec.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	return v.Err()
 }
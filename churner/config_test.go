@@ -0,0 +1,56 @@
+package churner_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/churner"
+	"github.com/Layr-Labs/eigenda/churner/flags"
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const churnerConfigFile = `
+chain:
+  rpc: http://localhost:8545
+  private-key: 0123456789abcdef
+churner:
+  hostname: localhost
+  grpc-port: "32002"
+  indexer-graph-url: http://localhost:8000/subgraphs/name/Layr-Labs/eigenda-operator-state
+  bls-operator-state-retriever: "0x0000000000000000000000000000000000000001"
+  eigenda-service-manager: "0x0000000000000000000000000000000000000002"
+  per-public-key-rate-limit: 30m
+  enable-metrics: true
+  metrics-http-port: "9101"
+  log:
+    level-std: debug
+indexer-pull-interval: 5s
+`
+
+func TestConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "churner.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(churnerConfigFile), 0600))
+
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	configfile.Enable(app)
+	var config *churner.Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = churner.NewConfig(ctx)
+		return err
+	}
+	assert.NoError(t, app.Run([]string{"churner", "--config", path, "--churner.metrics-http-port", "9102"}))
+
+	assert.Equal(t, "http://localhost:8545", config.EthClientConfig.RPCURL)
+	assert.Equal(t, "http://localhost:8000/subgraphs/name/Layr-Labs/eigenda-operator-state", config.GraphUrl)
+	assert.Equal(t, "0x0000000000000000000000000000000000000001", config.BLSOperatorStateRetrieverAddr)
+	assert.Equal(t, 30*time.Minute, config.PerPublicKeyRateLimit)
+	assert.True(t, config.MetricsConfig.EnableMetrics)
+	assert.Equal(t, "9102", config.MetricsConfig.HTTPPort)
+	assert.Equal(t, "debug", config.LoggerConfig.StdLevel)
+}
@@ -4,8 +4,11 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -94,5 +97,8 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, profiling.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpcsec.CLIFlags(envPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envPrefix)...)
+	Flags = append(Flags, configfile.CLIFlag(envPrefix))
 }
@@ -6,6 +6,9 @@ import (
 	"net/http"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -36,8 +39,9 @@ type Metrics struct {
 	NumRequests *prometheus.CounterVec
 	Latency     *prometheus.SummaryVec
 
-	httpPort string
-	logger   common.Logger
+	httpPort  string
+	profiling profiling.Config
+	logger    common.Logger
 }
 
 func NewMetrics(httpPort string, logger common.Logger) *Metrics {
@@ -45,6 +49,8 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(version.NewCollector(namespace))
+	reg.MustRegister(logging.NewLevelCollector(namespace, logger))
 
 	metrics := &Metrics{
 		NumRequests: promauto.With(reg).NewCounterVec(
@@ -94,6 +100,11 @@ func (g *Metrics) IncrementFailedRequestNum(method string, reason FailReason) {
 	}).Inc()
 }
 
+// EnableProfiling serves the pprof handlers of the config on the metrics server, and must be called before Start
+func (g *Metrics) EnableProfiling(config profiling.Config) {
+	g.profiling = config
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
@@ -105,6 +116,8 @@ func (g *Metrics) Start(ctx context.Context) {
 			g.registry,
 			promhttp.HandlerOpts{},
 		))
+		profiling.RegisterHandlers(mux, g.profiling)
+		logging.RegisterHandlers(mux, g.logger)
 		err := http.ListenAndServe(addr, mux)
 		log.Error("Prometheus server failed", "err", err)
 	}()
@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrInvalidSignature is returned when the disperser rejects the signature of an authenticated dispersal
+	ErrInvalidSignature = errors.New("disperser rejected the signature of the dispersal")
+	// ErrUnknownAccount is returned when the disperser doesn't allow the account to disperse
+	ErrUnknownAccount = errors.New("disperser rejected the account of the dispersal")
+)
+
+// DisperseBlobAuthenticated disperses the blob of the request through the DisperseBlobAuthenticated stream of the
+// client, signing the challenge of the disperser with the signer. The account ID of the request is set to the one
+// of the signer. It can be used with any disperser stub, and is what DisperserClient uses when it has a signer.
+// The errors of the disperser are returned as is, except for the rejections of the signature and of the account,
+// which are returned wrapped in ErrInvalidSignature and ErrUnknownAccount.
+func DisperseBlobAuthenticated(ctx context.Context, client disperser_rpc.DisperserClient, signer auth.BlobRequestSigner, request *disperser_rpc.DisperseBlobRequest, opts ...grpc.CallOption) (*disperser_rpc.DisperseBlobReply, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.DisperseBlobAuthenticated(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sendAuthenticatedRequest(stream, &disperser_rpc.AuthenticatedRequest{
+		Payload: &disperser_rpc.AuthenticatedRequest_DisperseRequest{
+			DisperseRequest: &disperser_rpc.DisperseBlobRequest{
+				Data:           request.GetData(),
+				SecurityParams: request.GetSecurityParams(),
+				AccountId:      signer.AccountID(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := stream.Recv()
+	if err != nil {
+		return nil, translateAuthError(err)
+	}
+	header := reply.GetBlobAuthHeader()
+	if header == nil {
+		return nil, fmt.Errorf("expected the BlobAuthHeader from the disperser, got %T", reply.GetPayload())
+	}
+
+	signature, err := signer.SignBlobRequest(header.GetChallengeParameter(), request.GetData())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the blob request: %w", err)
+	}
+	err = sendAuthenticatedRequest(stream, &disperser_rpc.AuthenticatedRequest{
+		Payload: &disperser_rpc.AuthenticatedRequest_AuthenticationData{
+			AuthenticationData: &disperser_rpc.AuthenticationData{
+				AuthenticationData: signature,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err = stream.Recv()
+	if err != nil {
+		return nil, translateAuthError(err)
+	}
+	disperseReply := reply.GetDisperseReply()
+	if disperseReply == nil {
+		return nil, fmt.Errorf("expected the DisperseBlobReply from the disperser, got %T", reply.GetPayload())
+	}
+	// Wait for the disperser to end the stream, so that the RPC completes with its status instead of being canceled
+	_ = stream.CloseSend()
+	_, _ = stream.Recv()
+	return disperseReply, nil
+}
+
+// sendAuthenticatedRequest sends the request on the stream. If the disperser already closed the stream, the
+// error it closed it with is returned.
+func sendAuthenticatedRequest(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedClient, request *disperser_rpc.AuthenticatedRequest) error {
+	err := stream.Send(request)
+	if errors.Is(err, io.EOF) {
+		_, err = stream.Recv()
+		if err == nil {
+			return errors.New("disperser replied before receiving the request")
+		}
+		return translateAuthError(err)
+	}
+	return err
+}
+
+func translateAuthError(err error) error {
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, status.Convert(err).Message())
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s", ErrUnknownAccount, status.Convert(err).Message())
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	rollupbindings "github.com/Layr-Labs/eigenda/contracts/bindings/MockRollup"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// BlobCert is the cert of a blob that the contracts check the confirmation of, i.e. the arguments of
+// EigenDABlobUtils.verifyBlob: the header of the blob and the proof of its inclusion in a batch confirmed on-chain
+type BlobCert struct {
+	BlobHeader            rollupbindings.IEigenDAServiceManagerBlobHeader
+	BlobVerificationProof rollupbindings.EigenDABlobUtilsBlobVerificationProof
+}
+
+// blobCertArguments returns the ABI of the (BlobHeader, BlobVerificationProof) arguments of the contracts, taken from
+// the bindings of MockRollup.postCommitment which verifies the cert
+func blobCertArguments() (abi.Arguments, error) {
+	rollupABI, err := rollupbindings.ContractMockRollupMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, ok := rollupABI.Methods["postCommitment"]
+	if !ok {
+		return nil, fmt.Errorf("the rollup ABI has no postCommitment method")
+	}
+	return method.Inputs, nil
+}
+
+// NewBlobCert returns the cert of the blob of the BlobInfo returned by the disperser once the blob is confirmed, to be
+// submitted to the contracts or to Retriever.RetrieveBlobFromCert once encoded
+func NewBlobCert(blobInfo *disperser_rpc.BlobInfo) (*BlobCert, error) {
+	header := blobInfo.GetBlobHeader()
+	proof := blobInfo.GetBlobVerificationProof()
+	metadata := proof.GetBatchMetadata()
+	batchHeader := metadata.GetBatchHeader()
+	if header == nil || batchHeader == nil {
+		return nil, fmt.Errorf("%w: missing blob header or batch header", ErrInvalidBlobInfo)
+	}
+	if len(batchHeader.GetBatchRoot()) != 32 || len(metadata.GetSignatoryRecordHash()) != 32 {
+		return nil, fmt.Errorf("%w: batch root or signatory record hash isn't 32 bytes", ErrInvalidBlobInfo)
+	}
+	if proof.GetBlobIndex() > 255 {
+		return nil, fmt.Errorf("%w: blob index %d overflows the uint8 of the cert", ErrInvalidBlobInfo, proof.GetBlobIndex())
+	}
+	commitment, err := new(core.Commitment).Deserialize(header.GetCommitment())
+	if err != nil || commitment.G1Point == nil {
+		return nil, fmt.Errorf("%w: invalid commitment: %v", ErrInvalidBlobInfo, err)
+	}
+
+	cert := &BlobCert{}
+	cert.BlobHeader.Commitment = rollupbindings.BN254G1Point{
+		X: commitment.X.BigInt(new(big.Int)),
+		Y: commitment.Y.BigInt(new(big.Int)),
+	}
+	cert.BlobHeader.DataLength = header.GetDataLength()
+	cert.BlobHeader.QuorumBlobParams = make([]rollupbindings.IEigenDAServiceManagerQuorumBlobParam, len(header.GetBlobQuorumParams()))
+	for i, param := range header.GetBlobQuorumParams() {
+		cert.BlobHeader.QuorumBlobParams[i] = rollupbindings.IEigenDAServiceManagerQuorumBlobParam{
+			QuorumNumber:                 uint8(param.GetQuorumNumber()),
+			AdversaryThresholdPercentage: uint8(param.GetAdversaryThresholdPercentage()),
+			QuorumThresholdPercentage:    uint8(param.GetQuorumThresholdPercentage()),
+			QuantizationParameter:        uint8(param.GetQuantizationParam()),
+		}
+	}
+
+	batchMetadata := &cert.BlobVerificationProof.BatchMetadata
+	copy(batchMetadata.BatchHeader.BlobHeadersRoot[:], batchHeader.GetBatchRoot())
+	batchMetadata.BatchHeader.QuorumNumbers = batchHeader.GetQuorumNumbers()
+	batchMetadata.BatchHeader.QuorumThresholdPercentages = batchHeader.GetQuorumSignedPercentages()
+	batchMetadata.BatchHeader.ReferenceBlockNumber = batchHeader.GetReferenceBlockNumber()
+	copy(batchMetadata.SignatoryRecordHash[:], metadata.GetSignatoryRecordHash())
+	batchMetadata.Fee = new(big.Int).SetBytes(metadata.GetFee())
+	batchMetadata.ConfirmationBlockNumber = metadata.GetConfirmationBlockNumber()
+	cert.BlobVerificationProof.BatchId = proof.GetBatchId()
+	cert.BlobVerificationProof.BlobIndex = uint8(proof.GetBlobIndex())
+	cert.BlobVerificationProof.InclusionProof = proof.GetInclusionProof()
+	cert.BlobVerificationProof.QuorumThresholdIndexes = proof.GetQuorumIndexes()
+	return cert, nil
+}
+
+// DecodeBlobCert decodes the cert from the ABI encoding of the (BlobHeader, BlobVerificationProof) structs of the
+// contracts, see BlobCert.Encode. The encodings that aren't the exact one of the contracts, e.g. with trailing bytes,
+// are rejected.
+func DecodeBlobCert(data []byte) (*BlobCert, error) {
+	arguments, err := blobCertArguments()
+	if err != nil {
+		return nil, err
+	}
+	values, err := arguments.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode the cert: %v", ErrInvalidBlobInfo, err)
+	}
+	cert := &BlobCert{
+		BlobHeader:            *abi.ConvertType(values[0], new(rollupbindings.IEigenDAServiceManagerBlobHeader)).(*rollupbindings.IEigenDAServiceManagerBlobHeader),
+		BlobVerificationProof: *abi.ConvertType(values[1], new(rollupbindings.EigenDABlobUtilsBlobVerificationProof)).(*rollupbindings.EigenDABlobUtilsBlobVerificationProof),
+	}
+	encoded, err := cert.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode the cert: %v", ErrInvalidBlobInfo, err)
+	}
+	if !bytes.Equal(encoded, data) {
+		return nil, fmt.Errorf("%w: the cert of %d bytes isn't in the ABI encoding of the contracts", ErrInvalidBlobInfo, len(data))
+	}
+	return cert, nil
+}
+
+// Encode returns the ABI encoding of the cert, i.e. abi.encode(blobHeader, blobVerificationProof), which is the
+// calldata of the contracts verifying the cert after the selector
+func (c *BlobCert) Encode() ([]byte, error) {
+	arguments, err := blobCertArguments()
+	if err != nil {
+		return nil, err
+	}
+	return arguments.Pack(c.BlobHeader, c.BlobVerificationProof)
+}
+
+// BatchHeaderHash returns the hash of the ReducedBatchHeader of the batch of the cert, which identifies the batch
+// to the operators
+func (c *BlobCert) BatchHeaderHash() ([32]byte, error) {
+	batchHeader := core.BatchHeader{
+		BatchRoot:            c.BlobVerificationProof.BatchMetadata.BatchHeader.BlobHeadersRoot,
+		ReferenceBlockNumber: uint(c.BlobVerificationProof.BatchMetadata.BatchHeader.ReferenceBlockNumber),
+	}
+	return batchHeader.GetBatchHeaderHash()
+}
+
+// BlobInfo returns the BlobInfo of the cert, as the disperser would return it, without the fields that aren't in
+// the cert
+func (c *BlobCert) BlobInfo() (*disperser_rpc.BlobInfo, error) {
+	commitment := &core.Commitment{G1Point: &bn254.G1Point{}}
+	commitment.X.SetBigInt(c.BlobHeader.Commitment.X)
+	commitment.Y.SetBigInt(c.BlobHeader.Commitment.Y)
+	serialized, err := commitment.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to serialize the commitment: %v", ErrInvalidBlobInfo, err)
+	}
+	header := &disperser_rpc.BlobHeader{
+		Commitment: serialized,
+		DataLength: c.BlobHeader.DataLength,
+	}
+	for _, param := range c.BlobHeader.QuorumBlobParams {
+		header.BlobQuorumParams = append(header.BlobQuorumParams, &disperser_rpc.BlobQuorumParam{
+			QuorumNumber:                 uint32(param.QuorumNumber),
+			AdversaryThresholdPercentage: uint32(param.AdversaryThresholdPercentage),
+			QuorumThresholdPercentage:    uint32(param.QuorumThresholdPercentage),
+			QuantizationParam:            uint32(param.QuantizationParameter),
+		})
+	}
+
+	proof := c.BlobVerificationProof
+	var fee []byte
+	if proof.BatchMetadata.Fee != nil {
+		fee = proof.BatchMetadata.Fee.Bytes()
+	}
+	return &disperser_rpc.BlobInfo{
+		BlobHeader: header,
+		BlobVerificationProof: &disperser_rpc.BlobVerificationProof{
+			BatchId:   proof.BatchId,
+			BlobIndex: uint32(proof.BlobIndex),
+			BatchMetadata: &disperser_rpc.BatchMetadata{
+				BatchHeader: &disperser_rpc.BatchHeader{
+					BatchRoot:               proof.BatchMetadata.BatchHeader.BlobHeadersRoot[:],
+					QuorumNumbers:           proof.BatchMetadata.BatchHeader.QuorumNumbers,
+					QuorumSignedPercentages: proof.BatchMetadata.BatchHeader.QuorumThresholdPercentages,
+					ReferenceBlockNumber:    proof.BatchMetadata.BatchHeader.ReferenceBlockNumber,
+				},
+				SignatoryRecordHash:     proof.BatchMetadata.SignatoryRecordHash[:],
+				Fee:                     fee,
+				ConfirmationBlockNumber: proof.BatchMetadata.ConfirmationBlockNumber,
+			},
+			InclusionProof: proof.InclusionProof,
+			QuorumIndexes:  proof.QuorumThresholdIndexes,
+		},
+	}, nil
+}
+
+// VerifyBlobCert checks that the blob of the cert was confirmed on-chain, see VerifyBlobInclusion
+func VerifyBlobCert(ctx context.Context, ethClient bind.ContractCaller, serviceManagerAddr gcommon.Address, cert *BlobCert) error {
+	blobInfo, err := cert.BlobInfo()
+	if err != nil {
+		return err
+	}
+	return VerifyBlobInclusion(ctx, ethClient, serviceManagerAddr, blobInfo)
+}
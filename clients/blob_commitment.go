@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/utils"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// BlobCommitter computes the commitment of a blob locally, exactly as the disperser computes it, so that the
+// commitment is known before the blob is dispersed. Only the G1 points of the SRS are needed.
+type BlobCommitter struct {
+	g1 []bn254.G1Point
+}
+
+// NewBlobCommitter reads the first numPoints G1 points of the SRS at g1Path. The committer can commit to blobs
+// of up to numPoints symbols, i.e. numPoints*bn254.BYTES_PER_COEFFICIENT bytes.
+func NewBlobCommitter(g1Path string, numPoints uint64) (*BlobCommitter, error) {
+	g1, err := utils.ReadG1Points(g1Path, numPoints, uint64(runtime.GOMAXPROCS(0)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read G1 points: %w", err)
+	}
+	return &BlobCommitter{g1: g1}, nil
+}
+
+// ComputeCommitment returns the KZG commitment of the payload and its length in symbols.
+// The payload is split into symbols of bn254.BYTES_PER_COEFFICIENT bytes, the last of which is padded with zeros,
+// and each symbol is a coefficient of the committed polynomial.
+func (c *BlobCommitter) ComputeCommitment(data []byte) (*core.Commitment, uint, error) {
+	if len(data) == 0 {
+		return nil, 0, errors.New("blob is empty")
+	}
+
+	dataFr := encoder.ToFrArray(data)
+	if len(dataFr) > len(c.g1) {
+		return nil, 0, fmt.Errorf("blob of %d symbols exceeds the %d loaded SRS points", len(dataFr), len(c.g1))
+	}
+
+	commit := bn254.LinCombG1(c.g1[:len(dataFr)], dataFr)
+	return &core.Commitment{G1Point: commit}, uint(len(dataFr)), nil
+}
+
+// VerifyBlobCommitment checks that the commitment and length in the blob header returned by the disperser are the
+// ones computed locally, and returns an error wrapping ErrCommitmentMismatch otherwise
+func VerifyBlobCommitment(header *disperser_rpc.BlobHeader, commitment *core.Commitment, length uint) error {
+	if header == nil {
+		return fmt.Errorf("%w: missing blob header", ErrCommitmentMismatch)
+	}
+	if uint(header.GetDataLength()) != length {
+		return fmt.Errorf("%w: disperser returned length %d, expected %d", ErrCommitmentMismatch, header.GetDataLength(), length)
+	}
+
+	dispersed, err := new(core.Commitment).Deserialize(header.GetCommitment())
+	if err != nil {
+		return fmt.Errorf("%w: invalid commitment: %v", ErrCommitmentMismatch, err)
+	}
+	if dispersed.G1Point == nil || !bn254.EqualG1(dispersed.G1Point, commitment.G1Point) {
+		return fmt.Errorf("%w: disperser returned a different commitment", ErrCommitmentMismatch)
+	}
+	return nil
+}
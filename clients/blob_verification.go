@@ -0,0 +1,163 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	retriever_rpc "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// The errors of VerifyBlobInclusion and VerifyRetrievedBlobInclusion, which wrap them with the details of the failure
+var (
+	// ErrInvalidBlobInfo is returned if the BlobInfo is missing fields or can't be decoded
+	ErrInvalidBlobInfo = errors.New("invalid blob info")
+	// ErrBatchNotConfirmed is returned if EigenDAServiceManager has no batch with the batch ID of the BlobInfo
+	ErrBatchNotConfirmed = errors.New("batch is not confirmed on-chain")
+	// ErrBatchMetadataMismatch is returned if the batch metadata of the BlobInfo is not the one confirmed on-chain
+	ErrBatchMetadataMismatch = errors.New("batch metadata does not match the one confirmed on-chain")
+	// ErrBlobNotIncluded is returned if the inclusion proof of the blob header in the batch is invalid
+	ErrBlobNotIncluded = errors.New("blob header is not included in the batch")
+	// ErrBatchHeaderMismatch is returned if the batch root of the proof of a retrieved blob is not the one of the
+	// batch the blob was requested from
+	ErrBatchHeaderMismatch = errors.New("batch root does not match the batch header hash")
+)
+
+// VerifyBlobInclusion checks that the blob of the BlobInfo returned by the disperser was confirmed on-chain, the
+// way EigenDABlobUtils.verifyBlob does: the hash of the batch metadata must be the one that EigenDAServiceManager
+// stored for the batch ID when it confirmed the batch, and the blob header must be included in the blob headers
+// root of the batch. The security parameters of the blob are not checked.
+// Only an eth RPC is needed, e.g. a geth.EthClient.
+func VerifyBlobInclusion(ctx context.Context, ethClient bind.ContractCaller, serviceManagerAddr gcommon.Address, blobInfo *disperser_rpc.BlobInfo) error {
+	proof := blobInfo.GetBlobVerificationProof()
+	metadata := proof.GetBatchMetadata()
+	batchHeader := metadata.GetBatchHeader()
+	if blobInfo.GetBlobHeader() == nil || batchHeader == nil {
+		return fmt.Errorf("%w: missing blob header or batch header", ErrInvalidBlobInfo)
+	}
+	if len(batchHeader.GetBatchRoot()) != 32 {
+		return fmt.Errorf("%w: batch root of %d bytes", ErrInvalidBlobInfo, len(batchHeader.GetBatchRoot()))
+	}
+	if len(metadata.GetSignatoryRecordHash()) != 32 {
+		return fmt.Errorf("%w: signatory record hash of %d bytes", ErrInvalidBlobInfo, len(metadata.GetSignatoryRecordHash()))
+	}
+	if len(proof.GetInclusionProof())%32 != 0 {
+		return fmt.Errorf("%w: inclusion proof of %d bytes is not a list of hashes", ErrInvalidBlobInfo, len(proof.GetInclusionProof()))
+	}
+
+	var batchRoot, signatoryRecordHash [32]byte
+	copy(batchRoot[:], batchHeader.GetBatchRoot())
+	copy(signatoryRecordHash[:], metadata.GetSignatoryRecordHash())
+	batchHeaderHash, err := core.HashBatchHeader(binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              batchHeader.GetQuorumNumbers(),
+		QuorumThresholdPercentages: batchHeader.GetQuorumSignedPercentages(),
+		ReferenceBlockNumber:       batchHeader.GetReferenceBlockNumber(),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: failed to hash the batch header: %v", ErrInvalidBlobInfo, err)
+	}
+	metadataHash, err := core.HashBatchMetadata(batchHeaderHash, signatoryRecordHash, metadata.GetFee(), metadata.GetConfirmationBlockNumber())
+	if err != nil {
+		return fmt.Errorf("%w: failed to hash the batch metadata: %v", ErrInvalidBlobInfo, err)
+	}
+
+	serviceManager, err := binding.NewContractEigenDAServiceManagerCaller(serviceManagerAddr, ethClient)
+	if err != nil {
+		return err
+	}
+	confirmedHash, err := serviceManager.BatchIdToBatchMetadataHash(&bind.CallOpts{Context: ctx}, proof.GetBatchId())
+	if err != nil {
+		return fmt.Errorf("failed to fetch the metadata hash of batch %d: %w", proof.GetBatchId(), err)
+	}
+	if confirmedHash == ([32]byte{}) {
+		return fmt.Errorf("%w: batch %d", ErrBatchNotConfirmed, proof.GetBatchId())
+	}
+	if confirmedHash != metadataHash {
+		return fmt.Errorf("%w: batch %d has metadata hash %x on-chain, got %x", ErrBatchMetadataMismatch, proof.GetBatchId(), confirmedHash, metadataHash)
+	}
+
+	blobHeaderHash, err := hashBlobHeader(blobInfo.GetBlobHeader())
+	if err != nil {
+		return err
+	}
+	inclusionProof := &merkletree.Proof{Index: uint64(proof.GetBlobIndex())}
+	for i := 0; i < len(proof.GetInclusionProof()); i += 32 {
+		inclusionProof.Hashes = append(inclusionProof.Hashes, proof.GetInclusionProof()[i:i+32])
+	}
+	included, err := merkletree.VerifyProofUsing(blobHeaderHash[:], false, inclusionProof, [][]byte{batchRoot[:]}, keccak256.New())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBlobNotIncluded, err)
+	}
+	if !included {
+		return fmt.Errorf("%w: invalid inclusion proof of blob %d", ErrBlobNotIncluded, proof.GetBlobIndex())
+	}
+	return nil
+}
+
+// VerifyRetrievedBlobInclusion checks the inclusion proof that the retriever returns along with a blob, without
+// trusting the retriever: the blob header must be included in the batch root of the proof, and the batch root and
+// the reference block number of the proof must hash to the batch header hash the blob was requested with, i.e. the
+// hash of the ReducedBatchHeader of the batch confirmed on-chain.
+func VerifyRetrievedBlobInclusion(batchHeaderHash [32]byte, proof *retriever_rpc.BlobInclusionProof) error {
+	if len(proof.GetBlobHeader()) == 0 || len(proof.GetBatchRoot()) != 32 {
+		return fmt.Errorf("%w: missing blob header or batch root", ErrBlobNotIncluded)
+	}
+	batchHeader := core.BatchHeader{ReferenceBlockNumber: uint(proof.GetReferenceBlockNumber())}
+	copy(batchHeader.BatchRoot[:], proof.GetBatchRoot())
+	hash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return err
+	}
+	if hash != batchHeaderHash {
+		return fmt.Errorf("%w: the batch header of the proof has hash %x, expected %x", ErrBatchHeaderMismatch, hash, batchHeaderHash)
+	}
+
+	blobHeaderHash := crypto.Keccak256(proof.GetBlobHeader())
+	inclusionProof := &merkletree.Proof{Hashes: proof.GetHashes(), Index: uint64(proof.GetIndex())}
+	included, err := merkletree.VerifyProofUsing(blobHeaderHash, false, inclusionProof, [][]byte{batchHeader.BatchRoot[:]}, keccak256.New())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBlobNotIncluded, err)
+	}
+	if !included {
+		return fmt.Errorf("%w: invalid inclusion proof of blob %d", ErrBlobNotIncluded, proof.GetIndex())
+	}
+	return nil
+}
+
+// hashBlobHeader returns the hash of the blob header, i.e. the leaf of the blob headers root
+func hashBlobHeader(header *disperser_rpc.BlobHeader) ([32]byte, error) {
+	commitment, err := new(core.Commitment).Deserialize(header.GetCommitment())
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("%w: invalid commitment: %v", ErrInvalidBlobInfo, err)
+	}
+	blobHeader := core.BlobHeader{
+		BlobCommitments: core.BlobCommitments{
+			Commitment: commitment,
+			Length:     uint(header.GetDataLength()),
+		},
+	}
+	for _, param := range header.GetBlobQuorumParams() {
+		blobHeader.QuorumInfos = append(blobHeader.QuorumInfos, &core.BlobQuorumInfo{
+			SecurityParam: core.SecurityParam{
+				QuorumID:           core.QuorumID(param.GetQuorumNumber()),
+				AdversaryThreshold: uint8(param.GetAdversaryThresholdPercentage()),
+				QuorumThreshold:    uint8(param.GetQuorumThresholdPercentage()),
+			},
+			QuantizationFactor: uint(param.GetQuantizationParam()),
+		})
+	}
+	hash, err := blobHeader.GetBlobHeaderHash()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("%w: failed to hash the blob header: %v", ErrInvalidBlobInfo, err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,584 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultStatusPollInitialInterval = time.Second
+	defaultStatusPollMaxInterval     = 30 * time.Second
+	defaultStatusPollMultiplier      = 2
+	defaultUnhealthyThreshold        = 3
+	defaultUnhealthyCooldown         = 30 * time.Second
+
+	// maxTrackedRequests is the number of most recent request IDs for which the client remembers
+	// the endpoint that accepted the dispersal
+	maxTrackedRequests = 10000
+)
+
+var (
+	// ErrDispersalTimeout is returned by DisperseAndWait when the context is done before the blob reached the
+	// target status. The blob may still be confirmed later, so its status can be queried with the returned request ID.
+	ErrDispersalTimeout = errors.New("timed out waiting for the blob to reach the target status")
+	// ErrDispersalFailed is returned by DisperseAndWait when the blob reached a terminal status other than the target,
+	// i.e. FAILED or INSUFFICIENT_SIGNATURES. The blob will not be confirmed and needs to be dispersed again.
+	ErrDispersalFailed = errors.New("blob dispersal failed")
+)
+
+type DisperserClientConfig struct {
+	// Hostname and Port are the address of the primary disperser
+	Hostname string
+	Port     string
+	// BackupEndpoints are the addresses (host:port) of the dispersers that dispersals fail over to when the
+	// primary is unavailable, in order of preference
+	BackupEndpoints   []string
+	UseSecureGrpcFlag bool
+	// Timeout is the timeout of each RPC to the disperser
+	Timeout time.Duration
+	// StatusPollInitialInterval is the delay before the first status query of DisperseAndWait.
+	// The delay is multiplied by StatusPollMultiplier after every query that doesn't return the target status,
+	// up to StatusPollMaxInterval.
+	StatusPollInitialInterval time.Duration
+	StatusPollMaxInterval     time.Duration
+	// StatusPollMultiplier defaults to 2 if it is less than 1. With 1, the status is polled at a fixed interval.
+	StatusPollMultiplier float64
+	// StatusPollJitter randomizes each delay by up to this fraction of it, in either direction, so that clients
+	// dispersing at the same time don't poll in lockstep. It must be in [0, 1]; 0 disables the jitter.
+	StatusPollJitter float64
+	// StatusHedgeDelay is the delay after which a status query that hasn't returned yet is sent a second time.
+	// Hedging is disabled if it is 0.
+	StatusHedgeDelay time.Duration
+	// UnhealthyThreshold is the number of consecutive failures to reach an endpoint after which it is considered
+	// unhealthy. Dispersals are sent to the healthy endpoints first. Defaults to 3.
+	UnhealthyThreshold int
+	// UnhealthyCooldown is the time after the last failure of an unhealthy endpoint after which dispersals are
+	// sent to it first again, so that the client goes back to the primary once it recovers. A failure restarts the
+	// cooldown. Defaults to 30s.
+	UnhealthyCooldown time.Duration
+	// GRPCOptions are the options of the connections to the dispersers. The connections use TLS if
+	// UseSecureGrpcFlag is set and the options don't set other credentials.
+	GRPCOptions *common.GRPCClientOptions
+}
+
+// DispersalResult is the outcome of DisperseAndWait
+type DispersalResult struct {
+	// BlobInfo is the BlobInfo of the blob once it reached the target status
+	BlobInfo *disperser_rpc.BlobInfo
+	// RequestID is set whenever the blob was accepted by the disperser
+	RequestID []byte
+	// NumPolls is the number of status queries, including the failed ones
+	NumPolls int
+	// TotalWait is the time spent waiting between the status queries
+	TotalWait time.Duration
+}
+
+// DispersalProgressCallback is called by DisperseAndWait once the blob is accepted by the disperser, and then
+// every time its status changes
+type DispersalProgressCallback func(requestID []byte, status disperser_rpc.BlobStatus)
+
+// DisperserClient is a client of one or more dispersers.
+// A request ID is only known to the disperser that accepted the dispersal, so the client remembers which endpoint
+// accepted each request ID and sends its status queries there. The status of a request ID that wasn't dispersed
+// through the client is queried on all the endpoints.
+type DisperserClient interface {
+	// DisperseBlob disperses the blob to the first healthy endpoint, failing over to the next endpoint if it is
+	// unavailable or doesn't reply in time
+	DisperseBlob(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (disperser_rpc.BlobStatus, []byte, error)
+	// DisperseBlobAuthenticated is like DisperseBlob, except that the dispersal is authenticated with the signer of
+	// the client, which must be set with WithSigner. See DisperseBlobAuthenticated for the errors it returns.
+	DisperseBlobAuthenticated(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (disperser_rpc.BlobStatus, []byte, error)
+	GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error)
+	// DisperseAndWait disperses the blob and polls its status until it reaches the target status, which must be
+	// CONFIRMED or FINALIZED, and returns the BlobInfo used to retrieve the blob or verify it onchain.
+	// The result is returned along with any error, and holds the request ID whenever the blob was accepted by the
+	// disperser, including with ErrDispersalTimeout and ErrDispersalFailed, and ErrCommitmentMismatch if the client
+	// checks the commitment. The dispersal is authenticated if the client has a signer. Failed status queries are
+	// retried if the error is transient, e.g. Unavailable, and returned otherwise, e.g. InvalidArgument or NotFound.
+	// The onProgress callback is optional.
+	DisperseAndWait(ctx context.Context, data []byte, securityParams []*core.SecurityParam, targetStatus disperser_rpc.BlobStatus, onProgress DispersalProgressCallback) (*DispersalResult, error)
+	// Close closes the connections to the dispersers
+	Close() error
+}
+
+// disperserEndpoint is a disperser along with the state of its connection
+type disperserEndpoint struct {
+	address string
+
+	mu                  sync.Mutex
+	conn                *grpc.ClientConn
+	consecutiveFailures int
+	lastFailure         time.Time
+}
+
+type disperserClient struct {
+	config      *DisperserClientConfig
+	dialOptions []grpc.DialOption
+	metrics     *DisperserClientMetrics
+	// endpoints holds the primary endpoint followed by the backup endpoints
+	endpoints []*disperserEndpoint
+	// requestEndpoints maps the request IDs to the endpoints that accepted the dispersals
+	requestEndpoints *lru.Cache[string, *disperserEndpoint]
+	// committer computes the commitments that DisperseAndWait checks the disperser's against, if set
+	committer *BlobCommitter
+	// signer authenticates the dispersals of DisperseAndWait and DisperseBlobAuthenticated, if set
+	signer auth.BlobRequestSigner
+	// collector records the RPCs to the dispersers
+	collector MetricsCollector
+}
+
+var _ DisperserClient = (*disperserClient)(nil)
+
+// DisperserClientOption configures optional behavior of the disperser client
+type DisperserClientOption func(*disperserClient)
+
+// WithCommitmentCheck makes DisperseAndWait compute the commitment of the blob locally before the dispersal, and
+// fail with ErrCommitmentMismatch if the blob header returned by the disperser has a different commitment or length
+func WithCommitmentCheck(committer *BlobCommitter) DisperserClientOption {
+	return func(c *disperserClient) {
+		c.committer = committer
+	}
+}
+
+// WithSigner authenticates the dispersals of DisperseAndWait with the signer, and enables DisperseBlobAuthenticated
+func WithSigner(signer auth.BlobRequestSigner) DisperserClientOption {
+	return func(c *disperserClient) {
+		c.signer = signer
+	}
+}
+
+// WithDisperserMetricsCollector records the RPCs to the dispersers with the collector
+func WithDisperserMetricsCollector(collector MetricsCollector) DisperserClientOption {
+	return func(c *disperserClient) {
+		c.collector = collector
+	}
+}
+
+// NewDisperserClient creates a client of the disperser. The status polling intervals default to
+// defaultStatusPollInitialInterval and defaultStatusPollMaxInterval if they are not set.
+// The metrics are optional.
+func NewDisperserClient(config *DisperserClientConfig, metrics *DisperserClientMetrics, opts ...DisperserClientOption) (DisperserClient, error) {
+	cfg := *config
+	if cfg.StatusPollJitter < 0 || cfg.StatusPollJitter > 1 {
+		return nil, fmt.Errorf("invalid status poll jitter %v: must be in [0, 1]", cfg.StatusPollJitter)
+	}
+	if cfg.StatusPollInitialInterval <= 0 {
+		cfg.StatusPollInitialInterval = defaultStatusPollInitialInterval
+	}
+	if cfg.StatusPollMaxInterval < cfg.StatusPollInitialInterval {
+		cfg.StatusPollMaxInterval = max(defaultStatusPollMaxInterval, cfg.StatusPollInitialInterval)
+	}
+	if cfg.StatusPollMultiplier < 1 {
+		cfg.StatusPollMultiplier = defaultStatusPollMultiplier
+	}
+	if cfg.UnhealthyThreshold <= 0 {
+		cfg.UnhealthyThreshold = defaultUnhealthyThreshold
+	}
+	if cfg.UnhealthyCooldown <= 0 {
+		cfg.UnhealthyCooldown = defaultUnhealthyCooldown
+	}
+
+	requestEndpoints, err := lru.New[string, *disperserEndpoint](maxTrackedRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := append([]string{net.JoinHostPort(cfg.Hostname, cfg.Port)}, cfg.BackupEndpoints...)
+	endpoints := make([]*disperserEndpoint, len(addresses))
+	for i, address := range addresses {
+		endpoints[i] = &disperserEndpoint{address: address}
+		metrics.setEndpointHealthy(address, true)
+	}
+
+	var defaultGRPCOptions common.GRPCClientOptions
+	if cfg.UseSecureGrpcFlag {
+		defaultGRPCOptions.TransportCredentials = credentials.NewTLS(&tls.Config{})
+	}
+	grpcOptions := cfg.GRPCOptions.WithDefaults(defaultGRPCOptions)
+
+	c := &disperserClient{
+		config:           &cfg,
+		dialOptions:      grpcOptions.DialOptions(),
+		metrics:          metrics,
+		endpoints:        endpoints,
+		requestEndpoints: requestEndpoints,
+		collector:        noopMetricsCollector{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+func (c *disperserClient) Close() error {
+	var errs []error
+	for _, endpoint := range c.endpoints {
+		endpoint.mu.Lock()
+		if endpoint.conn != nil {
+			errs = append(errs, endpoint.conn.Close())
+			endpoint.conn = nil
+		}
+		endpoint.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// client returns the client of the endpoint, dialing it when it is first used. The connection is kept open
+// and reconnects by itself when the disperser becomes reachable again.
+func (c *disperserClient) client(endpoint *disperserEndpoint) (disperser_rpc.DisperserClient, error) {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+	if endpoint.conn == nil {
+		conn, err := grpc.Dial(endpoint.address, withRPCMetrics(c.dialOptions, DisperserClientName, endpoint.address, c.collector)...)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.conn = conn
+	}
+	return disperser_rpc.NewDisperserClient(endpoint.conn), nil
+}
+
+// recordResult updates the health of the endpoint with the result of a request. Only the failures to reach the
+// endpoint count against its health, since any other error means the disperser is up.
+func (c *disperserClient) recordResult(endpoint *disperserEndpoint, method string, err error) {
+	c.metrics.observeRequest(endpoint.address, method, err)
+
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+	if err != nil && isFailoverError(err) {
+		endpoint.consecutiveFailures++
+		endpoint.lastFailure = time.Now()
+	} else {
+		endpoint.consecutiveFailures = 0
+	}
+	c.metrics.setEndpointHealthy(endpoint.address, endpoint.consecutiveFailures < c.config.UnhealthyThreshold)
+}
+
+// isHealthy tells whether the endpoint is healthy, or unhealthy for longer than the cooldown and so worth a try
+func (c *disperserClient) isHealthy(endpoint *disperserEndpoint) bool {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+	return endpoint.consecutiveFailures < c.config.UnhealthyThreshold || time.Since(endpoint.lastFailure) >= c.config.UnhealthyCooldown
+}
+
+// endpointsByHealth returns the healthy endpoints followed by the unhealthy ones, each in order of preference.
+// The unhealthy endpoints past their cooldown are tried along with the healthy ones, to find out if they recovered.
+func (c *disperserClient) endpointsByHealth() []*disperserEndpoint {
+	healthy := make([]*disperserEndpoint, 0, len(c.endpoints))
+	unhealthy := make([]*disperserEndpoint, 0)
+	for _, endpoint := range c.endpoints {
+		if c.isHealthy(endpoint) {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (disperser_rpc.BlobStatus, []byte, error) {
+	return c.disperse(ctx, data, securityParams, c.disperseBlob)
+}
+
+func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (disperser_rpc.BlobStatus, []byte, error) {
+	if c.signer == nil {
+		return disperser_rpc.BlobStatus_UNKNOWN, nil, errors.New("authenticated dispersal requires a signer")
+	}
+	return c.disperse(ctx, data, securityParams, c.disperseBlobAuthenticated)
+}
+
+type disperseFunc func(ctx context.Context, endpoint *disperserEndpoint, request *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error)
+
+// disperse sends the dispersal to the first healthy endpoint, failing over to the next endpoints
+func (c *disperserClient) disperse(ctx context.Context, data []byte, securityParams []*core.SecurityParam, send disperseFunc) (disperser_rpc.BlobStatus, []byte, error) {
+	request := &disperser_rpc.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: make([]*disperser_rpc.SecurityParams, len(securityParams)),
+	}
+	for i, param := range securityParams {
+		request.SecurityParams[i] = &disperser_rpc.SecurityParams{
+			QuorumId:           uint32(param.QuorumID),
+			AdversaryThreshold: uint32(param.AdversaryThreshold),
+			QuorumThreshold:    uint32(param.QuorumThreshold),
+		}
+	}
+
+	var err error
+	for _, endpoint := range c.endpointsByHealth() {
+		var reply *disperser_rpc.DisperseBlobReply
+		reply, err = send(ctx, endpoint, request)
+		if err == nil {
+			c.requestEndpoints.Add(string(reply.GetRequestId()), endpoint)
+			return reply.GetResult(), reply.GetRequestId(), nil
+		}
+		if !isFailoverError(err) || ctx.Err() != nil {
+			break
+		}
+		c.metrics.incrementFailovers(endpoint.address)
+	}
+
+	return disperser_rpc.BlobStatus_UNKNOWN, nil, err
+}
+
+func (c *disperserClient) disperseBlob(ctx context.Context, endpoint *disperserEndpoint, request *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	client, err := c.client(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	reply, err := client.DisperseBlob(ctxTimeout, request)
+	c.recordResult(endpoint, "DisperseBlob", err)
+	return reply, err
+}
+
+func (c *disperserClient) disperseBlobAuthenticated(ctx context.Context, endpoint *disperserEndpoint, request *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	client, err := c.client(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	reply, err := DisperseBlobAuthenticated(ctxTimeout, client, c.signer, request)
+	c.recordResult(endpoint, "DisperseBlobAuthenticated", err)
+	return reply, err
+}
+
+func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	if endpoint, ok := c.requestEndpoints.Get(string(requestID)); ok {
+		return c.getBlobStatusHedged(ctx, endpoint, requestID)
+	}
+	return c.getBlobStatusFromAny(ctx, requestID)
+}
+
+type blobStatusResult struct {
+	reply *disperser_rpc.BlobStatusReply
+	err   error
+}
+
+// getBlobStatusHedged queries the status of the request on the endpoint, and sends the query a second time if the
+// first one hasn't returned after StatusHedgeDelay. The first successful reply is returned.
+func (c *disperserClient) getBlobStatusHedged(ctx context.Context, endpoint *disperserEndpoint, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	if c.config.StatusHedgeDelay <= 0 {
+		return c.getBlobStatus(ctx, endpoint, requestID)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan blobStatusResult, 2)
+	query := func() {
+		reply, err := c.getBlobStatus(ctx, endpoint, requestID)
+		results <- blobStatusResult{reply: reply, err: err}
+	}
+	go query()
+
+	hedgeTimer := time.NewTimer(c.config.StatusHedgeDelay)
+	defer hedgeTimer.Stop()
+	hedged := false
+	pending := 1
+	var err error
+	for pending > 0 {
+		select {
+		case <-hedgeTimer.C:
+			hedged = true
+			pending++
+			c.metrics.incrementHedgedRequests(endpoint.address)
+			go query()
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				return result.reply, nil
+			}
+			err = result.err
+			// The query failed before it was considered slow, so there is no point in sending it again
+			if !hedged {
+				return nil, err
+			}
+		}
+	}
+	return nil, err
+}
+
+// getBlobStatusFromAny queries the status of the request on all the endpoints and returns the first successful reply.
+// If all the queries fail, the error of the most preferred endpoint is returned.
+func (c *disperserClient) getBlobStatusFromAny(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type indexedResult struct {
+		index int
+		blobStatusResult
+	}
+	results := make(chan indexedResult, len(c.endpoints))
+	for i, endpoint := range c.endpoints {
+		go func(i int, endpoint *disperserEndpoint) {
+			reply, err := c.getBlobStatus(ctx, endpoint, requestID)
+			results <- indexedResult{index: i, blobStatusResult: blobStatusResult{reply: reply, err: err}}
+		}(i, endpoint)
+	}
+
+	errs := make([]error, len(c.endpoints))
+	for range c.endpoints {
+		result := <-results
+		if result.err == nil {
+			return result.reply, nil
+		}
+		errs[result.index] = result.err
+	}
+	return nil, errs[0]
+}
+
+func (c *disperserClient) getBlobStatus(ctx context.Context, endpoint *disperserEndpoint, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	client, err := c.client(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	reply, err := client.GetBlobStatus(ctxTimeout, &disperser_rpc.BlobStatusRequest{
+		RequestId: requestID,
+	})
+	// Hedged queries that are cancelled once another one succeeded say nothing about the health of the endpoint
+	if status.Code(err) != codes.Canceled {
+		c.recordResult(endpoint, "GetBlobStatus", err)
+	}
+	return reply, err
+}
+
+func (c *disperserClient) DisperseAndWait(ctx context.Context, data []byte, securityParams []*core.SecurityParam, targetStatus disperser_rpc.BlobStatus, onProgress DispersalProgressCallback) (*DispersalResult, error) {
+	result := &DispersalResult{}
+	if targetStatus != disperser_rpc.BlobStatus_CONFIRMED && targetStatus != disperser_rpc.BlobStatus_FINALIZED {
+		return result, fmt.Errorf("invalid target status %s: must be %s or %s", targetStatus, disperser_rpc.BlobStatus_CONFIRMED, disperser_rpc.BlobStatus_FINALIZED)
+	}
+	if onProgress == nil {
+		onProgress = func([]byte, disperser_rpc.BlobStatus) {}
+	}
+
+	var commitment *core.Commitment
+	var length uint
+	if c.committer != nil {
+		var err error
+		commitment, length, err = c.committer.ComputeCommitment(data)
+		if err != nil {
+			return result, fmt.Errorf("failed to compute blob commitment: %w", err)
+		}
+	}
+	disperse := c.DisperseBlob
+	if c.signer != nil {
+		disperse = c.DisperseBlobAuthenticated
+	}
+	lastStatus, requestID, err := disperse(ctx, data, securityParams)
+	if err != nil {
+		return result, fmt.Errorf("failed to disperse blob: %w", err)
+	}
+	result.RequestID = requestID
+	onProgress(requestID, lastStatus)
+
+	// confirmed returns the result with the BlobInfo of the confirmed blob once its commitment is checked
+	confirmed := func(info *disperser_rpc.BlobInfo) (*DispersalResult, error) {
+		if commitment != nil {
+			if err := VerifyBlobCommitment(info.GetBlobHeader(), commitment, length); err != nil {
+				return result, err
+			}
+		}
+		result.BlobInfo = info
+		return result, nil
+	}
+
+	interval := c.config.StatusPollInitialInterval
+	timer := time.NewTimer(c.jitter(interval))
+	defer timer.Stop()
+	for {
+		waitStart := time.Now()
+		select {
+		case <-ctx.Done():
+			result.TotalWait += time.Since(waitStart)
+			return result, fmt.Errorf("%w (last status %s): %w", ErrDispersalTimeout, lastStatus, ctx.Err())
+		case <-timer.C:
+		}
+		result.TotalWait += time.Since(waitStart)
+
+		result.NumPolls++
+		reply, err := c.GetBlobStatus(ctx, requestID)
+		if err != nil {
+			// The query is retried if the error is transient, and a query interrupted by the context is
+			// reported as a timeout on the next iteration
+			if ctx.Err() == nil && !isTransientError(err) {
+				return result, fmt.Errorf("failed to get blob status: %w", err)
+			}
+		} else {
+			if reply.GetStatus() != lastStatus {
+				lastStatus = reply.GetStatus()
+				onProgress(requestID, lastStatus)
+			}
+
+			switch lastStatus {
+			case disperser_rpc.BlobStatus_FINALIZED:
+				return confirmed(reply.GetInfo())
+			case disperser_rpc.BlobStatus_CONFIRMED:
+				if targetStatus == disperser_rpc.BlobStatus_CONFIRMED {
+					return confirmed(reply.GetInfo())
+				}
+			case disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+				return result, fmt.Errorf("%w: blob status %s", ErrDispersalFailed, lastStatus)
+			}
+		}
+
+		interval = time.Duration(float64(interval) * c.config.StatusPollMultiplier)
+		if interval > c.config.StatusPollMaxInterval {
+			interval = c.config.StatusPollMaxInterval
+		}
+		timer.Reset(c.jitter(interval))
+	}
+}
+
+// jitter returns the interval randomized by up to StatusPollJitter of it in either direction
+func (c *disperserClient) jitter(interval time.Duration) time.Duration {
+	if c.config.StatusPollJitter == 0 {
+		return interval
+	}
+	return time.Duration(float64(interval) * (1 + c.config.StatusPollJitter*(2*rand.Float64()-1)))
+}
+
+// isTransientError returns whether a failed status query should be retried. A query that timed out is retried,
+// since it says nothing about the dispersal.
+func isTransientError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// isFailoverError returns whether a failed dispersal should be sent to the next endpoint, i.e. whether the
+// endpoint couldn't be reached or didn't reply in time
+func isFailoverError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
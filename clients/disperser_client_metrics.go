@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DisperserClientMetrics are the metrics of the disperser client, labeled by the endpoint address
+type DisperserClientMetrics struct {
+	NumRequests       *prometheus.CounterVec
+	NumFailovers      *prometheus.CounterVec
+	NumHedgedRequests *prometheus.CounterVec
+	EndpointHealthy   *prometheus.GaugeVec
+}
+
+func NewDisperserClientMetrics(reg *prometheus.Registry) *DisperserClientMetrics {
+	namespace := "eigenda_disperser_client"
+	return &DisperserClientMetrics{
+		NumRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests",
+				Help:      "the number of requests to the disperser",
+			},
+			[]string{"endpoint", "method", "status"},
+		),
+		NumFailovers: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "failovers",
+				Help:      "the number of dispersals that failed over from the endpoint to the next one",
+			},
+			[]string{"endpoint"},
+		),
+		NumHedgedRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "hedged_requests",
+				Help:      "the number of status queries that were sent again because the first one was slow",
+			},
+			[]string{"endpoint"},
+		),
+		EndpointHealthy: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "endpoint_healthy",
+				Help:      "whether the endpoint is considered healthy (1) or not (0)",
+			},
+			[]string{"endpoint"},
+		),
+	}
+}
+
+// The methods below are no-ops on a nil receiver, so that the client can be used without metrics
+
+func (m *DisperserClientMetrics) observeRequest(endpoint, method string, err error) {
+	if m == nil {
+		return
+	}
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	m.NumRequests.WithLabelValues(endpoint, method, status).Inc()
+}
+
+func (m *DisperserClientMetrics) incrementFailovers(endpoint string) {
+	if m == nil {
+		return
+	}
+	m.NumFailovers.WithLabelValues(endpoint).Inc()
+}
+
+func (m *DisperserClientMetrics) incrementHedgedRequests(endpoint string) {
+	if m == nil {
+		return
+	}
+	m.NumHedgedRequests.WithLabelValues(endpoint).Inc()
+}
+
+func (m *DisperserClientMetrics) setEndpointHealthy(endpoint string, healthy bool) {
+	if m == nil {
+		return
+	}
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	m.EndpointHealthy.WithLabelValues(endpoint).Set(value)
+}
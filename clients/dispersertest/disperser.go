@@ -0,0 +1,479 @@
+// Package dispersertest provides an in-memory implementation of the disperser gRPC service for the tests of the
+// code built on the disperser clients, in the spirit of net/http/httptest.
+//
+// The Disperser accepts blobs, walks them through PROCESSING, CONFIRMED and FINALIZED according to its Schedule,
+// serves the retrieval of the confirmed blobs, and supports the authenticated dispersal. Faults can be injected to
+// test how the clients handle an unreliable disperser. It can be served on a real port or on a bufconn listener.
+package dispersertest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxBlobSize is the maximum blob size accepted by the disperser
+	maxBlobSize = 512 * 1024
+	bufconnSize = 1024 * 1024
+)
+
+// Schedule is how the status of the blobs advances. The status advances at most one step per status query, once
+// both the delay and the number of queries of the current status are reached. The zero Schedule confirms the blobs
+// at the first status query and finalizes them at the second one.
+type Schedule struct {
+	// ConfirmationDelay is the time after the dispersal before which the blob is not confirmed
+	ConfirmationDelay time.Duration
+	// ProcessingPolls is the number of status queries answered with PROCESSING before the blob is confirmed
+	ProcessingPolls int
+	// FinalizationDelay is the time after the confirmation before which the blob is not finalized
+	FinalizationDelay time.Duration
+	// ConfirmedPolls is the number of status queries answered with CONFIRMED before the blob is finalized
+	ConfirmedPolls int
+	// NeverFinalize keeps the blobs CONFIRMED
+	NeverFinalize bool
+	// FailWith is the terminal status, FAILED or INSUFFICIENT_SIGNATURES, that the blobs reach instead of
+	// CONFIRMED. The blobs are confirmed if it is UNKNOWN.
+	FailWith disperser_rpc.BlobStatus
+}
+
+// StatusFault is the fault injected in a status query: the query takes Delay to return, and fails with Err if it
+// is set. A query that the client gives up on before Delay elapses fails with the error of its context.
+// The zero StatusFault lets the query through.
+type StatusFault struct {
+	Delay time.Duration
+	Err   error
+}
+
+type Config struct {
+	Schedule Schedule
+	// Committer computes the commitments of the blob headers, which are left empty if it is nil
+	Committer *clients.BlobCommitter
+	// Accounts are the account IDs allowed to use DisperseBlobAuthenticated, all of them if it is nil
+	Accounts []string
+}
+
+type blob struct {
+	requestID   []byte
+	request     *disperser_rpc.DisperseBlobRequest
+	status      disperser_rpc.BlobStatus
+	dispersedAt time.Time
+	confirmedAt time.Time
+	// polls is the number of status queries answered with the current status
+	polls int
+	info  *disperser_rpc.BlobInfo
+}
+
+// Disperser is an in-memory disperser. Its methods are safe for concurrent use.
+type Disperser struct {
+	disperser_rpc.UnimplementedDisperserServer
+
+	committer *clients.BlobCommitter
+	accounts  map[string]bool
+
+	mu       sync.Mutex
+	schedule Schedule
+	// blobs are the accepted blobs in order of dispersal
+	blobs         []*blob
+	blobsByID     map[string]*blob
+	numBatches    uint32
+	numChallenges uint32
+	statusQueries int
+
+	// The injected faults
+	rejections         []error
+	statusFaults       []StatusFault
+	dropFraction       float64
+	confirmationDelays time.Duration
+	tamper             func(info *disperser_rpc.BlobInfo)
+	rand               *rand.Rand
+
+	server *grpc.Server
+}
+
+var _ disperser_rpc.DisperserServer = (*Disperser)(nil)
+
+func NewDisperser(config Config) *Disperser {
+	d := &Disperser{
+		committer: config.Committer,
+		schedule:  config.Schedule,
+		blobsByID: make(map[string]*blob),
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if config.Accounts != nil {
+		d.accounts = make(map[string]bool)
+		for _, account := range config.Accounts {
+			d.accounts[account] = true
+		}
+	}
+	return d
+}
+
+// Start serves the disperser on the address, which picks a free port if it is "127.0.0.1:0", and returns the
+// address being served. The disperser can be started again after it is stopped, e.g. on the same address to
+// simulate a restart, and keeps its blobs.
+func (d *Disperser) Start(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	d.Serve(listener)
+	return listener.Addr().String(), nil
+}
+
+// StartBufconn serves the disperser in memory and returns the options connecting the clients to it. The
+// address of the clients is ignored.
+func (d *Disperser) StartBufconn() *common.GRPCClientOptions {
+	listener := bufconn.Listen(bufconnSize)
+	d.Serve(listener)
+	return &common.GRPCClientOptions{
+		ContextDialer: func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		},
+	}
+}
+
+// Serve serves the disperser on the listener in the background
+func (d *Disperser) Serve(listener net.Listener) {
+	server := grpc.NewServer()
+	disperser_rpc.RegisterDisperserServer(server, d)
+	d.mu.Lock()
+	d.server = server
+	d.mu.Unlock()
+	go func() { _ = server.Serve(listener) }()
+}
+
+// Stop stops serving the disperser and closes the connections of the clients
+func (d *Disperser) Stop() {
+	d.mu.Lock()
+	server := d.server
+	d.server = nil
+	d.mu.Unlock()
+	if server != nil {
+		server.Stop()
+	}
+}
+
+// SetSchedule changes the schedule of all the blobs, including the ones already dispersed
+func (d *Disperser) SetSchedule(schedule Schedule) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.schedule = schedule
+}
+
+// RejectNextDispersals makes the next n dispersals fail with err, or with Unavailable if err is nil
+func (d *Disperser) RejectNextDispersals(n int, err error) {
+	if err == nil {
+		err = status.Error(codes.Unavailable, "dispersal rejected")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := 0; i < n; i++ {
+		d.rejections = append(d.rejections, err)
+	}
+}
+
+// DelayConfirmations postpones the confirmation of the blobs that are not confirmed yet, including the ones
+// dispersed later, by the delay
+func (d *Disperser) DelayConfirmations(delay time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.confirmationDelays += delay
+}
+
+// InjectStatusFaults injects the faults in the next status queries, in order
+func (d *Disperser) InjectStatusFaults(faults ...StatusFault) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.statusFaults = append(d.statusFaults, faults...)
+}
+
+// DropStatusPolls makes a random fraction of the status queries fail with Unavailable
+func (d *Disperser) DropStatusPolls(fraction float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dropFraction = fraction
+}
+
+// TamperBlobInfo makes the disperser apply tamper to the BlobInfo in its replies, e.g. to return a commitment
+// that doesn't match the blob
+func (d *Disperser) TamperBlobInfo(tamper func(info *disperser_rpc.BlobInfo)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tamper = tamper
+}
+
+// Requests returns the accepted dispersals, in order
+func (d *Disperser) Requests() []*disperser_rpc.DisperseBlobRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	requests := make([]*disperser_rpc.DisperseBlobRequest, len(d.blobs))
+	for i, b := range d.blobs {
+		requests[i] = b.request
+	}
+	return requests
+}
+
+// RequestIDs returns the request IDs of the accepted dispersals, in order
+func (d *Disperser) RequestIDs() [][]byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ids := make([][]byte, len(d.blobs))
+	for i, b := range d.blobs {
+		ids[i] = b.requestID
+	}
+	return ids
+}
+
+// NumChallenges returns the number of challenges sent to the clients of DisperseBlobAuthenticated
+func (d *Disperser) NumChallenges() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return int(d.numChallenges)
+}
+
+// NumStatusQueries returns the number of status queries received, including the failed ones
+func (d *Disperser) NumStatusQueries() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.statusQueries
+}
+
+func (d *Disperser) DisperseBlob(ctx context.Context, request *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.rejections) > 0 {
+		err := d.rejections[0]
+		d.rejections = d.rejections[1:]
+		return nil, err
+	}
+
+	// The request IDs are unique, like the ones of the disperser
+	requestID := sha256.Sum256(binary.BigEndian.AppendUint64(append([]byte{}, request.GetData()...), uint64(len(d.blobs))))
+	b := &blob{
+		requestID:   requestID[:],
+		request:     request,
+		status:      disperser_rpc.BlobStatus_PROCESSING,
+		dispersedAt: time.Now(),
+	}
+	d.blobs = append(d.blobs, b)
+	d.blobsByID[string(b.requestID)] = b
+	return &disperser_rpc.DisperseBlobReply{
+		Result:    b.status,
+		RequestId: b.requestID,
+	}, nil
+}
+
+func validateRequest(request *disperser_rpc.DisperseBlobRequest) error {
+	if len(request.GetSecurityParams()) == 0 {
+		return status.Error(codes.InvalidArgument, "invalid request: security_params must not be empty")
+	}
+	if len(request.GetData()) > maxBlobSize {
+		return status.Error(codes.InvalidArgument, "blob size cannot exceed 512 KiB")
+	}
+	if len(request.GetData()) == 0 {
+		return status.Error(codes.InvalidArgument, "blob size must be greater than 0")
+	}
+	return nil
+}
+
+// DisperseBlobAuthenticated sends a new challenge for every dispersal, and accepts the dispersal once the signature
+// of the challenge is verified
+func (d *Disperser) DisperseBlobAuthenticated(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	request := in.GetDisperseRequest()
+	if request == nil {
+		return status.Error(codes.InvalidArgument, "expected a DisperseBlobRequest")
+	}
+	if d.accounts != nil && !d.accounts[request.GetAccountId()] {
+		return status.Errorf(codes.PermissionDenied, "account %s is not allowed to disperse", request.GetAccountId())
+	}
+
+	d.mu.Lock()
+	d.numChallenges++
+	challenge := d.numChallenges
+	d.mu.Unlock()
+	err = stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_BlobAuthHeader{
+		BlobAuthHeader: &disperser_rpc.BlobAuthHeader{ChallengeParameter: challenge},
+	}})
+	if err != nil {
+		return err
+	}
+
+	in, err = stream.Recv()
+	if err != nil {
+		return err
+	}
+	signature := in.GetAuthenticationData().GetAuthenticationData()
+	if err := auth.VerifyBlobRequestSignature(request.GetAccountId(), challenge, request.GetData(), signature); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	reply, err := d.DisperseBlob(stream.Context(), request)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_DisperseReply{DisperseReply: reply}})
+}
+
+func (d *Disperser) GetBlobStatus(ctx context.Context, request *disperser_rpc.BlobStatusRequest) (*disperser_rpc.BlobStatusReply, error) {
+	d.mu.Lock()
+	d.statusQueries++
+	b, ok := d.blobsByID[string(request.GetRequestId())]
+	if !ok {
+		d.mu.Unlock()
+		return nil, status.Error(codes.NotFound, "unknown request ID")
+	}
+	var fault StatusFault
+	if len(d.statusFaults) > 0 {
+		fault = d.statusFaults[0]
+		d.statusFaults = d.statusFaults[1:]
+	}
+	dropped := d.dropFraction > 0 && d.rand.Float64() < d.dropFraction
+	d.mu.Unlock()
+
+	if fault.Delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(fault.Delay):
+		}
+	}
+	if fault.Err != nil {
+		return nil, fault.Err
+	}
+	if dropped {
+		return nil, status.Error(codes.Unavailable, "status query dropped")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.advance(b, time.Now()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &disperser_rpc.BlobStatusReply{Status: b.status}
+	if b.info != nil {
+		reply.Info = d.tamperedInfo(b.info)
+	}
+	return reply, nil
+}
+
+// advance moves the blob to its next status if the schedule allows it, and counts the query otherwise. The blob
+// stays PROCESSING if it can't be confirmed.
+func (d *Disperser) advance(b *blob, now time.Time) error {
+	switch b.status {
+	case disperser_rpc.BlobStatus_PROCESSING:
+		if b.polls < d.schedule.ProcessingPolls || now.Before(b.dispersedAt.Add(d.schedule.ConfirmationDelay+d.confirmationDelays)) {
+			b.polls++
+			return nil
+		}
+		b.polls = 0
+		if d.schedule.FailWith != disperser_rpc.BlobStatus_UNKNOWN {
+			b.status = d.schedule.FailWith
+			return nil
+		}
+		info, err := d.confirm(b)
+		if err != nil {
+			return err
+		}
+		b.status = disperser_rpc.BlobStatus_CONFIRMED
+		b.confirmedAt = now
+		b.info = info
+	case disperser_rpc.BlobStatus_CONFIRMED:
+		if d.schedule.NeverFinalize || b.polls < d.schedule.ConfirmedPolls || now.Before(b.confirmedAt.Add(d.schedule.FinalizationDelay)) {
+			b.polls++
+			return nil
+		}
+		b.polls = 0
+		b.status = disperser_rpc.BlobStatus_FINALIZED
+	}
+	return nil
+}
+
+// confirm returns the BlobInfo of the blob, which is confirmed in a batch of its own
+func (d *Disperser) confirm(b *blob) (*disperser_rpc.BlobInfo, error) {
+	header := &disperser_rpc.BlobHeader{
+		DataLength: uint32((len(b.request.GetData()) + bn254.BYTES_PER_COEFFICIENT - 1) / bn254.BYTES_PER_COEFFICIENT),
+	}
+	if d.committer != nil {
+		// The blob was short enough for the committer when it was accepted by a real disperser, so the
+		// commitment can only fail to be computed if the committer has too few points for the test
+		commitment, length, err := d.committer.ComputeCommitment(b.request.GetData())
+		if err == nil {
+			header.Commitment, err = commitment.Serialize()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute the commitment of the blob: %w", err)
+		}
+		header.DataLength = uint32(length)
+	}
+	for _, param := range b.request.GetSecurityParams() {
+		header.BlobQuorumParams = append(header.BlobQuorumParams, &disperser_rpc.BlobQuorumParam{
+			QuorumNumber:                 param.GetQuorumId(),
+			AdversaryThresholdPercentage: param.GetAdversaryThreshold(),
+			QuorumThresholdPercentage:    param.GetQuorumThreshold(),
+		})
+	}
+
+	d.numBatches++
+	batchHeaderHash := sha256.Sum256(b.requestID)
+	return &disperser_rpc.BlobInfo{
+		BlobHeader: header,
+		BlobVerificationProof: &disperser_rpc.BlobVerificationProof{
+			BatchId:   d.numBatches,
+			BlobIndex: 0,
+			BatchMetadata: &disperser_rpc.BatchMetadata{
+				BatchHeaderHash: batchHeaderHash[:],
+			},
+		},
+	}, nil
+}
+
+// tamperedInfo returns a copy of the BlobInfo with the tampering applied
+func (d *Disperser) tamperedInfo(info *disperser_rpc.BlobInfo) *disperser_rpc.BlobInfo {
+	if d.tamper == nil {
+		return info
+	}
+	tampered := proto.Clone(info).(*disperser_rpc.BlobInfo)
+	d.tamper(tampered)
+	return tampered
+}
+
+// RetrieveBlob returns the blobs once they are confirmed
+func (d *Disperser) RetrieveBlob(ctx context.Context, request *disperser_rpc.RetrieveBlobRequest) (*disperser_rpc.RetrieveBlobReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, b := range d.blobs {
+		if b.info == nil {
+			continue
+		}
+		proof := b.info.GetBlobVerificationProof()
+		if string(proof.GetBatchMetadata().GetBatchHeaderHash()) == string(request.GetBatchHeaderHash()) && proof.GetBlobIndex() == request.GetBlobIndex() {
+			return &disperser_rpc.RetrieveBlobReply{Data: b.request.GetData()}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "no confirmed blob at this batch header hash and blob index")
+}
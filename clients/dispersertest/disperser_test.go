@@ -0,0 +1,113 @@
+package dispersertest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testSecurityParams = []*disperser_rpc.SecurityParams{{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}
+
+func dial(t *testing.T, server *dispersertest.Disperser) disperser_rpc.DisperserClient {
+	options := server.StartBufconn()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial("bufconn", options.DialOptions()...)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return disperser_rpc.NewDisperserClient(conn)
+}
+
+func getStatus(t *testing.T, stub disperser_rpc.DisperserClient, requestID []byte) *disperser_rpc.BlobStatusReply {
+	reply, err := stub.GetBlobStatus(context.Background(), &disperser_rpc.BlobStatusRequest{RequestId: requestID})
+	assert.NoError(t, err)
+	return reply
+}
+
+func TestDisperseAndRetrieve(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{ProcessingPolls: 1, ConfirmedPolls: 1}})
+	stub := dial(t, server)
+
+	reply, err := stub.DisperseBlob(context.Background(), &disperser_rpc.DisperseBlobRequest{Data: []byte("data"), SecurityParams: testSecurityParams})
+	assert.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING, reply.GetResult())
+	other, err := stub.DisperseBlob(context.Background(), &disperser_rpc.DisperseBlobRequest{Data: []byte("data"), SecurityParams: testSecurityParams})
+	assert.NoError(t, err)
+	assert.NotEqual(t, reply.GetRequestId(), other.GetRequestId())
+
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+	confirmed := getStatus(t, stub, reply.GetRequestId())
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, confirmed.GetStatus())
+	assert.Equal(t, uint32(1), confirmed.GetInfo().GetBlobHeader().GetDataLength())
+	assert.Equal(t, []*disperser_rpc.BlobQuorumParam{{QuorumNumber: 0, AdversaryThresholdPercentage: 50, QuorumThresholdPercentage: 100}}, confirmed.GetInfo().GetBlobHeader().GetBlobQuorumParams())
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+	assert.Equal(t, disperser_rpc.BlobStatus_FINALIZED, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+	assert.Equal(t, 4, server.NumStatusQueries())
+
+	proof := confirmed.GetInfo().GetBlobVerificationProof()
+	retrieved, err := stub.RetrieveBlob(context.Background(), &disperser_rpc.RetrieveBlobRequest{
+		BatchHeaderHash: proof.GetBatchMetadata().GetBatchHeaderHash(),
+		BlobIndex:       proof.GetBlobIndex(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), retrieved.GetData())
+
+	// The other blob isn't confirmed yet
+	_, err = stub.RetrieveBlob(context.Background(), &disperser_rpc.RetrieveBlobRequest{BatchHeaderHash: []byte("unknown")})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = stub.GetBlobStatus(context.Background(), &disperser_rpc.BlobStatusRequest{RequestId: []byte("unknown")})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestInvalidDispersals(t *testing.T) {
+	stub := dial(t, dispersertest.NewDisperser(dispersertest.Config{}))
+
+	for _, request := range []*disperser_rpc.DisperseBlobRequest{
+		{Data: []byte("data")},
+		{SecurityParams: testSecurityParams},
+		{Data: make([]byte, 512*1024+1), SecurityParams: testSecurityParams},
+	} {
+		_, err := stub.DisperseBlob(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestScheduleDelays(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{
+		ConfirmationDelay: 50 * time.Millisecond,
+		FinalizationDelay: 50 * time.Millisecond,
+	}})
+	stub := dial(t, server)
+	reply, err := stub.DisperseBlob(context.Background(), &disperser_rpc.DisperseBlobRequest{Data: []byte("data"), SecurityParams: testSecurityParams})
+	assert.NoError(t, err)
+
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, disperser_rpc.BlobStatus_FINALIZED, getStatus(t, stub, reply.GetRequestId()).GetStatus())
+}
+
+func TestCommitmentFailure(t *testing.T) {
+	// The committer has too few points to commit to the blob
+	committer, err := clients.NewBlobCommitter("../../inabox/resources/kzg/g1.point", 1)
+	assert.NoError(t, err)
+	stub := dial(t, dispersertest.NewDisperser(dispersertest.Config{Committer: committer}))
+	reply, err := stub.DisperseBlob(context.Background(), &disperser_rpc.DisperseBlobRequest{Data: make([]byte, 64), SecurityParams: testSecurityParams})
+	assert.NoError(t, err)
+
+	// The blob can't be confirmed, and stays PROCESSING
+	for i := 0; i < 2; i++ {
+		_, err = stub.GetBlobStatus(context.Background(), &disperser_rpc.BlobStatusRequest{RequestId: reply.GetRequestId()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.ErrorContains(t, err, "failed to compute the commitment of the blob")
+	}
+}
@@ -0,0 +1,128 @@
+package clients
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+)
+
+// operatorEndpoint is the endpoint of an operator that the retrievals connect to
+type operatorEndpoint struct {
+	// stateSocket is the socket of the operator in the operator state of the retrievals, and socket the fresh one
+	// they connect to instead. The fresh socket doesn't apply to the states with another socket, since they are
+	// more recent or older than the refresh.
+	stateSocket string
+	socket      string
+	// failures is the number of consecutive connection failures to the endpoint
+	failures    int
+	lastRefresh time.Time
+}
+
+// endpointRefresher re-fetches the socket of an operator from its current on-chain state after repeated
+// connection failures, e.g. when the operator moved since the reference block of the retrieval. The refreshes of
+// an operator are at least minInterval apart.
+type endpointRefresher struct {
+	mu        sync.Mutex
+	endpoints map[core.OperatorID]*operatorEndpoint
+
+	chainState       core.IndexedChainState
+	failureThreshold int
+	minInterval      time.Duration
+	logger           common.Logger
+}
+
+func newEndpointRefresher(chainState core.IndexedChainState, failureThreshold int, minInterval time.Duration, logger common.Logger) *endpointRefresher {
+	return &endpointRefresher{
+		endpoints:        make(map[core.OperatorID]*operatorEndpoint),
+		chainState:       chainState,
+		failureThreshold: failureThreshold,
+		minInterval:      minInterval,
+		logger:           logger,
+	}
+}
+
+// socket returns the socket to connect to the operator, whose socket in the operator state is stateSocket
+func (e *endpointRefresher) socket(opID core.OperatorID, stateSocket string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	endpoint, ok := e.endpoints[opID]
+	if !ok || endpoint.stateSocket != stateSocket || endpoint.socket == "" {
+		return stateSocket
+	}
+	return endpoint.socket
+}
+
+// connected records a successful connection to the operator
+func (e *endpointRefresher) connected(opID core.OperatorID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if endpoint, ok := e.endpoints[opID]; ok {
+		endpoint.failures = 0
+	}
+}
+
+// connectionFailed records a failure to connect to the operator at failedSocket. Once the failures reach the
+// threshold, it refreshes the socket of the operator, and returns the fresh socket if it changed.
+func (e *endpointRefresher) connectionFailed(ctx context.Context, opID core.OperatorID, quorumID core.QuorumID, stateSocket, failedSocket string) (string, bool) {
+	e.mu.Lock()
+	endpoint, ok := e.endpoints[opID]
+	if !ok {
+		endpoint = &operatorEndpoint{}
+		e.endpoints[opID] = endpoint
+	}
+	if endpoint.stateSocket != stateSocket {
+		endpoint.stateSocket = stateSocket
+		endpoint.socket = ""
+		endpoint.failures = 0
+	}
+	endpoint.failures++
+	if endpoint.failures < e.failureThreshold || time.Since(endpoint.lastRefresh) < e.minInterval {
+		e.mu.Unlock()
+		return "", false
+	}
+	// The refresh is recorded before the fetch so that the concurrent failures don't refresh the socket again
+	endpoint.lastRefresh = time.Now()
+	e.mu.Unlock()
+
+	logger := common.LoggerFromContext(ctx, e.logger)
+	operator := hex.EncodeToString(opID[:])
+	fresh, err := e.fetchSocket(ctx, opID, quorumID)
+	if err != nil {
+		logger.Warn("failed to refresh the endpoint of the operator", "operator", operator, "err", err)
+		return "", false
+	}
+	if fresh == failedSocket {
+		return "", false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	endpoint.socket = fresh
+	endpoint.failures = 0
+	logger.Info("operator endpoint changed", "operator", operator, "oldSocket", failedSocket, "newSocket", fresh)
+	return fresh, true
+}
+
+// fetchSocket returns the socket of the operator at the current block
+func (e *endpointRefresher) fetchSocket(ctx context.Context, opID core.OperatorID, quorumID core.QuorumID) (string, error) {
+	blockNumber, err := e.chainState.GetCurrentBlockNumber()
+	if err != nil {
+		return "", err
+	}
+	// The refresh looks for the current socket of the operator, so it bypasses the cache of the operator states
+	state, err := e.chainState.GetIndexedOperatorState(statecache.WithBypass(ctx), blockNumber, []core.QuorumID{quorumID})
+	if err != nil {
+		return "", err
+	}
+	opInfo, ok := state.IndexedOperators[opID]
+	if !ok {
+		return "", errors.New("operator is no longer registered")
+	}
+	return opInfo.Socket, nil
+}
@@ -0,0 +1,153 @@
+package clients
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// latencySmoothing is the weight of the last latency of an operator in its moving average
+const latencySmoothing = 0.2
+
+// DefaultLatencyExpiry is how long the latency of an operator counts after it was last observed, unless the
+// fan-out weights set another
+const DefaultLatencyExpiry = 10 * time.Minute
+
+// FanOutWeights are the weights of the stakes and of the latencies of the operators in the order in which they are
+// contacted for the chunks of a blob, see WithFanOutWeights
+type FanOutWeights struct {
+	Stake   float64
+	Latency float64
+	// LatencyExpiry is how long the latency of an operator counts after it was last observed, DefaultLatencyExpiry
+	// if 0. The operators whose latency expired count as not contacted yet, so that the slow operators that were
+	// then left out of the retrievals are sampled again.
+	LatencyExpiry time.Duration
+}
+
+// operatorLatencies are the moving averages of the latencies of the operators to return their chunks
+type operatorLatencies struct {
+	mu        sync.Mutex
+	expiry    time.Duration
+	latencies map[core.OperatorID]latencySample
+	// swept is when the expired latencies were last evicted, so that the operators that left the quorums don't
+	// accumulate
+	swept time.Time
+}
+
+// latencySample is the moving average of the latencies of an operator, and when its last latency was observed
+type latencySample struct {
+	average    time.Duration
+	observedAt time.Time
+}
+
+func newOperatorLatencies(expiry time.Duration) *operatorLatencies {
+	if expiry <= 0 {
+		expiry = DefaultLatencyExpiry
+	}
+	return &operatorLatencies{expiry: expiry, latencies: make(map[core.OperatorID]latencySample), swept: time.Now()}
+}
+
+func (l *operatorLatencies) observe(opID core.OperatorID, latency time.Duration) {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sample, ok := l.latencies[opID]; ok && now.Sub(sample.observedAt) < l.expiry {
+		latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(sample.average))
+	}
+	l.latencies[opID] = latencySample{average: latency, observedAt: now}
+	if now.Sub(l.swept) >= l.expiry {
+		for id, sample := range l.latencies {
+			if now.Sub(sample.observedAt) >= l.expiry {
+				delete(l.latencies, id)
+			}
+		}
+		l.swept = now
+	}
+}
+
+// latency returns the moving average of the latencies of the operator, if it hasn't expired. The latencies must be
+// locked.
+func (l *operatorLatencies) latency(opID core.OperatorID, now time.Time) (time.Duration, bool) {
+	sample, ok := l.latencies[opID]
+	if !ok || now.Sub(sample.observedAt) >= l.expiry {
+		return 0, false
+	}
+	return sample.average, true
+}
+
+// speeds returns the speeds of the operators between 0 and 1, relative to the fastest one. The operators without
+// latency yet, or whose latency expired, have the speed of the fastest, so that they are contacted early and their
+// latencies learned.
+func (l *operatorLatencies) speeds(operators []core.OperatorID) map[core.OperatorID]float64 {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var fastest time.Duration
+	for _, opID := range operators {
+		if latency, ok := l.latency(opID, now); ok && (fastest == 0 || latency < fastest) {
+			fastest = latency
+		}
+	}
+	speeds := make(map[core.OperatorID]float64, len(operators))
+	for _, opID := range operators {
+		latency, ok := l.latency(opID, now)
+		if !ok || latency <= 0 || fastest <= 0 {
+			speeds[opID] = 1
+			continue
+		}
+		speeds[opID] = float64(fastest) / float64(latency)
+	}
+	return speeds
+}
+
+// orderFanOut sorts the operators in the order in which they are contacted for their chunks. By default those
+// assigned the most chunks come first, so that the fewest operators supply the chunks the blob is reconstructed
+// from. With fan-out weights, the operators are sorted by the weighted sum of their stake relative to the largest
+// one and of their speed relative to the fastest one, the assignments breaking the ties.
+func (r *retrievalClient) orderFanOut(operators []core.OperatorID, assignments map[core.OperatorID]core.Assignment, infos map[core.OperatorID]*core.OperatorInfo) {
+	byAssignment := func(i, j int) bool {
+		a, b := assignments[operators[i]].NumChunks, assignments[operators[j]].NumChunks
+		if a != b {
+			return a > b
+		}
+		return bytes.Compare(operators[i][:], operators[j][:]) < 0
+	}
+	if r.fanOutWeights == nil {
+		sort.Slice(operators, byAssignment)
+		return
+	}
+
+	scores := make(map[core.OperatorID]float64, len(operators))
+	if r.fanOutWeights.Stake > 0 {
+		maxStake := new(big.Int)
+		for _, opID := range operators {
+			if stake := (*big.Int)(infos[opID].Stake); stake != nil && stake.Cmp(maxStake) > 0 {
+				maxStake = stake
+			}
+		}
+		if maxStake.Sign() > 0 {
+			for _, opID := range operators {
+				if stake := (*big.Int)(infos[opID].Stake); stake != nil {
+					share, _ := new(big.Rat).SetFrac(stake, maxStake).Float64()
+					scores[opID] += r.fanOutWeights.Stake * share
+				}
+			}
+		}
+	}
+	if r.fanOutWeights.Latency > 0 {
+		for opID, speed := range r.latencies.speeds(operators) {
+			scores[opID] += r.fanOutWeights.Latency * speed
+		}
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		a, b := scores[operators[i]], scores[operators[j]]
+		if a != b {
+			return a > b
+		}
+		return byAssignment(i, j)
+	})
+}
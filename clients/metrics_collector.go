@@ -0,0 +1,123 @@
+package clients
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// The clients an RPCObservation can come from
+const (
+	DisperserClientName = "disperser"
+	RetrievalClientName = "retrieval"
+	NodeClientName      = "node"
+)
+
+// RPCObservation describes a request made by a client
+type RPCObservation struct {
+	// Client is the client that made the request, i.e. DisperserClientName, RetrievalClientName or NodeClientName
+	Client string
+	// Address is the address of the disperser or of the node the request was sent to. It is empty for the
+	// retrievals, which span many nodes.
+	Address string
+	// Method is the name of the RPC, e.g. DisperseBlob, or RetrieveBlob for the retrievals
+	Method string
+	// Code is the gRPC status code of the request, OK if it succeeded. The context errors map to Canceled and
+	// DeadlineExceeded, and the other errors that aren't gRPC statuses to Unknown.
+	Code    codes.Code
+	Latency time.Duration
+	// RequestSize and ReplySize are the sizes in bytes of the uncompressed messages sent and received, summed
+	// over the messages of a stream. The ReplySize of a retrieval is the size of the blob.
+	RequestSize int64
+	ReplySize   int64
+}
+
+// MetricsCollector records the requests of the clients, e.g. to export them to a monitoring system.
+// ObserveRPC is called concurrently once every request is done, and must not block.
+type MetricsCollector interface {
+	ObserveRPC(observation RPCObservation)
+}
+
+// noopMetricsCollector is the collector of the clients that are not given one
+type noopMetricsCollector struct{}
+
+func (noopMetricsCollector) ObserveRPC(RPCObservation) {}
+
+// errorCode returns the gRPC status code of the error of a request
+func errorCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+	return status.FromContextError(err).Code()
+}
+
+// withRPCMetrics returns the dial options of a connection to the address with its RPCs recorded by the collector.
+// The options are copied so that the ones shared by the connections of a client are never appended to.
+func withRPCMetrics(dialOptions []grpc.DialOption, client string, address string, collector MetricsCollector) []grpc.DialOption {
+	if _, ok := collector.(noopMetricsCollector); ok {
+		return dialOptions
+	}
+	handler := &rpcStatsHandler{client: client, address: address, collector: collector}
+	return append(append([]grpc.DialOption{}, dialOptions...), grpc.WithStatsHandler(handler))
+}
+
+// rpcStatsHandler turns the stats of the RPCs of a connection into RPCObservations
+type rpcStatsHandler struct {
+	client    string
+	address   string
+	collector MetricsCollector
+}
+
+var _ stats.Handler = (*rpcStatsHandler)(nil)
+
+type rpcStatsKey struct{}
+
+// rpcStats accumulates the sizes of the messages of an RPC, which a stream may send and receive concurrently
+type rpcStats struct {
+	method      string
+	requestSize atomic.Int64
+	replySize   atomic.Int64
+}
+
+func (h *rpcStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	method := info.FullMethodName[strings.LastIndex(info.FullMethodName, "/")+1:]
+	return context.WithValue(ctx, rpcStatsKey{}, &rpcStats{method: method})
+}
+
+func (h *rpcStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rpc, ok := ctx.Value(rpcStatsKey{}).(*rpcStats)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.OutPayload:
+		rpc.requestSize.Add(int64(s.Length))
+	case *stats.InPayload:
+		rpc.replySize.Add(int64(s.Length))
+	case *stats.End:
+		h.collector.ObserveRPC(RPCObservation{
+			Client:      h.client,
+			Address:     h.address,
+			Method:      rpc.method,
+			Code:        errorCode(s.Error),
+			Latency:     s.EndTime.Sub(s.BeginTime),
+			RequestSize: rpc.requestSize.Load(),
+			ReplySize:   rpc.replySize.Load(),
+		})
+	}
+}
+
+func (h *rpcStatsHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *rpcStatsHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"context"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDisperserClient struct {
+	mock.Mock
+}
+
+var _ clients.DisperserClient = (*MockDisperserClient)(nil)
+
+func NewDisperserClient() *MockDisperserClient {
+	return &MockDisperserClient{}
+}
+
+func (c *MockDisperserClient) DisperseBlob(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (disperser_rpc.BlobStatus, []byte, error) {
+	args := c.Called(data, securityParams)
+
+	requestID := args.Get(1)
+	if requestID == nil {
+		return args.Get(0).(disperser_rpc.BlobStatus), nil, args.Error(2)
+	}
+	return args.Get(0).(disperser_rpc.BlobStatus), requestID.([]byte), args.Error(2)
+}
+
+func (c *MockDisperserClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, securityParams []*core.SecurityParam) (disperser_rpc.BlobStatus, []byte, error) {
+	args := c.Called(data, securityParams)
+
+	requestID := args.Get(1)
+	if requestID == nil {
+		return args.Get(0).(disperser_rpc.BlobStatus), nil, args.Error(2)
+	}
+	return args.Get(0).(disperser_rpc.BlobStatus), requestID.([]byte), args.Error(2)
+}
+
+func (c *MockDisperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	args := c.Called(requestID)
+
+	reply := args.Get(0)
+	if reply == nil {
+		return nil, args.Error(1)
+	}
+	return reply.(*disperser_rpc.BlobStatusReply), args.Error(1)
+}
+
+func (c *MockDisperserClient) DisperseAndWait(ctx context.Context, data []byte, securityParams []*core.SecurityParam, targetStatus disperser_rpc.BlobStatus, onProgress clients.DispersalProgressCallback) (*clients.DispersalResult, error) {
+	args := c.Called(data, securityParams, targetStatus)
+
+	result := &clients.DispersalResult{}
+	if r := args.Get(0); r != nil {
+		result = r.(*clients.DispersalResult)
+	}
+	return result, args.Error(1)
+}
+
+func (c *MockDisperserClient) Close() error {
+	args := c.Called()
+	return args.Error(0)
+}
@@ -43,6 +43,10 @@ func (c *MockNodeClient) GetBlobHeader(ctx context.Context, socket string, batch
 	return (args.Get(0)).(*core.BlobHeader), proof, err
 }
 
+func (c *MockNodeClient) Close() error {
+	return nil
+}
+
 func (c *MockNodeClient) GetChunks(
 	ctx context.Context,
 	opID core.OperatorID,
@@ -30,3 +30,99 @@ func (c *MockRetrievalClient) RetrieveBlob(
 	result := args.Get(0)
 	return result.([]byte), args.Error(1)
 }
+
+func (c *MockRetrievalClient) RetrieveBlobWithContributions(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, error) {
+	args := c.Called()
+
+	var contributions []clients.OperatorContribution
+	if args.Get(1) != nil {
+		contributions = args.Get(1).([]clients.OperatorContribution)
+	}
+	return args.Get(0).([]byte), contributions, args.Error(2)
+}
+
+func (c *MockRetrievalClient) RetrieveBlobWithInclusionProof(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, *clients.BlobInclusionProof, error) {
+	args := c.Called()
+
+	var contributions []clients.OperatorContribution
+	if args.Get(1) != nil {
+		contributions = args.Get(1).([]clients.OperatorContribution)
+	}
+	var proof *clients.BlobInclusionProof
+	if args.Get(2) != nil {
+		proof = args.Get(2).(*clients.BlobInclusionProof)
+	}
+	return args.Get(0).([]byte), contributions, proof, args.Error(3)
+}
+
+func (c *MockRetrievalClient) RetrieveBlobWithDiagnostics(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, *clients.BlobInclusionProof, *clients.RetrievalDiagnostics, error) {
+	args := c.Called()
+
+	var contributions []clients.OperatorContribution
+	if args.Get(1) != nil {
+		contributions = args.Get(1).([]clients.OperatorContribution)
+	}
+	var proof *clients.BlobInclusionProof
+	if args.Get(2) != nil {
+		proof = args.Get(2).(*clients.BlobInclusionProof)
+	}
+	var diagnostics *clients.RetrievalDiagnostics
+	if args.Get(3) != nil {
+		diagnostics = args.Get(3).(*clients.RetrievalDiagnostics)
+	}
+	var data []byte
+	if args.Get(0) != nil {
+		data = args.Get(0).([]byte)
+	}
+	return data, contributions, proof, diagnostics, args.Error(4)
+}
+
+func (c *MockRetrievalClient) RetrieveBlobs(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	blobs []clients.BlobRetrieval) <-chan clients.BlobRetrievalResult {
+	args := c.Called(blobs)
+
+	results := make(chan clients.BlobRetrievalResult, len(blobs))
+	for _, result := range args.Get(0).([]clients.BlobRetrievalResult) {
+		results <- result
+	}
+	close(results)
+	return results
+}
+
+func (c *MockRetrievalClient) GetBlobAssignments(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) (*clients.BlobAssignments, error) {
+	args := c.Called()
+
+	var assignments *clients.BlobAssignments
+	if args.Get(0) != nil {
+		assignments = args.Get(0).(*clients.BlobAssignments)
+	}
+	return assignments, args.Error(1)
+}
@@ -2,16 +2,22 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	node_utils "github.com/Layr-Labs/eigenda/node/grpc"
 	"github.com/wealdtech/go-merkletree"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
 )
 
+// ErrNodeConnectionFailed is returned when the connection to a node fails, i.e. before any request is sent to it
+var ErrNodeConnectionFailed = errors.New("failed to connect to the node")
+
 type RetrievedChunks struct {
 	OperatorID core.OperatorID
 	Chunks     []*core.Chunk
@@ -21,16 +27,63 @@ type RetrievedChunks struct {
 type NodeClient interface {
 	GetBlobHeader(ctx context.Context, socket string, batchHeaderHash [32]byte, blobIndex uint32) (*core.BlobHeader, *merkletree.Proof, error)
 	GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan RetrievedChunks)
+	// Close closes the connections the client reuses, see WithConnectionReuse. The client fails the requests to
+	// the nodes afterwards if it reuses its connections.
+	Close() error
 }
 
 type client struct {
-	timeout time.Duration
+	timeout     time.Duration
+	dialOptions []grpc.DialOption
+	// collector records the RPCs to the nodes
+	collector MetricsCollector
+	// connectionIdleTimeout and connectionObserver configure the reuse of the connections, see WithConnectionReuse
+	connectionIdleTimeout time.Duration
+	connectionObserver    ConnectionObserver
+	// pool holds the connections to the nodes if they are reused, and is nil if each request dials its own
+	pool *connectionPool
+}
+
+// NodeClientOption configures optional behavior of the node client
+type NodeClientOption func(*client)
+
+// WithNodeMetricsCollector records the RPCs to the nodes with the collector, along with the retrieval address of
+// the node
+func WithNodeMetricsCollector(collector MetricsCollector) NodeClientOption {
+	return func(c *client) {
+		c.collector = collector
+	}
+}
+
+// WithConnectionReuse makes the requests to a node share a single cached connection, over which they are
+// multiplexed, instead of each dialing its own. The connections unused for the idle timeout are closed, and the
+// observer, if it isn't nil, is notified of the number of connections open to each node.
+func WithConnectionReuse(idleTimeout time.Duration, observer ConnectionObserver) NodeClientOption {
+	return func(c *client) {
+		c.connectionIdleTimeout = idleTimeout
+		c.connectionObserver = observer
+	}
 }
 
-func NewNodeClient(timeout time.Duration) NodeClient {
-	return client{
+// NewNodeClient creates a client of the retrieval API of the DA nodes, whose requests time out after the given
+// timeout, including the time to connect to the node. The deadline of a request, the earlier of the timeout and the
+// deadline of its context, is sent to the node in the grpc-timeout header, so that the node stops serving it once
+// the client no longer waits for it. The gRPC options are optional.
+func NewNodeClient(timeout time.Duration, grpcOptions *common.GRPCClientOptions, opts ...NodeClientOption) NodeClient {
+	options := grpcOptions.WithDefaults(common.GRPCClientOptions{})
+	c := client{
 		timeout: timeout,
+		// The dial waits for the connection, unless it is refused, so that it is bounded by the request context
+		dialOptions: append(options.DialOptions(), grpc.WithBlock(), grpc.FailOnNonTempDialError(true)),
+		collector:   noopMetricsCollector{},
 	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.connectionIdleTimeout > 0 {
+		c.pool = newConnectionPool(c.connectionIdleTimeout, c.connectionObserver, c.dial)
+	}
+	return c
 }
 
 func (c client) GetBlobHeader(
@@ -39,18 +92,15 @@ func (c client) GetBlobHeader(
 	batchHeaderHash [32]byte,
 	blobIndex uint32,
 ) (*core.BlobHeader, *merkletree.Proof, error) {
-	conn, err := grpc.Dial(
-		core.OperatorSocket(socket).GetRetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	conn, release, err := c.connect(nodeCtx, core.OperatorSocket(socket).GetRetrievalSocket(), "GetBlobHeader")
 	if err != nil {
 		return nil, nil, err
 	}
-	defer conn.Close()
+	defer release()
 
 	n := node.NewRetrievalClient(conn)
-	nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
-	defer cancel()
 
 	request := &node.GetBlobHeaderRequest{
 		BatchHeaderHash: batchHeaderHash[:],
@@ -84,10 +134,9 @@ func (c client) GetChunks(
 	quorumID core.QuorumID,
 	chunksChan chan RetrievedChunks,
 ) {
-	conn, err := grpc.Dial(
-		core.OperatorSocket(opInfo.Socket).GetRetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	conn, release, err := c.connect(nodeCtx, core.OperatorSocket(opInfo.Socket).GetRetrievalSocket(), "RetrieveChunks")
 	if err != nil {
 		chunksChan <- RetrievedChunks{
 			OperatorID: opID,
@@ -96,10 +145,9 @@ func (c client) GetChunks(
 		}
 		return
 	}
+	defer release()
 
 	n := node.NewRetrievalClient(conn)
-	nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
-	defer cancel()
 
 	request := &node.RetrieveChunksRequest{
 		BatchHeaderHash: batchHeaderHash[:],
@@ -137,3 +185,45 @@ func (c client) GetChunks(
 		Chunks:     chunks,
 	}
 }
+
+func (c client) Close() error {
+	if c.pool != nil {
+		c.pool.Close()
+	}
+	return nil
+}
+
+// connect returns a connection to the node for a request, and the function to call once the request is done
+func (c client) connect(ctx context.Context, address string, method string) (*grpc.ClientConn, func(), error) {
+	if c.pool != nil {
+		return c.pool.acquire(ctx, address, method)
+	}
+	conn, err := c.dial(ctx, address, method)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, func() { _ = conn.Close() }, nil
+}
+
+// dial connects to the node before the context is done. The context is the one of the request, so that a node
+// that is slow to connect can't use more than the remaining budget of the request.
+// A failure to connect is recorded as a failure of the RPC the connection was for, since the RPC isn't sent.
+func (c client) dial(ctx context.Context, address string, method string) (*grpc.ClientConn, error) {
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, address, withRPCMetrics(c.dialOptions, NodeClientName, address, c.collector)...)
+	if err != nil {
+		code := errorCode(err)
+		if code == codes.Unknown {
+			code = codes.Unavailable
+		}
+		c.collector.ObserveRPC(RPCObservation{
+			Client:  NodeClientName,
+			Address: address,
+			Method:  method,
+			Code:    code,
+			Latency: time.Since(start),
+		})
+		return nil, fmt.Errorf("%w at %s: %w", ErrNodeConnectionFailed, address, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,202 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnectionObserver is notified of the number of connections the node client holds open to each node
+type ConnectionObserver interface {
+	ObserveNodeConnections(address string, numConnections int)
+}
+
+// pooledConnection is a connection to a node shared by the requests to it
+type pooledConnection struct {
+	conn *grpc.ClientConn
+	// ready is closed once the dial of the connection is done, successfully or not
+	ready chan struct{}
+	// refs is the number of requests using the connection, which is closed once it's evicted and unused
+	refs     int
+	lastUsed time.Time
+	evicted  bool
+}
+
+// connectionPool caches a connection per node, over which the concurrent requests to the node are multiplexed as
+// HTTP/2 streams instead of each dialing its own connection
+type connectionPool struct {
+	idleTimeout time.Duration
+	observer    ConnectionObserver
+	dial        func(ctx context.Context, address string, method string) (*grpc.ClientConn, error)
+
+	mu    sync.Mutex
+	conns map[string]*pooledConnection
+	// open is the number of open connections to each node, including the evicted ones still in use
+	open      map[string]int
+	lastSweep time.Time
+	// done is closed once the pool is closed, which stops the periodic sweeps
+	done   chan struct{}
+	closed bool
+}
+
+// newConnectionPool returns a pool whose idle connections are swept periodically, so that they are closed even if
+// no request is sent anymore, until the pool is closed
+func newConnectionPool(idleTimeout time.Duration, observer ConnectionObserver, dial func(ctx context.Context, address string, method string) (*grpc.ClientConn, error)) *connectionPool {
+	p := &connectionPool{
+		idleTimeout: idleTimeout,
+		observer:    observer,
+		dial:        dial,
+		conns:       make(map[string]*pooledConnection),
+		open:        make(map[string]int),
+		done:        make(chan struct{}),
+	}
+	go p.sweepPeriodically()
+	return p
+}
+
+func (p *connectionPool) sweepPeriodically() {
+	ticker := time.NewTicker(p.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case now := <-ticker.C:
+			p.mu.Lock()
+			p.sweep(now)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Close closes the connections of the pool, the ones still in use once their requests are done, and fails the
+// requests acquiring a connection afterwards
+func (p *connectionPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.done)
+	for address, c := range p.conns {
+		if c.conn != nil {
+			p.evict(address, c)
+		}
+	}
+}
+
+// acquire returns the connection to the node, which is dialed if there is none or if the cached one failed, and the
+// function releasing it once the request is done. The requests that find the connection being dialed wait for it.
+func (p *connectionPool) acquire(ctx context.Context, address string, method string) (*grpc.ClientConn, func(), error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, nil, fmt.Errorf("%w at %s: the node client is closed", ErrNodeConnectionFailed, address)
+		}
+		now := time.Now()
+		p.sweep(now)
+		c, ok := p.conns[address]
+		// The connections that failed are dialed again, so that a node that is down fails the requests with
+		// ErrNodeConnectionFailed rather than with the error of the RPC
+		if ok && c.conn != nil {
+			if state := c.conn.GetState(); state == connectivity.TransientFailure || state == connectivity.Shutdown {
+				p.evict(address, c)
+				ok = false
+			}
+		}
+
+		if !ok {
+			c = &pooledConnection{ready: make(chan struct{})}
+			p.conns[address] = c
+			p.mu.Unlock()
+			conn, err := p.dial(ctx, address, method)
+			p.mu.Lock()
+			if err != nil {
+				delete(p.conns, address)
+				close(c.ready)
+				p.mu.Unlock()
+				return nil, nil, err
+			}
+			c.conn = conn
+			close(c.ready)
+			p.open[address]++
+			p.observe(address)
+			// The pool was closed during the dial, so the connection is only used by this request, and closed once
+			// it's released
+			if p.closed {
+				delete(p.conns, address)
+				c.evicted = true
+			}
+		} else if c.conn == nil {
+			p.mu.Unlock()
+			select {
+			case <-c.ready:
+				// The dial is done, and is retried with the context of this request if it failed
+				continue
+			case <-ctx.Done():
+				return nil, nil, fmt.Errorf("%w at %s: %w", ErrNodeConnectionFailed, address, ctx.Err())
+			}
+		}
+
+		c.refs++
+		c.lastUsed = now
+		p.mu.Unlock()
+		var once sync.Once
+		return c.conn, func() { once.Do(func() { p.release(address, c) }) }, nil
+	}
+}
+
+func (p *connectionPool) release(address string, c *pooledConnection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c.refs--
+	c.lastUsed = time.Now()
+	if c.evicted && c.refs == 0 {
+		p.close(address, c)
+	}
+}
+
+// evict removes the connection from the pool, and closes it unless requests still use it
+func (p *connectionPool) evict(address string, c *pooledConnection) {
+	if p.conns[address] == c {
+		delete(p.conns, address)
+	}
+	c.evicted = true
+	if c.refs == 0 {
+		p.close(address, c)
+	}
+}
+
+func (p *connectionPool) close(address string, c *pooledConnection) {
+	_ = c.conn.Close()
+	p.open[address]--
+	p.observe(address)
+	if p.open[address] == 0 {
+		delete(p.open, address)
+	}
+}
+
+// sweep evicts the connections that have been unused for the idle timeout, at most twice per idle timeout
+func (p *connectionPool) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < p.idleTimeout/2 {
+		return
+	}
+	p.lastSweep = now
+	for address, c := range p.conns {
+		if c.conn != nil && c.refs == 0 && now.Sub(c.lastUsed) >= p.idleTimeout {
+			p.evict(address, c)
+		}
+	}
+}
+
+func (p *connectionPool) observe(address string) {
+	if p.observer != nil {
+		p.observer.ObserveNodeConnections(address, p.open[address])
+	}
+}
@@ -0,0 +1,298 @@
+package clients
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	retriever_rpc "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"google.golang.org/grpc"
+)
+
+// IndexedChunks are chunks of a blob along with their indices in the encoded blob
+type IndexedChunks struct {
+	Chunks  []*core.Chunk
+	Indices []core.ChunkNumber
+}
+
+type chunkCacheKey struct {
+	batchHeaderHash [32]byte
+	blobIndex       uint32
+	quorumID        core.QuorumID
+}
+
+// ChunkCache holds the verified chunks of the blobs last retrieved, up to a total size in bytes, so that they can be
+// served to the peer retrievers. The blobs least recently added or read are evicted first.
+type ChunkCache struct {
+	mu    sync.Mutex
+	cache *simplelru.LRU[chunkCacheKey, *IndexedChunks]
+	// size is the total size in bytes of the cached chunks
+	size    uint64
+	maxSize uint64
+}
+
+func NewChunkCache(maxSize uint64) *ChunkCache {
+	c := &ChunkCache{maxSize: maxSize}
+	// The cache is bounded by the size of the chunks rather than by their number of blobs
+	c.cache, _ = simplelru.NewLRU[chunkCacheKey, *IndexedChunks](math.MaxInt, func(key chunkCacheKey, chunks *IndexedChunks) {
+		c.size -= chunksSize(chunks.Chunks)
+	})
+	return c
+}
+
+func chunksSize(chunks []*core.Chunk) uint64 {
+	size := uint64(0)
+	for _, chunk := range chunks {
+		size += uint64(chunk.Size())
+	}
+	return size
+}
+
+// Add caches the chunks of the blob quorum along with the ones already cached. The chunks must have been verified
+// against their proofs, and the ones at the indices already cached are ignored. The chunks of a blob quorum larger
+// than the cache aren't cached.
+func (c *ChunkCache) Add(batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunks []*core.Chunk, indices []core.ChunkNumber) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := chunkCacheKey{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, quorumID: quorumID}
+	merged := &IndexedChunks{}
+	if cached, ok := c.cache.Peek(key); ok {
+		merged.Chunks = append(merged.Chunks, cached.Chunks...)
+		merged.Indices = append(merged.Indices, cached.Indices...)
+	}
+	known := make(map[core.ChunkNumber]bool, len(merged.Indices))
+	for _, index := range merged.Indices {
+		known[index] = true
+	}
+	for i, index := range indices {
+		if !known[index] {
+			known[index] = true
+			merged.Chunks = append(merged.Chunks, chunks[i])
+			merged.Indices = append(merged.Indices, index)
+		}
+	}
+
+	size := chunksSize(merged.Chunks)
+	if size > c.maxSize {
+		return
+	}
+	// The entry is replaced, which evicts the chunks cached before
+	c.cache.Remove(key)
+	c.cache.Add(key, merged)
+	c.size += size
+	for c.size > c.maxSize {
+		c.cache.RemoveOldest()
+	}
+}
+
+// Get returns the cached chunks of the blob quorum, and false if there are none
+func (c *ChunkCache) Get(batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID) (*IndexedChunks, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cache.Get(chunkCacheKey{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, quorumID: quorumID})
+}
+
+// Size returns the total size in bytes of the cached chunks
+func (c *ChunkCache) Size() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// defaultPeerMaxRecvMsgSize is the maximum size of the replies of the peers, as the chunks of a blob quorum exceed
+// the 4 MiB default of grpc. It's the maximum size of the requests the retriever receives.
+const defaultPeerMaxRecvMsgSize = 300 * 1024 * 1024
+
+// PeerClient gets the chunks that the peer retrievers cache, see the GetChunks RPC of the retriever
+type PeerClient interface {
+	GetPeerChunks(ctx context.Context, peer string, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID) (*IndexedChunks, error)
+	// Close closes the connections to the peers
+	Close() error
+}
+
+type peerClient struct {
+	timeout     time.Duration
+	dialOptions []grpc.DialOption
+
+	mu sync.Mutex
+	// conns are the connections to the peers, which are few and contacted on every fallback
+	conns map[string]*grpc.ClientConn
+}
+
+// NewPeerClient creates a client of the retrievers at their gRPC addresses, whose requests time out after the given
+// timeout. The connection to a peer is dialed when it's first contacted and reused by the next requests. The gRPC
+// options are optional, and set the TLS credentials of the connections if the peers serve TLS.
+func NewPeerClient(timeout time.Duration, grpcOptions *common.GRPCClientOptions) PeerClient {
+	options := grpcOptions.WithDefaults(common.GRPCClientOptions{MaxRecvMsgSize: defaultPeerMaxRecvMsgSize})
+	return &peerClient{
+		timeout:     timeout,
+		dialOptions: options.DialOptions(),
+		conns:       make(map[string]*grpc.ClientConn),
+	}
+}
+
+// conn returns the connection to the peer. The connection reconnects by itself, so that a peer that went down is
+// reached again once it's back.
+func (c *peerClient) conn(peer string) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, ok := c.conns[peer]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(peer, c.dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the peer at %s: %w", peer, err)
+	}
+	c.conns[peer] = conn
+	return conn, nil
+}
+
+func (c *peerClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs []error
+	for peer, conn := range c.conns {
+		errs = append(errs, conn.Close())
+		delete(c.conns, peer)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *peerClient) GetPeerChunks(ctx context.Context, peer string, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID) (*IndexedChunks, error) {
+	conn, err := c.conn(peer)
+	if err != nil {
+		return nil, err
+	}
+	peerCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	reply, err := retriever_rpc.NewRetrieverClient(conn).GetChunks(peerCtx, &retriever_rpc.ChunksRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       blobIndex,
+		QuorumId:        uint32(quorumID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.GetChunks()) != len(reply.GetIndices()) {
+		return nil, fmt.Errorf("got %d chunks and %d indices", len(reply.GetChunks()), len(reply.GetIndices()))
+	}
+	chunks := &IndexedChunks{
+		Chunks:  make([]*core.Chunk, len(reply.GetChunks())),
+		Indices: make([]core.ChunkNumber, len(reply.GetIndices())),
+	}
+	for i, data := range reply.GetChunks() {
+		chunk, err := new(core.Chunk).Deserialize(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize chunk %d: %w", i, err)
+		}
+		chunks.Chunks[i] = chunk
+		chunks.Indices[i] = core.ChunkNumber(reply.GetIndices()[i])
+	}
+	return chunks, nil
+}
+
+// peerFallback fetches from the peer retrievers the chunks that the operators fail to return, see WithPeerFallback
+type peerFallback struct {
+	client   PeerClient
+	peers    []string
+	maxPeers int
+	// next rotates the first peer contacted across the retrievals, which spreads the fallbacks over the peers
+	next atomic.Uint32
+}
+
+// fanOut returns the peers contacted by a fallback
+func (p *peerFallback) fanOut() []string {
+	n := min(p.maxPeers, len(p.peers))
+	start := int(p.next.Add(1)-1) % len(p.peers)
+	peers := make([]string, n)
+	for i := range peers {
+		peers[i] = p.peers[(start+i)%len(p.peers)]
+	}
+	return peers
+}
+
+type peerReply struct {
+	peer   string
+	chunks *IndexedChunks
+	err    error
+}
+
+// fetchPeerChunks requests the chunks of the blob quorum from the peers of a fallback, and returns the ones at the
+// indices that aren't held yet, until there are needed of them. The chunks of a peer are verified against the
+// commitments before they're used, and all of them are dropped if any fails its proof.
+func (r *retrievalClient) fetchPeerChunks(
+	ctx context.Context,
+	logger common.Logger,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	quorumID core.QuorumID,
+	held []core.ChunkNumber,
+	needed uint,
+	commitments core.BlobCommitments,
+	params core.EncodingParams) ([]*core.Chunk, []core.ChunkNumber) {
+	// The peers that didn't reply once enough chunks are fetched are canceled
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	peers := r.peers.fanOut()
+	replies := make(chan peerReply, len(peers))
+	for _, peer := range peers {
+		go func(peer string) {
+			chunks, err := r.peers.client.GetPeerChunks(ctx, peer, batchHeaderHash, blobIndex, quorumID)
+			replies <- peerReply{peer: peer, chunks: chunks, err: err}
+		}(peer)
+	}
+
+	known := make(map[core.ChunkNumber]bool, len(held))
+	for _, index := range held {
+		known[index] = true
+	}
+	var chunks []*core.Chunk
+	var indices []core.ChunkNumber
+	for range peers {
+		if uint(len(chunks)) >= needed {
+			break
+		}
+		reply := <-replies
+		if reply.err != nil {
+			logger.Warn("failed to fetch the chunks from a peer", "peer", reply.peer, "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "blobIndex", blobIndex, "quorum", quorumID, "err", reply.err)
+			continue
+		}
+		var peerChunks []*core.Chunk
+		var peerIndices []core.ChunkNumber
+		for i, index := range reply.chunks.Indices {
+			if index < params.NumChunks && !known[index] {
+				known[index] = true
+				peerChunks = append(peerChunks, reply.chunks.Chunks[i])
+				peerIndices = append(peerIndices, index)
+			}
+		}
+		if len(peerChunks) == 0 {
+			continue
+		}
+		err := checkChunkLengths(peerChunks, params.ChunkLength)
+		if err == nil {
+			err = r.encoder.VerifyChunks(peerChunks, peerIndices, commitments, params)
+		}
+		if err != nil {
+			// The chunks can be fetched again from the other peers
+			for _, index := range peerIndices {
+				delete(known, index)
+			}
+			logger.Warn("dropping the chunks of a peer that failed verification", "peer", reply.peer, "err", err)
+			continue
+		}
+		chunks = append(chunks, peerChunks...)
+		indices = append(indices, peerIndices...)
+	}
+	logger.Info("fetched chunks from the peers", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "blobIndex", blobIndex, "quorum", quorumID, "peers", len(peers), "chunks", len(chunks), "needed", needed)
+	return chunks, indices
+}
@@ -0,0 +1,72 @@
+package clients
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusMetricsCollector is a MetricsCollector exporting the requests of the clients as Prometheus metrics,
+// labeled by the client, the address of the server and the method
+type PrometheusMetricsCollector struct {
+	NumRequests    *prometheus.CounterVec
+	RequestLatency *prometheus.HistogramVec
+	RequestSize    *prometheus.HistogramVec
+	ReplySize      *prometheus.HistogramVec
+}
+
+var _ MetricsCollector = (*PrometheusMetricsCollector)(nil)
+
+// NewPrometheusMetricsCollector registers the metrics with the registerer, under the namespace if it is set and
+// eigenda_client otherwise
+func NewPrometheusMetricsCollector(reg prometheus.Registerer, namespace string) *PrometheusMetricsCollector {
+	if namespace == "" {
+		namespace = "eigenda_client"
+	}
+	labels := []string{"client", "address", "method"}
+	// The sizes range from a blob header to the chunks of a large blob
+	sizeBuckets := prometheus.ExponentialBuckets(256, 4, 10)
+	return &PrometheusMetricsCollector{
+		NumRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests",
+				Help:      "the number of requests, by gRPC status code",
+			},
+			append(labels, "code"),
+		),
+		RequestLatency: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "request_duration_seconds",
+				Help:      "the latency of the requests",
+				Buckets:   prometheus.DefBuckets,
+			},
+			labels,
+		),
+		RequestSize: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "request_size_bytes",
+				Help:      "the size of the messages sent by the requests",
+				Buckets:   sizeBuckets,
+			},
+			labels,
+		),
+		ReplySize: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "reply_size_bytes",
+				Help:      "the size of the messages received by the requests",
+				Buckets:   sizeBuckets,
+			},
+			labels,
+		),
+	}
+}
+
+func (m *PrometheusMetricsCollector) ObserveRPC(observation RPCObservation) {
+	m.NumRequests.WithLabelValues(observation.Client, observation.Address, observation.Method, observation.Code.String()).Inc()
+	m.RequestLatency.WithLabelValues(observation.Client, observation.Address, observation.Method).Observe(observation.Latency.Seconds())
+	m.RequestSize.WithLabelValues(observation.Client, observation.Address, observation.Method).Observe(float64(observation.RequestSize))
+	m.ReplySize.WithLabelValues(observation.Client, observation.Address, observation.Method).Observe(float64(observation.ReplySize))
+}
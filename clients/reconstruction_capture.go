@@ -0,0 +1,64 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// ReconstructionCapture holds the inputs of a reconstruction that failed, from which it can be replayed offline
+type ReconstructionCapture struct {
+	BatchHeaderHash [32]byte
+	BlobIndex       uint32
+	QuorumID        core.QuorumID
+	// Commitments are the commitments of the blob the reconstruction is checked against
+	Commitments core.BlobCommitments
+	Params      core.EncodingParams
+	// Threshold is the overridden number of chunks the blob is reconstructed from, or 0
+	Threshold uint
+	Chunks    []*core.Chunk
+	Indices   []core.ChunkNumber
+	// Err is the error the reconstruction failed with
+	Err string
+}
+
+// ReconstructionCapturer records the reconstructions that fail, see WithReconstructionCapture
+type ReconstructionCapturer interface {
+	CaptureReconstruction(ctx context.Context, capture *ReconstructionCapture)
+}
+
+// Encode serializes the capture, see DecodeReconstructionCapture
+func (c *ReconstructionCapture) Encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeReconstructionCapture deserializes a capture serialized by Encode
+func DecodeReconstructionCapture(data []byte) (*ReconstructionCapture, error) {
+	var capture ReconstructionCapture
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&capture); err != nil {
+		return nil, fmt.Errorf("failed to decode the reconstruction capture: %w", err)
+	}
+	return &capture, nil
+}
+
+// ReplayReconstruction reconstructs the blob from the captured chunks and checks it against its commitment, as the
+// retrieval did. It returns the error of the reconstruction, which is the captured one unless the failure depends on
+// the environment of the retrieval.
+func ReplayReconstruction(encoder core.Encoder, capture *ReconstructionCapture) ([]byte, error) {
+	data, err := encoder.Decode(capture.Chunks, capture.Indices, capture.Params, uint64(capture.Commitments.Length)*bn254.BYTES_PER_COEFFICIENT)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoder.VerifyCommitment(data, capture.Commitments); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCommitmentMismatch, err)
+	}
+	return data, nil
+}
@@ -1,8 +1,16 @@
 package clients
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
@@ -10,8 +18,54 @@ import (
 	"github.com/gammazero/workerpool"
 	"github.com/wealdtech/go-merkletree"
 	"github.com/wealdtech/go-merkletree/keccak256"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// ErrCommitmentMismatch is returned when a blob doesn't match its expected commitment, i.e. when the blob decoded
+// from the retrieved chunks doesn't match the commitment or the length proof in its blob header, or when the
+// disperser returns a commitment other than the one computed locally
+var ErrCommitmentMismatch = errors.New("retrieved blob does not match its commitment")
+
+// ErrChunkVerificationFailed is returned in strict mode when the chunks of an operator fail their proofs
+var ErrChunkVerificationFailed = errors.New("retrieved chunks failed verification")
+
+// ErrBlobNotFound is returned when every operator of the quorum replied that it doesn't store the blob, e.g. because
+// it expired from their stores. Unlike a failure to reach the operators, retrying doesn't help.
+var ErrBlobNotFound = errors.New("blob is not stored by any operator")
+
+// ErrOperatorStateUnavailable is returned when the operator state at the reference block can't be read from the
+// chain, so no operator is contacted
+var ErrOperatorStateUnavailable = errors.New("failed to read the operator state")
+
+// ChunkVerificationFailureMode is what a retrieval does with the chunks of an operator that fail their proofs
+type ChunkVerificationFailureMode int
+
+const (
+	// ChunkVerificationLenient drops the chunks of the operator and reconstructs the blob from the others
+	ChunkVerificationLenient ChunkVerificationFailureMode = iota
+	// ChunkVerificationStrict fails the retrieval
+	ChunkVerificationStrict
+)
+
+// ChunkVerificationObserver is notified of the operators whose chunks fail their proofs
+type ChunkVerificationObserver interface {
+	ObserveChunkVerificationFailure(operatorID core.OperatorID)
+}
+
+// OperatorContactObserver is notified of the number of operators contacted for the chunks of each retrieval
+type OperatorContactObserver interface {
+	ObserveOperatorsContacted(quorumID core.QuorumID, numOperators int)
+}
+
+// OperatorContribution describes the chunks an operator supplied for the reconstruction of a blob
+type OperatorContribution struct {
+	OperatorID core.OperatorID
+	NumChunks  int
+	// Latency is the time the operator took to return its chunks
+	Latency time.Duration
+}
+
 type RetrievalClient interface {
 	RetrieveBlob(
 		ctx context.Context,
@@ -20,6 +74,115 @@ type RetrievalClient interface {
 		referenceBlockNumber uint,
 		batchRoot [32]byte,
 		quorumID core.QuorumID) ([]byte, error)
+	// RetrieveBlobWithContributions is like RetrieveBlob, and also returns the operators whose chunks were used
+	// to reconstruct the blob, in the order in which they replied. Operators that were contacted but failed to
+	// return chunks are not included.
+	RetrieveBlobWithContributions(
+		ctx context.Context,
+		batchHeaderHash [32]byte,
+		blobIndex uint32,
+		referenceBlockNumber uint,
+		batchRoot [32]byte,
+		quorumID core.QuorumID) ([]byte, []OperatorContribution, error)
+	// RetrieveBlobWithInclusionProof is like RetrieveBlobWithContributions, and also returns the header of the blob
+	// along with its Merkle proof against the batch root, which the retrieval verified before reconstructing the blob
+	RetrieveBlobWithInclusionProof(
+		ctx context.Context,
+		batchHeaderHash [32]byte,
+		blobIndex uint32,
+		referenceBlockNumber uint,
+		batchRoot [32]byte,
+		quorumID core.QuorumID) ([]byte, []OperatorContribution, *BlobInclusionProof, error)
+	// RetrieveBlobWithDiagnostics is like RetrieveBlobWithInclusionProof, and also describes every chunk received:
+	// the operator that returned it, its latency, whether it passed its proof and whether it was used in the
+	// reconstruction. Every chunk is verified on its own, which makes it much heavier than the other retrievals.
+	// The diagnostics are returned even if the retrieval fails, once the operators were contacted.
+	RetrieveBlobWithDiagnostics(
+		ctx context.Context,
+		batchHeaderHash [32]byte,
+		blobIndex uint32,
+		referenceBlockNumber uint,
+		batchRoot [32]byte,
+		quorumID core.QuorumID) ([]byte, []OperatorContribution, *BlobInclusionProof, *RetrievalDiagnostics, error)
+	// RetrieveBlobs retrieves several blobs of the batch together, reading the operator state once and fetching the
+	// chunks of all the blobs through the same connections, and sends the result of each blob on the returned channel
+	// as soon as it's retrieved. A blob that fails to be retrieved doesn't fail the others. The channel is closed once
+	// every blob has its result. RetrieveBlob is a retrieval of a single blob this way.
+	RetrieveBlobs(
+		ctx context.Context,
+		batchHeaderHash [32]byte,
+		referenceBlockNumber uint,
+		batchRoot [32]byte,
+		blobs []BlobRetrieval) <-chan BlobRetrievalResult
+	// GetBlobAssignments returns the assignment of the chunks of the blob quorum to the operators that the retrievals
+	// fetch the chunks by, from the operator state at the reference block and the blob header fetched from the
+	// operators, without fetching any chunk
+	GetBlobAssignments(
+		ctx context.Context,
+		batchHeaderHash [32]byte,
+		blobIndex uint32,
+		referenceBlockNumber uint,
+		batchRoot [32]byte,
+		quorumID core.QuorumID) (*BlobAssignments, error)
+}
+
+// BlobRetrieval is one of the blobs of a batch retrieved together by RetrieveBlobs
+type BlobRetrieval struct {
+	BlobIndex uint32
+	QuorumID  core.QuorumID
+}
+
+// BlobRetrievalResult is the result of the retrieval of one of the blobs of RetrieveBlobs
+type BlobRetrievalResult struct {
+	// Index is the position of the blob in the blobs of the retrieval
+	Index int
+	Data  []byte
+	Err   error
+}
+
+// BlobInclusionProof is the header of a blob and its Merkle proof against the root of the blob headers of the batch
+type BlobInclusionProof struct {
+	BlobHeader *core.BlobHeader
+	Proof      *merkletree.Proof
+}
+
+// BlobAssignments is the assignment of the chunks of a blob quorum to the operators of the quorum, along with the
+// blob header and the encoding it's derived from
+type BlobAssignments struct {
+	// BlobHeader is the header of the blob, verified against the batch root with the Proof
+	BlobHeader *core.BlobHeader
+	Proof      *merkletree.Proof
+	QuorumInfo *core.BlobQuorumInfo
+	// Assignments are the chunks assigned to each operator of the quorum
+	Assignments    map[core.OperatorID]core.Assignment
+	Info           core.AssignmentInfo
+	EncodingParams core.EncodingParams
+}
+
+// NumChunksNeeded returns the number of chunks the blob can be reconstructed from, i.e. the chunks of its data
+func (a *BlobAssignments) NumChunksNeeded() uint {
+	return (uint(a.BlobHeader.Length) + a.EncodingParams.ChunkLength - 1) / a.EncodingParams.ChunkLength
+}
+
+// MemoryBudget bounds the memory used by concurrent reconstructions
+type MemoryBudget interface {
+	// Reserve reserves the estimated memory of a reconstruction before it starts, and returns the function
+	// that releases it once the reconstruction completes
+	Reserve(ctx context.Context, numBytes uint64) (release func(), err error)
+}
+
+const (
+	// fieldElementSize is the size in memory of a bn254.Fr
+	fieldElementSize = 32
+	// reconstructionMemoryFactor is the number of copies of the encoded blob the reconstruction holds at once,
+	// i.e. the retrieved chunks along with the samples and polynomials the decoder derives from them
+	reconstructionMemoryFactor = 4
+)
+
+// estimateReconstructionMemory estimates the memory in bytes used to reconstruct the blob from the chunks
+// of the quorum, where each encoded symbol is held as a field element
+func estimateReconstructionMemory(quorumHeader *core.BlobQuorumInfo) uint64 {
+	return uint64(quorumHeader.EncodedBlobLength) * fieldElementSize * reconstructionMemoryFactor
 }
 
 type retrievalClient struct {
@@ -29,10 +192,204 @@ type retrievalClient struct {
 	nodeClient            NodeClient
 	encoder               core.Encoder
 	numConnections        int
+	verifyCommitment      bool
+	verifyChunks          bool
+	chunkFailureMode      ChunkVerificationFailureMode
+	chunkObserver         ChunkVerificationObserver
+	memoryBudget          MemoryBudget
+	collector             MetricsCollector
+	// commitmentSamples is the number of chunks whose proofs are checked instead of the decoded blob against the
+	// commitment, which is checked in full if it's 0
+	commitmentSamples int
+	// reconstructionThresholds are the numbers of chunks the blobs of the quorums are reconstructed from, overriding
+	// the ones derived from their encoding parameters
+	reconstructionThresholds map[core.QuorumID]uint
+	// retryOnMismatch retries a reconstruction that doesn't match the commitment once, without the chunks that fail
+	// their proofs
+	retryOnMismatch bool
+	// endpoints refreshes the sockets of the operators that can't be connected to, if it isn't nil
+	endpoints *endpointRefresher
+	// maxOperators is the number of operators first contacted for the chunks of a blob, all of them if it's 0
+	maxOperators    int
+	contactObserver OperatorContactObserver
+	// capturer records the inputs of the failed reconstructions, if it isn't nil
+	capturer ReconstructionCapturer
+	// blacklistUnassigned drops all the chunks of the operators that return chunks outside of their assignment,
+	// instead of only the chunks outside of it
+	blacklistUnassigned bool
+	// sequentialFetch fetches the chunks from one operator at a time, in a deterministic order
+	sequentialFetch bool
+	// fanOutWeights order the operators contacted for the chunks by their stakes and latencies, which are
+	// observed into latencies, instead of by their assignments if it isn't nil
+	fanOutWeights *FanOutWeights
+	latencies     *operatorLatencies
+	// chunkCache caches the verified chunks of the blobs reconstructed, if it isn't nil
+	chunkCache *ChunkCache
+	// peers fetches the chunks the operators fail to return from the peer retrievers, if it isn't nil
+	peers *peerFallback
 }
 
 var _ RetrievalClient = (*retrievalClient)(nil)
 
+// RetrievalClientOption configures optional behavior of the retrieval client
+type RetrievalClientOption func(*retrievalClient)
+
+// WithMemoryBudget makes each reconstruction reserve its estimated memory from the budget before the chunks are
+// retrieved, so that the number of concurrent reconstructions is bounded by their total memory
+func WithMemoryBudget(budget MemoryBudget) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.memoryBudget = budget
+	}
+}
+
+// WithoutCommitmentVerification disables the check of the decoded blob against the commitment in its blob header.
+// The check requires the encoder to be loaded with enough SRS points to commit to the whole blob.
+func WithoutCommitmentVerification() RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.verifyCommitment = false
+	}
+}
+
+// WithSampledCommitmentVerification replaces the check of the decoded blob against the commitment, which commits to
+// the whole blob again, with the check of the proofs of numSamples chunks drawn at random among the ones the blob is
+// reconstructed from, all of them if they're fewer. The blob matches its commitment if all the chunks pass their
+// proofs, so checking a sample of them is only probabilistic: a blob reconstructed from n chunks of which m are wrong
+// passes with a probability of about (1-m/n)^numSamples, e.g. an operator corrupting a single chunk goes undetected
+// most of the time. It's an integrity signal for monitoring at high throughput, not a guarantee that the blob is
+// correct.
+//
+// The blobs reconstructed from chunks that were all verified upfront, see WithChunkVerification, aren't checked
+// again, as all their proofs passed, and the ones with an overridden reconstruction threshold are always checked in
+// full. It has no effect without commitment verification.
+func WithSampledCommitmentVerification(numSamples int) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.commitmentSamples = numSamples
+	}
+}
+
+// WithChunkVerification verifies the chunks of each operator against the commitment in the blob header before they
+// are used to reconstruct the blob. The failures are handled according to the mode, and reported to the observer
+// if it isn't nil.
+func WithChunkVerification(mode ChunkVerificationFailureMode, observer ChunkVerificationObserver) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.verifyChunks = true
+		r.chunkFailureMode = mode
+		r.chunkObserver = observer
+	}
+}
+
+// WithRetrievalMetricsCollector records the retrievals with the collector. The RPCs to the nodes are recorded by
+// the node client instead, see WithNodeMetricsCollector.
+func WithRetrievalMetricsCollector(collector MetricsCollector) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.collector = collector
+	}
+}
+
+// WithEndpointRefresh re-fetches the socket of an operator from the current operator state once failureThreshold
+// consecutive connections to it fail, and retries the request at the fresh socket if it changed. The refreshes of
+// an operator are at least minInterval apart.
+func WithEndpointRefresh(failureThreshold int, minInterval time.Duration) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.endpoints = newEndpointRefresher(r.indexedChainState, failureThreshold, minInterval, r.logger)
+	}
+}
+
+// WithReconstructionThresholds overrides the minimum number of chunks the blobs of the quorums in the map are
+// reconstructed from, which is otherwise derived from the on-chain parameters. The blobs of these quorums are
+// reconstructed from the first chunks that reach the threshold, and always checked against their commitment, even
+// without commitment verification. It's meant for testing changes of the coding parameters, not for production.
+func WithReconstructionThresholds(thresholds map[core.QuorumID]uint) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.reconstructionThresholds = thresholds
+	}
+}
+
+// WithCommitmentMismatchRetry retries once a reconstruction whose blob doesn't match its commitment: the chunks of
+// every operator used are checked against their proofs, and the blob is reconstructed again without the operators
+// whose chunks fail, along with the chunks of the operators that weren't used, e.g. beyond the reconstruction
+// threshold. The excluded operators are logged, and reported to the observer if it isn't nil. Unlike chunk
+// verification, which checks the chunks upfront, the chunks are only checked once a reconstruction fails.
+func WithCommitmentMismatchRetry(observer ChunkVerificationObserver) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.retryOnMismatch = true
+		if observer != nil {
+			r.chunkObserver = observer
+		}
+	}
+}
+
+// WithMaxOperators bounds the fan-out of the retrievals: the chunks of a blob are requested from the maxOperators
+// operators assigned the most chunks, and from the next ones only if the chunks they return are too few to
+// reconstruct the blob. All the operators are contacted if maxOperators is 0. The number of operators contacted by
+// each retrieval is reported to the observer if it isn't nil.
+func WithMaxOperators(maxOperators int, observer OperatorContactObserver) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.maxOperators = maxOperators
+		r.contactObserver = observer
+	}
+}
+
+// WithFanOutWeights orders the operators contacted for the chunks of a blob by the weighted sum of their stake,
+// relative to the largest stake of the quorum, and of their speed, relative to the fastest operator, as observed by
+// the past retrievals of the client. A stake weight alone contacts the most-staked operators first, a latency weight
+// alone the fastest ones, and both weights a combination of them. The operators not contacted yet, or whose latency
+// expired, count as the fastest. The weights only change the order, so with WithMaxOperators they choose the
+// operators first contacted.
+func WithFanOutWeights(weights FanOutWeights) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.fanOutWeights = &weights
+		r.latencies = newOperatorLatencies(weights.LatencyExpiry)
+	}
+}
+
+// WithReconstructionCapture records the chunks and parameters of the reconstructions that fail, for debugging
+func WithReconstructionCapture(capturer ReconstructionCapturer) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.capturer = capturer
+	}
+}
+
+// WithUnassignedChunkBlacklist excludes the operators that return more chunks than they're assigned from the rest
+// of the retrieval, including the retry of a reconstruction that doesn't match its commitment. Otherwise only the
+// chunks beyond their assignment are dropped.
+func WithUnassignedChunkBlacklist() RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.blacklistUnassigned = true
+	}
+}
+
+// WithSequentialFetch fetches the blob header and the chunks from the operators one at a time, in the order of their
+// IDs and assignments, so that the replies and thus the reconstructions are deterministic. It makes the retrievals
+// as slow as the sum of the latencies of the operators, and is only meant for reproducing bugs in tests.
+func WithSequentialFetch() RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.sequentialFetch = true
+	}
+}
+
+// WithChunkCache caches the chunks of the blobs reconstructed that were verified against their proofs, i.e. with
+// chunk verification, so that they can be served to the peer retrievers
+func WithChunkCache(cache *ChunkCache) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		r.chunkCache = cache
+	}
+}
+
+// WithPeerFallback fetches from the peer retrievers the chunks that the operators fail to return, once too few
+// chunks are retrieved from the operators to reconstruct the blob. Each fallback requests the chunks from up to
+// maxPeers of the peers at once, starting from a different peer each time. The chunks of the peers are verified
+// against their proofs before they're used, whatever the chunk verification.
+func WithPeerFallback(client PeerClient, peers []string, maxPeers int) RetrievalClientOption {
+	return func(r *retrievalClient) {
+		if len(peers) > 0 && maxPeers > 0 {
+			r.peers = &peerFallback{client: client, peers: peers, maxPeers: maxPeers}
+		}
+	}
+}
+
+// NewRetrievalClient returns a client retrieving the chunks through nodeClient, whose gRPC options thus apply to
+// the connections to the DA nodes
 func NewRetrievalClient(
 	logger common.Logger,
 	indexedChainState core.IndexedChainState,
@@ -40,15 +397,22 @@ func NewRetrievalClient(
 	nodeClient NodeClient,
 	encoder core.Encoder,
 	numConnections int,
+	opts ...RetrievalClientOption,
 ) *retrievalClient {
-	return &retrievalClient{
+	r := &retrievalClient{
 		logger:                logger,
 		indexedChainState:     indexedChainState,
 		assignmentCoordinator: assignmentCoordinator,
 		nodeClient:            nodeClient,
 		encoder:               encoder,
 		numConnections:        numConnections,
+		verifyCommitment:      true,
+		collector:             noopMetricsCollector{},
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *retrievalClient) RetrieveBlob(
@@ -58,45 +422,496 @@ func (r *retrievalClient) RetrieveBlob(
 	referenceBlockNumber uint,
 	batchRoot [32]byte,
 	quorumID core.QuorumID) ([]byte, error) {
+	data, _, err := r.RetrieveBlobWithContributions(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	return data, err
+}
+
+// timedChunks are the chunks retrieved from an operator along with the time the operator took to return them,
+// and the error of their verification if they were verified
+type timedChunks struct {
+	RetrievedChunks
+	latency   time.Duration
+	verifyErr error
+	// unassigned is the number of chunks the operator returned beyond its assignment, which were dropped
+	unassigned int
+	// lengthErr tells that a chunk of the operator doesn't have the chunk length of the encoding, so that none of the
+	// chunks of the operator are verified nor decoded
+	lengthErr error
+}
+
+func (r *retrievalClient) RetrieveBlobWithContributions(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []OperatorContribution, error) {
+	data, contributions, _, err := r.RetrieveBlobWithInclusionProof(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	return data, contributions, err
+}
+
+func (r *retrievalClient) RetrieveBlobWithInclusionProof(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []OperatorContribution, *BlobInclusionProof, error) {
+	start := time.Now()
+	data, contributions, proof, err := r.retrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID, nil)
+	r.observeRetrieval(start, data, err)
+	return data, contributions, proof, err
+}
+
+func (r *retrievalClient) RetrieveBlobWithDiagnostics(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []OperatorContribution, *BlobInclusionProof, *RetrievalDiagnostics, error) {
+	start := time.Now()
+	diagnostics := &RetrievalDiagnostics{}
+	data, contributions, proof, err := r.retrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID, diagnostics)
+	r.observeRetrieval(start, data, err)
+	r.verifyDiagnosedChunks(diagnostics)
+	return data, contributions, proof, diagnostics, err
+}
+
+func (r *retrievalClient) RetrieveBlobs(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	blobs []BlobRetrieval) <-chan BlobRetrievalResult {
+	results := make(chan BlobRetrievalResult, len(blobs))
+	if len(blobs) == 0 {
+		close(results)
+		return results
+	}
+	start := time.Now()
+	outcomes := make(chan blobOutcome, len(blobs))
+	go func() {
+		r.retrieveBlobs(ctx, batchHeaderHash, referenceBlockNumber, batchRoot, blobs, nil, outcomes)
+		close(outcomes)
+	}()
+	go func() {
+		defer close(results)
+		for outcome := range outcomes {
+			r.observeRetrieval(start, outcome.data, outcome.err)
+			results <- BlobRetrievalResult{Index: outcome.index, Data: outcome.data, Err: outcome.err}
+		}
+	}()
+	return results
+}
+
+func (r *retrievalClient) GetBlobAssignments(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) (*BlobAssignments, error) {
 	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, referenceBlockNumber, []core.QuorumID{quorumID})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w at block %d: %w", ErrOperatorStateUnavailable, referenceBlockNumber, err)
+	}
+	return r.blobAssignments(ctx, common.LoggerFromContext(ctx, r.logger), indexedOperatorState, batchHeaderHash, blobIndex, batchRoot, quorumID)
+}
+
+func (r *retrievalClient) observeRetrieval(start time.Time, data []byte, err error) {
+	r.collector.ObserveRPC(RPCObservation{
+		Client:    RetrievalClientName,
+		Method:    "RetrieveBlob",
+		Code:      errorCode(err),
+		Latency:   time.Since(start),
+		ReplySize: int64(len(data)),
+	})
+}
+
+// retrieveBlob retrieves a single blob, as a batch of one blob
+func (r *retrievalClient) retrieveBlob(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID,
+	diagnostics *RetrievalDiagnostics) ([]byte, []OperatorContribution, *BlobInclusionProof, error) {
+	var blobDiagnostics []*RetrievalDiagnostics
+	if diagnostics != nil {
+		blobDiagnostics = []*RetrievalDiagnostics{diagnostics}
+	}
+	outcomes := make(chan blobOutcome, 1)
+	r.retrieveBlobs(ctx, batchHeaderHash, referenceBlockNumber, batchRoot, []BlobRetrieval{{BlobIndex: blobIndex, QuorumID: quorumID}}, blobDiagnostics, outcomes)
+	outcome := <-outcomes
+	return outcome.data, outcome.contributions, outcome.proof, outcome.err
+}
+
+// blobOutcome is the outcome of the retrieval of one of the blobs of retrieveBlobs
+type blobOutcome struct {
+	// index is the position of the blob in the blobs of the retrieval
+	index         int
+	data          []byte
+	contributions []OperatorContribution
+	proof         *BlobInclusionProof
+	err           error
+}
+
+// retrieveBlobs retrieves the blobs of the batch from the operator state at the reference block, which is read once
+// for all the blobs, and sends the outcome of each blob as soon as it's retrieved. The blobs are retrieved
+// concurrently, up to numConnections of them at once, and their chunks are fetched through the same pool of
+// numConnections connections. The diagnostics are either nil or those of each blob. It returns once every outcome is
+// sent, so the channel must be buffered for all the blobs unless it's read concurrently.
+func (r *retrievalClient) retrieveBlobs(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	blobs []BlobRetrieval,
+	diagnostics []*RetrievalDiagnostics,
+	outcomes chan<- blobOutcome) {
+	// The logs carry the context of the request, e.g. its correlation ID, if it has a logger
+	logger := common.LoggerFromContext(ctx, r.logger)
+	quorums := make([]core.QuorumID, 0, 1)
+	for _, blob := range blobs {
+		if !slices.Contains(quorums, blob.QuorumID) {
+			quorums = append(quorums, blob.QuorumID)
+		}
+	}
+	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, referenceBlockNumber, quorums)
+	if err != nil {
+		err = fmt.Errorf("%w at block %d: %w", ErrOperatorStateUnavailable, referenceBlockNumber, err)
+		for i := range blobs {
+			outcomes <- blobOutcome{index: i, err: err}
+		}
+		return
+	}
+
+	pool := workerpool.New(r.numConnections)
+	// The fetches from the operators that didn't reply yet are abandoned once every blob is retrieved, without
+	// waiting for them
+	defer func() {
+		go pool.Stop()
+	}()
+	// slots bounds the blobs retrieved at once, and thus the chunks held for their reconstructions
+	slots := make(chan struct{}, r.numConnections)
+	var wg sync.WaitGroup
+	for i, blob := range blobs {
+		var blobDiagnostics *RetrievalDiagnostics
+		if diagnostics != nil {
+			blobDiagnostics = diagnostics[i]
+		}
+		// The slot is acquired before the retrieval is started, so that a request of many blobs doesn't start a
+		// goroutine per blob
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(blobs); j++ {
+				outcomes <- blobOutcome{index: j, err: ctx.Err()}
+			}
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func(i int, blob BlobRetrieval) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			data, contributions, proof, err := r.retrieveBatchBlob(ctx, logger, indexedOperatorState, pool, batchHeaderHash, blob.BlobIndex, batchRoot, blob.QuorumID, blobDiagnostics)
+			outcomes <- blobOutcome{index: i, data: data, contributions: contributions, proof: proof, err: err}
+		}(i, blob)
+	}
+	wg.Wait()
+}
+
+// retrieveBatchBlob retrieves one of the blobs of retrieveBlobs, fetching its chunks through the pool
+func (r *retrievalClient) retrieveBatchBlob(
+	ctx context.Context,
+	logger common.Logger,
+	indexedOperatorState *core.IndexedOperatorState,
+	pool *workerpool.WorkerPool,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	batchRoot [32]byte,
+	quorumID core.QuorumID,
+	diagnostics *RetrievalDiagnostics) ([]byte, []OperatorContribution, *BlobInclusionProof, error) {
+	plan, err := r.blobAssignments(ctx, logger, indexedOperatorState, batchHeaderHash, blobIndex, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	operators := indexedOperatorState.Operators[quorumID]
+	blobHeader, proof, quorumHeader, assignements, encodingParams := plan.BlobHeader, plan.Proof, plan.QuorumInfo, plan.Assignments, plan.EncodingParams
+
+	// Only the operators that are assigned chunks of the blob are contacted, in the order of the fan-out
+	assignedOperators := make([]core.OperatorID, 0, len(operators))
+	for opID := range operators {
+		if assignment, ok := assignements[opID]; ok && assignment.NumChunks > 0 {
+			assignedOperators = append(assignedOperators, opID)
+		}
+	}
+	if len(assignedOperators) < len(operators) {
+		logger.Debug("filtered out operators without chunk assignments", "filtered", len(operators)-len(assignedOperators), "total", len(operators), "quorum", quorumID)
+	}
+	r.orderFanOut(assignedOperators, assignements, operators)
+
+	if diagnostics != nil {
+		diagnostics.commitments = blobHeader.BlobCommitments
+		diagnostics.params = encodingParams
+	}
+
+	if r.memoryBudget != nil {
+		release, err := r.memoryBudget.Reserve(ctx, estimateReconstructionMemory(quorumHeader))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		defer release()
+	}
+
+	// Fetch chunks from the assigned operators, up to maxOperators of them at first
+	chunksChan := make(chan timedChunks, len(assignedOperators))
+	// contacted is the number of operators contacted, and pending the number of chunks assigned to the ones that
+	// didn't reply yet
+	contacted := 0
+	var pending uint
+	contact := func(opID core.OperatorID) {
+		contacted++
+		pending += assignements[opID].NumChunks
+		opInfo := indexedOperatorState.IndexedOperators[opID]
+		fetch := func() {
+			start := time.Now()
+			var chunks RetrievedChunks
+			_ = r.callOperator(ctx, opID, quorumID, opInfo.Socket, func(socket string) error {
+				info := opInfo
+				if socket != opInfo.Socket {
+					refreshed := *opInfo
+					refreshed.Socket = socket
+					info = &refreshed
+				}
+				replyChan := make(chan RetrievedChunks, 1)
+				r.nodeClient.GetChunks(ctx, opID, info, batchHeaderHash, blobIndex, quorumID, replyChan)
+				chunks = <-replyChan
+				return chunks.Err
+			})
+			reply := timedChunks{RetrievedChunks: chunks, latency: time.Since(start)}
+			// The failures count at their latency, e.g. the timeout of the operators that don't reply
+			if r.latencies != nil && ctx.Err() == nil {
+				r.latencies.observe(opID, reply.latency)
+			}
+			// The chunks have no indices: they're matched to the indices of the assignment in order, as the nodes
+			// serve them, so the chunks beyond the assignment are outside of it
+			if assigned := int(assignements[opID].NumChunks); reply.Err == nil && len(reply.Chunks) > assigned {
+				reply.unassigned = len(reply.Chunks) - assigned
+				reply.Chunks = reply.Chunks[:assigned]
+			}
+			if reply.Err == nil {
+				reply.lengthErr = checkChunkLengths(reply.Chunks, encodingParams.ChunkLength)
+			}
+			if r.verifyChunks && reply.Err == nil && reply.lengthErr == nil && len(reply.Chunks) > 0 && !r.blacklisted(reply) {
+				reply.verifyErr = r.verifyOperatorChunks(reply.Chunks, assignements[opID], blobHeader.BlobCommitments, encodingParams)
+			}
+			chunksChan <- reply
+		}
+		// The channel holds the replies of all the operators, so that the sequential fetches don't block
+		if r.sequentialFetch {
+			fetch()
+		} else {
+			pool.Submit(fetch)
+		}
+	}
+	for _, opID := range assignedOperators {
+		if r.maxOperators > 0 && contacted == r.maxOperators {
+			break
+		}
+		contact(opID)
+	}
+	defer func() {
+		if r.contactObserver != nil {
+			r.contactObserver.ObserveOperatorsContacted(quorumID, contacted)
+		}
+	}()
+
+	// threshold is the overridden number of chunks the blob is reconstructed from, or 0 if all the chunks are used
+	threshold := r.reconstructionThresholds[quorumID]
+	// needed is the number of chunks the blob can be reconstructed from, i.e. the chunks of its data
+	needed := plan.NumChunksNeeded()
+	if threshold > needed {
+		needed = threshold
 	}
+	var chunks []*core.Chunk
+	var indices []core.ChunkNumber
+	var contributions []OperatorContribution
+	// used are the replies the blob is reconstructed from, and received is the number of replies received
+	var used []timedChunks
+	received := 0
+	// awaitReply tells whether replies are still expected, once the next operators are contacted if the chunks
+	// received along with the ones pending are too few to reconstruct the blob
+	awaitReply := func() bool {
+		for uint(len(chunks))+pending < needed && contacted < len(assignedOperators) {
+			contact(assignedOperators[contacted])
+		}
+		return received < contacted
+	}
+	// TODO(ian-shim): if we gathered enough chunks, cancel remaining RPC calls
+	for ; awaitReply() && (threshold == 0 || uint(len(chunks)) < threshold); received++ {
+		reply := <-chunksChan
+		pending -= assignements[reply.OperatorID].NumChunks
+		diagnostics.observeReply(reply, assignements[reply.OperatorID])
+		if reply.Err != nil || len(reply.Chunks) == 0 {
+			continue
+		}
+		assignment, ok := assignements[reply.OperatorID]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("no assignment to operator %v", reply.OperatorID)
+		}
+		if reply.unassigned > 0 {
+			operator := hex.EncodeToString(reply.OperatorID[:])
+			socket := indexedOperatorState.IndexedOperators[reply.OperatorID].Socket
+			if r.blacklistUnassigned {
+				logger.Warn("dropping the chunks of an operator that returned chunks outside of its assignment", "operator", operator, "socket", socket, "unassigned", reply.unassigned, "assigned", assignment.NumChunks)
+				continue
+			}
+			logger.Warn("dropping the chunks an operator returned outside of its assignment", "operator", operator, "socket", socket, "unassigned", reply.unassigned, "assigned", assignment.NumChunks)
+		}
+		if reply.lengthErr != nil {
+			operator := hex.EncodeToString(reply.OperatorID[:])
+			logger.Warn("dropping the chunks of an operator that returned chunks of the wrong length", "operator", operator, "socket", indexedOperatorState.IndexedOperators[reply.OperatorID].Socket, "err", reply.lengthErr)
+			continue
+		}
+		if reply.verifyErr != nil {
+			if r.chunkObserver != nil {
+				r.chunkObserver.ObserveChunkVerificationFailure(reply.OperatorID)
+			}
+			operator := hex.EncodeToString(reply.OperatorID[:])
+			if r.chunkFailureMode == ChunkVerificationStrict {
+				return nil, nil, nil, fmt.Errorf("%w: operator %s: %v", ErrChunkVerificationFailed, operator, reply.verifyErr)
+			}
+			logger.Warn("dropping the chunks of an operator that failed verification", "operator", operator, "socket", indexedOperatorState.IndexedOperators[reply.OperatorID].Socket, "err", reply.verifyErr)
+			continue
+		}
+
+		chunks = append(chunks, reply.Chunks...)
+		indices = append(indices, assignment.GetIndices()...)
+		contributions = append(contributions, OperatorContribution{
+			OperatorID: reply.OperatorID,
+			NumChunks:  len(reply.Chunks),
+			Latency:    reply.latency,
+		})
+		used = append(used, reply)
+	}
+
+	// The chunks that the operators failed to return are fetched from the peers
+	if r.peers != nil && uint(len(chunks)) < needed {
+		peerChunks, peerIndices := r.fetchPeerChunks(ctx, logger, batchHeaderHash, blobIndex, quorumID, indices, needed-uint(len(chunks)), blobHeader.BlobCommitments, encodingParams)
+		chunks = append(chunks, peerChunks...)
+		indices = append(indices, peerIndices...)
+	}
+
+	if uint(len(chunks)) < threshold {
+		return nil, nil, nil, fmt.Errorf("retrieved %d chunks of quorum %d, fewer than its reconstruction threshold of %d", len(chunks), quorumID, threshold)
+	}
+
+	diagnostics.markUsed(contributions)
+	reconstructionStart := time.Now()
+	data, err := r.reconstruct(chunks, indices, encodingParams, blobHeader, threshold)
+	diagnostics.observeReconstruction(reconstructionStart)
+	// The chunks are cached once the blob is reconstructed from them, as they were all verified upfront
+	if err == nil && r.chunkCache != nil && r.verifyChunks {
+		r.chunkCache.Add(batchHeaderHash, blobIndex, quorumID, chunks, indices)
+	}
+	if errors.Is(err, ErrCommitmentMismatch) && r.retryOnMismatch {
+		// The replies that weren't received yet are the alternatives to the chunks of the suspect operators
+		var alternatives []timedChunks
+		for ; received < contacted; received++ {
+			reply := <-chunksChan
+			diagnostics.observeReply(reply, assignements[reply.OperatorID])
+			alternatives = append(alternatives, reply)
+		}
+		reconstructionStart = time.Now()
+		data, contributions, err = r.retryReconstruction(logger, used, alternatives, assignements, encodingParams, blobHeader, threshold, err)
+		diagnostics.observeReconstruction(reconstructionStart)
+		if err == nil {
+			diagnostics.markUsed(contributions)
+		}
+	}
+	if err != nil {
+		// The chunks of the first reconstruction are captured, which the retry doesn't change unless they're bad
+		if r.capturer != nil {
+			r.capturer.CaptureReconstruction(ctx, &ReconstructionCapture{
+				BatchHeaderHash: batchHeaderHash,
+				BlobIndex:       blobIndex,
+				QuorumID:        quorumID,
+				Commitments:     blobHeader.BlobCommitments,
+				Params:          encodingParams,
+				Threshold:       threshold,
+				Chunks:          chunks,
+				Indices:         indices,
+				Err:             err.Error(),
+			})
+		}
+		return nil, nil, nil, err
+	}
+
+	return data, contributions, &BlobInclusionProof{BlobHeader: blobHeader, Proof: proof}, nil
+}
+
+// blobAssignments returns the assignment of the chunks of the blob quorum to the operators of the state, from the blob
+// header fetched from the first operator whose header is included in the batch root
+func (r *retrievalClient) blobAssignments(
+	ctx context.Context,
+	logger common.Logger,
+	indexedOperatorState *core.IndexedOperatorState,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) (*BlobAssignments, error) {
 	operators, ok := indexedOperatorState.Operators[quorumID]
 	if !ok {
 		return nil, fmt.Errorf("no quorum with ID: %d", quorumID)
 	}
 
 	// Get blob header from any operator
+	var err error
 	var blobHeader *core.BlobHeader
 	var proof *merkletree.Proof
 	var proofVerified bool
-	for opID := range operators {
+	// notFound is the number of operators that replied that they don't store the blob
+	notFound := 0
+	for _, opID := range r.headerOperators(operators) {
 		opInfo := indexedOperatorState.IndexedOperators[opID]
-		blobHeader, proof, err = r.nodeClient.GetBlobHeader(ctx, opInfo.Socket, batchHeaderHash, blobIndex)
+		err = r.callOperator(ctx, opID, quorumID, opInfo.Socket, func(socket string) error {
+			var err error
+			blobHeader, proof, err = r.nodeClient.GetBlobHeader(ctx, socket, batchHeaderHash, blobIndex)
+			return err
+		})
 		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				notFound++
+			}
 			// try another operator
-			r.logger.Warn("failed to dial operator while fetching BlobHeader, trying different operator", "operator", opInfo.Socket, "err", err)
+			logger.Warn("failed to dial operator while fetching BlobHeader, trying different operator", "operator", opInfo.Socket, "err", err)
 			continue
 		}
 
 		blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
 		if err != nil {
-			r.logger.Warn("got invalid blob header, trying different operator", "operator", opInfo.Socket, "err", err)
+			logger.Warn("got invalid blob header, trying different operator", "operator", opInfo.Socket, "err", err)
 			continue
 		}
 		proofVerified, err = merkletree.VerifyProofUsing(blobHeaderHash[:], false, proof, [][]byte{batchRoot[:]}, keccak256.New())
 		if err != nil {
-			r.logger.Warn("got invalid blob header proof, trying different operator", "operator", opInfo.Socket, "err", err)
+			logger.Warn("got invalid blob header proof, trying different operator", "operator", opInfo.Socket, "err", err)
 			continue
 		}
 		if !proofVerified {
-			r.logger.Warn("failed to verify blob header against given proof, trying different operator", "operator", opInfo.Socket)
+			logger.Warn("failed to verify blob header against given proof, trying different operator", "operator", opInfo.Socket)
 			continue
 		}
 
 		break
 	}
+	if notFound > 0 && notFound == len(operators) {
+		return nil, fmt.Errorf("%w (header hash: %x, index: %d)", ErrBlobNotFound, batchHeaderHash, blobIndex)
+	}
 	if blobHeader == nil || proof == nil || !proofVerified {
 		return nil, fmt.Errorf("failed to get blob header from all operators (header hash: %s, index: %d)", batchHeaderHash, blobIndex)
 	}
@@ -108,7 +923,6 @@ func (r *retrievalClient) RetrieveBlob(
 			break
 		}
 	}
-
 	if quorumHeader == nil {
 		return nil, fmt.Errorf("no quorum header for quorum %d", quorumID)
 	}
@@ -118,44 +932,198 @@ func (r *retrievalClient) RetrieveBlob(
 		return nil, fmt.Errorf("failed to get assignments")
 	}
 
-	// Fetch chunks from all operators
-	chunksChan := make(chan RetrievedChunks, len(operators))
-	pool := workerpool.New(r.numConnections)
-	for opID := range operators {
-		opID := opID
-		opInfo := indexedOperatorState.IndexedOperators[opID]
-		pool.Submit(func() {
-			r.nodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
-			// TODO(ian-shim): validate chunks received from nodes
-		})
+	chunkLength, err := r.assignmentCoordinator.GetChunkLengthFromHeader(indexedOperatorState.OperatorState, quorumHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	encodingParams, err := core.GetEncodingParams(chunkLength, info.TotalChunks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlobAssignments{
+		BlobHeader:     blobHeader,
+		Proof:          proof,
+		QuorumInfo:     quorumHeader,
+		Assignments:    assignements,
+		Info:           info,
+		EncodingParams: encodingParams,
+	}, nil
+}
+
+// reconstruct decodes the blob from the chunks, and checks it against its commitment, or checks a sample of the
+// chunks against their proofs with sampled commitment verification
+func (r *retrievalClient) reconstruct(chunks []*core.Chunk, indices []core.ChunkNumber, params core.EncodingParams, blobHeader *core.BlobHeader, threshold uint) ([]byte, error) {
+	// The blobs reconstructed with an overridden threshold are always checked in full, as the decoder may accept too
+	// few chunks for the blob
+	sampled := r.verifyCommitment && r.commitmentSamples > 0 && threshold == 0
+	if sampled && !r.verifyChunks {
+		if err := r.verifySampledChunks(chunks, indices, params, blobHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := r.encoder.Decode(chunks, indices, params, uint64(blobHeader.Length)*bn254.BYTES_PER_COEFFICIENT)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unless the chunks are verified individually, operators serving consistent but wrong chunks are only
+	// detected by checking the decoded blob against the commitment.
+	if (r.verifyCommitment && !sampled) || threshold > 0 {
+		if err := r.encoder.VerifyCommitment(data, blobHeader.BlobCommitments); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCommitmentMismatch, err)
+		}
+	}
+	return data, nil
+}
+
+// verifySampledChunks checks the proofs of commitmentSamples chunks drawn at random among the chunks, and returns an
+// error wrapping ErrCommitmentMismatch if one of them fails, as the blob decoded from the chunks wouldn't match its
+// commitment
+func (r *retrievalClient) verifySampledChunks(chunks []*core.Chunk, indices []core.ChunkNumber, params core.EncodingParams, blobHeader *core.BlobHeader) error {
+	numSamples := min(r.commitmentSamples, len(chunks))
+	sampledChunks := make([]*core.Chunk, numSamples)
+	sampledIndices := make([]core.ChunkNumber, numSamples)
+	for i, position := range rand.Perm(len(chunks))[:numSamples] {
+		sampledChunks[i] = chunks[position]
+		sampledIndices[i] = indices[position]
+	}
+	if err := r.encoder.VerifyChunks(sampledChunks, sampledIndices, blobHeader.BlobCommitments, params); err != nil {
+		return fmt.Errorf("%w: a sampled chunk fails its proof: %v", ErrCommitmentMismatch, err)
 	}
+	return nil
+}
 
+// retryReconstruction reconstructs the blob again from the replies whose chunks pass their proofs, once the
+// reconstruction from the used replies didn't match the commitment. It returns the mismatch error if no used chunk
+// fails its proof, as the same chunks would be decoded again.
+func (r *retrievalClient) retryReconstruction(
+	logger common.Logger,
+	used []timedChunks,
+	alternatives []timedChunks,
+	assignments map[core.OperatorID]core.Assignment,
+	params core.EncodingParams,
+	blobHeader *core.BlobHeader,
+	threshold uint,
+	mismatch error) ([]byte, []OperatorContribution, error) {
+	var excluded []string
+	// suspects is the number of used replies whose chunks fail their proofs
+	suspects := 0
 	var chunks []*core.Chunk
 	var indices []core.ChunkNumber
-	// TODO(ian-shim): if we gathered enough chunks, cancel remaining RPC calls
-	for i := 0; i < len(operators); i++ {
-		reply := <-chunksChan
-		if reply.Err != nil {
+	var contributions []OperatorContribution
+	for i, reply := range append(used, alternatives...) {
+		if reply.Err != nil || reply.lengthErr != nil || len(reply.Chunks) == 0 || r.blacklisted(reply) {
 			continue
 		}
-		assignment, ok := assignements[reply.OperatorID]
+		assignment, ok := assignments[reply.OperatorID]
 		if !ok {
-			return nil, fmt.Errorf("no assignment to operator %v", reply.OperatorID)
+			continue
+		}
+		// The chunks verified upfront that were used passed their proofs, as the others were dropped
+		verifyErr := reply.verifyErr
+		if !r.verifyChunks {
+			verifyErr = r.verifyOperatorChunks(reply.Chunks, assignment, blobHeader.BlobCommitments, params)
+		}
+		if verifyErr != nil {
+			if r.chunkObserver != nil {
+				r.chunkObserver.ObserveChunkVerificationFailure(reply.OperatorID)
+			}
+			if i < len(used) {
+				suspects++
+			}
+			excluded = append(excluded, hex.EncodeToString(reply.OperatorID[:]))
+			continue
 		}
-
 		chunks = append(chunks, reply.Chunks...)
 		indices = append(indices, assignment.GetIndices()...)
+		contributions = append(contributions, OperatorContribution{
+			OperatorID: reply.OperatorID,
+			NumChunks:  len(reply.Chunks),
+			Latency:    reply.latency,
+		})
 	}
-
-	chunkLength, err := r.assignmentCoordinator.GetChunkLengthFromHeader(indexedOperatorState.OperatorState, quorumHeader)
+	if suspects == 0 {
+		logger.Warn("reconstructed blob does not match its commitment, but no chunk used fails its proof: not retrying", "err", mismatch)
+		return nil, nil, mismatch
+	}
+	logger.Warn("reconstructed blob does not match its commitment, retrying without the operators whose chunks fail their proofs", "excludedOperators", excluded, "operators", len(contributions), "err", mismatch)
+	data, err := r.reconstruct(chunks, indices, params, blobHeader, threshold)
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("%w, and the retry without the operators %v failed: %v", mismatch, excluded, err)
 	}
+	return data, contributions, nil
+}
 
-	encodingParams, err := core.GetEncodingParams(chunkLength, info.TotalChunks)
-	if err != nil {
-		return nil, err
+// headerOperators returns the operators the blob header is requested from, in turn, which are in the order of their
+// IDs with sequential fetches, and in the random order of the map otherwise
+func (r *retrievalClient) headerOperators(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID {
+	ids := make([]core.OperatorID, 0, len(operators))
+	for opID := range operators {
+		ids = append(ids, opID)
 	}
+	if r.sequentialFetch {
+		sort.Slice(ids, func(i, j int) bool {
+			return bytes.Compare(ids[i][:], ids[j][:]) < 0
+		})
+	}
+	return ids
+}
+
+// blacklisted tells whether the operator of the reply is excluded from the retrieval, see WithUnassignedChunkBlacklist
+func (r *retrievalClient) blacklisted(reply timedChunks) bool {
+	return r.blacklistUnassigned && reply.unassigned > 0
+}
+
+// checkChunkLengths checks that the chunks have the chunk length of the encoding, as the decoder and the verification
+// of the proofs assume it
+func checkChunkLengths(chunks []*core.Chunk, chunkLength uint) error {
+	for i, chunk := range chunks {
+		if chunk == nil {
+			return fmt.Errorf("chunk %d is missing", i)
+		}
+		if uint(chunk.Length()) != chunkLength {
+			return fmt.Errorf("chunk %d has length %d, expected %d", i, chunk.Length(), chunkLength)
+		}
+	}
+	return nil
+}
 
-	return r.encoder.Decode(chunks, indices, encodingParams, uint64(blobHeader.Length)*bn254.BYTES_PER_COEFFICIENT)
+// verifyOperatorChunks verifies the chunks an operator returned against the commitment, at the indices of its
+// assignment
+func (r *retrievalClient) verifyOperatorChunks(chunks []*core.Chunk, assignment core.Assignment, commitments core.BlobCommitments, params core.EncodingParams) error {
+	indices := assignment.GetIndices()
+	if len(chunks) != len(indices) {
+		return fmt.Errorf("got %d chunks, expected %d", len(chunks), len(indices))
+	}
+	return r.encoder.VerifyChunks(chunks, indices, commitments, params)
+}
+
+// callOperator calls the operator at its socket, and retries the call at the fresh socket of the operator if the
+// connection fails and the socket is refreshed
+func (r *retrievalClient) callOperator(ctx context.Context, opID core.OperatorID, quorumID core.QuorumID, stateSocket string, call func(socket string) error) error {
+	if r.endpoints == nil {
+		return call(stateSocket)
+	}
+	socket := r.endpoints.socket(opID, stateSocket)
+	err := call(socket)
+	if !errors.Is(err, ErrNodeConnectionFailed) {
+		r.endpoints.connected(opID)
+		return err
+	}
+	// The connections that fail because the request is out of time are not the fault of the operator
+	if ctx.Err() != nil {
+		return err
+	}
+	fresh, ok := r.endpoints.connectionFailed(ctx, opID, quorumID, stateSocket, socket)
+	if !ok {
+		return err
+	}
+	err = call(fresh)
+	if !errors.Is(err, ErrNodeConnectionFailed) {
+		r.endpoints.connected(opID)
+	}
+	return err
 }
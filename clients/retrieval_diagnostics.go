@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// ChunkDiagnostic describes a chunk an operator returned for the reconstruction of a blob
+type ChunkDiagnostic struct {
+	OperatorID core.OperatorID
+	// Index is the index of the chunk in the encoded blob, from the assignment of the operator
+	Index core.ChunkNumber
+	// Latency is the time the operator took to return its chunks, which are returned together
+	Latency time.Duration
+	// ProofVerified tells whether the chunk passed its proof, verified on its own against the commitment of the blob
+	ProofVerified bool
+	// Used tells whether the chunk was decoded in the last reconstruction of the blob
+	Used bool
+
+	chunk *core.Chunk
+}
+
+// OperatorFailure describes an operator contacted for its chunks that didn't return any
+type OperatorFailure struct {
+	OperatorID core.OperatorID
+	Latency    time.Duration
+	Err        string
+}
+
+// RetrievalDiagnostics describe the chunks of a retrieval, see RetrievalClient.RetrieveBlobWithDiagnostics. Only the
+// replies received before the retrieval completed are described: the operators that were contacted but didn't reply
+// while enough chunks were received are not.
+type RetrievalDiagnostics struct {
+	Chunks []ChunkDiagnostic
+	// OperatorFailures are the operators that failed to return their chunks, returned none, or returned chunks of
+	// the wrong length
+	OperatorFailures []OperatorFailure
+	// ReconstructionLatency is the time the decoding of the blob from the chunks took, including the retries
+	ReconstructionLatency time.Duration
+
+	commitments core.BlobCommitments
+	params      core.EncodingParams
+}
+
+// observeReply records the reply of an operator, with the chunks matched to the indices of its assignment
+func (d *RetrievalDiagnostics) observeReply(reply timedChunks, assignment core.Assignment) {
+	if d == nil {
+		return
+	}
+	if reply.Err != nil || reply.lengthErr != nil || len(reply.Chunks) == 0 {
+		failure := OperatorFailure{OperatorID: reply.OperatorID, Latency: reply.latency, Err: "no chunks returned"}
+		if reply.Err != nil {
+			failure.Err = reply.Err.Error()
+		} else if reply.lengthErr != nil {
+			failure.Err = reply.lengthErr.Error()
+		}
+		d.OperatorFailures = append(d.OperatorFailures, failure)
+		return
+	}
+	indices := assignment.GetIndices()
+	for i, chunk := range reply.Chunks {
+		if i >= len(indices) {
+			break
+		}
+		d.Chunks = append(d.Chunks, ChunkDiagnostic{
+			OperatorID: reply.OperatorID,
+			Index:      indices[i],
+			Latency:    reply.latency,
+			chunk:      chunk,
+		})
+	}
+}
+
+// markUsed records the operators whose chunks were decoded in the last reconstruction
+func (d *RetrievalDiagnostics) markUsed(contributions []OperatorContribution) {
+	if d == nil {
+		return
+	}
+	used := make(map[core.OperatorID]struct{}, len(contributions))
+	for _, contribution := range contributions {
+		used[contribution.OperatorID] = struct{}{}
+	}
+	for i := range d.Chunks {
+		_, d.Chunks[i].Used = used[d.Chunks[i].OperatorID]
+	}
+}
+
+// observeReconstruction adds the time of a reconstruction that started at the time
+func (d *RetrievalDiagnostics) observeReconstruction(start time.Time) {
+	if d == nil {
+		return
+	}
+	d.ReconstructionLatency += time.Since(start)
+}
+
+// verifyDiagnosedChunks verifies the proof of every chunk on its own, so that the chunks failing their proofs are
+// told apart from the other chunks of their operators
+func (r *retrievalClient) verifyDiagnosedChunks(d *RetrievalDiagnostics) {
+	if d.commitments.Commitment == nil {
+		return
+	}
+	for i := range d.Chunks {
+		chunk := &d.Chunks[i]
+		err := r.encoder.VerifyChunks([]*core.Chunk{chunk.chunk}, []core.ChunkNumber{chunk.Index}, d.commitments, d.params)
+		chunk.ProofVerified = err == nil
+		chunk.chunk = nil
+	}
+}
@@ -0,0 +1,115 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	testSignerKey  = "0x73ae7e3a40b59caacb1cda8fa04f4e7fa5bb2b37101f9f3506290c201f57bf7b"
+	otherSignerKey = "fb1c1b3e8d1b9e2a4a0b9d5e8a7c6f5e4d3c2b1a0f9e8d7c6b5a4938271605f4"
+)
+
+// impersonatingSigner signs with its own key but claims the account of another one
+type impersonatingSigner struct {
+	*auth.LocalBlobRequestSigner
+	accountID string
+}
+
+func (s *impersonatingSigner) AccountID() string {
+	return s.accountID
+}
+
+type failingSigner struct {
+	*auth.LocalBlobRequestSigner
+}
+
+func (s *failingSigner) SignBlobRequest(uint32, []byte) ([]byte, error) {
+	return nil, errors.New("kms unavailable")
+}
+
+func newTestSigner(t *testing.T, privateKey string) *auth.LocalBlobRequestSigner {
+	signer, err := auth.NewLocalBlobRequestSigner(privateKey)
+	assert.NoError(t, err)
+	return signer
+}
+
+func TestDisperseBlobAuthenticatedWithStub(t *testing.T) {
+	signer := newTestSigner(t, testSignerKey)
+	other := newTestSigner(t, otherSignerKey)
+	server := dispersertest.NewDisperser(dispersertest.Config{Accounts: []string{signer.AccountID()}})
+	conn, err := grpc.Dial(startTestDisperser(t, server), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+	stub := disperser_rpc.NewDisperserClient(conn)
+	request := &disperser_rpc.DisperseBlobRequest{Data: []byte("data"), SecurityParams: []*disperser_rpc.SecurityParams{{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}}
+
+	reply, err := clients.DisperseBlobAuthenticated(context.Background(), stub, signer, request)
+	assert.NoError(t, err)
+	assert.Equal(t, server.RequestIDs(), [][]byte{reply.GetRequestId()})
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING, reply.GetResult())
+	requests := server.Requests()
+	assert.Equal(t, signer.AccountID(), requests[0].GetAccountId())
+	assert.Equal(t, request.GetData(), requests[0].GetData())
+	// The request of the caller is left untouched
+	assert.Empty(t, request.GetAccountId())
+
+	// Every dispersal signs a new challenge
+	_, err = clients.DisperseBlobAuthenticated(context.Background(), stub, signer, request)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.NumChallenges())
+
+	_, err = clients.DisperseBlobAuthenticated(context.Background(), stub, &impersonatingSigner{LocalBlobRequestSigner: other, accountID: signer.AccountID()}, request)
+	assert.ErrorIs(t, err, clients.ErrInvalidSignature)
+	assert.ErrorContains(t, err, "signature does not match the account")
+
+	_, err = clients.DisperseBlobAuthenticated(context.Background(), stub, other, request)
+	assert.ErrorIs(t, err, clients.ErrUnknownAccount)
+
+	_, err = clients.DisperseBlobAuthenticated(context.Background(), stub, &failingSigner{signer}, request)
+	assert.ErrorContains(t, err, "failed to sign the blob request: kms unavailable")
+
+	assert.Len(t, server.Requests(), 2)
+}
+
+func TestDisperseAndWaitAuthenticated(t *testing.T) {
+	signer := newTestSigner(t, testSignerKey)
+	server := dispersertest.NewDisperser(dispersertest.Config{Accounts: []string{signer.AccountID()}})
+	host, port, err := net.SplitHostPort(startTestDisperser(t, server))
+	assert.NoError(t, err)
+	config := &clients.DisperserClientConfig{Hostname: host, Port: port, Timeout: time.Second, StatusPollInitialInterval: time.Millisecond}
+
+	client, err := clients.NewDisperserClient(config, nil, clients.WithSigner(signer))
+	assert.NoError(t, err)
+	defer client.Close()
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+	assert.NotNil(t, result.BlobInfo)
+	assert.Equal(t, signer.AccountID(), server.Requests()[0].GetAccountId())
+
+	// The rejection of the account is translated by the high-level client too
+	client, err = clients.NewDisperserClient(config, nil, clients.WithSigner(newTestSigner(t, otherSignerKey)))
+	assert.NoError(t, err)
+	defer client.Close()
+	_, _, err = client.DisperseBlobAuthenticated(context.Background(), []byte("data"), testSecurityParams)
+	assert.ErrorIs(t, err, clients.ErrUnknownAccount)
+
+	client, err = clients.NewDisperserClient(config, nil)
+	assert.NoError(t, err)
+	defer client.Close()
+	_, _, err = client.DisperseBlobAuthenticated(context.Background(), []byte("data"), testSecurityParams)
+	assert.ErrorContains(t, err, "requires a signer")
+	assert.Len(t, server.Requests(), 1)
+}
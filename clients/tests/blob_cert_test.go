@@ -0,0 +1,92 @@
+package retriever_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	rollupbindings "github.com/Layr-Labs/eigenda/contracts/bindings/MockRollup"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// readBlobCert returns the cert in testdata/blob_cert.hex, which is the encoding clients.BlobCert produced for the
+// second blob of testdata/confirmed_batch.json. It wasn't taken from a live chain, so it only guards against changes
+// of the encoding, whose compatibility with the contracts is checked against the calldata packed with their ABI.
+func readBlobCert(t *testing.T) []byte {
+	data, err := os.ReadFile("testdata/blob_cert.hex")
+	assert.NoError(t, err)
+	cert, err := hexutil.Decode(strings.TrimSpace(string(data)))
+	assert.NoError(t, err)
+	return cert
+}
+
+// abiWord returns the 32-byte word of the ABI encoding at the index as an integer
+func abiWord(data []byte, index int) uint64 {
+	return new(big.Int).SetBytes(data[32*index : 32*(index+1)]).Uint64()
+}
+
+func TestBlobCertEncoding(t *testing.T) {
+	address, metadataHash, blobInfos := readConfirmedBatch(t)
+	encoded := readBlobCert(t)
+
+	cert, err := clients.NewBlobCert(blobInfos[1])
+	assert.NoError(t, err)
+	data, err := cert.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(encoded), hexutil.Encode(data))
+
+	// The encoding is the calldata of the contracts verifying the cert after the selector
+	rollupABI, err := rollupbindings.ContractMockRollupMetaData.GetAbi()
+	assert.NoError(t, err)
+	calldata, err := rollupABI.Pack("postCommitment", cert.BlobHeader, cert.BlobVerificationProof)
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, calldata[4:])
+
+	// The words of the layout of abi.encode(BlobHeader, BlobVerificationProof): the offsets of the two structs, the
+	// dataLength after the inline commitment, then the batchId, blobIndex and the confirmationBlockNumber of the
+	// BatchMetadata of the proof
+	assert.Equal(t, uint64(0x40), abiWord(encoded, 0))
+	assert.Equal(t, uint64(0x1e0), abiWord(encoded, 1))
+	assert.Equal(t, uint64(blobInfos[1].GetBlobHeader().GetDataLength()), abiWord(encoded, 4))
+	assert.Equal(t, uint64(37), abiWord(encoded, 15))
+	assert.Equal(t, uint64(1), abiWord(encoded, 16))
+	assert.Equal(t, uint64(1210), abiWord(encoded, 23))
+
+	decoded, err := clients.DecodeBlobCert(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, cert, decoded)
+	batchHeaderHash, err := decoded.BatchHeaderHash()
+	assert.NoError(t, err)
+	assert.Equal(t, blobInfos[1].GetBlobVerificationProof().GetBatchMetadata().GetBatchHeaderHash(), batchHeaderHash[:])
+
+	caller := newServiceManagerCaller(t, address, map[uint32][32]byte{37: metadataHash})
+	assert.NoError(t, clients.VerifyBlobCert(context.Background(), caller, address, decoded))
+}
+
+func TestBlobCertFailures(t *testing.T) {
+	address, metadataHash, _ := readConfirmedBatch(t)
+	encoded := readBlobCert(t)
+
+	_, err := clients.DecodeBlobCert(encoded[:len(encoded)-32])
+	assert.ErrorIs(t, err, clients.ErrInvalidBlobInfo)
+	_, err = clients.DecodeBlobCert(append(append([]byte{}, encoded...), make([]byte, 32)...))
+	assert.ErrorIs(t, err, clients.ErrInvalidBlobInfo)
+	assert.ErrorContains(t, err, "isn't in the ABI encoding of the contracts")
+
+	// The batch of the cert isn't confirmed
+	cert, err := clients.DecodeBlobCert(encoded)
+	assert.NoError(t, err)
+	caller := newServiceManagerCaller(t, address, map[uint32][32]byte{})
+	assert.ErrorIs(t, clients.VerifyBlobCert(context.Background(), caller, address, cert), clients.ErrBatchNotConfirmed)
+
+	caller = newServiceManagerCaller(t, address, map[uint32][32]byte{37: metadataHash})
+	cert.BlobVerificationProof.BlobIndex = 0
+	assert.ErrorIs(t, clients.VerifyBlobCert(context.Background(), caller, address, cert), clients.ErrBlobNotIncluded)
+	cert.BlobVerificationProof.BlobIndex = 1
+	cert.BlobVerificationProof.BatchMetadata.Fee = big.NewInt(1)
+	assert.ErrorIs(t, clients.VerifyBlobCert(context.Background(), caller, address, cert), clients.ErrBatchMetadataMismatch)
+}
@@ -0,0 +1,158 @@
+package retriever_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+const testG1Path = "../../inabox/resources/kzg/g1.point"
+
+// blobCommitmentVector is a payload along with the commitment and length the disperser computes for it.
+// The vectors in testdata/blob_commitments.json were computed with the encoder of the disperser.
+type blobCommitmentVector struct {
+	Name        string `json:"name"`
+	Payload     string `json:"payload"`
+	Length      uint   `json:"length"`
+	CommitmentX string `json:"commitmentX"`
+	CommitmentY string `json:"commitmentY"`
+}
+
+func readBlobCommitmentVectors(t *testing.T) []blobCommitmentVector {
+	data, err := os.ReadFile("testdata/blob_commitments.json")
+	assert.NoError(t, err)
+	var vectors []blobCommitmentVector
+	assert.NoError(t, json.Unmarshal(data, &vectors))
+	assert.NotEmpty(t, vectors)
+	return vectors
+}
+
+func TestBlobCommitmentVectors(t *testing.T) {
+	committer, err := clients.NewBlobCommitter(testG1Path, 3000)
+	assert.NoError(t, err)
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+
+	for _, vector := range readBlobCommitmentVectors(t) {
+		t.Run(vector.Name, func(t *testing.T) {
+			payload, err := hex.DecodeString(vector.Payload)
+			assert.NoError(t, err)
+
+			commitment, length, err := committer.ComputeCommitment(payload)
+			assert.NoError(t, err)
+			assert.Equal(t, vector.Length, length)
+			x := commitment.X.Bytes()
+			y := commitment.Y.Bytes()
+			assert.Equal(t, vector.CommitmentX, hex.EncodeToString(x[:]))
+			assert.Equal(t, vector.CommitmentY, hex.EncodeToString(y[:]))
+
+			// The commitment doesn't depend on the encoding parameters the disperser picks
+			for _, params := range []core.EncodingParams{{ChunkLength: 128, NumChunks: 4}, {ChunkLength: 8, NumChunks: 256}} {
+				commitments, _, err := encoder.Encode(payload, params)
+				assert.NoError(t, err)
+				assert.Equal(t, commitments.Length, length)
+				assert.True(t, bn254.EqualG1(commitments.Commitment.G1Point, commitment.G1Point))
+			}
+		})
+	}
+}
+
+func TestBlobCommitterLimits(t *testing.T) {
+	committer, err := clients.NewBlobCommitter(testG1Path, 2)
+	assert.NoError(t, err)
+
+	_, _, err = committer.ComputeCommitment(nil)
+	assert.ErrorContains(t, err, "blob is empty")
+
+	_, length, err := committer.ComputeCommitment(make([]byte, 62))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), length)
+
+	_, _, err = committer.ComputeCommitment(make([]byte, 63))
+	assert.ErrorContains(t, err, "exceeds the 2 loaded SRS points")
+}
+
+func TestDisperseAndWaitCommitmentCheck(t *testing.T) {
+	committer, err := clients.NewBlobCommitter(testG1Path, 3000)
+	assert.NoError(t, err)
+	commitment, length, err := committer.ComputeCommitment(gettysburgAddressBytes)
+	assert.NoError(t, err)
+	serialized, err := commitment.Serialize()
+	assert.NoError(t, err)
+	other, _, err := committer.ComputeCommitment([]byte("another blob"))
+	assert.NoError(t, err)
+	otherSerialized, err := other.Serialize()
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name string
+		// tamper changes the blob header returned by the disperser, which computes the right one
+		tamper func(header *disperser_rpc.BlobHeader)
+		err    error
+	}{
+		{name: "matching"},
+		{name: "different commitment", tamper: func(header *disperser_rpc.BlobHeader) { header.Commitment = otherSerialized }, err: clients.ErrCommitmentMismatch},
+		{name: "different length", tamper: func(header *disperser_rpc.BlobHeader) { header.DataLength++ }, err: clients.ErrCommitmentMismatch},
+		{name: "invalid commitment", tamper: func(header *disperser_rpc.BlobHeader) { header.Commitment = []byte{1, 2, 3} }, err: clients.ErrCommitmentMismatch},
+		{name: "missing header", err: clients.ErrCommitmentMismatch},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := dispersertest.NewDisperser(dispersertest.Config{Committer: committer})
+			if c.tamper != nil {
+				server.TamperBlobInfo(func(info *disperser_rpc.BlobInfo) { c.tamper(info.BlobHeader) })
+			} else if c.err != nil {
+				server.TamperBlobInfo(func(info *disperser_rpc.BlobInfo) { info.BlobHeader = nil })
+			}
+			host, port, err := net.SplitHostPort(startTestDisperser(t, server))
+			assert.NoError(t, err)
+			client, err := clients.NewDisperserClient(&clients.DisperserClientConfig{
+				Hostname:                  host,
+				Port:                      port,
+				Timeout:                   time.Second,
+				StatusPollInitialInterval: time.Millisecond,
+			}, nil, clients.WithCommitmentCheck(committer))
+			assert.NoError(t, err)
+			defer client.Close()
+
+			result, err := client.DisperseAndWait(context.Background(), gettysburgAddressBytes, testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+			assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+				assert.Nil(t, result.BlobInfo)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, serialized, result.BlobInfo.GetBlobHeader().GetCommitment())
+				assert.Equal(t, uint32(length), result.BlobInfo.GetBlobHeader().GetDataLength())
+			}
+		})
+	}
+}
+
+func TestDisperseAndWaitCommitmentCheckBlobTooLarge(t *testing.T) {
+	committer, err := clients.NewBlobCommitter(testG1Path, 1)
+	assert.NoError(t, err)
+	server := dispersertest.NewDisperser(dispersertest.Config{})
+	host, port, err := net.SplitHostPort(startTestDisperser(t, server))
+	assert.NoError(t, err)
+	client, err := clients.NewDisperserClient(&clients.DisperserClientConfig{Hostname: host, Port: port, Timeout: time.Second}, nil, clients.WithCommitmentCheck(committer))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	// The blob is not dispersed if its commitment can't be checked
+	result, err := client.DisperseAndWait(context.Background(), gettysburgAddressBytes, testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.ErrorContains(t, err, "failed to compute blob commitment")
+	assert.Nil(t, result.RequestID)
+	assert.Empty(t, server.Requests())
+}
@@ -0,0 +1,286 @@
+package retriever_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	retriever_rpc "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/Layr-Labs/eigenda/clients"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// confirmedBatch are the BlobInfos of the blobs of a confirmed batch, along with the batch metadata hash
+// EigenDAServiceManager stored for it. The batch in testdata/confirmed_batch.json was built with the hashing and
+// merkle tree of the batcher.
+type confirmedBatch struct {
+	ServiceManager    string            `json:"serviceManager"`
+	BatchMetadataHash string            `json:"batchMetadataHash"`
+	BlobInfos         []json.RawMessage `json:"blobInfos"`
+}
+
+func readConfirmedBatch(t *testing.T) (gcommon.Address, [32]byte, []*disperser_rpc.BlobInfo) {
+	data, err := os.ReadFile("testdata/confirmed_batch.json")
+	assert.NoError(t, err)
+	var batch confirmedBatch
+	assert.NoError(t, json.Unmarshal(data, &batch))
+	var blobInfos []*disperser_rpc.BlobInfo
+	for _, raw := range batch.BlobInfos {
+		blobInfo := &disperser_rpc.BlobInfo{}
+		assert.NoError(t, protojson.Unmarshal(raw, blobInfo))
+		blobInfos = append(blobInfos, blobInfo)
+	}
+	assert.NotEmpty(t, blobInfos)
+	return gcommon.HexToAddress(batch.ServiceManager), gcommon.HexToHash(batch.BatchMetadataHash), blobInfos
+}
+
+// serviceManagerCaller serves the batchIdToBatchMetadataHash calls to the EigenDAServiceManager at the address
+type serviceManagerCaller struct {
+	t              *testing.T
+	address        gcommon.Address
+	metadataHashes map[uint32][32]byte
+	err            error
+}
+
+func newServiceManagerCaller(t *testing.T, address gcommon.Address, metadataHashes map[uint32][32]byte) *serviceManagerCaller {
+	return &serviceManagerCaller{t: t, address: address, metadataHashes: metadataHashes}
+}
+
+func (c *serviceManagerCaller) CodeAt(ctx context.Context, contract gcommon.Address, blockNumber *big.Int) ([]byte, error) {
+	if contract != c.address {
+		return nil, nil
+	}
+	return []byte{0x60}, nil
+}
+
+func (c *serviceManagerCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if call.To == nil || *call.To != c.address {
+		return nil, nil
+	}
+	serviceManagerABI, err := binding.ContractEigenDAServiceManagerMetaData.GetAbi()
+	assert.NoError(c.t, err)
+	method, err := serviceManagerABI.MethodById(call.Data[:4])
+	assert.NoError(c.t, err)
+	assert.Equal(c.t, "batchIdToBatchMetadataHash", method.Name)
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	assert.NoError(c.t, err)
+	// Unknown batch IDs map to the zero hash, as in the contract
+	return abi.Arguments(method.Outputs).Pack(c.metadataHashes[args[0].(uint32)])
+}
+
+func TestVerifyBlobInclusion(t *testing.T) {
+	address, metadataHash, blobInfos := readConfirmedBatch(t)
+	caller := newServiceManagerCaller(t, address, map[uint32][32]byte{37: metadataHash})
+
+	for _, blobInfo := range blobInfos {
+		assert.NoError(t, clients.VerifyBlobInclusion(context.Background(), caller, address, blobInfo))
+
+		// The batch header hash of the BlobInfo isn't part of the metadata confirmed on-chain, and is the hash of the
+		// reduced batch header the blob is retrieved with
+		metadata := blobInfo.GetBlobVerificationProof().GetBatchMetadata()
+		batchHeader := core.BatchHeader{ReferenceBlockNumber: uint(metadata.GetBatchHeader().GetReferenceBlockNumber())}
+		copy(batchHeader.BatchRoot[:], metadata.GetBatchHeader().GetBatchRoot())
+		batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+		assert.NoError(t, err)
+		assert.Equal(t, batchHeaderHash[:], metadata.GetBatchHeaderHash())
+	}
+}
+
+func TestVerifyBlobInclusionFailures(t *testing.T) {
+	address, metadataHash, blobInfos := readConfirmedBatch(t)
+	caller := newServiceManagerCaller(t, address, map[uint32][32]byte{37: metadataHash})
+	blobInfo := blobInfos[1]
+
+	tests := []struct {
+		name   string
+		tamper func(blobInfo *disperser_rpc.BlobInfo)
+		err    error
+	}{
+		{
+			name:   "missing batch header",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) { blobInfo.BlobVerificationProof.BatchMetadata.BatchHeader = nil },
+			err:    clients.ErrInvalidBlobInfo,
+		},
+		{
+			name: "truncated inclusion proof",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) {
+				blobInfo.BlobVerificationProof.InclusionProof = make([]byte, 31)
+			},
+			err: clients.ErrInvalidBlobInfo,
+		},
+		{
+			name:   "invalid commitment",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) { blobInfo.BlobHeader.Commitment = []byte{1, 2, 3} },
+			err:    clients.ErrInvalidBlobInfo,
+		},
+		{
+			name:   "unconfirmed batch",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) { blobInfo.BlobVerificationProof.BatchId = 38 },
+			err:    clients.ErrBatchNotConfirmed,
+		},
+		{
+			name: "wrong confirmation block",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) {
+				blobInfo.BlobVerificationProof.BatchMetadata.ConfirmationBlockNumber++
+			},
+			err: clients.ErrBatchMetadataMismatch,
+		},
+		{
+			name: "wrong signed percentages",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) {
+				blobInfo.BlobVerificationProof.BatchMetadata.BatchHeader.QuorumSignedPercentages[1] = 100
+			},
+			err: clients.ErrBatchMetadataMismatch,
+		},
+		{
+			name:   "wrong blob index",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) { blobInfo.BlobVerificationProof.BlobIndex = 0 },
+			err:    clients.ErrBlobNotIncluded,
+		},
+		{
+			name:   "wrong data length",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) { blobInfo.BlobHeader.DataLength++ },
+			err:    clients.ErrBlobNotIncluded,
+		},
+		{
+			name: "wrong security params",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) {
+				blobInfo.BlobHeader.BlobQuorumParams[0].AdversaryThresholdPercentage = 10
+			},
+			err: clients.ErrBlobNotIncluded,
+		},
+		{
+			name: "wrong inclusion proof",
+			tamper: func(blobInfo *disperser_rpc.BlobInfo) {
+				blobInfo.BlobVerificationProof.InclusionProof[0] ^= 1
+			},
+			err: clients.ErrBlobNotIncluded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tampered := proto.Clone(blobInfo).(*disperser_rpc.BlobInfo)
+			test.tamper(tampered)
+			err := clients.VerifyBlobInclusion(context.Background(), caller, address, tampered)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestVerifyBlobInclusionRPCFailure(t *testing.T) {
+	address, metadataHash, blobInfos := readConfirmedBatch(t)
+	caller := newServiceManagerCaller(t, address, map[uint32][32]byte{37: metadataHash})
+	caller.err = errors.New("connection refused")
+
+	err := clients.VerifyBlobInclusion(context.Background(), caller, address, blobInfos[0])
+	assert.ErrorIs(t, err, caller.err)
+	assert.NotErrorIs(t, err, clients.ErrBatchNotConfirmed)
+
+	// A contract that isn't there isn't mistaken for an unconfirmed batch
+	caller.err = nil
+	err = clients.VerifyBlobInclusion(context.Background(), caller, gcommon.HexToAddress("0x01"), blobInfos[0])
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, clients.ErrBatchNotConfirmed)
+}
+
+// retrievedBlobProof returns the proof the retriever returns for the blob at the index of a batch of blobs of other
+// lengths, along with the hash of the batch header
+func retrievedBlobProof(t *testing.T, index int) ([32]byte, *retriever_rpc.BlobInclusionProof) {
+	commitment := &core.Commitment{G1Point: &bn254.GenG1}
+	var blobHeaders []*core.BlobHeader
+	for length := uint(1); length <= 3; length++ {
+		blobHeaders = append(blobHeaders, &core.BlobHeader{
+			BlobCommitments: core.BlobCommitments{Commitment: commitment, Length: length},
+			QuorumInfos: []*core.BlobQuorumInfo{{
+				SecurityParam:      core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 80},
+				QuantizationFactor: 1,
+			}},
+		})
+	}
+	batchHeader := core.BatchHeader{ReferenceBlockNumber: 100}
+	tree, err := batchHeader.SetBatchRoot(blobHeaders)
+	assert.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+
+	encoded, err := blobHeaders[index].Encode()
+	assert.NoError(t, err)
+	leaf, err := blobHeaders[index].GetBlobHeaderHash()
+	assert.NoError(t, err)
+	proof, err := tree.GenerateProof(leaf[:], 0)
+	assert.NoError(t, err)
+	return batchHeaderHash, &retriever_rpc.BlobInclusionProof{
+		BlobHeader:           encoded,
+		Hashes:               proof.Hashes,
+		Index:                uint32(proof.Index),
+		BatchRoot:            batchHeader.BatchRoot[:],
+		ReferenceBlockNumber: 100,
+	}
+}
+
+func TestVerifyRetrievedBlobInclusion(t *testing.T) {
+	for index := 0; index < 3; index++ {
+		batchHeaderHash, proof := retrievedBlobProof(t, index)
+		assert.NoError(t, clients.VerifyRetrievedBlobInclusion(batchHeaderHash, proof))
+	}
+
+	batchHeaderHash, proof := retrievedBlobProof(t, 1)
+	tests := []struct {
+		name   string
+		tamper func(proof *retriever_rpc.BlobInclusionProof)
+		err    error
+	}{
+		{
+			name:   "other reference block",
+			tamper: func(proof *retriever_rpc.BlobInclusionProof) { proof.ReferenceBlockNumber++ },
+			err:    clients.ErrBatchHeaderMismatch,
+		},
+		{
+			name:   "other batch root",
+			tamper: func(proof *retriever_rpc.BlobInclusionProof) { proof.BatchRoot[0] ^= 1 },
+			err:    clients.ErrBatchHeaderMismatch,
+		},
+		{
+			name:   "other blob header",
+			tamper: func(proof *retriever_rpc.BlobInclusionProof) { proof.BlobHeader[len(proof.BlobHeader)-1] ^= 1 },
+			err:    clients.ErrBlobNotIncluded,
+		},
+		{
+			name:   "other index",
+			tamper: func(proof *retriever_rpc.BlobInclusionProof) { proof.Index = 0 },
+			err:    clients.ErrBlobNotIncluded,
+		},
+		{
+			name:   "truncated proof",
+			tamper: func(proof *retriever_rpc.BlobInclusionProof) { proof.Hashes = proof.Hashes[1:] },
+			err:    clients.ErrBlobNotIncluded,
+		},
+		{
+			name:   "missing blob header",
+			tamper: func(proof *retriever_rpc.BlobInclusionProof) { proof.BlobHeader = nil },
+			err:    clients.ErrBlobNotIncluded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tampered := proto.Clone(proof).(*retriever_rpc.BlobInclusionProof)
+			test.tamper(tampered)
+			assert.ErrorIs(t, clients.VerifyRetrievedBlobInclusion(batchHeaderHash, tampered), test.err)
+		})
+	}
+}
@@ -0,0 +1,398 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testSecurityParams = []*core.SecurityParam{{
+	QuorumID:           0,
+	AdversaryThreshold: 50,
+	QuorumThreshold:    100,
+}}
+
+// startTestDisperser serves the fake disperser on a free port until the end of the test and returns its address
+func startTestDisperser(t *testing.T, server *dispersertest.Disperser) string {
+	address, err := server.Start("127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(server.Stop)
+	return address
+}
+
+func newTestDisperserClient(t *testing.T, config clients.DisperserClientConfig, primary string, backups ...string) (clients.DisperserClient, *clients.DisperserClientMetrics) {
+	host, port, err := net.SplitHostPort(primary)
+	assert.NoError(t, err)
+	config.Hostname = host
+	config.Port = port
+	config.BackupEndpoints = backups
+	if config.Timeout == 0 {
+		config.Timeout = time.Second
+	}
+	if config.StatusPollInitialInterval == 0 {
+		config.StatusPollInitialInterval = time.Millisecond
+		config.StatusPollMaxInterval = 5 * time.Millisecond
+	}
+
+	metrics := clients.NewDisperserClientMetrics(prometheus.NewRegistry())
+	client, err := clients.NewDisperserClient(&config, metrics)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, metrics
+}
+
+// startFakeDisperser serves a fake disperser with the given schedule and returns a client connected to it
+func startFakeDisperser(t *testing.T, schedule dispersertest.Schedule) (clients.DisperserClient, *dispersertest.Disperser) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: schedule})
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{}, startTestDisperser(t, server))
+	return client, server
+}
+
+// unreachableAddress returns the address of a port that nothing listens on
+func unreachableAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+	return address
+}
+
+func TestDisperseAndWaitConfirmed(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{ProcessingPolls: 2})
+
+	var progress []disperser_rpc.BlobStatus
+	var progressIDs [][]byte
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, func(requestID []byte, status disperser_rpc.BlobStatus) {
+		progressIDs = append(progressIDs, requestID)
+		progress = append(progress, status)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+	assert.Equal(t, [][]byte{result.RequestID, result.RequestID}, progressIDs)
+	assert.Equal(t, uint32(1), result.BlobInfo.GetBlobVerificationProof().GetBatchId())
+	assert.Equal(t, uint32(0), result.BlobInfo.GetBlobVerificationProof().GetBlobIndex())
+	assert.Equal(t, []disperser_rpc.BlobStatus{disperser_rpc.BlobStatus_PROCESSING, disperser_rpc.BlobStatus_CONFIRMED}, progress)
+	assert.Equal(t, 3, server.NumStatusQueries())
+
+	requests := server.Requests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, []byte("data"), requests[0].GetData())
+	assert.Equal(t, []*disperser_rpc.SecurityParams{{QuorumId: 0, AdversaryThreshold: 50, QuorumThreshold: 100}}, requests[0].GetSecurityParams())
+}
+
+func TestDisperseAndWaitFinalized(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{ProcessingPolls: 1, ConfirmedPolls: 1})
+	server.InjectStatusFaults(dispersertest.StatusFault{}, dispersertest.StatusFault{Err: status.Error(codes.Unavailable, "disperser is restarting")})
+
+	var progress []disperser_rpc.BlobStatus
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_FINALIZED, func(requestID []byte, status disperser_rpc.BlobStatus) {
+		progress = append(progress, status)
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.Equal(t, []disperser_rpc.BlobStatus{disperser_rpc.BlobStatus_PROCESSING, disperser_rpc.BlobStatus_CONFIRMED, disperser_rpc.BlobStatus_FINALIZED}, progress)
+	assert.Equal(t, 5, result.NumPolls)
+}
+
+func TestDisperseAndWaitFailed(t *testing.T) {
+	for _, terminalStatus := range []disperser_rpc.BlobStatus{disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES} {
+		client, server := startFakeDisperser(t, dispersertest.Schedule{ProcessingPolls: 1, FailWith: terminalStatus})
+
+		result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_FINALIZED, nil)
+		assert.ErrorIs(t, err, clients.ErrDispersalFailed)
+		assert.NotErrorIs(t, err, clients.ErrDispersalTimeout)
+		assert.Nil(t, result.BlobInfo)
+		assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+	}
+}
+
+func TestDisperseAndWaitTimeout(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{ProcessingPolls: 1, NeverFinalize: true})
+
+	// The blob is confirmed but never finalized
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result, err := client.DisperseAndWait(ctx, []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_FINALIZED, nil)
+	assert.ErrorIs(t, err, clients.ErrDispersalTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, clients.ErrDispersalFailed)
+	assert.Nil(t, result.BlobInfo)
+	// The request ID can be used to keep polling the status of the blob
+	assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+}
+
+func TestDisperseAndWaitStatusError(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{ProcessingPolls: 1})
+	server.InjectStatusFaults(dispersertest.StatusFault{}, dispersertest.StatusFault{Err: status.Error(codes.Internal, "internal error")})
+
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(errors.Unwrap(err)))
+	assert.NotErrorIs(t, err, clients.ErrDispersalTimeout)
+	assert.NotErrorIs(t, err, clients.ErrDispersalFailed)
+	assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+}
+
+func TestDisperseAndWaitNonRetryableStatusErrors(t *testing.T) {
+	for _, code := range []codes.Code{codes.InvalidArgument, codes.NotFound} {
+		client, server := startFakeDisperser(t, dispersertest.Schedule{})
+		server.InjectStatusFaults(dispersertest.StatusFault{Err: status.Error(code, "rejected")})
+
+		result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+		assert.Equal(t, code, status.Code(errors.Unwrap(err)))
+		assert.Nil(t, result.BlobInfo)
+		assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+		assert.Equal(t, 1, result.NumPolls)
+		assert.Equal(t, 1, server.NumStatusQueries())
+	}
+}
+
+func TestDisperseAndWaitRetriesTimedOutPoll(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{})
+	server.InjectStatusFaults(
+		dispersertest.StatusFault{Delay: time.Second},
+		dispersertest.StatusFault{Err: status.Error(codes.Unavailable, "disperser is restarting")},
+	)
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{Timeout: 50 * time.Millisecond}, startTestDisperser(t, server))
+
+	// The first poll times out and the second fails, but the dispersal is still waited for
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.Equal(t, 3, result.NumPolls)
+}
+
+func TestDisperseAndWaitDroppedPolls(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{ProcessingPolls: 2, ConfirmedPolls: 2}})
+	server.DropStatusPolls(0.5)
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{}, startTestDisperser(t, server))
+
+	// The dropped polls are retried until the blob is finalized
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_FINALIZED, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.GreaterOrEqual(t, result.NumPolls, 6)
+	assert.Equal(t, result.NumPolls, server.NumStatusQueries())
+}
+
+func TestDisperseAndWaitDelayedConfirmation(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{})
+	server.DelayConfirmations(50 * time.Millisecond)
+
+	start := time.Now()
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Greater(t, result.NumPolls, 1)
+}
+
+func TestDisperseAndWaitBackoff(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{ProcessingPolls: 3}})
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{
+		StatusPollInitialInterval: 10 * time.Millisecond,
+		StatusPollMaxInterval:     20 * time.Millisecond,
+		StatusPollMultiplier:      1.5,
+	}, startTestDisperser(t, server))
+
+	// The polls wait 10ms, 15ms, 20ms and 20ms
+	start := time.Now()
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.NumPolls)
+	assert.GreaterOrEqual(t, result.TotalWait, 65*time.Millisecond)
+	assert.LessOrEqual(t, result.TotalWait, time.Since(start))
+}
+
+func TestDisperseAndWaitJitter(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{ProcessingPolls: 2}})
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{
+		StatusPollInitialInterval: 10 * time.Millisecond,
+		StatusPollMaxInterval:     10 * time.Millisecond,
+		StatusPollMultiplier:      1,
+		StatusPollJitter:          0.5,
+	}, startTestDisperser(t, server))
+
+	// Each poll waits between 5ms and 15ms
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.NumPolls)
+	assert.GreaterOrEqual(t, result.TotalWait, 15*time.Millisecond)
+
+	for _, jitter := range []float64{-0.1, 1.5} {
+		_, err = clients.NewDisperserClient(&clients.DisperserClientConfig{Hostname: "localhost", Port: "1", StatusPollJitter: jitter}, nil)
+		assert.ErrorContains(t, err, "invalid status poll jitter")
+	}
+}
+
+func TestDisperseAndWaitInvalidTargetStatus(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{})
+
+	_, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_PROCESSING, nil)
+	assert.Error(t, err)
+	assert.Len(t, server.Requests(), 0)
+}
+
+func TestDisperseBlobRejected(t *testing.T) {
+	client, server := startFakeDisperser(t, dispersertest.Schedule{})
+	server.RejectNextDispersals(1, status.Error(codes.ResourceExhausted, "rate limited"))
+
+	_, _, err := client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, _, err = client.DisperseBlob(context.Background(), nil, testSecurityParams)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, requestID, err := client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+	assert.Equal(t, server.RequestIDs(), [][]byte{requestID})
+}
+
+func TestDisperseBlobFailover(t *testing.T) {
+	backup := dispersertest.NewDisperser(dispersertest.Config{})
+	backupAddress := startTestDisperser(t, backup)
+	primaryAddress := unreachableAddress(t)
+	client, metrics := newTestDisperserClient(t, clients.DisperserClientConfig{UnhealthyThreshold: 1}, primaryAddress, backupAddress)
+
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.Equal(t, backup.RequestIDs(), [][]byte{result.RequestID})
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumFailovers.WithLabelValues(primaryAddress)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.EndpointHealthy.WithLabelValues(primaryAddress)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.EndpointHealthy.WithLabelValues(backupAddress)))
+
+	// The primary is unhealthy, so the next dispersal goes to the backup first
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+	assert.Len(t, backup.Requests(), 2)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumRequests.WithLabelValues(primaryAddress, "DisperseBlob", "failure")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumFailovers.WithLabelValues(primaryAddress)))
+}
+
+func TestDisperseBlobFailoverCooldown(t *testing.T) {
+	backup := dispersertest.NewDisperser(dispersertest.Config{})
+	backupAddress := startTestDisperser(t, backup)
+	primaryAddress := unreachableAddress(t)
+	client, metrics := newTestDisperserClient(t, clients.DisperserClientConfig{UnhealthyThreshold: 1, UnhealthyCooldown: 50 * time.Millisecond}, primaryAddress, backupAddress)
+	failures := func() float64 {
+		return testutil.ToFloat64(metrics.NumRequests.WithLabelValues(primaryAddress, "DisperseBlob", "failure"))
+	}
+
+	_, _, err := client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, failures())
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, failures())
+
+	// The primary is tried first again once the cooldown is over, and fails over to the backup while it's still down
+	time.Sleep(60 * time.Millisecond)
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, failures())
+	assert.Len(t, backup.Requests(), 3)
+
+	// Which restarts the cooldown
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, failures())
+}
+
+func TestDisperseAndWaitPrimaryOutageDuringPolling(t *testing.T) {
+	primary := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{ProcessingPolls: 1}})
+	primaryAddress := startTestDisperser(t, primary)
+	backup := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{FailWith: disperser_rpc.BlobStatus_FAILED}})
+	backupAddress := startTestDisperser(t, backup)
+	client, metrics := newTestDisperserClient(t, clients.DisperserClientConfig{}, primaryAddress, backupAddress)
+
+	// The primary goes down right after it accepted the dispersal, and comes back up after a while
+	restarted := make(chan struct{})
+	var progress []disperser_rpc.BlobStatus
+	onProgress := func(requestID []byte, status disperser_rpc.BlobStatus) {
+		progress = append(progress, status)
+		if status != disperser_rpc.BlobStatus_PROCESSING || len(progress) > 1 {
+			return
+		}
+		primary.Stop()
+		go func() {
+			defer close(restarted)
+			time.Sleep(100 * time.Millisecond)
+			_, err := primary.Start(primaryAddress)
+			assert.NoError(t, err)
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := client.DisperseAndWait(ctx, []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, onProgress)
+	<-restarted
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.Equal(t, primary.RequestIDs(), [][]byte{result.RequestID})
+	assert.Equal(t, []disperser_rpc.BlobStatus{disperser_rpc.BlobStatus_PROCESSING, disperser_rpc.BlobStatus_CONFIRMED}, progress)
+
+	// The status of the request was only queried on the disperser that accepted it
+	assert.Len(t, backup.Requests(), 0)
+	assert.Equal(t, 0, backup.NumStatusQueries())
+	assert.Greater(t, testutil.ToFloat64(metrics.NumRequests.WithLabelValues(primaryAddress, "GetBlobStatus", "failure")), 0.0)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.NumFailovers.WithLabelValues(primaryAddress)))
+}
+
+func TestGetBlobStatusUnknownRequest(t *testing.T) {
+	primaryAddress := startTestDisperser(t, dispersertest.NewDisperser(dispersertest.Config{}))
+	backupAddress := startTestDisperser(t, dispersertest.NewDisperser(dispersertest.Config{}))
+	// The request was dispersed to the backup by another client
+	otherClient, _ := newTestDisperserClient(t, clients.DisperserClientConfig{}, backupAddress)
+	_, requestID, err := otherClient.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{}, primaryAddress, backupAddress)
+	reply, err := client.GetBlobStatus(context.Background(), requestID)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, reply.GetStatus())
+
+	_, err = client.GetBlobStatus(context.Background(), []byte("unknown"))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetBlobStatusHedged(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{})
+	address := startTestDisperser(t, server)
+	client, metrics := newTestDisperserClient(t, clients.DisperserClientConfig{StatusHedgeDelay: 10 * time.Millisecond}, address)
+
+	_, requestID, err := client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.NoError(t, err)
+
+	// The first query is slow, so the hedged query returns first
+	server.InjectStatusFaults(dispersertest.StatusFault{Delay: 5 * time.Second})
+	start := time.Now()
+	reply, err := client.GetBlobStatus(context.Background(), requestID)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED, reply.GetStatus())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 2, server.NumStatusQueries())
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumHedgedRequests.WithLabelValues(address)))
+}
+
+func TestDisperseAndWaitBufconn(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{})
+	t.Cleanup(server.Stop)
+	// The address is ignored by the dialer of the in-memory server
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{GRPCOptions: server.StartBufconn()}, "bufconn:0")
+
+	result, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_FINALIZED, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.BlobInfo)
+	assert.Equal(t, server.RequestIDs(), [][]byte{result.RequestID})
+}
@@ -0,0 +1,88 @@
+package retriever_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodRecorder is an interceptor that records the methods called through it
+type methodRecorder struct {
+	mu      sync.Mutex
+	methods []string
+}
+
+func (r *methodRecorder) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	r.mu.Lock()
+	r.methods = append(r.methods, method)
+	r.mu.Unlock()
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (r *methodRecorder) calledMethods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.methods...)
+}
+
+func TestDisperserClientGRPCOptions(t *testing.T) {
+	address := startTestDisperser(t, dispersertest.NewDisperser(dispersertest.Config{}))
+	recorder := &methodRecorder{}
+	compress := true
+	client, _ := newTestDisperserClient(t, clients.DisperserClientConfig{
+		GRPCOptions: &common.GRPCClientOptions{
+			UseCompression:    &compress,
+			UnaryInterceptors: []grpc.UnaryClientInterceptor{recorder.intercept},
+		},
+	}, address)
+
+	_, err := client.DisperseAndWait(context.Background(), []byte("data"), testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/disperser.Disperser/DisperseBlob", "/disperser.Disperser/GetBlobStatus"}, recorder.calledMethods())
+
+	// The status reply doesn't fit in the receive limit
+	client, _ = newTestDisperserClient(t, clients.DisperserClientConfig{
+		GRPCOptions: &common.GRPCClientOptions{MaxRecvMsgSize: 1},
+	}, address)
+	_, _, err = client.DisperseBlob(context.Background(), []byte("data"), testSecurityParams)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestNodeClientGRPCOptions(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	node.RegisterRetrievalServer(server, &node.UnimplementedRetrievalServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NoError(t, err)
+	socket := core.MakeOperatorSocket(host, "0", port).String()
+
+	recorder := &methodRecorder{}
+	nodeClient := clients.NewNodeClient(time.Second, &common.GRPCClientOptions{
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{recorder.intercept},
+	})
+
+	_, _, err = nodeClient.GetBlobHeader(context.Background(), socket, [32]byte{}, 0)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	nodeClient.GetChunks(context.Background(), core.OperatorID{}, &core.IndexedOperatorInfo{Socket: socket}, [32]byte{}, 0, 0, chunksChan)
+	assert.Equal(t, codes.Unimplemented, status.Code((<-chunksChan).Err))
+
+	assert.Equal(t, []string{"/node.Retrieval/GetBlobHeader", "/node.Retrieval/RetrieveChunks"}, recorder.calledMethods())
+}
@@ -0,0 +1,186 @@
+package retriever_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingCollector records the observations of the clients
+type recordingCollector struct {
+	mu           sync.Mutex
+	observations []clients.RPCObservation
+}
+
+func (c *recordingCollector) ObserveRPC(observation clients.RPCObservation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observations = append(c.observations, observation)
+}
+
+func (c *recordingCollector) recorded() []clients.RPCObservation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]clients.RPCObservation{}, c.observations...)
+}
+
+func newCollectingDisperserClient(t *testing.T, address string, opts ...clients.DisperserClientOption) (clients.DisperserClient, *recordingCollector) {
+	host, port, err := net.SplitHostPort(address)
+	assert.NoError(t, err)
+	collector := &recordingCollector{}
+	config := &clients.DisperserClientConfig{Hostname: host, Port: port, Timeout: time.Second, StatusPollInitialInterval: time.Millisecond}
+	client, err := clients.NewDisperserClient(config, nil, append(opts, clients.WithDisperserMetricsCollector(collector))...)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, collector
+}
+
+func TestDisperserClientMetricsCollector(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{})
+	address := startTestDisperser(t, server)
+	client, collector := newCollectingDisperserClient(t, address)
+
+	data := make([]byte, 1000)
+	_, err := client.DisperseAndWait(context.Background(), data, testSecurityParams, disperser_rpc.BlobStatus_CONFIRMED, nil)
+	assert.NoError(t, err)
+	server.RejectNextDispersals(1, status.Error(codes.ResourceExhausted, "rate limited"))
+	_, _, err = client.DisperseBlob(context.Background(), data, testSecurityParams)
+	assert.Error(t, err)
+
+	observations := collector.recorded()
+	assert.Len(t, observations, 3)
+	for _, observation := range observations {
+		assert.Equal(t, clients.DisperserClientName, observation.Client)
+		assert.Equal(t, address, observation.Address)
+		assert.Positive(t, observation.Latency)
+	}
+	assert.Equal(t, "DisperseBlob", observations[0].Method)
+	assert.Equal(t, codes.OK, observations[0].Code)
+	assert.Greater(t, observations[0].RequestSize, int64(len(data)))
+	assert.Positive(t, observations[0].ReplySize)
+	assert.Equal(t, "GetBlobStatus", observations[1].Method)
+	assert.Equal(t, codes.OK, observations[1].Code)
+	assert.Positive(t, observations[1].ReplySize)
+	assert.Equal(t, "DisperseBlob", observations[2].Method)
+	assert.Equal(t, codes.ResourceExhausted, observations[2].Code)
+	assert.Zero(t, observations[2].ReplySize)
+}
+
+func TestDisperserClientMetricsCollectorAuthenticated(t *testing.T) {
+	signer := newTestSigner(t, testSignerKey)
+	server := dispersertest.NewDisperser(dispersertest.Config{Accounts: []string{signer.AccountID()}})
+	client, collector := newCollectingDisperserClient(t, startTestDisperser(t, server), clients.WithSigner(signer))
+
+	data := make([]byte, 1000)
+	_, _, err := client.DisperseBlobAuthenticated(context.Background(), data, testSecurityParams)
+	assert.NoError(t, err)
+
+	// The messages of the stream add up
+	observations := collector.recorded()
+	assert.Len(t, observations, 1)
+	assert.Equal(t, "DisperseBlobAuthenticated", observations[0].Method)
+	assert.Equal(t, codes.OK, observations[0].Code)
+	assert.Greater(t, observations[0].RequestSize, int64(len(data)))
+	assert.Positive(t, observations[0].ReplySize)
+}
+
+func TestNodeClientMetricsCollector(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	node.RegisterRetrievalServer(server, &node.UnimplementedRetrievalServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+	address := listener.Addr().String()
+	host, port, err := net.SplitHostPort(address)
+	assert.NoError(t, err)
+	socket := core.MakeOperatorSocket(host, "0", port).String()
+
+	collector := &recordingCollector{}
+	nodeClient := clients.NewNodeClient(time.Second, nil, clients.WithNodeMetricsCollector(collector))
+	_, _, err = nodeClient.GetBlobHeader(context.Background(), socket, [32]byte{}, 0)
+	assert.Error(t, err)
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	nodeClient.GetChunks(context.Background(), core.OperatorID{}, &core.IndexedOperatorInfo{Socket: socket}, [32]byte{}, 0, 0, chunksChan)
+	assert.Error(t, (<-chunksChan).Err)
+
+	// The requests to a node that is down are recorded although they are never sent
+	unreachable := unreachableAddress(t)
+	host, port, err = net.SplitHostPort(unreachable)
+	assert.NoError(t, err)
+	_, _, err = nodeClient.GetBlobHeader(context.Background(), core.MakeOperatorSocket(host, "0", port).String(), [32]byte{}, 0)
+	assert.Error(t, err)
+
+	observations := collector.recorded()
+	assert.Len(t, observations, 3)
+	for _, observation := range observations {
+		assert.Equal(t, clients.NodeClientName, observation.Client)
+	}
+	assert.Equal(t, clients.RPCObservation{Client: clients.NodeClientName, Address: address, Method: "GetBlobHeader", Code: codes.Unimplemented, Latency: observations[0].Latency, RequestSize: observations[0].RequestSize}, observations[0])
+	assert.Positive(t, observations[0].RequestSize)
+	assert.Equal(t, "RetrieveChunks", observations[1].Method)
+	assert.Equal(t, codes.Unimplemented, observations[1].Code)
+	assert.Equal(t, unreachable, observations[2].Address)
+	assert.Equal(t, "GetBlobHeader", observations[2].Method)
+	assert.Equal(t, codes.Unavailable, observations[2].Code)
+}
+
+func TestRetrievalClientMetricsCollector(t *testing.T) {
+	setup(t)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	collector := &recordingCollector{}
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithRetrievalMetricsCollector(collector))
+
+	// The second retrieval checks the blob header of every operator against the wrong batch root
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Times(1 + numOperators)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, [32]byte{}, 0)
+	assert.ErrorContains(t, err, "failed to get blob header from all operators")
+
+	observations := collector.recorded()
+	assert.Len(t, observations, 2)
+	assert.Equal(t, clients.RetrievalClientName, observations[0].Client)
+	assert.Equal(t, "RetrieveBlob", observations[0].Method)
+	assert.Equal(t, codes.OK, observations[0].Code)
+	assert.Equal(t, int64(len(data)), observations[0].ReplySize)
+	assert.Equal(t, codes.Unknown, observations[1].Code)
+}
+
+func TestPrometheusMetricsCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	collector := clients.NewPrometheusMetricsCollector(reg, "")
+	collector.ObserveRPC(clients.RPCObservation{Client: clients.NodeClientName, Address: "node:32001", Method: "RetrieveChunks", Code: codes.OK, Latency: time.Second, RequestSize: 64, ReplySize: 4096})
+	collector.ObserveRPC(clients.RPCObservation{Client: clients.NodeClientName, Address: "node:32001", Method: "RetrieveChunks", Code: codes.DeadlineExceeded, Latency: 2 * time.Second})
+	collector.ObserveRPC(clients.RPCObservation{Client: clients.DisperserClientName, Address: "disperser:443", Method: "DisperseBlob", Code: codes.OK})
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.NumRequests.WithLabelValues(clients.NodeClientName, "node:32001", "RetrieveChunks", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.NumRequests.WithLabelValues(clients.NodeClientName, "node:32001", "RetrieveChunks", "DeadlineExceeded")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.NumRequests.WithLabelValues(clients.DisperserClientName, "disperser:443", "DisperseBlob", "OK")))
+	count, err := testutil.GatherAndCount(reg, "eigenda_client_request_duration_seconds", "eigenda_client_reply_size_bytes")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
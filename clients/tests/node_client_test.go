@@ -0,0 +1,279 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// startSilentNode accepts the TCP connections but never completes the gRPC handshake, like a node that is slow to
+// connect. It returns the socket of the node.
+func startSilentNode(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		var conns []net.Conn
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				for _, conn := range conns {
+					_ = conn.Close()
+				}
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NoError(t, err)
+	return core.MakeOperatorSocket(host, "0", port).String()
+}
+
+func TestNodeClientDialRespectsContextDeadline(t *testing.T) {
+	socket := startSilentNode(t)
+	nodeClient := clients.NewNodeClient(10*time.Second, nil)
+
+	// The request is almost out of budget, so the dial gives up long before the timeout of the client
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := nodeClient.GetBlobHeader(ctx, socket, [32]byte{}, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failed to connect to the node")
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	nodeClient.GetChunks(ctx, core.OperatorID{}, &core.IndexedOperatorInfo{Socket: socket}, [32]byte{}, 0, 0, chunksChan)
+	assert.ErrorIs(t, (<-chunksChan).Err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestNodeClientDialTimeout(t *testing.T) {
+	socket := startSilentNode(t)
+	nodeClient := clients.NewNodeClient(50*time.Millisecond, nil)
+
+	// The timeout of the client bounds the dial when the request has a later deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, _, err := nodeClient.GetBlobHeader(ctx, socket, [32]byte{}, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestNodeClientDialRefused(t *testing.T) {
+	host, port, err := net.SplitHostPort(unreachableAddress(t))
+	assert.NoError(t, err)
+	nodeClient := clients.NewNodeClient(10*time.Second, nil)
+
+	// A node that refuses the connections fails the request right away
+	start := time.Now()
+	_, _, err = nodeClient.GetBlobHeader(context.Background(), core.MakeOperatorSocket(host, "0", port).String(), [32]byte{}, 0)
+	assert.ErrorIs(t, err, clients.ErrNodeConnectionFailed)
+	assert.ErrorContains(t, err, "failed to connect to the node")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// countingListener counts the connections it accepts
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+// chunksServer replies to the chunk requests with no chunks
+type chunksServer struct {
+	node.UnimplementedRetrievalServer
+}
+
+func (s *chunksServer) RetrieveChunks(ctx context.Context, request *node.RetrieveChunksRequest) (*node.RetrieveChunksReply, error) {
+	return &node.RetrieveChunksReply{}, nil
+}
+
+// recordingConnectionObserver records the last number of connections observed for each node
+type recordingConnectionObserver struct {
+	mu          sync.Mutex
+	connections map[string]int
+}
+
+func (o *recordingConnectionObserver) ObserveNodeConnections(address string, numConnections int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.connections[address] = numConnections
+}
+
+func (o *recordingConnectionObserver) observed(address string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.connections[address]
+}
+
+func TestNodeClientConnectionReuse(t *testing.T) {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	listener := &countingListener{Listener: tcpListener}
+	server := grpc.NewServer()
+	node.RegisterRetrievalServer(server, &chunksServer{})
+	go func() { _ = server.Serve(listener) }()
+	address := tcpListener.Addr().String()
+	host, port, err := net.SplitHostPort(address)
+	assert.NoError(t, err)
+	opInfo := &core.IndexedOperatorInfo{Socket: core.MakeOperatorSocket(host, "0", port).String()}
+
+	observer := &recordingConnectionObserver{connections: make(map[string]int)}
+	nodeClient := clients.NewNodeClient(time.Second, nil, clients.WithConnectionReuse(200*time.Millisecond, observer))
+
+	// The concurrent chunk requests to the node, e.g. of the blobs of a batch, share a single connection
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(blobIndex uint32) {
+			defer wg.Done()
+			chunksChan := make(chan clients.RetrievedChunks, 1)
+			nodeClient.GetChunks(context.Background(), core.OperatorID{}, opInfo, [32]byte{}, blobIndex, 0, chunksChan)
+			assert.NoError(t, (<-chunksChan).Err)
+		}(uint32(i))
+	}
+	wg.Wait()
+	_, _, err = nodeClient.GetBlobHeader(context.Background(), opInfo.Socket, [32]byte{}, 0)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), listener.accepted.Load())
+	assert.Equal(t, 1, observer.observed(address))
+
+	// The idle connection is closed without any other request, and a new one is dialed for the next request
+	assert.Eventually(t, func() bool { return observer.observed(address) == 0 }, time.Second, 10*time.Millisecond)
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	nodeClient.GetChunks(context.Background(), core.OperatorID{}, opInfo, [32]byte{}, 0, 0, chunksChan)
+	assert.NoError(t, (<-chunksChan).Err)
+	assert.Equal(t, int32(2), listener.accepted.Load())
+	assert.Equal(t, 1, observer.observed(address))
+
+	// The connection to a node that went down is dialed again, failing the request as a connection failure
+	server.Stop()
+	assert.Eventually(t, func() bool {
+		chunksChan := make(chan clients.RetrievedChunks, 1)
+		nodeClient.GetChunks(context.Background(), core.OperatorID{}, opInfo, [32]byte{}, 0, 0, chunksChan)
+		return errors.Is((<-chunksChan).Err, clients.ErrNodeConnectionFailed)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, observer.observed(address))
+}
+
+func TestNodeClientClose(t *testing.T) {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	node.RegisterRetrievalServer(server, &chunksServer{})
+	go func() { _ = server.Serve(tcpListener) }()
+	defer server.Stop()
+	address := tcpListener.Addr().String()
+	host, port, err := net.SplitHostPort(address)
+	assert.NoError(t, err)
+	opInfo := &core.IndexedOperatorInfo{Socket: core.MakeOperatorSocket(host, "0", port).String()}
+
+	observer := &recordingConnectionObserver{connections: make(map[string]int)}
+	nodeClient := clients.NewNodeClient(time.Second, nil, clients.WithConnectionReuse(time.Minute, observer))
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	nodeClient.GetChunks(context.Background(), core.OperatorID{}, opInfo, [32]byte{}, 0, 0, chunksChan)
+	assert.NoError(t, (<-chunksChan).Err)
+	assert.Equal(t, 1, observer.observed(address))
+
+	// The connections are closed with the client, which then fails the requests
+	assert.NoError(t, nodeClient.Close())
+	assert.Equal(t, 0, observer.observed(address))
+	nodeClient.GetChunks(context.Background(), core.OperatorID{}, opInfo, [32]byte{}, 0, 0, chunksChan)
+	assert.ErrorIs(t, (<-chunksChan).Err, clients.ErrNodeConnectionFailed)
+	assert.NoError(t, nodeClient.Close())
+}
+
+// deadlineRecorder records the remaining time to the deadline of the requests received by a node, which the server
+// derives from their grpc-timeout header
+type deadlineRecorder struct {
+	mu        sync.Mutex
+	remaining map[string]time.Duration
+}
+
+func (r *deadlineRecorder) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		r.mu.Lock()
+		r.remaining[info.FullMethod] = time.Until(deadline)
+		r.mu.Unlock()
+	}
+	return handler(ctx, req)
+}
+
+func (r *deadlineRecorder) recorded(method string) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	remaining, ok := r.remaining[method]
+	return remaining, ok
+}
+
+func TestNodeClientDeadlinePropagation(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	recorder := &deadlineRecorder{remaining: make(map[string]time.Duration)}
+	server := grpc.NewServer(grpc.UnaryInterceptor(recorder.intercept))
+	node.RegisterRetrievalServer(server, &chunksServer{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NoError(t, err)
+	opInfo := &core.IndexedOperatorInfo{Socket: core.MakeOperatorSocket(host, "0", port).String()}
+
+	for _, test := range []struct {
+		name          string
+		timeout       time.Duration
+		budget        time.Duration
+		wantRemaining time.Duration
+	}{
+		// The request has no deadline of its own, so the node gets the timeout of the client
+		{name: "node timeout", timeout: 2 * time.Second, wantRemaining: 2 * time.Second},
+		// The request has less budget left than the timeout of the client, so the node gets the remaining budget
+		{name: "remaining budget", timeout: 10 * time.Second, budget: time.Second, wantRemaining: time.Second},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			nodeClient := clients.NewNodeClient(test.timeout, nil)
+			ctx := context.Background()
+			if test.budget > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.budget)
+				defer cancel()
+			}
+
+			chunksChan := make(chan clients.RetrievedChunks, 1)
+			nodeClient.GetChunks(ctx, core.OperatorID{}, opInfo, [32]byte{}, 0, 0, chunksChan)
+			assert.NoError(t, (<-chunksChan).Err)
+			// The blob header isn't implemented by the node, but the request still reaches it
+			_, _, err := nodeClient.GetBlobHeader(ctx, opInfo.Socket, [32]byte{}, 0)
+			assert.Error(t, err)
+
+			for _, method := range []string{node.Retrieval_RetrieveChunks_FullMethodName, node.Retrieval_GetBlobHeader_FullMethodName} {
+				remaining, ok := recorder.recorded(method)
+				assert.True(t, ok, method)
+				assert.LessOrEqual(t, remaining, test.wantRemaining, method)
+				assert.Greater(t, remaining, test.wantRemaining-500*time.Millisecond, method)
+			}
+		})
+	}
+}
@@ -3,8 +3,16 @@ package retriever_test
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
 	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/clients"
 	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
@@ -17,6 +25,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/wealdtech/go-merkletree"
 	"github.com/wealdtech/go-merkletree/keccak256"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const numOperators = 10
@@ -47,12 +57,13 @@ var (
 	retrievalClient        clients.RetrievalClient
 	blobHeader             *core.BlobHeader
 	encodedBlob            core.EncodedBlob = make(core.EncodedBlob)
+	encodingParams         core.EncodingParams
 	batchHeaderHash        [32]byte
 	batchRoot              [32]byte
 	gettysburgAddressBytes = []byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth.")
 )
 
-func setup(t *testing.T) {
+func setup(t testing.TB) {
 
 	var err error
 	indexedChainState, err = coremock.NewChainDataMock(core.OperatorIndex(numOperators))
@@ -112,6 +123,7 @@ func setup(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	encodingParams = params
 
 	commitments, chunks, err := encoder.Encode(blob.Data, params)
 	if err != nil {
@@ -181,6 +193,25 @@ func TestInvalidBlobHeader(t *testing.T) {
 
 }
 
+func TestBlobNotFound(t *testing.T) {
+
+	setup(t)
+
+	notFound := status.Error(codes.NotFound, "blob header not found")
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*core.BlobHeader)(nil), nil, nil, notFound).Times(numOperators)
+	_, err := retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrBlobNotFound)
+
+	// The blob may still be stored by the operators that couldn't be reached
+	setup(t)
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*core.BlobHeader)(nil), nil, nil, notFound).Times(numOperators - 1)
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*core.BlobHeader)(nil), nil, nil, clients.ErrNodeConnectionFailed).Once()
+	_, err = retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorContains(t, err, "failed to get blob header from all operators")
+	assert.NotErrorIs(t, err, clients.ErrBlobNotFound)
+
+}
+
 func TestValidBlobHeader(t *testing.T) {
 
 	setup(t)
@@ -198,3 +229,1298 @@ func TestValidBlobHeader(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, recovered)
 
 }
+
+// unassigningCoordinator assigns no chunks to the given operator
+type unassigningCoordinator struct {
+	core.StdAssignmentCoordinator
+	unassigned core.OperatorID
+}
+
+func (c *unassigningCoordinator) GetAssignments(state *core.OperatorState, quorumID core.QuorumID, quantizationFactor uint) (map[core.OperatorID]core.Assignment, core.AssignmentInfo, error) {
+	assignments, info, err := c.StdAssignmentCoordinator.GetAssignments(state, quorumID, quantizationFactor)
+	if err != nil {
+		return nil, core.AssignmentInfo{}, err
+	}
+	assignments[c.unassigned] = core.Assignment{StartIndex: assignments[c.unassigned].StartIndex}
+	return assignments, info, nil
+}
+
+func TestSkipOperatorsWithoutAssignment(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var unassigned core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		unassigned = opID
+		break
+	}
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	client := clients.NewRetrievalClient(logger, indexedChainState, &unassigningCoordinator{unassigned: unassigned}, nodeClient, encoder, 2)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+
+	nodeClient.AssertNumberOfCalls(t, "GetChunks", numOperators-1)
+	nodeClient.AssertNotCalled(t, "GetChunks", unassigned, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// tamperedEncodedBlob returns the chunks of different data of the same length as the blob, which the operators
+// can serve consistently under the original blob header
+func tamperedEncodedBlob(t *testing.T) core.EncodedBlob {
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+
+	data := make([]byte, len(gettysburgAddressBytes))
+	copy(data, gettysburgAddressBytes)
+	data[0] ^= 1
+	_, chunks, err := encoder.Encode(data, encodingParams)
+	assert.NoError(t, err)
+
+	quorumHeader := blobHeader.QuorumInfos[0]
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{quorumHeader.QuorumID})
+	assert.NoError(t, err)
+	assignments, _, err := coordinator.GetAssignments(operatorState, quorumHeader.QuorumID, quorumHeader.QuantizationFactor)
+	assert.NoError(t, err)
+
+	tampered := make(core.EncodedBlob, len(assignments))
+	for id, assignment := range assignments {
+		tampered[id] = &core.BlobMessage{
+			BlobHeader: blobHeader,
+			Bundles: map[core.QuorumID]core.Bundle{
+				quorumHeader.QuorumID: chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks],
+			},
+		}
+	}
+	return tampered
+}
+
+func TestCommitmentMismatch(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(tamperedEncodedBlob(t))
+
+	_, err := retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+}
+
+// recordingCapturer records the captures of the failed reconstructions
+type recordingCapturer struct {
+	captures []*clients.ReconstructionCapture
+}
+
+func (c *recordingCapturer) CaptureReconstruction(ctx context.Context, capture *clients.ReconstructionCapture) {
+	c.captures = append(c.captures, capture)
+}
+
+func TestRetrieveBlobReconstructionCapture(t *testing.T) {
+
+	setup(t)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	capturer := &recordingCapturer{}
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithReconstructionCapture(capturer))
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	getChunks := nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, capturer.captures)
+
+	// The failed reconstruction is captured, and fails the same once replayed from its encoding
+	getChunks.Unset()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(tamperedEncodedBlob(t))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+	assert.Len(t, capturer.captures, 1)
+	capture := capturer.captures[0]
+	assert.Equal(t, batchHeaderHash, capture.BatchHeaderHash)
+	assert.Equal(t, blobHeader.BlobCommitments, capture.Commitments)
+	assert.Equal(t, encodingParams, capture.Params)
+	assert.Len(t, capture.Indices, len(capture.Chunks))
+	assert.Equal(t, err.Error(), capture.Err)
+
+	data, err := capture.Encode()
+	assert.NoError(t, err)
+	decoded, err := clients.DecodeReconstructionCapture(data)
+	assert.NoError(t, err)
+	_, err = clients.ReplayReconstruction(encoder, decoded)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+
+	// The chunks of the blob itself replay into the blob
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assignments, _, err := coordinator.GetAssignments(operatorState, 0, blobHeader.QuorumInfos[0].QuantizationFactor)
+	assert.NoError(t, err)
+	decoded.Chunks = decoded.Chunks[:0]
+	decoded.Indices = decoded.Indices[:0]
+	for opID, message := range encodedBlob {
+		assignment := assignments[opID]
+		decoded.Chunks = append(decoded.Chunks, message.Bundles[0]...)
+		decoded.Indices = append(decoded.Indices, assignment.GetIndices()...)
+	}
+	replayed, err := clients.ReplayReconstruction(encoder, decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(replayed, "\x00"))
+}
+
+func TestCommitmentMismatchWithoutVerification(t *testing.T) {
+
+	setup(t)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithoutCommitmentVerification())
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(tamperedEncodedBlob(t))
+
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.NotEqual(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+}
+
+// countingEncoder counts the checks of the decoded blobs against their commitment, and records the numbers of chunks
+// whose proofs are verified
+type countingEncoder struct {
+	core.Encoder
+	mu                sync.Mutex
+	commitmentChecks  int
+	verifiedNumChunks []int
+}
+
+func (e *countingEncoder) VerifyCommitment(data []byte, commitments core.BlobCommitments) error {
+	e.mu.Lock()
+	e.commitmentChecks++
+	e.mu.Unlock()
+	return e.Encoder.VerifyCommitment(data, commitments)
+}
+
+func (e *countingEncoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
+	e.mu.Lock()
+	e.verifiedNumChunks = append(e.verifiedNumChunks, len(chunks))
+	e.mu.Unlock()
+	return e.Encoder.VerifyChunks(chunks, indices, commitments, params)
+}
+
+func TestRetrieveBlobSampledCommitmentVerification(t *testing.T) {
+
+	setup(t)
+
+	testEncoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	chunksCall := nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	// Only the proofs of the sampled chunks are checked, rather than the whole decoded blob
+	encoder := &countingEncoder{Encoder: testEncoder}
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithSampledCommitmentVerification(3))
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Equal(t, 0, encoder.commitmentChecks)
+	assert.Equal(t, []int{3}, encoder.verifiedNumChunks)
+
+	// The chunks that were all verified upfront aren't checked again
+	encoder = &countingEncoder{Encoder: testEncoder}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2,
+		clients.WithSampledCommitmentVerification(3), clients.WithChunkVerification(clients.ChunkVerificationLenient, nil))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, encoder.commitmentChecks)
+	assert.Len(t, encoder.verifiedNumChunks, numOperators)
+
+	// A blob reconstructed from wrong chunks fails once one of them is sampled
+	chunksCall.Return(tamperedEncodedBlob(t))
+	encoder = &countingEncoder{Encoder: testEncoder}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithSampledCommitmentVerification(1))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+	assert.ErrorContains(t, err, "sampled chunk")
+	assert.Equal(t, 0, encoder.commitmentChecks)
+
+	// Without sampling, the decoded blob is checked in full
+	encoder = &countingEncoder{Encoder: testEncoder}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2)
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+	assert.Equal(t, 1, encoder.commitmentChecks)
+	assert.Empty(t, encoder.verifiedNumChunks)
+}
+
+func TestRetrieveBlobReconstructionThreshold(t *testing.T) {
+
+	setup(t)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	newClient := func(threshold uint) clients.RetrievalClient {
+		return clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2,
+			clients.WithoutCommitmentVerification(), clients.WithReconstructionThresholds(map[core.QuorumID]uint{0: threshold}))
+	}
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+
+	// The blob is reconstructed from the first chunks that reach the threshold
+	minChunks := (blobHeader.Length + encodingParams.ChunkLength - 1) / encodingParams.ChunkLength
+	getChunks := nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+	data, contributions, err := newClient(minChunks).RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	numChunks := 0
+	for _, contribution := range contributions {
+		numChunks += contribution.NumChunks
+	}
+	assert.GreaterOrEqual(t, uint(numChunks), minChunks)
+	assert.Less(t, uint(numChunks-contributions[len(contributions)-1].NumChunks), minChunks)
+
+	totalChunks := 0
+	for _, message := range encodedBlob {
+		totalChunks += len(message.Bundles[0])
+	}
+	_, err = newClient(uint(totalChunks)+1).RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorContains(t, err, fmt.Sprintf("retrieved %d chunks of quorum 0, fewer than its reconstruction threshold of %d", totalChunks, totalChunks+1))
+
+	// The reconstructed blob is checked against its commitment even without commitment verification
+	getChunks.Unset()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(tamperedEncodedBlob(t))
+	_, err = newClient(minChunks).RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+}
+
+// failingNodeClient fails the chunk requests to the given operator
+type failingNodeClient struct {
+	clients.NodeClient
+	failing core.OperatorID
+}
+
+func (c *failingNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if opID == c.failing {
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: errors.New("operator unavailable")}
+		return
+	}
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+}
+
+// recordingContactObserver records the number of operators contacted by each retrieval
+type recordingContactObserver struct {
+	mu          sync.Mutex
+	numContacts []int
+}
+
+func (o *recordingContactObserver) ObserveOperatorsContacted(quorumID core.QuorumID, numOperators int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.numContacts = append(o.numContacts, numOperators)
+}
+
+func TestRetrieveBlobMaxOperators(t *testing.T) {
+
+	setup(t)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	// The operators assigned the most chunks are contacted first, until their chunks can reconstruct the blob
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assignments, _, err := coordinator.GetAssignments(operatorState, 0, blobHeader.QuorumInfos[0].QuantizationFactor)
+	assert.NoError(t, err)
+	ranked := make([]core.OperatorID, 0, len(assignments))
+	for opID := range assignments {
+		ranked = append(ranked, opID)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := assignments[ranked[i]].NumChunks, assignments[ranked[j]].NumChunks
+		if a != b {
+			return a > b
+		}
+		return bytes.Compare(ranked[i][:], ranked[j][:]) < 0
+	})
+	minChunks := (blobHeader.Length + encodingParams.ChunkLength - 1) / encodingParams.ChunkLength
+	expected, numChunks := 0, uint(0)
+	for ; numChunks < minChunks; expected++ {
+		numChunks += assignments[ranked[expected]].NumChunks
+	}
+
+	observer := &recordingContactObserver{}
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithMaxOperators(1, observer))
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Equal(t, []int{expected}, observer.numContacts)
+	nodeClient.AssertNumberOfCalls(t, "GetChunks", expected)
+	for _, opID := range ranked[:expected] {
+		nodeClient.AssertCalled(t, "GetChunks", opID, mock.Anything, mock.Anything, mock.Anything)
+	}
+
+	// The next operators are contacted in place of the ones that fail
+	observer = &recordingContactObserver{}
+	failingClient := &failingNodeClient{NodeClient: nodeClient, failing: ranked[0]}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, failingClient, encoder, 2, clients.WithMaxOperators(1, observer))
+	data, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, observer.numContacts, 1)
+	assert.Greater(t, observer.numContacts[0], expected)
+
+	// All the operators are contacted without a cap
+	observer = &recordingContactObserver{}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithMaxOperators(0, observer))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{numOperators}, observer.numContacts)
+}
+
+func TestRetrieveBlobWithContributions(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var failing core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		failing = opID
+		break
+	}
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, &failingNodeClient{NodeClient: nodeClient, failing: failing}, encoder, 2)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+
+	// Only the operators whose chunks were used are listed
+	assert.Len(t, contributions, numOperators-1)
+	for _, contribution := range contributions {
+		assert.NotEqual(t, failing, contribution.OperatorID)
+		assert.Equal(t, len(encodedBlob[contribution.OperatorID].Bundles[0]), contribution.NumChunks)
+		assert.Greater(t, contribution.Latency, time.Duration(0))
+	}
+}
+
+// countingChainState counts the reads of the operator state
+type countingChainState struct {
+	core.IndexedChainState
+	reads atomic.Int32
+}
+
+func (s *countingChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	s.reads.Add(1)
+	return s.IndexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+}
+
+func TestRetrieveBlobs(t *testing.T) {
+
+	setup(t)
+
+	state := &countingChainState{IndexedChainState: indexedChainState}
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	client := clients.NewRetrievalClient(logger, state, coordinator, nodeClient, encoder, 2)
+
+	notFound := status.Error(codes.NotFound, "blob header not found")
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, uint32(7)).Return((*core.BlobHeader)(nil), nil, nil, notFound)
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	// The blob that no operator stores doesn't fail the others, which are retrieved from the same operator state
+	blobs := []clients.BlobRetrieval{{BlobIndex: 0}, {BlobIndex: 7}, {BlobIndex: 0}}
+	results := make(map[int]clients.BlobRetrievalResult)
+	for result := range client.RetrieveBlobs(context.Background(), batchHeaderHash, 0, batchRoot, blobs) {
+		results[result.Index] = result
+	}
+	assert.Len(t, results, len(blobs))
+	for _, i := range []int{0, 2} {
+		assert.NoError(t, results[i].Err)
+		assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(results[i].Data, "\x00"))
+	}
+	assert.ErrorIs(t, results[1].Err, clients.ErrBlobNotFound)
+	assert.Equal(t, int32(1), state.reads.Load())
+
+	// No blob has no result
+	_, ok := <-client.RetrieveBlobs(context.Background(), batchHeaderHash, 0, batchRoot, nil)
+	assert.False(t, ok)
+}
+
+func TestGetBlobAssignments(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+
+	assignments, err := retrievalClient.GetBlobAssignments(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, blobHeader, assignments.BlobHeader)
+	assert.Equal(t, encodingParams, assignments.EncodingParams)
+	assert.Equal(t, uint((blobHeader.Length+encodingParams.ChunkLength-1)/encodingParams.ChunkLength), assignments.NumChunksNeeded())
+
+	// The assignment is the one the chunks of the blob were dispersed by, and no chunk is fetched
+	assert.Len(t, assignments.Assignments, numOperators)
+	for opID, assignment := range assignments.Assignments {
+		assert.Equal(t, len(encodedBlob[opID].Bundles[0]), int(assignment.NumChunks))
+	}
+	nodeClient.AssertNotCalled(t, "GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetrieveBlobWithInclusionProof(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	data, contributions, proof, err := retrievalClient.RetrieveBlobWithInclusionProof(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, contributions, numOperators)
+
+	// The proof is the one of the blob header the chunks were verified against
+	assert.Equal(t, blobHeader, proof.BlobHeader)
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	assert.NoError(t, err)
+	included, err := merkletree.VerifyProofUsing(blobHeaderHash[:], false, proof.Proof, [][]byte{batchRoot[:]}, keccak256.New())
+	assert.NoError(t, err)
+	assert.True(t, included)
+}
+
+// tamperingNodeClient serves chunks of other data to the requests to the given operator
+type tamperingNodeClient struct {
+	clients.NodeClient
+	tampering core.OperatorID
+	tampered  core.EncodedBlob
+}
+
+func (c *tamperingNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if opID == c.tampering {
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Chunks: c.tampered[opID].Bundles[quorumID]}
+		return
+	}
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+}
+
+// recordingChunkObserver records the operators whose chunks fail verification
+type recordingChunkObserver struct {
+	mu        sync.Mutex
+	operators []core.OperatorID
+}
+
+func (o *recordingChunkObserver) ObserveChunkVerificationFailure(operatorID core.OperatorID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.operators = append(o.operators, operatorID)
+}
+
+func TestRetrieveBlobChunkVerification(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var tampering core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		tampering = opID
+		break
+	}
+	tamperingClient := &tamperingNodeClient{NodeClient: nodeClient, tampering: tampering, tampered: tamperedEncodedBlob(t)}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// The chunks of the tampering operator are dropped, and the blob is reconstructed from the others
+	observer := &recordingChunkObserver{}
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, tamperingClient, encoder, 2, clients.WithChunkVerification(clients.ChunkVerificationLenient, observer))
+	data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, contributions, numOperators-1)
+	for _, contribution := range contributions {
+		assert.NotEqual(t, tampering, contribution.OperatorID)
+	}
+	assert.Equal(t, []core.OperatorID{tampering}, observer.operators)
+
+	// The retrieval fails naming the tampering operator
+	observer = &recordingChunkObserver{}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, tamperingClient, encoder, 2, clients.WithChunkVerification(clients.ChunkVerificationStrict, observer))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrChunkVerificationFailed)
+	assert.ErrorContains(t, err, hex.EncodeToString(tampering[:]))
+	assert.Equal(t, []core.OperatorID{tampering}, observer.operators)
+}
+
+func TestRetrieveBlobWithDiagnostics(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var operators []core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		operators = append(operators, opID)
+	}
+	tampering, failing := operators[0], operators[1]
+	tamperingClient := &tamperingNodeClient{NodeClient: nodeClient, tampering: tampering, tampered: tamperedEncodedBlob(t)}
+	faultyClient := &failingNodeClient{NodeClient: tamperingClient, failing: failing}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	getChunks := nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// Every chunk received is described, the ones of the tampering operator failing their proofs and left unused
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, faultyClient, encoder, 2, clients.WithChunkVerification(clients.ChunkVerificationLenient, nil))
+	data, contributions, proof, diagnostics, err := client.RetrieveBlobWithDiagnostics(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, contributions, numOperators-2)
+	assert.Equal(t, blobHeader, proof.BlobHeader)
+	assert.Len(t, diagnostics.OperatorFailures, 1)
+	assert.Equal(t, failing, diagnostics.OperatorFailures[0].OperatorID)
+	assert.Equal(t, "operator unavailable", diagnostics.OperatorFailures[0].Err)
+	assignments, _, err := coordinator.GetAssignments(operatorState, 0, blobHeader.QuorumInfos[0].QuantizationFactor)
+	assert.NoError(t, err)
+	numChunks := 0
+	for _, opID := range operators {
+		if opID != failing {
+			numChunks += int(assignments[opID].NumChunks)
+		}
+	}
+	assert.Len(t, diagnostics.Chunks, numChunks)
+	for _, chunk := range diagnostics.Chunks {
+		assert.NotEqual(t, failing, chunk.OperatorID)
+		assert.Equal(t, chunk.OperatorID != tampering, chunk.ProofVerified)
+		assert.Equal(t, chunk.OperatorID != tampering, chunk.Used)
+	}
+	assert.Positive(t, diagnostics.ReconstructionLatency)
+
+	// The diagnostics of a failed reconstruction tell that the chunks used fail their proofs
+	getChunks.Unset()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(tamperedEncodedBlob(t))
+	_, _, _, diagnostics, err = retrievalClient.RetrieveBlobWithDiagnostics(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+	assert.NotEmpty(t, diagnostics.Chunks)
+	assert.Empty(t, diagnostics.OperatorFailures)
+	for _, chunk := range diagnostics.Chunks {
+		assert.False(t, chunk.ProofVerified)
+		assert.True(t, chunk.Used)
+	}
+}
+
+func TestRetrieveBlobCommitmentMismatchRetry(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var tampering core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		tampering = opID
+		break
+	}
+	tamperingClient := &tamperingNodeClient{NodeClient: nodeClient, tampering: tampering, tampered: tamperedEncodedBlob(t)}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// Without the retry, the chunks of the tampering operator fail the retrieval
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, tamperingClient, encoder, 2)
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+
+	// The retry reconstructs the blob without the tampering operator
+	observer := &recordingChunkObserver{}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, tamperingClient, encoder, 2, clients.WithCommitmentMismatchRetry(observer))
+	data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, contributions, numOperators-1)
+	for _, contribution := range contributions {
+		assert.NotEqual(t, tampering, contribution.OperatorID)
+	}
+	assert.Equal(t, []core.OperatorID{tampering}, observer.operators)
+
+	// The retry fails when too few chunks pass their proofs, which is still a mismatch
+	nodeClient.ExpectedCalls = nil
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(tamperedEncodedBlob(t))
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithCommitmentMismatchRetry(nil))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrCommitmentMismatch)
+	assert.ErrorContains(t, err, "the retry without the operators")
+}
+
+// paddingNodeClient appends the chunks of another operator to the chunks served to the requests to the given operator
+type paddingNodeClient struct {
+	clients.NodeClient
+	padding core.OperatorID
+	other   core.OperatorID
+}
+
+func (c *paddingNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if opID == c.padding {
+		chunks := append([]*core.Chunk{}, encodedBlob[opID].Bundles[quorumID]...)
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Chunks: append(chunks, encodedBlob[c.other].Bundles[quorumID]...)}
+		return
+	}
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+}
+
+func TestRetrieveBlobUnassignedChunks(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assignments, _, err := coordinator.GetAssignments(operatorState, 0, blobHeader.QuorumInfos[0].QuantizationFactor)
+	assert.NoError(t, err)
+	var opIDs []core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		opIDs = append(opIDs, opID)
+	}
+	padding := opIDs[0]
+	paddingClient := &paddingNodeClient{NodeClient: nodeClient, padding: padding, other: opIDs[1]}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// Only the chunks beyond the assignment of the operator are dropped, and those of its assignment pass their proofs
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, paddingClient, encoder, 2, clients.WithChunkVerification(clients.ChunkVerificationStrict, nil))
+	data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, contributions, numOperators)
+	for _, contribution := range contributions {
+		assert.Equal(t, int(assignments[contribution.OperatorID].NumChunks), contribution.NumChunks)
+	}
+
+	// With the blacklist, the blob is reconstructed without the operator
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, paddingClient, encoder, 2, clients.WithUnassignedChunkBlacklist())
+	data, contributions, err = client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Len(t, contributions, numOperators-1)
+	for _, contribution := range contributions {
+		assert.NotEqual(t, padding, contribution.OperatorID)
+	}
+}
+
+// resizingNodeClient returns the chunks of an operator with the first one resized to a length
+type resizingNodeClient struct {
+	clients.NodeClient
+	resized core.OperatorID
+	length  int
+}
+
+func (c *resizingNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if opID != c.resized {
+		c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+		return
+	}
+	chunks := append([]*core.Chunk{}, encodedBlob[opID].Bundles[quorumID]...)
+	coeffs := make([]core.Symbol, c.length)
+	copy(coeffs, chunks[0].Coeffs)
+	chunks[0] = &core.Chunk{Coeffs: coeffs, Proof: chunks[0].Proof}
+	chunksChan <- clients.RetrievedChunks{OperatorID: opID, Chunks: chunks}
+}
+
+func TestRetrieveBlobMismatchedChunkLength(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var resized core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		resized = opID
+		break
+	}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// The short and long chunks are rejected before they are verified or decoded, even with the strict verification,
+	// and the blob is reconstructed from the chunks of the other operators
+	chunkLength := int(encodingParams.ChunkLength)
+	for _, length := range []int{0, 1, chunkLength - 1, chunkLength + 1, 2 * chunkLength} {
+		resizingClient := &resizingNodeClient{NodeClient: nodeClient, resized: resized, length: length}
+		for _, client := range []clients.RetrievalClient{
+			clients.NewRetrievalClient(logger, indexedChainState, coordinator, resizingClient, encoder, 2),
+			clients.NewRetrievalClient(logger, indexedChainState, coordinator, resizingClient, encoder, 2, clients.WithChunkVerification(clients.ChunkVerificationStrict, nil)),
+		} {
+			data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+			assert.NoError(t, err, "length %d", length)
+			assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+			assert.Len(t, contributions, numOperators-1)
+			for _, contribution := range contributions {
+				assert.NotEqual(t, resized, contribution.OperatorID)
+			}
+		}
+	}
+
+	// The operator is reported as failed in the diagnostics
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, &resizingNodeClient{NodeClient: nodeClient, resized: resized, length: chunkLength + 1}, encoder, 2)
+	_, _, _, diagnostics, err := client.RetrieveBlobWithDiagnostics(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Len(t, diagnostics.OperatorFailures, 1)
+	assert.Equal(t, resized, diagnostics.OperatorFailures[0].OperatorID)
+	assert.Contains(t, diagnostics.OperatorFailures[0].Err, fmt.Sprintf("chunk 0 has length %d, expected %d", chunkLength+1, chunkLength))
+	for _, chunk := range diagnostics.Chunks {
+		assert.NotEqual(t, resized, chunk.OperatorID)
+	}
+}
+
+// orderingNodeClient records the order of the requests for chunks, and the most requests in flight at once
+type orderingNodeClient struct {
+	clients.NodeClient
+	mu          sync.Mutex
+	order       []core.OperatorID
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *orderingNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	c.mu.Lock()
+	c.order = append(c.order, opID)
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+	// Give the concurrent requests the time to overlap
+	time.Sleep(10 * time.Millisecond)
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+}
+
+func TestRetrieveBlobSequentialFetch(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	retrieve := func(opts ...clients.RetrievalClientOption) (*orderingNodeClient, []clients.OperatorContribution) {
+		orderingClient := &orderingNodeClient{NodeClient: nodeClient}
+		client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, orderingClient, encoder, numOperators, opts...)
+		data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+		return orderingClient, contributions
+	}
+
+	concurrent, _ := retrieve()
+	assert.Greater(t, concurrent.maxInFlight, 1)
+
+	// The operators are requested one at a time, and their chunks are used, in the same order every time
+	first, firstContributions := retrieve(clients.WithSequentialFetch())
+	assert.Equal(t, 1, first.maxInFlight)
+	assert.Len(t, first.order, numOperators)
+	second, secondContributions := retrieve(clients.WithSequentialFetch())
+	assert.Equal(t, first.order, second.order)
+	assert.Len(t, firstContributions, numOperators)
+	for i := range firstContributions {
+		assert.Equal(t, first.order[i], firstContributions[i].OperatorID)
+		assert.Equal(t, firstContributions[i].OperatorID, secondContributions[i].OperatorID)
+	}
+}
+
+// slowNodeClient delays the chunks of all the operators, and those of the slow one longer
+type slowNodeClient struct {
+	clients.NodeClient
+	slow      core.OperatorID
+	delay     time.Duration
+	slowDelay time.Duration
+}
+
+func (c *slowNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if opID == c.slow {
+		time.Sleep(c.slowDelay)
+	} else {
+		time.Sleep(c.delay)
+	}
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+}
+
+func TestRetrieveBlobFanOutWeights(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	operatorState, err := indexedChainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	byStake := make([]core.OperatorID, 0, numOperators)
+	for opID := range operatorState.Operators[0] {
+		byStake = append(byStake, opID)
+	}
+	sort.Slice(byStake, func(i, j int) bool {
+		a, b := (*big.Int)(operatorState.Operators[0][byStake[i]].Stake), (*big.Int)(operatorState.Operators[0][byStake[j]].Stake)
+		return a.Cmp(b) > 0
+	})
+	mostStaked := byStake[0]
+
+	// The fetches are sequential, so that the operators are contacted in the order of the fan-out
+	slowClient := &slowNodeClient{NodeClient: nodeClient, slow: mostStaked, delay: 10 * time.Millisecond, slowDelay: 50 * time.Millisecond}
+	newClient := func(weights clients.FanOutWeights) clients.RetrievalClient {
+		return clients.NewRetrievalClient(logger, indexedChainState, coordinator, slowClient, encoder, numOperators, clients.WithSequentialFetch(), clients.WithFanOutWeights(weights))
+	}
+	retrieve := func(client clients.RetrievalClient) []core.OperatorID {
+		_, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+		assert.NoError(t, err)
+		order := make([]core.OperatorID, len(contributions))
+		for i, contribution := range contributions {
+			order[i] = contribution.OperatorID
+		}
+		return order
+	}
+
+	// The most-staked operators are contacted first, however slow
+	client := newClient(clients.FanOutWeights{Stake: 1})
+	assert.Equal(t, byStake, retrieve(client))
+	assert.Equal(t, byStake, retrieve(client))
+
+	// The fastest operators are contacted first once their latencies are known
+	client = newClient(clients.FanOutWeights{Latency: 1})
+	retrieve(client)
+	order := retrieve(client)
+	assert.Equal(t, mostStaked, order[len(order)-1])
+
+	// Until their latencies expire, after which they're sampled again as if they weren't contacted yet
+	client = newClient(clients.FanOutWeights{Latency: 1, LatencyExpiry: 500 * time.Millisecond})
+	unknown := retrieve(client)
+	order = retrieve(client)
+	assert.Equal(t, mostStaked, order[len(order)-1])
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, unknown, retrieve(client))
+
+	// Both the stakes and the latencies count with a combination of the weights
+	client = newClient(clients.FanOutWeights{Stake: 1, Latency: 1})
+	assert.Equal(t, mostStaked, retrieve(client)[0])
+	order = retrieve(client)
+	assert.NotEqual(t, mostStaked, order[0])
+	assert.NotEqual(t, mostStaked, order[len(order)-1])
+}
+
+// recordingMemoryBudget records the reservations of the reconstructions
+type recordingMemoryBudget struct {
+	reserved []uint64
+	released int
+	err      error
+}
+
+func (b *recordingMemoryBudget) Reserve(ctx context.Context, numBytes uint64) (func(), error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	b.reserved = append(b.reserved, numBytes)
+	return func() { b.released++ }, nil
+}
+
+func TestRetrieveBlobMemoryBudget(t *testing.T) {
+
+	setup(t)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	budget := &recordingMemoryBudget{}
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithMemoryBudget(budget))
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+
+	// The reservation grows with the encoded blob, and is released once the blob is reconstructed
+	assert.Equal(t, []uint64{uint64(blobHeader.QuorumInfos[0].EncodedBlobLength) * 32 * 4}, budget.reserved)
+	assert.Equal(t, 1, budget.released)
+
+	// No chunks are retrieved if the reservation fails
+	nodeClient.Calls = nil
+	budget.err = errors.New("memory budget exhausted")
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, budget.err)
+	nodeClient.AssertNotCalled(t, "GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// movedChainState is the chain state of an operator that moved to a new socket as of the current block
+type movedChainState struct {
+	core.IndexedChainState
+	operator     core.OperatorID
+	socket       string
+	currentBlock uint
+	refreshes    int
+}
+
+func (s *movedChainState) GetCurrentBlockNumber() (uint, error) {
+	s.refreshes++
+	return s.currentBlock, nil
+}
+
+func (s *movedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	state, err := s.IndexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	if err != nil || blockNumber != s.currentBlock || s.socket == "" {
+		return state, err
+	}
+	moved := *state.IndexedOperators[s.operator]
+	moved.Socket = s.socket
+	state.IndexedOperators[s.operator] = &moved
+	return state, nil
+}
+
+// unreachableNodeClient fails to connect to the given socket, and records the sockets it connects to
+type unreachableNodeClient struct {
+	clients.NodeClient
+	unreachable string
+
+	mu      sync.Mutex
+	sockets []string
+}
+
+func (c *unreachableNodeClient) connect(socket string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sockets = append(c.sockets, socket)
+	if socket == c.unreachable {
+		return fmt.Errorf("%w at %s: connection refused", clients.ErrNodeConnectionFailed, socket)
+	}
+	return nil
+}
+
+func (c *unreachableNodeClient) dialed(socket string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dialed := 0
+	for _, s := range c.sockets {
+		if s == socket {
+			dialed++
+		}
+	}
+	return dialed
+}
+
+func (c *unreachableNodeClient) GetBlobHeader(ctx context.Context, socket string, batchHeaderHash [32]byte, blobIndex uint32) (*core.BlobHeader, *merkletree.Proof, error) {
+	if err := c.connect(socket); err != nil {
+		return nil, nil, err
+	}
+	return c.NodeClient.GetBlobHeader(ctx, socket, batchHeaderHash, blobIndex)
+}
+
+func (c *unreachableNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if err := c.connect(opInfo.Socket); err != nil {
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: err}
+		return
+	}
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+}
+
+func TestRetrieveBlobEndpointRefresh(t *testing.T) {
+
+	setup(t)
+
+	operatorState, err := indexedChainState.GetIndexedOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var moved core.OperatorID
+	for opID := range operatorState.Operators[0] {
+		moved = opID
+		break
+	}
+	oldSocket := operatorState.IndexedOperators[moved].Socket
+	newSocket := core.MakeOperatorSocket("10.0.0.1", "32000", "32001").String()
+	movingClient := &unreachableNodeClient{NodeClient: nodeClient, unreachable: oldSocket}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// The chain doesn't know of the move yet, so the refresh doesn't help and isn't repeated within the interval
+	chainState := &movedChainState{IndexedChainState: indexedChainState, operator: moved, currentBlock: 100}
+	client := clients.NewRetrievalClient(logger, chainState, coordinator, movingClient, encoder, 2, clients.WithEndpointRefresh(1, time.Hour))
+	for i := 0; i < 2; i++ {
+		data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+		assert.Len(t, contributions, numOperators-1)
+	}
+	assert.Equal(t, 1, chainState.refreshes)
+
+	// Once the move is on-chain, the chunks are retrieved from the new socket, which the next retrievals go to
+	// directly
+	chainState = &movedChainState{IndexedChainState: indexedChainState, operator: moved, socket: newSocket, currentBlock: 100}
+	client = clients.NewRetrievalClient(logger, chainState, coordinator, movingClient, encoder, 2, clients.WithEndpointRefresh(1, time.Hour))
+	_, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Len(t, contributions, numOperators)
+	assert.Equal(t, 1, chainState.refreshes)
+
+	dialed := movingClient.dialed(oldSocket)
+	_, contributions, err = client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Len(t, contributions, numOperators)
+	assert.Equal(t, dialed, movingClient.dialed(oldSocket))
+	assert.Greater(t, movingClient.dialed(newSocket), 0)
+}
+
+// unavailableNodeClient fails all the chunk requests
+type unavailableNodeClient struct {
+	clients.NodeClient
+}
+
+func (c *unavailableNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: errors.New("operator unavailable")}
+}
+
+// cachingPeerClient serves the chunks of the encoded blobs of the peers, and records the peers requested
+type cachingPeerClient struct {
+	mu        sync.Mutex
+	blobs     map[string]core.EncodedBlob
+	requested []string
+}
+
+func (c *cachingPeerClient) Close() error {
+	return nil
+}
+
+func (c *cachingPeerClient) GetPeerChunks(ctx context.Context, peer string, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID) (*clients.IndexedChunks, error) {
+	c.mu.Lock()
+	c.requested = append(c.requested, peer)
+	c.mu.Unlock()
+	blob, ok := c.blobs[peer]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no chunk is cached")
+	}
+	operatorState, err := indexedChainState.GetOperatorState(ctx, 0, []core.QuorumID{quorumID})
+	if err != nil {
+		return nil, err
+	}
+	assignments, _, err := coordinator.GetAssignments(operatorState, quorumID, blobHeader.QuorumInfos[0].QuantizationFactor)
+	if err != nil {
+		return nil, err
+	}
+	chunks := &clients.IndexedChunks{}
+	for opID, assignment := range assignments {
+		chunks.Chunks = append(chunks.Chunks, blob[opID].Bundles[quorumID]...)
+		chunks.Indices = append(chunks.Indices, assignment.GetIndices()...)
+	}
+	return chunks, nil
+}
+
+func (c *cachingPeerClient) takeRequested() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	requested := c.requested
+	c.requested = nil
+	return requested
+}
+
+func TestRetrieveBlobPeerFallback(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+
+	// The verified chunks of the reconstructed blobs are cached
+	cache := clients.NewChunkCache(1 << 20)
+	client := clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithChunkVerification(clients.ChunkVerificationLenient, nil), clients.WithChunkCache(cache))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	cached, ok := cache.Get(batchHeaderHash, 0, 0)
+	assert.True(t, ok)
+	var assigned []*core.Chunk
+	for _, message := range encodedBlob {
+		assigned = append(assigned, message.Bundles[0]...)
+	}
+	assert.ElementsMatch(t, assigned, cached.Chunks)
+	_, ok = cache.Get(batchHeaderHash, 1, 0)
+	assert.False(t, ok)
+
+	// The chunks the operators fail to return are fetched from the peers, without the ones that fail their proofs
+	blobs := map[string]core.EncodedBlob{"tampered:32011": tamperedEncodedBlob(t), "good:32011": encodedBlob}
+	peers := &cachingPeerClient{blobs: blobs}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, &unavailableNodeClient{NodeClient: nodeClient}, encoder, 2, clients.WithPeerFallback(peers, []string{"tampered:32011", "good:32011", "empty:32011"}, 2))
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	requested := peers.takeRequested()
+	assert.LessOrEqual(t, len(requested), 2)
+	assert.Contains(t, requested, "good:32011")
+
+	// The fan-out of the next fallback starts from the next peer
+	data, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.NotContains(t, peers.takeRequested(), "tampered:32011")
+
+	// The chunks of the peers are all dropped if they fail their proofs
+	peers = &cachingPeerClient{blobs: blobs}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, &unavailableNodeClient{NodeClient: nodeClient}, encoder, 2, clients.WithPeerFallback(peers, []string{"tampered:32011", "empty:32011"}, 2))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Error(t, err)
+	assert.ElementsMatch(t, []string{"tampered:32011", "empty:32011"}, peers.takeRequested())
+
+	// The peers aren't contacted if the operators return enough chunks
+	peers = &cachingPeerClient{blobs: blobs}
+	client = clients.NewRetrievalClient(logger, indexedChainState, coordinator, nodeClient, encoder, 2, clients.WithPeerFallback(peers, []string{"good:32011"}, 1))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, peers.takeRequested())
+}
+
+func TestChunkCache(t *testing.T) {
+	chunk := func() *core.Chunk {
+		return &core.Chunk{Coeffs: make([]core.Symbol, 4)}
+	}
+	chunkSize := uint64(chunk().Size())
+	cache := clients.NewChunkCache(3 * chunkSize)
+
+	// The chunks of a blob are merged with the ones already cached, ignoring the indices already cached
+	cache.Add([32]byte{1}, 0, 0, []*core.Chunk{chunk()}, []core.ChunkNumber{0})
+	cache.Add([32]byte{1}, 0, 0, []*core.Chunk{chunk(), chunk()}, []core.ChunkNumber{0, 1})
+	cached, ok := cache.Get([32]byte{1}, 0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, []core.ChunkNumber{0, 1}, cached.Indices)
+	assert.Equal(t, 2*chunkSize, cache.Size())
+
+	// The blobs least recently used are evicted to fit the size
+	cache.Add([32]byte{2}, 0, 0, []*core.Chunk{chunk()}, []core.ChunkNumber{0})
+	_, ok = cache.Get([32]byte{1}, 0, 0)
+	assert.True(t, ok)
+	cache.Add([32]byte{3}, 0, 0, []*core.Chunk{chunk()}, []core.ChunkNumber{3})
+	_, ok = cache.Get([32]byte{2}, 0, 0)
+	assert.False(t, ok)
+	_, ok = cache.Get([32]byte{1}, 0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, 3*chunkSize, cache.Size())
+
+	// The blobs larger than the cache aren't cached, keeping the others
+	cache.Add([32]byte{4}, 0, 0, []*core.Chunk{chunk(), chunk(), chunk(), chunk()}, []core.ChunkNumber{0, 1, 2, 3})
+	_, ok = cache.Get([32]byte{4}, 0, 0)
+	assert.False(t, ok)
+	assert.Equal(t, 3*chunkSize, cache.Size())
+}
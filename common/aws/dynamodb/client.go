@@ -2,6 +2,7 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -19,6 +20,12 @@ import (
 const (
 	// dynamoBatchLimit is the maximum number of items that can be written in a single batch
 	dynamoBatchLimit = 25
+	// maxQueryLimit is the maximum number of items returned by a single paginated query,
+	// which bounds the memory used by the results
+	maxQueryLimit = 10000
+	// maxQueryPages is the maximum number of pages of up to 1MB fetched by QueryIndex, which bounds the memory and the
+	// time of the queries matching more items than expected
+	maxQueryPages = 256
 )
 
 type batchOperation uint
@@ -28,6 +35,12 @@ const (
 	delete
 )
 
+// ErrConditionFailed is returned by the conditional writes of the items that don't match their condition
+var ErrConditionFailed = errors.New("the condition of the write isn't met")
+
+// ErrTooManyPages is returned by QueryIndex when the items matching the query span more than maxQueryPages pages
+var ErrTooManyPages = errors.New("the query matches too many items")
+
 var (
 	once      sync.Once
 	clientRef *Client
@@ -37,6 +50,13 @@ type Item = map[string]types.AttributeValue
 type Key = map[string]types.AttributeValue
 type ExpresseionValues = map[string]types.AttributeValue
 
+// QueryResult is a page of the items returned by a query
+type QueryResult struct {
+	Items []Item
+	// LastEvaluatedKey is the key to continue the query from, or nil if there are no more items
+	LastEvaluatedKey Key
+}
+
 type Client struct {
 	dynamoClient *dynamodb.Client
 	logger       common.Logger
@@ -107,6 +127,16 @@ func (c *Client) PutItems(ctx context.Context, tableName string, items []Item) (
 }
 
 func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item Item) (Item, error) {
+	return c.updateItem(ctx, tableName, key, item, nil)
+}
+
+// UpdateItemWithCondition updates the item like UpdateItem if the stored item matches the condition, and returns
+// ErrConditionFailed otherwise
+func (c *Client) UpdateItemWithCondition(ctx context.Context, tableName string, key Key, item Item, condition expression.ConditionBuilder) (Item, error) {
+	return c.updateItem(ctx, tableName, key, item, &condition)
+}
+
+func (c *Client) updateItem(ctx context.Context, tableName string, key Key, item Item, condition *expression.ConditionBuilder) (Item, error) {
 	update := expression.UpdateBuilder{}
 	for itemKey, itemValue := range item {
 		if _, ok := key[itemKey]; ok {
@@ -116,7 +146,11 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 		update = update.Set(expression.Name(itemKey), expression.Value(itemValue))
 	}
 
-	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	builder := expression.NewBuilder().WithUpdate(update)
+	if condition != nil {
+		builder = builder.WithCondition(*condition)
+	}
+	expr, err := builder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -127,9 +161,14 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
 		ReturnValues:              types.ReturnValueUpdatedNew,
 	})
 
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return nil, ErrConditionFailed
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -147,18 +186,74 @@ func (c *Client) GetItem(ctx context.Context, tableName string, key Key) (Item,
 }
 
 // QueryIndex returns all items in the index that match the given key
+// The results are fetched page by page, so the query is not limited by the 1MB page size of DynamoDB.
+// Because all the items are loaded into memory, QueryIndexWithPagination should be used for queries that can match
+// an unbounded number of items, and QueryIndex fails with ErrTooManyPages after maxQueryPages pages.
 func (c *Client) QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpresseionValues) ([]Item, error) {
-	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
-		TableName:                 aws.String(tableName),
-		IndexName:                 aws.String(indexName),
-		KeyConditionExpression:    aws.String(keyCondition),
-		ExpressionAttributeValues: expAttributeValues,
-	})
-	if err != nil {
-		return nil, err
+	items := make([]Item, 0)
+	var exclusiveStartKey Key
+	for page := 0; ; page++ {
+		if page == maxQueryPages {
+			return nil, fmt.Errorf("%w: more than %d pages of index %s of table %s", ErrTooManyPages, maxQueryPages, indexName, tableName)
+		}
+		response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(tableName),
+			IndexName:                 aws.String(indexName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			ExpressionAttributeValues: expAttributeValues,
+			ExclusiveStartKey:         exclusiveStartKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, response.Items...)
+		if len(response.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		exclusiveStartKey = response.LastEvaluatedKey
+	}
+}
+
+// QueryIndexWithPagination returns up to limit items in the index that match the given key, starting after
+// exclusiveStartKey (or from the beginning if it is nil).
+// The limit is capped at maxQueryLimit. If the query stopped before all the matching items were returned,
+// the result contains the LastEvaluatedKey to pass as exclusiveStartKey to fetch the next page.
+// Note that the next page can be empty if the number of matching items is a multiple of limit.
+func (c *Client) QueryIndexWithPagination(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpresseionValues, limit int32, exclusiveStartKey Key) (QueryResult, error) {
+	if limit <= 0 || limit > maxQueryLimit {
+		limit = maxQueryLimit
 	}
 
-	return response.Items, nil
+	items := make([]Item, 0)
+	for {
+		response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(tableName),
+			IndexName:                 aws.String(indexName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			ExpressionAttributeValues: expAttributeValues,
+			ExclusiveStartKey:         exclusiveStartKey,
+			Limit:                     aws.Int32(limit - int32(len(items))),
+		})
+		if err != nil {
+			return QueryResult{}, err
+		}
+
+		items = append(items, response.Items...)
+		exclusiveStartKey = response.LastEvaluatedKey
+		// A page ends either at the limit or at 1MB of data, in which case the query continues from the last key
+		if len(exclusiveStartKey) == 0 || len(items) >= int(limit) {
+			break
+		}
+	}
+
+	if len(exclusiveStartKey) == 0 {
+		exclusiveStartKey = nil
+	}
+	return QueryResult{
+		Items:            items,
+		LastEvaluatedKey: exclusiveStartKey,
+	}, nil
 }
 
 func (c *Client) DeleteItem(ctx context.Context, tableName string, key Key) error {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
@@ -234,3 +236,107 @@ func TestBatchOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, fetchedItem, 0)
 }
+
+func TestQueryIndexPagination(t *testing.T) {
+	tableName := "ProcessingWithPagination"
+	indexName := "StatusIndex"
+	ctx := context.Background()
+	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("MetadataKey"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("BlobStatus"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+			{
+				AttributeName: aws.String("RequestedAt"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{{
+			AttributeName: aws.String("MetadataKey"),
+			KeyType:       types.KeyTypeHash,
+		}},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{{
+			IndexName: aws.String(indexName),
+			KeySchema: []types.KeySchemaElement{
+				{
+					AttributeName: aws.String("BlobStatus"),
+					KeyType:       types.KeyTypeHash,
+				},
+				{
+					AttributeName: aws.String("RequestedAt"),
+					KeyType:       types.KeyTypeRange,
+				},
+			},
+			Projection: &types.Projection{
+				ProjectionType: types.ProjectionTypeAll,
+			},
+			ProvisionedThroughput: &types.ProvisionedThroughput{
+				ReadCapacityUnits:  aws.Int64(10),
+				WriteCapacityUnits: aws.Int64(10),
+			},
+		}},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+	})
+	assert.NoError(t, err)
+
+	// Seed several MB of items so that the results span more than one 1MB DynamoDB page
+	numItems := 400
+	padding := strings.Repeat("x", 16*1024)
+	items := make([]commondynamodb.Item, numItems)
+	for i := 0; i < numItems; i += 1 {
+		items[i] = commondynamodb.Item{
+			"MetadataKey": &types.AttributeValueMemberS{Value: fmt.Sprintf("key%d", i)},
+			"BlobStatus":  &types.AttributeValueMemberN{Value: "0"},
+			"RequestedAt": &types.AttributeValueMemberN{Value: strconv.Itoa(i)},
+			"Padding":     &types.AttributeValueMemberS{Value: padding},
+		}
+	}
+	unprocessed, err := dynamoClient.PutItems(ctx, tableName, items)
+	assert.NoError(t, err)
+	assert.Len(t, unprocessed, 0)
+
+	keyCondition := "BlobStatus = :status"
+	expAttributeValues := commondynamodb.ExpresseionValues{
+		":status": &types.AttributeValueMemberN{Value: "0"},
+	}
+
+	queried, err := dynamoClient.QueryIndex(ctx, tableName, indexName, keyCondition, expAttributeValues)
+	assert.NoError(t, err)
+	assert.Len(t, queried, numItems)
+
+	// Page through the index with a limit that doesn't divide the number of items
+	limit := int32(37)
+	seen := make(map[string]struct{})
+	var exclusiveStartKey commondynamodb.Key
+	for {
+		result, err := dynamoClient.QueryIndexWithPagination(ctx, tableName, indexName, keyCondition, expAttributeValues, limit, exclusiveStartKey)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(result.Items), int(limit))
+		for _, item := range result.Items {
+			key := item["MetadataKey"].(*types.AttributeValueMemberS).Value
+			_, ok := seen[key]
+			assert.False(t, ok, "item %s returned more than once", key)
+			seen[key] = struct{}{}
+			// The items are returned in the order of the sort key
+			assert.Equal(t, strconv.Itoa(len(seen)-1), item["RequestedAt"].(*types.AttributeValueMemberN).Value)
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		assert.Len(t, result.Items, int(limit))
+		exclusiveStartKey = result.LastEvaluatedKey
+	}
+	assert.Len(t, seen, numItems)
+
+	err = dynamoClient.DeleteTable(ctx, tableName)
+	assert.NoError(t, err)
+}
@@ -1,9 +1,9 @@
 package s3
 
 import (
-	"bytes"
 	"context"
 	"errors"
+	"io"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 var (
@@ -72,40 +73,45 @@ func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger common.Lo
 	return ref, err
 }
 
-func (s *client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
-	var partMiBs int64 = 10
-	downloader := manager.NewDownloader(s.s3Client, func(d *manager.Downloader) {
-		d.PartSize = partMiBs * 1024 * 1024 // 10MB per part
-		d.Concurrency = 3                   //The number of goroutines to spin up in parallel per call to Upload when sending parts
-	})
+// uploadPartSize is the size of the parts of the multipart uploads, the objects under it being uploaded in a single
+// request
+const uploadPartSize = 10 * 1024 * 1024
 
-	buffer := manager.NewWriteAtBuffer([]byte{})
-	_, err := downloader.Download(ctx, buffer, &s3.GetObjectInput{
+func (s *client) DownloadObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	return s.getObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
+}
+
+// getObject returns the body of the object, which is streamed from S3 as it's read
+func (s *client) getObject(ctx context.Context, input *s3.GetObjectInput) (io.ReadCloser, error) {
+	output, err := s.s3Client.GetObject(ctx, input)
 	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
 		return nil, err
 	}
-
-	if buffer == nil || len(buffer.Bytes()) == 0 {
+	// The empty objects are reported as not found, as the objects of the stores are never empty
+	if output.ContentLength == 0 {
+		output.Body.Close()
 		return nil, ErrObjectNotFound
 	}
-
-	return buffer.Bytes(), nil
+	return output.Body, nil
 }
 
-func (s *client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
-	var partMiBs int64 = 10
+func (s *client) UploadObject(ctx context.Context, bucket string, key string, body io.Reader) error {
 	uploader := manager.NewUploader(s.s3Client, func(u *manager.Uploader) {
-		u.PartSize = partMiBs * 1024 * 1024 // 10MB per part
-		u.Concurrency = 3                   //The number of goroutines to spin up in parallel per call to Upload when sending parts
+		u.PartSize = uploadPartSize
+		u.Concurrency = 3 //The number of goroutines to spin up in parallel per call to Upload when sending parts
 	})
 
 	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
-		Body:   bytes.NewReader(data),
+		Body:   body,
 	})
 	if err != nil {
 		return err
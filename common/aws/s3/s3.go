@@ -1,10 +1,19 @@
 package s3
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
+// Client is a store of objects which are uploaded and downloaded as streams, so that they aren't buffered whole in
+// memory by the store
 type Client interface {
-	DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error)
-	UploadObject(ctx context.Context, bucket string, key string, data []byte) error
+	// DownloadObject returns a reader of the content of the object, which the caller must close, or
+	// ErrObjectNotFound if there is no such object
+	DownloadObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error)
+	// UploadObject uploads the content of the object from the reader. A reader that is also an io.ReaderAt and an
+	// io.Seeker, such as a bytes.Reader, is uploaded without buffering it.
+	UploadObject(ctx context.Context, bucket string, key string, body io.Reader) error
 	DeleteObject(ctx context.Context, bucket string, key string) error
 	ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error)
 }
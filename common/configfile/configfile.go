@@ -0,0 +1,218 @@
+package configfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+const FlagName = "config"
+
+// CLIFlag is the flag of the path of the config file of the service
+func CLIFlag(envPrefix string) cli.Flag {
+	return cli.StringFlag{
+		Name:   FlagName,
+		Usage:  "Path of a YAML (.yaml, .yml) or TOML (.toml) config file setting the flags, keyed by their names, where the dots of the names can also nest the keys. The command line and the environment variables take precedence over the file",
+		EnvVar: common.PrefixEnvVar(envPrefix, "CONFIG"),
+	}
+}
+
+// Enable makes the app load the config file named by its config flag before its action runs. The required flags
+// can then be set in the file, so they are checked once the file is loaded rather than by the app.
+func Enable(app *cli.App) {
+	flags := make([]cli.Flag, len(app.Flags))
+	var required []string
+	for i, flag := range app.Flags {
+		var isRequired bool
+		flags[i], isRequired = optional(flag)
+		if isRequired {
+			required = append(required, flagName(flag))
+		}
+	}
+	app.Flags = flags
+
+	before := app.Before
+	app.Before = func(ctx *cli.Context) error {
+		// The commands of the app, such as version, don't run the service and don't need its flags, except for
+		// dump-config which reads its config
+		if !ctx.Args().Present() || app.Command(ctx.Args().First()) == nil || ctx.Args().First() == DumpCommandName {
+			if err := Load(ctx); err != nil {
+				return err
+			}
+			var missing []string
+			for _, name := range required {
+				if !ctx.GlobalIsSet(name) {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("required flags %q not set", strings.Join(missing, ", "))
+			}
+		}
+		if before != nil {
+			return before(ctx)
+		}
+		return nil
+	}
+}
+
+// Load sets the flags of the app that are not set on the command line or by an environment variable to their values
+// in the config file, if the config flag names one. The keys of the file must all be flags of the app.
+func Load(ctx *cli.Context) error {
+	path := ctx.GlobalString(FlagName)
+	if path == "" {
+		return nil
+	}
+	values, err := readFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the config file %s: %w", path, err)
+	}
+
+	known := make(map[string]bool, len(ctx.App.Flags))
+	for _, flag := range ctx.App.Flags {
+		known[flagName(flag)] = true
+	}
+	var unknown []string
+	for key := range values {
+		if !known[key] || key == FlagName {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown keys in the config file %s: %s", path, strings.Join(unknown, ", "))
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// The flags set by the file are recorded for dump-config, as they can't be told from the ones set on the command
+	// line
+	loaded := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if ctx.GlobalIsSet(key) {
+			continue
+		}
+		if err := set(ctx, key, values[key]); err != nil {
+			return fmt.Errorf("invalid value of %s in the config file %s: %w", key, path, err)
+		}
+		loaded[key] = true
+	}
+	if ctx.App.Metadata == nil {
+		ctx.App.Metadata = make(map[string]any)
+	}
+	ctx.App.Metadata[loadedMetadataKey] = loaded
+	return nil
+}
+
+// readFile reads the config file into the values of its keys, where the keys of the nested tables are joined to
+// their parents with dots
+func readFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tree := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q: must be .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	if err := flatten("", tree, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func flatten(prefix string, tree map[string]any, values map[string]any) error {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if table, ok := value.(map[string]any); ok {
+			if err := flatten(key, table, values); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := values[key]; ok {
+			return fmt.Errorf("duplicate key %s", key)
+		}
+		values[key] = value
+	}
+	return nil
+}
+
+// set sets the flag to the value in the file. The values of the slice flags replace their defaults.
+func set(ctx *cli.Context, name string, value any) error {
+	if value == nil {
+		value = ""
+	}
+	list, isList := value.([]any)
+	switch slice := ctx.GlobalGeneric(name).(type) {
+	case *cli.StringSlice:
+		*slice = cli.StringSlice{}
+	case *cli.IntSlice:
+		*slice = cli.IntSlice{}
+	case *cli.Int64Slice:
+		*slice = cli.Int64Slice{}
+	default:
+		if isList {
+			return fmt.Errorf("got a list for a flag of a single value")
+		}
+		return ctx.GlobalSet(name, fmt.Sprint(value))
+	}
+	if !isList {
+		list = []any{value}
+	}
+	for _, item := range list {
+		if err := ctx.GlobalSet(name, fmt.Sprint(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// optional returns a copy of the flag that is not required, and whether the flag was required
+func optional(flag cli.Flag) (cli.Flag, bool) {
+	value := reflect.ValueOf(flag)
+	if value.Kind() != reflect.Struct {
+		return flag, false
+	}
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+	required := copied.FieldByName("Required")
+	if !required.IsValid() || required.Kind() != reflect.Bool || !required.Bool() {
+		return flag, false
+	}
+	required.SetBool(false)
+	return copied.Interface().(cli.Flag), true
+}
+
+// flagName is the long name of the flag, which names it in the config file
+func flagName(flag cli.Flag) string {
+	name := ""
+	for _, n := range strings.Split(flag.GetName(), ",") {
+		if n = strings.TrimSpace(n); len(n) > len(name) {
+			name = n
+		}
+	}
+	return name
+}
@@ -0,0 +1,134 @@
+package configfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+// config is what the action of the test app reads from its flags
+type config struct {
+	host     string
+	port     int
+	timeout  time.Duration
+	verbose  bool
+	quorums  []string
+	required string
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// run runs a test app with the config file support, and returns the config it read
+func run(t *testing.T, args ...string) (config, error) {
+	defaultQuorums := cli.StringSlice{"0"}
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "test.host", Value: "localhost", EnvVar: "TEST_HOST"},
+		cli.IntFlag{Name: "test.port", Value: 8080, EnvVar: "TEST_PORT"},
+		cli.DurationFlag{Name: "test.timeout", Value: time.Second, EnvVar: "TEST_TIMEOUT"},
+		cli.BoolFlag{Name: "test.verbose", EnvVar: "TEST_VERBOSE"},
+		cli.StringSliceFlag{Name: "test.quorums", Value: &defaultQuorums, EnvVar: "TEST_QUORUMS"},
+		cli.StringFlag{Name: "test.required", Required: true, EnvVar: "TEST_REQUIRED"},
+		configfile.CLIFlag("TEST"),
+	}
+	configfile.Enable(app)
+	var got config
+	app.Action = func(ctx *cli.Context) error {
+		got = config{
+			host:     ctx.GlobalString("test.host"),
+			port:     ctx.GlobalInt("test.port"),
+			timeout:  ctx.GlobalDuration("test.timeout"),
+			verbose:  ctx.GlobalBool("test.verbose"),
+			quorums:  ctx.GlobalStringSlice("test.quorums"),
+			required: ctx.GlobalString("test.required"),
+		}
+		return nil
+	}
+	err := app.Run(append([]string{"test"}, args...))
+	return got, err
+}
+
+func TestPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+test:
+  host: file-host
+  port: 9000
+  timeout: 5s
+  required: file-required
+test.quorums: [1, 2]
+`)
+
+	// The file overrides the defaults, and its nested and dotted keys are the names of the flags
+	got, err := run(t, "--config", path)
+	assert.NoError(t, err)
+	assert.Equal(t, config{host: "file-host", port: 9000, timeout: 5 * time.Second, quorums: []string{"1", "2"}, required: "file-required"}, got)
+
+	// The environment overrides the file, and the command line overrides both
+	t.Setenv("TEST_HOST", "env-host")
+	t.Setenv("TEST_PORT", "9001")
+	got, err = run(t, "--config", path, "--test.port", "9002", "--test.verbose")
+	assert.NoError(t, err)
+	assert.Equal(t, config{host: "env-host", port: 9002, timeout: 5 * time.Second, verbose: true, quorums: []string{"1", "2"}, required: "file-required"}, got)
+
+	// The path of the config file can also be set by the environment
+	t.Setenv("TEST_CONFIG", path)
+	got, err = run(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "file-required", got.required)
+}
+
+func TestTOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+"test.required" = "file-required"
+
+[test]
+host = "file-host"
+port = 9000
+verbose = true
+quorums = ["3"]
+`)
+	got, err := run(t, "--config", path)
+	assert.NoError(t, err)
+	assert.Equal(t, config{host: "file-host", port: 9000, timeout: time.Second, verbose: true, quorums: []string{"3"}, required: "file-required"}, got)
+}
+
+func TestRequiredFlags(t *testing.T) {
+	// The required flags can be set on the command line without a config file
+	got, err := run(t, "--test.required", "cli-required")
+	assert.NoError(t, err)
+	assert.Equal(t, "cli-required", got.required)
+	assert.Equal(t, []string{"0"}, got.quorums)
+
+	_, err = run(t, "--config", writeFile(t, "config.yaml", "test.host: file-host\n"))
+	assert.ErrorContains(t, err, `required flags "test.required" not set`)
+}
+
+func TestInvalidFiles(t *testing.T) {
+	_, err := run(t, "--config", writeFile(t, "config.yaml", "test:\n  required: x\n  hots: file-host\nother: 1\n"))
+	assert.ErrorContains(t, err, "unknown keys in the config file")
+	assert.ErrorContains(t, err, "other, test.hots")
+
+	_, err = run(t, "--config", writeFile(t, "config.yaml", "test.required: x\ntest:\n  required: y\n"))
+	assert.ErrorContains(t, err, "duplicate key test.required")
+
+	_, err = run(t, "--config", writeFile(t, "config.yaml", "test.required: x\ntest.port: [1, 2]\n"))
+	assert.ErrorContains(t, err, "invalid value of test.port")
+
+	_, err = run(t, "--config", writeFile(t, "config.yaml", "test.required: x\ntest.port: eighty\n"))
+	assert.ErrorContains(t, err, "invalid value of test.port")
+
+	_, err = run(t, "--config", writeFile(t, "config.yaml", "test.required: x\nconfig: other.yaml\n"))
+	assert.ErrorContains(t, err, "unknown keys in the config file")
+
+	_, err = run(t, "--config", writeFile(t, "config.json", "{}"))
+	assert.ErrorContains(t, err, "unsupported config file extension")
+}
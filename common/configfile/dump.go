@@ -0,0 +1,235 @@
+package configfile
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// DumpCommandName is the command printing the effective config of the service, see AddDumpCommand
+const DumpCommandName = "dump-config"
+
+// loadedMetadataKey is the key of the metadata of the app holding the names of the flags set by the config file
+const loadedMetadataKey = "configfile.loaded"
+
+// redacted replaces the secrets in the dump
+const redacted = "<redacted>"
+
+// secretNames are the words of the names of the fields and flags holding secrets, once lowercased and stripped of
+// their separators
+var secretNames = []string{"privatekey", "privatebls", "keypair", "password", "secret", "accesskey", "token", "mnemonic"}
+
+// Flag sources, i.e. where the value of a flag comes from
+const (
+	sourceFlag    = "flag"
+	sourceEnv     = "env"
+	sourceFile    = "file"
+	sourceDefault = "default"
+)
+
+// AddDumpCommand adds the dump-config command to the app, which prints as YAML the config newConfig reads from the
+// flags, as the action of the app does, followed by the value of each flag and its source. The secrets are redacted.
+// newConfig must not connect to anything, e.g. it must not construct the chain or AWS clients, so that the command
+// can check the config of a deployment offline, such as in CI.
+func AddDumpCommand(app *cli.App, newConfig func(ctx *cli.Context) (any, error)) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:  DumpCommandName,
+		Usage: "print the effective config of the service with its secrets redacted, along with the source of each flag",
+		Action: func(ctx *cli.Context) error {
+			// The flags of the app belong to the context of the app rather than the one of the command
+			appCtx := ctx.Parent()
+			config, err := newConfig(appCtx)
+			if err != nil {
+				return err
+			}
+			doc := &yaml.Node{Kind: yaml.MappingNode}
+			doc.Content = append(doc.Content, scalarNode("config"), toNode(reflect.ValueOf(config)))
+			doc.Content = append(doc.Content, scalarNode("flags"), flagsNode(appCtx))
+			encoder := yaml.NewEncoder(ctx.App.Writer)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(doc); err != nil {
+				return err
+			}
+			return encoder.Close()
+		},
+	})
+}
+
+// isSecret tells whether the field or flag of the name holds a secret
+func isSecret(name string) bool {
+	name = strings.NewReplacer("-", "", "_", "", ".", "").Replace(strings.ToLower(name))
+	for _, secret := range secretNames {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL redacts the password of the value if it's a URL with one, e.g. of a proxy
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+	return u.Redacted()
+}
+
+func scalarNode(value any) *yaml.Node {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(value)}
+	}
+	return node
+}
+
+// toNode converts the value to a YAML node, where the structs are mappings of their exported fields, the byte arrays
+// and slices are hex strings, and the values with a String method are their string. The fields of the secrets are
+// redacted unless they're empty.
+func toNode(v reflect.Value) *yaml.Node {
+	if !v.IsValid() {
+		return scalarNode(nil)
+	}
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return scalarNode(nil)
+	}
+	if !v.CanInterface() {
+		return scalarNode(nil)
+	}
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return scalarNode(redactURL(stringer.String()))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return toNode(v.Elem())
+	case reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			value := v.Field(i)
+			if !field.IsExported() || value.Kind() == reflect.Func || value.Kind() == reflect.Chan {
+				continue
+			}
+			valueNode := toNode(value)
+			if isSecret(field.Name) && !value.IsZero() {
+				valueNode = scalarNode(redacted)
+			}
+			node.Content = append(node.Content, scalarNode(field.Name), valueNode)
+		}
+		return node
+	case reflect.Map:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			node.Content = append(node.Content, scalarNode(fmt.Sprint(key.Interface())), toNode(v.MapIndex(key)))
+		}
+		return node
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(bytes), v)
+			return scalarNode("0x" + hex.EncodeToString(bytes))
+		}
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for i := 0; i < v.Len(); i++ {
+			node.Content = append(node.Content, toNode(v.Index(i)))
+		}
+		if len(node.Content) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		return node
+	case reflect.String:
+		return scalarNode(redactURL(v.String()))
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return scalarNode(nil)
+	default:
+		return scalarNode(v.Interface())
+	}
+}
+
+// flagsNode maps the flags of the app to their value and source
+func flagsNode(ctx *cli.Context) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range ctx.App.Flags {
+		name := flagName(f)
+		if name == "help" || name == "version" {
+			continue
+		}
+		var value any
+		current, ok := ctx.GlobalGeneric(name).(flag.Value)
+		if getter, isGetter := current.(flag.Getter); isGetter {
+			value = getter.Get()
+		} else if ok {
+			value = current.String()
+		}
+		valueNode := toNode(reflect.ValueOf(value))
+		if isSecret(name) && value != nil && !reflect.ValueOf(value).IsZero() {
+			valueNode = scalarNode(redacted)
+		}
+		flagNode := &yaml.Node{Kind: yaml.MappingNode}
+		flagNode.Content = append(flagNode.Content, scalarNode("value"), valueNode, scalarNode("source"), scalarNode(flagSource(ctx, f, name, current)))
+		node.Content = append(node.Content, scalarNode(name), flagNode)
+	}
+	return node
+}
+
+// flagSource returns where the value of the flag comes from. A flag that is set on the command line to the value of
+// its environment variable is reported as set by the environment.
+func flagSource(ctx *cli.Context, f cli.Flag, name string, current flag.Value) string {
+	loaded, _ := ctx.App.Metadata[loadedMetadataKey].(map[string]bool)
+	if loaded[name] {
+		return sourceFile
+	}
+	if !ctx.GlobalIsSet(name) {
+		return sourceDefault
+	}
+	if env, ok := envValue(f, name); ok && current != nil && env == current.String() {
+		return sourceEnv
+	}
+	return sourceFlag
+}
+
+// envValue returns the value the environment sets the flag to as the string of its flag.Value, and whether the
+// environment sets it
+func envValue(f cli.Flag, name string) (string, bool) {
+	value := reflect.ValueOf(f)
+	if value.Kind() != reflect.Struct {
+		return "", false
+	}
+	envVar := value.FieldByName("EnvVar")
+	if !envVar.IsValid() || envVar.Kind() != reflect.String {
+		return "", false
+	}
+	found := false
+	for _, env := range strings.Split(envVar.String(), ",") {
+		if _, ok := os.LookupEnv(strings.TrimSpace(env)); ok {
+			found = true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	// Applying the flag to a new flag set parses its environment variable as the app did
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	f.Apply(set)
+	applied := set.Lookup(name)
+	if applied == nil {
+		return "", false
+	}
+	return applied.Value.String(), true
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultCorrelationIDKey is the default gRPC metadata key of the correlation IDs of the requests
+const DefaultCorrelationIDKey = "x-correlation-id"
+
+// maxCorrelationIDLength bounds the size of the IDs the clients supply, which end up in every log of the request
+const maxCorrelationIDLength = 128
+
+type correlationIDKey struct{}
+type loggerKey struct{}
+
+// CorrelationIDFromContext returns the correlation ID of the request of the context, if it has one
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok
+}
+
+// ContextWithLogger returns a context carrying the logger of its request
+func ContextWithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger of the request of the context, and the fallback if it has none
+func LoggerFromContext(ctx context.Context, fallback Logger) Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// CorrelationIDUnaryServerInterceptor tags the requests with the correlation ID the clients supply in the metadata
+// key, or a generated one if they don't supply a valid one. The ID is echoed back in the header of the response
+// and forwarded in the metadata of the requests the server makes on behalf of the request. The context of the
+// handler carries the ID and a logger with it, and the request is logged with it once it is done.
+func CorrelationIDUnaryServerInterceptor(key string, logger Logger) grpc.UnaryServerInterceptor {
+	if key == "" {
+		key = DefaultCorrelationIDKey
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, requestLogger, header := correlateRequest(ctx, key, logger)
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			requestLogger.Warn("failed to set the correlation ID of the response", "err", err)
+		}
+		reply, err := handler(ctx, req)
+
+		requestLogger.Info("handled request", "method", info.FullMethod, "code", status.Code(err), "latency", time.Since(start))
+		return reply, err
+	}
+}
+
+// CorrelationIDStreamServerInterceptor is CorrelationIDUnaryServerInterceptor for the streams, whose handlers see
+// the ID and the logger in the context of their stream
+func CorrelationIDStreamServerInterceptor(key string, logger Logger) grpc.StreamServerInterceptor {
+	if key == "" {
+		key = DefaultCorrelationIDKey
+	}
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, requestLogger, header := correlateRequest(ss.Context(), key, logger)
+		if err := ss.SetHeader(header); err != nil {
+			requestLogger.Warn("failed to set the correlation ID of the response", "err", err)
+		}
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+
+		requestLogger.Info("handled request", "method", info.FullMethod, "code", status.Code(err), "latency", time.Since(start))
+		return err
+	}
+}
+
+// correlateRequest returns the context of the handler of the request, carrying its correlation ID and its logger,
+// along with the logger and the header of the response echoing the ID
+func correlateRequest(ctx context.Context, key string, logger Logger) (context.Context, Logger, metadata.MD) {
+	id := ""
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 && validCorrelationID(values[0]) {
+		id = values[0]
+	} else {
+		id = uuid.NewString()
+	}
+	requestLogger := logger.New("correlation_id", id)
+	ctx = context.WithValue(ctx, correlationIDKey{}, id)
+	ctx = ContextWithLogger(ctx, requestLogger)
+	ctx = metadata.AppendToOutgoingContext(ctx, key, id)
+	return ctx, requestLogger, metadata.Pairs(key, id)
+}
+
+// contextServerStream is a server stream whose handler sees ctx as its context
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// validCorrelationID accepts the IDs of printable ASCII characters, which can't forge log lines
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x20 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
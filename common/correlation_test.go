@@ -0,0 +1,159 @@
+package common_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// recordingLogger returns a logger recording the messages it logs with their context
+func recordingLogger() (common.Logger, func() []*log.Record) {
+	var (
+		mu      sync.Mutex
+		records []*log.Record
+	)
+	logger := log.New()
+	logger.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, r)
+		return nil
+	}))
+	return &logging.Logger{Logger: logger}, func() []*log.Record {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*log.Record{}, records...)
+	}
+}
+
+// contextValue returns the value of the key in the context of the record
+func contextValue(record *log.Record, key string) any {
+	for i := 0; i+1 < len(record.Ctx); i += 2 {
+		if record.Ctx[i] == key {
+			return record.Ctx[i+1]
+		}
+	}
+	return nil
+}
+
+// handlerContext is what the handler of a request sees of its context
+type handlerContext struct {
+	correlationID string
+	outgoing      []string
+}
+
+// serveCorrelatedHealth serves the health service behind the correlation ID interceptor, and returns its address
+// along with the contexts its handlers see
+func serveCorrelatedHealth(t *testing.T, key string, logger common.Logger) (string, chan handlerContext) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	contexts := make(chan handlerContext, 10)
+	recordContext := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id, _ := common.CorrelationIDFromContext(ctx)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		contexts <- handlerContext{correlationID: id, outgoing: md.Get(common.DefaultCorrelationIDKey)}
+		common.LoggerFromContext(ctx, nil).Info("checking health")
+		return handler(ctx, req)
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(common.CorrelationIDUnaryServerInterceptor(key, logger), recordContext))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), contexts
+}
+
+func checkCorrelatedHealth(t *testing.T, address string, md metadata.MD) metadata.MD {
+	conn, err := grpc.Dial(address, (&common.GRPCClientOptions{}).DialOptions()...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	var header metadata.MD
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header))
+	assert.NoError(t, err)
+	return header
+}
+
+func TestCorrelationIDUnaryServerInterceptor(t *testing.T) {
+	logger, records := recordingLogger()
+	address, contexts := serveCorrelatedHealth(t, "", logger)
+
+	header := checkCorrelatedHealth(t, address, metadata.Pairs(common.DefaultCorrelationIDKey, "rollup-42"))
+	assert.Equal(t, []string{"rollup-42"}, header.Get(common.DefaultCorrelationIDKey))
+	assert.Equal(t, handlerContext{correlationID: "rollup-42", outgoing: []string{"rollup-42"}}, <-contexts)
+
+	// The logs of the handler and the log of the request carry the ID
+	logged := records()
+	assert.Len(t, logged, 2)
+	assert.Equal(t, "checking health", logged[0].Msg)
+	assert.Equal(t, "rollup-42", contextValue(logged[0], "correlation_id"))
+	assert.Equal(t, "handled request", logged[1].Msg)
+	assert.Equal(t, "rollup-42", contextValue(logged[1], "correlation_id"))
+	assert.Equal(t, "/grpc.health.v1.Health/Check", contextValue(logged[1], "method"))
+}
+
+func TestCorrelationIDUnaryServerInterceptorGeneratesID(t *testing.T) {
+	logger, _ := recordingLogger()
+	address, contexts := serveCorrelatedHealth(t, "x-request-id", logger)
+
+	// The IDs that are missing or too long are replaced
+	for _, md := range []metadata.MD{
+		nil,
+		metadata.Pairs(common.DefaultCorrelationIDKey, "ignored"),
+		metadata.Pairs("x-request-id", strings.Repeat("a", 129)),
+	} {
+		header := checkCorrelatedHealth(t, address, md)
+		ids := header.Get("x-request-id")
+		assert.Len(t, ids, 1)
+		assert.Len(t, ids[0], 36)
+		assert.Equal(t, ids[0], (<-contexts).correlationID)
+	}
+}
+
+func TestCorrelationIDStreamServerInterceptor(t *testing.T) {
+	logger, records := recordingLogger()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	contexts := make(chan handlerContext, 1)
+	recordContext := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id, _ := common.CorrelationIDFromContext(ss.Context())
+		md, _ := metadata.FromOutgoingContext(ss.Context())
+		contexts <- handlerContext{correlationID: id, outgoing: md.Get(common.DefaultCorrelationIDKey)}
+		return handler(srv, ss)
+	}
+	server := grpc.NewServer(grpc.ChainStreamInterceptor(common.CorrelationIDStreamServerInterceptor("", logger), recordContext))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), (&common.GRPCClientOptions{}).DialOptions()...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), metadata.Pairs(common.DefaultCorrelationIDKey, "rollup-42")))
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+	header, err := stream.Header()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rollup-42"}, header.Get(common.DefaultCorrelationIDKey))
+	assert.Equal(t, handlerContext{correlationID: "rollup-42", outgoing: []string{"rollup-42"}}, <-contexts)
+
+	// The stream is logged with the ID once it is done
+	cancel()
+	assert.Eventually(t, func() bool {
+		logged := records()
+		return len(logged) == 1 && logged[0].Msg == "handled request" && contextValue(logged[0], "correlation_id") == "rollup-42"
+	}, time.Second, 10*time.Millisecond)
+}
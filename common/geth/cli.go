@@ -13,6 +13,9 @@ var (
 type EthClientConfig struct {
 	RPCURL           string
 	PrivateKeyString string
+	// Proxy is the proxy of the connections to the RPC. It isn't read from the flags, since it applies to the
+	// other connections of the binaries too.
+	Proxy common.ProxyConfig
 }
 
 func EthClientFlags(envPrefix string) []cli.Flag {
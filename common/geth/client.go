@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/ethereum/go-ethereum"
@@ -15,6 +16,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
 )
 
 var (
@@ -35,11 +38,31 @@ type EthClient struct {
 
 var _ common.EthClient = (*EthClient)(nil)
 
+// DialRPC connects to the RPC of the config, through its proxy if it sets one. Otherwise, the connections use
+// the proxy of the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables, as the ones of ethclient.Dial.
+func DialRPC(ctx context.Context, config EthClientConfig) (*rpc.Client, error) {
+	if config.Proxy.URL == "" {
+		return rpc.DialContext(ctx, config.RPCURL)
+	}
+	proxy, err := config.Proxy.HTTPProxy()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	return rpc.DialOptions(ctx, config.RPCURL,
+		rpc.WithHTTPClient(&http.Client{Transport: transport}),
+		// The buffer sizes are the defaults of the websocket dialer of rpc.Dial
+		rpc.WithWebsocketDialer(websocket.Dialer{ReadBufferSize: 1024, WriteBufferSize: 1024, Proxy: proxy}),
+	)
+}
+
 func NewClient(config EthClientConfig, logger common.Logger) (*EthClient, error) {
-	chainClient, err := ethclient.Dial(config.RPCURL)
+	rpcClient, err := DialRPC(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf("NewClient: cannot connect to provider: %w", err)
 	}
+	chainClient := ethclient.NewClient(rpcClient)
 	var accountAddress gethcommon.Address
 	var privateKey *ecdsa.PrivateKey
 	var opts *bind.TransactOpts
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc/backoff"
+)
+
+const (
+	ConnectBackoffBaseDelayFlagName  = "node-connect-backoff-base-delay"
+	ConnectBackoffMaxDelayFlagName   = "node-connect-backoff-max-delay"
+	ConnectBackoffMultiplierFlagName = "node-connect-backoff-multiplier"
+)
+
+// ConnectBackoff is the backoff between the attempts of a gRPC connection to reconnect after a failure. The delay
+// starts at BaseDelay and is multiplied by Multiplier after every failed attempt, up to MaxDelay. The delays keep
+// the jitter of grpc, so that the clients of an operator coming back online don't all reconnect at once.
+type ConnectBackoff struct {
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	Multiplier float64
+}
+
+// DefaultConnectBackoff is the backoff of grpc
+var DefaultConnectBackoff = ConnectBackoff{
+	BaseDelay:  backoff.DefaultConfig.BaseDelay,
+	MaxDelay:   backoff.DefaultConfig.MaxDelay,
+	Multiplier: backoff.DefaultConfig.Multiplier,
+}
+
+func (b ConnectBackoff) config() backoff.Config {
+	return backoff.Config{
+		BaseDelay:  b.BaseDelay,
+		Multiplier: b.Multiplier,
+		Jitter:     backoff.DefaultConfig.Jitter,
+		MaxDelay:   b.MaxDelay,
+	}
+}
+
+// ConnectBackoffCLIFlags are the flags of the backoff of the connections to the operators
+func ConnectBackoffCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.DurationFlag{
+			Name:   PrefixFlag(flagPrefix, ConnectBackoffBaseDelayFlagName),
+			Usage:  "Delay before reconnecting to an operator after the first failed connection attempt",
+			Value:  DefaultConnectBackoff.BaseDelay,
+			EnvVar: PrefixEnvVar(envPrefix, "NODE_CONNECT_BACKOFF_BASE_DELAY"),
+		},
+		cli.DurationFlag{
+			Name:   PrefixFlag(flagPrefix, ConnectBackoffMaxDelayFlagName),
+			Usage:  "Maximum delay between the attempts to reconnect to an operator",
+			Value:  DefaultConnectBackoff.MaxDelay,
+			EnvVar: PrefixEnvVar(envPrefix, "NODE_CONNECT_BACKOFF_MAX_DELAY"),
+		},
+		cli.Float64Flag{
+			Name:   PrefixFlag(flagPrefix, ConnectBackoffMultiplierFlagName),
+			Usage:  "Factor by which the delay before reconnecting to an operator grows after every failed attempt",
+			Value:  DefaultConnectBackoff.Multiplier,
+			EnvVar: PrefixEnvVar(envPrefix, "NODE_CONNECT_BACKOFF_MULTIPLIER"),
+		},
+	}
+}
+
+func ReadConnectBackoffCLIConfig(ctx *cli.Context, flagPrefix string) ConnectBackoff {
+	return ConnectBackoff{
+		BaseDelay:  ctx.GlobalDuration(PrefixFlag(flagPrefix, ConnectBackoffBaseDelayFlagName)),
+		MaxDelay:   ctx.GlobalDuration(PrefixFlag(flagPrefix, ConnectBackoffMaxDelayFlagName)),
+		Multiplier: ctx.GlobalFloat64(PrefixFlag(flagPrefix, ConnectBackoffMultiplierFlagName)),
+	}
+}
+
+// ValidateConnectBackoffCLIFlags checks that the delays are positive and don't shrink between the attempts
+func ValidateConnectBackoffCLIFlags(ctx *cli.Context, flagPrefix string) error {
+	var v validation.Violations
+	b := ReadConnectBackoffCLIConfig(ctx, flagPrefix)
+	v.Add(validation.AtLeast(PrefixFlag(flagPrefix, ConnectBackoffBaseDelayFlagName), b.BaseDelay, time.Millisecond))
+	v.Add(validation.AtLeast(PrefixFlag(flagPrefix, ConnectBackoffMaxDelayFlagName), b.MaxDelay, b.BaseDelay))
+	v.Add(validation.AtLeast(PrefixFlag(flagPrefix, ConnectBackoffMultiplierFlagName), b.Multiplier, 1.0))
+	return v.Err()
+}
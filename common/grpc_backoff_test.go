@@ -0,0 +1,98 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+)
+
+// dialAttempts connects with the options to a server that refuses the connections, and returns the times of the
+// connection attempts
+func dialAttempts(t *testing.T, options common.GRPCClientOptions) <-chan time.Time {
+	attempts := make(chan time.Time, 100)
+	options.ContextDialer = func(ctx context.Context, address string) (net.Conn, error) {
+		select {
+		case attempts <- time.Now():
+		default:
+		}
+		return nil, errors.New("connection refused")
+	}
+	conn, err := grpc.Dial("operator:32005", options.DialOptions()...)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	conn.Connect()
+	return attempts
+}
+
+// nextAttempt waits for the next connection attempt, and returns its time
+func nextAttempt(t *testing.T, attempts <-chan time.Time) time.Time {
+	select {
+	case at := <-attempts:
+		return at
+	case <-time.After(5 * time.Second):
+		t.Fatal("no connection attempt within 5s")
+		return time.Time{}
+	}
+}
+
+func TestGRPCClientOptionsConnectBackoff(t *testing.T) {
+	// The first reconnection of grpc waits for a second, give or take its jitter of 20%
+	attempts := dialAttempts(t, common.GRPCClientOptions{})
+	first := nextAttempt(t, attempts)
+	assert.GreaterOrEqual(t, nextAttempt(t, attempts).Sub(first), 800*time.Millisecond)
+
+	// The reconnections wait for the delays of the backoff instead, which grow up to its maximum
+	fast := &common.ConnectBackoff{BaseDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond, Multiplier: 2}
+	attempts = dialAttempts(t, common.GRPCClientOptions{ConnectBackoff: fast})
+	first = nextAttempt(t, attempts)
+	last := first
+	for i := 0; i < 5; i++ {
+		at := nextAttempt(t, attempts)
+		assert.GreaterOrEqual(t, at.Sub(last), 8*time.Millisecond)
+		last = at
+	}
+	// Well before grpc would have reconnected once
+	assert.Less(t, last.Sub(first), 800*time.Millisecond)
+
+	// The backoff of the options takes precedence over the defaults
+	var options *common.GRPCClientOptions
+	assert.Equal(t, fast, options.WithDefaults(common.GRPCClientOptions{ConnectBackoff: fast}).ConnectBackoff)
+	slow := &common.ConnectBackoff{BaseDelay: time.Minute, MaxDelay: time.Minute, Multiplier: 1}
+	options = &common.GRPCClientOptions{ConnectBackoff: slow}
+	assert.Equal(t, slow, options.WithDefaults(common.GRPCClientOptions{ConnectBackoff: fast}).ConnectBackoff)
+}
+
+func runConnectBackoffFlags(t *testing.T, args ...string) (common.ConnectBackoff, error) {
+	app := cli.NewApp()
+	app.Flags = common.ConnectBackoffCLIFlags("TEST", "test")
+	var config common.ConnectBackoff
+	app.Action = func(ctx *cli.Context) error {
+		config = common.ReadConnectBackoffCLIConfig(ctx, "test")
+		return common.ValidateConnectBackoffCLIFlags(ctx, "test")
+	}
+	err := app.Run(append([]string{"test"}, args...))
+	return config, err
+}
+
+func TestConnectBackoffCLIFlags(t *testing.T) {
+	config, err := runConnectBackoffFlags(t)
+	assert.NoError(t, err)
+	assert.Equal(t, common.DefaultConnectBackoff, config)
+
+	config, err = runConnectBackoffFlags(t, "--test.node-connect-backoff-base-delay", "2s", "--test.node-connect-backoff-max-delay", "30s", "--test.node-connect-backoff-multiplier", "3")
+	assert.NoError(t, err)
+	assert.Equal(t, common.ConnectBackoff{BaseDelay: 2 * time.Second, MaxDelay: 30 * time.Second, Multiplier: 3}, config)
+
+	_, err = runConnectBackoffFlags(t, "--test.node-connect-backoff-base-delay", "0s", "--test.node-connect-backoff-max-delay", "-1s", "--test.node-connect-backoff-multiplier", "0.5")
+	assert.ErrorContains(t, err, "invalid configuration (3 errors)")
+	assert.ErrorContains(t, err, "test.node-connect-backoff-base-delay: 0s is less than 1ms")
+	assert.ErrorContains(t, err, "test.node-connect-backoff-max-delay: -1s is less than 0s")
+	assert.ErrorContains(t, err, "test.node-connect-backoff-multiplier: 0.5 is less than 1")
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/proto"
+)
+
+// CompressionObserver is notified of the messages sent with and without compression by the gRPC clients and servers
+// with a compression threshold
+type CompressionObserver interface {
+	ObserveCompression(method string, compressed bool)
+}
+
+// compressionThresholdUnaryInterceptor sends the requests smaller than the threshold uncompressed, as the cost of
+// compressing tiny messages outweighs the bytes it saves
+func compressionThresholdUnaryInterceptor(threshold int, observer CompressionObserver) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		compressed := !belowThreshold(req, threshold)
+		if !compressed {
+			// The options of the call override the default compressor of the connection
+			opts = append(opts, grpc.UseCompressor(encoding.Identity))
+		}
+		if observer != nil {
+			observer.ObserveCompression(method, compressed)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// CompressionThresholdServerInterceptor compresses the replies of the server that are at least the threshold in
+// size with gzip, provided the client accepts it, and sends the smaller ones uncompressed. Without it, the server
+// compresses the replies if and only if the requests were compressed.
+func CompressionThresholdServerInterceptor(threshold int, observer CompressionObserver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		reply, err := handler(ctx, req)
+		if err != nil {
+			return reply, err
+		}
+
+		compressed := !belowThreshold(reply, threshold) && acceptsGzip(ctx)
+		compressor := encoding.Identity
+		if compressed {
+			compressor = gzip.Name
+		}
+		if err := grpc.SetSendCompressor(ctx, compressor); err != nil {
+			// The reply is sent with the default compression of the server
+			return reply, nil
+		}
+		if observer != nil {
+			observer.ObserveCompression(info.FullMethod, compressed)
+		}
+		return reply, nil
+	}
+}
+
+// belowThreshold returns whether the message is smaller than the threshold. Messages that are not protobuf messages
+// are never below the threshold, as their size is unknown.
+func belowThreshold(message any, threshold int) bool {
+	m, ok := message.(proto.Message)
+	return ok && proto.Size(m) < threshold
+}
+
+func acceptsGzip(ctx context.Context) bool {
+	compressors, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil {
+		return false
+	}
+	for _, compressor := range compressors {
+		if compressor == gzip.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package common
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+// minConnectTimeout is the default minimum time grpc gives a connection attempt to complete
+const minConnectTimeout = 20 * time.Second
+
+// MinWindowSize is the smallest HTTP/2 flow-control window in bytes grpc accepts, which is the default window of
+// HTTP/2. The smaller windows are ignored.
+const MinWindowSize = 65535
+
+// GRPCClientOptions are the options of the gRPC connections opened by the clients. The fields that are not set
+// keep the defaults of the client they are passed to, and otherwise the defaults of grpc.
+//
+// With compression, the maximum send size applies to the compressed messages while the maximum receive size
+// applies to the decompressed ones, on the client as on the server. Compressed requests may thus exceed
+// MaxSendMsgSize, but not the receive limit of the server (4 MiB unless the server raises it).
+// Compression requires the server to support gzip, which is the case of the EigenDA services.
+type GRPCClientOptions struct {
+	// MaxRecvMsgSize is the maximum size in bytes of the messages the client receives
+	MaxRecvMsgSize int
+	// MaxSendMsgSize is the maximum size in bytes of the messages the client sends
+	MaxSendMsgSize int
+	// UseCompression enables the gzip compression of the messages if it's true, or disables it if it's false even
+	// if the defaults enable it. The defaults apply if it isn't set.
+	UseCompression *bool
+	// CompressionThreshold is the size in bytes of the requests below which they are sent uncompressed even if
+	// compression is enabled
+	CompressionThreshold int
+	// CompressionObserver, if set, is notified of the requests sent with and without compression when
+	// compression is enabled
+	CompressionObserver CompressionObserver
+	// Keepalive are the keepalive parameters of the connections
+	Keepalive *keepalive.ClientParameters
+	// Timeout is the timeout of the unary RPCs whose context has no deadline. The timeouts the clients set on
+	// their requests still apply.
+	Timeout time.Duration
+	// TransportCredentials are the credentials of the connections, e.g. TLS. The connections are insecure if
+	// neither the options nor the defaults of the client set them.
+	TransportCredentials credentials.TransportCredentials
+	// ConnectBackoff is the backoff between the attempts to reconnect after a failed connection, the one of grpc
+	// if it isn't set
+	ConnectBackoff *ConnectBackoff
+	// ContextDialer replaces the TCP dialer of grpc, e.g. to connect to an in-memory server over bufconn
+	ContextDialer func(ctx context.Context, address string) (net.Conn, error)
+	// InitialWindowSize and InitialConnWindowSize are the HTTP/2 flow-control windows in bytes of each stream and
+	// of the whole connection. Unless they are set, grpc sizes the windows from its estimate of the bandwidth-delay
+	// product of the link, up to 16 MiB. Setting them fixes the windows instead, which disables the estimate: large
+	// windows let a single download use the bandwidth of a link with a long round trip, at the cost of buffering up
+	// to a window of data per stream and per connection. Windows below MinWindowSize are ignored by grpc.
+	InitialWindowSize     int32
+	InitialConnWindowSize int32
+	// UnaryInterceptors and StreamInterceptors are chained in order after the defaults of the client
+	UnaryInterceptors  []grpc.UnaryClientInterceptor
+	StreamInterceptors []grpc.StreamClientInterceptor
+}
+
+// WithDefaults returns the options with the fields that are not set taken from the defaults.
+// A nil receiver returns the defaults.
+func (o *GRPCClientOptions) WithDefaults(defaults GRPCClientOptions) GRPCClientOptions {
+	if o == nil {
+		return defaults
+	}
+	options := *o
+	if options.MaxRecvMsgSize == 0 {
+		options.MaxRecvMsgSize = defaults.MaxRecvMsgSize
+	}
+	if options.MaxSendMsgSize == 0 {
+		options.MaxSendMsgSize = defaults.MaxSendMsgSize
+	}
+	if options.UseCompression == nil {
+		options.UseCompression = defaults.UseCompression
+	}
+	if options.CompressionThreshold == 0 {
+		options.CompressionThreshold = defaults.CompressionThreshold
+	}
+	if options.CompressionObserver == nil {
+		options.CompressionObserver = defaults.CompressionObserver
+	}
+	if options.Keepalive == nil {
+		options.Keepalive = defaults.Keepalive
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaults.Timeout
+	}
+	if options.TransportCredentials == nil {
+		options.TransportCredentials = defaults.TransportCredentials
+	}
+	if options.ConnectBackoff == nil {
+		options.ConnectBackoff = defaults.ConnectBackoff
+	}
+	if options.ContextDialer == nil {
+		options.ContextDialer = defaults.ContextDialer
+	}
+	if options.InitialWindowSize == 0 {
+		options.InitialWindowSize = defaults.InitialWindowSize
+	}
+	if options.InitialConnWindowSize == 0 {
+		options.InitialConnWindowSize = defaults.InitialConnWindowSize
+	}
+	options.UnaryInterceptors = append(append([]grpc.UnaryClientInterceptor{}, defaults.UnaryInterceptors...), o.UnaryInterceptors...)
+	options.StreamInterceptors = append(append([]grpc.StreamClientInterceptor{}, defaults.StreamInterceptors...), o.StreamInterceptors...)
+	return options
+}
+
+// DialOptions returns the options of grpc.Dial
+func (o *GRPCClientOptions) DialOptions() []grpc.DialOption {
+	if o == nil {
+		o = &GRPCClientOptions{}
+	}
+
+	creds := o.TransportCredentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+
+	var callOptions []grpc.CallOption
+	if o.MaxRecvMsgSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallRecvMsgSize(o.MaxRecvMsgSize))
+	}
+	if o.MaxSendMsgSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallSendMsgSize(o.MaxSendMsgSize))
+	}
+	compress := o.UseCompression != nil && *o.UseCompression
+	if compress {
+		callOptions = append(callOptions, grpc.UseCompressor(gzip.Name))
+	}
+	if len(callOptions) > 0 {
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(callOptions...))
+	}
+
+	if o.Keepalive != nil {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(*o.Keepalive))
+	}
+
+	if o.ConnectBackoff != nil {
+		// The connect params replace the minimum connect timeout too, which keeps the default of grpc
+		dialOptions = append(dialOptions, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           o.ConnectBackoff.config(),
+			MinConnectTimeout: minConnectTimeout,
+		}))
+	}
+
+	if o.ContextDialer != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(o.ContextDialer))
+	}
+
+	if o.InitialWindowSize > 0 {
+		dialOptions = append(dialOptions, grpc.WithInitialWindowSize(o.InitialWindowSize))
+	}
+	if o.InitialConnWindowSize > 0 {
+		dialOptions = append(dialOptions, grpc.WithInitialConnWindowSize(o.InitialConnWindowSize))
+	}
+
+	unaryInterceptors := o.UnaryInterceptors
+	if compress && (o.CompressionThreshold > 0 || o.CompressionObserver != nil) {
+		unaryInterceptors = append([]grpc.UnaryClientInterceptor{compressionThresholdUnaryInterceptor(o.CompressionThreshold, o.CompressionObserver)}, unaryInterceptors...)
+	}
+	if o.Timeout > 0 {
+		unaryInterceptors = append([]grpc.UnaryClientInterceptor{timeoutUnaryInterceptor(o.Timeout)}, unaryInterceptors...)
+	}
+	if len(unaryInterceptors) > 0 {
+		dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(unaryInterceptors...))
+	}
+	if len(o.StreamInterceptors) > 0 {
+		dialOptions = append(dialOptions, grpc.WithChainStreamInterceptor(o.StreamInterceptors...))
+	}
+
+	return dialOptions
+}
+
+func timeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
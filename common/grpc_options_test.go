@@ -0,0 +1,287 @@
+package common_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// payloadRecorder records the sizes of the payloads received by the server
+type payloadRecorder struct {
+	mu       sync.Mutex
+	payloads []*stats.InPayload
+}
+
+func (r *payloadRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+func (r *payloadRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if payload, ok := s.(*stats.InPayload); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.payloads = append(r.payloads, payload)
+	}
+}
+func (r *payloadRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+func (r *payloadRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func (r *payloadRecorder) lastPayload() *stats.InPayload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.payloads[len(r.payloads)-1]
+}
+
+// serveHealth serves the health service and returns its address
+func serveHealth(t *testing.T) (string, *payloadRecorder) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	recorder := &payloadRecorder{}
+	server := grpc.NewServer(grpc.StatsHandler(recorder))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), recorder
+}
+
+func checkHealth(t *testing.T, address string, options *common.GRPCClientOptions, service string) error {
+	conn, err := grpc.Dial(address, options.DialOptions()...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	return err
+}
+
+func TestGRPCClientOptionsWithDefaults(t *testing.T) {
+	var unaryCalls []string
+	defaultInterceptor := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		unaryCalls = append(unaryCalls, "default")
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	interceptor := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		unaryCalls = append(unaryCalls, "option")
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	defaults := common.GRPCClientOptions{
+		MaxRecvMsgSize:    100,
+		Timeout:           time.Second,
+		Keepalive:         &keepalive.ClientParameters{Time: time.Minute},
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{defaultInterceptor},
+	}
+
+	// The defaults are used as they are without options
+	var options *common.GRPCClientOptions
+	merged := options.WithDefaults(defaults)
+	assert.Equal(t, 100, merged.MaxRecvMsgSize)
+	assert.Equal(t, time.Second, merged.Timeout)
+
+	options = &common.GRPCClientOptions{
+		MaxRecvMsgSize:    200,
+		MaxSendMsgSize:    300,
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{interceptor},
+	}
+	merged = options.WithDefaults(defaults)
+	assert.Equal(t, 200, merged.MaxRecvMsgSize)
+	assert.Equal(t, 300, merged.MaxSendMsgSize)
+	assert.Equal(t, time.Second, merged.Timeout)
+	assert.Equal(t, defaults.Keepalive, merged.Keepalive)
+	assert.Len(t, options.UnaryInterceptors, 1)
+
+	// The interceptors of the options run after the defaults
+	address, _ := serveHealth(t)
+	assert.NoError(t, checkHealth(t, address, &merged, ""))
+	assert.Equal(t, []string{"default", "option"}, unaryCalls)
+
+	// The compression of the defaults applies unless the options enable or disable it
+	compress, noCompression := true, false
+	defaults.UseCompression = &compress
+	assert.True(t, *options.WithDefaults(defaults).UseCompression)
+	options.UseCompression = &noCompression
+	assert.False(t, *options.WithDefaults(defaults).UseCompression)
+}
+
+func TestGRPCClientOptionsMessageSizes(t *testing.T) {
+	address, _ := serveHealth(t)
+	assert.NoError(t, checkHealth(t, address, nil, ""))
+
+	// The reply of the health check doesn't fit in a single byte
+	err := checkHealth(t, address, &common.GRPCClientOptions{MaxRecvMsgSize: 1}, "")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	service := strings.Repeat("a", 1000)
+	err = checkHealth(t, address, &common.GRPCClientOptions{MaxSendMsgSize: 100}, service)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The send limit applies to the compressed requests. The service is unknown to the health server, which
+	// shows that the request was sent.
+	compress := true
+	err = checkHealth(t, address, &common.GRPCClientOptions{MaxSendMsgSize: 100, UseCompression: &compress}, service)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCClientOptionsCompression(t *testing.T) {
+	address, recorder := serveHealth(t)
+	service := strings.Repeat("a", 10000)
+
+	// The service is unknown to the health server, but the request is received all the same
+	err := checkHealth(t, address, nil, service)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, recorder.lastPayload().Length, recorder.lastPayload().CompressedLength)
+
+	compress := true
+	err = checkHealth(t, address, &common.GRPCClientOptions{UseCompression: &compress}, service)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Less(t, recorder.lastPayload().CompressedLength, recorder.lastPayload().Length/10)
+}
+
+// compressionRecorder records the messages sent with and without compression
+type compressionRecorder struct {
+	mu       sync.Mutex
+	messages []bool
+}
+
+func (r *compressionRecorder) ObserveCompression(method string, compressed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, compressed)
+}
+
+func TestGRPCClientOptionsCompressionThreshold(t *testing.T) {
+	address, recorder := serveHealth(t)
+	observer := &compressionRecorder{}
+	compress := true
+	options := &common.GRPCClientOptions{UseCompression: &compress, CompressionThreshold: 1000, CompressionObserver: observer}
+
+	// The small requests are sent uncompressed
+	err := checkHealth(t, address, options, strings.Repeat("a", 100))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, recorder.lastPayload().Length, recorder.lastPayload().CompressedLength)
+
+	err = checkHealth(t, address, options, strings.Repeat("a", 10000))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Less(t, recorder.lastPayload().CompressedLength, recorder.lastPayload().Length/10)
+
+	assert.Equal(t, []bool{false, true}, observer.messages)
+}
+
+func TestCompressionThresholdServerInterceptor(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	observer := &compressionRecorder{}
+	// The replies of the health checks are 2 bytes long
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		threshold := 100
+		if req.(*grpc_health_v1.HealthCheckRequest).GetService() == "large" {
+			threshold = 1
+		}
+		return common.CompressionThresholdServerInterceptor(threshold, observer)(ctx, req, info, handler)
+	}))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("large", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	recorder := &payloadRecorder{}
+	options := common.GRPCClientOptions{}
+	conn, err := grpc.Dial(listener.Addr().String(), append(options.DialOptions(), grpc.WithStatsHandler(recorder))...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	// The replies are compressed above the threshold even though the requests are not
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, recorder.lastPayload().Length, recorder.lastPayload().CompressedLength)
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "large"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, recorder.lastPayload().Length, recorder.lastPayload().CompressedLength)
+
+	assert.Equal(t, []bool{false, true}, observer.messages)
+}
+
+func TestGRPCClientOptionsTimeout(t *testing.T) {
+	address, _ := serveHealth(t)
+
+	var deadlines []time.Duration
+	recordDeadline := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if deadline, ok := ctx.Deadline(); ok {
+			deadlines = append(deadlines, time.Until(deadline))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	options := &common.GRPCClientOptions{
+		Timeout:           time.Minute,
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{recordDeadline},
+	}
+	conn, err := grpc.Dial(address, options.DialOptions()...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	// The timeout applies to the RPCs without a deadline
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+
+	if assert.Len(t, deadlines, 2) {
+		assert.Greater(t, deadlines[0], 50*time.Second)
+		assert.LessOrEqual(t, deadlines[1], time.Second)
+	}
+}
+
+func TestGRPCClientOptionsWindowSizes(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	conn, err := grpc.Dial(listener.Addr().String(), (&common.GRPCClientOptions{
+		InitialWindowSize:     1 << 20,
+		InitialConnWindowSize: 4 << 20,
+	}).DialOptions()...)
+	assert.NoError(t, err)
+	defer conn.Close()
+	conn.Connect()
+
+	// The client announces the window of the streams in its settings, and grows the window of the connection from
+	// the default of HTTP/2
+	serverConn, err := listener.Accept()
+	assert.NoError(t, err)
+	defer serverConn.Close()
+	assert.NoError(t, serverConn.SetDeadline(time.Now().Add(5*time.Second)))
+	preface := make([]byte, len(http2.ClientPreface))
+	_, err = io.ReadFull(serverConn, preface)
+	assert.NoError(t, err)
+	framer := http2.NewFramer(serverConn, serverConn)
+	frame, err := framer.ReadFrame()
+	assert.NoError(t, err)
+	settings, ok := frame.(*http2.SettingsFrame)
+	assert.True(t, ok)
+	window, ok := settings.Value(http2.SettingInitialWindowSize)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1<<20), window)
+	frame, err = framer.ReadFrame()
+	assert.NoError(t, err)
+	update, ok := frame.(*http2.WindowUpdateFrame)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0), update.StreamID)
+	assert.Equal(t, uint32(4<<20-common.MinWindowSize), update.Increment)
+}
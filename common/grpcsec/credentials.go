@@ -0,0 +1,169 @@
+package grpcsec
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// reloader serves the TLS configuration of the files of a config, and loads them again when they change so that
+// the next connections get the new certificate without restarting the server
+type reloader struct {
+	config *Config
+	logger common.Logger
+	// nextProtos are the ALPN protocols of the server
+	nextProtos []string
+
+	current atomic.Pointer[tls.Config]
+	// versions are the modification times and sizes of the files when they were last loaded
+	versions []fileVersion
+}
+
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func (r *reloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+	return files
+}
+
+func (r *reloader) stat() ([]fileVersion, error) {
+	versions := make([]fileVersion, 0, 3)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, fileVersion{modTime: info.ModTime(), size: info.Size()})
+	}
+	return versions, nil
+}
+
+// load reads the files and returns the configuration of the connections, along with the expiry of the certificate
+func (r *reloader) load() (*tls.Config, time.Time, error) {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse the TLS certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   r.config.MinVersion,
+		CipherSuites: r.config.CipherSuites,
+		// The configuration returned by GetConfigForClient doesn't inherit the ALPN protocols set by the server
+		NextProtos: r.nextProtos,
+	}
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to read the TLS client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, time.Time{}, errors.New("the TLS client CA file contains no PEM certificate")
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, leaf.NotAfter, nil
+}
+
+// reload loads the files again if they changed since they were last loaded. The previous certificate is still
+// served if the new files can't be loaded, e.g. while the certificate is written before its key.
+func (r *reloader) reload() {
+	versions, err := r.stat()
+	if err != nil {
+		r.logger.Error("Failed to check the TLS files for changes", "err", err)
+		return
+	}
+	if slices.EqualFunc(versions, r.versions, func(a, b fileVersion) bool {
+		return a.modTime.Equal(b.modTime) && a.size == b.size
+	}) {
+		return
+	}
+	r.versions = versions
+	config, notAfter, err := r.load()
+	if err != nil {
+		r.logger.Error("Failed to reload the TLS files, serving the previous certificate", "err", err)
+		return
+	}
+	r.current.Store(config)
+	r.logger.Info("Reloaded the TLS certificate", "certFile", r.config.CertFile, "notAfter", notAfter)
+}
+
+func (r *reloader) watch(ctx context.Context) {
+	ticker := time.NewTicker(r.config.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reload()
+		}
+	}
+}
+
+// ServerOption returns the credentials of a gRPC server serving TLS with the config, or an empty option if the
+// config is nil, in which case the server is plaintext. Unless the reload interval is 0, the files are checked for
+// changes until the context is done, and a new certificate is served to the connections made after it's loaded.
+func ServerOption(ctx context.Context, config *Config, logger common.Logger) (grpc.ServerOption, error) {
+	if config == nil {
+		return grpc.EmptyServerOption{}, nil
+	}
+	tlsConfig, err := serverTLSConfig(ctx, config, logger, []string{"h2"})
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
+
+// HTTPServerTLSConfig returns the TLS configuration of an HTTP server serving TLS with the config, e.g. an admin
+// server next to the gRPC server. The files are reloaded as with ServerOption.
+func HTTPServerTLSConfig(ctx context.Context, config *Config, logger common.Logger) (*tls.Config, error) {
+	return serverTLSConfig(ctx, config, logger, []string{"h2", "http/1.1"})
+}
+
+func serverTLSConfig(ctx context.Context, config *Config, logger common.Logger, nextProtos []string) (*tls.Config, error) {
+	r := &reloader{config: config, logger: logger, nextProtos: nextProtos}
+	versions, err := r.stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the TLS files: %w", err)
+	}
+	current, notAfter, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.versions = versions
+	r.current.Store(current)
+	logger.Info("Serving TLS", "certFile", config.CertFile, "notAfter", notAfter, "mTLS", config.ClientCAFile != "")
+	if config.ReloadInterval > 0 {
+		go r.watch(ctx)
+	}
+
+	return &tls.Config{
+		MinVersion: config.MinVersion,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current.Load(), nil
+		},
+	}, nil
+}
@@ -0,0 +1,217 @@
+package grpcsec_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCA issues the certificates of the tests
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	pemFile string
+	serial  int64
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	pemFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return &testCA{cert: cert, key: key, pemFile: pemFile, serial: 1}
+}
+
+func (ca *testCA) pool(t *testing.T) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue writes a certificate for 127.0.0.1 and its key to the files
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage, certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ca.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+}
+
+// clientCertificate returns a client certificate issued by the CA
+func (ca *testCA) clientCertificate(t *testing.T, name string) tls.Certificate {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	ca.issue(t, name, x509.ExtKeyUsageClientAuth, certFile, keyFile)
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	assert.NoError(t, err)
+	return cert
+}
+
+// serve starts a gRPC server with the health service and the option, and returns its address
+func serve(t *testing.T, option grpc.ServerOption) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	gs := grpc.NewServer(option)
+	healthcheck.RegisterHealthServer(gs)
+	go func() { _ = gs.Serve(listener) }()
+	t.Cleanup(gs.Stop)
+	return listener.Addr().String()
+}
+
+// check makes a health check to the server with the credentials
+func check(t *testing.T, address string, creds credentials.TransportCredentials) error {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+	assert.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestServerOptionPlaintext(t *testing.T) {
+	option, err := grpcsec.ServerOption(context.Background(), nil, commock.NewLogger(false))
+	assert.NoError(t, err)
+	address := serve(t, option)
+	assert.NoError(t, check(t, address, insecure.NewCredentials()))
+}
+
+func TestServerOptionMutualTLS(t *testing.T) {
+	serverCA := newTestCA(t, "server CA")
+	clientCA := newTestCA(t, "client CA")
+	untrustedCA := newTestCA(t, "untrusted CA")
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	serverCA.issue(t, "server", x509.ExtKeyUsageServerAuth, certFile, keyFile)
+
+	config, err := grpcsec.NewConfig(certFile, keyFile, clientCA.pemFile, "1.2", nil)
+	assert.NoError(t, err)
+	option, err := grpcsec.ServerOption(context.Background(), config, commock.NewLogger(false))
+	assert.NoError(t, err)
+	address := serve(t, option)
+
+	clientConfig := func(certs ...tls.Certificate) credentials.TransportCredentials {
+		return credentials.NewTLS(&tls.Config{RootCAs: serverCA.pool(t), Certificates: certs})
+	}
+	assert.NoError(t, check(t, address, clientConfig(clientCA.clientCertificate(t, "client"))))
+
+	// The clients without a certificate of the client CA are rejected
+	assert.Error(t, check(t, address, clientConfig(untrustedCA.clientCertificate(t, "untrusted"))))
+	assert.Error(t, check(t, address, clientConfig()))
+	assert.Error(t, check(t, address, insecure.NewCredentials()))
+}
+
+func TestServerOptionVersionAndCipherSuites(t *testing.T) {
+	ca := newTestCA(t, "CA")
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	ca.issue(t, "server", x509.ExtKeyUsageServerAuth, certFile, keyFile)
+	config, err := grpcsec.NewConfig(certFile, keyFile, "", "1.2", []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"})
+	assert.NoError(t, err)
+	option, err := grpcsec.ServerOption(context.Background(), config, commock.NewLogger(false))
+	assert.NoError(t, err)
+	address := serve(t, option)
+
+	handshake := func(clientConfig *tls.Config) (tls.ConnectionState, error) {
+		clientConfig.RootCAs = ca.pool(t)
+		clientConfig.NextProtos = []string{"h2"}
+		conn, err := tls.Dial("tcp", address, clientConfig)
+		if err != nil {
+			return tls.ConnectionState{}, err
+		}
+		defer conn.Close()
+		return conn.ConnectionState(), nil
+	}
+	state, err := handshake(&tls.Config{MaxVersion: tls.VersionTLS12})
+	assert.NoError(t, err)
+	assert.Equal(t, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, state.CipherSuite)
+	assert.Equal(t, "h2", state.NegotiatedProtocol)
+
+	// Neither older versions nor other cipher suites are accepted
+	_, err = handshake(&tls.Config{MaxVersion: tls.VersionTLS11})
+	assert.Error(t, err)
+	_, err = handshake(&tls.Config{MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}})
+	assert.Error(t, err)
+
+	_, err = grpcsec.ServerOption(context.Background(), &grpcsec.Config{CertFile: certFile, KeyFile: certFile}, commock.NewLogger(false))
+	assert.ErrorContains(t, err, "failed to load the TLS certificate")
+}
+
+func TestServerOptionReload(t *testing.T) {
+	ca := newTestCA(t, "CA")
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	ca.issue(t, "server-1", x509.ExtKeyUsageServerAuth, certFile, keyFile)
+	config, err := grpcsec.NewConfig(certFile, keyFile, "", "1.2", nil)
+	assert.NoError(t, err)
+	config.ReloadInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	option, err := grpcsec.ServerOption(ctx, config, commock.NewLogger(false))
+	assert.NoError(t, err)
+	address := serve(t, option)
+
+	servedName := func() string {
+		conn, err := tls.Dial("tcp", address, &tls.Config{RootCAs: ca.pool(t), NextProtos: []string{"h2"}})
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+	assert.Equal(t, "server-1", servedName())
+
+	// The previous certificate is served while the files are invalid
+	assert.NoError(t, os.WriteFile(certFile, []byte("partially written"), 0600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "server-1", servedName())
+
+	ca.issue(t, "server-2", x509.ExtKeyUsageServerAuth, certFile, keyFile)
+	assert.Eventually(t, func() bool { return servedName() == "server-2" }, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, check(t, address, credentials.NewTLS(&tls.Config{RootCAs: ca.pool(t)})))
+}
@@ -0,0 +1,168 @@
+// Package grpcsec configures the TLS of the gRPC servers of the services. The servers are plaintext unless a
+// certificate is set, so that the deployments without TLS keep working.
+package grpcsec
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/urfave/cli"
+)
+
+const (
+	CertFileFlagName       = "tls-cert-file"
+	KeyFileFlagName        = "tls-key-file"
+	ClientCAFileFlagName   = "tls-client-ca-file"
+	MinVersionFlagName     = "tls-min-version"
+	CipherSuitesFlagName   = "tls-cipher-suites"
+	ReloadIntervalFlagName = "tls-reload-interval"
+)
+
+// Config is the TLS configuration of a gRPC server
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the PEM bundle of the CAs that sign the certificates of the clients. The clients must present
+	// a certificate signed by one of them if it is set (mTLS), and don't present any otherwise.
+	ClientCAFile string
+	// MinVersion is tls.VersionTLS12 or tls.VersionTLS13
+	MinVersion uint16
+	// CipherSuites are the TLS 1.2 cipher suites the server accepts, or the Go defaults if empty.
+	// TLS 1.3 cipher suites are not configurable.
+	CipherSuites []uint16
+	// ReloadInterval is the interval at which the files are checked for changes, e.g. a renewed certificate. They
+	// are only loaded at startup if it is 0.
+	ReloadInterval time.Duration
+}
+
+// NewConfig validates the TLS settings. It returns nil if TLS is disabled, i.e. if no certificate is set.
+// TLS versions older than 1.2 and the cipher suites that Go considers insecure are rejected.
+func NewConfig(certFile, keyFile, clientCAFile, minVersion string, cipherSuites []string) (*Config, error) {
+	if certFile == "" && keyFile == "" {
+		if len(cipherSuites) > 0 {
+			return nil, errors.New("TLS cipher suites are set but TLS is disabled: the certificate and key files must be set")
+		}
+		if clientCAFile != "" {
+			return nil, errors.New("the TLS client CA file is set but TLS is disabled: the certificate and key files must be set")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both the TLS certificate and key files must be set")
+	}
+
+	config := &Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCAFile}
+	switch minVersion {
+	case "1.2", "":
+		config.MinVersion = tls.VersionTLS12
+	case "1.3":
+		config.MinVersion = tls.VersionTLS13
+	case "1.0", "1.1":
+		return nil, fmt.Errorf("insecure minimum TLS version %s: must be 1.2 or 1.3", minVersion)
+	default:
+		return nil, fmt.Errorf("invalid minimum TLS version %q: must be 1.2 or 1.3", minVersion)
+	}
+
+	if len(cipherSuites) > 0 && config.MinVersion == tls.VersionTLS13 {
+		return nil, errors.New("TLS cipher suites can't be set with a minimum TLS version of 1.3, whose cipher suites are not configurable")
+	}
+	// The TLS 1.3 cipher suites are left out, since they can't be configured
+	secure := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		if slices.Contains(suite.SupportedVersions, tls.VersionTLS12) {
+			secure[suite.Name] = suite.ID
+		}
+	}
+	insecure := make(map[string]bool)
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecure[suite.Name] = true
+	}
+	for _, name := range cipherSuites {
+		name = strings.TrimSpace(name)
+		if insecure[name] {
+			return nil, fmt.Errorf("insecure TLS cipher suite %s", name)
+		}
+		id, ok := secure[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown TLS 1.2 cipher suite %q", name)
+		}
+		config.CipherSuites = append(config.CipherSuites, id)
+	}
+	return config, nil
+}
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, CertFileFlagName),
+			Usage:  "path to the PEM certificate of the gRPC server, which serves TLS if it is set along with the key file (plaintext otherwise)",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, KeyFileFlagName),
+			Usage:  "path to the PEM private key of the certificate of the gRPC server",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_KEY_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientCAFileFlagName),
+			Usage:  "path to the PEM bundle of the CAs of the clients. If it is set, the clients must present a certificate signed by one of them (mTLS)",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_CLIENT_CA_FILE"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, MinVersionFlagName),
+			Usage:  "minimum TLS version accepted by the gRPC server, 1.2 or 1.3",
+			Value:  "1.2",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_MIN_VERSION"),
+		},
+		cli.StringSliceFlag{
+			Name:   common.PrefixFlag(flagPrefix, CipherSuitesFlagName),
+			Usage:  "TLS 1.2 cipher suites accepted by the gRPC server, e.g. TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256 (defaults to the secure suites of Go). Can't be set with a minimum version of 1.3",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_CIPHER_SUITES"),
+		},
+		cli.DurationFlag{
+			Name:   common.PrefixFlag(flagPrefix, ReloadIntervalFlagName),
+			Usage:  "interval at which the TLS files are checked for changes, so that a renewed certificate is served without a restart. 0 disables the reloading",
+			Value:  time.Minute,
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_RELOAD_INTERVAL"),
+		},
+	}
+}
+
+// ReadCLIConfig returns the TLS configuration of the flags, or nil if TLS is disabled
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) (*Config, error) {
+	config, err := NewConfig(
+		ctx.GlobalString(common.PrefixFlag(flagPrefix, CertFileFlagName)),
+		ctx.GlobalString(common.PrefixFlag(flagPrefix, KeyFileFlagName)),
+		ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientCAFileFlagName)),
+		ctx.GlobalString(common.PrefixFlag(flagPrefix, MinVersionFlagName)),
+		ctx.GlobalStringSlice(common.PrefixFlag(flagPrefix, CipherSuitesFlagName)),
+	)
+	if err != nil || config == nil {
+		return nil, err
+	}
+	config.ReloadInterval = ctx.GlobalDuration(common.PrefixFlag(flagPrefix, ReloadIntervalFlagName))
+	return config, nil
+}
+
+// ValidateCLIFlags checks that the TLS files that are set can be read and that the settings are consistent
+func ValidateCLIFlags(ctx *cli.Context, flagPrefix string) error {
+	var v validation.Violations
+	for _, name := range []string{CertFileFlagName, KeyFileFlagName, ClientCAFileFlagName} {
+		flag := common.PrefixFlag(flagPrefix, name)
+		if path := ctx.GlobalString(flag); path != "" {
+			v.Add(validation.ReadableFile(flag, path))
+		}
+	}
+	flag := common.PrefixFlag(flagPrefix, ReloadIntervalFlagName)
+	v.Add(validation.AtLeast(flag, ctx.GlobalDuration(flag), 0))
+	if _, err := ReadCLIConfig(ctx, flagPrefix); err != nil {
+		v.Add(err)
+	}
+	return v.Err()
+}
@@ -0,0 +1,94 @@
+package grpcsec_test
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestNewConfig(t *testing.T) {
+	config, err := grpcsec.NewConfig("", "", "", "1.2", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	config, err = grpcsec.NewConfig("cert.pem", "key.pem", "", "1.2", []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, config.CipherSuites)
+
+	config, err = grpcsec.NewConfig("cert.pem", "key.pem", "ca.pem", "1.3", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+	assert.Equal(t, "ca.pem", config.ClientCAFile)
+	assert.Empty(t, config.CipherSuites)
+
+	cases := []struct {
+		name         string
+		certFile     string
+		keyFile      string
+		clientCAFile string
+		minVersion   string
+		cipherSuites []string
+		err          string
+	}{
+		{name: "missing key", certFile: "cert.pem", minVersion: "1.2", err: "both the TLS certificate and key files must be set"},
+		{name: "ciphers without TLS", minVersion: "1.2", cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}, err: "TLS is disabled"},
+		{name: "client CA without TLS", clientCAFile: "ca.pem", minVersion: "1.2", err: "the TLS client CA file is set but TLS is disabled"},
+		{name: "TLS 1.1", certFile: "cert.pem", keyFile: "key.pem", minVersion: "1.1", err: "insecure minimum TLS version 1.1"},
+		{name: "unknown version", certFile: "cert.pem", keyFile: "key.pem", minVersion: "2", err: `invalid minimum TLS version "2"`},
+		{name: "ciphers with TLS 1.3", certFile: "cert.pem", keyFile: "key.pem", minVersion: "1.3", cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}, err: "can't be set with a minimum TLS version of 1.3"},
+		{name: "insecure cipher", certFile: "cert.pem", keyFile: "key.pem", minVersion: "1.2", cipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}, err: "insecure TLS cipher suite TLS_RSA_WITH_RC4_128_SHA"},
+		{name: "unknown cipher", certFile: "cert.pem", keyFile: "key.pem", minVersion: "1.2", cipherSuites: []string{"TLS_NULL"}, err: `unknown TLS 1.2 cipher suite "TLS_NULL"`},
+		{name: "TLS 1.3 cipher", certFile: "cert.pem", keyFile: "key.pem", minVersion: "1.2", cipherSuites: []string{"TLS_AES_128_GCM_SHA256"}, err: `unknown TLS 1.2 cipher suite "TLS_AES_128_GCM_SHA256"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := grpcsec.NewConfig(c.certFile, c.keyFile, c.clientCAFile, c.minVersion, c.cipherSuites)
+			assert.ErrorContains(t, err, c.err)
+		})
+	}
+}
+
+func runCLIFlags(t *testing.T, args ...string) (*grpcsec.Config, error) {
+	app := cli.NewApp()
+	app.Flags = grpcsec.CLIFlags("TEST", "test")
+	var config *grpcsec.Config
+	app.Action = func(ctx *cli.Context) error {
+		if err := grpcsec.ValidateCLIFlags(ctx, "test"); err != nil {
+			return err
+		}
+		var err error
+		config, err = grpcsec.ReadCLIConfig(ctx, "test")
+		return err
+	}
+	err := app.Run(append([]string{"test"}, args...))
+	return config, err
+}
+
+func TestCLIFlags(t *testing.T) {
+	// The servers are plaintext by default
+	config, err := runCLIFlags(t)
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, nil, 0600))
+	assert.NoError(t, os.WriteFile(keyFile, nil, 0600))
+	config, err = runCLIFlags(t, "--test.tls-cert-file", certFile, "--test.tls-key-file", keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, &grpcsec.Config{CertFile: certFile, KeyFile: keyFile, MinVersion: tls.VersionTLS12, ReloadInterval: time.Minute}, config)
+
+	_, err = runCLIFlags(t, "--test.tls-key-file", keyFile, "--test.tls-client-ca-file", filepath.Join(dir, "ca.pem"), "--test.tls-reload-interval", "-1s")
+	assert.ErrorContains(t, err, "invalid configuration (3 errors)")
+	assert.ErrorContains(t, err, "test.tls-client-ca-file: "+filepath.Join(dir, "ca.pem")+" does not exist")
+	assert.ErrorContains(t, err, "test.tls-reload-interval: -1s is less than 0s")
+	assert.ErrorContains(t, err, "both the TLS certificate and key files must be set")
+}
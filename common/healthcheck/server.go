@@ -7,7 +7,18 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
-type HealthServer struct{}
+// StatusFunc returns the current serving status of the server
+type StatusFunc func() grpc_health_v1.HealthCheckResponse_ServingStatus
+
+// ServiceStatusFunc returns the current serving status of the service of a health check, "" being the whole server
+type ServiceStatusFunc func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus
+
+type HealthServer struct {
+	// status is the serving status reported by the health checks. The server is always serving if it is nil.
+	status StatusFunc
+	// serviceStatus, if set, reports the serving status of each service in place of status
+	serviceStatus ServiceStatusFunc
+}
 
 // Watch implements grpc_health_v1.HealthServer.
 func (*HealthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
@@ -16,8 +27,14 @@ func (*HealthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.He
 
 func (s *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	// If the server is healthy, return a response with status "SERVING".
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if s.serviceStatus != nil {
+		status = s.serviceStatus(req.GetService())
+	} else if s.status != nil {
+		status = s.status()
+	}
 	return &grpc_health_v1.HealthCheckResponse{
-		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+		Status: status,
 	}, nil
 }
 
@@ -26,3 +43,15 @@ func RegisterHealthServer(server *grpc.Server) {
 	healthServer := &HealthServer{} // Initialize your health server implementation
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 }
+
+// RegisterHealthServerWithStatus registers a HealthServer that reports the serving status returned by the function,
+// e.g. NOT_SERVING while the server is draining.
+func RegisterHealthServerWithStatus(server *grpc.Server, status StatusFunc) {
+	grpc_health_v1.RegisterHealthServer(server, &HealthServer{status: status})
+}
+
+// RegisterHealthServerWithServiceStatus registers a HealthServer that reports the serving status the function returns
+// for the service of each health check, e.g. NOT_SERVING for a service that's turned off while the others still serve.
+func RegisterHealthServerWithServiceStatus(server *grpc.Server, status ServiceStatusFunc) {
+	grpc_health_v1.RegisterHealthServer(server, &HealthServer{serviceStatus: status})
+}
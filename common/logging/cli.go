@@ -6,9 +6,10 @@ import (
 )
 
 const (
-	PathFlagName      = "log.path"
-	FileLevelFlagName = "log.level-file"
-	StdLevelFlagName  = "log.level-std"
+	PathFlagName       = "log.path"
+	FileLevelFlagName  = "log.level-file"
+	StdLevelFlagName   = "log.level-std"
+	AdminTokenFlagName = "log.admin-token"
 )
 
 type Config struct {
@@ -16,6 +17,8 @@ type Config struct {
 	Prefix    string
 	FileLevel string
 	StdLevel  string
+	// AdminToken is the bearer token of the changes of the level over HTTP, see RegisterHandlers
+	AdminToken string
 }
 
 func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
@@ -38,6 +41,12 @@ func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:  "",
 			EnvVar: common.PrefixEnvVar(envPrefix, "LOG_PATH"),
 		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, AdminTokenFlagName),
+			Usage:  "Bearer token of the PUT /admin/log-level endpoint of the metrics server, which sets the level of the logs at runtime. The level can't be changed over HTTP if it is not set",
+			Value:  "",
+			EnvVar: common.PrefixEnvVar(envPrefix, "LOG_ADMIN_TOKEN"),
+		},
 	}
 }
 
@@ -54,5 +63,6 @@ func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
 	cfg.StdLevel = ctx.GlobalString(common.PrefixFlag(flagPrefix, StdLevelFlagName))
 	cfg.FileLevel = ctx.GlobalString(common.PrefixFlag(flagPrefix, FileLevelFlagName))
 	cfg.Path = ctx.GlobalString(common.PrefixFlag(flagPrefix, PathFlagName))
+	cfg.AdminToken = ctx.GlobalString(common.PrefixFlag(flagPrefix, AdminTokenFlagName))
 	return cfg
 }
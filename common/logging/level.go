@@ -0,0 +1,229 @@
+package logging
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LevelPath is the endpoint of the metrics server that returns the level of the logs on GET and sets it on PUT
+const LevelPath = "/admin/log-level"
+
+// levelChangedMsg is the message of the records of the changes of level
+const levelChangedMsg = "Log level changed"
+
+// levelChange tags the records of the changes of level, which are output whatever the level so that the changes can
+// be audited. Its type is unexported, so that no other record can have the tag and bypass the level.
+type levelChange struct{}
+
+func (levelChange) String() string {
+	return "level_change"
+}
+
+// isLevelChange tells whether the record is one of a change of level
+func isLevelChange(r *log.Record) bool {
+	for i := 1; i < len(r.Ctx); i += 2 {
+		if _, ok := r.Ctx[i].(levelChange); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cycledLevels are the levels SIGUSR1 cycles through, from the least to the most verbose
+var cycledLevels = []log.Lvl{log.LvlError, log.LvlWarn, log.LvlInfo, log.LvlDebug, log.LvlTrace}
+
+var levelNames = map[log.Lvl]string{
+	log.LvlCrit:  "crit",
+	log.LvlError: "error",
+	log.LvlWarn:  "warn",
+	log.LvlInfo:  "info",
+	log.LvlDebug: "debug",
+	log.LvlTrace: "trace",
+}
+
+func levelName(level log.Lvl) string {
+	if name, ok := levelNames[level]; ok {
+		return name
+	}
+	return fmt.Sprintf("level(%d)", int(level))
+}
+
+// ParseLevel parses the name of a level that can be set at runtime, from error to trace
+func ParseLevel(name string) (log.Lvl, error) {
+	for _, level := range cycledLevels {
+		if levelName(level) == name {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log level %q: must be one of trace, debug, info, warn, error", name)
+}
+
+// levels are the levels of the outputs of a logger, which can be changed while the service runs. The stdout and
+// file outputs start at the levels of their flags, and are both set to the new level on a change.
+type levels struct {
+	std  atomic.Int32
+	file atomic.Int32
+	// adminToken is the bearer token of the changes over HTTP, which are disabled if it's empty
+	adminToken string
+
+	mu       sync.Mutex
+	watchers []func(log.Lvl)
+}
+
+func newLevels(std, file log.Lvl, adminToken string) *levels {
+	l := &levels{adminToken: adminToken}
+	l.std.Store(int32(std))
+	l.file.Store(int32(file))
+	return l
+}
+
+// filter outputs the records to h up to the level
+func (l *levels) filter(level *atomic.Int32, h log.Handler) log.Handler {
+	return log.FuncHandler(func(r *log.Record) error {
+		if r.Lvl <= log.Lvl(level.Load()) || isLevelChange(r) {
+			return h.Log(r)
+		}
+		return nil
+	})
+}
+
+func (l *levels) get() log.Lvl {
+	return log.Lvl(l.std.Load())
+}
+
+// set changes the level and logs the change with the context of its source
+func (l *levels) set(logger common.Logger, level log.Lvl, source ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	old := l.get()
+	l.std.Store(int32(level))
+	l.file.Store(int32(level))
+	ctx := append([]any{"from", levelName(old), "to", levelName(level)}, source...)
+	logger.Warn(levelChangedMsg, append(ctx, "audit", levelChange{})...)
+	for _, watch := range l.watchers {
+		watch(level)
+	}
+}
+
+func (l *levels) watch(f func(log.Lvl)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.watchers = append(l.watchers, f)
+	f(l.get())
+}
+
+// levelsOf returns the levels of the logger, or nil if it wasn't created by GetLogger
+func levelsOf(logger common.Logger) *levels {
+	if l, ok := logger.(*Logger); ok {
+		return l.levels
+	}
+	return nil
+}
+
+// WatchLevel calls f with the level of the logs of the logger, and after every change of it, e.g. to export it to
+// a metrics backend. It does nothing if the logger wasn't created by GetLogger.
+func WatchLevel(logger common.Logger, f func(log.Lvl)) {
+	if l := levelsOf(logger); l != nil {
+		l.watch(f)
+	}
+}
+
+// CycleLevelOnSignal makes SIGUSR1 cycle the level of the logs from error to trace, one step more verbose on every
+// signal and back to error after trace
+func CycleLevelOnSignal(logger common.Logger) {
+	l := levelsOf(logger)
+	if l == nil {
+		return
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	go func() {
+		for range signals {
+			next := cycledLevels[0]
+			for i, level := range cycledLevels[:len(cycledLevels)-1] {
+				if l.get() == level {
+					next = cycledLevels[i+1]
+				}
+			}
+			l.set(logger, next, "source", "SIGUSR1")
+		}
+	}()
+}
+
+// RegisterHandlers registers the endpoint of the level of the logs on the mux of the metrics server. GET returns
+// the level. PUT sets it to the level of the body with the admin token as bearer token, and is forbidden if the
+// logger has no admin token.
+func RegisterHandlers(mux *http.ServeMux, logger common.Logger) {
+	l := levelsOf(logger)
+	if l == nil {
+		return
+	}
+	mux.HandleFunc(LevelPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintln(w, levelName(l.get()))
+		case http.MethodPut:
+			if l.adminToken == "" {
+				http.Error(w, "the log level can't be changed without an admin token", http.StatusForbidden)
+				return
+			}
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(l.adminToken)) != 1 {
+				logger.Warn("Rejected an unauthenticated change of the log level", "remoteAddr", r.RemoteAddr)
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "invalid admin token", http.StatusUnauthorized)
+				return
+			}
+			body, err := io.ReadAll(io.LimitReader(r.Body, 64))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, err := ParseLevel(strings.TrimSpace(string(body)))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			l.set(logger, level, "source", "http", "remoteAddr", r.RemoteAddr, "userAgent", r.UserAgent())
+			fmt.Fprintln(w, levelName(level))
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// NewLevelCollector returns the <namespace>_log_level gauge of the level of the logs of the logger, from 1 (error)
+// to 5 (trace). It has no value if the logger wasn't created by GetLogger.
+func NewLevelCollector(namespace string, logger common.Logger) prometheus.Collector {
+	opts := prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "log_level",
+		Help:      "the level of the logs, from 1 (error) to 5 (trace)",
+	}
+	l := levelsOf(logger)
+	if l == nil {
+		return noCollector{}
+	}
+	return prometheus.NewGaugeFunc(opts, func() float64 {
+		return float64(l.get())
+	})
+}
+
+// noCollector collects no metric
+type noCollector struct{}
+
+func (noCollector) Describe(chan<- *prometheus.Desc) {}
+func (noCollector) Collect(chan<- prometheus.Metric) {}
@@ -0,0 +1,123 @@
+package logging_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLogger returns a logger at the info level writing to a file, and the function reading the file
+func newLogger(t *testing.T, adminToken string) (common.Logger, func() string) {
+	path := filepath.Join(t.TempDir(), "service.log")
+	logger, err := logging.GetLogger(logging.Config{Path: path, FileLevel: "info", StdLevel: "info", AdminToken: adminToken})
+	assert.NoError(t, err)
+	return logger, func() string {
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		return string(content)
+	}
+}
+
+func request(t *testing.T, mux *http.ServeMux, method string, token string, body string) (int, string) {
+	r := httptest.NewRequest(method, logging.LevelPath, strings.NewReader(body))
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w.Code, w.Body.String()
+}
+
+func TestLevelEndpoint(t *testing.T) {
+	logger, readLogs := newLogger(t, "secret")
+	mux := http.NewServeMux()
+	logging.RegisterHandlers(mux, logger)
+	gauge := logging.NewLevelCollector("test", logger)
+
+	code, body := request(t, mux, http.MethodGet, "", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "info\n", body)
+	assert.Equal(t, 3.0, testutil.ToFloat64(gauge))
+
+	code, _ = request(t, mux, http.MethodPut, "", "debug")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = request(t, mux, http.MethodPut, "guess", "debug")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, body = request(t, mux, http.MethodPut, "secret", "verbose")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, `invalid log level "verbose"`)
+	code, _ = request(t, mux, http.MethodPost, "secret", "debug")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	// The derived loggers share the level of the logger
+	derived := logger.New("component", "test")
+	code, body = request(t, mux, http.MethodPut, "secret", "error\n")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "error\n", body)
+	code, body = request(t, mux, http.MethodGet, "", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "error\n", body)
+	assert.Equal(t, 1.0, testutil.ToFloat64(gauge))
+	derived.Warn("filtered out")
+	derived.Error("output")
+	// The records that only have the message of a change are filtered out as the others
+	derived.Warn("Log level changed", "from", "error", "to", "trace")
+
+	// The change is logged even though its level is now filtered out
+	logs := readLogs()
+	assert.Contains(t, logs, "Rejected an unauthenticated change of the log level")
+	assert.Contains(t, logs, `msg="Log level changed" from=info to=error source=http`)
+	assert.Contains(t, logs, "audit=level_change")
+	assert.NotContains(t, logs, "from=error to=trace")
+	assert.NotContains(t, logs, "filtered out")
+	assert.Contains(t, logs, "output")
+}
+
+func TestLevelEndpointWithoutToken(t *testing.T) {
+	logger, _ := newLogger(t, "")
+	mux := http.NewServeMux()
+	logging.RegisterHandlers(mux, logger)
+
+	code, _ := request(t, mux, http.MethodPut, "", "debug")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, body := request(t, mux, http.MethodGet, "", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "info\n", body)
+
+	// The loggers not created by GetLogger have no level to serve
+	mux = http.NewServeMux()
+	logging.RegisterHandlers(mux, &commock.Logger{})
+	code, _ = request(t, mux, http.MethodGet, "", "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestCycleLevelOnSignal(t *testing.T) {
+	logger, readLogs := newLogger(t, "")
+	levels := make(chan log.Lvl, 10)
+	logging.WatchLevel(logger, func(level log.Lvl) { levels <- level })
+	assert.Equal(t, log.LvlInfo, <-levels)
+
+	logging.CycleLevelOnSignal(logger)
+	for _, expected := range []log.Lvl{log.LvlDebug, log.LvlTrace, log.LvlError, log.LvlWarn} {
+		assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+		select {
+		case level := <-levels:
+			assert.Equal(t, expected, level)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the level wasn't changed to %v", expected)
+		}
+	}
+	assert.Contains(t, readLogs(), `msg="Log level changed" from=trace to=error source=SIGUSR1`)
+}
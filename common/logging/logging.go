@@ -10,10 +10,12 @@ import (
 
 type Logger struct {
 	log.Logger
+	// levels are shared by the loggers derived with New. They are nil if the logger wasn't created by GetLogger.
+	levels *levels
 }
 
 func (l *Logger) New(ctx ...interface{}) common.Logger {
-	return &Logger{Logger: l.Logger.New(ctx...)}
+	return &Logger{Logger: l.Logger.New(ctx...), levels: l.levels}
 }
 
 func (l *Logger) SetHandler(h log.Handler) {
@@ -31,7 +33,8 @@ func GetLogger(cfg Config) (common.Logger, error) {
 		return nil, err
 	}
 
-	logger := &Logger{Logger: log.New()}
+	levels := newLevels(stdLevel, fileLevel, cfg.AdminToken)
+	logger := &Logger{Logger: log.New(), levels: levels}
 	// This is required to print locations of log calls
 	// This was recently added in this PR: https://github.com/ethereum/go-ethereum/pull/28069/files
 	// where the default behavior was changed to not print origins
@@ -39,13 +42,14 @@ func GetLogger(cfg Config) (common.Logger, error) {
 	// We should evaluate enabling/disabling this based on the flag
 	log.PrintOrigins(true)
 	stdh := log.StreamHandler(os.Stdout, log.TerminalFormat(false))
-	stdHandler := log.CallerFileHandler(log.LvlFilterHandler(stdLevel, stdh))
+	// The levels are read on every record, so that they can be changed at runtime
+	stdHandler := log.CallerFileHandler(levels.filter(&levels.std, stdh))
 	if cfg.Path != "" {
 		fh, err := log.FileHandler(cfg.Path, log.LogfmtFormat())
 		if err != nil {
 			return nil, err
 		}
-		fileHandler := log.LvlFilterHandler(fileLevel, fh)
+		fileHandler := levels.filter(&levels.file, fh)
 		logger.SetHandler(log.MultiHandler(fileHandler, stdHandler))
 	} else {
 		logger.SetHandler(stdHandler)
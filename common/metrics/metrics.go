@@ -0,0 +1,129 @@
+// Package metrics abstracts the emission of metrics behind a Backend, exported either to Prometheus or to StatsD.
+//
+// Only the retriever routes its metrics through it so far. The node, the disperser, the churner and the data API
+// still register theirs in their own Prometheus registries, which they serve over HTTP, and are left to be migrated.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/urfave/cli"
+)
+
+const (
+	PrometheusBackendName = "prometheus"
+	StatsDBackendName     = "statsd"
+
+	BackendFlagName       = "metrics.backend"
+	StatsDAddressFlagName = "metrics.statsd-address"
+	ListenTimeoutFlagName = "metrics.listen-timeout"
+)
+
+// Opts describe a metric. The metrics of a namespace are named <namespace>_<name> with Prometheus and
+// <namespace>.<name> with StatsD, or <namespace>_<subsystem>_<name> and <namespace>.<subsystem>.<name> if they have
+// a subsystem. The empty parts are omitted.
+type Opts struct {
+	Namespace string
+	Subsystem string
+	Name      string
+	Help      string
+	// Labels are the names of the labels of the metric, whose values are passed when the metric is updated
+	Labels []string
+	// Buckets are the upper bounds of the buckets of a histogram. StatsD aggregates the histograms on the agent
+	// instead, so they are only used by Prometheus, which defaults to prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Counter is a metric that only goes up. The label values are given in the order of Opts.Labels.
+type Counter interface {
+	Inc(labelValues ...string)
+	Add(value float64, labelValues ...string)
+}
+
+// Gauge is a metric that can go up and down. The label values are given in the order of Opts.Labels.
+type Gauge interface {
+	Set(value float64, labelValues ...string)
+	Add(value float64, labelValues ...string)
+}
+
+// Histogram samples observations, e.g. latencies. The label values are given in the order of Opts.Labels.
+type Histogram interface {
+	Observe(value float64, labelValues ...string)
+}
+
+// Backend creates the metrics and exports them to a monitoring system
+type Backend interface {
+	NewCounter(opts Opts) Counter
+	NewGauge(opts Opts) Gauge
+	NewHistogram(opts Opts) Histogram
+	// Start exports the metrics in the background until the context is done, or fails if they can't be exported,
+	// e.g. as the port of the HTTP server Prometheus scrapes is in use
+	Start(ctx context.Context) error
+}
+
+type Config struct {
+	// Backend is either PrometheusBackendName or StatsDBackendName
+	Backend string
+	// HTTPPort is the port of the HTTP server that Prometheus scrapes
+	HTTPPort string
+	// ListenTimeout is how long the HTTP port of Prometheus is retried while it's in use before the backend fails to
+	// start, or 0 to fail at once
+	ListenTimeout time.Duration
+	// StatsDAddress is the address (host:port) of the StatsD agent
+	StatsDAddress string
+	// Profiling serves the pprof and expvar endpoints on the HTTP server of Prometheus. StatsD has no HTTP server,
+	// so they must be given a separate address with it.
+	Profiling profiling.Config
+}
+
+// NewBackend returns the backend selected by the config
+func NewBackend(config Config, logger common.Logger) (Backend, error) {
+	switch config.Backend {
+	case PrometheusBackendName, "":
+		backend := NewPrometheusBackend(config.HTTPPort, logger)
+		backend.EnableProfiling(config.Profiling)
+		backend.SetListenTimeout(config.ListenTimeout)
+		return backend, nil
+	case StatsDBackendName:
+		return NewStatsDBackend(config.StatsDAddress, logger)
+	default:
+		return nil, fmt.Errorf("unknown metrics backend %q: must be %q or %q", config.Backend, PrometheusBackendName, StatsDBackendName)
+	}
+}
+
+// CLIFlags returns the flags selecting the metrics backend. The HTTP port of Prometheus is left to the
+// binaries, which already have their own flag for it.
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, BackendFlagName),
+			Usage:  `The backend the metrics of the retriever are exported to. Accepted options are "prometheus", which serves them over HTTP to be scraped, and "statsd", which sends them to a StatsD or Datadog agent`,
+			Value:  PrometheusBackendName,
+			EnvVar: common.PrefixEnvVar(envPrefix, "METRICS_BACKEND"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, StatsDAddressFlagName),
+			Usage:  "The address (host:port) of the StatsD agent the metrics are sent to with the statsd backend",
+			Value:  "127.0.0.1:8125",
+			EnvVar: common.PrefixEnvVar(envPrefix, "METRICS_STATSD_ADDRESS"),
+		},
+		cli.DurationFlag{
+			Name:   common.PrefixFlag(flagPrefix, ListenTimeoutFlagName),
+			Usage:  "How long the metrics HTTP port of the prometheus backend is retried while it's in use, e.g. until a restarted process releases it, before failing to start. 0 fails at once",
+			Value:  0,
+			EnvVar: common.PrefixEnvVar(envPrefix, "METRICS_LISTEN_TIMEOUT"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		Backend:       ctx.GlobalString(common.PrefixFlag(flagPrefix, BackendFlagName)),
+		StatsDAddress: ctx.GlobalString(common.PrefixFlag(flagPrefix, StatsDAddressFlagName)),
+		ListenTimeout: ctx.GlobalDuration(common.PrefixFlag(flagPrefix, ListenTimeoutFlagName)),
+	}
+}
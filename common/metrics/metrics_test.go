@@ -0,0 +1,116 @@
+package metrics_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/metrics"
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackend(t *testing.T) {
+	logger := &mock.Logger{}
+
+	backend, err := metrics.NewBackend(metrics.Config{HTTPPort: "9100"}, logger)
+	assert.NoError(t, err)
+	assert.IsType(t, &metrics.PrometheusBackend{}, backend)
+
+	backend, err = metrics.NewBackend(metrics.Config{Backend: "statsd", StatsDAddress: "127.0.0.1:8125"}, logger)
+	assert.NoError(t, err)
+	assert.IsType(t, &metrics.StatsDBackend{}, backend)
+
+	_, err = metrics.NewBackend(metrics.Config{Backend: "graphite"}, logger)
+	assert.ErrorContains(t, err, `unknown metrics backend "graphite"`)
+}
+
+func TestPrometheusBackend(t *testing.T) {
+	backend := metrics.NewPrometheusBackend("9100", &mock.Logger{})
+
+	counter := backend.NewCounter(metrics.Opts{Namespace: "test", Name: "requests", Labels: []string{"method"}})
+	counter.Inc("get")
+	counter.Add(2, "get")
+	counter.Inc("put")
+	assert.Equal(t, 3.0, testutil.ToFloat64(counter.(*metrics.PrometheusCounter).WithLabelValues("get")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(counter.(*metrics.PrometheusCounter).WithLabelValues("put")))
+
+	gauge := backend.NewGauge(metrics.Opts{Namespace: "test", Name: "in_flight"})
+	gauge.Set(5)
+	gauge.Add(-2)
+	assert.Equal(t, 3.0, testutil.ToFloat64(gauge.(*metrics.PrometheusGauge)))
+
+	histogram := backend.NewHistogram(metrics.Opts{Namespace: "test", Name: "latency_ms", Buckets: []float64{1, 10}})
+	histogram.Observe(5)
+	count, err := testutil.GatherAndCount(backend.Registry(), "test_latency_ms")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestPrometheusBackendPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	assert.NoError(t, err)
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+	backend := metrics.NewPrometheusBackend(port, &mock.Logger{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = backend.Start(ctx)
+	assert.ErrorContains(t, err, "metrics port "+port+" is already in use")
+
+	// The port is retried until it's released
+	backend.SetListenTimeout(5 * time.Second)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = listener.Close()
+	}()
+	assert.NoError(t, backend.Start(ctx))
+	resp, err := http.Get("http://127.0.0.1:" + port + "/metrics")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+func TestStatsDBackend(t *testing.T) {
+	agent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer agent.Close()
+
+	backend, err := metrics.NewStatsDBackend(agent.LocalAddr().String(), &mock.Logger{})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, backend.Start(ctx))
+
+	counter := backend.NewCounter(metrics.Opts{Namespace: "test", Name: "requests", Labels: []string{"method", "status"}})
+	gauge := backend.NewGauge(metrics.Opts{Namespace: "test", Name: "in_flight", Labels: []string{"method"}})
+	histogram := backend.NewHistogram(metrics.Opts{Name: "latency_ms"})
+
+	counter.Inc("get", "success")
+	counter.Add(2.5, "get", "fail|ed,#")
+	gauge.Set(3, "get")
+	gauge.Add(-1, "get")
+	gauge.Add(4, "put")
+	histogram.Observe(12.5)
+
+	expected := []string{
+		"test.requests:1|c|#method:get,status:success",
+		"test.requests:2.5|c|#method:get,status:fail_ed__",
+		"test.in_flight:3|g|#method:get",
+		// Datadog has no relative gauge updates, so the new value is sent
+		"test.in_flight:2|g|#method:get",
+		"test.in_flight:4|g|#method:put",
+		"latency_ms:12.5|h",
+	}
+	buf := make([]byte, 1024)
+	for _, packet := range expected {
+		assert.NoError(t, agent.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := agent.ReadFrom(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, packet, string(buf[:n]))
+	}
+}
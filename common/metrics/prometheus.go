@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusBackend registers the metrics in a Prometheus registry, along with the Go and process metrics, and
+// serves them at /metrics
+type PrometheusBackend struct {
+	registry  *prometheus.Registry
+	httpPort  string
+	profiling profiling.Config
+	// listenTimeout is how long the port of the server is retried while it's in use
+	listenTimeout time.Duration
+	logger        common.Logger
+}
+
+// listenRetryInterval is the interval between the attempts to listen on the port of the server while it's in use
+const listenRetryInterval = 100 * time.Millisecond
+
+var _ Backend = (*PrometheusBackend)(nil)
+
+func NewPrometheusBackend(httpPort string, logger common.Logger) *PrometheusBackend {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	reg.MustRegister(collectors.NewGoCollector())
+	return &PrometheusBackend{
+		registry: reg,
+		httpPort: httpPort,
+		logger:   logger,
+	}
+}
+
+// Registry returns the registry of the metrics
+func (b *PrometheusBackend) Registry() *prometheus.Registry {
+	return b.registry
+}
+
+// EnableProfiling serves the pprof and expvar endpoints on the metrics server, per the config. It must be called
+// before Start.
+func (b *PrometheusBackend) EnableProfiling(config profiling.Config) {
+	b.profiling = config
+}
+
+// SetListenTimeout makes Start retry the port of the server while it's in use for up to the timeout, e.g. until the
+// process being replaced releases it. It must be called before Start.
+func (b *PrometheusBackend) SetListenTimeout(timeout time.Duration) {
+	b.listenTimeout = timeout
+}
+
+func (b *PrometheusBackend) NewCounter(opts Opts) Counter {
+	return &PrometheusCounter{promauto.With(b.registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Name:      opts.Name,
+			Help:      opts.Help,
+		},
+		opts.Labels,
+	)}
+}
+
+func (b *PrometheusBackend) NewGauge(opts Opts) Gauge {
+	return &PrometheusGauge{promauto.With(b.registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Name:      opts.Name,
+			Help:      opts.Help,
+		},
+		opts.Labels,
+	)}
+}
+
+func (b *PrometheusBackend) NewHistogram(opts Opts) Histogram {
+	return &PrometheusHistogram{promauto.With(b.registry).NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Name:      opts.Name,
+			Help:      opts.Help,
+			Buckets:   opts.Buckets,
+		},
+		opts.Labels,
+	)}
+}
+
+// Start listens on the port of the server, and fails if it can't, e.g. as the port is used by another process. The
+// metrics are then served in the background until the context is done.
+func (b *PrometheusBackend) Start(ctx context.Context) error {
+	b.logger.Info("Starting metrics server at ", "port", b.httpPort)
+	listener, err := b.listen(ctx)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(
+		b.registry,
+		promhttp.HandlerOpts{},
+	))
+	profiling.RegisterHandlers(mux, b.profiling)
+	logging.RegisterHandlers(mux, b.logger)
+	server := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	go func() {
+		err := server.Serve(listener)
+		if !errors.Is(err, http.ErrServerClosed) {
+			b.logger.Error("Prometheus server failed", "err", err)
+		}
+	}()
+	return nil
+}
+
+// listen listens on the port of the server, retrying while it's in use until the listen timeout
+func (b *PrometheusBackend) listen(ctx context.Context) (net.Listener, error) {
+	addr := fmt.Sprintf(":%s", b.httpPort)
+	deadline := time.Now().Add(b.listenTimeout)
+	for {
+		listener, err := net.Listen("tcp", addr)
+		if err == nil {
+			return listener, nil
+		}
+		if !errors.Is(err, syscall.EADDRINUSE) {
+			return nil, fmt.Errorf("failed to listen on the metrics port %s: %w", b.httpPort, err)
+		}
+		if !time.Now().Add(listenRetryInterval).Before(deadline) {
+			return nil, fmt.Errorf("metrics port %s is already in use by another process: %w", b.httpPort, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// PrometheusCounter, PrometheusGauge and PrometheusHistogram embed the Prometheus metrics, so that their values
+// can be read, e.g. with testutil.ToFloat64(counter.WithLabelValues(...))
+
+type PrometheusCounter struct {
+	*prometheus.CounterVec
+}
+
+func (c *PrometheusCounter) Inc(labelValues ...string) {
+	c.WithLabelValues(labelValues...).Inc()
+}
+
+func (c *PrometheusCounter) Add(value float64, labelValues ...string) {
+	c.WithLabelValues(labelValues...).Add(value)
+}
+
+type PrometheusGauge struct {
+	*prometheus.GaugeVec
+}
+
+func (g *PrometheusGauge) Set(value float64, labelValues ...string) {
+	g.WithLabelValues(labelValues...).Set(value)
+}
+
+func (g *PrometheusGauge) Add(value float64, labelValues ...string) {
+	g.WithLabelValues(labelValues...).Add(value)
+}
+
+type PrometheusHistogram struct {
+	*prometheus.HistogramVec
+}
+
+func (h *PrometheusHistogram) Observe(value float64, labelValues ...string) {
+	h.WithLabelValues(labelValues...).Observe(value)
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/common"
+)
+
+// StatsDBackend sends the metrics to a StatsD agent over UDP, one datagram per update. The labels are sent as
+// DogStatsD tags (|#label:value), which the Datadog agent and most StatsD servers understand.
+// Like any StatsD client, updates are dropped if the agent is unreachable.
+type StatsDBackend struct {
+	conn   net.Conn
+	logger common.Logger
+}
+
+var _ Backend = (*StatsDBackend)(nil)
+
+// NewStatsDBackend opens the UDP socket of the agent at address (host:port). No packet is sent yet, so the agent
+// doesn't need to be up.
+func NewStatsDBackend(address string, logger common.Logger) (*StatsDBackend, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the statsd socket: %w", err)
+	}
+	return &StatsDBackend{conn: conn, logger: logger}, nil
+}
+
+func (b *StatsDBackend) NewCounter(opts Opts) Counter {
+	return &statsdCounter{statsdMetric{backend: b, opts: opts}}
+}
+
+func (b *StatsDBackend) NewGauge(opts Opts) Gauge {
+	return &statsdGauge{statsdMetric: statsdMetric{backend: b, opts: opts}, values: make(map[string]float64)}
+}
+
+func (b *StatsDBackend) NewHistogram(opts Opts) Histogram {
+	return &statsdHistogram{statsdMetric{backend: b, opts: opts}}
+}
+
+// Start closes the socket once the context is done, as the metrics are sent as soon as they are updated
+func (b *StatsDBackend) Start(ctx context.Context) error {
+	b.logger.Info("Sending metrics to statsd", "address", b.conn.RemoteAddr())
+	go func() {
+		<-ctx.Done()
+		_ = b.conn.Close()
+	}()
+	return nil
+}
+
+func (b *StatsDBackend) send(packet string) {
+	// Write errors mean the agent is unreachable, which StatsD clients ignore
+	_, _ = b.conn.Write([]byte(packet))
+}
+
+type statsdMetric struct {
+	backend *StatsDBackend
+	opts    Opts
+}
+
+// send formats the update as <namespace>.<subsystem>.<name>:<value>|<type>|#<label>:<value>,...
+func (m *statsdMetric) send(value float64, metricType string, labelValues []string) {
+	var sb strings.Builder
+	for _, prefix := range []string{m.opts.Namespace, m.opts.Subsystem} {
+		if prefix != "" {
+			sb.WriteString(prefix)
+			sb.WriteByte('.')
+		}
+	}
+	sb.WriteString(m.opts.Name)
+	sb.WriteByte(':')
+	sb.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	sb.WriteByte('|')
+	sb.WriteString(metricType)
+	for i, label := range m.opts.Labels {
+		if i == 0 {
+			sb.WriteString("|#")
+		} else {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(label)
+		sb.WriteByte(':')
+		if i < len(labelValues) {
+			sb.WriteString(tagReplacer.Replace(labelValues[i]))
+		}
+	}
+	m.backend.send(sb.String())
+}
+
+// tagReplacer removes the characters that delimit the fields and tags of a packet from the tag values
+var tagReplacer = strings.NewReplacer("|", "_", ",", "_", "#", "_", "\n", "_")
+
+type statsdCounter struct {
+	statsdMetric
+}
+
+func (c *statsdCounter) Inc(labelValues ...string) {
+	c.send(1, "c", labelValues)
+}
+
+func (c *statsdCounter) Add(value float64, labelValues ...string) {
+	c.send(value, "c", labelValues)
+}
+
+// statsdGauge keeps the value of the gauge of each label values, since Datadog doesn't support relative gauge
+// updates and Add has to send the new value
+type statsdGauge struct {
+	statsdMetric
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func (g *statsdGauge) Set(value float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[strings.Join(labelValues, "\x00")] = value
+	g.send(value, "g", labelValues)
+}
+
+func (g *statsdGauge) Add(value float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := strings.Join(labelValues, "\x00")
+	g.values[key] += value
+	g.send(g.values[key], "g", labelValues)
+}
+
+type statsdHistogram struct {
+	statsdMetric
+}
+
+func (h *statsdHistogram) Observe(value float64, labelValues ...string) {
+	h.send(value, "h", labelValues)
+}
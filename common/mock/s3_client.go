@@ -1,13 +1,17 @@
 package mock
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"strings"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 )
 
 type S3Client struct {
+	mu     sync.Mutex
 	bucket map[string][]byte
 }
 
@@ -17,25 +21,37 @@ func NewS3Client() *S3Client {
 	return &S3Client{bucket: make(map[string][]byte)}
 }
 
-func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	data, ok := s.bucket[key]
 	if !ok {
-		return []byte{}, s3.ErrObjectNotFound
+		return nil, s3.ErrObjectNotFound
 	}
-	return data, nil
+	return io.NopCloser(bytes.NewReader(data)), nil
 }
 
-func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.bucket[key] = data
 	return nil
 }
 
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.bucket, key)
 	return nil
 }
 
 func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string) ([]s3.Object, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	objects := make([]s3.Object, 0, 5)
 	for k, v := range s.bucket {
 		if strings.HasPrefix(k, prefix) {
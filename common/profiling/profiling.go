@@ -0,0 +1,122 @@
+package profiling
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/version"
+	"github.com/urfave/cli"
+)
+
+const (
+	EnableFlagName  = "enable-pprof"
+	AddressFlagName = "pprof-address"
+)
+
+// vars are the values of the service published at /debug/vars, next to the command line and memory stats of
+// the process
+var vars = new(expvar.Map)
+
+func init() {
+	expvar.Publish("eigenda", vars)
+}
+
+type Config struct {
+	// Enabled serves the pprof profiles at /debug/pprof/ and the expvar variables at /debug/vars
+	Enabled bool
+	// Address is the address (host:port) of a separate HTTP server of the endpoints, e.g. on a private interface.
+	// If it is empty, the endpoints are served by the metrics server of the service.
+	Address string
+}
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   common.PrefixFlag(flagPrefix, EnableFlagName),
+			Usage:  "Serve the pprof profiles at /debug/pprof/ and the expvar variables at /debug/vars",
+			EnvVar: common.PrefixEnvVar(envPrefix, "ENABLE_PPROF"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, AddressFlagName),
+			Usage:  "Address (host:port) of a separate HTTP server of the pprof and expvar endpoints. If not set, they are served on the metrics port",
+			Value:  "",
+			EnvVar: common.PrefixEnvVar(envPrefix, "PPROF_ADDRESS"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		Enabled: ctx.GlobalBool(common.PrefixFlag(flagPrefix, EnableFlagName)),
+		Address: ctx.GlobalString(common.PrefixFlag(flagPrefix, AddressFlagName)),
+	}
+}
+
+// Publish publishes the value at /debug/vars under the name, e.g. the build info or key config values of the
+// service. The value is encoded to JSON on every request, and must not hold any secret.
+func Publish(name string, value any) {
+	vars.Set(name, expvar.Func(func() any { return value }))
+}
+
+// PublishBuildInfo publishes the build info of the binary, see the version package, under "build"
+func PublishBuildInfo() {
+	Publish("build", map[string]string{
+		"version":    version.Version,
+		"git_commit": version.GitCommit,
+		"git_date":   version.GitDate,
+		"build_time": version.BuildTime,
+	})
+}
+
+// RegisterHandlers registers the endpoints on the mux of the metrics server, unless they are disabled or served on
+// a separate address
+func RegisterHandlers(mux *http.ServeMux, config Config) {
+	if !config.Enabled || config.Address != "" {
+		return
+	}
+	registerHandlers(mux)
+}
+
+// Start serves the endpoints on the separate address of the config until the context is done. It doesn't do
+// anything unless the endpoints are enabled with a separate address.
+func Start(ctx context.Context, config Config, logger common.Logger) error {
+	if !config.Enabled || config.Address == "" {
+		return nil
+	}
+	listener, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	registerHandlers(mux)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		logger.Info("Serving pprof", "address", listener.Addr().String())
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("pprof server failed", "err", err)
+		}
+	}()
+	return nil
+}
+
+func registerHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+}
@@ -0,0 +1,77 @@
+package profiling_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/stretchr/testify/assert"
+)
+
+func get(t *testing.T, url string) *http.Response {
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestRegisterHandlers(t *testing.T) {
+	for _, test := range []struct {
+		config profiling.Config
+		status int
+	}{
+		{profiling.Config{Enabled: true}, http.StatusOK},
+		{profiling.Config{}, http.StatusNotFound},
+		// The endpoints are served on the separate address instead
+		{profiling.Config{Enabled: true, Address: "127.0.0.1:6060"}, http.StatusNotFound},
+	} {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {})
+		profiling.RegisterHandlers(mux, test.config)
+		server := httptest.NewServer(mux)
+
+		assert.Equal(t, http.StatusOK, get(t, server.URL+"/metrics").StatusCode)
+		for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/cmdline", "/debug/vars"} {
+			assert.Equal(t, test.status, get(t, server.URL+path).StatusCode, path)
+		}
+		server.Close()
+	}
+}
+
+func TestPublish(t *testing.T) {
+	mux := http.NewServeMux()
+	profiling.RegisterHandlers(mux, profiling.Config{Enabled: true})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	profiling.Publish("build", map[string]string{"version": "v0.5.0"})
+	resp, err := http.Get(server.URL + "/debug/vars")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	var vars struct {
+		EigenDA map[string]map[string]string `json:"eigenda"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&vars))
+	assert.Equal(t, "v0.5.0", vars.EigenDA["build"]["version"])
+}
+
+func TestStartSeparateAddress(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, profiling.Start(ctx, profiling.Config{Enabled: true, Address: address}, &mock.Logger{}))
+	assert.Equal(t, http.StatusOK, get(t, "http://"+address+"/debug/pprof/").StatusCode)
+	assert.Equal(t, http.StatusOK, get(t, "http://"+address+"/debug/vars").StatusCode)
+
+	// The address is already taken
+	assert.Error(t, profiling.Start(ctx, profiling.Config{Enabled: true, Address: address}, &mock.Logger{}))
+}
@@ -0,0 +1,247 @@
+package common_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProxy is an HTTP proxy tunneling the CONNECT requests to the backend, whatever address they are for, and
+// replying to the other requests itself
+type fakeProxy struct {
+	address string
+	backend string
+
+	mu             sync.Mutex
+	requests       []*http.Request
+	requiredHeader string
+}
+
+func startFakeProxy(t *testing.T, backend string) *fakeProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	p := &fakeProxy{address: listener.Addr().String(), backend: backend}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p
+}
+
+func (p *fakeProxy) serve(conn net.Conn) {
+	defer conn.Close()
+	request, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	p.requests = append(p.requests, request)
+	requiredHeader := p.requiredHeader
+	p.mu.Unlock()
+
+	if requiredHeader != "" && request.Header.Get("Proxy-Authorization") != requiredHeader {
+		_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+		return
+	}
+	if request.Method != http.MethodConnect {
+		body := `{"jsonrpc":"2.0","id":1,"result":"proxied"}`
+		_, _ = io.WriteString(conn, "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: "+strconv.Itoa(len(body))+"\r\n\r\n"+body)
+		return
+	}
+	pipe(conn, p.backend, "HTTP/1.1 200 Connection established\r\n\r\n")
+}
+
+func (p *fakeProxy) recorded() []*http.Request {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*http.Request{}, p.requests...)
+}
+
+// startFakeSOCKS5Proxy starts a SOCKS5 proxy without authentication tunneling the connections to the backend, and
+// records the addresses they are for
+func startFakeSOCKS5Proxy(t *testing.T, backend string) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	addresses := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				// The greeting lists the authentication methods, and the request is for a domain name or an IPv4
+				greeting := make([]byte, 2)
+				if _, err := io.ReadFull(conn, greeting); err != nil {
+					return
+				}
+				if _, err := io.ReadFull(conn, make([]byte, greeting[1])); err != nil {
+					return
+				}
+				if _, err := conn.Write([]byte{5, 0}); err != nil {
+					return
+				}
+				header := make([]byte, 4)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				var host string
+				switch header[3] {
+				case 1:
+					ip := make([]byte, 4)
+					if _, err := io.ReadFull(conn, ip); err != nil {
+						return
+					}
+					host = net.IP(ip).String()
+				case 3:
+					length := make([]byte, 1)
+					if _, err := io.ReadFull(conn, length); err != nil {
+						return
+					}
+					name := make([]byte, length[0])
+					if _, err := io.ReadFull(conn, name); err != nil {
+						return
+					}
+					host = string(name)
+				default:
+					return
+				}
+				port := make([]byte, 2)
+				if _, err := io.ReadFull(conn, port); err != nil {
+					return
+				}
+				addresses <- net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+				pipe(conn, backend, string([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}))
+			}()
+		}
+	}()
+	return listener.Addr().String(), addresses
+}
+
+// pipe connects the client to the backend once the reply is sent to the client
+func pipe(client net.Conn, backend string, reply string) {
+	conn, err := net.Dial("tcp", backend)
+	if err != nil {
+		_, _ = io.WriteString(client, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer conn.Close()
+	if _, err := io.WriteString(client, reply); err != nil {
+		return
+	}
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(conn, client)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(client, conn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+func TestProxyContextDialerHTTP(t *testing.T) {
+	address, _ := serveHealth(t)
+	proxy := startFakeProxy(t, address)
+
+	dialer, err := common.ProxyConfig{URL: "http://" + proxy.address}.ContextDialer()
+	assert.NoError(t, err)
+	// The node address doesn't resolve, so the connection only succeeds through the proxy
+	assert.NoError(t, checkHealth(t, "node.test:32001", &common.GRPCClientOptions{ContextDialer: dialer}, ""))
+
+	requests := proxy.recorded()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, http.MethodConnect, requests[0].Method)
+	assert.Equal(t, "node.test:32001", requests[0].Host)
+}
+
+func TestProxyContextDialerAuthentication(t *testing.T) {
+	address, _ := serveHealth(t)
+	proxy := startFakeProxy(t, address)
+	proxy.requiredHeader = "Basic dXNlcjpzZWNyZXQ="
+
+	dialer, err := common.ProxyConfig{URL: "http://user:secret@" + proxy.address}.ContextDialer()
+	assert.NoError(t, err)
+	conn, err := dialer(context.Background(), "node.test:32001")
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+
+	dialer, err = common.ProxyConfig{URL: "http://user:wrong@" + proxy.address}.ContextDialer()
+	assert.NoError(t, err)
+	_, err = dialer(context.Background(), "node.test:32001")
+	assert.ErrorContains(t, err, "407")
+	// The password isn't part of the error
+	assert.NotContains(t, err.Error(), "wrong")
+}
+
+func TestProxyContextDialerSOCKS5(t *testing.T) {
+	address, _ := serveHealth(t)
+	proxyAddress, addresses := startFakeSOCKS5Proxy(t, address)
+
+	dialer, err := common.ProxyConfig{URL: "socks5://" + proxyAddress}.ContextDialer()
+	assert.NoError(t, err)
+	assert.NoError(t, checkHealth(t, "node.test:32001", &common.GRPCClientOptions{ContextDialer: dialer}, ""))
+	assert.Equal(t, "node.test:32001", <-addresses)
+}
+
+func TestProxyContextDialerEnvironment(t *testing.T) {
+	address, _ := serveHealth(t)
+	proxy := startFakeProxy(t, address)
+	t.Setenv("HTTPS_PROXY", "http://"+proxy.address)
+	t.Setenv("NO_PROXY", "direct.test")
+
+	dialer, err := common.ProxyConfig{}.ContextDialer()
+	assert.NoError(t, err)
+	assert.NoError(t, checkHealth(t, "node.test:32001", &common.GRPCClientOptions{ContextDialer: dialer}, ""))
+	// The connections to localhost and to the hosts of NO_PROXY are direct
+	assert.NoError(t, checkHealth(t, address, &common.GRPCClientOptions{ContextDialer: dialer}, ""))
+	_, err = dialer(context.Background(), "direct.test:32001")
+	assert.ErrorContains(t, err, "direct.test")
+
+	requests := proxy.recorded()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "node.test:32001", requests[0].Host)
+}
+
+func TestProxyHTTPProxy(t *testing.T) {
+	proxy := startFakeProxy(t, "")
+	httpProxy, err := common.ProxyConfig{URL: "http://" + proxy.address}.HTTPProxy()
+	assert.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{Proxy: httpProxy}}
+	response, err := client.Post("http://chain.test:8545", "application/json", nil)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Contains(t, string(body), "proxied")
+
+	requests := proxy.recorded()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "http://chain.test:8545/", requests[0].RequestURI)
+}
+
+func TestProxyConfigInvalid(t *testing.T) {
+	for _, url := range []string{"ftp://proxy:21", "http://", "http://proxy:port"} {
+		_, err := common.ProxyConfig{URL: url}.ContextDialer()
+		assert.Error(t, err, url)
+	}
+}
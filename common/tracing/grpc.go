@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier carries the trace context in the gRPC metadata of the requests
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// UnaryServerInterceptor traces the requests to the server, as children of the spans of the callers if the
+// metadata of the requests carries their trace context
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(rpcAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
+		reply, err := handler(ctx, req)
+		endRPCSpan(span, err)
+		return reply, err
+	}
+}
+
+// UnaryClientInterceptor traces the requests of the client, and propagates their trace context to the server in
+// their metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(rpcAttributes(method)...),
+		)
+		defer span.End()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+		err := invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+		endRPCSpan(span, err)
+		return err
+	}
+}
+
+// rpcAttributes are the attributes of the span of the gRPC method, which is named /<service>/<method>
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/"); ok {
+		attributes = append(attributes, semconv.RPCService(service), semconv.RPCMethod(method))
+	}
+	return attributes
+}
+
+func endRPCSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
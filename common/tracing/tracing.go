@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	EndpointFlagName    = "tracing.endpoint"
+	SampleRatioFlagName = "tracing.sample-ratio"
+	InsecureFlagName    = "tracing.insecure"
+
+	// instrumentationName is the name of the tracer of the EigenDA services
+	instrumentationName = "github.com/Layr-Labs/eigenda"
+)
+
+// The attributes of the spans of the dispersal path
+const (
+	BlobKeyKey         = attribute.Key("eigenda.blob_key")
+	BlobSizeKey        = attribute.Key("eigenda.blob_size")
+	BatchIDKey         = attribute.Key("eigenda.batch_id")
+	BatchHeaderHashKey = attribute.Key("eigenda.batch_header_hash")
+	NumBlobsKey        = attribute.Key("eigenda.num_blobs")
+	BatchSizeKey       = attribute.Key("eigenda.batch_size")
+	NumChunksKey       = attribute.Key("eigenda.num_chunks")
+	ChunkLengthKey     = attribute.Key("eigenda.chunk_length")
+	QuorumIDKey        = attribute.Key("eigenda.quorum_id")
+	OperatorKey        = attribute.Key("eigenda.operator")
+)
+
+type Config struct {
+	// Endpoint is the address (host:port) of the OTLP gRPC collector the spans are exported to. Tracing is
+	// disabled if it is empty, though the trace context of the requests is still propagated.
+	Endpoint string
+	// SampleRatio is the ratio of the traces started by the service that are sampled. The traces started by the
+	// callers of the service follow the sampling decision of the callers.
+	SampleRatio float64
+	// Insecure exports the spans without TLS
+	Insecure bool
+}
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, EndpointFlagName),
+			Usage:  "The address (host:port) of the OTLP gRPC collector the traces are exported to, e.g. a Jaeger or OpenTelemetry collector. Tracing is disabled if it is not set",
+			Value:  "",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TRACING_ENDPOINT"),
+		},
+		cli.Float64Flag{
+			Name:   common.PrefixFlag(flagPrefix, SampleRatioFlagName),
+			Usage:  "The ratio between 0 and 1 of the traces started by the service that are sampled. The traces of the requests follow the sampling decision of their callers",
+			Value:  0.01,
+			EnvVar: common.PrefixEnvVar(envPrefix, "TRACING_SAMPLE_RATIO"),
+		},
+		cli.BoolFlag{
+			Name:   common.PrefixFlag(flagPrefix, InsecureFlagName),
+			Usage:  "Export the traces to the collector without TLS",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TRACING_INSECURE"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		Endpoint:    ctx.GlobalString(common.PrefixFlag(flagPrefix, EndpointFlagName)),
+		SampleRatio: ctx.GlobalFloat64(common.PrefixFlag(flagPrefix, SampleRatioFlagName)),
+		Insecure:    ctx.GlobalBool(common.PrefixFlag(flagPrefix, InsecureFlagName)),
+	}
+}
+
+// Start installs the W3C trace context propagator and, unless the endpoint of the config is empty, a tracer
+// provider exporting the spans of the service to the endpoint. It returns the function flushing the spans on
+// shutdown.
+func Start(ctx context.Context, config Config, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio must be between 0 and 1, got %v", config.SampleRatio)
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	// The exporter connects lazily, so that the service starts even if the collector is down
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+	provider := NewTracerProvider(sdktrace.NewBatchSpanProcessor(exporter), config.SampleRatio, serviceName)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider returns the tracer provider of the service, whose spans go to the processor
+func NewTracerProvider(processor sdktrace.SpanProcessor, sampleRatio float64, serviceName string) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+}
+
+// StartSpan starts a span of the service as a child of the span of the context, if any
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan ends the span, recording the error if it isn't nil
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
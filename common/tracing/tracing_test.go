@@ -0,0 +1,107 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newTestProvider(t *testing.T, sampleRatio float64) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewTracerProvider(sdktrace.NewSimpleSpanProcessor(exporter), sampleRatio, "test")
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return exporter
+}
+
+func TestStartWithoutEndpoint(t *testing.T) {
+	shutdown, err := tracing.Start(context.Background(), tracing.Config{SampleRatio: 2}, "test")
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = tracing.Start(context.Background(), tracing.Config{Endpoint: "localhost:4317", SampleRatio: 2}, "test")
+	assert.Error(t, err)
+}
+
+func TestGRPCInterceptorsPropagateTraceContext(t *testing.T) {
+	exporter := newTestProvider(t, 1)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	gs := grpc.NewServer(grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()))
+	grpc_health_v1.RegisterHealthServer(gs, health.NewServer())
+	go func() { _ = gs.Serve(listener) }()
+	defer gs.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	ctx, span := tracing.StartSpan(context.Background(), "batch", tracing.BatchIDKey.Int(7))
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	tracing.EndSpan(span, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+	byKind := make(map[trace.SpanKind]tracetest.SpanStub)
+	for _, s := range spans {
+		byKind[s.SpanKind] = s
+	}
+	root, client, server := byKind[trace.SpanKindInternal], byKind[trace.SpanKindClient], byKind[trace.SpanKindServer]
+	assert.Equal(t, "grpc.health.v1.Health/Check", server.Name)
+	assert.Contains(t, root.Attributes, tracing.BatchIDKey.Int(7))
+
+	// The spans are one trace, from the caller through the client to the server
+	assert.Equal(t, root.SpanContext.TraceID(), client.SpanContext.TraceID())
+	assert.Equal(t, root.SpanContext.TraceID(), server.SpanContext.TraceID())
+	assert.Equal(t, root.SpanContext.SpanID(), client.Parent.SpanID())
+	assert.Equal(t, client.SpanContext.SpanID(), server.Parent.SpanID())
+	assert.True(t, server.Parent.IsRemote())
+}
+
+func TestSampling(t *testing.T) {
+	exporter := newTestProvider(t, 0)
+
+	// The traces started by the service are dropped at a sample ratio of 0
+	_, span := tracing.StartSpan(context.Background(), "batch")
+	assert.False(t, span.IsRecording())
+	span.End()
+	assert.Empty(t, exporter.GetSpans())
+
+	// The traces sampled by the callers are sampled regardless of the ratio
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+	_, span = tracing.StartSpan(ctx, "encode")
+	tracing.EndSpan(span, errors.New("encoding failed"))
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, parent.TraceID(), spans[0].SpanContext.TraceID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
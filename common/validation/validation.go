@@ -0,0 +1,132 @@
+// Package validation checks the values of the flags of the services at startup, so that a misconfiguration is
+// reported with the name of the flag instead of surfacing as a failure deep in the service.
+package validation
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Violations collects the invalid values of the flags of a service, so that all of them are reported at once instead
+// of one per restart
+type Violations struct {
+	errs []error
+}
+
+// Add records err as a violation if it isn't nil. The errors joined by errors.Join are recorded one by one.
+func (v *Violations) Add(err error) {
+	if err == nil {
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			v.Add(err)
+		}
+		return
+	}
+	v.errs = append(v.errs, err)
+}
+
+// Addf records a violation for a check that has no validator of its own, such as a relationship between flags
+func (v *Violations) Addf(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf(format, args...))
+}
+
+// Err returns a ConfigError of the violations, or nil if there are none
+func (v *Violations) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ConfigError{Violations: v.errs}
+}
+
+// ConfigError is the error of a config with invalid flags, whose message lists all of them
+type ConfigError struct {
+	Violations []error
+}
+
+func (e *ConfigError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration (%d errors):", len(e.Violations))
+	for _, err := range e.Violations {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *ConfigError) Unwrap() []error {
+	return e.Violations
+}
+
+// Address checks that value is a 0x-prefixed hex address other than the zero address. A mixed-case address must have
+// a valid EIP-55 checksum, as a typo in it would otherwise go unnoticed; all-lowercase and all-uppercase addresses
+// carry no checksum and are accepted.
+func Address(flag string, value string) error {
+	hex, ok := strings.CutPrefix(value, "0x")
+	if !ok || !gethcommon.IsHexAddress(value) {
+		return fmt.Errorf("%s: %q is not a 0x-prefixed hex address of 20 bytes", flag, value)
+	}
+	address := gethcommon.HexToAddress(value)
+	if address == (gethcommon.Address{}) {
+		return fmt.Errorf("%s: is the zero address", flag)
+	}
+	if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) && value != address.Hex() {
+		return fmt.Errorf("%s: %q has an invalid checksum, the checksummed address is %s", flag, value, address.Hex())
+	}
+	return nil
+}
+
+// Port checks that value is a TCP port between 1 and 65535
+func Port(flag string, value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%s: %q is not a port between 1 and 65535", flag, value)
+	}
+	return nil
+}
+
+// Range checks that value is between min and max included. The durations are formatted as durations, e.g. 1m30s.
+func Range[T cmp.Ordered](flag string, value, min, max T) error {
+	if value < min || value > max {
+		return fmt.Errorf("%s: %v is not between %v and %v", flag, value, min, max)
+	}
+	return nil
+}
+
+// AtLeast checks that value is at least min
+func AtLeast[T cmp.Ordered](flag string, value, min T) error {
+	if value < min {
+		return fmt.Errorf("%s: %v is less than %v", flag, value, min)
+	}
+	return nil
+}
+
+// ReadableFile checks that path is a file that can be opened for reading
+func ReadableFile(flag string, path string) error {
+	if path == "" {
+		return fmt.Errorf("%s: the path is not set", flag)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s: %s does not exist", flag, path)
+		}
+		return fmt.Errorf("%s: %s is not readable: %w", flag, path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("%s: %s is not readable: %w", flag, path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s: %s is a directory", flag, path)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package validation_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddress(t *testing.T) {
+	// The lowercase and uppercase addresses have no checksum
+	assert.NoError(t, validation.Address("a", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	assert.NoError(t, validation.Address("a", "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"))
+	assert.NoError(t, validation.Address("a", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"))
+
+	assert.ErrorContains(t, validation.Address("a", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"), "a: \"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD\" has an invalid checksum, the checksummed address is 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	assert.ErrorContains(t, validation.Address("a", "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"), "is not a 0x-prefixed hex address")
+	assert.ErrorContains(t, validation.Address("a", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea"), "is not a 0x-prefixed hex address")
+	assert.ErrorContains(t, validation.Address("a", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeg"), "is not a 0x-prefixed hex address")
+	assert.ErrorContains(t, validation.Address("a", ""), "is not a 0x-prefixed hex address")
+	assert.ErrorContains(t, validation.Address("a", "0x0000000000000000000000000000000000000000"), "a: is the zero address")
+}
+
+func TestPort(t *testing.T) {
+	assert.NoError(t, validation.Port("p", "1"))
+	assert.NoError(t, validation.Port("p", "65535"))
+	for _, port := range []string{"0", "65536", "-1", "", "http"} {
+		assert.ErrorContains(t, validation.Port("p", port), "is not a port between 1 and 65535", port)
+	}
+}
+
+func TestRange(t *testing.T) {
+	assert.NoError(t, validation.Range("d", time.Second, time.Second, time.Minute))
+	assert.NoError(t, validation.Range("d", time.Minute, time.Second, time.Minute))
+	assert.EqualError(t, validation.Range("d", 0, time.Second, time.Minute), "d: 0s is not between 1s and 1m0s")
+	assert.EqualError(t, validation.Range("n", 101, 0, 100), "n: 101 is not between 0 and 100")
+
+	assert.NoError(t, validation.AtLeast("n", 1, 1))
+	assert.EqualError(t, validation.AtLeast("n", -1, 0), "n: -1 is less than 0")
+}
+
+func TestReadableFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "key.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+
+	assert.NoError(t, validation.ReadableFile("f", path))
+	assert.EqualError(t, validation.ReadableFile("f", filepath.Join(dir, "other.json")), "f: "+filepath.Join(dir, "other.json")+" does not exist")
+	assert.EqualError(t, validation.ReadableFile("f", dir), "f: "+dir+" is a directory")
+	assert.EqualError(t, validation.ReadableFile("f", ""), "f: the path is not set")
+}
+
+func TestViolations(t *testing.T) {
+	var v validation.Violations
+	v.Add(nil)
+	assert.NoError(t, v.Err())
+
+	portErr := validation.Port("p", "0")
+	v.Add(portErr)
+	v.Add(errors.Join(validation.AtLeast("n", 0, 1), nil, validation.Range("m", 2, 0, 1)))
+	v.Addf("%s: must be set", "u")
+
+	err := v.Err()
+	var configErr *validation.ConfigError
+	assert.ErrorAs(t, err, &configErr)
+	assert.Len(t, configErr.Violations, 4)
+	assert.ErrorIs(t, err, portErr)
+	assert.EqualError(t, err, `invalid configuration (4 errors):
+  - p: "0" is not a port between 1 and 65535
+  - n: 0 is less than 1
+  - m: 2 is not between 0 and 1
+  - u: must be set`)
+}
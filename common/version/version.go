@@ -0,0 +1,86 @@
+// Package version holds the build info of the binaries of EigenDA, which is injected at build time, see the
+// Makefiles and Dockerfiles:
+//
+//	go build -ldflags "-X github.com/Layr-Labs/eigenda/common/version.Version=<version> ..."
+//
+// The fields that aren't injected, e.g. in a binary built by go run, are empty.
+package version
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli"
+)
+
+var (
+	// Version is the tag the binary was built from, as described by git describe
+	Version   = ""
+	GitCommit = ""
+	GitDate   = ""
+	BuildTime = ""
+)
+
+// String formats the build info on a single line, e.g. for the version of a cli app
+func String() string {
+	parts := []string{Version}
+	if Version == "" {
+		parts[0] = "unknown"
+	}
+	for _, part := range []string{GitCommit, GitDate} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// LogFields returns the build info as the key-value pairs of a log line
+func LogFields() []any {
+	return []any{"version", Version, "gitCommit", GitCommit, "gitDate", GitDate, "buildTime", BuildTime}
+}
+
+// Print writes the build info to w, one field per line
+func Print(w io.Writer) {
+	fmt.Fprintf(w, "version:    %s\n", Version)
+	fmt.Fprintf(w, "git commit: %s\n", GitCommit)
+	fmt.Fprintf(w, "git date:   %s\n", GitDate)
+	fmt.Fprintf(w, "build time: %s\n", BuildTime)
+}
+
+// Configure makes the --version flag of the app print the build info, and adds the version command which prints it
+// too. The command doesn't run the action of the app, so none of its flags have to be set.
+func Configure(app *cli.App) {
+	app.Version = String()
+	cli.VersionPrinter = func(ctx *cli.Context) {
+		Print(ctx.App.Writer)
+	}
+	app.Commands = append(app.Commands, cli.Command{
+		Name:  "version",
+		Usage: "print the build info of the binary",
+		Action: func(ctx *cli.Context) error {
+			Print(ctx.App.Writer)
+			return nil
+		},
+	})
+}
+
+// NewCollector returns the <namespace>_build_info gauge, whose value is always 1 and whose labels are the build info,
+// so that the versions running in a deployment can be queried and joined with the other metrics
+func NewCollector(namespace string) prometheus.Collector {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "the build info of the binary, as labels of a gauge that is always 1",
+		ConstLabels: prometheus.Labels{
+			"version":    Version,
+			"git_commit": GitCommit,
+			"git_date":   GitDate,
+			"build_time": BuildTime,
+		},
+	})
+	gauge.Set(1)
+	return gauge
+}
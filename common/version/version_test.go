@@ -0,0 +1,89 @@
+package version_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/common/version"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const buildInfo = `version:    v0.5.0
+git commit: abc123
+git date:   1704164645
+build time: 2024-01-02T03:04:05Z
+`
+
+func setBuildInfo(t *testing.T) {
+	old := []string{version.Version, version.GitCommit, version.GitDate, version.BuildTime}
+	t.Cleanup(func() {
+		version.Version, version.GitCommit, version.GitDate, version.BuildTime = old[0], old[1], old[2], old[3]
+	})
+	version.Version, version.GitCommit, version.GitDate, version.BuildTime = "v0.5.0", "abc123", "1704164645", "2024-01-02T03:04:05Z"
+}
+
+// run runs an app with a required flag, as the services have, and returns what it printed
+func run(t *testing.T, args ...string) (string, bool, error) {
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Name = "test"
+	app.Writer = &out
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "test.required", Required: true},
+		configfile.CLIFlag("TEST"),
+	}
+	configfile.Enable(app)
+	version.Configure(app)
+	ran := false
+	app.Action = func(ctx *cli.Context) error {
+		ran = true
+		return nil
+	}
+	err := app.Run(append([]string{"test"}, args...))
+	return out.String(), ran, err
+}
+
+func TestString(t *testing.T) {
+	old := version.Version
+	defer func() { version.Version = old }()
+	version.Version = ""
+	assert.True(t, strings.HasPrefix(version.String(), "unknown"))
+
+	setBuildInfo(t)
+	assert.Equal(t, "v0.5.0-abc123-1704164645", version.String())
+	assert.Equal(t, []any{"version", "v0.5.0", "gitCommit", "abc123", "gitDate", "1704164645", "buildTime", "2024-01-02T03:04:05Z"}, version.LogFields())
+}
+
+func TestConfigure(t *testing.T) {
+	setBuildInfo(t)
+
+	// Neither the command nor the flag need the required flags of the service
+	out, ran, err := run(t, "version")
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.Equal(t, buildInfo, out)
+
+	out, ran, err = run(t, "--version")
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.Equal(t, buildInfo, out)
+
+	_, ran, err = run(t)
+	assert.ErrorContains(t, err, `required flags "test.required" not set`)
+	assert.False(t, ran)
+}
+
+func TestNewCollector(t *testing.T) {
+	setBuildInfo(t)
+
+	expected := `
+# HELP test_build_info the build info of the binary, as labels of a gauge that is always 1
+# TYPE test_build_info gauge
+test_build_info{build_time="2024-01-02T03:04:05Z",git_commit="abc123",git_date="1704164645",version="v0.5.0"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(version.NewCollector("test"), strings.NewReader(expected)))
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"errors"
 	"math/big"
+	"runtime"
 	"sort"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -14,6 +16,11 @@ var (
 	ErrPubKeysNotEqual     = errors.New("public keys are not equal")
 	ErrInsufficientEthSigs = errors.New("insufficient eth signatures")
 	ErrAggSigNotValid      = errors.New("aggregated signature is not valid")
+
+	// ErrSignerTimeout and ErrSignerRefused classify the errors of the SignerMessages, as the operators that did not
+	// reply in time and the ones that replied with an error. The operators of the other errors did not respond.
+	ErrSignerTimeout = errors.New("operator did not reply in time")
+	ErrSignerRefused = errors.New("operator refused to sign")
 )
 
 type SignerMessage struct {
@@ -24,7 +31,8 @@ type SignerMessage struct {
 
 // SignatureAggregation contains the results of aggregating signatures from a set of operators
 type SignatureAggregation struct {
-	// NonSigners contains the public keys of the operators that did not sign the message
+	// NonSigners contains the public keys of the operators that did not sign the message, sorted as the confirmation
+	// checks them onchain. They are the keys of the non-signers of the QuorumResults.
 	NonSigners []*G1Point
 	// QuorumAggPubKeys contains the aggregated public keys for all of the operators each quorum,
 	// Including those that did not sign
@@ -36,7 +44,7 @@ type SignatureAggregation struct {
 	// AggSignature is the aggregated signature for all of the operators that signed the message, mirroring the
 	// AggPubKey.
 	AggSignature *Signature
-	// QuorumResults contains the quorum ID and the amount signed for each quorum
+	// QuorumResults contains the quorum ID, the amount signed and the operators that did not sign for each quorum
 	QuorumResults map[QuorumID]*QuorumResult
 }
 
@@ -50,6 +58,12 @@ type SignatureAggregator interface {
 
 type StdSignatureAggregator struct {
 	Logger common.Logger
+	// NumWorkers is the number of goroutines verifying the signatures, GOMAXPROCS if it's 0
+	NumWorkers int
+	// APKs are the aggregate public keys of the quorums the signers are checked against, or nil if they are the
+	// AggKeys of the operator states. The cached keys are still checked against the AggKeys of the states that have
+	// them, which are the onchain or indexed ones.
+	APKs *APKCache
 }
 
 func NewStdSignatureAggregator(logger common.Logger) *StdSignatureAggregator {
@@ -60,6 +74,13 @@ func NewStdSignatureAggregator(logger common.Logger) *StdSignatureAggregator {
 
 var _ SignatureAggregator = (*StdSignatureAggregator)(nil)
 
+func (a *StdSignatureAggregator) numWorkers() int {
+	if a.NumWorkers > 0 {
+		return a.NumWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
 func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState, quorumIDs []QuorumID, message [32]byte, messageChan chan SignerMessage) (*SignatureAggregation, error) {
 
 	// TODO: Add logging
@@ -80,10 +101,14 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 	aggPubKeys := make([]*G2Point, len(quorumIDs))
 
 	signerMap := make(map[OperatorID]bool)
+	// reasons are the reasons of the operators that replied without a valid signature
+	reasons := make(map[OperatorID]NonSignerReason)
 
-	// Aggregate Signatures
+	// Collect the signatures, which are then verified together rather than one by one as they are received
 	numOperators := len(state.IndexedOperators)
-
+	signed := make([]SignerMessage, 0, numOperators)
+	sigs := make([]*Signature, 0, numOperators)
+	pubkeys := make([]*G2Point, 0, numOperators)
 	for numReply := 0; numReply < numOperators; numReply++ {
 		r := <-messageChan
 		operatorIDHex := hexutil.Encode(r.Operator[:])
@@ -93,6 +118,7 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 		}
 		if r.Err != nil {
 			a.Logger.Warn("[AggregateSignatures] error returned from messageChan", "operator", operatorIDHex, "socket", socket, "err", r.Err)
+			reasons[r.Operator] = classifySignerError(r.Err)
 			continue
 		}
 
@@ -101,12 +127,29 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 			a.Logger.Error("Operator not found in state", "operator", operatorIDHex, "socket", socket)
 			continue
 		}
+		if r.Signature == nil || r.Signature.G1Point == nil || r.Signature.G1Affine == nil {
+			a.Logger.Error("Signature is missing", "operator", operatorIDHex, "socket", socket)
+			reasons[r.Operator] = NonSignerInvalidSignature
+			continue
+		}
+
+		signed = append(signed, r)
+		sigs = append(sigs, r.Signature)
+		pubkeys = append(pubkeys, op.PubkeyG2)
+	}
+	valid := verifySignatures(sigs, pubkeys, message, a.numWorkers())
+
+	// Aggregate Signatures, in the order they were received
+	for i, r := range signed {
+		operatorIDHex := hexutil.Encode(r.Operator[:])
+		op := state.IndexedOperators[r.Operator]
+		socket := op.Socket
 
 		// Verify Signature
 		sig := r.Signature
-		ok := sig.Verify(op.PubkeyG2, message)
-		if !ok {
+		if !valid[i] {
 			a.Logger.Error("Signature is not valid", "operator", operatorIDHex, "socket", socket, "pubkey", hexutil.Encode(op.PubkeyG2.Serialize()))
+			reasons[r.Operator] = NonSignerInvalidSignature
 			continue
 		}
 
@@ -129,10 +172,10 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 			// Add to stake signed
 			stakeSigned[ind].Add(stakeSigned[ind], opInfo.Stake)
 
-			// Add to agg signature
+			// Add to agg signature, starting from copies of the first points as the accumulators
 			if aggSigs[ind] == nil {
-				aggSigs[ind] = &Signature{sig.Deserialize(sig.Serialize())}
-				aggPubKeys[ind] = op.PubkeyG2.Deserialize(op.PubkeyG2.Serialize())
+				aggSigs[ind] = &Signature{&G1Point{new(bn254.G1Affine).Set(sig.G1Affine)}}
+				aggPubKeys[ind] = &G2Point{new(bn254.G2Affine).Set(op.PubkeyG2.G2Affine)}
 			} else {
 				aggSigs[ind].Add(sig.G1Point)
 				aggPubKeys[ind].Add(op.PubkeyG2)
@@ -140,19 +183,28 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 		}
 	}
 
-	// Aggregrate Non signer Pubkey Id
-	nonSignerKeys := make([]*G1Point, 0)
-	nonSignerOperatorIds := make([]OperatorID, 0)
-
-	for id, op := range state.IndexedOperators {
-		_, found := signerMap[id]
-		if !found {
-			nonSignerKeys = append(nonSignerKeys, op.PubkeyG1)
-			nonSignerOperatorIds = append(nonSignerOperatorIds, id)
+	// The non-signers are the operators of the state that did not sign, from which both the non-signers of each quorum
+	// and the non-signer keys of the confirmation are derived
+	nonSigners := make(map[OperatorID]NonSignerReason)
+	for id := range state.IndexedOperators {
+		if !signerMap[id] {
+			reason, ok := reasons[id]
+			if !ok {
+				reason = NonSignerNoResponse
+			}
+			nonSigners[id] = reason
 		}
 	}
 
 	quorumAggPubKeys := make([]*G1Point, len(quorumIDs))
+	var cachedAggKeys map[QuorumID]*G1Point
+	if a.APKs != nil {
+		var err error
+		cachedAggKeys, err = a.APKs.AggKeys(state, quorumIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate the amount signed and aggregate signatures for each quorum
 	quorumResults := make(map[QuorumID]*QuorumResult)
@@ -160,21 +212,37 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 	for ind, id := range quorumIDs {
 		// Check that quorum has sufficient stake
 		percent := GetSignedPercentage(state.OperatorState, id, stakeSigned[ind])
+		quorumNonSigners := make([]*NonSigner, 0)
+		for opID, reason := range nonSigners {
+			if _, ok := state.Operators[id][opID]; ok {
+				quorumNonSigners = append(quorumNonSigners, &NonSigner{OperatorID: opID, Reason: reason})
+			}
+		}
+		sort.Slice(quorumNonSigners, func(i, j int) bool {
+			return bytes.Compare(quorumNonSigners[i].OperatorID[:], quorumNonSigners[j].OperatorID[:]) < 0
+		})
 		quorumResults[id] = &QuorumResult{
 			QuorumID:      id,
 			PercentSigned: percent,
+			NonSigners:    quorumNonSigners,
 		}
 
 		// Verify that the aggregated public key for the quorum matches the on-chain quorum aggregate public key sans non-signers of the quorum
 		quorumAggKey := state.AggKeys[id]
+		if cachedAggKeys != nil {
+			cached := cachedAggKeys[id]
+			if quorumAggKey != nil && !cached.Equal(quorumAggKey.G1Affine) {
+				a.Logger.Error("The cached aggregate public key of the quorum differs from the one of the operator state, recomputing it from the next operator state", "quorum", id, "block", state.BlockNumber)
+				a.APKs.Invalidate(id)
+				return nil, ErrPubKeysNotEqual
+			}
+			quorumAggKey = cached
+		}
 		quorumAggPubKeys[ind] = quorumAggKey
 
 		signersAggKey := quorumAggKey.Deserialize(quorumAggKey.Serialize())
-		for opInd, nsk := range nonSignerKeys {
-			ops := state.Operators[id]
-			if _, ok := ops[nonSignerOperatorIds[opInd]]; ok {
-				signersAggKey.Sub(nsk)
-			}
+		for _, nonSigner := range quorumNonSigners {
+			signersAggKey.Sub(state.IndexedOperators[nonSigner.OperatorID].PubkeyG1)
 		}
 
 		if aggPubKeys[ind] == nil {
@@ -206,6 +274,10 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 		aggPubKeys[0].Add(aggPubKeys[i])
 	}
 
+	nonSignerKeys := make([]*G1Point, 0, len(nonSigners))
+	for id := range nonSigners {
+		nonSignerKeys = append(nonSignerKeys, state.IndexedOperators[id].PubkeyG1)
+	}
 	// sort non signer keys according to how it's checked onchain
 	// ref: https://github.com/Layr-Labs/eigenlayer-contracts/blob/master/src/contracts/middleware/BLSSignatureChecker.sol#L99
 	sort.Slice(nonSignerKeys, func(i, j int) bool {
@@ -225,6 +297,18 @@ func (a *StdSignatureAggregator) AggregateSignatures(state *IndexedOperatorState
 
 }
 
+// classifySignerError returns the reason of the operator that replied with the error
+func classifySignerError(err error) NonSignerReason {
+	switch {
+	case errors.Is(err, ErrSignerTimeout):
+		return NonSignerTimeout
+	case errors.Is(err, ErrSignerRefused):
+		return NonSignerRefused
+	default:
+		return NonSignerNoResponse
+	}
+}
+
 func GetStakeThreshold(state *OperatorState, quorum QuorumID, quorumThreshold uint8) *big.Int {
 
 	// Get stake threshold
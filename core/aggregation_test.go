@@ -1,8 +1,8 @@
 package core_test
 
 import (
-	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math/big"
 	"testing"
@@ -30,33 +30,28 @@ func init() {
 	agg = core.NewStdSignatureAggregator(logger)
 }
 
-func simulateOperators(state mock.PrivateOperatorState, message [32]byte, update chan core.SignerMessage, advCount uint) {
-
-	count := 0
-
-	// Simulate the operators signing the message.
-	// In real life, the ordering will be random, but we simulate the signing in a fixed order
-	// to simulate stakes deterministically
-	for i := 0; i < len(state.PrivateOperators); i++ {
-		id := makeOperatorId(i)
-		op := state.PrivateOperators[id]
-		sig := op.KeyPair.SignMessage(message)
-		if count < len(state.IndexedOperators)-int(advCount) {
-			update <- core.SignerMessage{
-				Signature: sig,
-				Operator:  id,
-				Err:       nil,
-			}
-		} else {
-			update <- core.SignerMessage{
-				Signature: nil,
-				Operator:  id,
-				Err:       errors.New("adversary"),
-			}
-		}
+// generateState generates 10 operators of stakes 1 to 10, in the quorums 0 to 2 like the states of dat
+func generateState(t testing.TB) *mock.PrivateOperatorState {
+	members := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	state, err := mock.GenerateOperatorState(1, mock.OperatorSetSpec{
+		NumOperators: len(members),
+		Distribution: mock.CustomStakes,
+		Stakes:       []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		Quorums:      map[core.QuorumID][]int{0: members, 1: members, 2: members},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
 
-		count += 1
+// lastOperators returns the IDs of the last count operators of the state generated by generateState
+func lastOperators(count uint) []core.OperatorID {
+	ids := make([]core.OperatorID, 0, count)
+	for i := 10 - int(count); i < 10; i++ {
+		ids = append(ids, makeOperatorId(i))
 	}
+	return ids
 }
 
 func TestAggregateSignaturesStatus(t *testing.T) {
@@ -109,12 +104,9 @@ func TestAggregateSignaturesStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			state := dat.GetTotalOperatorState(context.Background(), 0)
-
-			update := make(chan core.SignerMessage)
+			state := generateState(t)
 			message := [32]byte{1, 2, 3, 4, 5, 6}
-
-			go simulateOperators(*state, message, update, tt.adversaryCount)
+			update := state.Sign(message, lastOperators(tt.adversaryCount)...)
 
 			quorumIDs := make([]core.QuorumID, len(tt.quorums))
 			for ind, quorum := range tt.quorums {
@@ -138,12 +130,9 @@ func TestAggregateSignaturesStatus(t *testing.T) {
 
 func TestSortNonsigners(t *testing.T) {
 
-	state := dat.GetTotalOperatorState(context.Background(), 0)
-
-	update := make(chan core.SignerMessage)
+	state := generateState(t)
 	message := [32]byte{1, 2, 3, 4, 5, 6}
-
-	go simulateOperators(*state, message, update, 4)
+	update := state.Sign(message, lastOperators(4)...)
 
 	quorums := []core.QuorumID{0}
 
@@ -161,3 +150,172 @@ func TestSortNonsigners(t *testing.T) {
 		assert.Equal(t, currHashInt.Cmp(prevHashInt), 1)
 	}
 }
+
+func TestAggregateSignaturesNonSigners(t *testing.T) {
+	state := generateState(t)
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+	otherMessage := [32]byte{6, 5, 4, 3, 2, 1}
+
+	// The operators 6 to 9 don't sign, each for a different reason
+	update := make(chan core.SignerMessage)
+	go func() {
+		for i := 0; i < len(state.PrivateOperators); i++ {
+			id := makeOperatorId(i)
+			keyPair := state.PrivateOperators[id].KeyPair
+			switch i {
+			case 6:
+				update <- core.SignerMessage{Operator: id, Err: errors.New("connection refused")}
+			case 7:
+				update <- core.SignerMessage{Operator: id, Err: fmt.Errorf("%w: deadline exceeded", core.ErrSignerTimeout)}
+			case 8:
+				update <- core.SignerMessage{Operator: id, Signature: keyPair.SignMessage(otherMessage)}
+			case 9:
+				update <- core.SignerMessage{Operator: id, Err: fmt.Errorf("%w: invalid batch", core.ErrSignerRefused)}
+			default:
+				update <- core.SignerMessage{Operator: id, Signature: keyPair.SignMessage(message)}
+			}
+		}
+	}()
+
+	sigAgg, err := agg.AggregateSignatures(state.IndexedOperatorState, []core.QuorumID{0, 1}, message, update)
+	assert.NoError(t, err)
+
+	expected := []*core.NonSigner{
+		{OperatorID: makeOperatorId(6), Reason: core.NonSignerNoResponse},
+		{OperatorID: makeOperatorId(7), Reason: core.NonSignerTimeout},
+		{OperatorID: makeOperatorId(8), Reason: core.NonSignerInvalidSignature},
+		{OperatorID: makeOperatorId(9), Reason: core.NonSignerRefused},
+	}
+	assert.Equal(t, expected, sigAgg.QuorumResults[0].NonSigners)
+	assert.Equal(t, expected, sigAgg.QuorumResults[1].NonSigners)
+
+	// The non-signer keys of the confirmation are the ones of the same operators
+	assert.Len(t, sigAgg.NonSigners, len(expected))
+	for _, nonSigner := range expected {
+		key := state.PrivateOperators[nonSigner.OperatorID].KeyPair.GetPubKeyG1()
+		assert.Contains(t, sigAgg.NonSigners, key)
+	}
+}
+
+func TestAggregateSignaturesBatchVerification(t *testing.T) {
+	state := generateState(t)
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+	otherMessage := [32]byte{6, 5, 4, 3, 2, 1}
+	signAll := func(invalid ...int) chan core.SignerMessage {
+		update := make(chan core.SignerMessage, len(state.PrivateOperators))
+		for i := 0; i < len(state.PrivateOperators); i++ {
+			id := makeOperatorId(i)
+			signed := message
+			for _, j := range invalid {
+				if i == j {
+					signed = otherMessage
+				}
+			}
+			update <- core.SignerMessage{Operator: id, Signature: state.PrivateOperators[id].KeyPair.SignMessage(signed)}
+		}
+		return update
+	}
+
+	// The invalid signatures of a batch are found by checking them one by one, whether the batch holds them all or
+	// they are split across the batches of several workers
+	for _, numWorkers := range []int{1, 2, 8} {
+		aggregator := &core.StdSignatureAggregator{Logger: &commonmock.Logger{}, NumWorkers: numWorkers}
+		sigAgg, err := aggregator.AggregateSignatures(state.IndexedOperatorState, []core.QuorumID{0}, message, signAll(2, 5))
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.NonSigner{
+			{OperatorID: makeOperatorId(2), Reason: core.NonSignerInvalidSignature},
+			{OperatorID: makeOperatorId(5), Reason: core.NonSignerInvalidSignature},
+		}, sigAgg.QuorumResults[0].NonSigners)
+	}
+
+	// The aggregation is the same whatever the number of workers
+	serial, err := (&core.StdSignatureAggregator{Logger: &commonmock.Logger{}, NumWorkers: 1}).AggregateSignatures(state.IndexedOperatorState, []core.QuorumID{0, 1}, message, signAll(3))
+	assert.NoError(t, err)
+	parallel, err := (&core.StdSignatureAggregator{Logger: &commonmock.Logger{}, NumWorkers: 8}).AggregateSignatures(state.IndexedOperatorState, []core.QuorumID{0, 1}, message, signAll(3))
+	assert.NoError(t, err)
+	assert.Equal(t, serial.AggSignature.Serialize(), parallel.AggSignature.Serialize())
+	assert.Equal(t, serial.AggPubKey.Serialize(), parallel.AggPubKey.Serialize())
+	assert.Equal(t, serial.NonSigners, parallel.NonSigners)
+	assert.Equal(t, serial.QuorumResults, parallel.QuorumResults)
+}
+
+func TestGenerateOperatorState(t *testing.T) {
+	spec := mock.OperatorSetSpec{
+		NumOperators: 20,
+		Distribution: mock.ParetoStakes,
+		Quorums: map[core.QuorumID][]int{
+			0: {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+			1: {5, 10, 15},
+		},
+		BlockNumber: 7,
+	}
+	state, err := mock.GenerateOperatorState(42, spec)
+	assert.NoError(t, err)
+
+	// The same seed generates the same operators, keys included
+	same, err := mock.GenerateOperatorState(42, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, state.OperatorState, same.OperatorState)
+	assert.Equal(t, state.IndexedOperators, same.IndexedOperators)
+	other, err := mock.GenerateOperatorState(43, spec)
+	assert.NoError(t, err)
+	assert.NotEqual(t, state.IndexedOperators, other.IndexedOperators)
+
+	assert.Len(t, state.Operators[0], 20)
+	assert.Len(t, state.Operators[1], 3)
+	assert.Equal(t, core.OperatorIndex(1), state.Operators[1][mock.GeneratedOperatorID(10)].Index)
+	assert.Equal(t, core.OperatorIndex(3), state.Totals[1].Index)
+	assert.Equal(t, uint(7), state.BlockNumber)
+
+	// The signatures of the batch header verify against the keys of the quorums
+	header := &core.BatchHeader{ReferenceBlockNumber: 7, BatchRoot: [32]byte{1}}
+	hash, update, err := state.SignBatchHeader(header, mock.GeneratedOperatorID(10))
+	assert.NoError(t, err)
+	sigAgg, err := agg.AggregateSignatures(state.IndexedOperatorState, []core.QuorumID{0, 1}, hash, update)
+	assert.NoError(t, err)
+	assert.True(t, sigAgg.AggSignature.Verify(sigAgg.AggPubKey, hash))
+	assert.Equal(t, []*core.NonSigner{{OperatorID: mock.GeneratedOperatorID(10), Reason: core.NonSignerNoResponse}}, sigAgg.QuorumResults[1].NonSigners)
+
+	_, err = mock.GenerateOperatorState(42, mock.OperatorSetSpec{NumOperators: 2, Distribution: mock.CustomStakes, Stakes: []int64{1}})
+	assert.ErrorContains(t, err, "got 1 custom stakes for 2 operators")
+	_, err = mock.GenerateOperatorState(42, mock.OperatorSetSpec{NumOperators: 2, Quorums: map[core.QuorumID][]int{0: {2}}})
+	assert.ErrorContains(t, err, "operator 2 of quorum 0 is out of the 2 operators")
+}
+
+func BenchmarkAggregateSignatures(b *testing.B) {
+	const numOperators = 400
+	state, err := mock.GenerateOperatorState(1, mock.OperatorSetSpec{NumOperators: numOperators, Distribution: mock.ParetoStakes})
+	if err != nil {
+		b.Fatal(err)
+	}
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+	replies := make([]core.SignerMessage, 0, numOperators)
+	for id, op := range state.PrivateOperators {
+		replies = append(replies, core.SignerMessage{Operator: id, Signature: op.KeyPair.SignMessage(message)})
+	}
+
+	// The signatures verified one by one, as the aggregation did before verifying them in batches
+	b.Run("individual", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, r := range replies {
+				if !r.Signature.Verify(state.IndexedOperators[r.Operator].PubkeyG2, message) {
+					b.Fatal("invalid signature")
+				}
+			}
+		}
+	})
+	for _, numWorkers := range []int{1, 0} {
+		aggregator := &core.StdSignatureAggregator{Logger: &commonmock.Logger{}, NumWorkers: numWorkers}
+		b.Run(fmt.Sprintf("workers=%d", numWorkers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				update := make(chan core.SignerMessage, len(replies))
+				for _, r := range replies {
+					update <- r
+				}
+				if _, err := aggregator.AggregateSignatures(state.IndexedOperatorState, []core.QuorumID{0}, message, update); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
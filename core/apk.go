@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// APKReader reads the aggregate public keys of the quorums recorded onchain, see Transactor
+type APKReader interface {
+	GetQuorumApk(ctx context.Context, quorumID QuorumID, blockNumber uint32) (*G1Point, error)
+}
+
+// quorumAPK is the aggregate public key of the operators of a quorum at a block
+type quorumAPK struct {
+	// digest is the digest of the operator set of the quorum, see OperatorState.Digests
+	digest      [32]byte
+	blockNumber uint
+	apk         *G1Point
+	pubkeys     map[OperatorID]*G1Point
+}
+
+// APKCache maintains the aggregate public key of each quorum, so that it isn't summed over all the operators of the
+// quorum on every use. The key of a quorum is computed in full from the first operator state of the quorum, and then
+// updated with the keys of the operators that registered and deregistered since, as the operator sets change rarely.
+type APKCache struct {
+	logger common.Logger
+
+	mu      sync.Mutex
+	quorums map[QuorumID]*quorumAPK
+}
+
+// NewAPKCache creates an empty cache of the aggregate public keys of the quorums
+func NewAPKCache(logger common.Logger) *APKCache {
+	return &APKCache{
+		logger:  logger,
+		quorums: make(map[QuorumID]*quorumAPK),
+	}
+}
+
+// AggKey returns the aggregate public key of the operators of the quorum in the state, which the caller may modify
+func (c *APKCache) AggKey(state *IndexedOperatorState, quorumID QuorumID) (*G1Point, error) {
+	keys, err := c.AggKeys(state, []QuorumID{quorumID})
+	if err != nil {
+		return nil, err
+	}
+	return keys[quorumID], nil
+}
+
+// AggKeys returns the aggregate public keys of the operators of the quorums in the state, which the caller may
+// modify. The digests of the operator sets are computed once for all the quorums.
+func (c *APKCache) AggKeys(state *IndexedOperatorState, quorumIDs []QuorumID) (map[QuorumID]*G1Point, error) {
+	digests := state.Digests()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make(map[QuorumID]*G1Point, len(quorumIDs))
+	for _, quorumID := range quorumIDs {
+		key, err := c.aggKey(state, quorumID, digests)
+		if err != nil {
+			return nil, err
+		}
+		keys[quorumID] = key
+	}
+	return keys, nil
+}
+
+// Invalidate drops the cached key of the quorum, so that it's computed in full from the next state
+func (c *APKCache) Invalidate(quorumID QuorumID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.quorums, quorumID)
+}
+
+func (c *APKCache) aggKey(state *IndexedOperatorState, quorumID QuorumID, digests OperatorStateDigests) (*G1Point, error) {
+	operators, ok := state.Operators[quorumID]
+	if !ok {
+		return nil, fmt.Errorf("quorum %d not found in the operator state", quorumID)
+	}
+	digest := digests[quorumID]
+
+	cached := c.quorums[quorumID]
+	if cached != nil && cached.digest == digest {
+		return copyG1(cached.apk), nil
+	}
+
+	updated := &quorumAPK{
+		digest:      digest,
+		blockNumber: state.BlockNumber,
+		apk:         &G1Point{new(bn254.G1Affine)},
+		pubkeys:     make(map[OperatorID]*G1Point, len(operators)),
+	}
+	if cached != nil {
+		// The keys of the operators that deregistered are subtracted, and those of the ones that registered added
+		updated.apk = copyG1(cached.apk)
+		for id, pubkey := range cached.pubkeys {
+			if _, ok := operators[id]; ok {
+				updated.pubkeys[id] = pubkey
+			} else {
+				updated.apk.Sub(pubkey)
+			}
+		}
+	}
+	for id := range operators {
+		if _, ok := updated.pubkeys[id]; ok {
+			continue
+		}
+		info, ok := state.IndexedOperators[id]
+		if !ok || info.PubkeyG1 == nil {
+			return nil, fmt.Errorf("public key of operator %x of quorum %d not found", id, quorumID)
+		}
+		updated.apk.Add(info.PubkeyG1)
+		updated.pubkeys[id] = info.PubkeyG1
+	}
+	c.quorums[quorumID] = updated
+	return copyG1(updated.apk), nil
+}
+
+// StartDriftCheck checks the cached keys against the keys recorded onchain at every interval, until the context is
+// done. A key that differs from the onchain one is dropped, so that it's computed in full from the next state.
+func (c *APKCache) StartDriftCheck(ctx context.Context, interval time.Duration, reader APKReader) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.checkDrift(ctx, reader)
+			}
+		}
+	}()
+}
+
+func (c *APKCache) checkDrift(ctx context.Context, reader APKReader) {
+	c.mu.Lock()
+	cached := make(map[QuorumID]*quorumAPK, len(c.quorums))
+	for quorumID, entry := range c.quorums {
+		cached[quorumID] = entry
+	}
+	c.mu.Unlock()
+
+	for quorumID, entry := range cached {
+		onchain, err := reader.GetQuorumApk(ctx, quorumID, uint32(entry.blockNumber))
+		if err != nil {
+			c.logger.Warn("Failed to read the aggregate public key of the quorum to check the cached one", "quorum", quorumID, "block", entry.blockNumber, "err", err)
+			continue
+		}
+		if onchain.Equal(entry.apk.G1Affine) {
+			continue
+		}
+		c.logger.Error("The cached aggregate public key of the quorum differs from the onchain one, recomputing it from the next operator state",
+			"quorum", quorumID, "block", entry.blockNumber, "cached", hexutil.Encode(entry.apk.Serialize()), "onchain", hexutil.Encode(onchain.Serialize()))
+		c.mu.Lock()
+		if c.quorums[quorumID] == entry {
+			delete(c.quorums, quorumID)
+		}
+		c.mu.Unlock()
+	}
+}
+
+func copyG1(p *G1Point) *G1Point {
+	return &G1Point{new(bn254.G1Affine).Set(p.G1Affine)}
+}
@@ -0,0 +1,115 @@
+package core_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+// withoutOperator returns a copy of the state where the operator left the quorum
+func withoutOperator(state *core.IndexedOperatorState, quorumID core.QuorumID, id core.OperatorID) *core.IndexedOperatorState {
+	operators := make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo, len(state.Operators))
+	for q, ops := range state.Operators {
+		operators[q] = make(map[core.OperatorID]*core.OperatorInfo, len(ops))
+		for opID, info := range ops {
+			if q != quorumID || opID != id {
+				operators[q][opID] = info
+			}
+		}
+	}
+	operatorState := *state.OperatorState
+	operatorState.Operators = operators
+	return &core.IndexedOperatorState{
+		OperatorState:    &operatorState,
+		IndexedOperators: state.IndexedOperators,
+		AggKeys:          state.AggKeys,
+	}
+}
+
+func TestAPKCache(t *testing.T) {
+	state := dat.GetTotalOperatorState(context.Background(), 0).IndexedOperatorState
+	cache := core.NewAPKCache(&commonmock.Logger{})
+
+	apk, err := cache.AggKey(state, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, state.AggKeys[0].Serialize(), apk.Serialize())
+
+	// The key of an operator that leaves the quorum is subtracted, and added back once it registers again
+	left := withoutOperator(state, 0, makeOperatorId(3))
+	apk, err = cache.AggKey(left, 0)
+	assert.NoError(t, err)
+	expected, err := core.NewAPKCache(&commonmock.Logger{}).AggKey(left, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Serialize(), apk.Serialize())
+	assert.NotEqual(t, state.AggKeys[0].Serialize(), apk.Serialize())
+
+	apk, err = cache.AggKey(state, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, state.AggKeys[0].Serialize(), apk.Serialize())
+
+	// The returned keys are copies
+	apk.Add(apk)
+	apk, err = cache.AggKey(state, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, state.AggKeys[0].Serialize(), apk.Serialize())
+
+	_, err = cache.AggKey(state, 9)
+	assert.ErrorContains(t, err, "quorum 9 not found")
+
+	// The aggregator checks the signers against the cached keys
+	privateState := dat.GetTotalOperatorState(context.Background(), 0)
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+	update := privateState.Sign(message)
+	aggregator := &core.StdSignatureAggregator{Logger: &commonmock.Logger{}, APKs: cache}
+	sigAgg, err := aggregator.AggregateSignatures(privateState.IndexedOperatorState, []core.QuorumID{0}, message, update)
+	assert.NoError(t, err)
+	assert.Equal(t, state.AggKeys[0].Serialize(), sigAgg.QuorumAggPubKeys[0].Serialize())
+
+	// A cached key that differs from the one of the state fails the aggregation, and is computed again
+	drifted := withoutOperator(state, 0, makeOperatorId(3))
+	drifted.AggKeys = state.AggKeys
+	update = privateState.Sign(message)
+	_, err = aggregator.AggregateSignatures(drifted, []core.QuorumID{0}, message, update)
+	assert.ErrorIs(t, err, core.ErrPubKeysNotEqual)
+	keys, err := cache.AggKeys(state, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Equal(t, state.AggKeys[0].Serialize(), keys[0].Serialize())
+}
+
+func TestAPKCacheDrift(t *testing.T) {
+	state := dat.GetTotalOperatorState(context.Background(), 0).IndexedOperatorState
+	cache := core.NewAPKCache(&commonmock.Logger{})
+	_, err := cache.AggKey(state, 0)
+	assert.NoError(t, err)
+
+	// The cached key is used without the keys of the operators while it matches the onchain one
+	withoutKeys := &core.IndexedOperatorState{
+		OperatorState:    state.OperatorState,
+		IndexedOperators: map[core.OperatorID]*core.IndexedOperatorInfo{},
+	}
+	reader := &mock.MockTransactor{}
+	reader.On("GetQuorumApk").Return(state.AggKeys[0], nil).Once()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cache.StartDriftCheck(ctx, 50*time.Millisecond, reader)
+	time.Sleep(75 * time.Millisecond)
+	_, err = cache.AggKey(withoutKeys, 0)
+	assert.NoError(t, err)
+
+	// Once the onchain key differs, the key is computed again from those of the operators
+	other := &core.G1Point{G1Affine: new(bn254.G1Affine).ScalarMultiplication(state.AggKeys[0].G1Affine, big.NewInt(2))}
+	reader.On("GetQuorumApk").Return(other, nil)
+	time.Sleep(75 * time.Millisecond)
+	_, err = cache.AggKey(withoutKeys, 0)
+	assert.ErrorContains(t, err, "public key of operator")
+	apk, err := cache.AggKey(state, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, state.AggKeys[0].Serialize(), apk.Serialize())
+}
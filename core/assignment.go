@@ -1,8 +1,11 @@
 package core
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
+	"sort"
 )
 
 // Assignment
@@ -59,9 +62,40 @@ const PercentMultiplier = 100
 
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrInsufficientChunks is returned for the assignments under which the stake of the quorum threshold above the
+	// adversary threshold may hold too few chunks to reconstruct a blob, see CheckReconstruction
+	ErrInsufficientChunks = errors.New("the operators holding the threshold stake are assigned too few chunks to reconstruct the blob")
 )
 
+// ChunkCaps caps the number of chunks assigned to the operators of the quorums, so that the largest operators
+// aren't assigned a bandwidth out of proportion with their rewards. The chunks above the cap of a quorum spill over
+// to the other operators of the quorum, see capChunks. As the batcher, the nodes and the retrievers must agree on
+// the assignments, the caps only apply to the operator states from the reference block ActivationBlock on, which is
+// set once all the nodes run with the same caps.
+//
+// Note that the capped operators hold fewer chunks than their share of the stake, and the others more, so the caps
+// must be high enough for the thresholds of the quorums to still hold. The batcher fails the blobs whose thresholds
+// they break, see CheckReconstruction.
+type ChunkCaps struct {
+	// MaxChunksPerOperator is the maximum number of chunks assigned to an operator of each quorum. The quorums it
+	// doesn't list aren't capped.
+	MaxChunksPerOperator map[QuorumID]ChunkNumber
+	// ActivationBlock is the first reference block the caps apply at. The caps are disabled if it's 0.
+	ActivationBlock uint
+}
+
+// maxChunks returns the cap of the chunks of the operators of the quorum at the operator state, and false if they
+// aren't capped
+func (c ChunkCaps) maxChunks(state *OperatorState, quorum QuorumID) (ChunkNumber, bool) {
+	if c.ActivationBlock == 0 || state.BlockNumber < c.ActivationBlock {
+		return 0, false
+	}
+	maxChunks, ok := c.MaxChunksPerOperator[quorum]
+	return maxChunks, ok && maxChunks > 0
+}
+
 type StdAssignmentCoordinator struct {
+	Caps ChunkCaps
 }
 
 var _ AssignmentCoordinator = (*StdAssignmentCoordinator)(nil)
@@ -93,6 +127,10 @@ func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, quorum Q
 
 	headerHash := [32]byte{}
 
+	if maxChunks, ok := c.Caps.maxChunks(state, quorum); ok {
+		capChunks(chunksByOperator, maxChunks, headerHash)
+	}
+
 	for orderedInd := range chunksByOperator {
 
 		// Find the operator that should be at index currentIndex
@@ -119,6 +157,82 @@ func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, quorum Q
 
 }
 
+// capChunks caps the chunks of the operators, by operator index, to maxChunks. The chunks above the cap spill over
+// one at a time to the operators below it, in the order of their assignments and in rounds until none is left, so
+// that the total number of chunks doesn't change. The cap is raised to the even split of the chunks over the
+// operators if they don't all fit under it.
+func capChunks(chunksByOperator []uint, maxChunks uint, headerHash [32]byte) {
+	numOperators := len(chunksByOperator)
+	if numOperators == 0 {
+		return
+	}
+	total := uint(0)
+	for _, m := range chunksByOperator {
+		total += m
+	}
+	maxChunks = max(maxChunks, roundUpDivide(total, uint(numOperators)))
+
+	spill := uint(0)
+	for i, m := range chunksByOperator {
+		if m > maxChunks {
+			spill += m - maxChunks
+			chunksByOperator[i] = maxChunks
+		}
+	}
+	for spill > 0 {
+		for orderedInd := 0; orderedInd < numOperators && spill > 0; orderedInd++ {
+			operatorInd := getOperatorAtIndex(headerHash, orderedInd, numOperators)
+			if chunksByOperator[operatorInd] < maxChunks {
+				chunksByOperator[operatorInd]++
+				spill--
+			}
+		}
+	}
+}
+
+// CheckReconstruction checks that the operators holding the quorum threshold of the stake above the adversary
+// threshold are assigned the chunks the blob is reconstructed from, which the thresholds of the blob rely on. This
+// holds for the assignments proportional to the stakes, while the caps take chunks from the operators with the most
+// stake. It's checked for the operators with the most stake that hold the threshold stake together, which are the
+// fewest operators and the most capped. It returns ErrInsufficientChunks if they hold fewer chunks than needed.
+func CheckReconstruction(state *OperatorState, quorum QuorumID, assignments map[OperatorID]Assignment, quantizationFactor uint, quorumThreshold, adversaryThreshold uint8) error {
+	if adversaryThreshold >= quorumThreshold {
+		return errors.New("invalid header: quorum threshold does not exceed adversary threshold")
+	}
+	operators := state.Operators[quorum]
+	totals, ok := state.Totals[quorum]
+	if !ok || len(operators) == 0 {
+		return nil
+	}
+	numSys := roundUpDivide(uint(quorumThreshold-adversaryThreshold)*uint(len(operators))*quantizationFactor, PercentMultiplier)
+
+	ids := make([]OperatorID, 0, len(operators))
+	for id := range operators {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if c := (*big.Int)(operators[ids[i]].Stake).Cmp(operators[ids[j]].Stake); c != 0 {
+			return c > 0
+		}
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	// The stake is compared in percents of the total stake
+	thresholdStake := new(big.Int).Mul(totals.Stake, big.NewInt(int64(quorumThreshold-adversaryThreshold)))
+	stake := new(big.Int)
+	numChunks := uint(0)
+	for _, id := range ids {
+		if new(big.Int).Mul(stake, big.NewInt(PercentMultiplier)).Cmp(thresholdStake) >= 0 {
+			break
+		}
+		stake.Add(stake, operators[id].Stake)
+		numChunks += assignments[id].NumChunks
+	}
+	if numChunks < numSys {
+		return fmt.Errorf("%w: %d chunks of the %d needed in quorum %d", ErrInsufficientChunks, numChunks, numSys, quorum)
+	}
+	return nil
+}
+
 // getOperatorAtIndex returns the operator at a given index within the reordered sequence.
 // We reorder the sequence by letting the reordered_index = operator_index + headerHash.
 // Thus, get get the operator at a given reordered_index, we simply reverse:
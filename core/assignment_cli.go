@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	MaxChunksPerOperatorFlagName     = "max-chunks-per-operator"
+	MaxChunksActivationBlockFlagName = "max-chunks-activation-block"
+)
+
+// ChunkCapsCLIFlags are the flags of the caps of the chunks assigned to the operators, which must be the same for the
+// batcher, the nodes and the retrievers
+func ChunkCapsCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringSliceFlag{
+			Name:     common.PrefixFlag(flagPrefix, MaxChunksPerOperatorFlagName),
+			Usage:    "maximum numbers of chunks assigned to an operator of the quorums, as quorum:chunks pairs such as 0:64, the chunks above the cap being assigned to the other operators of the quorum. The batcher, the nodes and the retrievers must all have the same caps, which only apply from max-chunks-activation-block on. The batcher fails the blobs whose thresholds the caps break",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_CHUNKS_PER_OPERATOR"),
+		},
+		cli.UintFlag{
+			Name:     common.PrefixFlag(flagPrefix, MaxChunksActivationBlockFlagName),
+			Usage:    "first reference block the caps of max-chunks-per-operator apply at, to be set once all the nodes are upgraded with the caps. 0 disables the caps",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_CHUNKS_ACTIVATION_BLOCK"),
+		},
+	}
+}
+
+func ReadChunkCapsCLIConfig(ctx *cli.Context, flagPrefix string) ChunkCaps {
+	// The caps are checked by ValidateChunkCapsCLIFlags
+	maxChunks, _ := ParseMaxChunksPerOperator(ctx.GlobalStringSlice(common.PrefixFlag(flagPrefix, MaxChunksPerOperatorFlagName)))
+	return ChunkCaps{
+		MaxChunksPerOperator: maxChunks,
+		ActivationBlock:      ctx.GlobalUint(common.PrefixFlag(flagPrefix, MaxChunksActivationBlockFlagName)),
+	}
+}
+
+// ValidateChunkCapsCLIFlags checks that the caps are positive numbers of chunks of distinct quorums
+func ValidateChunkCapsCLIFlags(ctx *cli.Context, flagPrefix string) error {
+	_, err := ParseMaxChunksPerOperator(ctx.GlobalStringSlice(common.PrefixFlag(flagPrefix, MaxChunksPerOperatorFlagName)))
+	return err
+}
+
+// ParseMaxChunksPerOperator parses the quorum:chunks pairs of the caps of the chunks assigned to the operators
+func ParseMaxChunksPerOperator(values []string) (map[QuorumID]ChunkNumber, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	maxChunks := make(map[QuorumID]ChunkNumber, len(values))
+	for _, value := range values {
+		quorum, chunks, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid max chunks per operator %q: must be quorum:chunks", value)
+		}
+		quorumID, err := strconv.ParseUint(quorum, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max chunks per operator %q: the quorum must be a number below 256", value)
+		}
+		numChunks, err := strconv.ParseUint(chunks, 10, 32)
+		if err != nil || numChunks == 0 {
+			return nil, fmt.Errorf("invalid max chunks per operator %q: the cap must be a positive number of chunks", value)
+		}
+		if _, ok := maxChunks[QuorumID(quorumID)]; ok {
+			return nil, fmt.Errorf("duplicate max chunks per operator of quorum %d", quorumID)
+		}
+		maxChunks[QuorumID(quorumID)] = ChunkNumber(numChunks)
+	}
+	return maxChunks, nil
+}
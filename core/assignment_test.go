@@ -1,11 +1,12 @@
 package core_test
 
 import (
-	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,7 +18,7 @@ func makeOperatorId(id int) core.OperatorID {
 
 func TestOperatorAssignments(t *testing.T) {
 
-	state := dat.GetTotalOperatorState(context.Background(), 0)
+	state := generateState(t)
 	operatorState := state.OperatorState
 	coordinator := &core.StdAssignmentCoordinator{}
 
@@ -83,3 +84,165 @@ func TestOperatorAssignments(t *testing.T) {
 	}
 
 }
+
+func TestOperatorAssignmentsGeneratedStakes(t *testing.T) {
+	coordinator := &core.StdAssignmentCoordinator{}
+	for _, distribution := range []mock.StakeDistribution{mock.UniformStakes, mock.ParetoStakes} {
+		for seed := int64(0); seed < 5; seed++ {
+			state, err := mock.GenerateOperatorState(seed, mock.OperatorSetSpec{NumOperators: 50, Distribution: distribution})
+			assert.NoError(t, err)
+
+			// The assignments cover the chunks without overlapping, and give each operator at least its share
+			assignments, info, err := coordinator.GetAssignments(state.OperatorState, 0, uint(2))
+			assert.NoError(t, err)
+			covered := make([]bool, info.TotalChunks)
+			for id, assignment := range assignments {
+				assert.GreaterOrEqual(t, assignment.NumChunks, uint(1))
+				for _, index := range assignment.GetIndices() {
+					assert.False(t, covered[index])
+					covered[index] = true
+				}
+				share := new(big.Int).Mul(state.Operators[0][id].Stake, big.NewInt(50*2))
+				share.Div(share, state.Totals[0].Stake)
+				assert.GreaterOrEqual(t, uint64(assignment.NumChunks), share.Uint64())
+			}
+			assert.NotContains(t, covered, false)
+		}
+	}
+}
+
+func TestOperatorAssignmentsCapped(t *testing.T) {
+	state := generateState(t)
+	operatorState := state.OperatorState
+	operatorState.BlockNumber = 100
+	coordinator := &core.StdAssignmentCoordinator{Caps: core.ChunkCaps{
+		MaxChunksPerOperator: map[core.QuorumID]core.ChunkNumber{0: 3, 1: 1},
+		ActivationBlock:      100,
+	}}
+
+	// The chunks of the operators 8 and 9 above the cap spill over to the operators 0 and 1, which are first in the
+	// order of the assignments
+	expectedChunks := []core.ChunkNumber{2, 2, 2, 2, 2, 3, 3, 3, 3, 3}
+	expectedInfo := core.AssignmentInfo{
+		TotalChunks: 25,
+	}
+	// The cap of the quorum 1 is below the even split of its 25 chunks over the 10 operators, so it's raised to 3
+	for _, quorumID := range []core.QuorumID{0, 1} {
+		assignments, info, err := coordinator.GetAssignments(operatorState, quorumID, uint(2))
+		assert.NoError(t, err)
+		assert.Equal(t, expectedInfo, info)
+		startIndex := core.ChunkNumber(0)
+		for i, numChunks := range expectedChunks {
+			expected := core.Assignment{StartIndex: startIndex, NumChunks: numChunks}
+			assert.Equal(t, expected, assignments[makeOperatorId(i)])
+
+			assignment, info, err := coordinator.GetOperatorAssignment(operatorState, quorumID, uint(2), makeOperatorId(i))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, assignment)
+			assert.Equal(t, expectedInfo, info)
+			startIndex += numChunks
+		}
+	}
+
+	// The quorums without a cap aren't capped
+	assignments, _, err := coordinator.GetAssignments(operatorState, 2, uint(2))
+	assert.NoError(t, err)
+	assert.Equal(t, core.Assignment{StartIndex: 21, NumChunks: 4}, assignments[makeOperatorId(9)])
+
+	// Nor are the operator states before the activation block
+	operatorState.BlockNumber = 99
+	assignments, _, err = coordinator.GetAssignments(operatorState, 0, uint(2))
+	assert.NoError(t, err)
+	assert.Equal(t, core.Assignment{StartIndex: 21, NumChunks: 4}, assignments[makeOperatorId(9)])
+	coordinator.Caps.ActivationBlock = 0
+	operatorState.BlockNumber = 100
+	assignments, _, err = coordinator.GetAssignments(operatorState, 0, uint(2))
+	assert.NoError(t, err)
+	assert.Equal(t, core.Assignment{StartIndex: 21, NumChunks: 4}, assignments[makeOperatorId(9)])
+}
+
+func TestOperatorAssignmentsCappedGeneratedStakes(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		state, err := mock.GenerateOperatorState(seed, mock.OperatorSetSpec{NumOperators: 50, Distribution: mock.ParetoStakes, BlockNumber: 1})
+		assert.NoError(t, err)
+		uncapped, uncappedInfo, err := (&core.StdAssignmentCoordinator{}).GetAssignments(state.OperatorState, 0, uint(2))
+		assert.NoError(t, err)
+
+		// The assignments still cover the same chunks without overlapping, with none above the cap
+		coordinator := &core.StdAssignmentCoordinator{Caps: core.ChunkCaps{
+			MaxChunksPerOperator: map[core.QuorumID]core.ChunkNumber{0: 4},
+			ActivationBlock:      1,
+		}}
+		assignments, info, err := coordinator.GetAssignments(state.OperatorState, 0, uint(2))
+		assert.NoError(t, err)
+		assert.Equal(t, uncappedInfo, info)
+		covered := make([]bool, info.TotalChunks)
+		for id, assignment := range assignments {
+			assert.GreaterOrEqual(t, assignment.NumChunks, uint(1))
+			assert.LessOrEqual(t, assignment.NumChunks, uint(4))
+			// Only the operators below the cap are assigned the chunks spilled over
+			if uncapped[id].NumChunks >= 4 {
+				assert.Equal(t, uint(4), assignment.NumChunks)
+			} else {
+				assert.GreaterOrEqual(t, assignment.NumChunks, uncapped[id].NumChunks)
+			}
+			for _, index := range assignment.GetIndices() {
+				assert.False(t, covered[index])
+				covered[index] = true
+			}
+		}
+		assert.NotContains(t, covered, false)
+	}
+}
+
+func TestCheckReconstruction(t *testing.T) {
+	// An operator holds 91% of the stake, and the 9 others 1%
+	state, err := mock.GenerateOperatorState(1, mock.OperatorSetSpec{
+		NumOperators: 10,
+		Distribution: mock.CustomStakes,
+		Stakes:       []int64{1, 1, 1, 1, 1, 1, 1, 1, 1, 91},
+		BlockNumber:  100,
+	})
+	assert.NoError(t, err)
+	uncapped, _, err := (&core.StdAssignmentCoordinator{}).GetAssignments(state.OperatorState, 0, uint(2))
+	assert.NoError(t, err)
+	coordinator := &core.StdAssignmentCoordinator{Caps: core.ChunkCaps{
+		MaxChunksPerOperator: map[core.QuorumID]core.ChunkNumber{0: 3},
+		ActivationBlock:      100,
+	}}
+	capped, _, err := coordinator.GetAssignments(state.OperatorState, 0, uint(2))
+	assert.NoError(t, err)
+	assert.Equal(t, core.Assignment{StartIndex: 25, NumChunks: 3}, capped[makeOperatorId(9)])
+
+	// The operator alone holds the 50% of the stake between the thresholds, and the 10 chunks of the 28 the blob is
+	// reconstructed from unless its chunks are capped to 3
+	assert.NoError(t, core.CheckReconstruction(state.OperatorState, 0, uncapped, uint(2), 90, 40))
+	err = core.CheckReconstruction(state.OperatorState, 0, capped, uint(2), 90, 40)
+	assert.ErrorIs(t, err, core.ErrInsufficientChunks)
+	assert.ErrorContains(t, err, "3 chunks of the 10 needed in quorum 0")
+
+	// While the thresholds needing at most 3 chunks still hold
+	assert.NoError(t, core.CheckReconstruction(state.OperatorState, 0, capped, uint(2), 90, 75))
+	assert.Error(t, core.CheckReconstruction(state.OperatorState, 0, capped, uint(2), 40, 40))
+}
+
+func TestParseMaxChunksPerOperator(t *testing.T) {
+	maxChunks, err := core.ParseMaxChunksPerOperator([]string{"0:64", "2:8"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[core.QuorumID]core.ChunkNumber{0: 64, 2: 8}, maxChunks)
+
+	maxChunks, err = core.ParseMaxChunksPerOperator(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, maxChunks)
+
+	for value, expected := range map[string]string{
+		"64":    "must be quorum:chunks",
+		"256:8": "the quorum must be a number below 256",
+		"0:0":   "the cap must be a positive number of chunks",
+	} {
+		_, err := core.ParseMaxChunksPerOperator([]string{value})
+		assert.ErrorContains(t, err, expected)
+	}
+	_, err = core.ParseMaxChunksPerOperator([]string{"0:64", "0:8"})
+	assert.ErrorContains(t, err, "duplicate max chunks per operator of quorum 0")
+}
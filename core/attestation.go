@@ -3,6 +3,7 @@ package core
 import (
 	"crypto/rand"
 	"math/big"
+	"sync"
 
 	bn254utils "github.com/Layr-Labs/eigenda/core/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -12,6 +13,10 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// minSignatureBatch is the fewest signatures verified together, below which the batch verification saves too little
+// over the separate checks to be worth its own scalar multiplications
+const minSignatureBatch = 8
+
 type G1Point struct {
 	*bn254.G1Affine
 }
@@ -78,6 +83,56 @@ func (s *Signature) Verify(pubkey *G2Point, message [32]byte) bool {
 	return ok
 }
 
+// verifySignatures verifies the signatures of the message against the public keys of the same index, and returns
+// whether each is valid. The signatures are checked in batches over numWorkers goroutines, and those of a batch that
+// fails are checked one by one to find the invalid ones.
+func verifySignatures(sigs []*Signature, pubkeys []*G2Point, message [32]byte, numWorkers int) []bool {
+	valid := make([]bool, len(sigs))
+	if len(sigs) == 0 {
+		return valid
+	}
+	msgPoint := bn254utils.MapToCurve(message)
+	verify := func(i int) {
+		ok, err := bn254utils.VerifySigPoint(sigs[i].G1Affine, pubkeys[i].G2Affine, msgPoint)
+		valid[i] = err == nil && ok
+	}
+
+	batchSize := (len(sigs) + numWorkers - 1) / numWorkers
+	if batchSize < minSignatureBatch {
+		batchSize = minSignatureBatch
+	}
+	var wg sync.WaitGroup
+	for start := 0; start < len(sigs); start += batchSize {
+		end := start + batchSize
+		if end > len(sigs) {
+			end = len(sigs)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			if end-start > 1 {
+				batchSigs := make([]bn254.G1Affine, 0, end-start)
+				batchPubkeys := make([]bn254.G2Affine, 0, end-start)
+				for i := start; i < end; i++ {
+					batchSigs = append(batchSigs, *sigs[i].G1Affine)
+					batchPubkeys = append(batchPubkeys, *pubkeys[i].G2Affine)
+				}
+				if ok, err := bn254utils.VerifySigs(batchSigs, batchPubkeys, msgPoint); err == nil && ok {
+					for i := start; i < end; i++ {
+						valid[i] = true
+					}
+					return
+				}
+			}
+			for i := start; i < end; i++ {
+				verify(i)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return valid
+}
+
 // GetOperatorID hashes the G1Point (public key of an operator) to generate the operator ID.
 // It does it to match how it's hashed in solidity: `keccak256(abi.encodePacked(pk.X, pk.Y))`
 // Ref: https://github.com/Layr-Labs/eigenlayer-contracts/blob/avs-unstable/src/contracts/libraries/BN254.sol#L285
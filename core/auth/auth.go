@@ -0,0 +1,79 @@
+// Package auth implements the signatures of the authenticated dispersals. The disperser sends a challenge, which
+// the client signs along with the hash of the blob using the ECDSA key of its account. The account ID is the
+// hex-encoded uncompressed public key of the key.
+//
+// It also implements the signatures of the requests of the disperser to the nodes, which the nodes check before
+// storing the chunks of a batch, see BatchSigner.
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlobRequestSigner signs the challenges of the authenticated dispersals of an account. Implementations may keep
+// the key outside of the process, e.g. in a KMS.
+type BlobRequestSigner interface {
+	// SignBlobRequest returns the 65-byte [R || S || V] signature of BlobRequestHash(challenge, data)
+	SignBlobRequest(challenge uint32, data []byte) ([]byte, error)
+	// AccountID returns the account authenticated by the signatures
+	AccountID() string
+}
+
+// LocalBlobRequestSigner signs with a private key held in memory
+type LocalBlobRequestSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+var _ BlobRequestSigner = (*LocalBlobRequestSigner)(nil)
+
+// NewLocalBlobRequestSigner parses the hex-encoded private key, with or without the 0x prefix
+func NewLocalBlobRequestSigner(privateKeyHex string) (*LocalBlobRequestSigner, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return &LocalBlobRequestSigner{privateKey: privateKey}, nil
+}
+
+func (s *LocalBlobRequestSigner) SignBlobRequest(challenge uint32, data []byte) ([]byte, error) {
+	return crypto.Sign(BlobRequestHash(challenge, data), s.privateKey)
+}
+
+func (s *LocalBlobRequestSigner) AccountID() string {
+	return hexutil.Encode(crypto.FromECDSAPub(&s.privateKey.PublicKey))
+}
+
+// BlobRequestHash returns keccak256(challenge as 4 big-endian bytes || keccak256(data))
+func BlobRequestHash(challenge uint32, data []byte) []byte {
+	buf := make([]byte, 4, 4+32)
+	binary.BigEndian.PutUint32(buf, challenge)
+	return crypto.Keccak256(append(buf, crypto.Keccak256(data)...))
+}
+
+// VerifyBlobRequestSignature checks that the signature of the challenge and data was made by the key of the account
+func VerifyBlobRequestSignature(accountID string, challenge uint32, data []byte, signature []byte) error {
+	publicKey, err := hexutil.Decode(accountID)
+	if err != nil {
+		return fmt.Errorf("invalid account ID: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("invalid signature length %d, expected %d", len(signature), crypto.SignatureLength)
+	}
+
+	recovered, err := crypto.Ecrecover(BlobRequestHash(challenge, data), signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !bytes.Equal(recovered, publicKey) {
+		return errors.New("signature does not match the account")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testPrivateKey  = "0x73ae7e3a40b59caacb1cda8fa04f4e7fa5bb2b37101f9f3506290c201f57bf7b"
+	otherPrivateKey = "fb1c1b3e8d1b9e2a4a0b9d5e8a7c6f5e4d3c2b1a0f9e8d7c6b5a4938271605f4"
+)
+
+func TestBlobRequestSignature(t *testing.T) {
+	signer, err := auth.NewLocalBlobRequestSigner(testPrivateKey)
+	assert.NoError(t, err)
+	other, err := auth.NewLocalBlobRequestSigner(otherPrivateKey)
+	assert.NoError(t, err)
+	assert.Len(t, signer.AccountID(), 2+2*65)
+	assert.NotEqual(t, signer.AccountID(), other.AccountID())
+
+	data := []byte("blob")
+	signature, err := signer.SignBlobRequest(42, data)
+	assert.NoError(t, err)
+	assert.NoError(t, auth.VerifyBlobRequestSignature(signer.AccountID(), 42, data, signature))
+
+	// The signature is bound to the challenge, the blob and the account
+	assert.ErrorContains(t, auth.VerifyBlobRequestSignature(signer.AccountID(), 43, data, signature), "does not match")
+	assert.ErrorContains(t, auth.VerifyBlobRequestSignature(signer.AccountID(), 42, []byte("other blob"), signature), "does not match")
+	assert.ErrorContains(t, auth.VerifyBlobRequestSignature(other.AccountID(), 42, data, signature), "does not match")
+
+	assert.ErrorContains(t, auth.VerifyBlobRequestSignature(signer.AccountID(), 42, data, signature[:64]), "invalid signature length")
+	assert.ErrorContains(t, auth.VerifyBlobRequestSignature("not hex", 42, data, signature), "invalid account ID")
+
+	_, err = auth.NewLocalBlobRequestSigner("0x1234")
+	assert.ErrorContains(t, err, "failed to parse private key")
+}
+
+func TestBatchSignature(t *testing.T) {
+	signer, err := auth.NewLocalBatchSigner(testPrivateKey)
+	assert.NoError(t, err)
+	other, err := auth.NewLocalBatchSigner(otherPrivateKey)
+	assert.NoError(t, err)
+	assert.NotEqual(t, signer.Address(), other.Address())
+
+	batchHeaderHash := [32]byte{1, 2, 3}
+	signature, err := signer.SignBatch(batchHeaderHash)
+	assert.NoError(t, err)
+	address, err := auth.RecoverBatchSigner(batchHeaderHash, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, signer.Address(), address)
+
+	// The signature is bound to the batch
+	address, err = auth.RecoverBatchSigner([32]byte{1, 2, 4}, signature)
+	assert.NoError(t, err)
+	assert.NotEqual(t, signer.Address(), address)
+
+	// The signatures of the blob requests aren't valid for the batches, even over the same bytes
+	blobSigner, err := auth.NewLocalBlobRequestSigner(testPrivateKey)
+	assert.NoError(t, err)
+	blobSignature, err := blobSigner.SignBlobRequest(0, batchHeaderHash[:])
+	assert.NoError(t, err)
+	address, err = auth.RecoverBatchSigner(batchHeaderHash, blobSignature)
+	assert.NoError(t, err)
+	assert.NotEqual(t, signer.Address(), address)
+
+	_, err = auth.RecoverBatchSigner(batchHeaderHash, signature[:64])
+	assert.ErrorContains(t, err, "invalid signature length")
+	_, err = auth.NewLocalBatchSigner("0x1234")
+	assert.ErrorContains(t, err, "failed to parse private key")
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// storeChunksDomain separates the signatures of the requests to the nodes from the other signatures of the key
+const storeChunksDomain = "EigenDA.StoreChunks"
+
+// BatchSigner signs the requests of the disperser to store the chunks of a batch on the nodes. The nodes identify
+// the disperser by the Ethereum address of its key. Implementations may keep the key outside of the process, e.g. in
+// a KMS.
+type BatchSigner interface {
+	// SignBatch returns the 65-byte [R || S || V] signature of StoreChunksRequestHash(batchHeaderHash)
+	SignBatch(batchHeaderHash [32]byte) ([]byte, error)
+	// Address returns the address the signatures are recovered to
+	Address() gethcommon.Address
+}
+
+// LocalBatchSigner signs with a private key held in memory
+type LocalBatchSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+var _ BatchSigner = (*LocalBatchSigner)(nil)
+
+// NewLocalBatchSigner parses the hex-encoded private key, with or without the 0x prefix
+func NewLocalBatchSigner(privateKeyHex string) (*LocalBatchSigner, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return &LocalBatchSigner{privateKey: privateKey}, nil
+}
+
+func (s *LocalBatchSigner) SignBatch(batchHeaderHash [32]byte) ([]byte, error) {
+	return crypto.Sign(StoreChunksRequestHash(batchHeaderHash), s.privateKey)
+}
+
+func (s *LocalBatchSigner) Address() gethcommon.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+// StoreChunksRequestHash returns keccak256("EigenDA.StoreChunks" || batchHeaderHash)
+func StoreChunksRequestHash(batchHeaderHash [32]byte) []byte {
+	return crypto.Keccak256([]byte(storeChunksDomain), batchHeaderHash[:])
+}
+
+// RecoverBatchSigner returns the address of the key that signed the requests for the batch
+func RecoverBatchSigner(batchHeaderHash [32]byte, signature []byte) (gethcommon.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return gethcommon.Address{}, fmt.Errorf("invalid signature length %d, expected %d", len(signature), crypto.SignatureLength)
+	}
+	publicKey, err := crypto.SigToPub(StoreChunksRequestHash(batchHeaderHash), signature)
+	if err != nil {
+		return gethcommon.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
@@ -1,8 +1,10 @@
 package bn254
 
 import (
+	"fmt"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -11,10 +13,14 @@ import (
 )
 
 func VerifySig(sig *bn254.G1Affine, pubkey *bn254.G2Affine, msgBytes [32]byte) (bool, error) {
+	return VerifySigPoint(sig, pubkey, MapToCurve(msgBytes))
+}
 
-	g2Gen := GetG2Generator()
+// VerifySigPoint verifies the signature of the message mapped to the curve, which the verifications of the same
+// message can share
+func VerifySigPoint(sig *bn254.G1Affine, pubkey *bn254.G2Affine, msgPoint *bn254.G1Affine) (bool, error) {
 
-	msgPoint := MapToCurve(msgBytes)
+	g2Gen := GetG2Generator()
 
 	var negSig bn254.G1Affine
 	negSig.Neg((*bn254.G1Affine)(sig))
@@ -30,6 +36,37 @@ func VerifySig(sig *bn254.G1Affine, pubkey *bn254.G2Affine, msgBytes [32]byte) (
 
 }
 
+// VerifySigs verifies the signatures of the same message point against their public keys with a single pairing check
+// of a random linear combination of them: e(sum r_i*sig_i, g2) = e(msg, sum r_i*pk_i) holds for random r_i only if
+// all the signatures are valid, but for a negligible probability. It doesn't tell which signatures are invalid.
+func VerifySigs(sigs []bn254.G1Affine, pubkeys []bn254.G2Affine, msgPoint *bn254.G1Affine) (bool, error) {
+	if len(sigs) != len(pubkeys) {
+		return false, fmt.Errorf("%d signatures for %d public keys", len(sigs), len(pubkeys))
+	}
+	scalars := make([]fr.Element, len(sigs))
+	for i := range scalars {
+		if _, err := scalars[i].SetRandom(); err != nil {
+			return false, err
+		}
+	}
+	config := ecc.MultiExpConfig{}
+	sig, err := new(bn254.G1Affine).MultiExp(sigs, scalars, config)
+	if err != nil {
+		return false, err
+	}
+	pubkey, err := new(bn254.G2Affine).MultiExp(pubkeys, scalars, config)
+	if err != nil {
+		return false, err
+	}
+	var negSig bn254.G1Affine
+	negSig.Neg(sig)
+	ok, err := bn254.PairingCheck([]bn254.G1Affine{*msgPoint, negSig}, []bn254.G2Affine{*pubkey, *GetG2Generator()})
+	if err != nil {
+		return false, nil
+	}
+	return ok, nil
+}
+
 func MapToCurve(digest [32]byte) *bn254.G1Affine {
 
 	one := new(big.Int).SetUint64(1)
@@ -2,6 +2,7 @@ package core
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
@@ -32,6 +33,29 @@ type QuorumResult struct {
 	QuorumID QuorumID
 	// PercentSigned is percentage of the total stake for the quorum that signed for a particular batch.
 	PercentSigned uint8
+	// NonSigners are the operators of the quorum that did not sign for the batch, sorted by ID
+	NonSigners []*NonSigner
+}
+
+// NonSignerReason classifies why an operator did not sign for a batch
+type NonSignerReason string
+
+const (
+	// NonSignerNoResponse is the reason of the operators that could not be reached, e.g. as their node is down
+	NonSignerNoResponse NonSignerReason = "no_response"
+	// NonSignerTimeout is the reason of the operators that did not reply in time
+	NonSignerTimeout NonSignerReason = "timeout"
+	// NonSignerInvalidSignature is the reason of the operators that replied with a signature that doesn't verify
+	NonSignerInvalidSignature NonSignerReason = "invalid_signature"
+	// NonSignerRefused is the reason of the operators that replied with an error rather than a signature, e.g. as
+	// they found the chunks invalid
+	NonSignerRefused NonSignerReason = "refused"
+)
+
+// NonSigner is an operator that did not sign for a batch
+type NonSigner struct {
+	OperatorID OperatorID
+	Reason     NonSignerReason
 }
 
 // Blob stores the data and header of a single data blob. Blobs are the fundamental unit of data posted to EigenDA by users.
@@ -50,10 +74,51 @@ type BlobRequestHeader struct {
 	AccountID AccountID `json:"account_id"`
 }
 
-func (h *BlobRequestHeader) Validate() error {
+// DefaultThresholdMargin is the margin, in percentage points, by which the quorum threshold of a blob must exceed its
+// adversary threshold unless the margin of the quorum is overridden
+const DefaultThresholdMargin = 10
+
+// ThresholdMargins are the margins, in percentage points, by which the quorum thresholds of the blobs must exceed
+// their adversary thresholds
+type ThresholdMargins struct {
+	Default uint8
+	// Quorums are the margins of the quorums that override the default one
+	Quorums map[QuorumID]uint8
+}
+
+// Margin returns the margin of the quorum
+func (m ThresholdMargins) Margin(quorumID QuorumID) uint8 {
+	if margin, ok := m.Quorums[quorumID]; ok {
+		return margin
+	}
+	return m.Default
+}
+
+// ThresholdMarginError is the error of a security param whose quorum threshold doesn't exceed its adversary
+// threshold by the margin of its quorum
+type ThresholdMarginError struct {
+	QuorumID           QuorumID
+	AdversaryThreshold uint8
+	QuorumThreshold    uint8
+	Margin             uint8
+}
+
+func (e *ThresholdMarginError) Error() string {
+	return fmt.Sprintf("invalid request: quorum threshold %d of quorum %d must be >= %d + adversary threshold %d", e.QuorumThreshold, e.QuorumID, e.Margin, e.AdversaryThreshold)
+}
+
+// Validate checks the security params of the header, whose quorum thresholds must exceed their adversary thresholds
+// by the margins. The margin errors are *ThresholdMarginError.
+func (h *BlobRequestHeader) Validate(margins ThresholdMargins) error {
 	for _, quorum := range h.SecurityParams {
-		if quorum.QuorumThreshold < quorum.AdversaryThreshold+10 {
-			return errors.New("invalid request: quorum threshold must be >= 10 + adversary threshold")
+		margin := margins.Margin(quorum.QuorumID)
+		if int(quorum.QuorumThreshold) < int(quorum.AdversaryThreshold)+int(margin) {
+			return &ThresholdMarginError{
+				QuorumID:           quorum.QuorumID,
+				AdversaryThreshold: quorum.AdversaryThreshold,
+				QuorumThreshold:    quorum.QuorumThreshold,
+				Margin:             margin,
+			}
 		}
 		if quorum.QuorumThreshold > 100 {
 			return errors.New("invalid request: quorum threshold exceeds 100")
@@ -0,0 +1,35 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlobRequestHeaderValidate(t *testing.T) {
+	header := func(quorumID core.QuorumID, adversaryThreshold, quorumThreshold uint8) *core.BlobRequestHeader {
+		return &core.BlobRequestHeader{SecurityParams: []*core.SecurityParam{{
+			QuorumID:           quorumID,
+			AdversaryThreshold: adversaryThreshold,
+			QuorumThreshold:    quorumThreshold,
+		}}}
+	}
+	margins := core.ThresholdMargins{Default: core.DefaultThresholdMargin, Quorums: map[core.QuorumID]uint8{1: 0, 2: 30}}
+
+	assert.NoError(t, header(0, 80, 90).Validate(margins))
+	assert.NoError(t, header(1, 80, 80).Validate(margins))
+	assert.NoError(t, header(2, 60, 90).Validate(margins))
+
+	err := header(2, 61, 90).Validate(margins)
+	var marginErr *core.ThresholdMarginError
+	assert.True(t, errors.As(err, &marginErr))
+	assert.Equal(t, core.ThresholdMarginError{QuorumID: 2, AdversaryThreshold: 61, QuorumThreshold: 90, Margin: 30}, *marginErr)
+	assert.EqualError(t, err, "invalid request: quorum threshold 90 of quorum 2 must be >= 30 + adversary threshold 61")
+
+	// The margin doesn't overflow the thresholds
+	assert.Error(t, header(0, 250, 255).Validate(margins))
+	assert.ErrorContains(t, header(1, 90, 101).Validate(margins), "exceeds 100")
+	assert.ErrorContains(t, header(1, 0, 50).Validate(margins), "adversary threshold equals 0")
+}
@@ -41,6 +41,10 @@ type Encoder interface {
 	// VerifyBlobLength takes in the commitments and returns an error if the blob length is invalid.
 	VerifyBlobLength(commitments BlobCommitments) error
 
+	// VerifyCommitment takes in a decoded blob and its commitments and returns an error if the commitment or the
+	// length proof doesn't match the data.
+	VerifyCommitment(data []byte, commitments BlobCommitments) error
+
 	// Decode takes in the chunks, indices, and encoding parameters and returns the decoded blob
 	Decode(chunks []*Chunk, indices []ChunkNumber, params EncodingParams, inputSize uint64) ([]byte, error)
 }
@@ -14,11 +14,17 @@ const (
 	CachePathFlagName         = "kzg.cache-path"
 	SRSOrderFlagName          = "kzg.srs-order"
 	NumWorkerFlagName         = "kzg.num-workers"
+	NumDecodeWorkerFlagName   = "kzg.num-decode-workers"
+	DecodePoolMaxBlobFlagName = "kzg.decode-buffer-pool-max-blob-size"
+	DisableDecodePoolFlagName = "kzg.disable-decode-buffer-pool"
 	VerboseFlagName           = "kzg.verbose"
 	PreloadEncoderFlagName    = "kzg.preload-encoder"
 	CacheEncodedBlobsFlagName = "cache-encoded-blobs"
 )
 
+// defaultDecodePoolMaxBlobSize is the max size of the blobs accepted by the disperser
+const defaultDecodePoolMaxBlobSize = 512 * 1024
+
 func CLIFlags(envPrefix string) []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
@@ -52,6 +58,26 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_WORKERS"),
 			Value:    uint64(runtime.GOMAXPROCS(0)),
 		},
+		cli.Uint64Flag{
+			Name:     NumDecodeWorkerFlagName,
+			Usage:    "Number of goroutines of the erasure decoding of a blob",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_DECODE_WORKERS"),
+			Value:    uint64(runtime.NumCPU()),
+		},
+		cli.Uint64Flag{
+			Name:     DecodePoolMaxBlobFlagName,
+			Usage:    "Size in bytes of the largest blobs whose erasure decodings recycle their buffers across decodings, which reduces the allocations under load. The decodings of larger blobs allocate their own",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DECODE_BUFFER_POOL_MAX_BLOB_SIZE"),
+			Value:    defaultDecodePoolMaxBlobSize,
+		},
+		cli.BoolFlag{
+			Name:     DisableDecodePoolFlagName,
+			Usage:    "Disable the recycling of the buffers of the erasure decodings, which then allocate their own",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DISABLE_DECODE_BUFFER_POOL"),
+		},
 		cli.BoolFlag{
 			Name:     VerboseFlagName,
 			Usage:    "Enable to see verbose output for encoding/decoding",
@@ -80,6 +106,10 @@ func ReadCLIConfig(ctx *cli.Context) EncoderConfig {
 	cfg.CacheDir = ctx.GlobalString(CachePathFlagName)
 	cfg.SRSOrder = ctx.GlobalUint64(SRSOrderFlagName)
 	cfg.NumWorker = ctx.GlobalUint64(NumWorkerFlagName)
+	cfg.NumDecodeWorker = ctx.GlobalUint64(NumDecodeWorkerFlagName)
+	if !ctx.GlobalBool(DisableDecodePoolFlagName) {
+		cfg.DecodeBufferPoolMaxBlobSize = ctx.GlobalUint64(DecodePoolMaxBlobFlagName)
+	}
 	cfg.Verbose = ctx.GlobalBool(VerboseFlagName)
 	cfg.PreloadEncoder = ctx.GlobalBool(PreloadEncoderFlagName)
 	return EncoderConfig{
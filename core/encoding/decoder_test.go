@@ -0,0 +1,231 @@
+package encoding_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+// The benchmarks in this file exercise the encoder the same way the retriever does when reconstructing a blob:
+// the chunks gathered from the operators are verified against the blob commitment and then decoded, and the decoded
+// blob is checked against the commitment.
+//
+// Run with:
+//
+//	go test ./core/encoding -run '^$' -bench 'Decode|VerifyChunksForDecode|VerifyCommitmentForDecode'
+//
+// BenchmarkDecodeBufferPool reports the allocations the recycling of the buffers of the decodings saves.
+//
+// The scaling of the decode with the number of workers is only visible on a machine with as many CPUs, and is
+// bounded by the recovery of the polynomial from the samples, which is not parallel.
+//
+// The first run generates the SRS tables for the larger chunk lengths, which may take a few minutes.
+
+// benchmarkNumChunks is the total number of chunks the blobs are encoded into
+const benchmarkNumChunks = 64
+
+// decodeBenchmarkCase is a blob size along with a coding ratio, i.e. the ratio of the number of encoded symbols
+// to the number of symbols in the blob. A blob with coding ratio r can be reconstructed from roughly 1/r of its chunks.
+type decodeBenchmarkCase struct {
+	blobSize    int
+	codingRatio uint
+}
+
+var decodeBenchmarkCases = []decodeBenchmarkCase{
+	{blobSize: 32 * 1024, codingRatio: 2},
+	{blobSize: 32 * 1024, codingRatio: 4},
+	{blobSize: 32 * 1024, codingRatio: 8},
+	{blobSize: 128 * 1024, codingRatio: 2},
+	{blobSize: 128 * 1024, codingRatio: 4},
+	{blobSize: 128 * 1024, codingRatio: 8},
+	{blobSize: 512 * 1024, codingRatio: 2},
+	{blobSize: 512 * 1024, codingRatio: 4},
+	{blobSize: 512 * 1024, codingRatio: 8},
+}
+
+func (c decodeBenchmarkCase) String() string {
+	return fmt.Sprintf("blobSize=%dKiB/codingRatio=%d", c.blobSize/1024, c.codingRatio)
+}
+
+type encodedBenchmarkBlob struct {
+	data        []byte
+	params      core.EncodingParams
+	commitments core.BlobCommitments
+	chunks      []*core.Chunk
+	indices     []core.ChunkNumber
+	// minNumChunks is the minimum number of chunks needed to reconstruct the blob
+	minNumChunks int
+}
+
+// encodedBenchmarkBlobs caches the encoded blobs, since each benchmark function is invoked several times
+var encodedBenchmarkBlobs = make(map[decodeBenchmarkCase]*encodedBenchmarkBlob)
+
+func getEncodedBenchmarkBlob(b *testing.B, c decodeBenchmarkCase) *encodedBenchmarkBlob {
+	if blob, ok := encodedBenchmarkBlobs[c]; ok {
+		return blob
+	}
+
+	data := make([]byte, c.blobSize)
+	_, err := rand.Read(data)
+	assert.NoError(b, err)
+
+	numSymbols := encoder.RoundUpDivision(uint64(c.blobSize), bn254.BYTES_PER_COEFFICIENT)
+	chunkLength := encoder.RoundUpDivision(numSymbols*uint64(c.codingRatio), benchmarkNumChunks)
+	params, err := core.GetEncodingParams(uint(chunkLength), benchmarkNumChunks)
+	assert.NoError(b, err)
+
+	commitments, chunks, err := enc.Encode(data, params)
+	assert.NoError(b, err)
+
+	indices := make([]core.ChunkNumber, len(chunks))
+	for i := range indices {
+		indices[i] = core.ChunkNumber(i)
+	}
+
+	blob := &encodedBenchmarkBlob{
+		data:         data,
+		params:       params,
+		commitments:  commitments,
+		chunks:       chunks,
+		indices:      indices,
+		minNumChunks: int(encoder.RoundUpDivision(numSymbols, uint64(params.ChunkLength))),
+	}
+	encodedBenchmarkBlobs[c] = blob
+	return blob
+}
+
+func benchmarkDecode(b *testing.B, numChunks func(blob *encodedBenchmarkBlob) int) {
+	for _, c := range decodeBenchmarkCases {
+		b.Run(c.String(), func(b *testing.B) {
+			blob := getEncodedBenchmarkBlob(b, c)
+			// Use the trailing chunks so that a partial set of chunks can't be decoded without recovery
+			n := numChunks(blob)
+			chunks := blob.chunks[len(blob.chunks)-n:]
+			indices := blob.indices[len(blob.indices)-n:]
+			maxInputSize := uint64(len(blob.data))
+
+			decoded, err := enc.Decode(chunks, indices, blob.params, maxInputSize)
+			assert.NoError(b, err)
+			assert.Equal(b, blob.data, decoded)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = enc.Decode(chunks, indices, blob.params, maxInputSize)
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeAllChunks measures the fast path of the decoder, where all the chunks are available and the
+// polynomial doesn't need to be recovered from the samples.
+func BenchmarkDecodeAllChunks(b *testing.B) {
+	benchmarkDecode(b, func(blob *encodedBenchmarkBlob) int {
+		return len(blob.chunks)
+	})
+}
+
+// BenchmarkDecodeMinChunks measures the full decode, where the blob is reconstructed from the minimum number of chunks.
+func BenchmarkDecodeMinChunks(b *testing.B) {
+	benchmarkDecode(b, func(blob *encodedBenchmarkBlob) int {
+		return blob.minNumChunks
+	})
+}
+
+// BenchmarkDecodeWorkers measures the scaling of the full decode of a blob with the number of decode workers.
+func BenchmarkDecodeWorkers(b *testing.B) {
+	group := enc.(*encoding.Encoder).EncoderGroup
+	defer func(numWorker uint64) { group.NumDecodeWorker = numWorker }(group.NumDecodeWorker)
+
+	blob := getEncodedBenchmarkBlob(b, decodeBenchmarkCase{blobSize: 512 * 1024, codingRatio: 4})
+	chunks := blob.chunks[len(blob.chunks)-blob.minNumChunks:]
+	indices := blob.indices[len(blob.indices)-blob.minNumChunks:]
+	maxInputSize := uint64(len(blob.data))
+	for _, numWorker := range []uint64{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", numWorker), func(b *testing.B) {
+			group.NumDecodeWorker = numWorker
+			decoded, err := enc.Decode(chunks, indices, blob.params, maxInputSize)
+			assert.NoError(b, err)
+			assert.Equal(b, blob.data, decoded)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = enc.Decode(chunks, indices, blob.params, maxInputSize)
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeBufferPool measures the allocations of the full decode of a blob with and without the recycling of
+// the buffers of the decodings.
+func BenchmarkDecodeBufferPool(b *testing.B) {
+	blob := getEncodedBenchmarkBlob(b, decodeBenchmarkCase{blobSize: 512 * 1024, codingRatio: 4})
+	kzgEncoder, err := enc.(*encoding.Encoder).EncoderGroup.GetKzgEncoder(encoder.ParamsFromMins(uint64(blob.params.NumChunks), uint64(blob.params.ChunkLength)))
+	assert.NoError(b, err)
+	defer func(pool *encoder.DecodeBufferPool) { kzgEncoder.BufferPool = pool }(kzgEncoder.BufferPool)
+
+	chunks := blob.chunks[len(blob.chunks)-blob.minNumChunks:]
+	indices := blob.indices[len(blob.indices)-blob.minNumChunks:]
+	maxInputSize := uint64(len(blob.data))
+	for _, pool := range []*encoder.DecodeBufferPool{nil, encoder.NewDecodeBufferPool(maxInputSize)} {
+		b.Run(fmt.Sprintf("pool=%t", pool != nil), func(b *testing.B) {
+			kzgEncoder.BufferPool = pool
+			decoded, err := enc.Decode(chunks, indices, blob.params, maxInputSize)
+			assert.NoError(b, err)
+			assert.Equal(b, blob.data, decoded)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = enc.Decode(chunks, indices, blob.params, maxInputSize)
+			}
+		})
+	}
+}
+
+// BenchmarkVerifyChunksForDecode measures the verification of the minimum set of chunks needed for reconstruction
+// against the blob commitment.
+func BenchmarkVerifyChunksForDecode(b *testing.B) {
+	for _, c := range decodeBenchmarkCases {
+		b.Run(c.String(), func(b *testing.B) {
+			blob := getEncodedBenchmarkBlob(b, c)
+			chunks := blob.chunks[:blob.minNumChunks]
+			indices := blob.indices[:blob.minNumChunks]
+
+			err := enc.VerifyChunks(chunks, indices, blob.commitments, blob.params)
+			assert.NoError(b, err)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = enc.VerifyChunks(chunks, indices, blob.commitments, blob.params)
+			}
+		})
+	}
+}
+
+// BenchmarkVerifyCommitmentForDecode measures the check of the decoded blob against its commitment and length proof,
+// which is the latency the retrieval client adds to a retrieval when the commitment verification is enabled.
+func BenchmarkVerifyCommitmentForDecode(b *testing.B) {
+	for _, c := range decodeBenchmarkCases {
+		b.Run(c.String(), func(b *testing.B) {
+			blob := getEncodedBenchmarkBlob(b, c)
+
+			err := enc.VerifyCommitment(blob.data, blob.commitments)
+			assert.NoError(b, err)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = enc.VerifyCommitment(blob.data, blob.commitments)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ package encoding
 
 import (
 	"crypto/sha256"
+	"errors"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	lru "github.com/hashicorp/golang-lru/v2"
 )
 
@@ -103,6 +106,27 @@ func (e *Encoder) VerifyBlobLength(commitments core.BlobCommitments) error {
 
 }
 
+func (e *Encoder) VerifyCommitment(data []byte, commitments core.BlobCommitments) error {
+	if commitments.Commitment == nil || commitments.LengthProof == nil {
+		return errors.New("missing commitment or length proof")
+	}
+
+	dataFr := encoder.ToFrArray(data)
+	if uint(len(dataFr)) > commitments.Length {
+		return fmt.Errorf("blob of %d symbols exceeds the committed length %d", len(dataFr), commitments.Length)
+	}
+
+	commit, err := e.EncoderGroup.Commit(dataFr)
+	if err != nil {
+		return err
+	}
+	if !bn254.EqualG1(commit, commitments.Commitment.G1Point) {
+		return errors.New("commitment does not match the blob")
+	}
+
+	return e.VerifyBlobLength(commitments)
+}
+
 func (e *Encoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
 
 	encParams := toEncParams(params)
@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,6 +79,40 @@ func TestEncoder(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, decoded)
 }
 
+func TestVerifyCommitment(t *testing.T) {
+	params := core.EncodingParams{
+		ChunkLength: 5,
+		NumChunks:   5,
+	}
+	commitments, _, err := enc.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	err = enc.VerifyCommitment(gettysburgAddressBytes, commitments)
+	assert.NoError(t, err)
+
+	// The decoded blob is padded with zeros up to the committed length
+	padded := make([]byte, commitments.Length*bn254.BYTES_PER_COEFFICIENT)
+	copy(padded, gettysburgAddressBytes)
+	err = enc.VerifyCommitment(padded, commitments)
+	assert.NoError(t, err)
+
+	tampered := make([]byte, len(gettysburgAddressBytes))
+	copy(tampered, gettysburgAddressBytes)
+	tampered[0] ^= 1
+	err = enc.VerifyCommitment(tampered, commitments)
+	assert.ErrorContains(t, err, "commitment does not match the blob")
+
+	err = enc.VerifyCommitment(append(padded, 1), commitments)
+	assert.ErrorContains(t, err, "exceeds the committed length")
+
+	otherCommitments, _, err := enc.Encode(tampered, params)
+	assert.NoError(t, err)
+	wrongLengthProof := commitments
+	wrongLengthProof.LengthProof = otherCommitments.LengthProof
+	err = enc.VerifyCommitment(gettysburgAddressBytes, wrongLengthProof)
+	assert.Error(t, err)
+}
+
 // Ballpark number for 400KiB blob encoding
 //
 // goos: darwin
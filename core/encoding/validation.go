@@ -0,0 +1,79 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// The sizes of the points in the SRS files, which are hex-encoded compressed points
+const (
+	g1PointSize = 64
+	g2PointSize = 128
+)
+
+// ValidateConfig checks that the SRS files of the config exist and hold the points of the configured SRS order,
+// so that a misconfiguration fails at startup instead of at the first encoding or verification. Only the first
+// and the last points needed are decoded, so it's cheap compared to loading the SRS.
+func ValidateConfig(config EncoderConfig) error {
+	kzgConfig := config.KzgConfig
+	if kzgConfig.SRSOrder == 0 {
+		return errors.New("SRS order must be positive")
+	}
+	if kzgConfig.NumWorker == 0 {
+		return errors.New("number of workers must be positive")
+	}
+	if err := validatePointsFile("G1", kzgConfig.G1Path, kzgConfig.SRSOrder, g1PointSize, func(text []byte) error {
+		return new(bn254.G1Point).UnmarshalText(text)
+	}); err != nil {
+		return err
+	}
+	if err := validatePointsFile("G2", kzgConfig.G2Path, kzgConfig.SRSOrder, g2PointSize, func(text []byte) error {
+		return new(bn254.G2Point).UnmarshalText(text)
+	}); err != nil {
+		return err
+	}
+	if kzgConfig.PreloadEncoder {
+		info, err := os.Stat(kzgConfig.CacheDir)
+		if err == nil && !info.IsDir() {
+			return fmt.Errorf("SRS table cache path %s is not a directory", kzgConfig.CacheDir)
+		}
+	}
+	return nil
+}
+
+func validatePointsFile(group string, path string, order uint64, pointSize int64, decode func(text []byte) error) error {
+	if path == "" {
+		return fmt.Errorf("%s SRS path is not set", group)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s SRS file: %w", group, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s SRS file %s: %w", group, path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s SRS path %s is a directory", group, path)
+	}
+	numPoints := uint64(info.Size() / pointSize)
+	if numPoints < order {
+		return fmt.Errorf("%s SRS file %s has %d points (%d bytes), fewer than the SRS order %d", group, path, numPoints, info.Size(), order)
+	}
+
+	text := make([]byte, pointSize)
+	for _, index := range []uint64{0, order - 1} {
+		if _, err := file.ReadAt(text, int64(index)*pointSize); err != nil {
+			return fmt.Errorf("failed to read %s point %d from %s: %w", group, index, path, err)
+		}
+		if err := decode(text); err != nil {
+			return fmt.Errorf("%s SRS file %s has an invalid point at index %d: %w", group, path, index, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package encoding_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestConfig(order uint64) encoding.EncoderConfig {
+	return encoding.EncoderConfig{
+		KzgConfig: kzgEncoder.KzgConfig{
+			G1Path:    "../../inabox/resources/kzg/g1.point",
+			G2Path:    "../../inabox/resources/kzg/g2.point",
+			CacheDir:  "../../inabox/resources/kzg/SRSTables",
+			SRSOrder:  order,
+			NumWorker: 1,
+		},
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	// g1.point and g2.point hold 3000 points
+	assert.NoError(t, encoding.ValidateConfig(makeTestConfig(3000)))
+	assert.NoError(t, encoding.ValidateConfig(makeTestConfig(1)))
+
+	config := makeTestConfig(3000)
+	config.KzgConfig.PreloadEncoder = true
+	assert.NoError(t, encoding.ValidateConfig(config))
+}
+
+func TestValidateConfigFailures(t *testing.T) {
+	dir := t.TempDir()
+	truncated := filepath.Join(dir, "g1.point.truncated")
+	data, err := os.ReadFile("../../inabox/resources/kzg/g1.point")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(truncated, data[:64*10], 0644))
+	corrupted := filepath.Join(dir, "g1.point.corrupted")
+	corruptedData := append([]byte{}, data...)
+	copy(corruptedData[64*2999:], "zz")
+	assert.NoError(t, os.WriteFile(corrupted, corruptedData, 0644))
+
+	tests := []struct {
+		name   string
+		modify func(config *kzgEncoder.KzgConfig)
+		err    string
+	}{
+		{
+			name:   "zero order",
+			modify: func(config *kzgEncoder.KzgConfig) { config.SRSOrder = 0 },
+			err:    "SRS order must be positive",
+		},
+		{
+			name:   "zero workers",
+			modify: func(config *kzgEncoder.KzgConfig) { config.NumWorker = 0 },
+			err:    "number of workers must be positive",
+		},
+		{
+			name:   "order over the SRS size",
+			modify: func(config *kzgEncoder.KzgConfig) { config.SRSOrder = 3001 },
+			err:    "G1 SRS file ../../inabox/resources/kzg/g1.point has 3000 points (192000 bytes), fewer than the SRS order 3001",
+		},
+		{
+			name:   "missing G1 file",
+			modify: func(config *kzgEncoder.KzgConfig) { config.G1Path = filepath.Join(dir, "missing") },
+			err:    "failed to open G1 SRS file",
+		},
+		{
+			name:   "unset G2 path",
+			modify: func(config *kzgEncoder.KzgConfig) { config.G2Path = "" },
+			err:    "G2 SRS path is not set",
+		},
+		{
+			name:   "truncated G1 file",
+			modify: func(config *kzgEncoder.KzgConfig) { config.G1Path = truncated },
+			err:    "has 10 points (640 bytes), fewer than the SRS order 3000",
+		},
+		{
+			name:   "corrupted G1 file",
+			modify: func(config *kzgEncoder.KzgConfig) { config.G1Path = corrupted },
+			err:    "has an invalid point at index 2999",
+		},
+		{
+			name: "swapped G1 and G2 files",
+			modify: func(config *kzgEncoder.KzgConfig) {
+				config.G1Path, config.G2Path = config.G2Path, config.G1Path
+			},
+			err: "G1 SRS file ../../inabox/resources/kzg/g2.point has an invalid point",
+		},
+		{
+			name: "cache path is a file",
+			modify: func(config *kzgEncoder.KzgConfig) {
+				config.PreloadEncoder = true
+				config.CacheDir = truncated
+			},
+			err: "is not a directory",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := makeTestConfig(3000)
+			test.modify(&config.KzgConfig)
+			assert.ErrorContains(t, encoding.ValidateConfig(config), test.err)
+		})
+	}
+}
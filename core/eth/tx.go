@@ -16,6 +16,7 @@ import (
 	indexreg "github.com/Layr-Labs/eigenda/contracts/bindings/IIndexRegistry"
 	stakereg "github.com/Layr-Labs/eigenda/contracts/bindings/StakeRegistry"
 
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -130,11 +131,12 @@ func (t *Transactor) RegisterBLSPublicKey(ctx context.Context, keypair *core.Key
 		}
 		// estimate gas and send tx
 
-		_, err = t.EthClient.EstimateGasPriceAndLimitAndSendTx(context.Background(), tx, "RegisterBLSPubkey", nil)
+		receipt, err := t.EthClient.EstimateGasPriceAndLimitAndSendTx(context.Background(), tx, "RegisterBLSPubkey", nil)
 		if err != nil {
 			t.Logger.Error("Failed to estimate gas price and limit", "err", err)
 			return err
 		}
+		t.Logger.Info("Registered BLS public key with compendium", "txHash", receipt.TxHash.Hex(), "block", receipt.BlockNumber)
 
 	}
 
@@ -181,11 +183,12 @@ func (t *Transactor) RegisterOperator(ctx context.Context, pubkeyG1 *core.G1Poin
 		return err
 	}
 
-	_, err = t.EthClient.EstimateGasPriceAndLimitAndSendTx(context.Background(), tx, "RegisterOperatorWithCoordinator1", nil)
+	receipt, err := t.EthClient.EstimateGasPriceAndLimitAndSendTx(context.Background(), tx, "RegisterOperatorWithCoordinator1", nil)
 	if err != nil {
 		t.Logger.Error("Failed to estimate gas price and limit", "err", err)
 		return err
 	}
+	t.Logger.Info("Registered operator", "quorums", quorumIds, "socket", socket, "txHash", receipt.TxHash.Hex(), "block", receipt.BlockNumber)
 	return nil
 }
 
@@ -235,11 +238,12 @@ func (t *Transactor) RegisterOperatorWithChurn(ctx context.Context, pubkeyG1 *co
 		return err
 	}
 
-	_, err = t.EthClient.EstimateGasPriceAndLimitAndSendTx(context.Background(), tx, "RegisterOperatorWithCoordinatorWithChurn", nil)
+	receipt, err := t.EthClient.EstimateGasPriceAndLimitAndSendTx(context.Background(), tx, "RegisterOperatorWithCoordinatorWithChurn", nil)
 	if err != nil {
 		t.Logger.Error("Failed to estimate gas price and limit", "err", err)
 		return err
 	}
+	t.Logger.Info("Registered operator with churn", "quorums", quorumIds, "socket", socket, "churned", len(operatorsToChurn), "txHash", receipt.TxHash.Hex(), "block", receipt.BlockNumber)
 	return nil
 }
 
@@ -540,6 +544,20 @@ func (t *Transactor) GetQuorumCount(ctx context.Context, blockNumber uint32) (ui
 	})
 }
 
+func (t *Transactor) GetQuorumApk(ctx context.Context, quorumID core.QuorumID, blockNumber uint32) (*core.G1Point, error) {
+	apk, err := t.Bindings.BLSPubkeyRegistry.GetApkForQuorum(&bind.CallOpts{
+		Context:     ctx,
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}, uint8(quorumID))
+	if err != nil {
+		return nil, err
+	}
+	point := new(bn254.G1Affine)
+	point.X.SetBigInt(apk.X)
+	point.Y.SetBigInt(apk.Y)
+	return &core.G1Point{G1Affine: point}, nil
+}
+
 func (t *Transactor) updateContractBindings(blsOperatorStateRetrieverAddr, eigenDAServiceManagerAddr gethcommon.Address) error {
 	contractEigenDAServiceManager, err := eigendasrvmg.NewContractEigenDAServiceManager(eigenDAServiceManagerAddr, t.EthClient)
 	if err != nil {
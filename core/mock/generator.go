@@ -0,0 +1,247 @@
+package mock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// StakeDistribution is the shape of the stakes of the generated operators
+type StakeDistribution int
+
+const (
+	// UniformStakes draws the stakes uniformly between 1 and MaxStake
+	UniformStakes StakeDistribution = iota
+	// ParetoStakes draws the stakes from a Pareto distribution of shape ParetoShape and minimum 1, so that a few
+	// operators hold most of the stake, capped at MaxStake
+	ParetoStakes
+	// CustomStakes gives the operators the stakes of Stakes
+	CustomStakes
+)
+
+const (
+	defaultMaxStake    = 1_000_000
+	defaultParetoShape = 1.16
+)
+
+// OperatorSetSpec describes the operators of a generated operator state, see GenerateOperatorState
+type OperatorSetSpec struct {
+	NumOperators int
+	Distribution StakeDistribution
+	// MaxStake bounds the uniform and Pareto stakes, 1,000,000 if it's 0
+	MaxStake int64
+	// ParetoShape is the shape of the Pareto stakes, 1.16 if it's 0, which gives 80% of the stake to 20% of the
+	// operators
+	ParetoShape float64
+	// Stakes are the stakes of the operators with CustomStakes, one per operator
+	Stakes []int64
+	// Quorums are the indices of the operators of each quorum. All the operators are in quorum 0 if it's empty.
+	Quorums     map[core.QuorumID][]int
+	BlockNumber uint
+}
+
+// GeneratedOperatorID returns the ID of the generated operator of the index, which is the same as the one of the
+// operator of the index in the states of ChainDataMock
+func GeneratedOperatorID(index int) core.OperatorID {
+	return makeOperatorId(index)
+}
+
+// GenerateOperatorState generates the operator state described by the spec, along with the BLS keys of the
+// operators, so that their signatures verify. The same seed and spec always generate the same state. An operator has
+// the same stake in all its quorums, and its OperatorInfo in the private state is the one of its lowest quorum.
+func GenerateOperatorState(seed int64, spec OperatorSetSpec) (*PrivateOperatorState, error) {
+	if spec.NumOperators <= 0 {
+		return nil, errors.New("the operator set must have at least 1 operator")
+	}
+	rng := rand.New(rand.NewSource(seed))
+	stakes, err := generateStakes(rng, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	quorums := spec.Quorums
+	if len(quorums) == 0 {
+		all := make([]int, spec.NumOperators)
+		for i := range all {
+			all[i] = i
+		}
+		quorums = map[core.QuorumID][]int{0: all}
+	}
+	quorumIDs := make([]core.QuorumID, 0, len(quorums))
+	for quorumID, members := range quorums {
+		for _, index := range members {
+			if index < 0 || index >= spec.NumOperators {
+				return nil, fmt.Errorf("operator %d of quorum %d is out of the %d operators", index, quorumID, spec.NumOperators)
+			}
+		}
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+
+	indexedOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo, spec.NumOperators)
+	privateOperators := make(map[core.OperatorID]*PrivateOperatorInfo, spec.NumOperators)
+	for i := 0; i < spec.NumOperators; i++ {
+		keyPair := generateKeyPair(rng)
+		host := "0.0.0.0"
+		dispersalPort := fmt.Sprintf("3%03v", 2*i)
+		retrievalPort := fmt.Sprintf("3%03v", 2*i+1)
+		indexed := &core.IndexedOperatorInfo{
+			Socket:   string(core.MakeOperatorSocket(host, dispersalPort, retrievalPort)),
+			PubkeyG1: keyPair.GetPubKeyG1(),
+			PubkeyG2: keyPair.GetPubKeyG2(),
+		}
+		id := makeOperatorId(i)
+		indexedOperators[id] = indexed
+		privateOperators[id] = &PrivateOperatorInfo{
+			IndexedOperatorInfo: indexed,
+			KeyPair:             keyPair,
+			Host:                host,
+			DispersalPort:       dispersalPort,
+			RetrievalPort:       retrievalPort,
+		}
+	}
+
+	operators := make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo, len(quorums))
+	totals := make(map[core.QuorumID]*core.OperatorInfo, len(quorums))
+	aggKeys := make(map[core.QuorumID]*core.G1Point, len(quorums))
+	for _, quorumID := range quorumIDs {
+		members := append([]int{}, quorums[quorumID]...)
+		sort.Ints(members)
+		operators[quorumID] = make(map[core.OperatorID]*core.OperatorInfo, len(members))
+		total := new(big.Int)
+		var aggKey *core.G1Point
+		for position, index := range members {
+			id := makeOperatorId(index)
+			if _, ok := operators[quorumID][id]; ok {
+				return nil, fmt.Errorf("operator %d is in quorum %d twice", index, quorumID)
+			}
+			info := &core.OperatorInfo{
+				Stake: big.NewInt(stakes[index]),
+				Index: core.OperatorIndex(position),
+			}
+			operators[quorumID][id] = info
+			if privateOperators[id].OperatorInfo == nil {
+				privateOperators[id].OperatorInfo = info
+			}
+			total.Add(total, info.Stake)
+			key := indexedOperators[id].PubkeyG1
+			if aggKey == nil {
+				aggKey = key.Deserialize(key.Serialize())
+			} else {
+				aggKey.Add(key)
+			}
+		}
+		totals[quorumID] = &core.OperatorInfo{
+			Stake: total,
+			Index: core.OperatorIndex(len(members)),
+		}
+		if aggKey != nil {
+			aggKeys[quorumID] = aggKey
+		}
+	}
+
+	operatorState := &core.OperatorState{
+		Operators:   operators,
+		Totals:      totals,
+		BlockNumber: spec.BlockNumber,
+	}
+	return &PrivateOperatorState{
+		OperatorState: operatorState,
+		IndexedOperatorState: &core.IndexedOperatorState{
+			OperatorState:    operatorState,
+			IndexedOperators: indexedOperators,
+			AggKeys:          aggKeys,
+		},
+		PrivateOperators: privateOperators,
+	}, nil
+}
+
+func generateStakes(rng *rand.Rand, spec OperatorSetSpec) ([]int64, error) {
+	maxStake := spec.MaxStake
+	if maxStake == 0 {
+		maxStake = defaultMaxStake
+	}
+	if maxStake < 1 {
+		return nil, fmt.Errorf("the maximum stake %d is less than 1", maxStake)
+	}
+	stakes := make([]int64, spec.NumOperators)
+	switch spec.Distribution {
+	case UniformStakes:
+		for i := range stakes {
+			stakes[i] = 1 + rng.Int63n(maxStake)
+		}
+	case ParetoStakes:
+		shape := spec.ParetoShape
+		if shape == 0 {
+			shape = defaultParetoShape
+		}
+		if shape < 0 {
+			return nil, fmt.Errorf("the Pareto shape %v is negative", shape)
+		}
+		for i := range stakes {
+			// 1 - Float64() is in (0, 1], so that the stake is at least 1
+			stake := math.Pow(1-rng.Float64(), -1/shape)
+			stakes[i] = int64(math.Min(stake, float64(maxStake)))
+		}
+	case CustomStakes:
+		if len(spec.Stakes) != spec.NumOperators {
+			return nil, fmt.Errorf("got %d custom stakes for %d operators", len(spec.Stakes), spec.NumOperators)
+		}
+		copy(stakes, spec.Stakes)
+	default:
+		return nil, fmt.Errorf("unknown stake distribution %d", spec.Distribution)
+	}
+	return stakes, nil
+}
+
+// generateKeyPair generates a BLS key pair from the random source, unlike core.GenRandomBlsKeys
+func generateKeyPair(rng *rand.Rand) *core.KeyPair {
+	for {
+		n := new(big.Int).Rand(rng, fr.Modulus())
+		if n.Sign() != 0 {
+			return core.MakeKeyPair(new(core.PrivateKey).SetBigInt(n))
+		}
+	}
+}
+
+// Sign returns the replies of the operators to a request to sign the message, as the aggregator receives them: the
+// operators reply in the order of their IDs, and all sign except the non-signers, which reply with an error
+func (s *PrivateOperatorState) Sign(message [32]byte, nonSigners ...core.OperatorID) chan core.SignerMessage {
+	ids := make([]core.OperatorID, 0, len(s.PrivateOperators))
+	for id := range s.PrivateOperators {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	skipped := make(map[core.OperatorID]bool, len(nonSigners))
+	for _, id := range nonSigners {
+		skipped[id] = true
+	}
+
+	update := make(chan core.SignerMessage, len(ids))
+	for _, id := range ids {
+		if skipped[id] {
+			update <- core.SignerMessage{Operator: id, Err: errors.New("not signing")}
+			continue
+		}
+		update <- core.SignerMessage{Operator: id, Signature: s.PrivateOperators[id].KeyPair.SignMessage(message)}
+	}
+	return update
+}
+
+// SignBatchHeader returns the hash of the batch header, which the operators sign, and their replies, see Sign
+func (s *PrivateOperatorState) SignBatchHeader(header *core.BatchHeader, nonSigners ...core.OperatorID) ([32]byte, chan core.SignerMessage, error) {
+	hash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return hash, s.Sign(hash, nonSigners...), nil
+}
@@ -140,6 +140,15 @@ func (t *MockTransactor) GetQuorumCount(ctx context.Context, blockNumber uint32)
 	return result.(uint16), args.Error(1)
 }
 
+func (t *MockTransactor) GetQuorumApk(ctx context.Context, quorumID core.QuorumID, blockNumber uint32) (*core.G1Point, error) {
+	args := t.Called()
+	result := args.Get(0)
+	if result == nil {
+		return nil, args.Error(1)
+	}
+	return result.(*core.G1Point), args.Error(1)
+}
+
 func (t *MockTransactor) PubkeyHashToOperator(ctx context.Context, operatorId core.OperatorID) (gethcommon.Address, error) {
 	args := t.Called()
 	result := args.Get(0)
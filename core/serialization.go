@@ -170,6 +170,29 @@ func HashBatchHeader(batchHeader binding.IEigenDAServiceManagerBatchHeader) ([32
 	return headerHash, nil
 }
 
+// HashBatchMetadata returns the hash of the metadata of a confirmed batch that EigenDAServiceManager stores for
+// the batch ID, where the fee is the bytes of a big-endian uint96
+// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDAHasher.sol#L19
+func HashBatchMetadata(batchHeaderHash [32]byte, signatoryRecordHash [32]byte, fee []byte, confirmationBlockNumber uint32) ([32]byte, error) {
+	// The fields are packed like abi.encodePacked(bytes32, bytes32, uint96, uint32)
+	feeInt := new(big.Int).SetBytes(fee)
+	if feeInt.BitLen() > 96 {
+		return [32]byte{}, fmt.Errorf("fee of %d bytes overflows uint96", len(fee))
+	}
+	buf := make([]byte, 0, 32+32+12+4)
+	buf = append(buf, batchHeaderHash[:]...)
+	buf = append(buf, signatoryRecordHash[:]...)
+	buf = append(buf, feeInt.FillBytes(make([]byte, 12))...)
+	buf = binary.BigEndian.AppendUint32(buf, confirmationBlockNumber)
+
+	var res [32]byte
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(buf)
+	copy(res[:], hasher.Sum(nil)[:32])
+
+	return res, nil
+}
+
 // GetBlobHeaderHash returns the hash of the BlobHeader that is used to sign the Blob
 func (h BlobHeader) GetBlobHeaderHash() ([32]byte, error) {
 	headerByte, err := h.Encode()
@@ -21,6 +21,9 @@ const (
 	batchHeaderHash        = "0xa48219ff51a67bf779c6f7858e3bf9760ef10a766e5dc5d461318c8e9d5607b6"
 	encodedBlobHeader      = "0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000a000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000014"
 	blobHeaderHash         = "0x48b3e6540820e2f2c185764e22d438d5ff03551a867299b26cccf57fa2c3f237"
+	signatoryRecordHash    = "0xf60f497b0f816a24c750d818c538f7eb2131a6c3bf487053042914021a671023"
+	// batchMetadataHash is keccak256(abi.encodePacked(batchHeaderHash, signatoryRecordHash, uint96(256), uint32(150)))
+	batchMetadataHash = "0x4c919e7a03e9881c03b55cce7020a6aa8e3bfff5b12849788268534444fb831b"
 )
 
 func TestBatchHeaderEncoding(t *testing.T) {
@@ -129,6 +132,19 @@ func TestSignatoryRecord(t *testing.T) {
 	assert.Equal(t, common.Bytes2Hex(hash[:]), expected)
 }
 
+func TestBatchMetadataHash(t *testing.T) {
+	var headerHash, recordHash [32]byte
+	copy(headerHash[:], hexutil.MustDecode(batchHeaderHash))
+	copy(recordHash[:], hexutil.MustDecode(signatoryRecordHash))
+
+	hash, err := core.HashBatchMetadata(headerHash, recordHash, big.NewInt(256).Bytes(), 150)
+	assert.NoError(t, err)
+	assert.Equal(t, batchMetadataHash, hexutil.Encode(hash[:]))
+
+	_, err = core.HashBatchMetadata(headerHash, recordHash, new(big.Int).Lsh(big.NewInt(1), 96).Bytes(), 150)
+	assert.ErrorContains(t, err, "overflows uint96")
+}
+
 func TestCommitmentMarshaling(t *testing.T) {
 
 	var commitX, commitY fp.Element
@@ -1,9 +1,15 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
 )
 
 // Operators
@@ -40,6 +46,63 @@ type OperatorState struct {
 	BlockNumber uint
 }
 
+// OperatorStateDigests are the digests of the operators of each quorum in an operator state, see OperatorState.Digests
+type OperatorStateDigests map[QuorumID][32]byte
+
+// String formats the digests as quorum:digest pairs sorted by quorum
+func (d OperatorStateDigests) String() string {
+	quorums := make([]QuorumID, 0, len(d))
+	for quorum := range d {
+		quorums = append(quorums, quorum)
+	}
+	sort.Slice(quorums, func(i, j int) bool { return quorums[i] < quorums[j] })
+	pairs := make([]string, len(quorums))
+	for i, quorum := range quorums {
+		digest := d[quorum]
+		pairs[i] = fmt.Sprintf("%d:%x", quorum, digest)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Digests returns the digest of each quorum of the state, which is the keccak256 hash of the ID, stake and index of
+// each operator of the quorum sorted by ID, followed by the totals of the quorum. The digest of a quorum doesn't depend
+// on the other quorums of the state, so that the states fetched for different sets of quorums can be compared.
+func (s *OperatorState) Digests() OperatorStateDigests {
+	digests := make(OperatorStateDigests, len(s.Operators))
+	for quorum, operators := range s.Operators {
+		ids := make([]OperatorID, 0, len(operators))
+		for id := range operators {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+
+		hasher := sha3.NewLegacyKeccak256()
+		writeInfo := func(info *OperatorInfo) {
+			if info == nil {
+				return
+			}
+			stake := (*big.Int)(info.Stake)
+			if stake == nil {
+				stake = new(big.Int)
+			}
+			stakeBytes := stake.Bytes()
+			_ = binary.Write(hasher, binary.BigEndian, uint32(len(stakeBytes)))
+			hasher.Write(stakeBytes)
+			_ = binary.Write(hasher, binary.BigEndian, uint64(info.Index))
+		}
+		for _, id := range ids {
+			hasher.Write(id[:])
+			writeInfo(operators[id])
+		}
+		writeInfo(s.Totals[quorum])
+
+		var digest [32]byte
+		copy(digest[:], hasher.Sum(nil))
+		digests[quorum] = digest
+	}
+	return digests
+}
+
 // IndexedOperatorInfo contains information about an operator which is contained in events from the EigenDA smart contracts. Note that
 // this information does not depend on the quorum.
 type IndexedOperatorInfo struct {
@@ -0,0 +1,35 @@
+package core_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorStateDigests(t *testing.T) {
+	state := dat.GetTotalOperatorState(context.Background(), 0).OperatorState
+	digests := state.Digests()
+	assert.Len(t, digests, 3)
+	assert.Equal(t, digests[0], digests[1])
+
+	// The digest of a quorum doesn't depend on the other quorums of the state
+	quorumState := dat.GetTotalOperatorStateWithQuorums(context.Background(), 0, []core.QuorumID{1}).OperatorState
+	assert.Equal(t, core.OperatorStateDigests{1: digests[1]}, quorumState.Digests())
+
+	// A change of the stake of an operator changes the digest of its quorum only
+	changed := dat.GetTotalOperatorState(context.Background(), 0).OperatorState
+	operators := make(map[core.OperatorID]*core.OperatorInfo)
+	for id, info := range changed.Operators[2] {
+		operators[id] = info
+	}
+	operators[makeOperatorId(0)] = &core.OperatorInfo{Stake: big.NewInt(100), Index: 0}
+	changed.Operators[2] = operators
+	changedDigests := changed.Digests()
+	assert.Equal(t, digests[0], changedDigests[0])
+	assert.NotEqual(t, digests[2], changedDigests[2])
+
+	assert.Regexp(t, "^0:[0-9a-f]{64},1:[0-9a-f]{64},2:[0-9a-f]{64}$", digests.String())
+}
@@ -0,0 +1,69 @@
+package statecache
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/urfave/cli"
+)
+
+const (
+	SizeFlagName              = "state-cache-size"
+	FinalizationDepthFlagName = "state-cache-finalization-depth"
+	UnfinalizedTTLFlagName    = "state-cache-unfinalized-ttl"
+	FinalizedTTLFlagName      = "state-cache-finalized-ttl"
+)
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, SizeFlagName),
+			Usage:    "Maximum number of indexed operator states cached by reference block and quorums, beyond which the least recently used are evicted. 0 disables the cache",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "STATE_CACHE_SIZE"),
+		},
+		cli.UintFlag{
+			Name:     common.PrefixFlag(flagPrefix, FinalizationDepthFlagName),
+			Usage:    "Number of blocks behind the current block from which the cached operator states are finalized, and expire after state-cache-finalized-ttl",
+			Required: false,
+			Value:    64,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "STATE_CACHE_FINALIZATION_DEPTH"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, UnfinalizedTTLFlagName),
+			Usage:    "Time after which the cached operator states of the more recent blocks expire. 0 doesn't cache them",
+			Required: false,
+			Value:    12 * time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "STATE_CACHE_UNFINALIZED_TTL"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, FinalizedTTLFlagName),
+			Usage:    "Time after which the cached operator states of the finalized blocks expire, so that the changes of the sockets of the operators are eventually seen. 0 doesn't expire them",
+			Required: false,
+			Value:    time.Hour,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "STATE_CACHE_FINALIZED_TTL"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		Size:              ctx.GlobalInt(common.PrefixFlag(flagPrefix, SizeFlagName)),
+		FinalizationDepth: ctx.GlobalUint(common.PrefixFlag(flagPrefix, FinalizationDepthFlagName)),
+		UnfinalizedTTL:    ctx.GlobalDuration(common.PrefixFlag(flagPrefix, UnfinalizedTTLFlagName)),
+		FinalizedTTL:      ctx.GlobalDuration(common.PrefixFlag(flagPrefix, FinalizedTTLFlagName)),
+	}
+}
+
+// ValidateCLIFlags checks the size of the cache, and the TTLs of the states if it's enabled
+func ValidateCLIFlags(ctx *cli.Context, flagPrefix string) error {
+	var v validation.Violations
+	config := ReadCLIConfig(ctx, flagPrefix)
+	v.Add(validation.AtLeast(common.PrefixFlag(flagPrefix, SizeFlagName), config.Size, 0))
+	if config.Size > 0 {
+		v.Add(validation.Range(common.PrefixFlag(flagPrefix, UnfinalizedTTLFlagName), config.UnfinalizedTTL, 0, time.Hour))
+		v.Add(validation.Range(common.PrefixFlag(flagPrefix, FinalizedTTLFlagName), config.FinalizedTTL, 0, 24*time.Hour))
+	}
+	return v.Err()
+}
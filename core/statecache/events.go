@@ -0,0 +1,81 @@
+package statecache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common"
+	blsregcoord "github.com/Layr-Labs/eigenda/contracts/bindings/BLSRegistryCoordinatorWithIndices"
+	stakereg "github.com/Layr-Labs/eigenda/contracts/bindings/StakeRegistry"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// eventIDs returns the topics of the events of the contract ABI
+func eventIDs(contractABI string, names ...string) ([]gcommon.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]gcommon.Hash, len(names))
+	for i, name := range names {
+		event, ok := parsed.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("no %s event in the ABI", name)
+		}
+		ids[i] = event.ID
+	}
+	return ids, nil
+}
+
+// WatchEvents subscribes to the registrations, deregistrations and stake updates of the operators, and invalidates
+// the cached states from the block of every event until the context is done. It fails if the RPC doesn't support the
+// subscriptions, e.g. over HTTP, in which case the states of the unfinalized blocks only expire after their TTL.
+func (s *CachedIndexedChainState) WatchEvents(ctx context.Context, client common.EthClient, registryCoordinatorAddr gcommon.Address) error {
+	coordinator, err := blsregcoord.NewContractBLSRegistryCoordinatorWithIndicesCaller(registryCoordinatorAddr, client)
+	if err != nil {
+		return err
+	}
+	stakeRegistryAddr, err := coordinator.StakeRegistry(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to get the address of the stake registry: %w", err)
+	}
+	registrationIDs, err := eventIDs(blsregcoord.ContractBLSRegistryCoordinatorWithIndicesMetaData.ABI, "OperatorRegistered", "OperatorDeregistered")
+	if err != nil {
+		return err
+	}
+	stakeIDs, err := eventIDs(stakereg.ContractStakeRegistryMetaData.ABI, "StakeUpdate")
+	if err != nil {
+		return err
+	}
+
+	logs := make(chan types.Log, 16)
+	sub, err := client.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []gcommon.Address{registryCoordinatorAddr, stakeRegistryAddr},
+		Topics:    [][]gcommon.Hash{append(registrationIDs, stakeIDs...)},
+	}, logs)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to the operator state events: %w", err)
+	}
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// The events removed by a reorg invalidate the states as well
+				s.logger.Debug("Invalidating the cached operator states", "blockNumber", log.BlockNumber, "removed", log.Removed)
+				s.Invalidate(uint(log.BlockNumber))
+			case err := <-sub.Err():
+				s.logger.Error("Subscription to the operator state events failed, the unfinalized states only expire from now on", "err", err)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
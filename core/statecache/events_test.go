@@ -0,0 +1,116 @@
+package statecache_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+	"github.com/ethereum/go-ethereum"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscription is the subscription of the operator state events, which fails once err is sent
+type subscription struct {
+	err          chan error
+	unsubscribed atomic.Bool
+}
+
+func (s *subscription) Unsubscribe() {
+	s.unsubscribed.Store(true)
+}
+
+func (s *subscription) Err() <-chan error {
+	return s.err
+}
+
+// subscribingEthClient serves the address of the stake registry and the subscription of the operator state events
+type subscribingEthClient struct {
+	common.EthClient
+	stakeRegistry gcommon.Address
+	subscribeErr  error
+
+	mu    sync.Mutex
+	query ethereum.FilterQuery
+	logs  chan<- types.Log
+	sub   *subscription
+}
+
+func (c *subscribingEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return gcommon.LeftPadBytes(c.stakeRegistry.Bytes(), 32), nil
+}
+
+func (c *subscribingEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if c.subscribeErr != nil {
+		return nil, c.subscribeErr
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.query, c.logs, c.sub = q, ch, &subscription{err: make(chan error, 1)}
+	return c.sub, nil
+}
+
+func TestWatchEvents(t *testing.T) {
+	cs := newChainState(t, 200)
+	cache, err := statecache.NewCachedIndexedChainState(cs, statecache.Config{
+		Size:              16,
+		FinalizationDepth: 64,
+		UnfinalizedTTL:    time.Hour,
+	}, &commock.Logger{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	coordinator := gcommon.HexToAddress("0x0000000000000000000000000000000000000001")
+	client := &subscribingEthClient{stakeRegistry: gcommon.HexToAddress("0x0000000000000000000000000000000000000002")}
+	require.NoError(t, cache.WatchEvents(ctx, client, coordinator))
+	assert.Equal(t, []gcommon.Address{coordinator, client.stakeRegistry}, client.query.Addresses)
+	assert.Len(t, client.query.Topics[0], 3)
+
+	for _, blockNumber := range []uint{100, 190} {
+		_, err = cache.GetIndexedOperatorState(ctx, blockNumber, []core.QuorumID{0})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, cs.reads)
+
+	// An event invalidates the states from its block, which are read again
+	client.logs <- types.Log{BlockNumber: 180}
+	assert.Eventually(t, func() bool {
+		_, err := cache.GetIndexedOperatorState(ctx, 190, []core.QuorumID{0})
+		return err == nil && cs.reads > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	reads := cs.reads
+	_, err = cache.GetIndexedOperatorState(ctx, 100, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Equal(t, reads, cs.reads)
+
+	// And the watch stops once the subscription fails
+	client.sub.err <- errors.New("connection closed")
+	assert.Eventually(t, client.sub.unsubscribed.Load, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWatchEventsUntilDone(t *testing.T) {
+	cs := newChainState(t, 200)
+	cache, err := statecache.NewCachedIndexedChainState(cs, statecache.Config{Size: 16}, &commock.Logger{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &subscribingEthClient{}
+	require.NoError(t, cache.WatchEvents(ctx, client, gcommon.Address{}))
+	cancel()
+	assert.Eventually(t, client.sub.unsubscribed.Load, 5*time.Second, 10*time.Millisecond)
+
+	// The RPCs without subscriptions fail the watch
+	client = &subscribingEthClient{subscribeErr: errors.New("notifications not supported")}
+	err = cache.WatchEvents(context.Background(), client, gcommon.Address{})
+	assert.ErrorContains(t, err, "failed to subscribe to the operator state events: notifications not supported")
+}
@@ -0,0 +1,145 @@
+package statecache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// Config is the configuration of the cache of the operator states
+type Config struct {
+	// Size is the maximum number of cached states, beyond which the least recently used are evicted. 0 disables the
+	// cache.
+	Size int
+	// FinalizationDepth is the number of blocks behind the current block from which the states are finalized, as
+	// they can't be reorged anymore
+	FinalizationDepth uint
+	// UnfinalizedTTL is how long the states of the more recent blocks are cached. 0 doesn't cache them.
+	UnfinalizedTTL time.Duration
+	// FinalizedTTL is how long the states of the finalized blocks are cached, so that the sockets of the operators
+	// are eventually read again. 0 doesn't expire them.
+	FinalizedTTL time.Duration
+}
+
+type cacheKey struct {
+	blockNumber uint
+	// quorums are the sorted IDs of the quorums of the state
+	quorums string
+}
+
+type cachedState struct {
+	state *core.IndexedOperatorState
+	// expiry is the zero time for the states that don't expire
+	expiry time.Time
+}
+
+type bypassKey struct{}
+
+// WithBypass returns the context whose operator state reads bypass the cache, for the paths that must see the state
+// of the chain as it is, such as the refreshes of the sockets of the unreachable operators
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+func bypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey{}).(bool)
+	return bypass
+}
+
+// CachedIndexedChainState memoizes the indexed operator states by reference block and quorums. The states of the
+// blocks that aren't finalized expire after the unfinalized TTL, the others after the finalized TTL, and the states of
+// the blocks from the one of an event changing the operator state are invalidated once it's observed, see Invalidate
+// and WatchEvents, as the index the states are read from may lag behind the chain. The cached states are shared by
+// the readers, which must not modify them.
+type CachedIndexedChainState struct {
+	core.IndexedChainState
+	config Config
+	cache  *lru.Cache[cacheKey, *cachedState]
+	logger common.Logger
+
+	// generation is incremented by every invalidation, so that the states read before it are not cached after it
+	generation atomic.Uint64
+}
+
+var _ core.IndexedChainState = (*CachedIndexedChainState)(nil)
+
+// NewCachedIndexedChainState returns the chain state with the indexed operator states cached
+func NewCachedIndexedChainState(state core.IndexedChainState, config Config, logger common.Logger) (*CachedIndexedChainState, error) {
+	cache, err := lru.New[cacheKey, *cachedState](config.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the operator state cache: %w", err)
+	}
+	return &CachedIndexedChainState{
+		IndexedChainState: state,
+		config:            config,
+		cache:             cache,
+		logger:            logger,
+	}, nil
+}
+
+func (s *CachedIndexedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	if bypassed(ctx) {
+		return s.IndexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	}
+	key := newCacheKey(blockNumber, quorums)
+	if cached, ok := s.cache.Get(key); ok {
+		if cached.expiry.IsZero() || time.Now().Before(cached.expiry) {
+			return cached.state, nil
+		}
+		s.cache.Remove(key)
+	}
+
+	generation := s.generation.Load()
+	state, err := s.IndexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, err
+	}
+	currentBlock, err := s.GetCurrentBlockNumber()
+	if err != nil {
+		s.logger.Warn("Failed to get the current block, not caching the operator state", "blockNumber", blockNumber, "err", err)
+		return state, nil
+	}
+	cached := &cachedState{state: state}
+	ttl := s.config.FinalizedTTL
+	if blockNumber+s.config.FinalizationDepth > currentBlock {
+		if s.config.UnfinalizedTTL <= 0 {
+			return state, nil
+		}
+		ttl = s.config.UnfinalizedTTL
+	}
+	if ttl > 0 {
+		cached.expiry = time.Now().Add(ttl)
+	}
+	if s.generation.Load() == generation {
+		s.cache.Add(key, cached)
+	}
+	return state, nil
+}
+
+// Invalidate drops the cached states of the block and of the later blocks, once an event changing the operator state
+// is observed at the block
+func (s *CachedIndexedChainState) Invalidate(blockNumber uint) {
+	s.generation.Add(1)
+	for _, key := range s.cache.Keys() {
+		if key.blockNumber >= blockNumber {
+			s.cache.Remove(key)
+		}
+	}
+}
+
+func newCacheKey(blockNumber uint, quorums []core.QuorumID) cacheKey {
+	sorted := slices.Clone(quorums)
+	slices.Sort(sorted)
+	var ids strings.Builder
+	for _, id := range sorted {
+		fmt.Fprintf(&ids, "%d,", id)
+	}
+	return cacheKey{blockNumber: blockNumber, quorums: ids.String()}
+}
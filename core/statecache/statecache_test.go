@@ -0,0 +1,135 @@
+package statecache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingChainState counts the reads of the indexed operator states
+type countingChainState struct {
+	*coremock.ChainDataMock
+	reads int
+}
+
+func (s *countingChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	s.reads++
+	return s.ChainDataMock.GetIndexedOperatorState(ctx, blockNumber, quorums)
+}
+
+func newChainState(t *testing.T, currentBlock uint) *countingChainState {
+	cs, err := coremock.NewChainDataMock(4)
+	assert.NoError(t, err)
+	cs.On("GetCurrentBlockNumber").Return(currentBlock, nil)
+	return &countingChainState{ChainDataMock: cs}
+}
+
+func TestCachedIndexedChainState(t *testing.T) {
+	cs := newChainState(t, 200)
+	cache, err := statecache.NewCachedIndexedChainState(cs, statecache.Config{
+		Size:              2,
+		FinalizationDepth: 64,
+		UnfinalizedTTL:    100 * time.Millisecond,
+	}, &commock.Logger{})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// The states are cached by block and quorums, whatever the order of the quorums
+	state, err := cache.GetIndexedOperatorState(ctx, 100, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	cached, err := cache.GetIndexedOperatorState(ctx, 100, []core.QuorumID{1, 0})
+	assert.NoError(t, err)
+	assert.Same(t, state, cached)
+	assert.Equal(t, 1, cs.reads)
+	_, err = cache.GetIndexedOperatorState(ctx, 100, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cs.reads)
+
+	// The bypass reads the state of the chain
+	_, err = cache.GetIndexedOperatorState(statecache.WithBypass(ctx), 100, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, cs.reads)
+
+	// The states of the unfinalized blocks expire
+	_, err = cache.GetIndexedOperatorState(ctx, 150, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	_, err = cache.GetIndexedOperatorState(ctx, 150, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, cs.reads)
+	time.Sleep(150 * time.Millisecond)
+	_, err = cache.GetIndexedOperatorState(ctx, 150, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, cs.reads)
+
+	// The least recently used states are evicted beyond the size of the cache
+	_, err = cache.GetIndexedOperatorState(ctx, 100, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, cs.reads)
+}
+
+func TestCachedIndexedChainStateInvalidate(t *testing.T) {
+	cs := newChainState(t, 200)
+	cache, err := statecache.NewCachedIndexedChainState(cs, statecache.Config{
+		Size:              16,
+		FinalizationDepth: 64,
+		UnfinalizedTTL:    time.Hour,
+	}, &commock.Logger{})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	for _, blockNumber := range []uint{100, 180, 190} {
+		_, err = cache.GetIndexedOperatorState(ctx, blockNumber, []core.QuorumID{0})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, cs.reads)
+
+	// An event at a block invalidates the states of the block and of the later blocks
+	cache.Invalidate(180)
+	for _, blockNumber := range []uint{100, 180, 190} {
+		_, err = cache.GetIndexedOperatorState(ctx, blockNumber, []core.QuorumID{0})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 5, cs.reads)
+}
+
+func TestCachedIndexedChainStateUnfinalizedNotCached(t *testing.T) {
+	cs := newChainState(t, 200)
+	cache, err := statecache.NewCachedIndexedChainState(cs, statecache.Config{
+		Size:              16,
+		FinalizationDepth: 64,
+	}, &commock.Logger{})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = cache.GetIndexedOperatorState(context.Background(), 200, []core.QuorumID{0})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, cs.reads)
+}
+
+func TestCachedIndexedChainStateFinalizedTTL(t *testing.T) {
+	cs := newChainState(t, 200)
+	cache, err := statecache.NewCachedIndexedChainState(cs, statecache.Config{
+		Size:              16,
+		FinalizationDepth: 64,
+		FinalizedTTL:      100 * time.Millisecond,
+	}, &commock.Logger{})
+	assert.NoError(t, err)
+
+	// The states of the finalized blocks are read again once they expire
+	for i := 0; i < 2; i++ {
+		_, err = cache.GetIndexedOperatorState(context.Background(), 100, []core.QuorumID{0})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, cs.reads)
+	time.Sleep(150 * time.Millisecond)
+	_, err = cache.GetIndexedOperatorState(context.Background(), 100, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cs.reads)
+}
@@ -209,3 +209,33 @@ func TestCoreLibrary(t *testing.T) {
 	}
 
 }
+
+func TestCoreLibraryCappedAssignments(t *testing.T) {
+	// The chunks are assigned as evenly as the caps allow, and the nodes validate the capped assignments
+	uncapped := asn
+	asn = &core.StdAssignmentCoordinator{Caps: core.ChunkCaps{
+		MaxChunksPerOperator: map[core.QuorumID]core.ChunkNumber{0: 1},
+		ActivationBlock:      1,
+	}}
+	defer func() { asn = uncapped }()
+
+	securityParams := []*core.SecurityParam{
+		{
+			QuorumID:           0,
+			AdversaryThreshold: 50,
+			QuorumThreshold:    100,
+		},
+	}
+	for _, operatorCount := range []uint{4, 10, 30} {
+		t.Run(fmt.Sprintf("operatorCount=%v", operatorCount), func(t *testing.T) {
+			blob := makeTestBlob(t, 1000, securityParams)
+
+			cst, err := mock.NewChainDataMock(core.OperatorIndex(operatorCount))
+			assert.NoError(t, err)
+
+			batch, header := prepareBatch(t, cst, blob, 0, 2, 1)
+
+			checkBatch(t, cst, batch, header)
+		})
+	}
+}
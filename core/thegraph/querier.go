@@ -0,0 +1,38 @@
+package thegraph
+
+import (
+	"context"
+	"time"
+)
+
+type retryingQuerier struct {
+	querier GraphQLQuerier
+	retries int
+	backoff time.Duration
+}
+
+// NewRetryingQuerier returns the querier with the failed queries retried up to the number of retries, after a backoff
+// doubling after every retry. The queries that fail because their context is done are not retried.
+func NewRetryingQuerier(querier GraphQLQuerier, retries int, backoff time.Duration) GraphQLQuerier {
+	return &retryingQuerier{
+		querier: querier,
+		retries: retries,
+		backoff: backoff,
+	}
+}
+
+func (q *retryingQuerier) Query(ctx context.Context, query any, variables map[string]any) error {
+	backoff := q.backoff
+	for attempt := 0; ; attempt++ {
+		err := q.querier.Query(ctx, query, variables)
+		if err == nil || attempt >= q.retries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
@@ -2,7 +2,9 @@ package thegraph_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/core"
@@ -151,3 +153,29 @@ func TestIndexedChainState_GetIndexedOperatorInfoByOperatorId(t *testing.T) {
 	assert.Equal(t, "3336192159512049190945679273141887248666932624338963482128432381981287252980", info.PubkeyG1.X.String())
 	assert.Equal(t, "15195175002875833468883745675063986308012687914999552116603423331534089122704", info.PubkeyG1.Y.String())
 }
+
+func TestRetryingQuerier(t *testing.T) {
+	calls := 0
+	querier := thegraph.NewRetryingQuerier(&mockGraphQLQuerier{
+		QueryFn: func(ctx context.Context, q any, variables map[string]any) error {
+			calls++
+			if calls < 3 {
+				return errors.New("subgraph unavailable")
+			}
+			return nil
+		},
+	}, 2, time.Millisecond)
+	assert.NoError(t, querier.Query(context.Background(), nil, nil))
+	assert.Equal(t, 3, calls)
+
+	// The error of the last attempt is returned once the retries are exhausted
+	calls = 0
+	querier = thegraph.NewRetryingQuerier(&mockGraphQLQuerier{
+		QueryFn: func(ctx context.Context, q any, variables map[string]any) error {
+			calls++
+			return errors.New("subgraph unavailable")
+		},
+	}, 2, time.Millisecond)
+	assert.EqualError(t, querier.Query(context.Background(), nil, nil), "subgraph unavailable")
+	assert.Equal(t, 3, calls)
+}
@@ -101,4 +101,8 @@ type Transactor interface {
 
 	// GetQuorumCount returns the number of quorums registered at given block number.
 	GetQuorumCount(ctx context.Context, blockNumber uint32) (uint16, error)
+
+	// GetQuorumApk returns the aggregate public key of the operators registered in the quorum at the given block
+	// number, as the BLS pubkey registry records it.
+	GetQuorumApk(ctx context.Context, quorumID QuorumID, blockNumber uint32) (*G1Point, error)
 }
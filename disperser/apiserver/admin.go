@@ -0,0 +1,54 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
+)
+
+// adminShutdownTimeout bounds the wait for the admin requests in progress on shutdown
+const adminShutdownTimeout = 5 * time.Second
+
+// ServeAdmin serves the admin endpoints of the server on the admin address until the context is done.
+// The admin server is kept off the metrics server, which is usually reachable by the whole network for scraping, and
+// serves TLS if the gRPC server does, so that the admin tokens aren't sent in plaintext.
+func (s *DispersalServer) ServeAdmin(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(ReadOnlyPath, s.readOnly.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	listener, err := net.Listen("tcp", s.config.AdminAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on the admin address: %w", err)
+	}
+	if s.config.TLSConfig != nil {
+		tlsConfig, err := grpcsec.HTTPServerTLSConfig(ctx, s.config.TLSConfig, s.logger)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		server.TLSConfig = tlsConfig
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), adminShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	s.logger.Info("Serving the admin endpoints", "address", listener.Addr().String(), "tls", s.config.TLSConfig != nil)
+	if s.config.TLSConfig != nil {
+		err = server.ServeTLS(listener, "", "")
+	} else {
+		err = server.Serve(listener)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
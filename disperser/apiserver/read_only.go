@@ -0,0 +1,179 @@
+package apiserver
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ReadOnlyPath is the admin endpoint that returns whether the server is in read-only mode on GET and toggles it on PUT
+const ReadOnlyPath = "/admin/read-only"
+
+// DispersalHealthService is the service of the health checks that report NOT_SERVING in read-only mode. The health
+// checks of the whole server keep reporting SERVING, as the reads are still served.
+const DispersalHealthService = "disperser.Disperser.Dispersal"
+
+const (
+	DefaultReadOnlyMessage    = "the disperser is in read-only mode for maintenance and doesn't accept new blobs"
+	DefaultReadOnlyRetryAfter = 5 * time.Minute
+)
+
+// ReadOnlyMode is the read-only mode of the server, used during maintenance. In read-only mode, the dispersals are
+// rejected with Unavailable and the message of the mode, with a hint of when to retry, while the status of the blobs
+// and their retrieval are still served. The mode is set at startup, and toggled at runtime over ReadOnlyPath on the
+// admin server.
+type ReadOnlyMode struct {
+	mu      sync.RWMutex
+	enabled bool
+
+	message    string
+	retryAfter time.Duration
+	// adminTokens are the bearer tokens allowed to toggle the mode, by admin name
+	adminTokens map[string]string
+
+	metrics *disperser.Metrics
+	logger  common.Logger
+}
+
+func NewReadOnlyMode(config disperser.ServerConfig, metrics *disperser.Metrics, logger common.Logger) *ReadOnlyMode {
+	m := &ReadOnlyMode{
+		message:     config.ReadOnlyMessage,
+		retryAfter:  config.ReadOnlyRetryAfter,
+		adminTokens: config.AdminTokens,
+		metrics:     metrics,
+		logger:      logger,
+	}
+	if m.message == "" {
+		m.message = DefaultReadOnlyMessage
+	}
+	if m.retryAfter <= 0 {
+		m.retryAfter = DefaultReadOnlyRetryAfter
+	}
+	if config.ReadOnly {
+		m.Set(true, "source", "flag")
+	} else if metrics != nil {
+		metrics.SetReadOnly(false)
+	}
+	return m
+}
+
+func (m *ReadOnlyMode) Enabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// Set turns the read-only mode on or off, and logs the toggle with the fields identifying who requested it
+func (m *ReadOnlyMode) Set(enabled bool, requester ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	changed := m.enabled != enabled
+	m.enabled = enabled
+	if m.metrics != nil {
+		m.metrics.SetReadOnly(enabled)
+	}
+	msg := "Read-only mode disabled"
+	if enabled {
+		msg = "Read-only mode enabled"
+	}
+	m.logger.Info(msg, append([]any{"changed", changed, "message", m.message, "retryAfter", m.retryAfter}, requester...)...)
+}
+
+// Err is the error the dispersals are rejected with in read-only mode
+func (m *ReadOnlyMode) Err() error {
+	st, err := status.New(codes.Unavailable, m.message).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(m.retryAfter),
+	})
+	if err != nil {
+		return status.Error(codes.Unavailable, m.message)
+	}
+	return st.Err()
+}
+
+// HealthStatus is the serving status of the service for the health checks
+func (m *ReadOnlyMode) HealthStatus(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if service == DispersalHealthService && m.Enabled() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// StreamServerInterceptor rejects the streams of the authenticated dispersals in read-only mode
+func (m *ReadOnlyMode) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.FullMethod == pb.Disperser_DisperseBlobAuthenticated_FullMethodName && m.Enabled() {
+			return m.Err()
+		}
+		return handler(srv, ss)
+	}
+}
+
+// admin returns the name of the admin whose token the request bears, if any. All the tokens are compared so
+// that the time of the check doesn't tell which one is closest.
+func (m *ReadOnlyMode) admin(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", false
+	}
+	admin := ""
+	for name, adminToken := range m.adminTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			admin = name
+		}
+	}
+	return admin, admin != ""
+}
+
+// Handler is the handler of ReadOnlyPath. GET returns whether the server is in read-only mode. PUT turns it on or
+// off from the boolean of the body with an admin token as bearer token, and is forbidden without admin tokens.
+// The requester of a toggle is identified in the logs by the name of its token and its address.
+func (m *ReadOnlyMode) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintln(w, m.Enabled())
+		case http.MethodPut:
+			if len(m.adminTokens) == 0 {
+				http.Error(w, "the read-only mode can't be toggled without admin tokens", http.StatusForbidden)
+				return
+			}
+			admin, ok := m.admin(r)
+			if !ok {
+				m.logger.Warn("Rejected an unauthenticated toggle of the read-only mode", "remoteAddr", r.RemoteAddr, "userAgent", r.UserAgent())
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "invalid admin token", http.StatusUnauthorized)
+				return
+			}
+			body, err := io.ReadAll(io.LimitReader(r.Body, 64))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			enabled, err := strconv.ParseBool(strings.TrimSpace(string(body)))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid read-only mode %q: must be true or false", strings.TrimSpace(string(body))), http.StatusBadRequest)
+				return
+			}
+			m.Set(enabled, "source", "admin", "admin", admin, "remoteAddr", r.RemoteAddr)
+			fmt.Fprintln(w, enabled)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package apiserver_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	logger := &cmock.Logger{}
+	metrics := disperser.NewMetrics("9001", logger)
+	mode := apiserver.NewReadOnlyMode(disperser.ServerConfig{}, metrics, logger)
+	assert.False(t, mode.Enabled())
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.ReadOnly))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, mode.HealthStatus(apiserver.DispersalHealthService))
+
+	mode = apiserver.NewReadOnlyMode(disperser.ServerConfig{ReadOnly: true, ReadOnlyMessage: "migrating the metadata", ReadOnlyRetryAfter: time.Hour}, metrics, logger)
+	assert.True(t, mode.Enabled())
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ReadOnly))
+
+	// The dispersals are rejected with a hint of when to retry
+	st := status.Convert(mode.Err())
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "migrating the metadata", st.Message())
+	if assert.Len(t, st.Details(), 1) {
+		assert.Equal(t, time.Hour, st.Details()[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+	}
+
+	// The server keeps serving the reads
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, mode.HealthStatus(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, mode.HealthStatus(apiserver.DispersalHealthService))
+
+	interceptor := mode.StreamServerInterceptor()
+	handled := false
+	handler := func(srv any, stream grpc.ServerStream) error {
+		handled = true
+		return nil
+	}
+	err := interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: pb.Disperser_DisperseBlobAuthenticated_FullMethodName}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.False(t, handled)
+
+	mode.Set(false, "source", "test")
+	assert.False(t, mode.Enabled())
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.ReadOnly))
+	assert.NoError(t, interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: pb.Disperser_DisperseBlobAuthenticated_FullMethodName}, handler))
+	assert.True(t, handled)
+}
+
+func TestReadOnlyModeHandler(t *testing.T) {
+	logger := &cmock.Logger{}
+	request := func(mode *apiserver.ReadOnlyMode, method, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, apiserver.ReadOnlyPath, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		mode.Handler().ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// The mode can't be toggled without admin tokens
+	mode := apiserver.NewReadOnlyMode(disperser.ServerConfig{}, nil, logger)
+	assert.Equal(t, http.StatusForbidden, request(mode, http.MethodPut, "secret", "true").Code)
+	assert.False(t, mode.Enabled())
+
+	mode = apiserver.NewReadOnlyMode(disperser.ServerConfig{AdminTokens: map[string]string{"alice": "secret", "bob": "other"}}, nil, logger)
+	response := request(mode, http.MethodGet, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "false\n", response.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, request(mode, http.MethodPut, "", "true").Code)
+	assert.Equal(t, http.StatusUnauthorized, request(mode, http.MethodPut, "wrong", "true").Code)
+	assert.False(t, mode.Enabled())
+
+	response = request(mode, http.MethodPut, "secret", "abc")
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Body.String(), `invalid read-only mode "abc": must be true or false`)
+
+	response = request(mode, http.MethodPut, "secret", "true\n")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, mode.Enabled())
+	assert.Equal(t, "true\n", request(mode, http.MethodGet, "", "").Body.String())
+	assert.Equal(t, http.StatusOK, request(mode, http.MethodPut, "other", "false").Code)
+	assert.False(t, mode.Enabled())
+
+	assert.Equal(t, http.StatusMethodNotAllowed, request(mode, http.MethodPost, "secret", "true").Code)
+}
+
+func TestServeAdmin(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+
+	logger := &cmock.Logger{}
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{AdminAddress: address, AdminTokens: map[string]string{"alice": "secret"}}, nil, nil, logger, nil, nil, apiserver.RateConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- server.ServeAdmin(ctx)
+	}()
+
+	url := "http://" + address + apiserver.ReadOnlyPath
+	assert.Eventually(t, func() bool {
+		response, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		response.Body.Close()
+		return response.StatusCode == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader("true"))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	response, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.True(t, server.ReadOnly().Enabled())
+
+	cancel()
+	assert.NoError(t, <-served)
+}
+
+func TestDisperseBlobReadOnly(t *testing.T) {
+	data := make([]byte, 1024)
+	blobStatus, _, key := disperseBlob(t, dispersalServer, data)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, blobStatus)
+
+	dispersalServer.ReadOnly().Set(true, "source", "test")
+	defer dispersalServer.ReadOnly().Set(false, "source", "test")
+	_, err := dispersalServer.DisperseBlob(context.Background(), &pb.DisperseBlobRequest{
+		Data:           data,
+		SecurityParams: []*pb.SecurityParams{{QuorumId: 0, AdversaryThreshold: 80, QuorumThreshold: 100}},
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, apiserver.DefaultReadOnlyMessage, status.Convert(err).Message())
+
+	// The status of the blobs is still served
+	reply, err := dispersalServer.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{RequestId: key})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetStatus())
+}
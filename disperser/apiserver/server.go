@@ -10,12 +10,18 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	healthcheck "github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 var errSystemRateLimit = fmt.Errorf("request ratelimited: system limit")
@@ -38,7 +44,12 @@ type DispersalServer struct {
 	rateConfig  RateConfig
 	ratelimiter common.RateLimiter
 
+	// thresholdMargins are the margins of the config, or the default ones if it has none
+	thresholdMargins core.ThresholdMargins
+
 	metrics *disperser.Metrics
+	// readOnly rejects the dispersals during maintenance
+	readOnly *ReadOnlyMode
 
 	logger common.Logger
 }
@@ -55,16 +66,22 @@ func NewDispersalServer(
 	ratelimiter common.RateLimiter,
 	rateConfig RateConfig,
 ) *DispersalServer {
+	thresholdMargins := core.ThresholdMargins{Default: core.DefaultThresholdMargin}
+	if config.ThresholdMargins != nil {
+		thresholdMargins = *config.ThresholdMargins
+	}
 	return &DispersalServer{
-		config:      config,
-		blobStore:   store,
-		tx:          tx,
-		quorumCount: 0,
-		metrics:     metrics,
-		logger:      logger,
-		ratelimiter: ratelimiter,
-		rateConfig:  rateConfig,
-		mu:          &sync.Mutex{},
+		config:           config,
+		blobStore:        store,
+		tx:               tx,
+		quorumCount:      0,
+		thresholdMargins: thresholdMargins,
+		metrics:          metrics,
+		readOnly:         NewReadOnlyMode(config, metrics, logger),
+		logger:           logger,
+		ratelimiter:      ratelimiter,
+		rateConfig:       rateConfig,
+		mu:               &sync.Mutex{},
 	}
 }
 
@@ -74,6 +91,10 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 	}))
 	defer timer.ObserveDuration()
 
+	if s.readOnly.Enabled() {
+		return nil, s.readOnly.Err()
+	}
+
 	securityParams := req.GetSecurityParams()
 	if len(securityParams) == 0 {
 		return nil, fmt.Errorf("invalid request: security_params must not be empty")
@@ -125,13 +146,13 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 
 	s.logger.Debug("received a new blob request", "origin", origin, "securityParams", securityParams)
 
-	if err := blob.RequestHeader.Validate(); err != nil {
+	if err := blob.RequestHeader.Validate(s.thresholdMargins); err != nil {
 		s.logger.Warn("invalid header", "err", err)
 		for _, param := range securityParams {
 			quorumId := string(uint8(param.GetQuorumId()))
 			s.metrics.HandleFailedRequest(quorumId, blobSize, "DisperseBlob")
 		}
-		return nil, err
+		return nil, securityParamsError(err)
 	}
 
 	if s.ratelimiter != nil {
@@ -160,6 +181,8 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 		}
 		return nil, err
 	}
+	// The blob key links the trace of the request to the traces of the batcher encoding the blob
+	trace.SpanFromContext(ctx).SetAttributes(tracing.BlobKeyKey.String(metadataKey.String()), tracing.BlobSizeKey.Int(blobSize))
 
 	for _, param := range securityParams {
 		quorumId := string(uint8(param.GetQuorumId()))
@@ -242,7 +265,8 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 	}
 
 	s.logger.Debug("metadataKey", "metadataKey", metadataKey.String())
-	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+	// The request of a deduplicated blob reports the status of the blob dispersed in its place
+	metadata, err := disperser.ResolveBlobMetadata(ctx, s.blobStore, metadataKey)
 	if err != nil {
 		return nil, err
 	}
@@ -339,7 +363,12 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 		return nil, err
 	}
 
-	data, err := s.blobStore.GetBlobContent(ctx, blobMetadata.BlobHash)
+	// The content is read into a buffer of the size of the blob, as the whole blob is returned
+	content, err := s.blobStore.GetBlobContent(ctx, blobMetadata.BlobHash)
+	var data []byte
+	if err == nil {
+		data, err = disperser.ReadBlobContent(content, blobMetadata.RequestMetadata.BlobSize)
+	}
 	if err != nil {
 		s.logger.Error("Failed to retrieve blob", "err", err)
 		s.metrics.HandleFailedRequest("", len(data), "RetrieveBlob")
@@ -354,6 +383,50 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 	}, nil
 }
 
+// GetQuorumParams returns the limits of the security params of the requested quorums, all the quorums if none is
+// requested
+func (s *DispersalServer) GetQuorumParams(ctx context.Context, req *pb.QuorumParamsRequest) (*pb.QuorumParamsReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetQuorumParams", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if err := s.updateQuorumCount(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get onchain quorum count: %w", err)
+	}
+	s.mu.Lock()
+	quorumCount := s.quorumCount
+	s.mu.Unlock()
+
+	quorumIDs := req.GetQuorumIds()
+	if len(quorumIDs) == 0 {
+		for quorumID := uint32(0); quorumID < uint32(quorumCount); quorumID++ {
+			quorumIDs = append(quorumIDs, quorumID)
+		}
+	}
+	params := make([]*pb.QuorumParams, len(quorumIDs))
+	for i, quorumID := range quorumIDs {
+		if quorumID >= uint32(quorumCount) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid request: the quorum_id must be in range [0, %d], but found %d", int(quorumCount)-1, quorumID)
+		}
+		params[i] = &pb.QuorumParams{
+			QuorumId:              quorumID,
+			ThresholdMargin:       uint32(s.thresholdMargins.Margin(core.QuorumID(quorumID))),
+			MinAdversaryThreshold: 1,
+			MaxQuorumThreshold:    100,
+		}
+	}
+	return &pb.QuorumParamsReply{
+		QuorumParams: params,
+		MaxBlobSize:  maxBlobSize,
+	}, nil
+}
+
+// ReadOnly is the read-only mode of the server
+func (s *DispersalServer) ReadOnly() *ReadOnlyMode {
+	return s.readOnly
+}
+
 func (s *DispersalServer) Start(ctx context.Context) error {
 	s.logger.Trace("Entering Start function...")
 	defer s.logger.Trace("Exiting Start function...")
@@ -365,13 +438,17 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 		return fmt.Errorf("could not start tcp listener")
 	}
 
+	creds, err := grpcsec.ServerOption(ctx, s.config.TLSConfig, s.logger)
+	if err != nil {
+		return err
+	}
 	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(opt, creds, grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()), grpc.ChainStreamInterceptor(s.readOnly.StreamServerInterceptor()))
 	reflection.Register(gs)
 	pb.RegisterDisperserServer(gs, s)
 
-	// Register Server for Health Checks
-	healthcheck.RegisterHealthServer(gs)
+	// Register Server for Health Checks, which report the dispersals NOT_SERVING in read-only mode
+	healthcheck.RegisterHealthServerWithServiceStatus(gs, s.readOnly.HealthStatus)
 
 	s.logger.Info("port", s.config.GrpcPort, "address", listener.Addr().String(), "GRPC Listening")
 	if err := gs.Serve(listener); err != nil {
@@ -398,6 +475,26 @@ func (s *DispersalServer) updateQuorumCount(ctx context.Context) error {
 	return nil
 }
 
+// securityParamsError returns the gRPC error of invalid security params. The margin errors are InvalidArgument, with
+// the quorum threshold the request needs in their details.
+func securityParamsError(err error) error {
+	var marginErr *core.ThresholdMarginError
+	if !errors.As(err, &marginErr) {
+		return err
+	}
+	st, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field: "security_params.quorum_threshold",
+			Description: fmt.Sprintf("the quorum threshold of quorum %d must be at least %d, the adversary threshold %d plus the margin %d of the quorum",
+				marginErr.QuorumID, int(marginErr.AdversaryThreshold)+int(marginErr.Margin), marginErr.AdversaryThreshold, marginErr.Margin),
+		}},
+	})
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
 func getResponseStatus(status disperser.BlobStatus) pb.BlobStatus {
 	switch status {
 	case disperser.Processing:
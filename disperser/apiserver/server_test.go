@@ -20,6 +20,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -29,7 +30,10 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -105,6 +109,97 @@ func TestDisperseBlobWithInvalidQuorum(t *testing.T) {
 	assert.ErrorContains(t, err, "invalid request: security_params must not contain duplicate quorum_id")
 }
 
+func TestDisperseBlobWithThresholdMargin(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	// Quorum 1 requires a margin of 20 instead of the default 10
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*pb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: 85,
+				QuorumThreshold:    95,
+			},
+			{
+				QuorumId:           1,
+				AdversaryThreshold: 75,
+				QuorumThreshold:    90,
+			},
+		},
+	})
+	assert.ErrorContains(t, err, "invalid request: quorum threshold 90 of quorum 1 must be >= 20 + adversary threshold 75")
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	assert.True(t, ok)
+	assert.Contains(t, badRequest.GetFieldViolations()[0].GetDescription(), "must be at least 95")
+
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*pb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: 85,
+				QuorumThreshold:    95,
+			},
+			{
+				QuorumId:           1,
+				AdversaryThreshold: 75,
+				QuorumThreshold:    95,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+}
+
+func TestGetQuorumParams(t *testing.T) {
+	reply, err := dispersalServer.GetQuorumParams(context.Background(), &pb.QuorumParamsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(512*1024), reply.GetMaxBlobSize())
+	assert.Len(t, reply.GetQuorumParams(), 2)
+	for i, margin := range []uint32{10, 20} {
+		params := reply.GetQuorumParams()[i]
+		assert.Equal(t, uint32(i), params.GetQuorumId())
+		assert.Equal(t, margin, params.GetThresholdMargin())
+		assert.Equal(t, uint32(1), params.GetMinAdversaryThreshold())
+		assert.Equal(t, uint32(100), params.GetMaxQuorumThreshold())
+	}
+
+	reply, err = dispersalServer.GetQuorumParams(context.Background(), &pb.QuorumParamsRequest{QuorumIds: []uint32{1}})
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetQuorumParams(), 1)
+	assert.Equal(t, uint32(20), reply.GetQuorumParams()[0].GetThresholdMargin())
+
+	_, err = dispersalServer.GetQuorumParams(context.Background(), &pb.QuorumParamsRequest{QuorumIds: []uint32{2}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The quorums have the default margin if the config has none
+	logger := &cmock.Logger{}
+	tx := &mock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	server := apiserver.NewDispersalServer(disperser.ServerConfig{}, queue, tx, logger, disperser.NewMetrics("9001", logger), nil, apiserver.RateConfig{})
+	reply, err = server.GetQuorumParams(context.Background(), &pb.QuorumParamsRequest{})
+	assert.NoError(t, err)
+	for _, params := range reply.GetQuorumParams() {
+		assert.Equal(t, uint32(core.DefaultThresholdMargin), params.GetThresholdMargin())
+	}
+}
+
 func TestGetBlobStatus(t *testing.T) {
 	data := make([]byte, 1024)
 	_, err := rand.Read(data)
@@ -353,6 +448,10 @@ func newTestServer(m *testing.M) *apiserver.DispersalServer {
 
 	return apiserver.NewDispersalServer(disperser.ServerConfig{
 		GrpcPort: "51001",
+		ThresholdMargins: &core.ThresholdMargins{
+			Default: core.DefaultThresholdMargin,
+			Quorums: map[core.QuorumID]uint8{1: 20},
+		},
 	}, queue, tx, logger, disperser.NewMetrics("9001", logger), ratelimiter, rateConfig)
 }
 
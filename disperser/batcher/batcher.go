@@ -3,12 +3,14 @@ package batcher
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -50,9 +52,23 @@ type Config struct {
 	SRSOrder                 int
 	NumConnections           int
 	EncodingRequestQueueSize int
+	// DispersalBudget is the time before each batch cut that the encodings of the batch must finish by
+	DispersalBudget time.Duration
 	// BatchSizeMBLimit is the maximum size of a batch in MB
 	BatchSizeMBLimit     uint
 	MaxNumRetriesPerBlob uint
+	// EncodingStallThreshold and BatchStallThreshold are the times the encoding and the batches can go without
+	// progress before their stage is reported stuck. The stalls are not detected if they are 0.
+	EncodingStallThreshold time.Duration
+	BatchStallThreshold    time.Duration
+	// StateConsistencyRetries is the number of times the reference block is picked again when the operator state
+	// changes while the assignments are computed from it
+	StateConsistencyRetries int
+
+	// BlobDeduplication links the blobs dispersed again with the same data and security params within the
+	// DeduplicationWindow after a processing copy to that copy, which is dispersed in their place
+	BlobDeduplication   bool
+	DeduplicationWindow time.Duration
 }
 
 type Batcher struct {
@@ -69,6 +85,7 @@ type Batcher struct {
 	Aggregator            core.SignatureAggregator
 	EncodingStreamer      *EncodingStreamer
 	Metrics               *Metrics
+	Liveness              *LivenessMonitor
 
 	ethClient common.EthClient
 	finalizer Finalizer
@@ -96,14 +113,24 @@ func NewBatcher(
 	)
 	streamerConfig := StreamerConfig{
 		SRSOrder:               config.SRSOrder,
-		EncodingRequestTimeout: config.PullInterval,
+		EncodingRequestTimeout: timeoutConfig.EncodingTimeout,
 		EncodingQueueLimit:     config.EncodingRequestQueueSize,
+		BatchInterval:          config.PullInterval,
+		DispersalBudget:        config.DispersalBudget,
+
+		StateConsistencyRetries: config.StateConsistencyRetries,
+
+		BlobDeduplication:   config.BlobDeduplication,
+		DeduplicationWindow: config.DeduplicationWindow,
 	}
 	encodingWorkerPool := workerpool.New(config.NumConnections)
 	encodingStreamer, err := NewEncodingStreamer(streamerConfig, queue, chainState, encoderClient, assignmentCoordinator, batchTrigger, encodingWorkerPool, logger)
 	if err != nil {
 		return nil, err
 	}
+	liveness := NewLivenessMonitor(config.EncodingStallThreshold, config.BatchStallThreshold, metrics, logger)
+	encodingStreamer.liveness = liveness
+	encodingStreamer.metrics = metrics
 
 	return &Batcher{
 		Config:        config,
@@ -119,6 +146,7 @@ func NewBatcher(
 		Aggregator:            aggregator,
 		EncodingStreamer:      encodingStreamer,
 		Metrics:               metrics,
+		Liveness:              liveness,
 
 		ethClient: ethClient,
 		finalizer: finalizer,
@@ -140,6 +168,7 @@ func (b *Batcher) Start(ctx context.Context) error {
 	}
 	batchTrigger := b.EncodingStreamer.EncodedSizeNotifier
 	b.finalizer.Start(ctx)
+	b.Liveness.Start(ctx)
 
 	go func() {
 		ticker := time.NewTicker(b.PullInterval)
@@ -194,7 +223,7 @@ func (b *Batcher) handleFailure(ctx context.Context, blobMetadatas []*disperser.
 	// Return the error(s)
 	return result.ErrorOrNil()
 }
-func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
+func (b *Batcher) HandleSingleBatch(ctx context.Context) (err error) {
 	log := b.logger
 	// start a timer
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
@@ -202,17 +231,34 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	}))
 	defer timer.ObserveDuration()
 
+	ctx, span := tracing.StartSpan(ctx, "batcher.HandleSingleBatch")
+	defer func() {
+		tracing.EndSpan(span, err)
+		b.Liveness.batchCompleted(err)
+	}()
+
 	stageTimer := time.Now()
+	b.Liveness.enterBatchStage(StageCreateBatch)
 	batch, err := b.EncodingStreamer.CreateBatch()
 	if err != nil {
 		return err
 	}
 	log.Trace("[batcher] CreateBatch took", "duration", time.Since(stageTimer))
+	batchSize := 0
+	for _, metadata := range batch.BlobMetadata {
+		batchSize += int(metadata.RequestMetadata.BlobSize)
+	}
+	span.SetAttributes(tracing.NumBlobsKey.Int(len(batch.BlobMetadata)), tracing.BatchSizeKey.Int(batchSize))
 
 	// Dispatch encoded batch
 	log.Trace("[batcher] Dispatching encoded batch...")
 	stageTimer = time.Now()
-	update := b.Dispatcher.DisperseBatch(ctx, batch.BatchMetadata.State, batch.EncodedBlobs, batch.BatchHeader)
+	b.Liveness.enterBatchStage(StageDisperseBatch)
+	// The chunks are sent to the operators in the background, so the spans of the requests to the operators outlive
+	// the span of the dispersal, and end during the aggregation of their signatures
+	disperseCtx, disperseSpan := tracing.StartSpan(ctx, "batcher.DisperseBatch")
+	update := b.Dispatcher.DisperseBatch(disperseCtx, batch.BatchMetadata.State, batch.EncodedBlobs, batch.BatchHeader)
+	disperseSpan.End()
 	log.Trace("[batcher] DisperseBatch took", "duration", time.Since(stageTimer))
 
 	// Get the batch header hash
@@ -222,6 +268,7 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 		_ = b.handleFailure(ctx, batch.BlobMetadata)
 		return fmt.Errorf("HandleSingleBatch: error getting batch header hash: %w", err)
 	}
+	span.SetAttributes(tracing.BatchHeaderHashKey.String(hex.EncodeToString(headerHash[:])))
 
 	// Aggregate the signatures
 	log.Trace("[batcher] Aggregating signatures...")
@@ -233,7 +280,10 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	}
 
 	stageTimer = time.Now()
+	b.Liveness.enterBatchStage(StageAggregateSignatures)
+	_, aggregateSpan := tracing.StartSpan(ctx, "batcher.AggregateSignatures")
 	aggSig, err := b.Aggregator.AggregateSignatures(batch.BatchMetadata.State, quorumIDs, headerHash, update)
+	tracing.EndSpan(aggregateSpan, err)
 	if err != nil {
 		_ = b.handleFailure(ctx, batch.BlobMetadata)
 		return fmt.Errorf("HandleSingleBatch: error aggregating signatures: %w", err)
@@ -241,6 +291,7 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	log.Trace("[batcher] AggregateSignatures took", "duration", time.Since(stageTimer))
 	b.Metrics.ObserveLatency("AggregateSignatures", float64(time.Since(stageTimer).Milliseconds()))
 	b.Metrics.UpdateAttestation(len(batch.BatchMetadata.State.IndexedOperators), len(aggSig.NonSigners))
+	b.Metrics.UpdateNonSigners(aggSig.QuorumResults)
 
 	passed, numPassed := getBlobQuorumPassStatus(aggSig.QuorumResults, batch.BlobHeaders)
 	// TODO(mooselumph): Determine whether to confirm the batch based on the number of successes
@@ -252,7 +303,10 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	// Confirm the batch
 	log.Trace("[batcher] Confirming batch...")
 	stageTimer = time.Now()
-	txnReceipt, err := b.Confirmer.ConfirmBatch(ctx, batch.BatchHeader, aggSig.QuorumResults, aggSig)
+	b.Liveness.enterBatchStage(StageConfirmBatch)
+	confirmCtx, confirmSpan := tracing.StartSpan(ctx, "batcher.ConfirmBatch")
+	txnReceipt, err := b.Confirmer.ConfirmBatch(confirmCtx, batch.BatchHeader, aggSig.QuorumResults, aggSig)
+	tracing.EndSpan(confirmSpan, err)
 	if err != nil {
 		_ = b.handleFailure(ctx, batch.BlobMetadata)
 		return fmt.Errorf("HandleSingleBatch: error confirming batch: %w", err)
@@ -267,10 +321,12 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 		_ = b.handleFailure(ctx, batch.BlobMetadata)
 		return fmt.Errorf("HandleSingleBatch: error fetching batch ID: %w", err)
 	}
+	span.SetAttributes(tracing.BatchIDKey.Int64(int64(batchID)))
 
 	// Mark the blobs as complete
 	log.Trace("[batcher] Marking blobs as complete...")
 	stageTimer = time.Now()
+	b.Liveness.enterBatchStage(StageUpdateConfirmationInfo)
 	blobsToRetry := make([]*disperser.BlobMetadata, 0)
 	var updateConfirmationInfoErr error
 	for blobIndex, metadata := range batch.BlobMetadata {
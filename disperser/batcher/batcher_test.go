@@ -23,6 +23,7 @@ import (
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -91,6 +92,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher) {
 		BatchSizeMBLimit:         100,
 		SRSOrder:                 3000,
 		MaxNumRetriesPerBlob:     2,
+		BatchStallThreshold:      time.Minute,
 	}
 	timeoutConfig := bat.TimeoutConfig{
 		EncodingTimeout:    10 * time.Second,
@@ -180,6 +182,7 @@ func TestBatcherIterations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, blobKey2, meta2.GetBlobKey())
 	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
+	assert.NotZero(t, testutil.ToFloat64(batcher.Metrics.LastConfirmedBatch))
 
 	res, err := components.encodingStreamer.EncodedBlobstore.GetEncodingResult(meta1.GetBlobKey(), 0)
 	assert.ErrorContains(t, err, "no such key")
@@ -244,6 +247,54 @@ func TestBlobFailures(t *testing.T) {
 	assert.Equal(t, uint(2), meta.NumRetries)
 }
 
+func TestStallDetection(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+
+	components, batcher := makeBatcher(t)
+	components.confirmer.On("ConfirmBatch").Return(nil, fmt.Errorf("error"))
+	ctx := context.Background()
+	requestedAt, _ := queueBlob(t, ctx, &blob, components.blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.Error(t, err)
+
+	stuck := func(stage string) float64 {
+		return testutil.ToFloat64(batcher.Metrics.StageStuck.WithLabelValues(stage))
+	}
+
+	// The batch failed to confirm, but the batcher is within the stall threshold
+	batcher.Liveness.Check(time.Now())
+	assert.Equal(t, 0.0, stuck(bat.StageConfirmBatch))
+	assert.Equal(t, 1.0, testutil.ToFloat64(batcher.Metrics.PendingBlobs))
+
+	// The stall is attributed to the stage the batch failed at
+	now := time.Now().Add(2 * time.Minute)
+	batcher.Liveness.Check(now)
+	assert.Equal(t, 1.0, stuck(bat.StageConfirmBatch))
+	assert.Equal(t, 0.0, stuck(bat.StageCreateBatch))
+	assert.Equal(t, 0.0, stuck(bat.StageAggregateSignatures))
+	// The stall detection of the encoding is disabled
+	assert.Equal(t, 0.0, stuck(bat.StageEncoding))
+	oldestAge := now.Sub(time.Unix(0, int64(requestedAt))).Seconds()
+	assert.InDelta(t, oldestAge, testutil.ToFloat64(batcher.Metrics.OldestPendingBlobAge), 1)
+
+	// The batcher is no longer stuck once it has made progress, even without encoded blobs to batch
+	err = batcher.HandleSingleBatch(ctx)
+	assert.ErrorContains(t, err, "no encoded results")
+	batcher.Liveness.Check(time.Now())
+	assert.Equal(t, 0.0, stuck(bat.StageConfirmBatch))
+	assert.Zero(t, testutil.ToFloat64(batcher.Metrics.LastConfirmedBatch))
+}
+
 func TestRetryTxnReceipt(t *testing.T) {
 	var err error
 	blob := makeTestBlob([]*core.SecurityParam{{
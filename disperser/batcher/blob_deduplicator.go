@@ -0,0 +1,73 @@
+package batcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// blobDeduplicator tracks the content of the processing blobs, so that the blobs dispersed again with the same data
+// and security params within the window after the first copy are linked to it rather than encoded and dispersed
+// again. It's only used by the goroutine requesting the encodings.
+type blobDeduplicator struct {
+	window time.Duration
+	// firstCopies are the metadata of the first copies of the processing blobs, by the keys of their content
+	firstCopies map[string]*disperser.BlobMetadata
+}
+
+func newBlobDeduplicator(window time.Duration) *blobDeduplicator {
+	return &blobDeduplicator{
+		window:      window,
+		firstCopies: make(map[string]*disperser.BlobMetadata),
+	}
+}
+
+// contentKey identifies the blobs with the same data dispersed by the same account with the same security params, so
+// that the blobs of an account are never linked to the dispersal of another one
+func contentKey(blob *core.Blob) string {
+	hasher := sha256.New()
+	fmt.Fprintf(hasher, "%q/%d/", blob.RequestHeader.AccountID, len(blob.Data))
+	hasher.Write(blob.Data)
+	for _, param := range blob.RequestHeader.SecurityParams {
+		fmt.Fprintf(hasher, "/%d/%d/%d/%d", param.QuorumID, param.AdversaryThreshold, param.QuorumThreshold, param.QuorumRate)
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// prune forgets the first copies that are no longer processing, or whose window has passed as the new blobs are
+// requested after it
+func (d *blobDeduplicator) prune(processing []*disperser.BlobMetadata, now time.Time) {
+	keys := make(map[disperser.BlobKey]struct{}, len(processing))
+	for _, metadata := range processing {
+		keys[metadata.GetBlobKey()] = struct{}{}
+	}
+	for key, first := range d.firstCopies {
+		_, ok := keys[first.GetBlobKey()]
+		if !ok || now.Sub(time.Unix(0, int64(first.RequestMetadata.RequestedAt))) > d.window {
+			delete(d.firstCopies, key)
+		}
+	}
+}
+
+// firstCopy returns the first copy of the blob if the blob was requested within the window after it, and records the
+// blob as the first copy of its content otherwise
+func (d *blobDeduplicator) firstCopy(metadata *disperser.BlobMetadata, blob *core.Blob) *disperser.BlobMetadata {
+	key := contentKey(blob)
+	first, ok := d.firstCopies[key]
+	if !ok {
+		d.firstCopies[key] = metadata
+		return nil
+	}
+	if first.GetBlobKey() == metadata.GetBlobKey() {
+		return nil
+	}
+	requestedAt, firstRequestedAt := metadata.RequestMetadata.RequestedAt, first.RequestMetadata.RequestedAt
+	if requestedAt < firstRequestedAt || time.Duration(requestedAt-firstRequestedAt) > d.window {
+		return nil
+	}
+	return first
+}
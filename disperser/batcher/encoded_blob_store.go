@@ -151,6 +151,14 @@ func (e *encodedBlobStore) GetNewAndDeleteStaleEncodingResults(blockNumber uint)
 	return fetched
 }
 
+// GetRequestedCount returns the number of encoding requests that have not returned yet
+func (e *encodedBlobStore) GetRequestedCount() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.requested)
+}
+
 // GetEncodedResultSize returns the total size of all the chunks in the encoded results in bytes
 func (e *encodedBlobStore) GetEncodedResultSize() uint {
 	e.mu.RLock()
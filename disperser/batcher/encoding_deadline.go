@@ -0,0 +1,103 @@
+package batcher
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// The states the encoding requests are counted in
+const (
+	encodingRequestDeferred = "deferred"
+	encodingRequestFailed   = "failed"
+)
+
+// encodeTimeWindow is the number of the last encode times of each size the estimates are made from
+const encodeTimeWindow = 16
+
+// batchSchedule estimates when the next batch is cut from the time of the last cut. The batches are cut at every
+// interval, the ticks missed while a batch is processed being skipped.
+type batchSchedule struct {
+	mu sync.Mutex
+
+	interval time.Duration
+	// budget is the part of the batch window before the cut that the encodings don't get
+	budget  time.Duration
+	lastCut time.Time
+}
+
+func newBatchSchedule(interval, budget time.Duration, now time.Time) *batchSchedule {
+	return &batchSchedule{interval: interval, budget: budget, lastCut: now}
+}
+
+// cut records that a batch was cut at the time
+func (s *batchSchedule) cut(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCut = at
+}
+
+// deadline returns the time the encodings requested now must finish by to be in the next batch, or the zero time
+// if the batches aren't cut on a schedule
+func (s *batchSchedule) deadline(now time.Time) time.Time {
+	if s == nil || s.interval <= 0 {
+		return time.Time{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := s.lastCut.Add(s.interval)
+	if !next.After(now) {
+		next = next.Add((now.Sub(next)/s.interval + 1) * s.interval)
+	}
+	return next.Add(-s.budget)
+}
+
+// windowBudget is the time the encodings get in a whole batch window
+func (s *batchSchedule) windowBudget() time.Duration {
+	return s.interval - s.budget
+}
+
+// encodeTimeModel estimates the time an encoding takes from the last encode times of the encodings of about the
+// same size, the sizes being bucketed by powers of two of the encoded length
+type encodeTimeModel struct {
+	mu sync.Mutex
+
+	samples map[int][]time.Duration
+}
+
+func newEncodeTimeModel() *encodeTimeModel {
+	return &encodeTimeModel{samples: make(map[int][]time.Duration)}
+}
+
+func encodeTimeBucket(params core.EncodingParams) int {
+	return bits.Len64(uint64(params.ChunkLength) * uint64(params.NumChunks))
+}
+
+// observe records the time an encoding with the params took
+func (m *encodeTimeModel) observe(params core.EncodingParams, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bucket := encodeTimeBucket(params)
+	samples := append(m.samples[bucket], duration)
+	if len(samples) > encodeTimeWindow {
+		samples = samples[len(samples)-encodeTimeWindow:]
+	}
+	m.samples[bucket] = samples
+}
+
+// estimate returns the mean of the last encode times of the size of the params, and false if there are none
+func (m *encodeTimeModel) estimate(params core.EncodingParams) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	samples := m.samples[encodeTimeBucket(params)]
+	if len(samples) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, sample := range samples {
+		total += sample
+	}
+	return total / time.Duration(len(samples)), true
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/wealdtech/go-merkletree"
 )
@@ -17,6 +21,10 @@ const encodingInterval = 2 * time.Second
 
 var errNoEncodedResults = errors.New("no encoded results")
 
+// errOperatorStateChanged is returned when the operator state at the reference block keeps changing while the chunks
+// are assigned from it, as the nodes would reject the assignments
+var errOperatorStateChanged = errors.New("operator state changed while the chunks were assigned")
+
 type EncodedSizeNotifier struct {
 	mu sync.Mutex
 
@@ -32,11 +40,25 @@ type StreamerConfig struct {
 
 	// SRSOrder is the order of the SRS used for encoding
 	SRSOrder int
-	// EncodingRequestTimeout is the timeout for each encoding request
+	// EncodingRequestTimeout is the timeout for each encoding request, which bounds the deadlines derived from the
+	// batch cuts
 	EncodingRequestTimeout time.Duration
+	// BatchInterval is the interval the batches are cut at. If it's set, the encoding requests must finish
+	// DispersalBudget before the next cut, and those estimated not to are deferred to the next batch.
+	BatchInterval   time.Duration
+	DispersalBudget time.Duration
 
 	// EncodingQueueLimit is the maximum number of encoding requests that can be queued
 	EncodingQueueLimit int
+
+	// StateConsistencyRetries is the number of times the reference block is picked again when the operator state at
+	// the block changes while the chunks are assigned from it
+	StateConsistencyRetries int
+
+	// BlobDeduplication links the blobs with the same data and security params as a processing blob requested up to
+	// DeduplicationWindow before them to that blob, which is dispersed in their place, rather than encoding them again
+	BlobDeduplication   bool
+	DeduplicationWindow time.Duration
 }
 
 type EncodingStreamer struct {
@@ -56,6 +78,17 @@ type EncodingStreamer struct {
 
 	encodingCtxCancelFuncs []context.CancelFunc
 
+	// schedule and encodeTimes give the deadlines of the encoding requests and estimate whether they can meet them
+	schedule    *batchSchedule
+	encodeTimes *encodeTimeModel
+
+	// deduplicator, if set, finds the blobs dispersed again while their first copy is processing
+	deduplicator *blobDeduplicator
+	// liveness, if set, is notified of the progress of the encoding
+	liveness *LivenessMonitor
+	// metrics, if set, counts the operator state mismatches
+	metrics *Metrics
+
 	logger common.Logger
 }
 
@@ -93,6 +126,16 @@ func NewEncodingStreamer(
 	if config.EncodingQueueLimit <= 0 {
 		return nil, fmt.Errorf("EncodingQueueLimit should be greater than 0")
 	}
+	var deduplicator *blobDeduplicator
+	if config.BlobDeduplication {
+		if config.DeduplicationWindow <= 0 {
+			return nil, fmt.Errorf("DeduplicationWindow should be greater than 0")
+		}
+		deduplicator = newBlobDeduplicator(config.DeduplicationWindow)
+	}
+	if config.BatchInterval > 0 && (config.DispersalBudget < 0 || config.DispersalBudget >= config.BatchInterval) {
+		return nil, fmt.Errorf("DispersalBudget should be at least 0 and less than BatchInterval")
+	}
 	return &EncodingStreamer{
 		StreamerConfig:         config,
 		EncodedBlobstore:       newEncodedBlobStore(logger),
@@ -104,12 +147,17 @@ func NewEncodingStreamer(
 		encoderClient:          encoderClient,
 		assignmentCoordinator:  assignmentCoordinator,
 		encodingCtxCancelFuncs: make([]context.CancelFunc, 0),
+		schedule:               newBatchSchedule(config.BatchInterval, config.DispersalBudget, time.Now()),
+		encodeTimes:            newEncodeTimeModel(),
+		deduplicator:           deduplicator,
 		logger:                 logger,
 	}, nil
 }
 
 func (e *EncodingStreamer) Start(ctx context.Context) error {
 	encoderChan := make(chan EncodingResultOrStatus)
+	// The batches are cut from now on
+	e.schedule.cut(time.Now())
 
 	// goroutine for handling blob encoding responses
 	go func() {
@@ -140,6 +188,7 @@ func (e *EncodingStreamer) Start(ctx context.Context) error {
 			case <-ticker.C:
 				err := e.RequestEncoding(ctx, encoderChan)
 				if err != nil {
+					e.liveness.encodingFailed(err)
 					e.logger.Error("error requesting encoding", "err", err)
 				}
 			}
@@ -175,8 +224,13 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 	if err != nil {
 		return fmt.Errorf("error getting blob metadatas: %w", err)
 	}
+	e.liveness.observePendingBlobs(metadatas)
+	if e.deduplicator != nil {
+		e.deduplicator.prune(metadatas, time.Now())
+	}
 	if len(metadatas) == 0 {
 		e.logger.Info("no new metadatas to encode")
+		e.liveness.encodingRoundCompleted(true)
 		return nil
 	}
 
@@ -202,6 +256,8 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 	metadatas = e.dedupRequests(metadatas, referenceBlockNumber)
 	if len(metadatas) == 0 {
 		e.logger.Info("no new metadatas to encode")
+		// The encoding is idle once the requests of the pending blobs have all returned
+		e.liveness.encodingRoundCompleted(e.EncodedBlobstore.GetRequestedCount() == 0)
 		return nil
 	}
 
@@ -213,6 +269,7 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 	if numMetadatastoProcess <= 0 {
 		// encoding queue is full
 		e.logger.Warn("[RequestEncoding] worker pool queue is full. skipping this round of encoding requests", "waitingQueueSize", waitingQueueSize, "encodingQueueLimit", e.EncodingQueueLimit)
+		e.liveness.encodingRoundCompleted(false)
 		return nil
 	}
 	// only process subset of blobs so it doesn't exceed the EncodingQueueLimit
@@ -221,10 +278,17 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 
 	e.logger.Trace("[encodingstreamer] new metadatas to encode", "numMetadata", len(metadatas), "duration", time.Since(stageTimer))
 
-	batchMetadata, err := e.getBatchMetadata(ctx, metadatas, referenceBlockNumber)
+	batchMetadata, blockNumber, err := e.getBatchMetadata(ctx, metadatas, referenceBlockNumber, e.StateConsistencyRetries)
 	if err != nil {
 		return fmt.Errorf("error getting quorum infos: %w", err)
 	}
+	if blockNumber != referenceBlockNumber {
+		// The blobs encoded at the previous reference block are requested again in the next rounds
+		e.mu.Lock()
+		e.ReferenceBlockNumber = blockNumber
+		e.mu.Unlock()
+		referenceBlockNumber = blockNumber
+	}
 
 	metadataByKey := make(map[disperser.BlobKey]*disperser.BlobMetadata, 0)
 	for _, metadata := range metadatas {
@@ -240,15 +304,48 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 
 	e.logger.Trace("[RequestEncoding] encoding blobs...", "numBlobs", len(blobs), "blockNumber", referenceBlockNumber)
 
+	if e.deduplicator != nil {
+		// The first copies of the blobs requested in the same round are the oldest ones
+		sort.SliceStable(metadatas, func(i, j int) bool {
+			return metadatas[i].RequestMetadata.RequestedAt < metadatas[j].RequestMetadata.RequestedAt
+		})
+	}
 	for i := range metadatas {
 		metadata := metadatas[i]
 
+		if e.deduplicator != nil && e.linkDuplicateBlob(ctx, metadata, blobs[metadata.GetBlobKey()], referenceBlockNumber) {
+			continue
+		}
 		e.RequestEncodingForBlob(ctx, metadata, blobs[metadata.GetBlobKey()], batchMetadata, referenceBlockNumber, encoderChan)
 	}
+	e.liveness.encodingRoundCompleted(false)
 
 	return nil
 }
 
+// linkDuplicateBlob links the blob to its first copy if it's a duplicate of a processing blob whose encoding wasn't
+// requested yet, and returns whether it was linked. The blob is encoded as any other if it can't be linked.
+func (e *EncodingStreamer) linkDuplicateBlob(ctx context.Context, metadata *disperser.BlobMetadata, blob *core.Blob, referenceBlockNumber uint) bool {
+	for _, quorum := range metadata.RequestMetadata.SecurityParams {
+		if e.EncodedBlobstore.HasEncodingRequested(metadata.GetBlobKey(), quorum.QuorumID, referenceBlockNumber) {
+			return false
+		}
+	}
+	first := e.deduplicator.firstCopy(metadata, blob)
+	if first == nil {
+		return false
+	}
+	if err := e.blobStore.MarkBlobDeduplicated(ctx, metadata, first.GetBlobKey()); err != nil {
+		e.logger.Error("[linkDuplicateBlob] error linking the blob to its first copy, encoding it", "blobKey", metadata.GetBlobKey().String(), "firstCopy", first.GetBlobKey().String(), "err", err)
+		return false
+	}
+	e.logger.Info("[linkDuplicateBlob] linked the blob to its first copy", "blobKey", metadata.GetBlobKey().String(), "firstCopy", first.GetBlobKey().String())
+	if e.metrics != nil {
+		e.metrics.IncrementDeduplicatedBlob()
+	}
+	return true
+}
+
 type pendingRequestInfo struct {
 	BlobQuorumInfo *core.BlobQuorumInfo
 	EncodingParams core.EncodingParams
@@ -285,6 +382,18 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 			continue
 		}
 
+		// The caps of the chunks of the operators may break the thresholds of the blob
+		err = core.CheckReconstruction(batchMetadata.State.OperatorState, quorum.QuorumID, quorumInfo.Assignments, quorumInfo.QuantizationFactor, quorum.QuorumThreshold, quorum.AdversaryThreshold)
+		if err != nil {
+			e.logger.Error("[RequestEncodingForBlob] the assignments can't guarantee the reconstruction of the blob", "blobKey", blobKey.String(), "err", err)
+			// Cancel the blob
+			err := e.blobStore.MarkBlobFailed(ctx, blobKey)
+			if err != nil {
+				e.logger.Error("[RequestEncodingForBlob] error marking blob failed", "err", err)
+			}
+			return
+		}
+
 		err = core.ValidateEncodingParams(params, int(blobLength), e.SRSOrder)
 		if err != nil {
 			e.logger.Error("[RequestEncodingForBlob] invalid encoding params", "err", err)
@@ -313,6 +422,18 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 		})
 	}
 
+	// The requests must finish before the next batch is cut to be in it
+	now := time.Now()
+	deadline := now.Add(e.EncodingRequestTimeout)
+	if cutDeadline := e.schedule.deadline(now); !cutDeadline.IsZero() {
+		if e.deferEncoding(blobKey, pending, cutDeadline.Sub(now)) {
+			return
+		}
+		if cutDeadline.Before(deadline) {
+			deadline = cutDeadline
+		}
+	}
+
 	// Execute the encoding requests
 	for ind := range pending {
 
@@ -323,13 +444,21 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 		// This is necessary because an encoding request is dependent on the reference block number
 		// If the reference block number changes, we need to cancel all outstanding encoding requests
 		// and re-request them with the new reference block number
-		encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
+		encodingCtx, cancel := context.WithDeadline(ctx, deadline)
 		e.mu.Lock()
 		e.encodingCtxCancelFuncs = append(e.encodingCtxCancelFuncs, cancel)
 		e.mu.Unlock()
 		e.Pool.Submit(func() {
 			defer cancel()
-			commits, chunks, err := e.encoderClient.EncodeBlob(encodingCtx, blob.Data, res.EncodingParams)
+			// The blob key links the encoding of the blob to the trace of its dispersal request
+			spanCtx, span := tracing.StartSpan(encodingCtx, "batcher.EncodeBlob",
+				tracing.BlobKeyKey.String(blobKey.String()),
+				tracing.BlobSizeKey.Int(len(blob.Data)),
+				tracing.QuorumIDKey.Int(int(res.BlobQuorumInfo.QuorumID)),
+			)
+			start := time.Now()
+			commits, chunks, err := e.encoderClient.EncodeBlob(spanCtx, blob.Data, res.EncodingParams)
+			tracing.EndSpan(span, err)
 			if err != nil {
 				encoderChan <- EncodingResultOrStatus{Err: err, EncodingResult: EncodingResult{
 					BlobMetadata:   metadata,
@@ -337,6 +466,7 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 				}}
 				return
 			}
+			e.encodeTimes.observe(res.EncodingParams, time.Since(start))
 
 			encoderChan <- EncodingResultOrStatus{
 				EncodingResult: EncodingResult{
@@ -356,9 +486,41 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 
 }
 
+// deferEncoding returns whether the encoding requests of the blob are deferred to the next batch as they can't
+// finish within the remaining time. The requests estimated to take longer than a whole batch window are issued
+// anyway, as deferring them wouldn't give them more time. All the requests of the blob are deferred together since
+// the blob is only batched once it's encoded for all its quorums.
+func (e *EncodingStreamer) deferEncoding(blobKey disperser.BlobKey, pending []pendingRequestInfo, remaining time.Duration) bool {
+	if len(pending) == 0 {
+		return false
+	}
+	deferred := remaining <= 0
+	for i := 0; i < len(pending) && !deferred; i++ {
+		estimate, ok := e.encodeTimes.estimate(pending[i].EncodingParams)
+		deferred = ok && estimate > remaining && estimate <= e.schedule.windowBudget()
+	}
+	if !deferred {
+		return false
+	}
+	e.logger.Debug("[RequestEncodingForBlob] deferring the encoding to the next batch", "blobKey", blobKey.String(), "remaining", remaining)
+	if e.metrics != nil {
+		for range pending {
+			e.metrics.IncrementEncodingRequest(encodingRequestDeferred)
+		}
+	}
+	return true
+}
+
 func (e *EncodingStreamer) ProcessEncodedBlobs(ctx context.Context, result EncodingResultOrStatus) error {
 	if result.Err != nil {
 		e.EncodedBlobstore.DeleteEncodingRequest(result.BlobMetadata.GetBlobKey(), result.BlobQuorumInfo.QuorumID)
+		// The requests are canceled when a batch is created, which is not a failure of the encoding
+		if !errors.Is(result.Err, context.Canceled) {
+			e.liveness.encodingFailed(result.Err)
+			if e.metrics != nil {
+				e.metrics.IncrementEncodingRequest(encodingRequestFailed)
+			}
+		}
 		return fmt.Errorf("error encoding blob: %w", result.Err)
 	}
 
@@ -366,6 +528,7 @@ func (e *EncodingStreamer) ProcessEncodedBlobs(ctx context.Context, result Encod
 	if err != nil {
 		return fmt.Errorf("failed to putEncodedBlob: %w", err)
 	}
+	e.liveness.encodingSucceeded()
 
 	encodedSize := e.EncodedBlobstore.GetEncodedResultSize()
 	if e.EncodedSizeNotifier.threshold > 0 && encodedSize >= e.EncodedSizeNotifier.threshold {
@@ -400,6 +563,7 @@ func (e *EncodingStreamer) CreateBatch() (*batch, error) {
 		}
 		e.encodingCtxCancelFuncs = make([]context.CancelFunc, 0)
 	}
+	e.schedule.cut(time.Now())
 
 	// If there were no requested blobs between the last batch and now, there is no need to create a new batch
 	if e.ReferenceBlockNumber == 0 {
@@ -497,7 +661,13 @@ func (e *EncodingStreamer) CreateBatch() (*batch, error) {
 		i++
 	}
 
-	batchMetadata, err := e.getBatchMetadata(context.Background(), metadatas, e.ReferenceBlockNumber)
+	// The blobs are encoded for the reference block, so it can't be picked again if the state changed
+	batchMetadata, _, err := e.getBatchMetadata(context.Background(), metadatas, e.ReferenceBlockNumber, 0)
+	if errors.Is(err, errOperatorStateChanged) {
+		// The blobs are encoded again at a new reference block, and the stale results are dropped
+		e.ReferenceBlockNumber = 0
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -531,7 +701,10 @@ func (e *EncodingStreamer) RemoveEncodedBlob(metadata *disperser.BlobMetadata) {
 	}
 }
 
-func (e *EncodingStreamer) getBatchMetadata(ctx context.Context, metadatas []*disperser.BlobMetadata, blockNumber uint) (*batchMetadata, error) {
+// getBatchMetadata computes the assignments of the quorums of the blobs from the operator state at the block, and
+// returns them with the block they are computed at. The state is fetched again once the assignments are computed, and
+// the current block is picked if it changed, up to the retries times.
+func (e *EncodingStreamer) getBatchMetadata(ctx context.Context, metadatas []*disperser.BlobMetadata, blockNumber uint, retries int) (*batchMetadata, uint, error) {
 	quorums := make(map[core.QuorumID]QuorumInfo, 0)
 	for _, metadata := range metadatas {
 		for _, quorum := range metadata.RequestMetadata.SecurityParams {
@@ -546,26 +719,50 @@ func (e *EncodingStreamer) getBatchMetadata(ctx context.Context, metadatas []*di
 		i++
 	}
 
-	// Get the operator state
-	state, err := e.chainState.GetIndexedOperatorState(ctx, blockNumber, quorumIds)
-	if err != nil {
-		return nil, fmt.Errorf("error getting operator state at block number %d: %w", blockNumber, err)
-	}
+	for retry := 0; ; retry++ {
+		// Get the operator state
+		state, err := e.chainState.GetIndexedOperatorState(ctx, blockNumber, quorumIds)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error getting operator state at block number %d: %w", blockNumber, err)
+		}
+
+		for quorumID := range quorums {
+			assignments, info, err := e.assignmentCoordinator.GetAssignments(state.OperatorState, quorumID, QuantizationFactor)
+			if err != nil {
+				return nil, 0, err
+			}
+			quorums[quorumID] = QuorumInfo{
+				Assignments:        assignments,
+				Info:               info,
+				QuantizationFactor: QuantizationFactor,
+			}
+		}
 
-	for quorumID := range quorums {
-		assignments, info, err := e.assignmentCoordinator.GetAssignments(state.OperatorState, quorumID, QuantizationFactor)
+		// The state is fetched again past the cache, as the state the cache or the indexer returned may not have
+		// caught up with the block
+		current, err := e.chainState.GetIndexedOperatorState(statecache.WithBypass(ctx), blockNumber, quorumIds)
 		if err != nil {
-			return nil, err
+			return nil, 0, fmt.Errorf("error getting operator state at block number %d: %w", blockNumber, err)
 		}
-		quorums[quorumID] = QuorumInfo{
-			Assignments:        assignments,
-			Info:               info,
-			QuantizationFactor: QuantizationFactor,
+		expected, actual := state.Digests(), current.Digests()
+		if maps.Equal(expected, actual) {
+			e.logger.Debug("[getBatchMetadata] assigned chunks", "blockNumber", blockNumber, "operatorStateDigests", expected.String())
+			return &batchMetadata{
+				QuorumInfos: quorums,
+				State:       state,
+			}, blockNumber, nil
 		}
-	}
 
-	return &batchMetadata{
-		QuorumInfos: quorums,
-		State:       state,
-	}, nil
+		if e.metrics != nil {
+			e.metrics.IncrementOperatorStateMismatch()
+		}
+		e.logger.Warn("[getBatchMetadata] operator state changed while the chunks were assigned", "blockNumber", blockNumber, "expected", expected.String(), "actual", actual.String(), "retry", retry)
+		if retry >= retries {
+			return nil, 0, fmt.Errorf("%w at block %d after %d retries", errOperatorStateChanged, blockNumber, retry)
+		}
+		blockNumber, err = e.chainState.GetCurrentBlockNumber()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get current block number: %w", err)
+		}
+	}
 }
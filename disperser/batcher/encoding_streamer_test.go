@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -276,6 +277,105 @@ func TestStreamingEncoding(t *testing.T) {
 	assert.Equal(t, total, uint(131584))
 }
 
+func TestBlobDeduplication(t *testing.T) {
+	config := streamerConfig
+	config.BlobDeduplication = true
+	config.DeduplicationWindow = time.Minute
+	encodingStreamer, c := createEncodingStreamer(t, 10, 1e12, config)
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(10), nil)
+
+	securityParams := []*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}}
+	blob := makeTestBlob(securityParams)
+	ctx := context.Background()
+	requestedAt := uint64(time.Now().UnixNano())
+	firstKey, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt)
+	assert.Nil(t, err)
+	out := make(chan batcher.EncodingResultOrStatus)
+	assert.Nil(t, encodingStreamer.RequestEncoding(ctx, out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+
+	// The same blob is dispersed again while the first copy is processing, with the same security params, with
+	// other ones, by another account, and after the window
+	duplicateKey, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt+uint64(time.Second))
+	assert.Nil(t, err)
+	otherParamsBlob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 70,
+		QuorumThreshold:    100,
+	}})
+	otherParamsBlob.Data = blob.Data
+	otherParamsKey, err := c.blobStore.StoreBlob(ctx, &otherParamsBlob, requestedAt+uint64(time.Second))
+	assert.Nil(t, err)
+	otherAccountBlob := blob
+	otherAccountBlob.RequestHeader.AccountID = blob.RequestHeader.AccountID + "-other"
+	otherAccountKey, err := c.blobStore.StoreBlob(ctx, &otherAccountBlob, requestedAt+uint64(time.Second))
+	assert.Nil(t, err)
+	lateKey, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt+uint64(2*time.Minute))
+	assert.Nil(t, err)
+	assert.Nil(t, encodingStreamer.RequestEncoding(ctx, out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+
+	// Only the duplicate within the window is linked to the first copy, rather than encoded
+	for _, key := range []disperser.BlobKey{otherParamsKey, otherAccountKey, lateKey} {
+		assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(key, 0, 10))
+	}
+	assert.False(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(duplicateKey, 0, 10))
+	duplicate, err := c.blobStore.GetBlobMetadata(ctx, duplicateKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Deduplicated, duplicate.BlobStatus)
+	assert.Equal(t, &firstKey, duplicate.LinkedBlobKey)
+	batch, err := encodingStreamer.CreateBatch()
+	assert.Nil(t, err)
+	assert.Len(t, batch.BlobMetadata, 4)
+
+	// The request of the duplicate reports the confirmation of the first copy
+	first, err := c.blobStore.GetBlobMetadata(ctx, firstKey)
+	assert.Nil(t, err)
+	confirmationInfo := &disperser.ConfirmationInfo{BatchHeaderHash: [32]byte{1}, BlobIndex: 2}
+	_, err = c.blobStore.MarkBlobConfirmed(ctx, first, confirmationInfo)
+	assert.Nil(t, err)
+	resolved, err := disperser.ResolveBlobMetadata(ctx, c.blobStore, duplicateKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Confirmed, resolved.BlobStatus)
+	assert.Equal(t, confirmationInfo, resolved.ConfirmationInfo)
+
+	// The confirmed blob is forgotten, so the next copies are encoded again
+	nextKey, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt+uint64(3*time.Second))
+	assert.Nil(t, err)
+	assert.Nil(t, encodingStreamer.RequestEncoding(ctx, out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(nextKey, 0, 10))
+}
+
+func TestBlobDeduplicationDisabled(t *testing.T) {
+	encodingStreamer, c := createEncodingStreamer(t, 10, 1e12, streamerConfig)
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(10), nil)
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	ctx := context.Background()
+	requestedAt := uint64(time.Now().UnixNano())
+	firstKey, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt)
+	assert.Nil(t, err)
+	secondKey, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt+1)
+	assert.Nil(t, err)
+	out := make(chan batcher.EncodingResultOrStatus)
+	assert.Nil(t, encodingStreamer.RequestEncoding(ctx, out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+	assert.Nil(t, encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(firstKey, 0, 10))
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(secondKey, 0, 10))
+}
+
 func TestEncodingFailure(t *testing.T) {
 	logger := &cmock.Logger{}
 	blobStore := inmem.NewBlobStore()
@@ -668,3 +768,195 @@ func TestGetBatch(t *testing.T) {
 	assert.Contains(t, batch.BlobMetadata, metadata1)
 	assert.Contains(t, batch.BlobMetadata, metadata2)
 }
+
+// changingChainState drops an operator from the state at the changing blocks on every other fetch, as if the operator
+// deregistered while the chunks were assigned from the state
+type changingChainState struct {
+	*coremock.ChainDataMock
+	changing map[uint]bool
+
+	mu      sync.Mutex
+	fetches map[uint]int
+}
+
+func (s *changingChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	state, err := s.ChainDataMock.GetIndexedOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.fetches[blockNumber]++
+	changed := s.changing[blockNumber] && s.fetches[blockNumber]%2 == 0
+	s.mu.Unlock()
+	if changed {
+		operators := make(map[core.OperatorID]*core.OperatorInfo)
+		for id, info := range state.Operators[0] {
+			if info.Index != numOperators-1 {
+				operators[id] = info
+			}
+		}
+		state.Operators[0] = operators
+	}
+	return state, nil
+}
+
+func TestOperatorStateConsistency(t *testing.T) {
+	logger := &cmock.Logger{}
+	blobStore := inmem.NewBlobStore()
+	cst, err := coremock.NewChainDataMock(numOperators)
+	assert.Nil(t, err)
+	chainState := &changingChainState{ChainDataMock: cst, changing: map[uint]bool{10: true, 11: true}, fetches: make(map[uint]int)}
+	enc, err := makeTestEncoder()
+	assert.Nil(t, err)
+	encoderClient := disperser.NewLocalEncoderClient(enc)
+	asgn := &core.StdAssignmentCoordinator{}
+	sizeNotifier := batcher.NewEncodedSizeNotifier(make(chan struct{}, 1), 1e12)
+	config := streamerConfig
+	config.StateConsistencyRetries = 2
+	encodingStreamer, err := batcher.NewEncodingStreamer(config, blobStore, chainState, encoderClient, asgn, sizeNotifier, workerpool.New(5), logger)
+	assert.Nil(t, err)
+	encodingStreamer.ReferenceBlockNumber = 10
+
+	ctx := context.Background()
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	metadataKey, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+
+	// The state changes at the reference block and at the next block, so the blob is encoded at the block after
+	cst.On("GetCurrentBlockNumber").Return(uint(11), nil).Once()
+	cst.On("GetCurrentBlockNumber").Return(uint(12), nil).Once()
+	out := make(chan batcher.EncodingResultOrStatus)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(12), encodingStreamer.ReferenceBlockNumber)
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(metadataKey, core.QuorumID(0), 12))
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	cst.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 2)
+
+	// The batch is created at the block the blob is encoded at
+	batch, err := encodingStreamer.CreateBatch()
+	assert.Nil(t, err)
+	assert.Equal(t, uint(12), batch.BatchHeader.ReferenceBlockNumber)
+
+	// The encoding round is aborted once the retries are exhausted
+	config.StateConsistencyRetries = 0
+	blobStore = inmem.NewBlobStore()
+	encodingStreamer, err = batcher.NewEncodingStreamer(config, blobStore, chainState, encoderClient, asgn, sizeNotifier, workerpool.New(5), logger)
+	assert.Nil(t, err)
+	encodingStreamer.ReferenceBlockNumber = 11
+	metadataKey, err = blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.ErrorContains(t, err, "operator state changed while the chunks were assigned at block 11 after 0 retries")
+	assert.False(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(metadataKey, core.QuorumID(0), 11))
+	cst.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 2)
+}
+
+// slowEncoderClient encodes the blobs after a delay, and records the deadlines of the requests
+type slowEncoderClient struct {
+	disperser.EncoderClient
+	delay time.Duration
+
+	mu        sync.Mutex
+	deadlines []time.Time
+}
+
+func (c *slowEncoderClient) EncodeBlob(ctx context.Context, data []byte, encodingParams core.EncodingParams) (*core.BlobCommitments, []*core.Chunk, error) {
+	deadline, _ := ctx.Deadline()
+	c.mu.Lock()
+	c.deadlines = append(c.deadlines, deadline)
+	c.mu.Unlock()
+	time.Sleep(c.delay)
+	return c.EncoderClient.EncodeBlob(ctx, data, encodingParams)
+}
+
+func (c *slowEncoderClient) lastDeadline() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadlines[len(c.deadlines)-1]
+}
+
+func TestEncodingDeadlines(t *testing.T) {
+	logger := &cmock.Logger{}
+	blobStore := inmem.NewBlobStore()
+	cst, err := coremock.NewChainDataMock(numOperators)
+	assert.Nil(t, err)
+	cst.On("GetCurrentBlockNumber").Return(uint(10), nil)
+	enc, err := makeTestEncoder()
+	assert.Nil(t, err)
+	encoderClient := &slowEncoderClient{EncoderClient: disperser.NewLocalEncoderClient(enc), delay: 500 * time.Millisecond}
+	asgn := &core.StdAssignmentCoordinator{}
+	sizeNotifier := batcher.NewEncodedSizeNotifier(make(chan struct{}, 1), 1e12)
+	config := streamerConfig
+	config.BatchInterval = time.Second
+	config.DispersalBudget = 200 * time.Millisecond
+	// The encodings must finish 800ms after the batches are cut
+	start := time.Now()
+	encodingStreamer, err := batcher.NewEncodingStreamer(config, blobStore, cst, encoderClient, asgn, sizeNotifier, workerpool.New(5), logger)
+	assert.Nil(t, err)
+	created := time.Now()
+	encodingStreamer.ReferenceBlockNumber = 10
+
+	ctx := context.Background()
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+		QuorumThreshold:    100,
+	}})
+	encodedKey, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+
+	// The encode time of the blob is unknown, so it's requested with the deadline of the batch cut
+	out := make(chan batcher.EncodingResultOrStatus)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	assert.WithinRange(t, encoderClient.lastDeadline(), start.Add(800*time.Millisecond), created.Add(800*time.Millisecond))
+
+	// Another blob of the size can't be encoded before the cut, so it's deferred to the next batch
+	deferredKey, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	assert.Len(t, encoderClient.deadlines, 1)
+	assert.False(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(deferredKey, core.QuorumID(0), 10))
+	metadata, err := blobStore.GetBlobMetadata(ctx, deferredKey)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+
+	// It's requested once the batch is cut
+	cut := time.Now()
+	batch, err := encodingStreamer.CreateBatch()
+	assert.Nil(t, err)
+	assert.Len(t, batch.BlobMetadata, 1)
+	err = blobStore.MarkBlobFailed(ctx, encodedKey)
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	assert.True(t, encodingStreamer.EncodedBlobstore.HasEncodingRequested(deferredKey, core.QuorumID(0), 10))
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	assert.WithinRange(t, encoderClient.lastDeadline(), cut.Add(800*time.Millisecond), time.Now().Add(800*time.Millisecond))
+
+	// The timeout of the requests bounds their deadlines
+	config.EncodingRequestTimeout = 100 * time.Millisecond
+	encoderClient.delay = 0
+	blobStore = inmem.NewBlobStore()
+	encodingStreamer, err = batcher.NewEncodingStreamer(config, blobStore, cst, encoderClient, asgn, sizeNotifier, workerpool.New(5), logger)
+	assert.Nil(t, err)
+	encodingStreamer.ReferenceBlockNumber = 10
+	_, err = blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	requested := time.Now()
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	assert.WithinRange(t, encoderClient.lastDeadline(), requested.Add(100*time.Millisecond), time.Now().Add(100*time.Millisecond))
+}
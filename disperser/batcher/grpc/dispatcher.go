@@ -2,19 +2,37 @@ package dispatcher
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/disperser"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Config struct {
 	Timeout time.Duration
+	// UseCompression compresses the chunks sent to the operators with gzip
+	UseCompression bool
+	// CompressionThreshold is the size in bytes of the requests below which the chunks are sent uncompressed
+	CompressionThreshold int
+	// CompressionObserver, if set, is notified of the requests sent with and without compression
+	CompressionObserver common.CompressionObserver
+	// ConnectBackoff is the backoff of the reconnections to the operators, the one of grpc if it isn't set
+	ConnectBackoff *common.ConnectBackoff
+	// Signer signs the requests to store the chunks of the batches, which the operators authenticate the disperser
+	// with. The requests are unsigned if it isn't set.
+	Signer auth.BatchSigner
 }
 
 type dispatcher struct {
@@ -35,13 +53,39 @@ var _ disperser.Dispatcher = (*dispatcher)(nil)
 func (c *dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader) chan core.SignerMessage {
 	update := make(chan core.SignerMessage, len(state.IndexedOperators))
 
+	// The batch is signed once for all the operators
+	signature, err := c.signBatch(header)
+	if err != nil {
+		c.logger.Error("Failed to sign the batch", "err", err)
+		for id := range state.IndexedOperators {
+			update <- core.SignerMessage{Err: err, Operator: id}
+		}
+		return update
+	}
+
 	// Disperse
-	c.sendAllChunks(ctx, state, blobs, header, update)
+	c.sendAllChunks(ctx, state, blobs, header, signature, update)
 
 	return update
 }
 
-func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader, update chan core.SignerMessage) {
+// signBatch returns the signature of the disperser on the batch, or nil if there's no signer
+func (c *dispatcher) signBatch(header *core.BatchHeader) ([]byte, error) {
+	if c.Signer == nil {
+		return nil, nil
+	}
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := c.Signer.SignBatch(batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the batch: %w", err)
+	}
+	return signature, nil
+}
+
+func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, header *core.BatchHeader, signature []byte, update chan core.SignerMessage) {
 	for id, op := range state.IndexedOperators {
 		go func(op core.IndexedOperatorInfo, id core.OperatorID) {
 			blobMessages := make([]*core.BlobMessage, len(blobs))
@@ -49,7 +93,9 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 				blobMessages[i] = blob[id]
 			}
 
-			sig, err := c.sendChunks(ctx, blobMessages, header, &op)
+			ctx, span := tracing.StartSpan(ctx, "batcher.SendChunks", tracing.OperatorKey.String(hex.EncodeToString(id[:])))
+			sig, err := c.sendChunks(ctx, blobMessages, header, signature, &op)
+			tracing.EndSpan(span, err)
 			if err != nil {
 				update <- core.SignerMessage{
 					Err:       err,
@@ -72,13 +118,17 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 	}
 }
 
-func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage, header *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error) {
+func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage, header *core.BatchHeader, signature []byte, op *core.IndexedOperatorInfo) (*core.Signature, error) {
 	// TODO Add secure Grpc
 
-	conn, err := grpc.Dial(
-		core.OperatorSocket(op.Socket).GetDispersalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	options := &common.GRPCClientOptions{
+		UseCompression:       &c.UseCompression,
+		CompressionThreshold: c.CompressionThreshold,
+		CompressionObserver:  c.CompressionObserver,
+		ConnectBackoff:       c.ConnectBackoff,
+		UnaryInterceptors:    []grpc.UnaryClientInterceptor{tracing.UnaryClientInterceptor()},
+	}
+	conn, err := grpc.Dial(core.OperatorSocket(op.Socket).GetDispersalSocket(), options.DialOptions()...)
 	if err != nil {
 		c.logger.Error("Disperser cannot connect to operator dispersal socket", "dispersal_socket", core.OperatorSocket(op.Socket).GetDispersalSocket(), "err", err)
 		return nil, err
@@ -93,13 +143,16 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage,
 	if err != nil {
 		return nil, err
 	}
+	request.DisperserSignature = signature
+
+	trace.SpanFromContext(ctx).SetAttributes(tracing.NumBlobsKey.Int(len(blobs)), tracing.BatchSizeKey.Int(totalSize))
 
 	opt := grpc.MaxCallSendMsgSize(1024 * 1024 * 1024)
 	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "size", totalSize)
 	reply, err := gc.StoreChunks(ctx, request, opt)
 
 	if err != nil {
-		return nil, err
+		return nil, classifyStoreChunksError(err)
 	}
 
 	sigBytes := reply.GetSignature()
@@ -107,6 +160,22 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage,
 	return sig, nil
 }
 
+// classifyStoreChunksError wraps the error of the request to the operator with the core error of the reason the
+// operator did not sign, if it replied too late or with an error of its own
+func classifyStoreChunksError(err error) error {
+	code := status.Code(err)
+	switch {
+	case code == codes.DeadlineExceeded || errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", core.ErrSignerTimeout, err)
+	case code == codes.Unavailable || code == codes.Canceled:
+		// The operator could not be reached, or the request was canceled before it replied
+		return err
+	default:
+		// The node replies with the errors of its validation of the batch as Unknown
+		return fmt.Errorf("%w: %w", core.ErrSignerRefused, err)
+	}
+}
+
 func GetStoreChunksRequest(blobMessages []*core.BlobMessage, header *core.BatchHeader) (*node.StoreChunksRequest, int, error) {
 	blobs := make([]*node.Blob, len(blobMessages))
 	totalSize := 0
@@ -0,0 +1,211 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// Stages of the batcher that a stall is attributed to. The encoding of the pending blobs is a single stage, while
+// a batch goes through the other stages in order.
+const (
+	StageEncoding               = "encoding"
+	StageCreateBatch            = "create_batch"
+	StageDisperseBatch          = "disperse_batch"
+	StageAggregateSignatures    = "aggregate_signatures"
+	StageConfirmBatch           = "confirm_batch"
+	StageUpdateConfirmationInfo = "update_confirmation_info"
+)
+
+const livenessCheckInterval = 5 * time.Second
+
+var batchStages = []string{StageCreateBatch, StageDisperseBatch, StageAggregateSignatures, StageConfirmBatch, StageUpdateConfirmationInfo}
+
+// loopLiveness tracks the progress of a loop of the batcher, and the stage the loop is in or last failed at
+type loopLiveness struct {
+	stages []string
+	// threshold is the time the loop can go without progress before it is stuck. The loop is never stuck if it is 0.
+	threshold    time.Duration
+	lastProgress time.Time
+	stage        string
+	// lastErr is the last error since the last progress, and failedStage the stage it happened at
+	lastErr     error
+	failedStage string
+	// stuckStage is the stage the Metrics report as stuck, if any
+	stuckStage string
+}
+
+// LivenessMonitor detects the stalls of the batcher, and attributes them to the stage the encoding or the batches
+// are stuck at. A loop makes progress when it completes a round of work, or when it has no work to do, so an idle
+// batcher is never stuck.
+type LivenessMonitor struct {
+	mu sync.Mutex
+
+	encoding *loopLiveness
+	batching *loopLiveness
+	// oldestPending is the request time of the oldest blob pending confirmation, or zero if there are none
+	oldestPending time.Time
+
+	metrics *Metrics
+	logger  common.Logger
+}
+
+func NewLivenessMonitor(encodingStallThreshold, batchStallThreshold time.Duration, metrics *Metrics, logger common.Logger) *LivenessMonitor {
+	now := time.Now()
+	return &LivenessMonitor{
+		encoding: &loopLiveness{
+			stages:       []string{StageEncoding},
+			threshold:    encodingStallThreshold,
+			lastProgress: now,
+			stage:        StageEncoding,
+		},
+		batching: &loopLiveness{
+			stages:       batchStages,
+			threshold:    batchStallThreshold,
+			lastProgress: now,
+			stage:        StageCreateBatch,
+		},
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+// Start checks for stalls periodically until the context is done
+func (m *LivenessMonitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(livenessCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				m.Check(now)
+			}
+		}
+	}()
+}
+
+// Check updates the stall metrics as of now, and logs the stages that became stuck since the last check
+func (m *LivenessMonitor) Check(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pendingAge := time.Duration(0)
+	if !m.oldestPending.IsZero() {
+		pendingAge = now.Sub(m.oldestPending)
+	}
+	m.metrics.OldestPendingBlobAge.Set(pendingAge.Seconds())
+
+	for _, loop := range []*loopLiveness{m.encoding, m.batching} {
+		stuckStage := ""
+		sinceProgress := now.Sub(loop.lastProgress)
+		if loop.threshold > 0 && sinceProgress > loop.threshold {
+			// A loop that keeps failing goes through the stages before the one it fails at on every retry, so it is
+			// stuck at the failed stage. Otherwise it hangs at the stage it is in.
+			stuckStage = loop.stage
+			if loop.lastErr != nil {
+				stuckStage = loop.failedStage
+			}
+		}
+		switch {
+		case stuckStage != "" && stuckStage != loop.stuckStage:
+			m.logger.Error("batcher stage is stuck", "stage", stuckStage, "sinceProgress", sinceProgress, "threshold", loop.threshold, "lastErr", loop.lastErr)
+		case stuckStage == "" && loop.stuckStage != "":
+			m.logger.Info("batcher stage is no longer stuck", "stage", loop.stuckStage)
+		}
+		loop.stuckStage = stuckStage
+		for _, stage := range loop.stages {
+			stuck := 0.0
+			if stage == stuckStage {
+				stuck = 1
+			}
+			m.metrics.StageStuck.WithLabelValues(stage).Set(stuck)
+		}
+	}
+}
+
+// observePendingBlobs records the blobs pending confirmation, i.e. in the processing status
+func (m *LivenessMonitor) observePendingBlobs(metadatas []*disperser.BlobMetadata) {
+	if m == nil {
+		return
+	}
+	oldest := time.Time{}
+	for _, metadata := range metadatas {
+		requestedAt := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
+		if oldest.IsZero() || requestedAt.Before(oldest) {
+			oldest = requestedAt
+		}
+	}
+	m.metrics.PendingBlobs.Set(float64(len(metadatas)))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.oldestPending = oldest
+}
+
+// encodingRoundCompleted records a round of encoding requests. The round is progress only if there is nothing left
+// to encode, since the encoding of the requested blobs could still fail.
+func (m *LivenessMonitor) encodingRoundCompleted(idle bool) {
+	if m == nil {
+		return
+	}
+	m.metrics.LastEncodingRound.SetToCurrentTime()
+	if idle {
+		m.progress(m.encoding)
+	}
+}
+
+// encodingSucceeded records a blob encoded for a quorum
+func (m *LivenessMonitor) encodingSucceeded() {
+	if m == nil {
+		return
+	}
+	m.progress(m.encoding)
+}
+
+func (m *LivenessMonitor) encodingFailed(err error) {
+	if m == nil {
+		return
+	}
+	m.fail(m.encoding, err)
+}
+
+// enterBatchStage records the stage the batch being made is at
+func (m *LivenessMonitor) enterBatchStage(stage string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batching.stage = stage
+}
+
+// batchCompleted records the outcome of a batch, which is either confirmed, not made for lack of encoded blobs, or
+// failed at the stage it was at
+func (m *LivenessMonitor) batchCompleted(err error) {
+	switch {
+	case err == nil:
+		m.metrics.LastConfirmedBatch.SetToCurrentTime()
+		m.progress(m.batching)
+	case errors.Is(err, errNoEncodedResults):
+		m.progress(m.batching)
+	default:
+		m.fail(m.batching, err)
+	}
+}
+
+func (m *LivenessMonitor) progress(loop *loopLiveness) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	loop.lastProgress = time.Now()
+	loop.lastErr = nil
+}
+
+func (m *LivenessMonitor) fail(loop *loopLiveness, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	loop.lastErr = err
+	loop.failedStage = loop.stage
+}
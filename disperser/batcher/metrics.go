@@ -6,6 +6,10 @@ import (
 	"net/http"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -26,9 +30,22 @@ type Metrics struct {
 	BatchProcLatency *prometheus.SummaryVec
 	GasUsed          prometheus.Gauge
 	Attestation      *prometheus.GaugeVec
+	NonSigners       *prometheus.CounterVec
+	NodeMessages     *prometheus.CounterVec
 
-	httpPort string
-	logger   common.Logger
+	LastConfirmedBatch   prometheus.Gauge
+	LastEncodingRound    prometheus.Gauge
+	PendingBlobs         prometheus.Gauge
+	OldestPendingBlobAge prometheus.Gauge
+	StageStuck           *prometheus.GaugeVec
+
+	OperatorStateMismatches prometheus.Counter
+	DeduplicatedBlobs       prometheus.Counter
+	EncodingRequests        *prometheus.CounterVec
+
+	httpPort  string
+	profiling profiling.Config
+	logger    common.Logger
 }
 
 func NewMetrics(httpPort string, logger common.Logger) *Metrics {
@@ -36,6 +53,8 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(version.NewCollector(namespace))
+	reg.MustRegister(logging.NewLevelCollector(namespace, logger))
 
 	metrics := &Metrics{
 		Blob: promauto.With(reg).NewCounterVec(
@@ -78,6 +97,80 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 			},
 			[]string{"type"},
 		),
+		NonSigners: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "non_signers_total",
+				Help:      "the number of operators that did not sign the batches, by quorum and by reason",
+			},
+			[]string{"quorum", "reason"},
+		),
+		NodeMessages: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "node_messages_total",
+				Help:      "the number of requests sent to the operators with gzip compression enabled, by whether they were compressed or below the compression threshold",
+			},
+			[]string{"method", "compression"},
+		),
+		LastConfirmedBatch: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "last_confirmed_batch_timestamp_seconds",
+				Help:      "unix time of the last batch confirmed onchain",
+			},
+		),
+		LastEncodingRound: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "last_encoding_round_timestamp_seconds",
+				Help:      "unix time of the last completed round of encoding requests",
+			},
+		),
+		PendingBlobs: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "pending_blobs",
+				Help:      "number of blobs pending confirmation",
+			},
+		),
+		OldestPendingBlobAge: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "oldest_pending_blob_age_seconds",
+				Help:      "time since the oldest blob pending confirmation was requested",
+			},
+		),
+		StageStuck: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "stage_stuck",
+				Help:      "1 if the stage has not progressed within its stall threshold, 0 otherwise",
+			},
+			[]string{"stage"},
+		),
+		OperatorStateMismatches: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operator_state_mismatches_total",
+				Help:      "the number of times the operator state at the reference block changed while the chunks were assigned from it",
+			},
+		),
+		DeduplicatedBlobs: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "deduplicated_blobs_total",
+				Help:      "the number of blobs linked to an identical processing blob rather than encoded again",
+			},
+		),
+		EncodingRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "encoding_requests_total",
+				Help:      "the number of encoding requests deferred to the next batch as they couldn't finish before its cut, counted at every round they are deferred at, and the number that failed",
+			},
+			[]string{"state"},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger,
@@ -90,6 +183,15 @@ func (g *Metrics) UpdateAttestation(operatorCount, nonSignerCount int) {
 	g.Attestation.WithLabelValues("non_signers").Set(float64(nonSignerCount))
 }
 
+// UpdateNonSigners counts the operators that did not sign the batch in each quorum, by the reason they did not sign.
+func (g *Metrics) UpdateNonSigners(quorumResults map[core.QuorumID]*core.QuorumResult) {
+	for quorumID, result := range quorumResults {
+		for _, nonSigner := range result.NonSigners {
+			g.NonSigners.WithLabelValues(fmt.Sprintf("%d", quorumID), string(nonSigner.Reason)).Inc()
+		}
+	}
+}
+
 // UpdateCompletedBlob increments the number and updates size of processed blobs.
 func (g *Metrics) UpdateCompletedBlob(size int, status disperser.BlobStatus) {
 	switch status {
@@ -110,6 +212,19 @@ func (g *Metrics) UpdateCompletedBlob(size int, status disperser.BlobStatus) {
 	g.Blob.WithLabelValues("total", "size").Add(float64(size))
 }
 
+func (g *Metrics) IncrementOperatorStateMismatch() {
+	g.OperatorStateMismatches.Inc()
+}
+
+func (g *Metrics) IncrementDeduplicatedBlob() {
+	g.DeduplicatedBlobs.Inc()
+}
+
+// IncrementEncodingRequest counts an encoding request in the state, either deferred or failed
+func (g *Metrics) IncrementEncodingRequest(state string) {
+	g.EncodingRequests.WithLabelValues(state).Inc()
+}
+
 func (g *Metrics) IncrementBatchCount(size int) {
 	g.Batch.WithLabelValues("number").Inc()
 	g.Batch.WithLabelValues("size").Add(float64(size))
@@ -119,6 +234,19 @@ func (g *Metrics) ObserveLatency(stage string, latencyMs float64) {
 	g.BatchProcLatency.WithLabelValues(stage).Observe(latencyMs)
 }
 
+// ObserveCompression counts the requests sent to the operators compressed and uncompressed
+func (g *Metrics) ObserveCompression(method string, compressed bool) {
+	compression := "uncompressed"
+	if compressed {
+		compression = "compressed"
+	}
+	g.NodeMessages.WithLabelValues(method, compression).Inc()
+}
+
+func (g *Metrics) EnableProfiling(config profiling.Config) {
+	g.profiling = config
+}
+
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
@@ -129,6 +257,8 @@ func (g *Metrics) Start(ctx context.Context) {
 			g.registry,
 			promhttp.HandlerOpts{},
 		))
+		profiling.RegisterHandlers(mux, g.profiling)
+		logging.RegisterHandlers(mux, g.logger)
 		err := http.ListenAndServe(addr, mux)
 		log.Error("prometheus server failed", "err", err)
 	}()
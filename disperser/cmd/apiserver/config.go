@@ -1,10 +1,24 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
@@ -18,6 +32,8 @@ type Config struct {
 	ServerConfig      disperser.ServerConfig
 	LoggerConfig      logging.Config
 	MetricsConfig     disperser.MetricsConfig
+	TracingConfig     tracing.Config
+	ProfilingConfig   profiling.Config
 	RatelimiterConfig ratelimit.Config
 	RateConfig        apiserver.RateConfig
 	EnableRatelimiter bool
@@ -30,26 +46,57 @@ type Config struct {
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return Config{}, err
+	}
 
 	ratelimiterConfig, err := ratelimit.ReadCLIConfig(ctx, flags.FlagPrefix)
 	if err != nil {
 		return Config{}, err
 	}
 
+	tlsConfig, err := grpcsec.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
+
+	blobstoreConfig := blobstore.ReadCLIConfig(ctx, flags.FlagPrefix)
+	blobstoreConfig.BucketName = ctx.GlobalString(flags.S3BucketNameFlag.Name)
+	blobstoreConfig.TableName = ctx.GlobalString(flags.DynamoDBTableNameFlag.Name)
+
+	quorumMargins, err := ParseThresholdMargins(ctx.GlobalStringSlice(flags.QuorumThresholdMarginsFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+
+	adminTokens, err := ParseAdminTokens(ctx.GlobalStringSlice(flags.AdminTokensFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+
 	config := Config{
 		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
-			GrpcPort: ctx.GlobalString(flags.GrpcPortFlag.Name),
-		},
-		BlobstoreConfig: blobstore.Config{
-			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
-			TableName:  ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
+			GrpcPort:  ctx.GlobalString(flags.GrpcPortFlag.Name),
+			TLSConfig: tlsConfig,
+			ThresholdMargins: &core.ThresholdMargins{
+				Default: uint8(ctx.GlobalUint(flags.ThresholdMarginFlag.Name)),
+				Quorums: quorumMargins,
+			},
+			ReadOnly:           ctx.GlobalBool(flags.ReadOnlyFlag.Name),
+			ReadOnlyMessage:    ctx.GlobalString(flags.ReadOnlyMessageFlag.Name),
+			ReadOnlyRetryAfter: ctx.GlobalDuration(flags.ReadOnlyRetryAfterFlag.Name),
+			AdminAddress:       ctx.GlobalString(flags.AdminAddressFlag.Name),
+			AdminTokens:        adminTokens,
 		},
-		LoggerConfig: logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+		BlobstoreConfig: blobstoreConfig,
+		LoggerConfig:    logging.ReadCLIConfig(ctx, flags.FlagPrefix),
 		MetricsConfig: disperser.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
+		TracingConfig:     tracing.ReadCLIConfig(ctx, flags.FlagPrefix),
+		ProfilingConfig:   profiling.ReadCLIConfig(ctx, flags.FlagPrefix),
 		RatelimiterConfig: ratelimiterConfig,
 		RateConfig:        apiserver.ReadCLIConfig(ctx),
 		EnableRatelimiter: ctx.GlobalBool(flags.EnableRatelimiter.Name),
@@ -62,3 +109,121 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	}
 	return config, nil
 }
+
+// validateFlags checks the ports, contracts, read-only mode, admin server and quorum throughputs of the apiserver
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Port(flags.GrpcPortFlag.Name, ctx.GlobalString(flags.GrpcPortFlag.Name)))
+	if ctx.GlobalBool(flags.EnableMetrics.Name) {
+		v.Add(validation.Port(flags.MetricsHTTPPort.Name, ctx.GlobalString(flags.MetricsHTTPPort.Name)))
+	}
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+	if ctx.GlobalBool(flags.EnableRatelimiter.Name) {
+		v.Add(validation.AtLeast(flags.BucketStoreSize.Name, ctx.GlobalInt(flags.BucketStoreSize.Name), 1))
+	}
+	v.Add(grpcsec.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(validation.Range(flags.ReadOnlyRetryAfterFlag.Name, ctx.GlobalDuration(flags.ReadOnlyRetryAfterFlag.Name), time.Second, 24*time.Hour))
+	if ctx.GlobalString(flags.ReadOnlyMessageFlag.Name) == "" {
+		v.Addf("%s: must not be empty", flags.ReadOnlyMessageFlag.Name)
+	}
+	v.Add(validateAdminFlags(ctx))
+
+	// The throughputs are the ones of the registered quorums at the same positions
+	quorums := ctx.GlobalIntSlice(apiserver.RegisteredQuorumFlagName)
+	totals := ctx.GlobalIntSlice(apiserver.TotalUnauthThroughputFlagName)
+	perUsers := ctx.GlobalIntSlice(apiserver.PerUserUnauthThroughputFlagName)
+	if len(totals) != len(quorums) || len(perUsers) != len(quorums) {
+		v.Addf("%s and %s must have a value per quorum of %s: got %d and %d values for %d quorums",
+			apiserver.TotalUnauthThroughputFlagName, apiserver.PerUserUnauthThroughputFlagName, apiserver.RegisteredQuorumFlagName,
+			len(totals), len(perUsers), len(quorums))
+	}
+	for i, quorum := range quorums {
+		v.Add(validation.Range(apiserver.RegisteredQuorumFlagName, quorum, 0, math.MaxUint8))
+		if i < len(totals) && i < len(perUsers) && perUsers[i] > totals[i] {
+			v.Addf("%s: the throughput %d of quorum %d exceeds its total throughput %d of %s",
+				apiserver.PerUserUnauthThroughputFlagName, perUsers[i], quorum, totals[i], apiserver.TotalUnauthThroughputFlagName)
+		}
+	}
+
+	v.Add(validation.Range(flags.ThresholdMarginFlag.Name, ctx.GlobalUint(flags.ThresholdMarginFlag.Name), 0, 99))
+	_, err := ParseThresholdMargins(ctx.GlobalStringSlice(flags.QuorumThresholdMarginsFlag.Name))
+	v.Add(err)
+	return v.Err()
+}
+
+// ParseThresholdMargins parses the quorum:margin pairs of the threshold margin overrides
+// validateAdminFlags checks that the admin tokens are only set along with an admin server, which doesn't receive
+// them in plaintext over the network
+func validateAdminFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	address := ctx.GlobalString(flags.AdminAddressFlag.Name)
+	_, err := ParseAdminTokens(ctx.GlobalStringSlice(flags.AdminTokensFlag.Name))
+	v.Add(err)
+	if address == "" {
+		if len(ctx.GlobalStringSlice(flags.AdminTokensFlag.Name)) > 0 {
+			v.Addf("%s: the admin tokens are set but the admin server is disabled: %s must be set", flags.AdminTokensFlag.Name, flags.AdminAddressFlag.Name)
+		}
+		return v.Err()
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		v.Addf("%s: invalid address %q: %v", flags.AdminAddressFlag.Name, address, err)
+		return v.Err()
+	}
+	v.Add(validation.Port(flags.AdminAddressFlag.Name, port))
+	plaintext := ctx.GlobalString(common.PrefixFlag(flags.FlagPrefix, grpcsec.CertFileFlagName)) == ""
+	if ip := net.ParseIP(host); plaintext && host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		v.Addf("%s: %s isn't a loopback address, so the admin tokens would be sent to it in plaintext: the gRPC server must serve TLS", flags.AdminAddressFlag.Name, address)
+	}
+	return v.Err()
+}
+
+// ParseAdminTokens parses the name:token pairs of the admin tokens
+func ParseAdminTokens(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tokens := make(map[string]string, len(values))
+	for _, value := range values {
+		name, token, ok := strings.Cut(value, ":")
+		// The errors leave the tokens out, so that they aren't logged
+		if !ok {
+			return nil, errors.New("invalid admin token: must be name:token")
+		}
+		if name == "" || token == "" {
+			return nil, fmt.Errorf("invalid admin token of %q: the name and the token must not be empty", name)
+		}
+		if _, ok := tokens[name]; ok {
+			return nil, fmt.Errorf("duplicate admin token of %q", name)
+		}
+		tokens[name] = token
+	}
+	return tokens, nil
+}
+
+func ParseThresholdMargins(values []string) (map[core.QuorumID]uint8, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	margins := make(map[core.QuorumID]uint8, len(values))
+	for _, value := range values {
+		quorum, margin, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid threshold margin %q: must be quorum:margin", value)
+		}
+		quorumID, err := strconv.ParseUint(quorum, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold margin %q: the quorum must be a number below 256", value)
+		}
+		percentage, err := strconv.ParseUint(margin, 10, 8)
+		if err != nil || percentage > 99 {
+			return nil, fmt.Errorf("invalid threshold margin %q: the margin must be a percentage below 100", value)
+		}
+		if _, ok := margins[core.QuorumID(quorumID)]; ok {
+			return nil, fmt.Errorf("duplicate threshold margin of quorum %d", quorumID)
+		}
+		margins[core.QuorumID(quorumID)] = uint8(percentage)
+	}
+	return margins, nil
+}
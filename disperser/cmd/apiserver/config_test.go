@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const apiServerConfigFile = `
+chain:
+  rpc: http://localhost:8545
+  private-key: 0123456789abcdef
+auth:
+  registered-quorum: [0, 1]
+  total-unauth-throughput: [10000000, 20000000]
+  per-user-unauth-throughput: [32000, 64000]
+disperser-server:
+  s3-bucket-name: test-eigenda-blobstore
+  dynamodb-table-name: test-BlobMetadata
+  grpc-port: "32001"
+  rate-bucket-table-name: test-BucketStore
+  bls-operator-state-retriever: "0x0000000000000000000000000000000000000001"
+  eigenda-service-manager: "0x0000000000000000000000000000000000000002"
+  enable-metrics: false
+  enable-ratelimiter: true
+  bucket-sizes: [5s, 1m]
+  bucket-multipliers: [2, 0.5]
+  aws:
+    region: us-east-1
+    endpoint-url: http://localhost:4566
+`
+
+// newConfig returns the config of the apiserver run with apiServerConfigFile and the arguments
+func newConfig(t *testing.T, args ...string) (Config, error) {
+	path := filepath.Join(t.TempDir(), "apiserver.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(apiServerConfigFile), 0600))
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	configfile.Enable(app)
+	var config Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = NewConfig(ctx)
+		return err
+	}
+	err := app.Run(append([]string{"apiserver", "--config", path}, args...))
+	return config, err
+}
+
+func TestConfigFile(t *testing.T) {
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "test-eigenda-blobstore", config.BlobstoreConfig.BucketName)
+	assert.Equal(t, "32001", config.ServerConfig.GrpcPort)
+	assert.Equal(t, "http://localhost:4566", config.AwsClientConfig.EndpointURL)
+	assert.True(t, config.EnableRatelimiter)
+	// The lists of the file replace the defaults of the flags
+	assert.Equal(t, []time.Duration{5 * time.Second, time.Minute}, config.RatelimiterConfig.BucketSizes)
+	assert.Equal(t, []float32{2, 0.5}, config.RatelimiterConfig.Multipliers)
+	assert.Len(t, config.RateConfig.QuorumRateInfos, 2)
+	assert.EqualValues(t, 64000, config.RateConfig.QuorumRateInfos[1].PerUserUnauthThroughput)
+	assert.Equal(t, &core.ThresholdMargins{Default: 10}, config.ServerConfig.ThresholdMargins)
+	assert.False(t, config.ServerConfig.ReadOnly)
+	assert.Equal(t, apiserver.DefaultReadOnlyMessage, config.ServerConfig.ReadOnlyMessage)
+	assert.Equal(t, apiserver.DefaultReadOnlyRetryAfter, config.ServerConfig.ReadOnlyRetryAfter)
+
+	config, err = newConfig(t, "--disperser-server.read-only", "--disperser-server.read-only-retry-after", "30m",
+		"--disperser-server.admin-address", "127.0.0.1:9102", "--disperser-server.admin-tokens", "alice:secret", "--disperser-server.admin-tokens", "bob:other")
+	assert.NoError(t, err)
+	assert.True(t, config.ServerConfig.ReadOnly)
+	assert.Equal(t, 30*time.Minute, config.ServerConfig.ReadOnlyRetryAfter)
+	assert.Equal(t, "127.0.0.1:9102", config.ServerConfig.AdminAddress)
+	assert.Equal(t, map[string]string{"alice": "secret", "bob": "other"}, config.ServerConfig.AdminTokens)
+	_, err = newConfig(t, "--disperser-server.read-only-retry-after", "0s", "--disperser-server.read-only-message", "")
+	assert.ErrorContains(t, err, "disperser-server.read-only-retry-after: 0s is not between 1s and 24h0m0s")
+	assert.ErrorContains(t, err, "disperser-server.read-only-message: must not be empty")
+
+	// The admin tokens need an admin server, which doesn't receive them in plaintext over the network
+	_, err = newConfig(t, "--disperser-server.admin-tokens", "alice:secret")
+	assert.ErrorContains(t, err, "disperser-server.admin-tokens: the admin tokens are set but the admin server is disabled")
+	_, err = newConfig(t, "--disperser-server.admin-address", "0.0.0.0:9102")
+	assert.ErrorContains(t, err, "disperser-server.admin-address: 0.0.0.0:9102 isn't a loopback address")
+	_, err = newConfig(t, "--disperser-server.admin-address", "localhost:9102", "--disperser-server.admin-tokens", "alice:")
+	assert.ErrorContains(t, err, `invalid admin token of "alice": the name and the token must not be empty`)
+}
+
+func TestThresholdMargins(t *testing.T) {
+	config, err := newConfig(t,
+		"--disperser-server.threshold-margin", "0",
+		"--disperser-server.quorum-threshold-margins", "1:20",
+	)
+	assert.NoError(t, err)
+	margins := config.ServerConfig.ThresholdMargins
+	assert.Equal(t, uint8(0), margins.Margin(0))
+	assert.Equal(t, uint8(20), margins.Margin(1))
+
+	_, err = newConfig(t,
+		"--disperser-server.threshold-margin", "100",
+		"--disperser-server.quorum-threshold-margins", "1:20",
+		"--disperser-server.quorum-threshold-margins", "1:30",
+	)
+	assert.ErrorContains(t, err, "invalid configuration (2 errors)")
+	assert.ErrorContains(t, err, "disperser-server.threshold-margin: 100 is not between 0 and 99")
+	assert.ErrorContains(t, err, "duplicate threshold margin of quorum 1")
+
+	for _, value := range []string{"1", "256:10", "1:100", "1:-1"} {
+		_, err := ParseThresholdMargins([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestInvalidFlags(t *testing.T) {
+	_, err := newConfig(t,
+		"--auth.registered-quorum", "0", "--auth.registered-quorum", "256",
+		"--auth.per-user-unauth-throughput", "32000",
+		"--disperser-server.bls-operator-state-retriever", "0xabc",
+	)
+
+	// The quorums without a throughput would otherwise fail deep in the reading of the rate config
+	assert.ErrorContains(t, err, "invalid configuration (3 errors)")
+	assert.ErrorContains(t, err, `disperser-server.bls-operator-state-retriever: "0xabc" is not a 0x-prefixed hex address of 20 bytes`)
+	assert.ErrorContains(t, err, "got 2 and 1 values for 2 quorums")
+	assert.ErrorContains(t, err, "auth.registered-quorum: 256 is not between 0 and 255")
+}
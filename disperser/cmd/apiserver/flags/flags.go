@@ -3,10 +3,15 @@ package flags
 import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/urfave/cli"
 )
 
@@ -79,6 +84,51 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_STORE_SIZE"),
 		Required: false,
 	}
+	ThresholdMarginFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "threshold-margin"),
+		Usage:    "min percentage points by which the quorum threshold of a dispersal must exceed its adversary threshold, in the quorums without an override",
+		Value:    10,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "THRESHOLD_MARGIN"),
+		Required: false,
+	}
+	QuorumThresholdMarginsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum-threshold-margins"),
+		Usage:    "overrides of the threshold margin of quorums, as quorum:margin pairs, e.g. 1:20",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUORUM_THRESHOLD_MARGINS"),
+		Required: false,
+	}
+	ReadOnlyFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "read-only"),
+		Usage:    "start in read-only mode, where the dispersals are rejected with Unavailable while the status and the retrieval of the blobs are still served, e.g. during maintenance. The mode is toggled at runtime by a PUT of true or false to /admin/read-only on the admin server",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "READ_ONLY"),
+		Required: false,
+	}
+	ReadOnlyMessageFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "read-only-message"),
+		Usage:    "message of the errors the dispersals are rejected with in read-only mode",
+		Value:    apiserver.DefaultReadOnlyMessage,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "READ_ONLY_MESSAGE"),
+		Required: false,
+	}
+	ReadOnlyRetryAfterFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "read-only-retry-after"),
+		Usage:    "delay after which the clients are told to retry the dispersals rejected in read-only mode",
+		Value:    apiserver.DefaultReadOnlyRetryAfter,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "READ_ONLY_RETRY_AFTER"),
+		Required: false,
+	}
+	AdminAddressFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-address"),
+		Usage:    "host:port of the admin server, which serves the admin endpoints such as the toggle of the read-only mode with the TLS configuration of the gRPC server. It must be a loopback address if the gRPC server doesn't serve TLS. The admin server is disabled if empty",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_ADDRESS"),
+		Required: false,
+	}
+	AdminTokensFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-tokens"),
+		Usage:    "bearer tokens of the admin endpoints that change the state of the server, as name:token pairs, the name identifying the admin in the logs. These endpoints are forbidden if there are none",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_TOKENS"),
+		Required: false,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -95,6 +145,13 @@ var optionalFlags = []cli.Flag{
 	EnableMetrics,
 	EnableRatelimiter,
 	BucketStoreSize,
+	ThresholdMarginFlag,
+	QuorumThresholdMarginsFlag,
+	ReadOnlyFlag,
+	ReadOnlyMessageFlag,
+	ReadOnlyRetryAfterFlag,
+	AdminAddressFlag,
+	AdminTokensFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -104,7 +161,12 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, profiling.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, tracing.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpcsec.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, apiserver.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, configfile.CLIFlag(envVarPrefix))
 }
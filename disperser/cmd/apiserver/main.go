@@ -8,32 +8,32 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
-	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
 	"github.com/urfave/cli"
 )
 
-var (
-	// version is the version of the binary.
-	version   string
-	gitCommit string
-	gitDate   string
-)
-
 func main() {
 	app := cli.NewApp()
 	app.Flags = flags.Flags
-	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	configfile.Enable(app)
+	version.Configure(app)
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return NewConfig(ctx)
+	})
 	app.Name = "disperser"
 	app.Usage = "EigenDA Disperser Server"
 	app.Description = "Service for accepting blobs for dispersal"
@@ -43,8 +43,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RunDisperserServer(ctx *cli.Context) error {
@@ -57,6 +55,29 @@ func RunDisperserServer(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	logging.CycleLevelOnSignal(logger)
+
+	shutdownTracing, err := tracing.Start(context.Background(), config.TracingConfig, "disperser-apiserver")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush the traces", "err", err)
+		}
+	}()
+
+	logger.Info("Starting disperser", version.LogFields()...)
+	profiling.PublishBuildInfo()
+	profiling.Publish("config", map[string]any{
+		"grpc_port":          config.ServerConfig.GrpcPort,
+		"s3_bucket_name":     config.BlobstoreConfig.BucketName,
+		"dynamodb_table":     config.BlobstoreConfig.TableName,
+		"enable_ratelimiter": config.EnableRatelimiter,
+	})
+	if err := profiling.Start(context.Background(), config.ProfilingConfig, logger); err != nil {
+		return err
+	}
 
 	client, err := geth.NewClient(config.EthClientConfig, logger)
 	if err != nil {
@@ -77,20 +98,15 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
 
-	s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
-	if err != nil {
-		return err
-	}
-
-	dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
+	blobStore, closeBlobStore, err := blobstore.NewBlobStore(context.Background(), config.BlobstoreConfig, config.AwsClientConfig, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second, logger)
 	if err != nil {
 		return err
 	}
-
-	bucketName := config.BlobstoreConfig.BucketName
-	logger.Info("Creating blob store", "bucket", bucketName)
-	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
-	blobStore := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	defer func() {
+		if err := closeBlobStore(); err != nil {
+			logger.Error("Failed to close the blob store", "err", err)
+		}
+	}()
 
 	var ratelimiter common.RateLimiter
 	if config.EnableRatelimiter {
@@ -114,7 +130,16 @@ func RunDisperserServer(ctx *cli.Context) error {
 
 	// TODO: create a separate metrics for batcher
 	metrics := disperser.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	metrics.EnableProfiling(config.ProfilingConfig)
 	server := apiserver.NewDispersalServer(config.ServerConfig, blobStore, transactor, logger, metrics, ratelimiter, config.RateConfig)
+	// The read-only mode is toggled on the admin server
+	if config.ServerConfig.AdminAddress != "" {
+		go func() {
+			if err := server.ServeAdmin(context.Background()); err != nil {
+				logger.Error("Admin server failed", "err", err)
+			}
+		}()
+	}
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
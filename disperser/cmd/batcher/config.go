@@ -1,10 +1,19 @@
 package main
 
 import (
+	"net"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
@@ -20,36 +29,73 @@ type Config struct {
 	AwsClientConfig aws.ClientConfig
 	EncoderConfig   encoding.EncoderConfig
 	LoggerConfig    logging.Config
+	TracingConfig   tracing.Config
+	ProfilingConfig profiling.Config
 	MetricsConfig   batcher.MetricsConfig
 	IndexerConfig   indexer.Config
 	GraphUrl        string
 	UseGraph        bool
+	// StateCacheConfig is the cache of the operator states, which is disabled if its size is 0
+	StateCacheConfig statecache.Config
+	// APKCheckInterval is the interval of the checks of the cached aggregate public keys of the quorums against the
+	// onchain ones, or 0 if they aren't checked
+	APKCheckInterval time.Duration
 
 	IndexerDataDir string
 
+	NodeCompression          bool
+	NodeCompressionThreshold int
+	NodeConnectBackoff       common.ConnectBackoff
+	// ChunkCaps caps the chunks assigned to the operators, which must be the same as the ones of the nodes
+	ChunkCaps core.ChunkCaps
+	// DispersalSigningKey is the key the requests to store chunks are signed with
+	DispersalSigningKey string
+
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 }
 
-func NewConfig(ctx *cli.Context) Config {
+// The bounds of the intervals of the loops of the batcher and of the timeouts of its requests
+const (
+	minInterval = 100 * time.Millisecond
+	maxInterval = time.Hour
+	minTimeout  = 100 * time.Millisecond
+	maxTimeout  = time.Hour
+)
+
+func NewConfig(ctx *cli.Context) (Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return Config{}, err
+	}
+
+	blobstoreConfig := blobstore.ReadCLIConfig(ctx, flags.FlagPrefix)
+	blobstoreConfig.BucketName = ctx.GlobalString(flags.S3BucketNameFlag.Name)
+	blobstoreConfig.TableName = ctx.GlobalString(flags.DynamoDBTableNameFlag.Name)
+
 	config := Config{
-		BlobstoreConfig: blobstore.Config{
-			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
-			TableName:  ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
-		},
+		BlobstoreConfig: blobstoreConfig,
 		EthClientConfig: geth.ReadEthClientConfig(ctx),
 		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		EncoderConfig:   encoding.ReadCLIConfig(ctx),
 		LoggerConfig:    logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+		TracingConfig:   tracing.ReadCLIConfig(ctx, flags.FlagPrefix),
+		ProfilingConfig: profiling.ReadCLIConfig(ctx, flags.FlagPrefix),
 		BatcherConfig: batcher.Config{
 			PullInterval:             ctx.GlobalDuration(flags.PullIntervalFlag.Name),
 			FinalizerInterval:        ctx.GlobalDuration(flags.FinalizerIntervalFlag.Name),
 			EncoderSocket:            ctx.GlobalString(flags.EncoderSocket.Name),
 			NumConnections:           ctx.GlobalInt(flags.NumConnectionsFlag.Name),
 			EncodingRequestQueueSize: ctx.GlobalInt(flags.EncodingRequestQueueSizeFlag.Name),
+			DispersalBudget:          ctx.GlobalDuration(flags.DispersalBudgetFlag.Name),
 			BatchSizeMBLimit:         ctx.GlobalUint(flags.BatchSizeLimitFlag.Name),
 			SRSOrder:                 ctx.GlobalInt(flags.SRSOrderFlag.Name),
 			MaxNumRetriesPerBlob:     ctx.GlobalUint(flags.MaxNumRetriesPerBlobFlag.Name),
+			EncodingStallThreshold:   ctx.GlobalDuration(flags.EncodingStallThresholdFlag.Name),
+			BatchStallThreshold:      ctx.GlobalDuration(flags.BatchStallThresholdFlag.Name),
+			StateConsistencyRetries:  ctx.GlobalInt(flags.StateConsistencyRetriesFlag.Name),
+
+			BlobDeduplication:   ctx.GlobalBool(flags.BlobDeduplicationFlag.Name),
+			DeduplicationWindow: ctx.GlobalDuration(flags.DeduplicationWindowFlag.Name),
 		},
 		TimeoutConfig: batcher.TimeoutConfig{
 			EncodingTimeout:    ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name),
@@ -67,6 +113,74 @@ func NewConfig(ctx *cli.Context) Config {
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		IndexerDataDir:                ctx.GlobalString(flags.IndexerDataDirFlag.Name),
 		IndexerConfig:                 indexer.ReadIndexerConfig(ctx),
+		StateCacheConfig:              statecache.ReadCLIConfig(ctx, flags.FlagPrefix),
+		APKCheckInterval:              ctx.GlobalDuration(flags.APKCheckIntervalFlag.Name),
+		NodeCompression:               ctx.GlobalBool(flags.NodeCompressionFlag.Name),
+		NodeCompressionThreshold:      ctx.GlobalInt(flags.NodeCompressionThresholdFlag.Name),
+		NodeConnectBackoff:            common.ReadConnectBackoffCLIConfig(ctx, flags.FlagPrefix),
+		ChunkCaps:                     core.ReadChunkCapsCLIConfig(ctx, flags.FlagPrefix),
+		DispersalSigningKey:           ctx.GlobalString(flags.DispersalSigningKeyFlag.Name),
+	}
+	if config.DispersalSigningKey == "" {
+		config.DispersalSigningKey = config.EthClientConfig.PrivateKeyString
+	}
+	return config, nil
+}
+
+// validateFlags checks the contracts, the encoder socket, the intervals, timeouts and limits of the batches
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+	if ctx.GlobalBool(flags.EnableMetrics.Name) {
+		v.Add(validation.Port(flags.MetricsHTTPPort.Name, ctx.GlobalString(flags.MetricsHTTPPort.Name)))
+	}
+	if _, port, err := net.SplitHostPort(ctx.GlobalString(flags.EncoderSocket.Name)); err != nil {
+		v.Addf("%s: %q is not a host:port address", flags.EncoderSocket.Name, ctx.GlobalString(flags.EncoderSocket.Name))
+	} else {
+		v.Add(validation.Port(flags.EncoderSocket.Name, port))
+	}
+	if ctx.GlobalBool(flags.UseGraphFlag.Name) && ctx.GlobalString(flags.GraphUrlFlag.Name) == "" {
+		v.Addf("%s: must be set when %s is", flags.GraphUrlFlag.Name, flags.UseGraphFlag.Name)
+	}
+
+	pullInterval := ctx.GlobalDuration(flags.PullIntervalFlag.Name)
+	v.Add(validation.Range(flags.PullIntervalFlag.Name, pullInterval, minInterval, maxInterval))
+	// The encodings get the part of each batch window before the budget
+	if dispersalBudget := ctx.GlobalDuration(flags.DispersalBudgetFlag.Name); dispersalBudget < 0 || dispersalBudget >= pullInterval {
+		v.Addf("%s: %s must be at least 0 and less than the %s of %s", flags.DispersalBudgetFlag.Name, dispersalBudget, flags.PullIntervalFlag.Name, pullInterval)
+	}
+	v.Add(validation.Range(flags.FinalizerIntervalFlag.Name, ctx.GlobalDuration(flags.FinalizerIntervalFlag.Name), minInterval, maxInterval))
+	if apkCheckInterval := ctx.GlobalDuration(flags.APKCheckIntervalFlag.Name); apkCheckInterval != 0 {
+		v.Add(validation.Range(flags.APKCheckIntervalFlag.Name, apkCheckInterval, minInterval, maxInterval))
 	}
-	return config
+	for _, flag := range []cli.DurationFlag{flags.EncodingTimeoutFlag, flags.AttestationTimeoutFlag, flags.ChainReadTimeoutFlag, flags.ChainWriteTimeoutFlag} {
+		v.Add(validation.Range(flag.Name, ctx.GlobalDuration(flag.Name), minTimeout, maxTimeout))
+	}
+	v.Add(validation.AtLeast(flags.NumConnectionsFlag.Name, ctx.GlobalInt(flags.NumConnectionsFlag.Name), 1))
+	v.Add(validation.AtLeast(flags.EncodingRequestQueueSizeFlag.Name, ctx.GlobalInt(flags.EncodingRequestQueueSizeFlag.Name), 1))
+	v.Add(validation.AtLeast(flags.BatchSizeLimitFlag.Name, ctx.GlobalUint(flags.BatchSizeLimitFlag.Name), 1))
+	v.Add(validation.AtLeast(flags.SRSOrderFlag.Name, ctx.GlobalInt(flags.SRSOrderFlag.Name), 1))
+	v.Add(validation.AtLeast(flags.NodeCompressionThresholdFlag.Name, ctx.GlobalInt(flags.NodeCompressionThresholdFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.StateConsistencyRetriesFlag.Name, ctx.GlobalInt(flags.StateConsistencyRetriesFlag.Name), 0))
+	if ctx.GlobalBool(flags.BlobDeduplicationFlag.Name) {
+		v.Add(validation.Range(flags.DeduplicationWindowFlag.Name, ctx.GlobalDuration(flags.DeduplicationWindowFlag.Name), minInterval, maxInterval))
+	}
+
+	// A stall threshold within the time the stage normally takes would report it stuck while it's making progress
+	encodingStallThreshold := ctx.GlobalDuration(flags.EncodingStallThresholdFlag.Name)
+	encodingTimeout := ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name)
+	if encodingStallThreshold < 0 || (encodingStallThreshold > 0 && encodingStallThreshold <= encodingTimeout) {
+		v.Addf("%s: %s must be 0 or exceed the %s of %s", flags.EncodingStallThresholdFlag.Name, encodingStallThreshold, flags.EncodingTimeoutFlag.Name, encodingTimeout)
+	}
+	batchStallThreshold := ctx.GlobalDuration(flags.BatchStallThresholdFlag.Name)
+	if batchStallThreshold < 0 || (batchStallThreshold > 0 && batchStallThreshold <= pullInterval) {
+		v.Addf("%s: %s must be 0 or exceed the %s of %s", flags.BatchStallThresholdFlag.Name, batchStallThreshold, flags.PullIntervalFlag.Name, pullInterval)
+	}
+
+	v.Add(common.ValidateConnectBackoffCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(indexer.ReadIndexerConfig(ctx).Validate())
+	v.Add(statecache.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(core.ValidateChunkCapsCLIFlags(ctx, flags.FlagPrefix))
+	return v.Err()
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const batcherConfigFile = `
+encoder-socket = "encoder:34000"
+attestation-timeout = "30s"
+num-connections = 64
+indexer-pull-interval = "2s"
+
+[chain]
+rpc = "http://localhost:8545"
+private-key = "0123456789abcdef"
+
+[batcher]
+s3-bucket-name = "test-eigenda-blobstore"
+dynamodb-table-name = "test-BlobMetadata"
+pull-interval = "5s"
+bls-operator-state-retriever = "0x0000000000000000000000000000000000000001"
+eigenda-service-manager = "0x0000000000000000000000000000000000000002"
+enable-metrics = true
+graph-url = "http://localhost:8000/subgraphs/name/Layr-Labs/eigenda-operator-state"
+use-graph = false
+batch-size-limit = 10240
+srs-order = 300000
+batch-stall-threshold = "15m"
+blob-deduplication = true
+deduplication-window = "2m"
+dispersal-budget = "1s"
+
+[batcher.aws]
+region = "us-east-1"
+`
+
+func TestConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batcher.toml")
+	assert.NoError(t, os.WriteFile(path, []byte(batcherConfigFile), 0600))
+
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	configfile.Enable(app)
+	var config Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = NewConfig(ctx)
+		return err
+	}
+	assert.NoError(t, app.Run([]string{"batcher", "--config", path, "--batcher.pull-interval", "10s"}))
+
+	assert.Equal(t, "test-BlobMetadata", config.BlobstoreConfig.TableName)
+	assert.Equal(t, "encoder:34000", config.BatcherConfig.EncoderSocket)
+	assert.Equal(t, 10*time.Second, config.BatcherConfig.PullInterval)
+	assert.Equal(t, uint(10240), config.BatcherConfig.BatchSizeMBLimit)
+	assert.Equal(t, 300000, config.BatcherConfig.SRSOrder)
+	assert.Equal(t, 15*time.Minute, config.BatcherConfig.BatchStallThreshold)
+	assert.Equal(t, 64, config.BatcherConfig.NumConnections)
+	assert.Equal(t, 30*time.Second, config.TimeoutConfig.AttestationTimeout)
+	assert.Equal(t, 2*time.Second, config.IndexerConfig.PullInterval)
+	assert.True(t, config.MetricsConfig.EnableMetrics)
+	assert.False(t, config.UseGraph)
+	assert.True(t, config.BatcherConfig.BlobDeduplication)
+	assert.Equal(t, 2*time.Minute, config.BatcherConfig.DeduplicationWindow)
+	assert.Equal(t, time.Second, config.BatcherConfig.DispersalBudget)
+
+	// The encodings get no time if the budget takes the whole batch window
+	err := app.Run([]string{"batcher", "--config", path, "--batcher.dispersal-budget", "5s"})
+	assert.ErrorContains(t, err, "batcher.dispersal-budget: 5s must be at least 0 and less than the batcher.pull-interval of 5s")
+}
@@ -5,8 +5,14 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -94,7 +100,7 @@ var (
 	}
 	EncodingTimeoutFlag = cli.DurationFlag{
 		Name:     "encoding-timeout",
-		Usage:    "connection timeout from grpc call to encoder",
+		Usage:    "connection timeout from grpc call to encoder, which bounds the deadlines of the encoding requests derived from the batch cuts",
 		Required: false,
 		Value:    10 * time.Second,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_TIMEOUT"),
@@ -134,6 +140,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FINALIZER_INTERVAL"),
 		Value:    6 * time.Minute,
 	}
+	APKCheckIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "apk-check-interval"),
+		Usage:    "Interval at which the cached aggregate public keys of the quorums are checked against the ones of the BLS pubkey registry, a key that differs being computed again from all the operators of its quorum. 0 disables the checks",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "APK_CHECK_INTERVAL"),
+		Value:    10 * time.Minute,
+	}
 	EncodingRequestQueueSizeFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoding-request-queue-size"),
 		Usage:    "Size of the encoding request queue",
@@ -154,6 +167,66 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_RETRIES_PER_BLOB"),
 		Value:    2,
 	}
+	NodeCompressionFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-compression"),
+		Usage:    "Compress the chunks sent to the DA nodes with gzip",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NODE_COMPRESSION"),
+	}
+	NodeCompressionThresholdFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-compression-threshold"),
+		Usage:    "Size in bytes of the requests to the DA nodes below which the chunks are sent uncompressed with --batcher.node-compression, as compressing small messages costs more CPU than it saves bandwidth",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NODE_COMPRESSION_THRESHOLD"),
+		Value:    1024,
+	}
+	EncodingStallThresholdFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-stall-threshold"),
+		Usage:    "Time the encoding of the pending blobs can go without progress before the encoding stage is reported stuck. 0 disables the detection",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_STALL_THRESHOLD"),
+		Value:    5 * time.Minute,
+	}
+	BatchStallThresholdFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-stall-threshold"),
+		Usage:    "Time the batcher can go without confirming a batch while it has encoded blobs before the stage it is at is reported stuck. 0 disables the detection",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_STALL_THRESHOLD"),
+		Value:    10 * time.Minute,
+	}
+	StateConsistencyRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "state-consistency-retries"),
+		Usage:    "Number of times the reference block of the encoding is picked again when the operator state at the block changes while the chunks are assigned, before the encoding round is aborted",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATE_CONSISTENCY_RETRIES"),
+		Value:    2,
+	}
+	BlobDeduplicationFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-deduplication"),
+		Usage:    "Link the blobs dispersed again with the same data and security params while a first copy is processing to that copy, so that they get its confirmation without being encoded and dispersed again. Disable it if the clients intentionally disperse the same blobs several times",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_DEDUPLICATION"),
+	}
+	DeduplicationWindowFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "deduplication-window"),
+		Usage:    "Time after the request of a processing blob within which the identical blobs are linked to it, when the blob deduplication is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DEDUPLICATION_WINDOW"),
+		Value:    10 * time.Minute,
+	}
+	DispersalBudgetFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-budget"),
+		Usage:    "Time of each batch window kept for dispersing the batch and aggregating its signatures. The encoding requests must finish this long before the next batch cut, and those estimated not to from the recent encode times of the blobs of their size are deferred to the next batch rather than failed. Must be less than the pull interval",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_BUDGET"),
+		Value:    0,
+	}
+	DispersalSigningKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-signing-key"),
+		Usage:    "Hex-encoded ECDSA private key the requests to store chunks are signed with, which the DA nodes authenticate the disperser with. If empty, the private key of the chain client is used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_SIGNING_KEY"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -179,8 +252,18 @@ var optionalFlags = []cli.Flag{
 	ChainWriteTimeoutFlag,
 	NumConnectionsFlag,
 	FinalizerIntervalFlag,
+	APKCheckIntervalFlag,
 	EncodingRequestQueueSizeFlag,
 	MaxNumRetriesPerBlobFlag,
+	NodeCompressionFlag,
+	NodeCompressionThresholdFlag,
+	EncodingStallThresholdFlag,
+	BatchStallThresholdFlag,
+	StateConsistencyRetriesFlag,
+	DispersalBudgetFlag,
+	DispersalSigningKeyFlag,
+	BlobDeduplicationFlag,
+	DeduplicationWindowFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -190,6 +273,13 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, common.ConnectBackoffCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, profiling.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, tracing.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, statecache.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, core.ChunkCapsCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, configfile.CLIFlag(envVarPrefix))
 }
@@ -9,16 +9,20 @@ import (
 
 	"github.com/shurcooL/graphql"
 
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 
 	inmemstore "github.com/Layr-Labs/eigenda/indexer/inmem"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
-	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
-	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/core"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
@@ -31,17 +35,14 @@ import (
 	"github.com/urfave/cli"
 )
 
-var (
-	// version is the version of the binary.
-	version   string
-	gitCommit string
-	gitDate   string
-)
-
 func main() {
 	app := cli.NewApp()
 	app.Flags = flags.Flags
-	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	configfile.Enable(app)
+	version.Configure(app)
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return NewConfig(ctx)
+	})
 	app.Name = "batcher"
 	app.Usage = "EigenDA Batcher"
 	app.Description = "Service for creating a batch from queued blobs, distributing coded chunks to nodes, and confirming onchain"
@@ -51,35 +52,48 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RunBatcher(ctx *cli.Context) error {
-	config := NewConfig(ctx)
+	config, err := NewConfig(ctx)
+	if err != nil {
+		return err
+	}
 
 	logger, err := logging.GetLogger(config.LoggerConfig)
 	if err != nil {
 		return err
 	}
+	logging.CycleLevelOnSignal(logger)
 
-	bucketName := config.BlobstoreConfig.BucketName
-	s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
-	if err != nil {
+	// The batcher runs in the background until the process exits, so the traces are exported as they are batched
+	// rather than flushed on shutdown
+	if _, err := tracing.Start(context.Background(), config.TracingConfig, "disperser-batcher"); err != nil {
 		return err
 	}
-	logger.Info("Initialized S3 client", "bucket", bucketName)
 
-	dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
-	if err != nil {
+	logger.Info("Starting batcher", version.LogFields()...)
+	profiling.PublishBuildInfo()
+	profiling.Publish("config", map[string]any{
+		"pull_interval":               config.BatcherConfig.PullInterval.String(),
+		"encoder_socket":              config.BatcherConfig.EncoderSocket,
+		"batch_size_mb_limit":         config.BatcherConfig.BatchSizeMBLimit,
+		"encoding_request_queue_size": config.BatcherConfig.EncodingRequestQueueSize,
+		"node_compression":            config.NodeCompression,
+		"node_compression_threshold":  config.NodeCompressionThreshold,
+		"node_connect_backoff":        config.NodeConnectBackoff,
+		"apk_check_interval":          config.APKCheckInterval.String(),
+		"chunk_caps":                  config.ChunkCaps,
+	})
+	if err := profiling.Start(context.Background(), config.ProfilingConfig, logger); err != nil {
 		return err
 	}
 
-	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
-		Timeout: config.TimeoutConfig.AttestationTimeout,
-	}, logger)
+	// The aggregate public keys of the quorums the signers are checked against are maintained as the operators
+	// register and deregister
 	agg := core.NewStdSignatureAggregator(logger)
-	asgn := &core.StdAssignmentCoordinator{}
+	agg.APKs = core.NewAPKCache(logger)
+	asgn := &core.StdAssignmentCoordinator{Caps: config.ChunkCaps}
 
 	client, err := geth.NewClient(config.EthClientConfig, logger)
 	if err != nil {
@@ -94,6 +108,9 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if config.APKCheckInterval > 0 {
+		agg.APKs.StartDriftCheck(context.Background(), config.APKCheckInterval, tx)
+	}
 	confirmer, err := eth.NewBatchConfirmer(tx, config.TimeoutConfig.ChainWriteTimeout)
 	if err != nil {
 		return err
@@ -107,8 +124,15 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil || storeDurationBlocks == 0 {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
-	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
-	queue := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	queue, closeBlobStore, err := blobstore.NewBlobStore(context.Background(), config.BlobstoreConfig, config.AwsClientConfig, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := closeBlobStore(); err != nil {
+			logger.Error("Failed to close the blob store", "err", err)
+		}
+	}()
 
 	cs := coreeth.NewChainState(tx, client)
 
@@ -129,12 +153,37 @@ func RunBatcher(ctx *cli.Context) error {
 		}
 	}
 
+	if config.StateCacheConfig.Size > 0 {
+		cachedState, err := statecache.NewCachedIndexedChainState(ics, config.StateCacheConfig, logger)
+		if err != nil {
+			return err
+		}
+		if err := cachedState.WatchEvents(context.Background(), client, tx.Bindings.RegCoordinatorAddr); err != nil {
+			logger.Warn("Not invalidating the cached operator states on the operator events, the unfinalized states only expire", "err", err)
+		}
+		ics = cachedState
+	}
+
 	metrics := batcher.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	metrics.EnableProfiling(config.ProfilingConfig)
+	signer, err := auth.NewLocalBatchSigner(config.DispersalSigningKey)
+	if err != nil {
+		return fmt.Errorf("invalid dispersal signing key: %w", err)
+	}
+	logger.Info("Signing the requests to store chunks", "disperser", signer.Address().Hex())
+	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
+		Timeout:              config.TimeoutConfig.AttestationTimeout,
+		UseCompression:       config.NodeCompression,
+		CompressionThreshold: config.NodeCompressionThreshold,
+		CompressionObserver:  metrics,
+		ConnectBackoff:       &config.NodeConnectBackoff,
+		Signer:               signer,
+	}, logger)
 
 	if len(config.BatcherConfig.EncoderSocket) == 0 {
 		return fmt.Errorf("encoder socket must be specified")
 	}
-	encoderClient, err := encoder.NewEncoderClient(config.BatcherConfig.EncoderSocket, config.TimeoutConfig.EncodingTimeout)
+	encoderClient, err := encoder.NewEncoderClient(config.BatcherConfig.EncoderSocket, config.TimeoutConfig.EncodingTimeout, nil)
 	if err != nil {
 		return err
 	}
@@ -156,6 +205,6 @@ func RunBatcher(ctx *cli.Context) error {
 		return err
 	}
 
-	return nil
-
+	// The batcher runs in the background until the process exits
+	select {}
 }
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/validation"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
+	"github.com/gin-gonic/gin"
 	"github.com/urfave/cli"
 )
 
@@ -25,17 +29,24 @@ type Config struct {
 	SubgraphApiOperatorStateAddr string
 	ServerMode                   string
 	AllowOrigins                 []string
+	// QuorumStakeRefreshInterval is the interval of the quorum stake metrics, which are disabled if it is 0
+	QuorumStakeRefreshInterval time.Duration
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 }
 
-func NewConfig(ctx *cli.Context) Config {
+func NewConfig(ctx *cli.Context) (Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return Config{}, err
+	}
+
+	blobstoreConfig := blobstore.ReadCLIConfig(ctx, flags.FlagPrefix)
+	blobstoreConfig.BucketName = ctx.GlobalString(flags.S3BucketNameFlag.Name)
+	blobstoreConfig.TableName = ctx.GlobalString(flags.DynamoTableNameFlag.Name)
+
 	config := Config{
-		BlobstoreConfig: blobstore.Config{
-			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
-			TableName:  ctx.GlobalString(flags.DynamoTableNameFlag.Name),
-		},
+		BlobstoreConfig:               blobstoreConfig,
 		AwsClientConfig:               aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		EthClientConfig:               geth.ReadEthClientConfig(ctx),
 		LoggerConfig:                  logging.ReadCLIConfig(ctx, flags.FlagPrefix),
@@ -51,11 +62,29 @@ func NewConfig(ctx *cli.Context) Config {
 			Secret:    ctx.GlobalString(flags.PrometheusServerSecretFlag.Name),
 			Cluster:   ctx.GlobalString(flags.PrometheusMetricsClusterLabelFlag.Name),
 		},
-		AllowOrigins: ctx.GlobalStringSlice(flags.AllowOriginsFlag.Name),
+		AllowOrigins:               ctx.GlobalStringSlice(flags.AllowOriginsFlag.Name),
+		QuorumStakeRefreshInterval: ctx.GlobalDuration(flags.QuorumStakeRefreshIntervalFlag.Name),
 		MetricsConfig: dataapi.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
 	}
-	return config
+	return config, nil
+}
+
+// validateFlags checks the contract addresses, the metrics and the server mode of the data access api
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+	if ctx.GlobalBool(flags.EnableMetrics.Name) {
+		v.Add(validation.Port(flags.MetricsHTTPPort.Name, ctx.GlobalString(flags.MetricsHTTPPort.Name)))
+		v.Add(validation.AtLeast(flags.QuorumStakeRefreshIntervalFlag.Name, ctx.GlobalDuration(flags.QuorumStakeRefreshIntervalFlag.Name), 0))
+	}
+	switch mode := ctx.GlobalString(flags.ServerModeFlag.Name); mode {
+	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+	default:
+		v.Addf("%s: must be %s, %s or %s, got %q", flags.ServerModeFlag.Name, gin.DebugMode, gin.ReleaseMode, gin.TestMode, mode)
+	}
+	return v.Err()
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+// dataApiArgs are the required flags of the data access api
+var dataApiArgs = []string{
+	"--chain.rpc", "http://localhost:8545",
+	"--chain.private-key", "0123456789abcdef",
+	"--data-access-api.dynamo-table-name", "test-BlobMetadata",
+	"--data-access-api.s3-bucket-name", "test-eigenda-blobstore",
+	"--data-access-api.socket-addr", "0.0.0.0:8080",
+	"--data-access-api.prometheus-server-url", "http://localhost:9090",
+	"--data-access-api.prometheus-server-usename", "user",
+	"--data-access-api.prometheus-server-secret", "secret",
+	"--data-access-api.prometheus-metrics-cluster-label", "test",
+	"--data-access-api.sub-batch-metadata-socket-addr", "http://localhost:8000/subgraphs/name/batch-metadata",
+	"--data-access-api.sub-op-state-socket-addr", "http://localhost:8000/subgraphs/name/operator-state",
+	"--data-access-api.bls-operator-state-retriever", "0x0000000000000000000000000000000000000001",
+	"--data-access-api.eigenda-service-manager", "0x0000000000000000000000000000000000000002",
+	"--data-access-api.allow-origins", "*",
+	"--data-access-api.aws.region", "us-east-1",
+	"--data-access-api.enable-metrics",
+}
+
+// newConfig returns the config of the data access api run with dataApiArgs and the arguments, which override them
+func newConfig(args ...string) (Config, error) {
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	var config Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = NewConfig(ctx)
+		return err
+	}
+	err := app.Run(append(append([]string{"dataapi"}, dataApiArgs...), args...))
+	return config, err
+}
+
+func TestConfig(t *testing.T) {
+	config, err := newConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "test-eigenda-blobstore", config.BlobstoreConfig.BucketName)
+	assert.Equal(t, "0.0.0.0:8080", config.SocketAddr)
+	assert.Equal(t, "debug", config.ServerMode)
+	assert.True(t, config.MetricsConfig.EnableMetrics)
+	assert.Equal(t, time.Minute, config.QuorumStakeRefreshInterval)
+
+	_, err = newConfig(
+		"--data-access-api.eigenda-service-manager", "0x1234",
+		"--data-access-api.metrics-http-port", "70000",
+		"--data-access-api.server-mode", "production",
+	)
+	assert.ErrorContains(t, err, "invalid configuration (3 errors)")
+	assert.ErrorContains(t, err, "data-access-api.eigenda-service-manager")
+	assert.ErrorContains(t, err, "data-access-api.metrics-http-port")
+	assert.ErrorContains(t, err, `data-access-api.server-mode: must be debug, release or test, got "production"`)
+}
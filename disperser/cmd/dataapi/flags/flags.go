@@ -1,10 +1,13 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/urfave/cli"
 )
 
@@ -110,6 +113,13 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_HTTP_PORT"),
 	}
+	QuorumStakeRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum-stake-refresh-interval"),
+		Usage:    "the interval at which the stake distribution of the quorums is read from the chain and exported as metrics, which is disabled if 0",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUORUM_STAKE_REFRESH_INTERVAL"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -131,6 +141,7 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	ServerModeFlag,
 	MetricsHTTPPort,
+	QuorumStakeRefreshIntervalFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -141,4 +152,5 @@ func init() {
 	Flags = append(Flags, logging.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.CLIFlags(envVarPrefix, FlagPrefix)...)
 }
@@ -6,10 +6,10 @@ import (
 	"log"
 	"os"
 
-	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
-	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/version"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
@@ -19,13 +19,6 @@ import (
 	"github.com/urfave/cli"
 )
 
-var (
-	// version is the version of the binary.
-	version   string
-	gitCommit string
-	gitDate   string
-)
-
 // @title			EigenDA Data Access API
 // @description	This is the EigenDA Data Access API server.
 // @version		1
@@ -33,7 +26,10 @@ var (
 func main() {
 	app := cli.NewApp()
 	app.Flags = flags.Flags
-	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	app.Version = version.String()
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return NewConfig(ctx)
+	})
 	app.Name = "data-access-api"
 	app.Usage = "EigenDA Data Access API"
 	app.Description = "Service that provides access to data blobs."
@@ -43,27 +39,30 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RunDataApi(ctx *cli.Context) error {
-	config := NewConfig(ctx)
-
-	logger, err := logging.GetLogger(config.LoggerConfig)
+	config, err := NewConfig(ctx)
 	if err != nil {
 		return err
 	}
 
-	s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
+	logger, err := logging.GetLogger(config.LoggerConfig)
 	if err != nil {
 		return err
 	}
+	logging.CycleLevelOnSignal(logger)
+	logger.Info("Starting data access api", version.LogFields()...)
 
-	dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
+	sharedStorage, closeBlobStore, err := blobstore.NewBlobStore(context.Background(), config.BlobstoreConfig, config.AwsClientConfig, 0, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err := closeBlobStore(); err != nil {
+			logger.Error("Failed to close the blob store", "err", err)
+		}
+	}()
 
 	promApi, err := prometheus.NewApi(config.PrometheusConfig)
 	if err != nil {
@@ -81,14 +80,12 @@ func RunDataApi(ctx *cli.Context) error {
 	}
 
 	var (
-		promClient        = dataapi.NewPrometheusClient(promApi, config.PrometheusConfig.Cluster)
-		blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, 0)
-		sharedStorage     = blobstore.NewSharedStorage(config.BlobstoreConfig.BucketName, s3Client, blobMetadataStore, logger)
-		subgraphApi       = subgraph.NewApi(config.SubgraphApiBatchMetadataAddr, config.SubgraphApiOperatorStateAddr)
-		subgraphClient    = dataapi.NewSubgraphClient(subgraphApi)
-		chainState        = coreeth.NewChainState(tx, client)
-		metrics           = dataapi.NewMetrics(config.MetricsConfig.HTTPPort, logger)
-		server            = dataapi.NewServer(
+		promClient     = dataapi.NewPrometheusClient(promApi, config.PrometheusConfig.Cluster)
+		subgraphApi    = subgraph.NewApi(config.SubgraphApiBatchMetadataAddr, config.SubgraphApiOperatorStateAddr)
+		subgraphClient = dataapi.NewSubgraphClient(subgraphApi)
+		chainState     = coreeth.NewChainState(tx, client)
+		metrics        = dataapi.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+		server         = dataapi.NewServer(
 			dataapi.Config{
 				ServerMode:   config.ServerMode,
 				SocketAddr:   config.SocketAddr,
@@ -109,6 +106,10 @@ func RunDataApi(ctx *cli.Context) error {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
 		metrics.Start(context.Background())
 		logger.Info("Enabled metrics for Data Access API", "socket", httpSocket)
+
+		if config.QuorumStakeRefreshInterval > 0 {
+			dataapi.NewQuorumStakeExporter(tx, chainState, metrics, config.QuorumStakeRefreshInterval, logger).Start(context.Background())
+		}
 	}
 
 	return server.Start()
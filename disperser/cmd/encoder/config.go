@@ -1,7 +1,13 @@
 package main
 
 import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/validation"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
@@ -9,13 +15,24 @@ import (
 )
 
 type Config struct {
-	EncoderConfig encoding.EncoderConfig
-	LoggerConfig  logging.Config
-	ServerConfig  *encoder.ServerConfig
-	MetricsConfig encoder.MetrisConfig
+	EncoderConfig   encoding.EncoderConfig
+	LoggerConfig    logging.Config
+	ServerConfig    *encoder.ServerConfig
+	MetricsConfig   encoder.MetrisConfig
+	TracingConfig   tracing.Config
+	ProfilingConfig profiling.Config
 }
 
-func NewConfig(ctx *cli.Context) Config {
+func NewConfig(ctx *cli.Context) (Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return Config{}, err
+	}
+
+	tlsConfig, err := grpcsec.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
+
 	config := Config{
 		EncoderConfig: encoding.ReadCLIConfig(ctx),
 		LoggerConfig:  logging.ReadCLIConfig(ctx, flags.FlagPrefix),
@@ -23,11 +40,32 @@ func NewConfig(ctx *cli.Context) Config {
 			GrpcPort:              ctx.GlobalString(flags.GrpcPortFlag.Name),
 			MaxConcurrentRequests: ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
 			RequestPoolSize:       ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
+			TLSConfig:             tlsConfig,
 		},
 		MetricsConfig: encoder.MetrisConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
+		TracingConfig:   tracing.ReadCLIConfig(ctx, flags.FlagPrefix),
+		ProfilingConfig: profiling.ReadCLIConfig(ctx, flags.FlagPrefix),
+	}
+	return config, nil
+}
+
+// validateFlags checks the ports, the request limits and the encoding config before the SRS is loaded
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Port(flags.GrpcPortFlag.Name, ctx.GlobalString(flags.GrpcPortFlag.Name)))
+	if ctx.GlobalBool(flags.EnableMetrics.Name) {
+		v.Add(validation.Port(flags.MetricsHTTPPort.Name, ctx.GlobalString(flags.MetricsHTTPPort.Name)))
+	}
+	maxConcurrentRequests := ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name)
+	v.Add(validation.AtLeast(flags.MaxConcurrentRequestsFlag.Name, maxConcurrentRequests, 1))
+	// The requests are admitted to the pool before they run, so a smaller pool would cap the concurrent requests
+	v.Add(validation.AtLeast(flags.RequestPoolSizeFlag.Name, ctx.GlobalInt(flags.RequestPoolSizeFlag.Name), maxConcurrentRequests))
+	v.Add(grpcsec.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	if err := encoding.ValidateConfig(encoding.ReadCLIConfig(ctx)); err != nil {
+		v.Add(fmt.Errorf("invalid encoding config: %w", err))
 	}
-	return config
+	return v.Err()
 }
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const encoderConfigFile = `
+kzg:
+  g1-path: ../../../inabox/resources/kzg/g1.point.300000
+  g2-path: ../../../inabox/resources/kzg/g2.point.300000
+  cache-path: /data/kzg/SRSTables
+  srs-order: 300000
+  num-workers: 4
+  preload-encoder: true
+disperser-encoder:
+  grpc-port: "34000"
+  enable-metrics: true
+  metrics-http-port: "9100"
+  max-concurrent-requests: 16
+  log.level-std: warn
+`
+
+func TestConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "encoder.yml")
+	assert.NoError(t, os.WriteFile(path, []byte(encoderConfigFile), 0600))
+	t.Setenv("DISPERSER_ENCODER_MAX_CONCURRENT_REQUESTS", "32")
+
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	configfile.Enable(app)
+	var config Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = NewConfig(ctx)
+		return err
+	}
+	assert.NoError(t, app.Run([]string{"encoder", "--config", path}))
+
+	assert.Equal(t, "../../../inabox/resources/kzg/g1.point.300000", config.EncoderConfig.KzgConfig.G1Path)
+	assert.Equal(t, uint64(300000), config.EncoderConfig.KzgConfig.SRSOrder)
+	assert.Equal(t, uint64(4), config.EncoderConfig.KzgConfig.NumWorker)
+	assert.True(t, config.EncoderConfig.KzgConfig.PreloadEncoder)
+	assert.Equal(t, "34000", config.ServerConfig.GrpcPort)
+	assert.True(t, config.MetricsConfig.EnableMetrics)
+	assert.Equal(t, "warn", config.LoggerConfig.StdLevel)
+	// The environment takes precedence over the file
+	assert.Equal(t, 32, config.ServerConfig.MaxConcurrentRequests)
+}
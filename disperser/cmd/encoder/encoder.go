@@ -21,6 +21,7 @@ func NewEncoderGRPCServer(config Config, logger common.Logger) (*EncoderGRPCServ
 	}
 
 	metrics := encoder.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	metrics.EnableProfiling(config.ProfilingConfig)
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
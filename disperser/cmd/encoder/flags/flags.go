@@ -2,7 +2,11 @@ package flags
 
 import (
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/urfave/cli"
 )
@@ -68,4 +72,8 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, encoding.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, profiling.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, tracing.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpcsec.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, configfile.CLIFlag(envVarPrefix))
 }
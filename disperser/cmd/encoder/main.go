@@ -2,27 +2,27 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	"github.com/urfave/cli"
 )
 
-var (
-	// Version is the version of the binary.
-	Version   string
-	GitCommit string
-	GitDate   string
-)
-
 func main() {
 
 	app := cli.NewApp()
 	app.Flags = flags.Flags
-	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	configfile.Enable(app)
+	version.Configure(app)
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return NewConfig(ctx)
+	})
 	app.Name = "encoder"
 	app.Usage = "EigenDA Encoder"
 	app.Description = "Service for encoding blobs"
@@ -32,18 +32,41 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RunEncoderServer(ctx *cli.Context) error {
 
-	config := NewConfig(ctx)
+	config, err := NewConfig(ctx)
+	if err != nil {
+		return err
+	}
 
 	logger, err := logging.GetLogger(config.LoggerConfig)
 	if err != nil {
 		return err
 	}
+	logging.CycleLevelOnSignal(logger)
+
+	shutdownTracing, err := tracing.Start(context.Background(), config.TracingConfig, "disperser-encoder")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush the traces", "err", err)
+		}
+	}()
+
+	logger.Info("Starting encoder", version.LogFields()...)
+	profiling.PublishBuildInfo()
+	profiling.Publish("config", map[string]any{
+		"grpc_port":               config.ServerConfig.GrpcPort,
+		"max_concurrent_requests": config.ServerConfig.MaxConcurrentRequests,
+		"request_pool_size":       config.ServerConfig.RequestPoolSize,
+	})
+	if err := profiling.Start(context.Background(), config.ProfilingConfig, logger); err != nil {
+		return err
+	}
 
 	enc, err := NewEncoderGRPCServer(config, logger)
 	if err != nil {
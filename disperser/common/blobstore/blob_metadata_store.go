@@ -2,7 +2,9 @@ package blobstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -43,6 +46,12 @@ func NewBlobMetadataStore(dynamoDBClient *commondynamodb.Client, logger common.L
 	}
 }
 
+var _ MetadataStore = (*BlobMetadataStore)(nil)
+
+func (s *BlobMetadataStore) TTL() time.Duration {
+	return s.ttl
+}
+
 func (s *BlobMetadataStore) QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error {
 	item, err := MarshalBlobMetadata(blobMetadata)
 	if err != nil {
@@ -75,7 +84,8 @@ func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, metadataKey dis
 
 // GetBlobMetadataByStatus returns all the metadata with the given status
 // Because this function scans the entire index, it should only be used for status with a limited number of items.
-// It should only be used to filter "Processing" status. To support other status, a streaming version should be implemented.
+// It should only be used to filter "Processing" status. Other status should be fetched with
+// GetBlobMetadataByStatusWithPagination.
 func (s *BlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error) {
 	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, statusIndexName, "BlobStatus = :status", commondynamodb.ExpresseionValues{
 		":status": &types.AttributeValueMemberN{
@@ -96,6 +106,54 @@ func (s *BlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status
 	return metadata, nil
 }
 
+// GetBlobMetadataByStatusWithPagination returns up to limit metadata with the given status ordered by the request time,
+// starting after the position encoded in continuationToken (or from the beginning if it is empty).
+// It returns the token to fetch the next page, which is empty if there are no more metadata.
+func (s *BlobMetadataStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, limit int32, continuationToken string) ([]*disperser.BlobMetadata, string, error) {
+	cursor, err := decodeContinuationToken(continuationToken, status)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var exclusiveStartKey commondynamodb.Key
+	if cursor != nil {
+		exclusiveStartKey, err = attributevalue.MarshalMap(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	result, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, statusIndexName, "BlobStatus = :status", commondynamodb.ExpresseionValues{
+		":status": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(status)),
+		}}, pageSize(limit), exclusiveStartKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	metadata := make([]*disperser.BlobMetadata, len(result.Items))
+	for i, item := range result.Items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if result.LastEvaluatedKey == nil {
+		return metadata, "", nil
+	}
+
+	lastKey := new(statusIndexCursor)
+	if err := attributevalue.UnmarshalMap(result.LastEvaluatedKey, lastKey); err != nil {
+		return nil, "", err
+	}
+	nextToken, err := encodeContinuationToken(lastKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return metadata, nextToken, nil
+}
+
 func (s *BlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
 	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, batchIndexName, "BatchHeaderHash = :batch_header_hash", commondynamodb.ExpresseionValues{
 		":batch_header_hash": &types.AttributeValueMemberB{
@@ -200,6 +258,103 @@ func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey dispe
 	return err
 }
 
+// UpgradeBlobMetadata upgrades the metadata of the schema version fromVersion, only writing the attributes the upgrade
+// sets or changes so that the concurrent updates of the other attributes aren't overridden. The write is conditioned
+// on the schema version, and fails with ErrSchemaVersionChanged if the metadata was upgraded concurrently.
+func (s *BlobMetadataStore) UpgradeBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, fromVersion uint32, upgrade func(*disperser.BlobMetadata) error) (*disperser.BlobMetadata, error) {
+	key := map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: metadataKey.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: metadataKey.MetadataHash,
+		},
+	}
+	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(item) == 0 {
+		return nil, disperser.ErrBlobNotFound
+	}
+
+	metadata, err := UnmarshalBlobMetadata(item)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.SchemaVersion != fromVersion {
+		return nil, ErrSchemaVersionChanged
+	}
+	if err := upgrade(metadata); err != nil {
+		return nil, err
+	}
+	upgradedItem, err := MarshalBlobMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(commondynamodb.Item)
+	for name, value := range upgradedItem {
+		if stored, ok := item[name]; !ok || !reflect.DeepEqual(stored, value) {
+			changes[name] = value
+		}
+	}
+
+	// The metadata written before the schema was versioned has no SchemaVersion attribute
+	condition := expression.Name("SchemaVersion").Equal(expression.Value(fromVersion))
+	if fromVersion == 0 {
+		condition = condition.Or(expression.AttributeNotExists(expression.Name("SchemaVersion")))
+	}
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, key, changes, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return nil, ErrSchemaVersionChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// GetSchemaVersion returns the schema version of the table, which is 0 if it was never set
+func (s *BlobMetadataStore) GetSchemaVersion(ctx context.Context) (uint32, error) {
+	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, schemaItemKey())
+	if err != nil {
+		return 0, err
+	}
+	schema := struct{ SchemaVersion uint32 }{}
+	if err := attributevalue.UnmarshalMap(item, &schema); err != nil {
+		return 0, err
+	}
+	return schema.SchemaVersion, nil
+}
+
+// SetSchemaVersion raises the schema version of the table to the version. It fails with ErrNewerSchema if the schema
+// version of the table is already newer.
+func (s *BlobMetadataStore) SetSchemaVersion(ctx context.Context, version uint32) error {
+	condition := expression.AttributeNotExists(expression.Name("SchemaVersion")).Or(expression.Name("SchemaVersion").LessThanEqual(expression.Value(version)))
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, schemaItemKey(), commondynamodb.Item{
+		"SchemaVersion": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(uint64(version), 10),
+		},
+	}, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: the schema version of table %s is above %d", ErrNewerSchema, s.tableName, version)
+	}
+	return err
+}
+
+// schemaItemKey is the key of the item of the schema version of the table. It isn't the key of any blob, whose hashes
+// are in hex, and the item has none of the attributes of the indexes.
+func schemaItemKey() commondynamodb.Key {
+	return commondynamodb.Key{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: "_schema",
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: "_version",
+		},
+	}
+}
+
 func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
 	return &dynamodb.CreateTableInput{
 		AttributeDefinitions: []types.AttributeDefinition{
@@ -2,12 +2,15 @@ package blobstore_test
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
@@ -16,6 +19,30 @@ import (
 )
 
 func TestBlobMetadataStoreOperations(t *testing.T) {
+	blobKey1, blobKey2 := testBlobMetadataStoreOperations(t, blobMetadataStore)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey1.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey1.BlobHash},
+		},
+		{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey2.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey2.BlobHash},
+		},
+	})
+}
+
+func TestLocalBlobMetadataStoreOperations(t *testing.T) {
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+
+	testBlobMetadataStoreOperations(t, localStore)
+}
+
+// testBlobMetadataStoreOperations runs the conformance tests of the metadata store and returns the keys it created
+func testBlobMetadataStoreOperations(t *testing.T, blobMetadataStore blobstore.MetadataStore) (disperser.BlobKey, disperser.BlobKey) {
 	ctx := context.Background()
 	blobKey1 := disperser.BlobKey{
 		BlobHash:     blobHash,
@@ -87,16 +114,87 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, metadata, confirmedMetadata)
 
-	deleteItems(t, []commondynamodb.Key{
-		{
-			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey1.MetadataHash},
-			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey1.BlobHash},
-		},
-		{
-			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey2.MetadataHash},
-			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey2.BlobHash},
-		},
-	})
+	return blobKey1, blobKey2
+}
+
+func TestBlobMetadataStoreGetBlobMetadataByStatusWithPagination(t *testing.T) {
+	blobKeys := testGetBlobMetadataByStatusWithPagination(t, blobMetadataStore)
+
+	keys := make([]commondynamodb.Key, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		keys[i] = commondynamodb.Key{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+		}
+	}
+	deleteItems(t, keys)
+}
+
+func TestLocalBlobMetadataStoreGetBlobMetadataByStatusWithPagination(t *testing.T) {
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+
+	testGetBlobMetadataByStatusWithPagination(t, localStore)
+}
+
+// testGetBlobMetadataByStatusWithPagination pages through the metadata of a status and checks that no metadata
+// is skipped or returned twice. It returns the keys it created.
+func testGetBlobMetadataByStatusWithPagination(t *testing.T, blobMetadataStore blobstore.MetadataStore) []disperser.BlobKey {
+	ctx := context.Background()
+	numBlobs := 50
+	blobKeys := make([]disperser.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		blobKeys[i] = disperser.BlobKey{
+			BlobHash:     fmt.Sprintf("paginated-blob%d", i),
+			MetadataHash: fmt.Sprintf("hash%d", i),
+		}
+		err := blobMetadataStore.QueueNewBlobMetadata(ctx, &disperser.BlobMetadata{
+			BlobHash:     blobKeys[i].BlobHash,
+			MetadataHash: blobKeys[i].MetadataHash,
+			BlobStatus:   disperser.Failed,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+				RequestedAt:       uint64(i),
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	// Page through the metadata with a limit that doesn't divide the number of blobs
+	limit := int32(7)
+	seen := make(map[disperser.BlobKey]struct{})
+	token := ""
+	for numPages := 0; ; numPages++ {
+		assert.Less(t, numPages, numBlobs, "pagination did not terminate")
+		metadatas, nextToken, err := blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, disperser.Failed, limit, token)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(metadatas), int(limit))
+		for _, metadata := range metadatas {
+			_, ok := seen[metadata.GetBlobKey()]
+			assert.False(t, ok, "blob %s returned more than once", metadata.GetBlobKey())
+			seen[metadata.GetBlobKey()] = struct{}{}
+			// Metadata are returned in the order of the request time
+			assert.Equal(t, uint64(len(seen)-1), metadata.RequestMetadata.RequestedAt)
+		}
+		if nextToken == "" {
+			break
+		}
+		token = nextToken
+	}
+	assert.Len(t, seen, numBlobs)
+
+	// A token can only be used to continue a query of the same status
+	_, token, err := blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, disperser.Failed, limit, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	_, _, err = blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, disperser.Processing, limit, token)
+	assert.Error(t, err)
+	_, _, err = blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, disperser.Failed, limit, "invalid")
+	assert.Error(t, err)
+
+	return blobKeys
 }
 
 func deleteItems(t *testing.T, keys []commondynamodb.Key) {
@@ -158,3 +256,66 @@ func getConfirmedMetadata(t *testing.T, metadataKey disperser.BlobKey) *disperse
 		},
 	}
 }
+
+func TestBlobMetadataStoreSchemaMigration(t *testing.T) {
+	ctx := context.Background()
+	blobKey := disperser.BlobKey{
+		BlobHash:     "unversioned",
+		MetadataHash: "hash",
+	}
+	requestedAt := uint64(time.Unix(1000, 0).UnixNano())
+	metadata := getConfirmedMetadata(t, blobKey)
+	metadata.RequestMetadata.RequestedAt = requestedAt
+	// The metadata written before the schema was versioned has no version, expiry nor retry count
+	item, err := blobstore.MarshalBlobMetadata(metadata)
+	assert.NoError(t, err)
+	delete(item, "SchemaVersion")
+	delete(item, "Expiry")
+	delete(item, "NumRetries")
+	assert.NoError(t, dynamoClient.PutItem(ctx, metadataTableName, item))
+	defer deleteItems(t, []commondynamodb.Key{
+		{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+		},
+	})
+
+	// The concurrent readers upgrade the metadata, which is written once
+	store := blobstore.NewMigratingMetadataStore(blobMetadataStore, true, logger)
+	var wg sync.WaitGroup
+	upgraded := make([]*disperser.BlobMetadata, 5)
+	errs := make([]error, len(upgraded))
+	for i := range upgraded {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			upgraded[i], errs[i] = store.GetBlobMetadata(ctx, blobKey)
+		}(i)
+	}
+	wg.Wait()
+	for i := range upgraded {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, blobstore.SchemaVersion, upgraded[i].SchemaVersion)
+		assert.Equal(t, uint64(1000+3600), upgraded[i].Expiry)
+		assert.Equal(t, metadata.ConfirmationInfo.BatchHeaderHash, upgraded[i].ConfirmationInfo.BatchHeaderHash)
+	}
+
+	item, err = dynamoClient.GetItem(ctx, metadataTableName, commondynamodb.Key{
+		"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+		"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "1"}, item["SchemaVersion"])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "4600"}, item["Expiry"])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "0"}, item["NumRetries"])
+
+	// The upgrade of the stale version fails
+	_, err = blobMetadataStore.UpgradeBlobMetadata(ctx, blobKey, 0, func(*disperser.BlobMetadata) error { return nil })
+	assert.ErrorIs(t, err, blobstore.ErrSchemaVersionChanged)
+
+	assert.NoError(t, store.CheckSchemaVersion(ctx))
+	version, err := blobMetadataStore.GetSchemaVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.SchemaVersion, version)
+	assert.ErrorIs(t, blobMetadataStore.SetSchemaVersion(ctx, 0), blobstore.ErrNewerSchema)
+}
@@ -0,0 +1,128 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/urfave/cli"
+)
+
+const (
+	BlobBackendFlagName     = "blobstore.blob-backend"
+	MetadataBackendFlagName = "blobstore.metadata-backend"
+	DataDirFlagName         = "blobstore.data-dir"
+	MigrationRateFlagName   = "blobstore.migration-rate"
+
+	S3Backend       = "s3"
+	DynamoDBBackend = "dynamodb"
+	LocalBackend    = "local"
+)
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, BlobBackendFlagName),
+			Usage:  fmt.Sprintf("Backend of the blob store. Accepted options are %q and %q (filesystem, for local development)", S3Backend, LocalBackend),
+			Value:  S3Backend,
+			EnvVar: common.PrefixEnvVar(envPrefix, "BLOBSTORE_BLOB_BACKEND"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, MetadataBackendFlagName),
+			Usage:  fmt.Sprintf("Backend of the blob metadata store. Accepted options are %q and %q (embedded LevelDB, for local development; it can only be opened by a single process, so the apiserver and the batcher can't share it)", DynamoDBBackend, LocalBackend),
+			Value:  DynamoDBBackend,
+			EnvVar: common.PrefixEnvVar(envPrefix, "BLOBSTORE_METADATA_BACKEND"),
+		},
+		cli.StringFlag{
+			Name:   common.PrefixFlag(flagPrefix, DataDirFlagName),
+			Usage:  "Directory where the local blob store and metadata store keep their data",
+			Value:  "./data/blobstore",
+			EnvVar: common.PrefixEnvVar(envPrefix, "BLOBSTORE_DATA_DIR"),
+		},
+		cli.IntFlag{
+			Name:   common.PrefixFlag(flagPrefix, MigrationRateFlagName),
+			Usage:  "Number of blob metadata items per second upgraded to the schema version of the binary in the background at startup. 0 only upgrades the items as they're read",
+			Value:  0,
+			EnvVar: common.PrefixEnvVar(envPrefix, "BLOBSTORE_MIGRATION_RATE"),
+		},
+	}
+}
+
+// ReadCLIConfig reads the backend configuration of the blob store. The bucket and table names
+// are set by each binary.
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		BlobBackend:     ctx.GlobalString(common.PrefixFlag(flagPrefix, BlobBackendFlagName)),
+		MetadataBackend: ctx.GlobalString(common.PrefixFlag(flagPrefix, MetadataBackendFlagName)),
+		DataDir:         ctx.GlobalString(common.PrefixFlag(flagPrefix, DataDirFlagName)),
+		MigrationRate:   ctx.GlobalInt(common.PrefixFlag(flagPrefix, MigrationRateFlagName)),
+	}
+}
+
+// NewBlobStore creates the shared blob store on the backends selected in the config.
+// AWS clients are only created for the backends that need them. The returned function stops the background
+// migration of the metadata and closes the LevelDB database of the local metadata store, and must be called once the
+// store isn't used anymore.
+func NewBlobStore(ctx context.Context, config Config, awsConfig aws.ClientConfig, ttl time.Duration, logger common.Logger) (*SharedBlobStore, func() error, error) {
+	var objectStore s3.Client
+	switch config.BlobBackend {
+	case S3Backend, "":
+		s3Client, err := s3.NewClient(ctx, awsConfig, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		objectStore = s3Client
+	case LocalBackend:
+		localStore, err := NewLocalObjectStore(filepath.Join(config.DataDir, "blobs"))
+		if err != nil {
+			return nil, nil, err
+		}
+		objectStore = localStore
+	default:
+		return nil, nil, fmt.Errorf("unknown blob store backend: %s", config.BlobBackend)
+	}
+
+	var metadataStore MetadataStore
+	closeMetadataStore := func() error { return nil }
+	switch config.MetadataBackend {
+	case DynamoDBBackend, "":
+		dynamoClient, err := dynamodb.NewClient(awsConfig, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		metadataStore = NewBlobMetadataStore(dynamoClient, logger, config.TableName, ttl)
+	case LocalBackend:
+		localStore, err := NewLocalBlobMetadataStore(filepath.Join(config.DataDir, "metadata", config.TableName), logger, ttl)
+		if err != nil {
+			return nil, nil, err
+		}
+		metadataStore = localStore
+		closeMetadataStore = localStore.Close
+	default:
+		return nil, nil, fmt.Errorf("unknown blob metadata store backend: %s", config.MetadataBackend)
+	}
+
+	// The stores without a TTL only read the metadata, and can't set the expiry of the metadata they upgrade, so
+	// they only upgrade it as it's returned
+	migratingStore := NewMigratingMetadataStore(metadataStore, ttl > 0, logger)
+	if err := migratingStore.CheckSchemaVersion(ctx); err != nil {
+		_ = closeMetadataStore()
+		return nil, nil, err
+	}
+	migrationCtx, stopMigration := context.WithCancel(ctx)
+	if config.MigrationRate > 0 && ttl > 0 {
+		migratingStore.StartMigration(migrationCtx, config.MigrationRate)
+	}
+	closeStore := func() error {
+		stopMigration()
+		return closeMetadataStore()
+	}
+
+	logger.Info("Creating blob store", "bucket", config.BucketName, "blobBackend", config.BlobBackend, "metadataBackend", config.MetadataBackend)
+	return NewSharedStorage(config.BucketName, objectStore, migratingStore, logger), closeStore, nil
+}
@@ -0,0 +1,62 @@
+package blobstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	// maxBlobMetadataPageSize is the maximum number of metadata returned by a single paginated query,
+	// which bounds the memory used by the results
+	maxBlobMetadataPageSize = 10000
+)
+
+// statusIndexCursor is the position in the StatusIndex after which a paginated query continues.
+// It holds the attributes of the last returned item that make up the keys of the table and the index.
+type statusIndexCursor struct {
+	BlobHash     string
+	MetadataHash string
+	BlobStatus   disperser.BlobStatus
+	RequestedAt  uint64
+}
+
+// pageSize returns the number of metadata to return for the requested limit
+func pageSize(limit int32) int32 {
+	if limit <= 0 || limit > maxBlobMetadataPageSize {
+		return maxBlobMetadataPageSize
+	}
+	return limit
+}
+
+// encodeContinuationToken encodes the cursor into an opaque token that is returned to the caller
+func encodeContinuationToken(cursor *statusIndexCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeContinuationToken decodes a token returned by encodeContinuationToken and checks that it was issued
+// for a query of the given status. An empty token decodes to a nil cursor, i.e. the start of the index.
+func decodeContinuationToken(token string, status disperser.BlobStatus) (*statusIndexCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continuation token: %w", err)
+	}
+	cursor := new(statusIndexCursor)
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("invalid continuation token: %w", err)
+	}
+	if cursor.BlobStatus != status {
+		return nil, fmt.Errorf("continuation token was issued for status %s, not %s", cursor.BlobStatus, status)
+	}
+	return cursor, nil
+}
@@ -0,0 +1,515 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	schemaVersionKey     = []byte("v")
+	metadataKeyPrefix    = []byte("m")
+	statusIndexKeyPrefix = []byte("s/")
+	batchIndexKeyPrefix  = []byte("b/")
+)
+
+// expirySweepInterval is the interval at which the local store deletes the expired metadata
+const expirySweepInterval = time.Minute
+
+// ErrLocalStoreLocked is returned when the LevelDB database of the local metadata store is already open in another
+// process
+var ErrLocalStoreLocked = errors.New("the local blob metadata store is already open in another process")
+
+// LocalBlobMetadataStore is a blob metadata storage backed by LevelDB, intended for local development.
+// LevelDB locks its database, so the store can only be opened by a single process: the apiserver and the batcher can't
+// share it, and the flags of the blob store should select the DynamoDB backend for the deployments that run both.
+// It maintains the same indexes as the DynamoDB-backed BlobMetadataStore.
+// - Metadata: m/<BlobHash>/<MetadataHash> -> Metadata (JSON)
+// - Indexes
+//   - StatusIndex: s/<Status><RequestedAt>/<BlobHash>/<MetadataHash> -> nil
+//   - BatchIndex: b/<BatchHeaderHash><BlobIndex>/<BlobHash>/<MetadataHash> -> nil
+//
+// All writes are serialized, and each write applies the metadata and its index entries in a
+// single LevelDB batch, so status transitions are atomic. Index scans read from a snapshot so
+// that results are consistent with concurrent writers.
+//
+// Like the DynamoDB TTL, the metadata expires at its Expiry: the expired metadata isn't returned by the reads, and is
+// deleted by a sweep every expirySweepInterval if the store has a TTL.
+type LocalBlobMetadataStore struct {
+	db     *leveldb.DB
+	logger common.Logger
+	ttl    time.Duration
+
+	// mu serializes the read-modify-write cycle of the updates
+	mu sync.Mutex
+
+	// stopSweep stops the sweep of the expired metadata, which is done once sweepDone is
+	stopSweep context.CancelFunc
+	sweepDone sync.WaitGroup
+}
+
+var _ MetadataStore = (*LocalBlobMetadataStore)(nil)
+
+// NewLocalBlobMetadataStore opens the local metadata store at the path. It fails with ErrLocalStoreLocked if the store
+// is already open in another process.
+func NewLocalBlobMetadataStore(path string, logger common.Logger, ttl time.Duration) (*LocalBlobMetadataStore, error) {
+	logger.Debugf("creating local blob metadata store at %s with TTL: %s", path, ttl)
+	db, err := leveldb.OpenFile(path, nil)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return nil, fmt.Errorf("%w: %s can only be used by a single process, use the %s metadata backend to run several: %w", ErrLocalStoreLocked, path, DynamoDBBackend, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stopSweep := context.WithCancel(context.Background())
+	s := &LocalBlobMetadataStore{
+		db:        db,
+		logger:    logger,
+		ttl:       ttl,
+		stopSweep: stopSweep,
+	}
+	if ttl > 0 {
+		s.sweepDone.Add(1)
+		go s.sweepExpired(ctx)
+	}
+	return s, nil
+}
+
+// Close stops the sweep of the expired metadata and closes the database
+func (s *LocalBlobMetadataStore) Close() error {
+	s.stopSweep()
+	s.sweepDone.Wait()
+	return s.db.Close()
+}
+
+func (s *LocalBlobMetadataStore) TTL() time.Duration {
+	return s.ttl
+}
+
+func (s *LocalBlobMetadataStore) QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The expired metadata is replaced along with its index entries
+	existing, err := s.getStoredMetadata(s.db, blobMetadata.GetBlobKey())
+	if err != nil && !errors.Is(err, disperser.ErrBlobNotFound) {
+		return err
+	}
+	return s.write(existing, blobMetadata)
+}
+
+func (s *LocalBlobMetadataStore) GetBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	return s.getMetadata(s.db, metadataKey)
+}
+
+// GetBlobMetadataByStatus returns all the metadata with the given status ordered by the request time
+func (s *LocalBlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error) {
+	metadatas, _, err := s.scanIndex(statusIndexPrefix(status), 8, nil, 0)
+	return metadatas, err
+}
+
+// GetBlobMetadataByStatusWithPagination returns up to limit metadata with the given status ordered by the request time,
+// starting after the position encoded in continuationToken (or from the beginning if it is empty).
+// It returns the token to fetch the next page, which is empty if there are no more metadata.
+func (s *LocalBlobMetadataStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, limit int32, continuationToken string) ([]*disperser.BlobMetadata, string, error) {
+	cursor, err := decodeContinuationToken(continuationToken, status)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var exclusiveStartKey []byte
+	if cursor != nil {
+		exclusiveStartKey = statusIndexKey(status, cursor.RequestedAt, disperser.BlobKey{
+			BlobHash:     cursor.BlobHash,
+			MetadataHash: cursor.MetadataHash,
+		})
+	}
+
+	metadatas, more, err := s.scanIndex(statusIndexPrefix(status), 8, exclusiveStartKey, int(pageSize(limit)))
+	if err != nil {
+		return nil, "", err
+	}
+	if !more {
+		return metadatas, "", nil
+	}
+
+	last := metadatas[len(metadatas)-1]
+	var requestedAt uint64
+	if last.RequestMetadata != nil {
+		requestedAt = last.RequestMetadata.RequestedAt
+	}
+	nextToken, err := encodeContinuationToken(&statusIndexCursor{
+		BlobHash:     last.BlobHash,
+		MetadataHash: last.MetadataHash,
+		BlobStatus:   status,
+		RequestedAt:  requestedAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return metadatas, nextToken, nil
+}
+
+func (s *LocalBlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	metadatas, _, err := s.scanIndex(batchIndexPrefix(batchHeaderHash), 4, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(metadatas) == 0 {
+		return nil, fmt.Errorf("there is no metadata for batch %x", batchHeaderHash)
+	}
+
+	return metadatas, nil
+}
+
+func (s *LocalBlobMetadataStore) GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
+	metadatas, _, err := s.scanIndex(batchIndexPrefix(batchHeaderHash, blobIndex), 0, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(metadatas) == 0 {
+		return nil, fmt.Errorf("there is no metadata for batch %x and blob index %d", batchHeaderHash, blobIndex)
+	}
+
+	if len(metadatas) > 1 {
+		s.logger.Error("there are multiple metadata for batch and blob index", "batchHeaderHash", batchHeaderHash, "blobIndex", blobIndex)
+	}
+
+	return metadatas[0], nil
+}
+
+func (s *LocalBlobMetadataStore) IncrementNumRetries(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
+	return s.update(existingMetadata.GetBlobKey(), func(metadata *disperser.BlobMetadata) {
+		metadata.NumRetries = existingMetadata.NumRetries + 1
+	})
+}
+
+func (s *LocalBlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, updated *disperser.BlobMetadata) error {
+	return s.update(metadataKey, func(metadata *disperser.BlobMetadata) {
+		*metadata = *updated
+	})
+}
+
+func (s *LocalBlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error {
+	return s.update(metadataKey, func(metadata *disperser.BlobMetadata) {
+		metadata.BlobStatus = status
+	})
+}
+
+// UpgradeBlobMetadata upgrades the metadata of the schema version fromVersion atomically, failing with
+// ErrSchemaVersionChanged if the metadata was upgraded concurrently
+func (s *LocalBlobMetadataStore) UpgradeBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, fromVersion uint32, upgrade func(*disperser.BlobMetadata) error) (*disperser.BlobMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.getMetadata(s.db, metadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing.SchemaVersion != fromVersion {
+		return nil, ErrSchemaVersionChanged
+	}
+
+	updated := *existing
+	if err := upgrade(&updated); err != nil {
+		return nil, err
+	}
+	updated.BlobHash = metadataKey.BlobHash
+	updated.MetadataHash = metadataKey.MetadataHash
+	if err := s.write(existing, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// GetSchemaVersion returns the schema version of the store, which is 0 if it was never set
+func (s *LocalBlobMetadataStore) GetSchemaVersion(ctx context.Context) (uint32, error) {
+	value, err := s.db.Get(schemaVersionKey, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 4 {
+		return 0, fmt.Errorf("invalid schema version %x", value)
+	}
+	return binary.BigEndian.Uint32(value), nil
+}
+
+// SetSchemaVersion raises the schema version of the store to the version. It fails with ErrNewerSchema if the schema
+// version of the store is already newer.
+func (s *LocalBlobMetadataStore) SetSchemaVersion(ctx context.Context, version uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.GetSchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current > version {
+		return fmt.Errorf("%w: the schema version of the store is %d, above %d", ErrNewerSchema, current, version)
+	}
+	return s.db.Put(schemaVersionKey, binary.BigEndian.AppendUint32(nil, version), nil)
+}
+
+// DeleteExpiredBlobMetadata deletes the metadata that expired and its index entries, and returns the number of
+// metadata deleted
+func (s *LocalBlobMetadataStore) DeleteExpiredBlobMetadata(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	iter := s.db.NewIterator(util.BytesPrefix(metadataKeyPrefix), nil)
+	defer iter.Release()
+
+	now := time.Now()
+	batch := new(leveldb.Batch)
+	deleted := 0
+	for iter.Next() {
+		metadata := new(disperser.BlobMetadata)
+		if err := json.Unmarshal(iter.Value(), metadata); err != nil {
+			return 0, err
+		}
+		if !expired(metadata, now) {
+			continue
+		}
+		batch.Delete(metadataKey(metadata.GetBlobKey()))
+		for _, key := range indexKeys(metadata) {
+			batch.Delete(key)
+		}
+		deleted++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+	return deleted, s.db.Write(batch, nil)
+}
+
+// sweepExpired deletes the expired metadata every expirySweepInterval until the context is done
+func (s *LocalBlobMetadataStore) sweepExpired(ctx context.Context) {
+	defer s.sweepDone.Done()
+
+	ticker := time.NewTicker(expirySweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.DeleteExpiredBlobMetadata(ctx)
+			if err != nil {
+				s.logger.Error("failed to delete the expired blob metadata", "err", err)
+				continue
+			}
+			if deleted > 0 {
+				s.logger.Debug("deleted the expired blob metadata", "count", deleted)
+			}
+		}
+	}
+}
+
+// update applies the given mutation to the stored metadata atomically
+func (s *LocalBlobMetadataStore) update(metadataKey disperser.BlobKey, mutate func(*disperser.BlobMetadata)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.getMetadata(s.db, metadataKey)
+	if err != nil {
+		return err
+	}
+
+	updated := *existing
+	mutate(&updated)
+	// The metadata is keyed by the blob key, which cannot be changed by an update
+	updated.BlobHash = metadataKey.BlobHash
+	updated.MetadataHash = metadataKey.MetadataHash
+	return s.write(existing, &updated)
+}
+
+// write replaces the existing metadata (nil if there is none) and its index entries with the updated one
+// in a single batch. It must be called with s.mu held.
+func (s *LocalBlobMetadataStore) write(existing *disperser.BlobMetadata, updated *disperser.BlobMetadata) error {
+	value, err := json.Marshal(updated)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	if existing != nil {
+		for _, key := range indexKeys(existing) {
+			batch.Delete(key)
+		}
+	}
+	batch.Put(metadataKey(updated.GetBlobKey()), value)
+	for _, key := range indexKeys(updated) {
+		batch.Put(key, nil)
+	}
+
+	return s.db.Write(batch, nil)
+}
+
+// scanIndex returns the metadata of the index entries with the given prefix, where sortKeyLen
+// is the length of the remaining part of the sort key not covered by the prefix.
+// The scan starts after exclusiveStartKey if it is not nil, and stops after limit entries if limit is positive,
+// in which case it also returns whether there are more entries left.
+// The index and the metadata are read from the same snapshot, and the expired metadata is skipped.
+func (s *LocalBlobMetadataStore) scanIndex(prefix []byte, sortKeyLen int, exclusiveStartKey []byte, limit int) ([]*disperser.BlobMetadata, bool, error) {
+	snapshot, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, false, err
+	}
+	defer snapshot.Release()
+
+	keyRange := util.BytesPrefix(prefix)
+	if exclusiveStartKey != nil {
+		keyRange.Start = exclusiveStartKey
+	}
+	iter := snapshot.NewIterator(keyRange, nil)
+	defer iter.Release()
+
+	metadatas := make([]*disperser.BlobMetadata, 0)
+	more := false
+	for iter.Next() {
+		if exclusiveStartKey != nil && bytes.Equal(iter.Key(), exclusiveStartKey) {
+			continue
+		}
+		if limit > 0 && len(metadatas) == limit {
+			more = true
+			break
+		}
+		blobKey, err := parseIndexKey(iter.Key()[len(prefix):], sortKeyLen)
+		if err != nil {
+			return nil, false, err
+		}
+		metadata, err := s.getMetadata(snapshot, blobKey)
+		if errors.Is(err, disperser.ErrBlobNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		metadatas = append(metadatas, metadata)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, false, err
+	}
+
+	return metadatas, more, nil
+}
+
+type leveldbReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+}
+
+// getMetadata returns the metadata of the blob key, failing with ErrBlobNotFound if there is none or it expired
+func (s *LocalBlobMetadataStore) getMetadata(reader leveldbReader, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	metadata, err := s.getStoredMetadata(reader, blobKey)
+	if err != nil {
+		return nil, err
+	}
+	if expired(metadata, time.Now()) {
+		return nil, disperser.ErrBlobNotFound
+	}
+	return metadata, nil
+}
+
+// getStoredMetadata returns the metadata of the blob key even if it expired, as long as it wasn't deleted yet
+func (s *LocalBlobMetadataStore) getStoredMetadata(reader leveldbReader, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	value, err := reader.Get(metadataKey(blobKey), nil)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, disperser.ErrBlobNotFound
+		}
+		return nil, err
+	}
+
+	metadata := new(disperser.BlobMetadata)
+	if err := json.Unmarshal(value, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// expired returns whether the metadata expired at the time, where a zero Expiry never expires
+func expired(metadata *disperser.BlobMetadata, now time.Time) bool {
+	return metadata.Expiry != 0 && metadata.Expiry <= uint64(now.Unix())
+}
+
+func metadataKey(blobKey disperser.BlobKey) []byte {
+	return append(append([]byte{}, metadataKeyPrefix...), blobKeySuffix(blobKey)...)
+}
+
+func statusIndexPrefix(status disperser.BlobStatus) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte{}, statusIndexKeyPrefix...), uint32(status))
+}
+
+func statusIndexKey(status disperser.BlobStatus, requestedAt uint64, blobKey disperser.BlobKey) []byte {
+	// The request time is encoded in big endian so that entries of the same status are sorted by it
+	key := binary.BigEndian.AppendUint64(statusIndexPrefix(status), requestedAt)
+	return append(key, blobKeySuffix(blobKey)...)
+}
+
+// batchIndexPrefix returns the prefix of the batch index entries for the given batch and,
+// if provided, the blob index within the batch.
+func batchIndexPrefix(batchHeaderHash [32]byte, blobIndex ...uint32) []byte {
+	prefix := append(append([]byte{}, batchIndexKeyPrefix...), batchHeaderHash[:]...)
+	for _, index := range blobIndex {
+		prefix = binary.BigEndian.AppendUint32(prefix, index)
+	}
+	return prefix
+}
+
+func indexKeys(metadata *disperser.BlobMetadata) [][]byte {
+	suffix := blobKeySuffix(metadata.GetBlobKey())
+
+	var requestedAt uint64
+	if metadata.RequestMetadata != nil {
+		requestedAt = metadata.RequestMetadata.RequestedAt
+	}
+	keys := [][]byte{statusIndexKey(metadata.BlobStatus, requestedAt, metadata.GetBlobKey())}
+
+	if metadata.ConfirmationInfo != nil {
+		batchKey := batchIndexPrefix(metadata.ConfirmationInfo.BatchHeaderHash, metadata.ConfirmationInfo.BlobIndex)
+		keys = append(keys, append(batchKey, suffix...))
+	}
+	return keys
+}
+
+func blobKeySuffix(blobKey disperser.BlobKey) []byte {
+	return []byte(fmt.Sprintf("/%s/%s", blobKey.BlobHash, blobKey.MetadataHash))
+}
+
+// parseIndexKey extracts the blob key from the remainder of an index key after the scanned prefix
+// and the sortKeyLen bytes of the sort key that wasn't part of the prefix
+func parseIndexKey(key []byte, sortKeyLen int) (disperser.BlobKey, error) {
+	if len(key) < sortKeyLen {
+		return disperser.BlobKey{}, fmt.Errorf("invalid index key %x", key)
+	}
+	parts := strings.Split(string(key[sortKeyLen:]), "/")
+	if len(parts) != 3 || parts[0] != "" {
+		return disperser.BlobKey{}, fmt.Errorf("invalid index key %x", key)
+	}
+	return disperser.BlobKey{
+		BlobHash:     parts[1],
+		MetadataHash: parts[2],
+	}, nil
+}
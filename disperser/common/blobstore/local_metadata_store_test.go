@@ -0,0 +1,232 @@
+package blobstore_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBlobMetadataStoreConcurrentStatusUpdates(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+
+	numBlobs := 50
+	keys := make([]disperser.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		keys[i] = disperser.BlobKey{
+			BlobHash:     fmt.Sprintf("blob%d", i),
+			MetadataHash: fmt.Sprintf("hash%d", i),
+		}
+		err := localStore.QueueNewBlobMetadata(ctx, &disperser.BlobMetadata{
+			BlobHash:     keys[i].BlobHash,
+			MetadataHash: keys[i].MetadataHash,
+			BlobStatus:   disperser.Processing,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+				RequestedAt:       uint64(i),
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	done := make(chan struct{})
+	readerErrs := make(chan error, 1)
+	go func() {
+		defer close(readerErrs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			metadatas, err := localStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+			if err != nil {
+				readerErrs <- err
+				return
+			}
+			seen := make(map[disperser.BlobKey]struct{})
+			for _, metadata := range metadatas {
+				if metadata.BlobStatus != disperser.Processing {
+					readerErrs <- fmt.Errorf("listed blob %s with status %s", metadata.GetBlobKey(), metadata.BlobStatus)
+					return
+				}
+				if _, ok := seen[metadata.GetBlobKey()]; ok {
+					readerErrs <- fmt.Errorf("listed blob %s more than once", metadata.GetBlobKey())
+					return
+				}
+				seen[metadata.GetBlobKey()] = struct{}{}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key disperser.BlobKey) {
+			defer wg.Done()
+			for _, status := range []disperser.BlobStatus{disperser.Failed, disperser.Processing, disperser.Finalized} {
+				assert.NoError(t, localStore.SetBlobStatus(ctx, key, status))
+			}
+		}(key)
+	}
+	wg.Wait()
+	close(done)
+	assert.NoError(t, <-readerErrs)
+
+	processing, err := localStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, processing, 0)
+	finalized, err := localStore.GetBlobMetadataByStatus(ctx, disperser.Finalized)
+	assert.NoError(t, err)
+	assert.Len(t, finalized, numBlobs)
+	for i, metadata := range finalized {
+		// Entries of the same status are ordered by request time
+		assert.Equal(t, uint64(i), metadata.RequestMetadata.RequestedAt)
+	}
+
+	_, err = localStore.GetBlobMetadata(ctx, disperser.BlobKey{BlobHash: "missing", MetadataHash: "missing"})
+	assert.ErrorIs(t, err, disperser.ErrBlobNotFound)
+}
+
+func TestLocalBlobMetadataStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+
+	newMetadata := func(blobHash string, expiry uint64) *disperser.BlobMetadata {
+		return &disperser.BlobMetadata{
+			BlobHash:     blobHash,
+			MetadataHash: "hash",
+			BlobStatus:   disperser.Processing,
+			Expiry:       expiry,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+			},
+		}
+	}
+	expiredMetadata := newMetadata("expired", uint64(time.Now().Add(-time.Minute).Unix()))
+	liveMetadata := newMetadata("live", uint64(time.Now().Add(time.Hour).Unix()))
+	for _, metadata := range []*disperser.BlobMetadata{expiredMetadata, liveMetadata, newMetadata("unbounded", 0)} {
+		assert.NoError(t, localStore.QueueNewBlobMetadata(ctx, metadata))
+	}
+
+	// The expired metadata isn't returned before it's deleted
+	_, err = localStore.GetBlobMetadata(ctx, expiredMetadata.GetBlobKey())
+	assert.ErrorIs(t, err, disperser.ErrBlobNotFound)
+	assert.ErrorIs(t, localStore.SetBlobStatus(ctx, expiredMetadata.GetBlobKey(), disperser.Failed), disperser.ErrBlobNotFound)
+	metadatas, err := localStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 2)
+
+	deleted, err := localStore.DeleteExpiredBlobMetadata(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	deleted, err = localStore.DeleteExpiredBlobMetadata(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	metadata, err := localStore.GetBlobMetadata(ctx, liveMetadata.GetBlobKey())
+	assert.NoError(t, err)
+	assert.Equal(t, liveMetadata.Expiry, metadata.Expiry)
+
+	// The metadata queued again after it expired replaces the expired one and its index entries
+	assert.NoError(t, localStore.QueueNewBlobMetadata(ctx, expiredMetadata))
+	requeued := newMetadata("expired", 0)
+	requeued.BlobStatus = disperser.Failed
+	assert.NoError(t, localStore.QueueNewBlobMetadata(ctx, requeued))
+	metadatas, err = localStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 2)
+	metadatas, err = localStore.GetBlobMetadataByStatus(ctx, disperser.Failed)
+	assert.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{requeued}, metadatas)
+}
+
+func TestLocalBlobMetadataStoreSingleProcess(t *testing.T) {
+	path := t.TempDir()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(path, logger, time.Hour)
+	assert.NoError(t, err)
+
+	// LevelDB locks the database of the store, which can't be opened again until it's closed
+	_, err = blobstore.NewLocalBlobMetadataStore(path, logger, time.Hour)
+	assert.ErrorIs(t, err, blobstore.ErrLocalStoreLocked)
+	assert.ErrorContains(t, err, "can only be used by a single process")
+
+	assert.NoError(t, localStore.Close())
+	localStore, err = blobstore.NewLocalBlobMetadataStore(path, logger, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, localStore.Close())
+}
+
+func TestLocalObjectStore(t *testing.T) {
+	ctx := context.Background()
+	objectStore, err := blobstore.NewLocalObjectStore(t.TempDir())
+	assert.NoError(t, err)
+
+	_, err = objectStore.DownloadObject(ctx, bucketName, "blob/missing.json")
+	assert.ErrorIs(t, err, s3.ErrObjectNotFound)
+
+	err = objectStore.UploadObject(ctx, bucketName, "blob/a.json", strings.NewReader("a"))
+	assert.NoError(t, err)
+	err = objectStore.UploadObject(ctx, bucketName, "blob/b.json", strings.NewReader("bb"))
+	assert.NoError(t, err)
+	err = objectStore.UploadObject(ctx, bucketName, "other/c.json", strings.NewReader("ccc"))
+	assert.NoError(t, err)
+
+	content, err := objectStore.DownloadObject(ctx, bucketName, "blob/a.json")
+	assert.NoError(t, err)
+	data, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, []byte("a"), data)
+
+	objects, err := objectStore.ListObjects(ctx, bucketName, "blob/")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []s3.Object{{Key: "blob/a.json", Size: 1}, {Key: "blob/b.json", Size: 2}}, objects)
+
+	err = objectStore.DeleteObject(ctx, bucketName, "blob/a.json")
+	assert.NoError(t, err)
+	_, err = objectStore.DownloadObject(ctx, bucketName, "blob/a.json")
+	assert.ErrorIs(t, err, s3.ErrObjectNotFound)
+
+	err = objectStore.UploadObject(ctx, bucketName, "../escape.json", strings.NewReader("x"))
+	assert.Error(t, err)
+}
+
+func TestNewBlobStoreLocalClose(t *testing.T) {
+	ctx := context.Background()
+	config := blobstore.Config{
+		BucketName:      "bucket",
+		TableName:       "table",
+		BlobBackend:     blobstore.LocalBackend,
+		MetadataBackend: blobstore.LocalBackend,
+		DataDir:         t.TempDir(),
+	}
+	store, closeStore, err := blobstore.NewBlobStore(ctx, config, aws.ClientConfig{}, time.Hour, logger)
+	assert.NoError(t, err)
+	key, err := store.StoreBlob(ctx, blob, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, closeStore())
+
+	// The database of the metadata is released once the store is closed, so it can be opened again
+	store, closeStore, err = blobstore.NewBlobStore(ctx, config, aws.ClientConfig{}, time.Hour, logger)
+	assert.NoError(t, err)
+	defer closeStore()
+	metadata, err := store.GetBlobMetadata(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+}
@@ -0,0 +1,135 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+)
+
+// LocalObjectStore is an implementation of s3.Client backed by the local filesystem, intended for
+// local development. Each object is stored as a file at <dataDir>/<bucket>/<key>. Since SharedBlobStore
+// keys the blobs by their hash, the blob files are content-addressed.
+//
+// Objects are streamed to a temporary file and then renamed into place, so readers never observe a
+// partially written object. The downloads stream the file, which stays readable if the object is replaced or deleted.
+type LocalObjectStore struct {
+	dataDir string
+}
+
+var _ s3.Client = (*LocalObjectStore)(nil)
+
+func NewLocalObjectStore(dataDir string) (*LocalObjectStore, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return &LocalObjectStore{dataDir: dataDir}, nil
+}
+
+func (s *LocalObjectStore) DownloadObject(ctx context.Context, bucket string, key string) (io.ReadCloser, error) {
+	path, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, s3.ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *LocalObjectStore) UploadObject(ctx context.Context, bucket string, key string, body io.Reader) error {
+	path, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalObjectStore) DeleteObject(ctx context.Context, bucket string, key string) error {
+	path, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalObjectStore) ListObjects(ctx context.Context, bucket string, prefix string) ([]s3.Object, error) {
+	bucketDir := filepath.Join(s.dataDir, bucket)
+	objects := make([]s3.Object, 0)
+	err := filepath.WalkDir(bucketDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketDir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, s3.Object{Key: key, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// objectPath returns the path of the file holding the object, making sure it doesn't escape the bucket directory
+func (s *LocalObjectStore) objectPath(bucket string, key string) (string, error) {
+	bucketDir := filepath.Join(s.dataDir, bucket)
+	path := filepath.Join(bucketDir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, bucketDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key %s in bucket %s", key, bucket)
+	}
+	return path, nil
+}
@@ -0,0 +1,265 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// SchemaVersion is the version of the schema of the blob metadata written by this binary. The metadata of the older
+// versions is upgraded by the migrations, and the stores of the newer versions are refused, see
+// MigratingMetadataStore.
+const SchemaVersion uint32 = 1
+
+// migrationPageSize is the maximum number of metadata read at once by the background migration
+const migrationPageSize = 100
+
+var (
+	// ErrNewerSchema is returned for the stores and the metadata of a schema version above SchemaVersion, which this
+	// binary doesn't understand
+	ErrNewerSchema = errors.New("the blob metadata schema is newer than the one of this binary")
+	// ErrSchemaVersionChanged is returned by the upgrades of the metadata that isn't of the version they upgrade from
+	// anymore, e.g. as it was upgraded concurrently
+	ErrSchemaVersionChanged = errors.New("the schema version of the blob metadata changed")
+)
+
+// Migration upgrades the blob metadata of the schema version before Version to Version
+type Migration struct {
+	Version     uint32
+	Description string
+	// Upgrade upgrades the metadata in place, with the TTL of the store, which is 0 if the metadata doesn't expire
+	Upgrade func(metadata *disperser.BlobMetadata, ttl time.Duration) error
+}
+
+// migrations are the migrations up to SchemaVersion, in the order of their versions
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "set the expiry and the retry count of the metadata written before they were added",
+		Upgrade:     addExpiryAndRetries,
+	},
+}
+
+// addExpiryAndRetries sets the expiry of the metadata written before it had one to the TTL after the request of the
+// blob. The confirmed and finalized blobs are retained for at least the TTL after the migration, so that the blobs
+// requested more than the TTL ago don't expire as soon as they're migrated. The retry count of that metadata reads as
+// 0, and is written along with the expiry.
+func addExpiryAndRetries(metadata *disperser.BlobMetadata, ttl time.Duration) error {
+	if metadata.Expiry != 0 || ttl == 0 {
+		return nil
+	}
+	if metadata.RequestMetadata == nil {
+		return fmt.Errorf("blob %s has no request metadata", metadata.GetBlobKey().String())
+	}
+	expiry := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt)).Add(ttl)
+	if metadata.BlobStatus == disperser.Confirmed || metadata.BlobStatus == disperser.Finalized {
+		if migrated := time.Now().Add(ttl); migrated.After(expiry) {
+			expiry = migrated
+		}
+	}
+	metadata.Expiry = uint64(expiry.Unix())
+	return nil
+}
+
+// upgradeMetadata applies the migrations above the schema version of the metadata to it
+func upgradeMetadata(metadata *disperser.BlobMetadata, ttl time.Duration) error {
+	if metadata.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("%w: blob %s is of schema version %d, above %d", ErrNewerSchema, metadata.GetBlobKey().String(), metadata.SchemaVersion, SchemaVersion)
+	}
+	for _, migration := range migrations {
+		if migration.Version <= metadata.SchemaVersion {
+			continue
+		}
+		if err := migration.Upgrade(metadata, ttl); err != nil {
+			return fmt.Errorf("failed to migrate blob %s to schema version %d: %w", metadata.GetBlobKey().String(), migration.Version, err)
+		}
+		metadata.SchemaVersion = migration.Version
+	}
+	return nil
+}
+
+// MigratingMetadataStore upgrades the metadata of the older schema versions read from the store to SchemaVersion.
+// The upgrades are written back to the store if they're persisted, conditioned on the schema version of the stored
+// metadata so that the concurrent upgrades of the same metadata are only written once. Migrate upgrades the rest of
+// the metadata in the background.
+type MigratingMetadataStore struct {
+	MetadataStore
+	logger common.Logger
+	// persist writes the upgrades to the store, otherwise the metadata is only upgraded as it's returned
+	persist bool
+}
+
+var _ MetadataStore = (*MigratingMetadataStore)(nil)
+
+func NewMigratingMetadataStore(store MetadataStore, persist bool, logger common.Logger) *MigratingMetadataStore {
+	return &MigratingMetadataStore{
+		MetadataStore: store,
+		logger:        logger,
+		persist:       persist,
+	}
+}
+
+// CheckSchemaVersion fails with ErrNewerSchema if the store is of a schema version above SchemaVersion, which this
+// binary doesn't understand. If the upgrades are persisted, the schema version of the store is raised to
+// SchemaVersion so that the binaries of the older versions refuse it.
+func (s *MigratingMetadataStore) CheckSchemaVersion(ctx context.Context) error {
+	version, err := s.GetSchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the schema version of the blob metadata store: %w", err)
+	}
+	if version > SchemaVersion {
+		return fmt.Errorf("%w: the blob metadata store is of schema version %d, above %d", ErrNewerSchema, version, SchemaVersion)
+	}
+	if version == SchemaVersion || !s.persist {
+		return nil
+	}
+	if err := s.SetSchemaVersion(ctx, SchemaVersion); err != nil {
+		return err
+	}
+	s.logger.Info("Raised the schema version of the blob metadata store", "from", version, "to", SchemaVersion)
+	return nil
+}
+
+func (s *MigratingMetadataStore) GetBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	metadata, err := s.MetadataStore.GetBlobMetadata(ctx, metadataKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.upgrade(ctx, metadata)
+}
+
+func (s *MigratingMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error) {
+	metadatas, err := s.MetadataStore.GetBlobMetadataByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return s.upgradeAll(ctx, metadatas)
+}
+
+func (s *MigratingMetadataStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, limit int32, continuationToken string) ([]*disperser.BlobMetadata, string, error) {
+	metadatas, nextToken, err := s.MetadataStore.GetBlobMetadataByStatusWithPagination(ctx, status, limit, continuationToken)
+	if err != nil {
+		return nil, "", err
+	}
+	metadatas, err = s.upgradeAll(ctx, metadatas)
+	if err != nil {
+		return nil, "", err
+	}
+	return metadatas, nextToken, nil
+}
+
+func (s *MigratingMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	metadatas, err := s.MetadataStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	return s.upgradeAll(ctx, metadatas)
+}
+
+func (s *MigratingMetadataStore) GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
+	metadata, err := s.MetadataStore.GetBlobMetadataInBatch(ctx, batchHeaderHash, blobIndex)
+	if err != nil {
+		return nil, err
+	}
+	return s.upgrade(ctx, metadata)
+}
+
+// Migrate upgrades the stored metadata of the older schema versions, of every status, up to rate metadata per second.
+// It returns the number of metadata it upgraded. The metadata whose status changes during the migration may be
+// skipped, and is upgraded as it's read.
+func (s *MigratingMetadataStore) Migrate(ctx context.Context, rate int) (int, error) {
+	if !s.persist {
+		return 0, errors.New("the upgrades of the blob metadata aren't persisted")
+	}
+	if rate <= 0 || rate > int(time.Second) {
+		return 0, fmt.Errorf("invalid migration rate %d: must be between 1 and %d", rate, int(time.Second))
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	numUpgraded := 0
+	for status := disperser.Processing; status <= disperser.Deduplicated; status++ {
+		continuationToken := ""
+		for {
+			metadatas, nextToken, err := s.MetadataStore.GetBlobMetadataByStatusWithPagination(ctx, status, migrationPageSize, continuationToken)
+			if err != nil {
+				return numUpgraded, fmt.Errorf("failed to list the blob metadata of status %s: %w", status, err)
+			}
+			for _, metadata := range metadatas {
+				if metadata.SchemaVersion == SchemaVersion {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return numUpgraded, ctx.Err()
+				case <-ticker.C:
+				}
+				if _, err := s.upgrade(ctx, metadata); err != nil {
+					return numUpgraded, err
+				}
+				numUpgraded++
+			}
+			if nextToken == "" {
+				break
+			}
+			continuationToken = nextToken
+		}
+	}
+	return numUpgraded, nil
+}
+
+// StartMigration runs Migrate in the background
+func (s *MigratingMetadataStore) StartMigration(ctx context.Context, rate int) {
+	go func() {
+		s.logger.Info("Migrating the blob metadata", "schemaVersion", SchemaVersion, "rate", rate)
+		numUpgraded, err := s.Migrate(ctx, rate)
+		if err != nil {
+			s.logger.Error("Failed to migrate the blob metadata", "upgraded", numUpgraded, "err", err)
+			return
+		}
+		s.logger.Info("Migrated the blob metadata", "upgraded", numUpgraded)
+	}()
+}
+
+func (s *MigratingMetadataStore) upgradeAll(ctx context.Context, metadatas []*disperser.BlobMetadata) ([]*disperser.BlobMetadata, error) {
+	for i, metadata := range metadatas {
+		upgraded, err := s.upgrade(ctx, metadata)
+		if err != nil {
+			return nil, err
+		}
+		metadatas[i] = upgraded
+	}
+	return metadatas, nil
+}
+
+// upgrade returns the metadata upgraded to SchemaVersion, writing the upgrade to the store if it's persisted
+func (s *MigratingMetadataStore) upgrade(ctx context.Context, metadata *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+	if metadata.SchemaVersion == SchemaVersion {
+		return metadata, nil
+	}
+	if !s.persist {
+		if err := upgradeMetadata(metadata, s.TTL()); err != nil {
+			return nil, err
+		}
+		return metadata, nil
+	}
+
+	upgraded, err := s.UpgradeBlobMetadata(ctx, metadata.GetBlobKey(), metadata.SchemaVersion, func(stored *disperser.BlobMetadata) error {
+		return upgradeMetadata(stored, s.TTL())
+	})
+	if errors.Is(err, ErrSchemaVersionChanged) {
+		// The metadata was upgraded since it was read, e.g. by another reader, so the upgraded one is read again
+		upgraded, err = s.MetadataStore.GetBlobMetadata(ctx, metadata.GetBlobKey())
+		if err == nil {
+			err = upgradeMetadata(upgraded, s.TTL())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return upgraded, nil
+}
@@ -0,0 +1,247 @@
+package blobstore_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// requestTime is the request time of the metadata queued by queueMixedVersions, whose expiry after the migration is
+	// the TTL after it
+	requestTime = time.Now().Truncate(time.Second)
+	// currentExpiry is the expiry of the metadata of the current schema version queued by queueMixedVersions
+	currentExpiry = uint64(requestTime.Add(2 * time.Hour).Unix())
+)
+
+// queueMixedVersions queues metadata of the blobs alternating between the metadata written before the schema was
+// versioned, without expiry, and the metadata of the current schema version. It returns the keys of the blobs.
+func queueMixedVersions(t *testing.T, store blobstore.MetadataStore, numBlobs int, status disperser.BlobStatus) []disperser.BlobKey {
+	keys := make([]disperser.BlobKey, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		keys[i] = disperser.BlobKey{
+			BlobHash:     fmt.Sprintf("blob%d", i),
+			MetadataHash: fmt.Sprintf("hash%d", i),
+		}
+		metadata := &disperser.BlobMetadata{
+			BlobHash:     keys[i].BlobHash,
+			MetadataHash: keys[i].MetadataHash,
+			BlobStatus:   status,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+				RequestedAt:       uint64(requestTime.UnixNano()) + uint64(i),
+			},
+		}
+		if i%2 == 1 {
+			metadata.Expiry = currentExpiry
+			metadata.NumRetries = 1
+			metadata.SchemaVersion = blobstore.SchemaVersion
+		}
+		assert.NoError(t, store.QueueNewBlobMetadata(context.Background(), metadata))
+	}
+	return keys
+}
+
+func TestMigratingMetadataStoreMixedVersions(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+	keys := queueMixedVersions(t, localStore, 10, disperser.Processing)
+	store := blobstore.NewMigratingMetadataStore(localStore, true, logger)
+
+	metadatas, err := store.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 10)
+	for i, metadata := range metadatas {
+		assert.Equal(t, blobstore.SchemaVersion, metadata.SchemaVersion)
+		if i%2 == 0 {
+			// The expiry of the old metadata is the TTL after its request
+			assert.Equal(t, uint64(requestTime.Add(time.Hour).Unix()), metadata.Expiry)
+			assert.Equal(t, uint(0), metadata.NumRetries)
+		} else {
+			assert.Equal(t, currentExpiry, metadata.Expiry)
+			assert.Equal(t, uint(1), metadata.NumRetries)
+		}
+	}
+
+	// The upgrades are written to the store
+	for i, key := range keys {
+		stored, err := localStore.GetBlobMetadata(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, blobstore.SchemaVersion, stored.SchemaVersion)
+		if i%2 == 0 {
+			assert.Equal(t, uint64(requestTime.Add(time.Hour).Unix()), stored.Expiry)
+		}
+	}
+
+	// The metadata of a newer schema version isn't understood
+	newer := *metadatas[0]
+	newer.BlobHash = "newer"
+	newer.SchemaVersion = blobstore.SchemaVersion + 1
+	assert.NoError(t, localStore.QueueNewBlobMetadata(ctx, &newer))
+	_, err = store.GetBlobMetadata(ctx, newer.GetBlobKey())
+	assert.ErrorIs(t, err, blobstore.ErrNewerSchema)
+}
+
+func TestMigratingMetadataStoreConfirmedExpiry(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+	keys := queueMixedVersions(t, localStore, 1, disperser.Confirmed)
+	store := blobstore.NewMigratingMetadataStore(localStore, true, logger)
+
+	// The blob was requested long before the TTL, and is retained for the TTL after the migration
+	before := time.Now().Add(time.Hour)
+	metadata, err := store.GetBlobMetadata(ctx, keys[0])
+	assert.NoError(t, err)
+	after := time.Now().Add(time.Hour)
+	assert.Equal(t, blobstore.SchemaVersion, metadata.SchemaVersion)
+	assert.GreaterOrEqual(t, metadata.Expiry, uint64(before.Unix()))
+	assert.LessOrEqual(t, metadata.Expiry, uint64(after.Unix()))
+	stored, err := localStore.GetBlobMetadata(ctx, keys[0])
+	assert.NoError(t, err)
+	assert.Equal(t, metadata.Expiry, stored.Expiry)
+}
+
+func TestMigratingMetadataStoreInMemoryUpgrades(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, 0)
+	assert.NoError(t, err)
+	defer localStore.Close()
+	keys := queueMixedVersions(t, localStore, 2, disperser.Confirmed)
+	store := blobstore.NewMigratingMetadataStore(localStore, false, logger)
+
+	// The metadata is upgraded as it's returned, without an expiry as the store has no TTL
+	metadata, err := store.GetBlobMetadata(ctx, keys[0])
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.SchemaVersion, metadata.SchemaVersion)
+	assert.Equal(t, uint64(0), metadata.Expiry)
+	stored, err := localStore.GetBlobMetadata(ctx, keys[0])
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), stored.SchemaVersion)
+
+	_, err = store.Migrate(ctx, 1000)
+	assert.ErrorContains(t, err, "the upgrades of the blob metadata aren't persisted")
+	// The schema version of the store is only checked
+	assert.NoError(t, store.CheckSchemaVersion(ctx))
+	version, err := localStore.GetSchemaVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), version)
+}
+
+func TestMigratingMetadataStoreConcurrentUpgrades(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+	keys := queueMixedVersions(t, localStore, 20, disperser.Processing)
+	store := blobstore.NewMigratingMetadataStore(localStore, true, logger)
+
+	// The readers upgrade the same metadata concurrently with the updates of their retry counts, which the upgrades
+	// don't override
+	var wg sync.WaitGroup
+	errs := make(chan error, 10*len(keys))
+	for reader := 0; reader < 10; reader++ {
+		wg.Add(1)
+		go func(reader int) {
+			defer wg.Done()
+			for _, key := range keys {
+				metadata, err := store.GetBlobMetadata(ctx, key)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if metadata.SchemaVersion != blobstore.SchemaVersion {
+					errs <- fmt.Errorf("blob %s is of schema version %d", key.String(), metadata.SchemaVersion)
+					return
+				}
+				if reader == 0 {
+					if err := store.SetBlobStatus(ctx, key, disperser.Failed); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}(reader)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	for i, key := range keys {
+		stored, err := localStore.GetBlobMetadata(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, blobstore.SchemaVersion, stored.SchemaVersion)
+		assert.Equal(t, disperser.Failed, stored.BlobStatus)
+		if i%2 == 0 {
+			assert.Equal(t, uint64(requestTime.Add(time.Hour).Unix()), stored.Expiry)
+		}
+	}
+}
+
+func TestMigratingMetadataStoreMigrate(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+	processing := queueMixedVersions(t, localStore, 6, disperser.Processing)
+	store := blobstore.NewMigratingMetadataStore(localStore, true, logger)
+	for _, key := range processing[:2] {
+		assert.NoError(t, localStore.SetBlobStatus(ctx, key, disperser.Finalized))
+	}
+
+	// The old metadata of every status is upgraded, and the metadata of the current version is skipped
+	numUpgraded, err := store.Migrate(ctx, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, numUpgraded)
+	for _, key := range processing {
+		stored, err := localStore.GetBlobMetadata(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, blobstore.SchemaVersion, stored.SchemaVersion)
+	}
+	numUpgraded, err = store.Migrate(ctx, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, numUpgraded)
+
+	// The migration stops at the rate of 1 upgrade per second when it's canceled
+	queueMixedVersions(t, localStore, 4, disperser.Processing)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	numUpgraded, err = store.Migrate(timeoutCtx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, numUpgraded)
+}
+
+func TestMigratingMetadataStoreSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := blobstore.NewLocalBlobMetadataStore(t.TempDir(), logger, time.Hour)
+	assert.NoError(t, err)
+	defer localStore.Close()
+	store := blobstore.NewMigratingMetadataStore(localStore, true, logger)
+
+	// The schema version of the unversioned store is raised to the one of the binary
+	version, err := localStore.GetSchemaVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), version)
+	assert.NoError(t, store.CheckSchemaVersion(ctx))
+	version, err = localStore.GetSchemaVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, blobstore.SchemaVersion, version)
+	assert.NoError(t, store.CheckSchemaVersion(ctx))
+
+	// The store of a newer binary is refused, and its schema version isn't lowered
+	assert.NoError(t, localStore.SetSchemaVersion(ctx, blobstore.SchemaVersion+1))
+	assert.ErrorIs(t, store.CheckSchemaVersion(ctx), blobstore.ErrNewerSchema)
+	assert.ErrorIs(t, localStore.SetSchemaVersion(ctx, blobstore.SchemaVersion), blobstore.ErrNewerSchema)
+}
@@ -1,11 +1,13 @@
 package blobstore
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -20,7 +22,8 @@ const (
 )
 
 // The shared blob store that the disperser is operating on.
-// The metadata store is backed by DynamoDB and the blob store is backed by S3.
+// The metadata store is backed by DynamoDB and the blob store is backed by S3. For local
+// development, both can be replaced by filesystem-backed implementations (see Config).
 //
 // Note:
 //   - For each entry in the store (i.e. an S3 object), the user has to ensure there is no
@@ -41,13 +44,47 @@ const (
 type SharedBlobStore struct {
 	bucketName        string
 	s3Client          s3.Client
-	blobMetadataStore *BlobMetadataStore
+	blobMetadataStore MetadataStore
 	logger            common.Logger
 }
 
+// MetadataStore is the storage of blob metadata used by SharedBlobStore.
+// It is implemented by BlobMetadataStore (DynamoDB) and LocalBlobMetadataStore (LevelDB), whose metadata of the older
+// schema versions is upgraded by MigratingMetadataStore.
+type MetadataStore interface {
+	QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error
+	GetBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) (*disperser.BlobMetadata, error)
+	GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error)
+	GetBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, limit int32, continuationToken string) ([]*disperser.BlobMetadata, string, error)
+	GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error)
+	GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error)
+	IncrementNumRetries(ctx context.Context, existingMetadata *disperser.BlobMetadata) error
+	UpdateBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, updated *disperser.BlobMetadata) error
+	SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error
+	// UpgradeBlobMetadata applies the upgrade to the stored metadata of the schema version fromVersion and returns the
+	// upgraded metadata, or fails with ErrSchemaVersionChanged if the stored metadata isn't of fromVersion anymore
+	UpgradeBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, fromVersion uint32, upgrade func(*disperser.BlobMetadata) error) (*disperser.BlobMetadata, error)
+	// GetSchemaVersion returns the schema version of the store, which is 0 if it was never set
+	GetSchemaVersion(ctx context.Context) (uint32, error)
+	// SetSchemaVersion raises the schema version of the store, or fails with ErrNewerSchema if it's already newer
+	SetSchemaVersion(ctx context.Context, version uint32) error
+	// TTL returns the duration for which the metadata is retained after it's created or confirmed
+	TTL() time.Duration
+}
+
 type Config struct {
 	BucketName string
 	TableName  string
+
+	// BlobBackend selects where blobs are stored: S3Backend (default) or LocalBackend, on the filesystem
+	BlobBackend string
+	// MetadataBackend selects where blob metadata is stored: DynamoDBBackend (default) or LocalBackend, in LevelDB
+	MetadataBackend string
+	// DataDir is the root directory for the filesystem-backed stores
+	DataDir string
+	// MigrationRate is the number of metadata per second upgraded to SchemaVersion in the background, or 0 if the
+	// metadata is only upgraded as it's read
+	MigrationRate int
 }
 
 // This represents the s3 fetch result for a blob.
@@ -63,7 +100,7 @@ type blobResultOrError struct {
 
 var _ disperser.BlobStore = (*SharedBlobStore)(nil)
 
-func NewSharedStorage(bucketName string, s3Client s3.Client, blobMetadataStore *BlobMetadataStore, logger common.Logger) *SharedBlobStore {
+func NewSharedStorage(bucketName string, s3Client s3.Client, blobMetadataStore MetadataStore, logger common.Logger) *SharedBlobStore {
 	return &SharedBlobStore{
 		bucketName:        bucketName,
 		s3Client:          s3Client,
@@ -87,7 +124,9 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 	metadataKey.BlobHash = blobHash
 	metadataKey.MetadataHash = metadataHash
 
-	err = s.s3Client.UploadObject(ctx, s.bucketName, blobObjectKey(blobHash), blob.Data)
+	// The blob is keyed by its hash, so it's uploaded from the data of the request, which the uploader reads
+	// without copying it
+	err = s.s3Client.UploadObject(ctx, s.bucketName, blobObjectKey(blobHash), bytes.NewReader(blob.Data))
 	if err != nil {
 		s.logger.Error("error uploading blob", "err", err)
 		return metadataKey, err
@@ -95,15 +134,16 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 
 	// don't expire if ttl is 0
 	expiry := uint64(0)
-	if s.blobMetadataStore.ttl > 0 {
-		expiry = uint64(time.Now().Add(s.blobMetadataStore.ttl).Unix())
+	if s.blobMetadataStore.TTL() > 0 {
+		expiry = uint64(time.Now().Add(s.blobMetadataStore.TTL()).Unix())
 	}
 	metadata := disperser.BlobMetadata{
-		BlobHash:     blobHash,
-		MetadataHash: metadataHash,
-		NumRetries:   0,
-		BlobStatus:   disperser.Processing,
-		Expiry:       expiry,
+		BlobHash:      blobHash,
+		MetadataHash:  metadataHash,
+		NumRetries:    0,
+		BlobStatus:    disperser.Processing,
+		Expiry:        expiry,
+		SchemaVersion: SchemaVersion,
 		RequestMetadata: &disperser.RequestMetadata{
 			BlobRequestHeader: blob.RequestHeader,
 			BlobSize:          uint(len(blob.Data)),
@@ -119,24 +159,29 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 	return metadataKey, nil
 }
 
-// GetBlobContent retrieves blob content by the blob key.
-func (s *SharedBlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) ([]byte, error) {
+// GetBlobContent returns a reader of the blob content by the blob key, which is streamed from the object store.
+func (s *SharedBlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) (io.ReadCloser, error) {
 	return s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobHash))
 }
 
-func (s *SharedBlobStore) getBlobContentParallel(ctx context.Context, blobKey disperser.BlobKey, blobRequestHeader core.BlobRequestHeader, resultChan chan<- blobResultOrError) {
-	blob, err := s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobKey.BlobHash))
+func (s *SharedBlobStore) getBlobContentParallel(ctx context.Context, metadata *disperser.BlobMetadata, resultChan chan<- blobResultOrError) {
+	content, err := s.GetBlobContent(ctx, metadata.BlobHash)
+	if err != nil {
+		resultChan <- blobResultOrError{err: err}
+		return
+	}
+	blob, err := disperser.ReadBlobContent(content, metadata.RequestMetadata.BlobSize)
 	if err != nil {
 		resultChan <- blobResultOrError{err: err}
 		return
 	}
-	resultChan <- blobResultOrError{blob: blob, blobKey: blobKey, blobRequestHeader: blobRequestHeader}
+	resultChan <- blobResultOrError{blob: blob, blobKey: metadata.GetBlobKey(), blobRequestHeader: metadata.RequestMetadata.BlobRequestHeader}
 }
 
 func (s *SharedBlobStore) MarkBlobConfirmed(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
 	newMetadata := *existingMetadata
 	// Update the TTL if needed
-	ttlFromNow := time.Now().Add(s.blobMetadataStore.ttl)
+	ttlFromNow := time.Now().Add(s.blobMetadataStore.TTL())
 	if existingMetadata.Expiry < uint64(ttlFromNow.Unix()) {
 		newMetadata.Expiry = uint64(ttlFromNow.Unix())
 	}
@@ -164,6 +209,15 @@ func (s *SharedBlobStore) MarkBlobFailed(ctx context.Context, metadataKey disper
 	return s.blobMetadataStore.SetBlobStatus(ctx, metadataKey, disperser.Failed)
 }
 
+// MarkBlobDeduplicated links the metadata of the blob to the one of the identical blob dispersed in its place. The
+// content of both blobs is the same object, as it's keyed by its hash.
+func (s *SharedBlobStore) MarkBlobDeduplicated(ctx context.Context, existingMetadata *disperser.BlobMetadata, linkedBlobKey disperser.BlobKey) error {
+	newMetadata := *existingMetadata
+	newMetadata.BlobStatus = disperser.Deduplicated
+	newMetadata.LinkedBlobKey = &linkedBlobKey
+	return s.blobMetadataStore.UpdateBlobMetadata(ctx, existingMetadata.GetBlobKey(), &newMetadata)
+}
+
 func (s *SharedBlobStore) IncrementBlobRetryCount(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
 	return s.blobMetadataStore.IncrementNumRetries(ctx, existingMetadata)
 }
@@ -178,7 +232,7 @@ func (s *SharedBlobStore) GetBlobsByMetadata(ctx context.Context, metadata []*di
 		mCopy := m // avoid capturing loop variable "m" directly by making a copy
 		pool.Submit(func() {
 			// Fetch blob content from S3
-			s.getBlobContentParallel(ctx, mCopy.GetBlobKey(), mCopy.RequestMetadata.BlobRequestHeader, resultChan)
+			s.getBlobContentParallel(ctx, mCopy, resultChan)
 		})
 	}
 
@@ -203,6 +257,13 @@ func (s *SharedBlobStore) GetBlobMetadataByStatus(ctx context.Context, blobStatu
 	return s.blobMetadataStore.GetBlobMetadataByStatus(ctx, blobStatus)
 }
 
+// GetBlobMetadataByStatusWithPagination returns a page of up to limit blob metadata with the given status,
+// starting after the position of the continuation token, along with the token for the next page.
+// An empty token starts from the beginning, and an empty returned token means there are no more blob metadata.
+func (s *SharedBlobStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, blobStatus disperser.BlobStatus, limit int32, continuationToken string) ([]*disperser.BlobMetadata, string, error) {
+	return s.blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, blobStatus, limit, continuationToken)
+}
+
 func (s *SharedBlobStore) GetMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
 	return s.blobMetadataStore.GetBlobMetadataInBatch(ctx, batchHeaderHash, blobIndex)
 }
@@ -5,17 +5,38 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 func TestSharedBlobStore(t *testing.T) {
+	testSharedBlobStore(t, sharedStorage)
+}
+
+func TestLocalSharedBlobStore(t *testing.T) {
+	dataDir := t.TempDir()
+	objectStore, err := blobstore.NewLocalObjectStore(filepath.Join(dataDir, "blobs"))
+	assert.Nil(t, err)
+	metadataStore, err := blobstore.NewLocalBlobMetadataStore(filepath.Join(dataDir, "metadata"), logger, time.Hour)
+	assert.Nil(t, err)
+	defer metadataStore.Close()
+
+	testSharedBlobStore(t, blobstore.NewSharedStorage(bucketName, objectStore, metadataStore, logger))
+}
+
+func testSharedBlobStore(t *testing.T, sharedStorage *blobstore.SharedBlobStore) {
+	// The test stores a second blob by modifying the shared blob fixture
+	originalData := blob.Data
+	defer func() { blob.Data = originalData }()
+
 	requestedAt := uint64(time.Now().UnixNano())
 	ctx := context.Background()
 	blobKey, err := sharedStorage.StoreBlob(ctx, blob, requestedAt)
@@ -32,7 +53,9 @@ func TestSharedBlobStore(t *testing.T) {
 	assert.Len(t, blobs, 1)
 	assertBlob(t, blobs[blobKey])
 
-	data, err := sharedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	content, err := sharedStorage.GetBlobContent(ctx, blobKey.BlobHash)
+	assert.Nil(t, err)
+	data, err := disperser.ReadBlobContent(content, blobSize)
 	assert.Nil(t, err)
 	assert.Equal(t, blob.Data, data)
 
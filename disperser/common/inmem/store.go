@@ -1,9 +1,11 @@
 package inmem
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"io"
 	"strconv"
 
 	"github.com/Layr-Labs/eigenda/core"
@@ -61,9 +63,9 @@ func (q *BlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt
 	return blobKey, nil
 }
 
-func (q *BlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) ([]byte, error) {
+func (q *BlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) (io.ReadCloser, error) {
 	if holder, ok := q.Blobs[blobHash]; ok {
-		return holder.Data, nil
+		return io.NopCloser(bytes.NewReader(holder.Data)), nil
 	} else {
 		return nil, disperser.ErrBlobNotFound
 	}
@@ -120,6 +122,18 @@ func (q *BlobStore) MarkBlobFailed(ctx context.Context, blobKey disperser.BlobKe
 	return nil
 }
 
+func (q *BlobStore) MarkBlobDeduplicated(ctx context.Context, existingMetadata *disperser.BlobMetadata, linkedBlobKey disperser.BlobKey) error {
+	blobKey := existingMetadata.GetBlobKey()
+	if _, ok := q.Metadata[blobKey]; !ok {
+		return disperser.ErrBlobNotFound
+	}
+	newMetadata := *existingMetadata
+	newMetadata.BlobStatus = disperser.Deduplicated
+	newMetadata.LinkedBlobKey = &linkedBlobKey
+	q.Metadata[blobKey] = &newMetadata
+	return nil
+}
+
 func (q *BlobStore) IncrementBlobRetryCount(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
 	if _, ok := q.Metadata[existingMetadata.GetBlobKey()]; !ok {
 		return disperser.ErrBlobNotFound
@@ -35,10 +35,20 @@ func TestBlobStore(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, metas, numBlobs)
 
-	data, err := bs.GetBlobContent(ctx, keys[1].BlobHash)
+	content, err := bs.GetBlobContent(ctx, keys[1].BlobHash)
+	assert.Nil(t, err)
+	data, err := disperser.ReadBlobContent(content, 1)
 	assert.Nil(t, err)
 	assert.Equal(t, data, []byte{byte(1)})
 
+	// The content must have the size of the blob
+	for _, size := range []uint{0, 2} {
+		content, err = bs.GetBlobContent(ctx, keys[1].BlobHash)
+		assert.Nil(t, err)
+		_, err = disperser.ReadBlobContent(content, size)
+		assert.Error(t, err)
+	}
+
 	metadatas, err := bs.GetBlobMetadataByStatus(ctx, disperser.Processing)
 	assert.Nil(t, err)
 	assert.Len(t, metadatas, numBlobs)
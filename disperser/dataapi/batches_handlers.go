@@ -0,0 +1,340 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	defaultFeedLimit = 10
+	maxFeedLimit     = 100
+	// The blobs of an account are found by scanning the batches, which is bounded per request. The cursor of a
+	// page that is cut short resumes the scan.
+	accountScanBatchesPerQuery = 20
+	maxAccountScannedBatches   = 200
+)
+
+var blobStatuses = []disperser.BlobStatus{
+	disperser.Processing,
+	disperser.Confirmed,
+	disperser.Failed,
+	disperser.Finalized,
+	disperser.InsufficientSignatures,
+}
+
+// parseFeedLimit parses the size of a page, defaultFeedLimit if it is empty
+func parseFeedLimit(value string) (int, error) {
+	if value == "" {
+		return defaultFeedLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxFeedLimit {
+		return 0, fmt.Errorf("limit %q must be between 1 and %d: %w", value, maxFeedLimit, errInvalidParameter)
+	}
+	return limit, nil
+}
+
+// parseTimeRange parses the unix timestamps, in seconds, bounding a range. It defaults to all the time until now.
+func parseTimeRange(start, end string) (int64, int64, error) {
+	startSeconds, endSeconds := int64(0), time.Now().Unix()
+	var err error
+	if start != "" {
+		if startSeconds, err = strconv.ParseInt(start, 10, 64); err != nil || startSeconds < 0 {
+			return 0, 0, fmt.Errorf("invalid start %q: %w", start, errInvalidParameter)
+		}
+	}
+	if end != "" {
+		if endSeconds, err = strconv.ParseInt(end, 10, 64); err != nil || endSeconds < 0 {
+			return 0, 0, fmt.Errorf("invalid end %q: %w", end, errInvalidParameter)
+		}
+	}
+	if startSeconds > endSeconds {
+		return 0, 0, fmt.Errorf("start %d is after end %d: %w", startSeconds, endSeconds, errInvalidParameter)
+	}
+	return startSeconds, endSeconds, nil
+}
+
+// parseBlobStatuses parses a comma separated list of blob statuses, e.g. "confirmed,finalized". There is no filter
+// on the status if the list is empty.
+func parseBlobStatuses(value string) (map[disperser.BlobStatus]struct{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+	statuses := make(map[disperser.BlobStatus]struct{})
+	for _, name := range strings.Split(value, ",") {
+		found := false
+		for _, status := range blobStatuses {
+			if strings.EqualFold(strings.TrimSpace(name), status.String()) {
+				statuses[status] = struct{}{}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown blob status %q: %w", name, errInvalidParameter)
+		}
+	}
+	return statuses, nil
+}
+
+func parseBatchHeaderHash(value string) ([32]byte, error) {
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(value))
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid batch header hash %q: %w", value, errInvalidParameter)
+	}
+	return batchHeaderHash, nil
+}
+
+// parseBatchCursor parses the cursor of the pages of batches, the ID of the last batch of the previous page. The
+// pages without a cursor start with the latest batch.
+func parseBatchCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return math.MaxInt32, nil
+	}
+	batchId, err := strconv.ParseUint(cursor, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q: %w", cursor, errInvalidParameter)
+	}
+	return batchId, nil
+}
+
+// parseBlobCursor parses the cursor of the pages of blobs, the "<batch id>-<blob index>" of the last blob of the
+// previous page
+func parseBlobCursor(cursor string) (uint64, uint32, error) {
+	batchId, blobIndex, found := strings.Cut(cursor, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid cursor %q: %w", cursor, errInvalidParameter)
+	}
+	id, err := strconv.ParseUint(batchId, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cursor %q: %w", cursor, errInvalidParameter)
+	}
+	index, err := strconv.ParseUint(blobIndex, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cursor %q: %w", cursor, errInvalidParameter)
+	}
+	return id, uint32(index), nil
+}
+
+func formatBlobCursor(batchId uint64, blobIndex uint32) string {
+	return fmt.Sprintf("%d-%d", batchId, blobIndex)
+}
+
+// getBatches returns a page of the batches confirmed in the time range, latest first, and the cursor of the next
+// page if this one is full
+func (s *server) getBatches(ctx context.Context, cursor string, startSeconds, endSeconds int64, limit int) ([]*BatchResponse, string, error) {
+	beforeBatchId, err := parseBatchCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	batches, err := s.subgraphClient.QueryBatchesInRange(ctx, beforeBatchId, startSeconds, endSeconds, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	responses := make([]*BatchResponse, len(batches))
+	for i, batch := range batches {
+		metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batch.BatchHeaderHash)
+		if err != nil {
+			return nil, "", err
+		}
+		responses[i] = convertBatchToBatchResponse(batch, metadatas)
+	}
+
+	nextCursor := ""
+	if len(batches) == limit {
+		nextCursor = strconv.FormatUint(batches[len(batches)-1].BatchId, 10)
+	}
+	return responses, nextCursor, nil
+}
+
+// getBatchBlobs returns a page of the blobs of a batch by blob index, and the cursor of the next page if this one
+// is full
+func (s *server) getBatchBlobs(ctx context.Context, batchHeaderHash [32]byte, cursor string, statuses map[disperser.BlobStatus]struct{}, limit int) ([]*BlobMetadataResponse, string, error) {
+	metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(metadatas) == 0 {
+		return nil, "", fmt.Errorf("batch %s: %w", hex.EncodeToString(batchHeaderHash[:]), errNotFound)
+	}
+	sortByBlobIndex(metadatas)
+
+	batchId := uint64(metadatas[0].ConfirmationInfo.BatchID)
+	afterIndex := int64(-1)
+	if cursor != "" {
+		cursorBatchId, cursorIndex, err := parseBlobCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if cursorBatchId != batchId {
+			return nil, "", fmt.Errorf("cursor %q is not of batch %d: %w", cursor, batchId, errInvalidParameter)
+		}
+		afterIndex = int64(cursorIndex)
+	}
+
+	page := make([]*disperser.BlobMetadata, 0, limit)
+	for _, metadata := range metadatas {
+		if int64(metadata.ConfirmationInfo.BlobIndex) <= afterIndex || !hasBlobStatus(metadata, statuses) {
+			continue
+		}
+		page = append(page, metadata)
+		if len(page) == limit {
+			break
+		}
+	}
+
+	responses, err := convertBlobMetadatasInOrder(page)
+	if err != nil {
+		return nil, "", err
+	}
+	nextCursor := ""
+	if len(page) == limit {
+		nextCursor = formatBlobCursor(batchId, page[len(page)-1].ConfirmationInfo.BlobIndex)
+	}
+	return responses, nextCursor, nil
+}
+
+// getAccountBlobs returns a page of the blobs of an account in the batches confirmed in the time range, by
+// descending batch ID and then ascending blob index, and the cursor of the next page if this one is full or the scan
+// of the batches was cut short
+func (s *server) getAccountBlobs(ctx context.Context, accountId core.AccountID, cursor string, statuses map[disperser.BlobStatus]struct{}, startSeconds, endSeconds int64, limit int) ([]*BlobMetadataResponse, string, error) {
+	beforeBatchId := uint64(math.MaxInt32)
+	afterIndex := int64(-1)
+	if cursor != "" {
+		cursorBatchId, cursorIndex, err := parseBlobCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		// The batch of the cursor is scanned again, from the blob after the cursor
+		beforeBatchId = cursorBatchId + 1
+		afterIndex = int64(cursorIndex)
+	}
+
+	page := make([]*disperser.BlobMetadata, 0, limit)
+	exhausted := false
+	for scanned := 0; scanned < maxAccountScannedBatches; {
+		batches, err := s.subgraphClient.QueryBatchesInRange(ctx, beforeBatchId, startSeconds, endSeconds, accountScanBatchesPerQuery)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(batches) == 0 {
+			exhausted = true
+			break
+		}
+
+		for _, batch := range batches {
+			metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batch.BatchHeaderHash)
+			if err != nil {
+				return nil, "", err
+			}
+			sortByBlobIndex(metadatas)
+			for _, metadata := range metadatas {
+				if batch.BatchId+1 == beforeBatchId && int64(metadata.ConfirmationInfo.BlobIndex) <= afterIndex {
+					continue
+				}
+				if metadata.RequestMetadata.AccountID != accountId || !hasBlobStatus(metadata, statuses) {
+					continue
+				}
+				page = append(page, metadata)
+				if len(page) == limit {
+					responses, err := convertBlobMetadatasInOrder(page)
+					if err != nil {
+						return nil, "", err
+					}
+					return responses, formatBlobCursor(batch.BatchId, metadata.ConfirmationInfo.BlobIndex), nil
+				}
+			}
+		}
+		scanned += len(batches)
+		beforeBatchId = batches[len(batches)-1].BatchId
+		afterIndex = -1
+	}
+
+	responses, err := convertBlobMetadatasInOrder(page)
+	if err != nil {
+		return nil, "", err
+	}
+	nextCursor := ""
+	if !exhausted {
+		// The next page resumes the scan after the last blob of the last batch scanned, whose ID is beforeBatchId
+		nextCursor = formatBlobCursor(beforeBatchId, math.MaxUint32)
+	}
+	return responses, nextCursor, nil
+}
+
+func convertBatchToBatchResponse(batch *BatchInfo, metadatas []*disperser.BlobMetadata) *BatchResponse {
+	response := &BatchResponse{
+		BatchId:                 batch.BatchId,
+		BatchHeaderHash:         hex.EncodeToString(batch.BatchHeaderHash[:]),
+		ReferenceBlockNumber:    batch.ReferenceBlockNumber,
+		QuorumNumbers:           batch.QuorumNumbers,
+		BlobCount:               len(metadatas),
+		ConfirmationTxnHash:     batch.TxHash,
+		ConfirmationBlockNumber: batch.BlockNumber,
+		ConfirmedAt:             batch.BlockTimestamp,
+		SignedStake:             make([]*QuorumSignedStake, 0),
+	}
+	if len(metadatas) == 0 {
+		return response
+	}
+
+	// The blobs of the batch share its confirmation, and the store may no longer have all of them
+	confirmationInfo := metadatas[0].ConfirmationInfo
+	if int(confirmationInfo.BlobCount) > response.BlobCount {
+		response.BlobCount = int(confirmationInfo.BlobCount)
+	}
+	for _, quorumResult := range confirmationInfo.QuorumResults {
+		nonSigners := make([]*QuorumNonSigner, len(quorumResult.NonSigners))
+		for i, nonSigner := range quorumResult.NonSigners {
+			nonSigners[i] = &QuorumNonSigner{
+				OperatorId: "0x" + hex.EncodeToString(nonSigner.OperatorID[:]),
+				Reason:     string(nonSigner.Reason),
+			}
+		}
+		response.SignedStake = append(response.SignedStake, &QuorumSignedStake{
+			QuorumId:         quorumResult.QuorumID,
+			SignedPercentage: quorumResult.PercentSigned,
+			NonSigners:       nonSigners,
+		})
+	}
+	sort.Slice(response.SignedStake, func(i, j int) bool {
+		return response.SignedStake[i].QuorumId < response.SignedStake[j].QuorumId
+	})
+	return response
+}
+
+func convertBlobMetadatasInOrder(metadatas []*disperser.BlobMetadata) ([]*BlobMetadataResponse, error) {
+	responses := make([]*BlobMetadataResponse, len(metadatas))
+	for i, metadata := range metadatas {
+		response, err := convertMetadataToBlobMetadataResponse(metadata)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = response
+	}
+	return responses, nil
+}
+
+func sortByBlobIndex(metadatas []*disperser.BlobMetadata) {
+	sort.Slice(metadatas, func(i, j int) bool {
+		return metadatas[i].ConfirmationInfo.BlobIndex < metadatas[j].ConfirmationInfo.BlobIndex
+	})
+}
+
+func hasBlobStatus(metadata *disperser.BlobMetadata, statuses map[disperser.BlobStatus]struct{}) bool {
+	if statuses == nil {
+		return true
+	}
+	_, ok := statuses[metadata.BlobStatus]
+	return ok
+}
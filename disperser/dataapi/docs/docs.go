@@ -15,6 +15,209 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/feed/accounts/{account_id}/blobs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Fetch the blobs metadata of a requestor account, latest batch first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID of the requestor",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit [default: 10, max: 100]",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next_cursor of the previous page [default: latest blob]",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated blob statuses, e.g. confirmed,finalized [default: all]",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp of the confirmations [default: 0]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp of the confirmations [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.PaginatedBlobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/batches": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Fetch the confirmed batches, latest first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit [default: 10, max: 100]",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next_cursor of the previous page [default: latest batch]",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp of the confirmations [default: 0]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp of the confirmations [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.BatchesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/batches/{batch_header_hash}/blobs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Fetch the blobs metadata of a batch, by blob index",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch header hash in hex string",
+                        "name": "batch_header_hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit [default: 10, max: 100]",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next_cursor of the previous page [default: first blob]",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated blob statuses, e.g. confirmed,finalized [default: all]",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.PaginatedBlobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/feed/blobs": {
             "get": {
                 "produces": [
@@ -264,6 +467,180 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/metrics/throughput/buckets": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Fetch the data confirmed in time buckets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp [default: 1 hour ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bucket size in seconds, enlarged for long ranges [default: 60]",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Whether the partial buckets at the edges of the range are marked or dropped: mark or drop [default: mark]",
+                        "name": "partial",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ThroughputBucketsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/ejection_candidates": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Fetch the non signing rates of the registered operators per quorum, flagging the ejection candidates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window of the non signing rates, e.g. 1h or 7d [default: 24h]",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Non signing percentage above which the operators are ejection candidates",
+                        "name": "threshold",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the response: json or csv [default: json]",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorEjectionCandidatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/operators/{operator_id}/nonsigning": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operators"
+                ],
+                "summary": "Fetch the signing rates of an operator per quorum over time windows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operator ID",
+                        "name": "operator_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated windows, e.g. 1h,24h,7d [default: 24h]",
+                        "name": "windows",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.OperatorNonSigningResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -312,6 +689,58 @@ const docTemplate = `{
                 }
             }
         },
+        "dataapi.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "batch_id": {
+                    "type": "integer"
+                },
+                "blob_count": {
+                    "type": "integer"
+                },
+                "confirmation_block_number": {
+                    "type": "integer"
+                },
+                "confirmation_txn_hash": {
+                    "type": "string"
+                },
+                "confirmed_at": {
+                    "type": "integer"
+                },
+                "quorum_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reference_block_number": {
+                    "type": "integer"
+                },
+                "signed_stake": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.QuorumSignedStake"
+                    }
+                }
+            }
+        },
+        "dataapi.BatchesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.PaginationMeta"
+                }
+            }
+        },
         "dataapi.BlobMetadataResponse": {
             "type": "object",
             "properties": {
@@ -395,8 +824,8 @@ const docTemplate = `{
         "dataapi.Metric": {
             "type": "object",
             "properties": {
-                "cost_in_wei": {
-                    "type": "integer"
+                "cost_in_gas": {
+                    "type": "number"
                 },
                 "throughput": {
                     "type": "number"
@@ -417,6 +846,160 @@ const docTemplate = `{
                 }
             }
         },
+        "dataapi.OperatorEjectionCandidate": {
+            "type": "object",
+            "properties": {
+                "above_threshold": {
+                    "type": "boolean"
+                },
+                "nonsigning_percentage": {
+                    "type": "number"
+                },
+                "operator_id": {
+                    "type": "string"
+                },
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "signed_batches": {
+                    "type": "integer"
+                },
+                "stake_percentage": {
+                    "type": "number"
+                },
+                "total_batches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.OperatorEjectionCandidatesResponse": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorEjectionCandidate"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                },
+                "threshold": {
+                    "type": "number"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorNonSigningQuorum": {
+            "type": "object",
+            "properties": {
+                "nonsigning_percentage": {
+                    "type": "number"
+                },
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "signed_batches": {
+                    "type": "integer"
+                },
+                "signing_percentage": {
+                    "type": "number"
+                },
+                "total_batches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.OperatorNonSigningResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorNonSigningWindow"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                },
+                "operator_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.OperatorNonSigningWindow": {
+            "type": "object",
+            "properties": {
+                "quorums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.OperatorNonSigningQuorum"
+                    }
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.PaginatedBlobsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobMetadataResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.PaginationMeta"
+                }
+            }
+        },
+        "dataapi.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.QuorumNonSigner": {
+            "type": "object",
+            "properties": {
+                "operator_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dataapi.QuorumSignedStake": {
+            "type": "object",
+            "properties": {
+                "non_signers": {
+                    "description": "NonSigners are the operators of the quorum that did not sign the batch, recorded by the batcher",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.QuorumNonSigner"
+                    }
+                },
+                "quorum_id": {
+                    "type": "integer"
+                },
+                "signed_percentage": {
+                    "type": "integer"
+                }
+            }
+        },
         "dataapi.Throughput": {
             "type": "object",
             "properties": {
@@ -428,6 +1011,46 @@ const docTemplate = `{
                 }
             }
         },
+        "dataapi.ThroughputBucket": {
+            "type": "object",
+            "properties": {
+                "blob_count": {
+                    "type": "number"
+                },
+                "bytes_dispersed": {
+                    "type": "number"
+                },
+                "end": {
+                    "type": "integer"
+                },
+                "partial": {
+                    "type": "boolean"
+                },
+                "start": {
+                    "type": "integer"
+                },
+                "throughput": {
+                    "type": "number"
+                }
+            }
+        },
+        "dataapi.ThroughputBucketsResponse": {
+            "type": "object",
+            "properties": {
+                "bucket_size": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.ThroughputBucket"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                }
+            }
+        },
         "github_com_Layr-Labs_eigenda_disperser.BlobStatus": {
             "type": "integer",
             "enum": [
@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -23,6 +25,18 @@ type Metrics struct {
 	NumRequests *prometheus.CounterVec
 	Latency     *prometheus.SummaryVec
 
+	// The stake distribution of the quorums, as last read from the chain by the QuorumStakeExporter
+	QuorumOperators             *prometheus.GaugeVec
+	QuorumMaxOperators          *prometheus.GaugeVec
+	QuorumTotalStake            *prometheus.GaugeVec
+	QuorumMaxOperatorStake      *prometheus.GaugeVec
+	QuorumMedianOperatorStake   *prometheus.GaugeVec
+	QuorumMinOperatorStake      *prometheus.GaugeVec
+	QuorumTopOperatorStakeShare *prometheus.GaugeVec
+	QuorumStakeBlockNumber      prometheus.Gauge
+	QuorumStakeLastRefresh      prometheus.Gauge
+	QuorumStakeStale            prometheus.Gauge
+
 	httpPort string
 	logger   common.Logger
 }
@@ -32,6 +46,8 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(version.NewCollector(namespace))
+	reg.MustRegister(logging.NewLevelCollector(namespace, logger))
 
 	metrics := &Metrics{
 		NumRequests: promauto.With(reg).NewCounterVec(
@@ -51,6 +67,83 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 			},
 			[]string{"method"},
 		),
+		QuorumOperators: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_operators",
+				Help:      "the number of operators registered in the quorum",
+			},
+			[]string{"quorum"},
+		),
+		QuorumMaxOperators: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_max_operators",
+				Help:      "the maximum number of operators of the quorum, beyond which new operators have to churn existing ones",
+			},
+			[]string{"quorum"},
+		),
+		QuorumTotalStake: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_total_stake",
+				Help:      "the total stake of the quorum",
+			},
+			[]string{"quorum"},
+		),
+		QuorumMaxOperatorStake: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_max_operator_stake",
+				Help:      "the stake of the highest-staked operator of the quorum",
+			},
+			[]string{"quorum"},
+		),
+		QuorumMedianOperatorStake: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_median_operator_stake",
+				Help:      "the median stake of the operators of the quorum",
+			},
+			[]string{"quorum"},
+		),
+		QuorumMinOperatorStake: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_min_operator_stake",
+				Help:      "the stake of the lowest-staked operator of the quorum, which is the one churned by new operators once the quorum is full",
+			},
+			[]string{"quorum"},
+		),
+		QuorumTopOperatorStakeShare: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_top_operator_stake_share",
+				Help:      "the share in percent of the total stake of the quorum held by its highest-staked operator",
+			},
+			[]string{"quorum"},
+		),
+		QuorumStakeBlockNumber: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_stake_block_number",
+				Help:      "the block the stake distribution of the quorums was last read at",
+			},
+		),
+		QuorumStakeLastRefresh: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_stake_last_refresh_timestamp_seconds",
+				Help:      "the unix time the stake distribution of the quorums was last read successfully",
+			},
+		),
+		QuorumStakeStale: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quorum_stake_stale",
+				Help:      "1 if the last read of the stake distribution of the quorums failed, in which case the quorum gauges keep their last values",
+			},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger,
@@ -90,6 +183,7 @@ func (g *Metrics) Start(ctx context.Context) {
 			g.registry,
 			promhttp.HandlerOpts{},
 		))
+		logging.RegisterHandlers(mux, g.logger)
 		err := http.ListenAndServe(addr, mux)
 		log.Error("Prometheus server failed", "err", err)
 	}()
@@ -13,8 +13,23 @@ import (
 const (
 	avgThroughputWindowSize    = 120 // The time window (in seconds) to calculate the data throughput.
 	maxWorkersGetOperatorState = 10  // The maximum number of workers to use when querying operator state.
+
+	defaultThroughputBucketSize = 60   // The default size (in seconds) of the throughput buckets.
+	maxThroughputBuckets        = 1000 // The maximum number of throughput buckets, beyond which the buckets are enlarged.
+	throughputBucketCacheSize   = 100_000
+	// throughputSettleDelay is the delay after which the samples of a bucket are all scraped, so that it can't change
+	throughputSettleDelay = 2 * time.Minute
 )
 
+// throughputBucketSpan is the time span of a bucket, clipped to the queried range
+type throughputBucketSpan struct {
+	start, end int64
+}
+
+func (b throughputBucketSpan) cacheKey(bucketSize int64) string {
+	return fmt.Sprintf("%d/%d", bucketSize, b.start)
+}
+
 func (s *server) getMetric(ctx context.Context, startTime int64, endTime int64, limit int) (*Metric, error) {
 	blockNumber, err := s.transactor.GetCurrentBlockNumber(ctx)
 	if err != nil {
@@ -74,6 +89,145 @@ func (s *server) getThroughput(ctx context.Context, start int64, end int64) ([]*
 	return calculateAverageThroughput(result.Values, avgThroughputWindowSize), nil
 }
 
+// getThroughputBuckets returns the bytes and the blobs confirmed in the buckets of the time range, which are aligned
+// to the multiples of the bucket size since the unix epoch. The buckets at the edges of the range are clipped to it
+// and marked partial, or dropped. The buckets are enlarged to a multiple of the bucket size if the range has more than
+// maxThroughputBuckets of them, and the bucket size used is returned. The buckets are computed from the samples of
+// the totals of the confirmed blobs at their boundaries, and the ones missing samples are left out.
+func (s *server) getThroughputBuckets(ctx context.Context, start int64, end int64, bucketSize int64, dropPartial bool) ([]*ThroughputBucket, int64, error) {
+	now := time.Now()
+	if end > now.Unix() {
+		end = now.Unix()
+	}
+	if start >= end {
+		return nil, 0, fmt.Errorf("start %d must be before end %d: %w", start, end, errInvalidParameter)
+	}
+	if numBuckets := (end - start + bucketSize - 1) / bucketSize; numBuckets > maxThroughputBuckets {
+		bucketSize *= (numBuckets + maxThroughputBuckets - 1) / maxThroughputBuckets
+	}
+
+	spans := make([]throughputBucketSpan, 0)
+	for bucketStart := start - start%bucketSize; bucketStart < end; bucketStart += bucketSize {
+		span := throughputBucketSpan{start: max(bucketStart, start), end: min(bucketStart+bucketSize, end)}
+		if dropPartial && span.end-span.start < bucketSize {
+			continue
+		}
+		spans = append(spans, span)
+	}
+
+	// The buckets of closed periods can't change, so only the others are computed
+	buckets := make([]*ThroughputBucket, len(spans))
+	sampleTimes := make(map[int64]struct{})
+	for i, span := range spans {
+		if bucket, ok := s.throughputBucketCache.Get(span.cacheKey(bucketSize)); ok {
+			buckets[i] = bucket
+			continue
+		}
+		sampleTimes[span.start] = struct{}{}
+		sampleTimes[span.end] = struct{}{}
+	}
+	samples, err := s.queryConfirmedBlobSamples(ctx, sampleTimes, bucketSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	closedBefore := now.Add(-throughputSettleDelay).Unix()
+	result := make([]*ThroughputBucket, 0, len(spans))
+	for i, span := range spans {
+		bucket := buckets[i]
+		if bucket == nil {
+			startSample, ok := samples[span.start]
+			if !ok {
+				continue
+			}
+			endSample, ok := samples[span.end]
+			if !ok {
+				continue
+			}
+			bucket = newThroughputBucket(span, startSample, endSample, bucketSize)
+			if !bucket.Partial && span.end <= closedBefore {
+				s.throughputBucketCache.Add(span.cacheKey(bucketSize), bucket)
+			}
+		}
+		result = append(result, bucket)
+	}
+	return result, bucketSize, nil
+}
+
+// confirmedBlobsSample is the total size and number of the confirmed blobs at a time
+type confirmedBlobsSample struct {
+	size, number float64
+}
+
+// queryConfirmedBlobSamples samples the totals of the confirmed blobs at the times. The times that are multiples of
+// the bucket size are sampled by a single query, and the others one by one.
+func (s *server) queryConfirmedBlobSamples(ctx context.Context, times map[int64]struct{}, bucketSize int64) (map[int64]confirmedBlobsSample, error) {
+	samples := make(map[int64]confirmedBlobsSample, len(times))
+	if len(times) == 0 {
+		return samples, nil
+	}
+
+	type timeRange struct {
+		start, end int64
+	}
+	var (
+		ranges       []timeRange
+		alignedRange *timeRange
+	)
+	for t := range times {
+		if t%bucketSize != 0 {
+			ranges = append(ranges, timeRange{start: t, end: t})
+			continue
+		}
+		if alignedRange == nil {
+			alignedRange = &timeRange{start: t, end: t}
+		}
+		alignedRange.start = min(alignedRange.start, t)
+		alignedRange.end = max(alignedRange.end, t)
+	}
+	if alignedRange != nil {
+		ranges = append(ranges, *alignedRange)
+	}
+
+	step := time.Duration(bucketSize) * time.Second
+	for _, r := range ranges {
+		result, err := s.promClient.QueryDisperserConfirmedBlobs(ctx, time.Unix(r.start, 0), time.Unix(r.end, 0), step)
+		if err != nil {
+			return nil, err
+		}
+		numbers := make(map[int64]float64, len(result.Number))
+		for _, v := range result.Number {
+			numbers[v.Timestamp.Unix()] = v.Value
+		}
+		for _, v := range result.Size {
+			t := v.Timestamp.Unix()
+			if _, ok := times[t]; !ok {
+				continue
+			}
+			if number, ok := numbers[t]; ok {
+				samples[t] = confirmedBlobsSample{size: v.Value, number: number}
+			}
+		}
+	}
+	return samples, nil
+}
+
+func newThroughputBucket(span throughputBucketSpan, startSample, endSample confirmedBlobsSample, bucketSize int64) *ThroughputBucket {
+	// The totals restart from zero when the batchers restart, in which case the bucket counts the totals at its end
+	bytes, blobs := endSample.size-startSample.size, endSample.number-startSample.number
+	if bytes < 0 || blobs < 0 {
+		bytes, blobs = endSample.size, endSample.number
+	}
+	return &ThroughputBucket{
+		Start:          uint64(span.start),
+		End:            uint64(span.end),
+		BytesDispersed: bytes,
+		BlobCount:      blobs,
+		Throughput:     bytes / float64(span.end-span.start),
+		Partial:        span.end-span.start < bucketSize,
+	}
+}
+
 func (s *server) calculateTotalCostGasUsed(ctx context.Context) (float64, error) {
 	batches, err := s.subgraphClient.QueryBatchesWithLimit(ctx, 1, 0)
 	if err != nil {
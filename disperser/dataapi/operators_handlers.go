@@ -0,0 +1,354 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/gammazero/workerpool"
+)
+
+const (
+	defaultNonSigningWindows = "24h"
+	defaultEjectionWindow    = "24h"
+	// The longest window of the non signing rates, which are computed from all the batches of the window
+	maxNonSigningWindow = 30 * 24 * time.Hour
+	// The non signing rates are cached for a short time, since computing them queries every batch of the window
+	nonSigningRateCacheTTL  = time.Minute
+	nonSigningRateCacheSize = 1024
+)
+
+// nonSigningWindow is a window of the non signing rates, named as it was requested, e.g. 24h
+type nonSigningWindow struct {
+	name     string
+	duration time.Duration
+}
+
+// cachedNonSigningRate is the non signing rate of an operator over a window, cached until it expires
+type cachedNonSigningRate struct {
+	rate      *OperatorNonSigningWindow
+	expiresAt time.Time
+}
+
+// parseNonSigningWindows parses the comma separated windows of the non signing rates, each being a duration such as
+// 1h or 90m, or a number of days such as 7d
+func parseNonSigningWindows(windows string) ([]nonSigningWindow, error) {
+	parsed := make([]nonSigningWindow, 0)
+	for _, name := range strings.Split(windows, ",") {
+		name = strings.TrimSpace(name)
+		var (
+			duration time.Duration
+			err      error
+		)
+		if days, ok := strings.CutSuffix(name, "d"); ok {
+			var n int64
+			n, err = strconv.ParseInt(days, 10, 64)
+			duration = time.Duration(n) * 24 * time.Hour
+		} else {
+			duration, err = time.ParseDuration(name)
+		}
+		if err != nil || duration < time.Second || duration > maxNonSigningWindow {
+			return nil, fmt.Errorf("%w: window %q must be a duration between 1s and %s", errInvalidParameter, name, maxNonSigningWindow)
+		}
+		parsed = append(parsed, nonSigningWindow{name: name, duration: duration})
+	}
+	return parsed, nil
+}
+
+// parseOperatorId returns the operator ID as it is indexed by the subgraphs, i.e. lower case hex prefixed with 0x
+func parseOperatorId(operatorId string) (string, error) {
+	id := strings.ToLower(strings.TrimPrefix(operatorId, "0x"))
+	decoded, err := hex.DecodeString(id)
+	if err != nil || len(decoded) != len(core.OperatorID{}) {
+		return "", fmt.Errorf("%w: operator ID %q must be 32 hex encoded bytes", errInvalidParameter, operatorId)
+	}
+	return "0x" + id, nil
+}
+
+// getOperatorNonSigningRates returns the signing rates of the operator over the windows, per quorum. The operator is
+// only expected to sign the batches whose reference block it was registered in the quorum at. The batches are queried
+// once for the longest window that isn't cached, and the shorter windows keep the batches confirmed within them.
+func (s *server) getOperatorNonSigningRates(ctx context.Context, operatorId string, windows []nonSigningWindow) ([]*OperatorNonSigningWindow, error) {
+	rates := make([]*OperatorNonSigningWindow, len(windows))
+	var longest time.Duration
+	for i, window := range windows {
+		if cached, ok := s.cachedNonSigningRate(operatorId, window); ok {
+			rates[i] = cached
+		} else {
+			longest = max(longest, window.duration)
+		}
+	}
+	if longest == 0 {
+		return rates, nil
+	}
+
+	events, err := s.subgraphClient.QueryOperatorQuorumEvents(ctx, operatorId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	batches, err := s.subgraphClient.QueryBatchSigningInfoInInterval(ctx, int64(longest.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	for i, window := range windows {
+		if rates[i] != nil {
+			continue
+		}
+		rates[i] = &OperatorNonSigningWindow{
+			Window:  window.name,
+			Quorums: computeOperatorSigningRates(operatorId, events, batchesWithin(batches, now, window.duration)),
+		}
+		s.cacheNonSigningRate(operatorId, window, rates[i])
+	}
+	return rates, nil
+}
+
+// batchesWithin returns the batches confirmed within the window ending now
+func batchesWithin(batches []*BatchSigningInfo, now time.Time, window time.Duration) []*BatchSigningInfo {
+	after := now.Add(-window).Unix()
+	within := make([]*BatchSigningInfo, 0, len(batches))
+	for _, batch := range batches {
+		if int64(batch.BlockTimestamp) > after {
+			within = append(within, batch)
+		}
+	}
+	return within
+}
+
+// cachedNonSigningRate returns the non signing rate of the operator over the window, if it is cached
+func (s *server) cachedNonSigningRate(operatorId string, window nonSigningWindow) (*OperatorNonSigningWindow, bool) {
+	cached, ok := s.nonSigningRateCache.Get(nonSigningRateCacheKey(operatorId, window))
+	if !ok || !time.Now().Before(cached.expiresAt) {
+		return nil, false
+	}
+	return &OperatorNonSigningWindow{Window: window.name, Quorums: cached.rate.Quorums}, true
+}
+
+func (s *server) cacheNonSigningRate(operatorId string, window nonSigningWindow, rate *OperatorNonSigningWindow) {
+	s.nonSigningRateCache.Add(nonSigningRateCacheKey(operatorId, window), &cachedNonSigningRate{rate: rate, expiresAt: time.Now().Add(nonSigningRateCacheTTL)})
+}
+
+// nonSigningRateCacheKey keys the rates by the duration of their window, whatever name it was requested with
+func nonSigningRateCacheKey(operatorId string, window nonSigningWindow) string {
+	return fmt.Sprintf("%s/%d", operatorId, int64(window.duration.Seconds()))
+}
+
+// computeOperatorSigningRates counts, for each quorum, the batches the operator was expected to sign and the ones
+// it signed. The quorums the operator wasn't expected to sign any batch of are left out.
+func computeOperatorSigningRates(operatorId string, events []*OperatorQuorumEvent, batches []*BatchSigningInfo) []*OperatorNonSigningQuorum {
+	rates := make(map[core.QuorumID]*OperatorNonSigningQuorum)
+	for _, batch := range batches {
+		quorums := operatorQuorumsAt(events, batch.ReferenceBlockNumber)
+		_, nonSigner := batch.NonSigners[operatorId]
+		for _, quorum := range batch.QuorumNumbers {
+			if _, ok := quorums[quorum]; !ok {
+				continue
+			}
+			rate, ok := rates[quorum]
+			if !ok {
+				rate = &OperatorNonSigningQuorum{QuorumId: quorum}
+				rates[quorum] = rate
+			}
+			rate.TotalBatches++
+			if !nonSigner {
+				rate.SignedBatches++
+			}
+		}
+	}
+
+	result := make([]*OperatorNonSigningQuorum, 0, len(rates))
+	for _, rate := range rates {
+		rate.SigningPercentage = math.Round(float64(rate.SignedBatches)/float64(rate.TotalBatches)*10000) / 100
+		rate.NonSigningPercentage = math.Round((100-rate.SigningPercentage)*100) / 100
+		result = append(result, rate)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QuorumId < result[j].QuorumId
+	})
+	return result
+}
+
+// operatorQuorumsAt returns the quorums the operator was registered in at the block, given its events ordered by
+// block. The events of a block apply to the state at that block.
+func operatorQuorumsAt(events []*OperatorQuorumEvent, blockNumber uint64) map[core.QuorumID]struct{} {
+	quorums := make(map[core.QuorumID]struct{})
+	for _, event := range events {
+		if event.BlockNumber > blockNumber {
+			break
+		}
+		for _, quorum := range event.QuorumNumbers {
+			if event.Added {
+				quorums[quorum] = struct{}{}
+			} else {
+				delete(quorums, quorum)
+			}
+		}
+	}
+	return quorums
+}
+
+// parseEjectionThreshold parses the non signing percentage above which the operators are ejection candidates
+func parseEjectionThreshold(threshold string) (float64, error) {
+	parsed, err := strconv.ParseFloat(threshold, 64)
+	if err != nil || parsed < 0 || parsed > 100 {
+		return 0, fmt.Errorf("%w: threshold %q must be a percentage between 0 and 100", errInvalidParameter, threshold)
+	}
+	return parsed, nil
+}
+
+// getEjectionCandidates returns the non signing rates over the window of the operators currently registered, one per
+// quorum they are registered in, along with the block of the registrations. The operators whose non signing rate is
+// above the threshold are flagged. The candidates are sorted by non signing rate, then by stake share, descending.
+func (s *server) getEjectionCandidates(ctx context.Context, window nonSigningWindow, threshold float64) ([]*OperatorEjectionCandidate, uint32, error) {
+	blockNumber, err := s.transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	quorumCount, err := s.transactor.GetQuorumCount(ctx, blockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+	quorums := make([]core.QuorumID, quorumCount)
+	for i := range quorums {
+		quorums[i] = core.QuorumID(i)
+	}
+	state, err := s.chainState.GetOperatorState(ctx, uint(blockNumber), quorums)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	operatorIds := make(map[string]struct{})
+	for _, operators := range state.Operators {
+		for id := range operators {
+			operatorIds["0x"+hex.EncodeToString(id[:])] = struct{}{}
+		}
+	}
+	rates, err := s.getOperatorsNonSigningRates(ctx, operatorIds, window)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	candidates := make([]*OperatorEjectionCandidate, 0)
+	for quorum, operators := range state.Operators {
+		total, ok := state.Totals[quorum]
+		if !ok || (*big.Int)(total.Stake).Sign() <= 0 {
+			continue
+		}
+		for id, operator := range operators {
+			operatorId := "0x" + hex.EncodeToString(id[:])
+			stake, _ := new(big.Rat).SetFrac(new(big.Int).Mul(operator.Stake, big.NewInt(100)), total.Stake).Float64()
+			candidate := &OperatorEjectionCandidate{
+				OperatorId:      operatorId,
+				QuorumId:        quorum,
+				StakePercentage: math.Round(stake*100) / 100,
+			}
+			for _, rate := range rates[operatorId].Quorums {
+				if rate.QuorumId == quorum {
+					candidate.TotalBatches = rate.TotalBatches
+					candidate.SignedBatches = rate.SignedBatches
+					candidate.NonSigningPercentage = rate.NonSigningPercentage
+				}
+			}
+			candidate.AboveThreshold = candidate.TotalBatches > 0 && candidate.NonSigningPercentage > threshold
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.NonSigningPercentage != b.NonSigningPercentage {
+			return a.NonSigningPercentage > b.NonSigningPercentage
+		}
+		if a.StakePercentage != b.StakePercentage {
+			return a.StakePercentage > b.StakePercentage
+		}
+		if a.OperatorId != b.OperatorId {
+			return a.OperatorId < b.OperatorId
+		}
+		return a.QuorumId < b.QuorumId
+	})
+	return candidates, blockNumber, nil
+}
+
+// getOperatorsNonSigningRates returns the non signing rates of the operators over the window, sharing the cache of
+// the rates of the individual operators. The batches of the window are only queried once for all the operators.
+func (s *server) getOperatorsNonSigningRates(ctx context.Context, operatorIds map[string]struct{}, window nonSigningWindow) (map[string]*OperatorNonSigningWindow, error) {
+	rates := make(map[string]*OperatorNonSigningWindow, len(operatorIds))
+	missing := make([]string, 0)
+	for operatorId := range operatorIds {
+		if cached, ok := s.cachedNonSigningRate(operatorId, window); ok {
+			rates[operatorId] = cached
+		} else {
+			missing = append(missing, operatorId)
+		}
+	}
+	if len(missing) == 0 {
+		return rates, nil
+	}
+
+	batches, err := s.subgraphClient.QueryBatchSigningInfoInInterval(ctx, int64(window.duration.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		operatorId string
+		events     []*OperatorQuorumEvent
+		err        error
+	}
+	pool := workerpool.New(maxWorkersGetOperatorState)
+	results := make(chan result, len(missing))
+	for _, operatorId := range missing {
+		operatorId := operatorId
+		pool.Submit(func() {
+			events, err := s.subgraphClient.QueryOperatorQuorumEvents(ctx, operatorId)
+			results <- result{operatorId: operatorId, events: events, err: err}
+		})
+	}
+	pool.StopWait()
+	close(results)
+
+	for r := range results {
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to query the quorum events of operator %s: %w", r.operatorId, r.err)
+		}
+		rates[r.operatorId] = &OperatorNonSigningWindow{
+			Window:  window.name,
+			Quorums: computeOperatorSigningRates(r.operatorId, r.events, batches),
+		}
+		s.cacheNonSigningRate(r.operatorId, window, rates[r.operatorId])
+	}
+	return rates, nil
+}
+
+// writeEjectionCandidatesCSV writes the candidates as CSV, with a header row
+func writeEjectionCandidatesCSV(w io.Writer, candidates []*OperatorEjectionCandidate) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"operator_id", "quorum_id", "stake_percentage", "total_batches", "signed_batches", "nonsigning_percentage", "above_threshold"}); err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		if err := writer.Write([]string{
+			candidate.OperatorId,
+			strconv.Itoa(int(candidate.QuorumId)),
+			strconv.FormatFloat(candidate.StakePercentage, 'f', -1, 64),
+			strconv.Itoa(candidate.TotalBatches),
+			strconv.Itoa(candidate.SignedBatches),
+			strconv.FormatFloat(candidate.NonSigningPercentage, 'f', -1, 64),
+			strconv.FormatBool(candidate.AboveThreshold),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
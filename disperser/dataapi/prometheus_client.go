@@ -15,6 +15,7 @@ const maxNumOfDataPoints = 3500
 type (
 	PrometheusClient interface {
 		QueryDisperserBlobSizeBytesPerSecond(ctx context.Context, start time.Time, end time.Time) (*PrometheusResult, error)
+		QueryDisperserConfirmedBlobs(ctx context.Context, start time.Time, end time.Time, step time.Duration) (*PrometheusBlobsResult, error)
 	}
 
 	PrometheusResultValues struct {
@@ -26,6 +27,12 @@ type (
 		Values []*PrometheusResultValues
 	}
 
+	// PrometheusBlobsResult is the total size in bytes and the total number of the confirmed blobs over time
+	PrometheusBlobsResult struct {
+		Size   []*PrometheusResultValues
+		Number []*PrometheusResultValues
+	}
+
 	prometheusClient struct {
 		api     prometheus.Api
 		cluster string
@@ -69,3 +76,35 @@ func (pc *prometheusClient) QueryDisperserBlobSizeBytesPerSecond(ctx context.Con
 		Values: values,
 	}, nil
 }
+
+// QueryDisperserConfirmedBlobs returns the total size and number of the blobs confirmed by the batchers, evaluated
+// every step from start to end
+func (pc *prometheusClient) QueryDisperserConfirmedBlobs(ctx context.Context, start time.Time, end time.Time, step time.Duration) (*PrometheusBlobsResult, error) {
+	query := fmt.Sprintf("sum by (data) (eigenda_batcher_blobs_total{state=\"confirmed\",data=~\"size|number\",cluster=\"%s\"})", pc.cluster)
+	v, _, err := pc.api.QueryRange(ctx, query, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PrometheusBlobsResult{
+		Size:   make([]*PrometheusResultValues, 0),
+		Number: make([]*PrometheusResultValues, 0),
+	}
+	for _, stream := range v.(model.Matrix) {
+		values := make([]*PrometheusResultValues, len(stream.Values))
+		for i, v := range stream.Values {
+			values[i] = &PrometheusResultValues{
+				Timestamp: v.Timestamp.Time(),
+				Value:     float64(v.Value),
+			}
+		}
+		switch stream.Metric["data"] {
+		case "size":
+			result.Size = values
+		case "number":
+			result.Number = values
+		}
+	}
+
+	return result, nil
+}
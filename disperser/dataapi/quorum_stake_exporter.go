@@ -0,0 +1,153 @@
+package dataapi
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// QuorumStakeExporter periodically reads the operator state of the quorums at the latest block, and exports their
+// stake distribution as metrics
+type QuorumStakeExporter struct {
+	transactor core.Transactor
+	chainState core.ChainState
+	metrics    *Metrics
+	interval   time.Duration
+	logger     common.Logger
+}
+
+// quorumStake is the stake distribution of a quorum
+type quorumStake struct {
+	operators    int
+	maxOperators uint32
+	total        float64
+	max          float64
+	median       float64
+	min          float64
+	topShare     float64
+}
+
+func NewQuorumStakeExporter(transactor core.Transactor, chainState core.ChainState, metrics *Metrics, interval time.Duration, logger common.Logger) *QuorumStakeExporter {
+	return &QuorumStakeExporter{
+		transactor: transactor,
+		chainState: chainState,
+		metrics:    metrics,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+// Start refreshes the metrics right away, then at every interval until the context is done
+func (e *QuorumStakeExporter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			if err := e.Refresh(ctx); err != nil {
+				e.logger.Error("failed to refresh the quorum stake metrics", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Refresh reads the operator state at the latest block and updates the metrics. If the state can't be read, the
+// metrics keep their last values and are marked stale.
+func (e *QuorumStakeExporter) Refresh(ctx context.Context) error {
+	blockNumber, stakes, err := e.readQuorumStakes(ctx)
+	if err != nil {
+		e.metrics.QuorumStakeStale.Set(1)
+		return err
+	}
+
+	for quorum, stake := range stakes {
+		label := strconv.Itoa(int(quorum))
+		e.metrics.QuorumOperators.WithLabelValues(label).Set(float64(stake.operators))
+		e.metrics.QuorumMaxOperators.WithLabelValues(label).Set(float64(stake.maxOperators))
+		e.metrics.QuorumTotalStake.WithLabelValues(label).Set(stake.total)
+		e.metrics.QuorumMaxOperatorStake.WithLabelValues(label).Set(stake.max)
+		e.metrics.QuorumMedianOperatorStake.WithLabelValues(label).Set(stake.median)
+		e.metrics.QuorumMinOperatorStake.WithLabelValues(label).Set(stake.min)
+		e.metrics.QuorumTopOperatorStakeShare.WithLabelValues(label).Set(stake.topShare)
+	}
+	e.metrics.QuorumStakeBlockNumber.Set(float64(blockNumber))
+	e.metrics.QuorumStakeLastRefresh.Set(float64(time.Now().Unix()))
+	e.metrics.QuorumStakeStale.Set(0)
+	return nil
+}
+
+// readQuorumStakes reads the stake distribution of all the quorums at the latest block. Nothing is returned unless
+// every quorum could be read, so that the metrics are updated all at once.
+func (e *QuorumStakeExporter) readQuorumStakes(ctx context.Context) (uint32, map[core.QuorumID]*quorumStake, error) {
+	blockNumber, err := e.transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	quorumCount, err := e.transactor.GetQuorumCount(ctx, blockNumber)
+	if err != nil {
+		return 0, nil, err
+	}
+	quorums := make([]core.QuorumID, quorumCount)
+	for i := range quorums {
+		quorums[i] = core.QuorumID(i)
+	}
+	state, err := e.chainState.GetOperatorState(ctx, uint(blockNumber), quorums)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	stakes := make(map[core.QuorumID]*quorumStake, len(quorums))
+	for _, quorum := range quorums {
+		params, err := e.transactor.GetOperatorSetParams(ctx, quorum)
+		if err != nil {
+			return 0, nil, err
+		}
+		stake := computeQuorumStake(state.Operators[quorum])
+		stake.maxOperators = params.MaxOperatorCount
+		stakes[quorum] = stake
+	}
+	return blockNumber, stakes, nil
+}
+
+// computeQuorumStake computes the stake distribution of the operators of a quorum
+func computeQuorumStake(operators map[core.OperatorID]*core.OperatorInfo) *quorumStake {
+	stakes := make([]*big.Int, 0, len(operators))
+	total := new(big.Int)
+	for _, operator := range operators {
+		stakes = append(stakes, operator.Stake)
+		total.Add(total, operator.Stake)
+	}
+	stake := &quorumStake{operators: len(stakes), total: toFloat(total)}
+	if len(stakes) == 0 {
+		return stake
+	}
+
+	sort.Slice(stakes, func(i, j int) bool {
+		return stakes[i].Cmp(stakes[j]) < 0
+	})
+	stake.min = toFloat(stakes[0])
+	stake.max = toFloat(stakes[len(stakes)-1])
+	if middle := len(stakes) / 2; len(stakes)%2 == 1 {
+		stake.median = toFloat(stakes[middle])
+	} else {
+		stake.median = toFloat(new(big.Int).Add(stakes[middle-1], stakes[middle])) / 2
+	}
+	if total.Sign() > 0 {
+		stake.topShare, _ = new(big.Rat).SetFrac(new(big.Int).Mul(stakes[len(stakes)-1], big.NewInt(100)), total).Float64()
+	}
+	return stake
+}
+
+func toFloat(n *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f
+}
@@ -0,0 +1,53 @@
+package dataapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuorumStakeExporterRefresh(t *testing.T) {
+	// The three operators have stakes 1, 2 and 3 in quorums 0 and 1
+	tx := &coremock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(300), nil).Once()
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	tx.On("GetOperatorSetParams").Return(&core.OperatorSetParam{MaxOperatorCount: 200}, nil)
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(3))
+	assert.NoError(t, err)
+	metrics := dataapi.NewMetrics("9001", &commock.Logger{})
+	exporter := dataapi.NewQuorumStakeExporter(tx, chainState, metrics, 0, &commock.Logger{})
+
+	assertQuorumGauges := func() {
+		for _, quorum := range []string{"0", "1"} {
+			assert.Equal(t, 3.0, testutil.ToFloat64(metrics.QuorumOperators.WithLabelValues(quorum)))
+			assert.Equal(t, 200.0, testutil.ToFloat64(metrics.QuorumMaxOperators.WithLabelValues(quorum)))
+			assert.Equal(t, 6.0, testutil.ToFloat64(metrics.QuorumTotalStake.WithLabelValues(quorum)))
+			assert.Equal(t, 3.0, testutil.ToFloat64(metrics.QuorumMaxOperatorStake.WithLabelValues(quorum)))
+			assert.Equal(t, 2.0, testutil.ToFloat64(metrics.QuorumMedianOperatorStake.WithLabelValues(quorum)))
+			assert.Equal(t, 1.0, testutil.ToFloat64(metrics.QuorumMinOperatorStake.WithLabelValues(quorum)))
+			assert.Equal(t, 50.0, testutil.ToFloat64(metrics.QuorumTopOperatorStakeShare.WithLabelValues(quorum)))
+		}
+		assert.Equal(t, 300.0, testutil.ToFloat64(metrics.QuorumStakeBlockNumber))
+	}
+
+	assert.NoError(t, exporter.Refresh(context.Background()))
+	assertQuorumGauges()
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.QuorumStakeStale))
+	lastRefresh := testutil.ToFloat64(metrics.QuorumStakeLastRefresh)
+	assert.Greater(t, lastRefresh, 0.0)
+
+	// A failed read keeps the last values, and marks them stale
+	readErr := errors.New("rpc unavailable")
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), readErr)
+	assert.ErrorIs(t, exporter.Refresh(context.Background()), readErr)
+	assertQuorumGauges()
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.QuorumStakeStale))
+	assert.Equal(t, lastRefresh, testutil.ToFloat64(metrics.QuorumStakeLastRefresh))
+}
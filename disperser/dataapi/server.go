@@ -1,8 +1,10 @@
 package dataapi
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -18,6 +20,7 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/logger"
 	"github.com/gin-gonic/gin"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	swaggerfiles "github.com/swaggo/files"     // swagger embed files
 	ginswagger "github.com/swaggo/gin-swagger" // gin-swagger middleware
@@ -28,7 +31,10 @@ const (
 	maxQueryBatchesLimit = 2
 )
 
-var errNotFound = errors.New("not found")
+var (
+	errNotFound         = errors.New("not found")
+	errInvalidParameter = errors.New("invalid parameter")
+)
 
 type (
 	BlobMetadataResponse struct {
@@ -59,6 +65,23 @@ type (
 		Timestamp  uint64  `json:"timestamp"`
 	}
 
+	// ThroughputBucket is the data confirmed in a time bucket, in unix seconds from Start inclusive to End exclusive.
+	// Partial buckets are shorter than the bucket size, being clipped to the queried range.
+	ThroughputBucket struct {
+		Start          uint64  `json:"start"`
+		End            uint64  `json:"end"`
+		BytesDispersed float64 `json:"bytes_dispersed"`
+		BlobCount      float64 `json:"blob_count"`
+		Throughput     float64 `json:"throughput"`
+		Partial        bool    `json:"partial"`
+	}
+
+	ThroughputBucketsResponse struct {
+		BucketSize uint64              `json:"bucket_size"`
+		Meta       Meta                `json:"meta"`
+		Data       []*ThroughputBucket `json:"data"`
+	}
+
 	Meta struct {
 		Size int `json:"size"`
 	}
@@ -68,6 +91,88 @@ type (
 		Data []*BlobMetadataResponse `json:"data"`
 	}
 
+	QuorumSignedStake struct {
+		QuorumId         core.QuorumID `json:"quorum_id"`
+		SignedPercentage uint8         `json:"signed_percentage"`
+		// NonSigners are the operators of the quorum that did not sign the batch, recorded by the batcher
+		NonSigners []*QuorumNonSigner `json:"non_signers"`
+	}
+
+	// QuorumNonSigner is an operator that did not sign a batch, with the reason: no_response, timeout,
+	// invalid_signature or refused
+	QuorumNonSigner struct {
+		OperatorId string `json:"operator_id"`
+		Reason     string `json:"reason"`
+	}
+
+	BatchResponse struct {
+		BatchId                 uint64               `json:"batch_id"`
+		BatchHeaderHash         string               `json:"batch_header_hash"`
+		ReferenceBlockNumber    uint64               `json:"reference_block_number"`
+		QuorumNumbers           []core.QuorumID      `json:"quorum_numbers"`
+		BlobCount               int                  `json:"blob_count"`
+		ConfirmationTxnHash     string               `json:"confirmation_txn_hash"`
+		ConfirmationBlockNumber uint64               `json:"confirmation_block_number"`
+		ConfirmedAt             uint64               `json:"confirmed_at"`
+		SignedStake             []*QuorumSignedStake `json:"signed_stake"`
+	}
+
+	// PaginationMeta is the Meta of the paginated lists. NextCursor is the cursor of the next page, empty if there
+	// are no more items.
+	PaginationMeta struct {
+		Size       int    `json:"size"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+
+	BatchesResponse struct {
+		Meta PaginationMeta   `json:"meta"`
+		Data []*BatchResponse `json:"data"`
+	}
+
+	PaginatedBlobsResponse struct {
+		Meta PaginationMeta          `json:"meta"`
+		Data []*BlobMetadataResponse `json:"data"`
+	}
+
+	OperatorNonSigningQuorum struct {
+		QuorumId             core.QuorumID `json:"quorum_id"`
+		TotalBatches         int           `json:"total_batches"`
+		SignedBatches        int           `json:"signed_batches"`
+		SigningPercentage    float64       `json:"signing_percentage"`
+		NonSigningPercentage float64       `json:"nonsigning_percentage"`
+	}
+
+	OperatorNonSigningWindow struct {
+		Window  string                      `json:"window"`
+		Quorums []*OperatorNonSigningQuorum `json:"quorums"`
+	}
+
+	OperatorNonSigningResponse struct {
+		OperatorId string                      `json:"operator_id"`
+		Meta       Meta                        `json:"meta"`
+		Data       []*OperatorNonSigningWindow `json:"data"`
+	}
+
+	// OperatorEjectionCandidate is the non signing rate over a window of an operator in a quorum it is currently
+	// registered in, with its current share of the stake of the quorum
+	OperatorEjectionCandidate struct {
+		OperatorId           string        `json:"operator_id"`
+		QuorumId             core.QuorumID `json:"quorum_id"`
+		StakePercentage      float64       `json:"stake_percentage"`
+		TotalBatches         int           `json:"total_batches"`
+		SignedBatches        int           `json:"signed_batches"`
+		NonSigningPercentage float64       `json:"nonsigning_percentage"`
+		AboveThreshold       bool          `json:"above_threshold"`
+	}
+
+	OperatorEjectionCandidatesResponse struct {
+		Window      string                       `json:"window"`
+		Threshold   float64                      `json:"threshold"`
+		BlockNumber uint32                       `json:"block_number"`
+		Meta        Meta                         `json:"meta"`
+		Data        []*OperatorEjectionCandidate `json:"data"`
+	}
+
 	ErrorResponse struct {
 		Error string `json:"error"`
 	}
@@ -83,6 +188,9 @@ type (
 		transactor     core.Transactor
 		chainState     core.ChainState
 
+		nonSigningRateCache   *lru.Cache[string, *cachedNonSigningRate]
+		throughputBucketCache *lru.Cache[string, *ThroughputBucket]
+
 		metrics *Metrics
 	}
 )
@@ -97,6 +205,9 @@ func NewServer(
 	logger common.Logger,
 	metrics *Metrics,
 ) *server {
+	// The caches are only ever created with a positive size
+	nonSigningRateCache, _ := lru.New[string, *cachedNonSigningRate](nonSigningRateCacheSize)
+	throughputBucketCache, _ := lru.New[string, *ThroughputBucket](throughputBucketCacheSize)
 	return &server{
 		logger:         logger,
 		serverMode:     config.ServerMode,
@@ -108,6 +219,9 @@ func NewServer(
 		transactor:     transactor,
 		chainState:     chainState,
 		metrics:        metrics,
+
+		nonSigningRateCache:   nonSigningRateCache,
+		throughputBucketCache: throughputBucketCache,
 	}
 }
 
@@ -128,13 +242,22 @@ func (s *server) Start() error {
 		{
 			feed.GET("/blobs", s.FetchBlobsHandler)
 			feed.GET("/blobs/:blob_key", s.FetchBlobHandler)
+			feed.GET("/batches", s.FetchBatchesHandler)
+			feed.GET("/batches/:batch_header_hash/blobs", s.FetchBatchBlobsHandler)
+			feed.GET("/accounts/:account_id/blobs", s.FetchAccountBlobsHandler)
 		}
 		metrics := v1.Group("/metrics")
 		{
 			metrics.GET("/", s.FetchMetricsHandler)
 			metrics.GET("/throughput", s.FetchMetricsTroughputHandler)
+			metrics.GET("/throughput/buckets", s.FetchMetricsThroughputBucketsHandler)
 			metrics.GET("/non_signers", s.FetchNonSigners)
 		}
+		operators := v1.Group("/operators")
+		{
+			operators.GET("/ejection_candidates", s.FetchOperatorEjectionCandidatesHandler)
+			operators.GET("/:operator_id/nonsigning", s.FetchOperatorNonSigningHandler)
+		}
 		swagger := v1.Group("/swagger")
 		{
 			swagger.GET("/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
@@ -240,6 +363,170 @@ func (s *server) FetchBlobsHandler(c *gin.Context) {
 	})
 }
 
+// FetchBatchesHandler godoc
+//
+//	@Summary	Fetch the confirmed batches, latest first
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		limit	query		int		false	"Limit [default: 10, max: 100]"
+//	@Param		cursor	query		string	false	"Cursor of the page, the next_cursor of the previous page [default: latest batch]"
+//	@Param		start	query		int		false	"Start unix timestamp of the confirmations [default: 0]"
+//	@Param		end		query		int		false	"End unix timestamp of the confirmations [default: unix time now]"
+//	@Success	200		{object}	BatchesResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/feed/batches [get]
+func (s *server) FetchBatchesHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBatches", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	limit, err := parseFeedLimit(c.Query("limit"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatches")
+		errorResponse(c, err)
+		return
+	}
+	start, end, err := parseTimeRange(c.Query("start"), c.Query("end"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatches")
+		errorResponse(c, err)
+		return
+	}
+
+	batches, nextCursor, err := s.getBatches(c.Request.Context(), c.Query("cursor"), start, end, limit)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatches")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBatches")
+	c.JSON(http.StatusOK, BatchesResponse{
+		Meta: PaginationMeta{
+			Size:       len(batches),
+			NextCursor: nextCursor,
+		},
+		Data: batches,
+	})
+}
+
+// FetchBatchBlobsHandler godoc
+//
+//	@Summary	Fetch the blobs metadata of a batch, by blob index
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch header hash in hex string"
+//	@Param		limit				query		int		false	"Limit [default: 10, max: 100]"
+//	@Param		cursor				query		string	false	"Cursor of the page, the next_cursor of the previous page [default: first blob]"
+//	@Param		status				query		string	false	"Comma separated blob statuses, e.g. confirmed,finalized [default: all]"
+//	@Success	200					{object}	PaginatedBlobsResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/feed/batches/{batch_header_hash}/blobs [get]
+func (s *server) FetchBatchBlobsHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBatchBlobs", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	batchHeaderHash, err := parseBatchHeaderHash(c.Param("batch_header_hash"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatchBlobs")
+		errorResponse(c, err)
+		return
+	}
+	limit, err := parseFeedLimit(c.Query("limit"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatchBlobs")
+		errorResponse(c, err)
+		return
+	}
+	statuses, err := parseBlobStatuses(c.Query("status"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatchBlobs")
+		errorResponse(c, err)
+		return
+	}
+
+	metadatas, nextCursor, err := s.getBatchBlobs(c.Request.Context(), batchHeaderHash, c.Query("cursor"), statuses, limit)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatchBlobs")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBatchBlobs")
+	c.JSON(http.StatusOK, PaginatedBlobsResponse{
+		Meta: PaginationMeta{
+			Size:       len(metadatas),
+			NextCursor: nextCursor,
+		},
+		Data: metadatas,
+	})
+}
+
+// FetchAccountBlobsHandler godoc
+//
+//	@Summary	Fetch the blobs metadata of a requestor account, latest batch first
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		account_id	path		string	true	"Account ID of the requestor"
+//	@Param		limit		query		int		false	"Limit [default: 10, max: 100]"
+//	@Param		cursor		query		string	false	"Cursor of the page, the next_cursor of the previous page [default: latest blob]"
+//	@Param		status		query		string	false	"Comma separated blob statuses, e.g. confirmed,finalized [default: all]"
+//	@Param		start		query		int		false	"Start unix timestamp of the confirmations [default: 0]"
+//	@Param		end			query		int		false	"End unix timestamp of the confirmations [default: unix time now]"
+//	@Success	200			{object}	PaginatedBlobsResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/feed/accounts/{account_id}/blobs [get]
+func (s *server) FetchAccountBlobsHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchAccountBlobs", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	limit, err := parseFeedLimit(c.Query("limit"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobs")
+		errorResponse(c, err)
+		return
+	}
+	statuses, err := parseBlobStatuses(c.Query("status"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobs")
+		errorResponse(c, err)
+		return
+	}
+	start, end, err := parseTimeRange(c.Query("start"), c.Query("end"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobs")
+		errorResponse(c, err)
+		return
+	}
+
+	metadatas, nextCursor, err := s.getAccountBlobs(c.Request.Context(), c.Param("account_id"), c.Query("cursor"), statuses, start, end, limit)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobs")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchAccountBlobs")
+	c.JSON(http.StatusOK, PaginatedBlobsResponse{
+		Meta: PaginationMeta{
+			Size:       len(metadatas),
+			NextCursor: nextCursor,
+		},
+		Data: metadatas,
+	})
+}
+
 // FetchMetricsHandler godoc
 //
 //	@Summary	Fetch metrics
@@ -325,6 +612,69 @@ func (s *server) FetchMetricsTroughputHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, ths)
 }
 
+// FetchMetricsThroughputBucketsHandler godoc
+//
+//	@Summary	Fetch the data confirmed in time buckets
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start	query		int		false	"Start unix timestamp [default: 1 hour ago]"
+//	@Param		end		query		int		false	"End unix timestamp [default: unix time now]"
+//	@Param		bucket	query		int		false	"Bucket size in seconds, enlarged for long ranges [default: 60]"
+//	@Param		partial	query		string	false	"Whether the partial buckets at the edges of the range are marked or dropped: mark or drop [default: mark]"
+//	@Success	200		{object}	ThroughputBucketsResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/throughput/buckets  [get]
+func (s *server) FetchMetricsThroughputBucketsHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchMetricsThroughputBuckets", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)), 10, 64)
+	if err != nil || start < 0 {
+		s.metrics.IncrementFailedRequestNum("FetchMetricsThroughputBuckets")
+		errorResponse(c, fmt.Errorf("invalid start: %w", errInvalidParameter))
+		return
+	}
+	end, err := strconv.ParseInt(c.DefaultQuery("end", strconv.FormatInt(now.Unix(), 10)), 10, 64)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchMetricsThroughputBuckets")
+		errorResponse(c, fmt.Errorf("invalid end: %w", errInvalidParameter))
+		return
+	}
+	bucketSize, err := strconv.ParseInt(c.DefaultQuery("bucket", strconv.Itoa(defaultThroughputBucketSize)), 10, 64)
+	if err != nil || bucketSize <= 0 {
+		s.metrics.IncrementFailedRequestNum("FetchMetricsThroughputBuckets")
+		errorResponse(c, fmt.Errorf("invalid bucket size: %w", errInvalidParameter))
+		return
+	}
+	partial := c.DefaultQuery("partial", "mark")
+	if partial != "mark" && partial != "drop" {
+		s.metrics.IncrementFailedRequestNum("FetchMetricsThroughputBuckets")
+		errorResponse(c, fmt.Errorf("partial %q must be mark or drop: %w", partial, errInvalidParameter))
+		return
+	}
+
+	buckets, bucketSize, err := s.getThroughputBuckets(c.Request.Context(), start, end, bucketSize, partial == "drop")
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchMetricsThroughputBuckets")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchMetricsThroughputBuckets")
+	c.JSON(http.StatusOK, ThroughputBucketsResponse{
+		BucketSize: uint64(bucketSize),
+		Meta: Meta{
+			Size: len(buckets),
+		},
+		Data: buckets,
+	})
+}
+
 // FetchNonSigners godoc
 //
 //	@Summary	Fetch non signers
@@ -357,6 +707,127 @@ func (s *server) FetchNonSigners(c *gin.Context) {
 	c.JSON(http.StatusOK, metric)
 }
 
+// FetchOperatorNonSigningHandler godoc
+//
+//	@Summary	Fetch the signing rates of an operator per quorum over time windows
+//	@Tags		Operators
+//	@Produce	json
+//	@Param		operator_id	path		string	true	"Operator ID"
+//	@Param		windows		query		string	false	"Comma separated windows, e.g. 1h,24h,7d [default: 24h]"
+//	@Success	200			{object}	OperatorNonSigningResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/{operator_id}/nonsigning  [get]
+func (s *server) FetchOperatorNonSigningHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorNonSigning", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	operatorId, err := parseOperatorId(c.Param("operator_id"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorNonSigning")
+		errorResponse(c, err)
+		return
+	}
+	windows, err := parseNonSigningWindows(c.DefaultQuery("windows", defaultNonSigningWindows))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorNonSigning")
+		errorResponse(c, err)
+		return
+	}
+
+	rates, err := s.getOperatorNonSigningRates(c.Request.Context(), operatorId, windows)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorNonSigning")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorNonSigning")
+	c.JSON(http.StatusOK, OperatorNonSigningResponse{
+		OperatorId: operatorId,
+		Meta: Meta{
+			Size: len(rates),
+		},
+		Data: rates,
+	})
+}
+
+// FetchOperatorEjectionCandidatesHandler godoc
+//
+//	@Summary	Fetch the non signing rates of the registered operators per quorum, flagging the ejection candidates
+//	@Tags		Operators
+//	@Produce	json
+//	@Produce	text/csv
+//	@Param		window		query		string	false	"Window of the non signing rates, e.g. 1h or 7d [default: 24h]"
+//	@Param		threshold	query		number	true	"Non signing percentage above which the operators are ejection candidates"
+//	@Param		format		query		string	false	"Format of the response: json or csv [default: json]"
+//	@Success	200			{object}	OperatorEjectionCandidatesResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators/ejection_candidates  [get]
+func (s *server) FetchOperatorEjectionCandidatesHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorEjectionCandidates", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	windows, err := parseNonSigningWindows(c.DefaultQuery("window", defaultEjectionWindow))
+	if err != nil || len(windows) != 1 {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorEjectionCandidates")
+		if err == nil {
+			err = fmt.Errorf("%w: a single window must be requested", errInvalidParameter)
+		}
+		errorResponse(c, err)
+		return
+	}
+	threshold, err := parseEjectionThreshold(c.Query("threshold"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorEjectionCandidates")
+		errorResponse(c, err)
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorEjectionCandidates")
+		errorResponse(c, fmt.Errorf("format %q must be json or csv: %w", format, errInvalidParameter))
+		return
+	}
+
+	candidates, blockNumber, err := s.getEjectionCandidates(c.Request.Context(), windows[0], threshold)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorEjectionCandidates")
+		errorResponse(c, err)
+		return
+	}
+
+	if format == "csv" {
+		var buf bytes.Buffer
+		if err := writeEjectionCandidatesCSV(&buf, candidates); err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchOperatorEjectionCandidates")
+			errorResponse(c, err)
+			return
+		}
+		s.metrics.IncrementSuccessfulRequestNum("FetchOperatorEjectionCandidates")
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorEjectionCandidates")
+	c.JSON(http.StatusOK, OperatorEjectionCandidatesResponse{
+		Window:      windows[0].name,
+		Threshold:   threshold,
+		BlockNumber: blockNumber,
+		Meta: Meta{
+			Size: len(candidates),
+		},
+		Data: candidates,
+	})
+}
+
 func (s *server) getBlobMetadataByBatchesWithLimit(ctx context.Context, limit int) ([]*Batch, []*disperser.BlobMetadata, error) {
 	var (
 		blobMetadatas   = make([]*disperser.BlobMetadata, 0)
@@ -432,6 +903,8 @@ func errorResponse(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, errNotFound):
 		code = http.StatusNotFound
+	case errors.Is(err, errInvalidParameter):
+		code = http.StatusBadRequest
 	default:
 		code = http.StatusInternalServerError
 	}
@@ -5,10 +5,15 @@ import (
 	_ "embed"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	commock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
@@ -17,13 +22,16 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	prommock "github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
 	subgraphmock "github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph/mock"
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/common/model"
+	"github.com/shurcooL/graphql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/goleak"
 )
 
@@ -42,6 +50,8 @@ var (
 	mockTx                          = &coremock.MockTransactor{}
 	mockChainState, _               = coremock.NewChainDataMock(core.OperatorIndex(1))
 	testDataApiServer               = dataapi.NewServer(config, blobstore, prometheusClient, subgraphClient, mockTx, mockChainState, &commock.Logger{}, dataapi.NewMetrics("9001", &commock.Logger{}))
+	batchBlobsOnce                  sync.Once
+	batchBlobKeys                   []string
 	expectedBatchHeaderHash         = [32]byte{1, 2, 3}
 	expectedBlobIndex               = uint32(1)
 	expectedRequestedAt             = uint64(5567830000000000000)
@@ -228,6 +238,429 @@ func TestFetchMetricsTroughputHandler(t *testing.T) {
 	assert.Equal(t, float64(5.761665289256135e+07), totalThroughput)
 }
 
+func TestFetchOperatorNonSigningHandler(t *testing.T) {
+	r := setUpRouter()
+
+	// The batches are queried once for the longest window, and the 1h window only has the ones from the operator
+	// joining quorum 1
+	mockSubgraphApi.On("QueryOperatorRegisteredsByOperatorId", nonSigningOperatorId).Return([]*subgraph.OperatorRegistered{{OperatorId: graphql.String(nonSigningOperatorId), Operator: graphql.String(nonSigningOperatorAddress)}}, nil)
+	mockSubgraphApi.On("QueryOperatorRegisteredsByOperatorId", mock.Anything).Return(nil, nil)
+	mockSubgraphApi.On("QueryOperatorQuorumEvents", nonSigningOperatorAddress).Return(subgraphOperatorQuorumEvents, nil)
+	mockSubgraphApi.On("QueryBatchSigningInfoInInterval", int64(7*24*3600)).Return(subgraphBatchSigningInfo, nil).Once()
+
+	r.GET("/v1/operators/:operator_id/nonsigning", testDataApiServer.FetchOperatorNonSigningHandler)
+
+	fetch := func(url string) (int, dataapi.OperatorNonSigningResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		r.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+
+		var response dataapi.OperatorNonSigningResponse
+		err = json.Unmarshal(data, &response)
+		assert.NoError(t, err)
+		return res.StatusCode, response
+	}
+
+	// Batch 1 predates the registration of the operator, and batches 4 and 5 its removal from quorum 0
+	expected := []*dataapi.OperatorNonSigningWindow{
+		{
+			Window: "1h",
+			Quorums: []*dataapi.OperatorNonSigningQuorum{
+				{QuorumId: 0, TotalBatches: 1, SignedBatches: 0, SigningPercentage: 0, NonSigningPercentage: 100},
+				{QuorumId: 1, TotalBatches: 3, SignedBatches: 2, SigningPercentage: 66.67, NonSigningPercentage: 33.33},
+			},
+		},
+		{
+			Window: "7d",
+			Quorums: []*dataapi.OperatorNonSigningQuorum{
+				{QuorumId: 0, TotalBatches: 2, SignedBatches: 1, SigningPercentage: 50, NonSigningPercentage: 50},
+				{QuorumId: 1, TotalBatches: 3, SignedBatches: 2, SigningPercentage: 66.67, NonSigningPercentage: 33.33},
+			},
+		},
+	}
+	code, response := fetch("/v1/operators/" + nonSigningOperatorId + "/nonsigning?windows=1h,7d")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, nonSigningOperatorId, response.OperatorId)
+	assert.Equal(t, 2, response.Meta.Size)
+	assert.Equal(t, expected, response.Data)
+
+	// The rates are cached, so the batches aren't queried again
+	code, response = fetch("/v1/operators/" + strings.ToUpper(nonSigningOperatorId[2:]) + "/nonsigning?windows=7d")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expected[1:], response.Data)
+
+	code, _ = fetch("/v1/operators/" + nonSigningOperatorId + "/nonsigning?windows=1h,1y")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetch("/v1/operators/" + nonSigningOperatorId + "/nonsigning?windows=31d")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetch("/v1/operators/0x1234/nonsigning")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetch("/v1/operators/0xe1cdae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568313/nonsigning")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestFetchOperatorEjectionCandidatesHandler(t *testing.T) {
+	// The server has its own mocks, with three operators of stakes 1, 2 and 3 in quorums 0 and 1
+	subgraphApi := &subgraphmock.MockSubgraphApi{}
+	tx := &coremock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(300), nil)
+	tx.On("GetQuorumCount").Return(uint16(2), nil)
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(3))
+	assert.NoError(t, err)
+	server := dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(subgraphApi), tx, chainState, &commock.Logger{}, dataapi.NewMetrics("9001", &commock.Logger{}))
+	r := setUpRouter()
+	r.GET("/v1/operators/ejection_candidates", server.FetchOperatorEjectionCandidatesHandler)
+
+	operatorIds := make([]string, 3)
+	for i := range operatorIds {
+		id := [32]byte{byte('0' + i)}
+		operatorIds[i] = "0x" + hex.EncodeToString(id[:])
+		address := fmt.Sprintf("0x%064x", i)
+		subgraphApi.On("QueryOperatorRegisteredsByOperatorId", operatorIds[i]).Return([]*subgraph.OperatorRegistered{{OperatorId: graphql.String(operatorIds[i]), Operator: graphql.String(address)}}, nil)
+		// The last operator only joins quorum 1 at block 150
+		events := &subgraph.OperatorQuorumEvents{AddedToQuorum: []*subgraph.OperatorQuorum{{Operator: graphql.String(address), QuorumNumbers: "0x0001", BlockNumber: "100"}}}
+		if i == 2 {
+			events.AddedToQuorum = []*subgraph.OperatorQuorum{
+				{Operator: graphql.String(address), QuorumNumbers: "0x00", BlockNumber: "100"},
+				{Operator: graphql.String(address), QuorumNumbers: "0x01", BlockNumber: "150"},
+			}
+		}
+		subgraphApi.On("QueryOperatorQuorumEvents", address).Return(events, nil).Once()
+	}
+	subgraphApi.On("QueryBatchSigningInfoInInterval", int64(24*3600)).Return([]*subgraph.BatchSigningInfo{
+		makeBatchSigningInfo("1", confirmedAgo(time.Hour), "120", operatorIds[0]),
+		makeBatchSigningInfo("2", confirmedAgo(time.Hour), "160", operatorIds[0], operatorIds[1]),
+		makeBatchSigningInfo("3", confirmedAgo(time.Hour), "170"),
+		makeBatchSigningInfo("4", confirmedAgo(time.Hour), "180", operatorIds[0]),
+	}, nil).Once()
+
+	var response dataapi.OperatorEjectionCandidatesResponse
+	code := fetchJSON(t, r, "/v1/operators/ejection_candidates?threshold=50", &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "24h", response.Window)
+	assert.Equal(t, float64(50), response.Threshold)
+	assert.Equal(t, uint32(300), response.BlockNumber)
+	assert.Equal(t, 6, response.Meta.Size)
+	assert.Equal(t, []*dataapi.OperatorEjectionCandidate{
+		{OperatorId: operatorIds[0], QuorumId: 0, StakePercentage: 16.67, TotalBatches: 4, SignedBatches: 1, NonSigningPercentage: 75, AboveThreshold: true},
+		{OperatorId: operatorIds[0], QuorumId: 1, StakePercentage: 16.67, TotalBatches: 4, SignedBatches: 1, NonSigningPercentage: 75, AboveThreshold: true},
+		{OperatorId: operatorIds[1], QuorumId: 0, StakePercentage: 33.33, TotalBatches: 4, SignedBatches: 3, NonSigningPercentage: 25},
+		{OperatorId: operatorIds[1], QuorumId: 1, StakePercentage: 33.33, TotalBatches: 4, SignedBatches: 3, NonSigningPercentage: 25},
+		{OperatorId: operatorIds[2], QuorumId: 0, StakePercentage: 50, TotalBatches: 4, SignedBatches: 4},
+		{OperatorId: operatorIds[2], QuorumId: 1, StakePercentage: 50, TotalBatches: 3, SignedBatches: 3},
+	}, response.Data)
+
+	// The rates are cached, so the CSV with a lower threshold doesn't query the subgraph again
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/operators/ejection_candidates?window=1d&threshold=25&format=csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, strings.Join([]string{
+		"operator_id,quorum_id,stake_percentage,total_batches,signed_batches,nonsigning_percentage,above_threshold",
+		operatorIds[0] + ",0,16.67,4,1,75,true",
+		operatorIds[0] + ",1,16.67,4,1,75,true",
+		operatorIds[1] + ",0,33.33,4,3,25,false",
+		operatorIds[1] + ",1,33.33,4,3,25,false",
+		operatorIds[2] + ",0,50,4,4,0,false",
+		operatorIds[2] + ",1,50,3,3,0,false",
+		"",
+	}, "\n"), w.Body.String())
+	subgraphApi.AssertExpectations(t)
+
+	for _, url := range []string{
+		"/v1/operators/ejection_candidates",
+		"/v1/operators/ejection_candidates?threshold=101",
+		"/v1/operators/ejection_candidates?threshold=50&window=1h,24h",
+		"/v1/operators/ejection_candidates?threshold=50&format=xml",
+	} {
+		code = fetchJSON(t, r, url, &dataapi.ErrorResponse{})
+		assert.Equal(t, http.StatusBadRequest, code, url)
+	}
+}
+
+func TestFetchMetricsThroughputBucketsHandler(t *testing.T) {
+	// The server has its own Prometheus mock, to count the queries
+	promApi := &prommock.MockPrometheusApi{}
+	server := dataapi.NewServer(config, blobstore, dataapi.NewPrometheusClient(promApi, "test-cluster"), subgraphClient, mockTx, mockChainState, &commock.Logger{}, dataapi.NewMetrics("9001", &commock.Logger{}))
+	r := setUpRouter()
+	r.GET("/v1/metrics/throughput/buckets", server.FetchMetricsThroughputBucketsHandler)
+
+	// The batchers confirm 100 bytes and 0.1 blob per second, sampled at the boundaries of the buckets of a minute
+	// and at the edges of the range
+	size := &model.SampleStream{Metric: model.Metric{"data": "size"}}
+	number := &model.SampleStream{Metric: model.Metric{"data": "number"}}
+	for _, t := range []int64{1700000010, 1700000040, 1700000100, 1700000160, 1700000220, 1700000280, 1700000330} {
+		size.Values = append(size.Values, model.SamplePair{Timestamp: model.TimeFromUnix(t), Value: model.SampleValue((t - 1700000000) * 100)})
+		number.Values = append(number.Values, model.SamplePair{Timestamp: model.TimeFromUnix(t), Value: model.SampleValue(float64(t-1700000000) / 10)})
+	}
+	promApi.On("QueryRange").Return(model.Matrix{size, number}, nil, nil)
+
+	fetch := func(url string) (int, dataapi.ThroughputBucketsResponse) {
+		var response dataapi.ThroughputBucketsResponse
+		code := fetchJSON(t, r, url, &response)
+		return code, response
+	}
+
+	code, response := fetch("/v1/metrics/throughput/buckets?start=1700000010&end=1700000330&bucket=60")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, uint64(60), response.BucketSize)
+	assert.Equal(t, 6, response.Meta.Size)
+	assert.Equal(t, &dataapi.ThroughputBucket{Start: 1700000010, End: 1700000040, BytesDispersed: 3000, BlobCount: 3, Throughput: 100, Partial: true}, response.Data[0])
+	assert.Equal(t, &dataapi.ThroughputBucket{Start: 1700000040, End: 1700000100, BytesDispersed: 6000, BlobCount: 6, Throughput: 100}, response.Data[1])
+	assert.Equal(t, &dataapi.ThroughputBucket{Start: 1700000280, End: 1700000330, BytesDispersed: 5000, BlobCount: 5, Throughput: 100, Partial: true}, response.Data[5])
+	// The aligned boundaries are sampled at once, and the edges one by one
+	promApi.AssertNumberOfCalls(t, "QueryRange", 3)
+
+	// The full buckets of the past are cached
+	code, response = fetch("/v1/metrics/throughput/buckets?start=1700000010&end=1700000330&bucket=60&partial=drop")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 4, response.Meta.Size)
+	assert.Equal(t, uint64(1700000040), response.Data[0].Start)
+	assert.Equal(t, uint64(1700000280), response.Data[3].End)
+	promApi.AssertNumberOfCalls(t, "QueryRange", 3)
+
+	// The buckets of long ranges are enlarged
+	code, response = fetch("/v1/metrics/throughput/buckets?start=1697408000&end=1700000000&bucket=60&partial=drop")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, uint64(60*44), response.BucketSize)
+
+	for _, query := range []string{"start=1700000330&end=1700000010", "bucket=0", "bucket=abc", "partial=keep", "start=abc"} {
+		code, _ = fetch("/v1/metrics/throughput/buckets?" + query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
+
+func TestFetchBatchesHandler(t *testing.T) {
+	r := setUpRouter()
+	storeBatchBlobs(t)
+	mockSubgraphApi.On("QueryBatchesInRange").Return(subgraphBatchInfos, nil)
+	r.GET("/v1/feed/batches", testDataApiServer.FetchBatchesHandler)
+
+	code, response := fetchBatches(t, r, "/v1/feed/batches?limit=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 2, NextCursor: "11"}, response.Meta)
+	batchHeaderHash := batchInfoHeaderHash(12)
+	assert.Equal(t, &dataapi.BatchResponse{
+		BatchId:                 12,
+		BatchHeaderHash:         hex.EncodeToString(batchHeaderHash[:]),
+		ReferenceBlockNumber:    112,
+		QuorumNumbers:           []core.QuorumID{0, 1},
+		BlobCount:               3,
+		ConfirmationTxnHash:     "0x0000000000000000000000000000000000000000000000000000000000000b0c",
+		ConfirmationBlockNumber: 212,
+		ConfirmedAt:             1700000200,
+		SignedStake: []*dataapi.QuorumSignedStake{
+			{QuorumId: 0, SignedPercentage: 80, NonSigners: []*dataapi.QuorumNonSigner{{OperatorId: "0xab00000000000000000000000000000000000000000000000000000000000000", Reason: "timeout"}}},
+			{QuorumId: 1, SignedPercentage: 70, NonSigners: []*dataapi.QuorumNonSigner{}},
+		},
+	}, response.Data[0])
+	assert.Equal(t, uint64(11), response.Data[1].BatchId)
+	assert.Equal(t, 1, response.Data[1].BlobCount)
+
+	// The blobs of the last batch are no longer in the store
+	code, response = fetchBatches(t, r, "/v1/feed/batches?limit=2&cursor=11")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 1}, response.Meta)
+	assert.Equal(t, uint64(10), response.Data[0].BatchId)
+	assert.Equal(t, 0, response.Data[0].BlobCount)
+	assert.Empty(t, response.Data[0].SignedStake)
+
+	code, response = fetchBatches(t, r, "/v1/feed/batches?start=1700000050&end=1700000150")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint64(11), response.Data[0].BatchId)
+
+	for _, query := range []string{"limit=0", "limit=101", "cursor=abc", "start=1700000150&end=1700000050", "end=-1"} {
+		code, _ = fetchBatches(t, r, "/v1/feed/batches?"+query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
+
+func TestFetchBatchBlobsHandler(t *testing.T) {
+	r := setUpRouter()
+	keys := storeBatchBlobs(t)
+	r.GET("/v1/feed/batches/:batch_header_hash/blobs", testDataApiServer.FetchBatchBlobsHandler)
+	batchHeaderHash := batchInfoHeaderHash(12)
+	path := "/v1/feed/batches/0x" + hex.EncodeToString(batchHeaderHash[:]) + "/blobs"
+
+	code, response := fetchPaginatedBlobs(t, r, path+"?limit=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 2, NextCursor: "12-1"}, response.Meta)
+	assert.Equal(t, []string{keys[0], keys[1]}, blobKeys(response.Data))
+	// The blobs can be retrieved with their batch header hash, blob index and quorums
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.Data[1].BatchHeaderHash)
+	assert.Equal(t, uint32(1), response.Data[1].BlobIndex)
+	assert.Equal(t, core.QuorumID(1), response.Data[1].SecurityParams[0].QuorumID)
+
+	code, response = fetchPaginatedBlobs(t, r, path+"?limit=2&cursor=12-1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 1}, response.Meta)
+	assert.Equal(t, []string{keys[2]}, blobKeys(response.Data))
+
+	code, response = fetchPaginatedBlobs(t, r, path+"?status=Finalized")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{keys[2]}, blobKeys(response.Data))
+
+	for _, query := range []string{"status=unknown", "cursor=11-0", "cursor=12", "limit=abc"} {
+		code, _ = fetchPaginatedBlobs(t, r, path+"?"+query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+	code, _ = fetchPaginatedBlobs(t, r, "/v1/feed/batches/0x1234/blobs")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetchPaginatedBlobs(t, r, "/v1/feed/batches/0x"+strings.Repeat("ff", 32)+"/blobs")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestFetchAccountBlobsHandler(t *testing.T) {
+	r := setUpRouter()
+	keys := storeBatchBlobs(t)
+	mockSubgraphApi.On("QueryBatchesInRange").Return(subgraphBatchInfos, nil)
+	r.GET("/v1/feed/accounts/:account_id/blobs", testDataApiServer.FetchAccountBlobsHandler)
+
+	code, response := fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?limit=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 2, NextCursor: "12-2"}, response.Meta)
+	assert.Equal(t, []string{keys[0], keys[2]}, blobKeys(response.Data))
+
+	code, response = fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?limit=2&cursor=12-2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 1}, response.Meta)
+	assert.Equal(t, []string{keys[3]}, blobKeys(response.Data))
+
+	code, response = fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?status=confirmed")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{keys[0], keys[3]}, blobKeys(response.Data))
+
+	code, response = fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?end=1700000150")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{keys[3]}, blobKeys(response.Data))
+
+	code, response = fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-c/blobs")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Data)
+
+	code, _ = fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?cursor=abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestFetchAccountBlobsHandlerScanLimit(t *testing.T) {
+	r := setUpRouter()
+	store := inmem.NewBlobStore()
+	subgraphApi := &subgraphmock.MockSubgraphApi{}
+	server := dataapi.NewServer(config, store, prometheusClient, dataapi.NewSubgraphClient(subgraphApi), mockTx, mockChainState, &commock.Logger{}, dataapi.NewMetrics("9001", &commock.Logger{}))
+	r.GET("/v1/feed/accounts/:account_id/blobs", server.FetchAccountBlobsHandler)
+
+	// The only blob of the account is in the batch 5, right past the 200 batches scanned for a page
+	batches := make([]*subgraph.BatchInfo, 0, 205)
+	for id := 1; id <= 205; id++ {
+		batches = append(batches, makeBatchInfo(strconv.Itoa(id), "1700000000"))
+	}
+	subgraphApi.On("QueryBatchesInRange").Return(batches, nil)
+	key := storeBatchBlob(t, store, 5, 0, "account-a", disperser.Confirmed)
+
+	// The first page stops at the scan limit, and resumes right after the last batch it scanned
+	code, response := fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?limit=10")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{NextCursor: "6-4294967295"}, response.Meta)
+	assert.Empty(t, response.Data)
+
+	code, response = fetchPaginatedBlobs(t, r, "/v1/feed/accounts/account-a/blobs?limit=10&cursor=6-4294967295")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.PaginationMeta{Size: 1}, response.Meta)
+	assert.Equal(t, []string{key}, blobKeys(response.Data))
+}
+
+func fetchBatches(t *testing.T, r *gin.Engine, url string) (int, dataapi.BatchesResponse) {
+	var response dataapi.BatchesResponse
+	code := fetchJSON(t, r, url, &response)
+	return code, response
+}
+
+func fetchPaginatedBlobs(t *testing.T, r *gin.Engine, url string) (int, dataapi.PaginatedBlobsResponse) {
+	var response dataapi.PaginatedBlobsResponse
+	code := fetchJSON(t, r, url, &response)
+	return code, response
+}
+
+func fetchJSON(t *testing.T, r *gin.Engine, url string, response any) int {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	r.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, response))
+	return res.StatusCode
+}
+
+func blobKeys(metadatas []*dataapi.BlobMetadataResponse) []string {
+	keys := make([]string, len(metadatas))
+	for i, metadata := range metadatas {
+		keys[i] = metadata.BlobKey
+	}
+	return keys
+}
+
+// storeBatchBlobs stores the blobs of the batches 11 and 12 of subgraphBatchInfos, once, and returns their keys by
+// batch and blob index
+func storeBatchBlobs(t *testing.T) []string {
+	batchBlobsOnce.Do(func() {
+		for _, blob := range []struct {
+			batchId   uint64
+			blobIndex uint32
+			account   core.AccountID
+			status    disperser.BlobStatus
+		}{
+			{12, 0, "account-a", disperser.Confirmed},
+			{12, 1, "account-b", disperser.Confirmed},
+			{12, 2, "account-a", disperser.Finalized},
+			{11, 0, "account-a", disperser.Confirmed},
+		} {
+			batchBlobKeys = append(batchBlobKeys, storeBatchBlob(t, blobstore, blob.batchId, blob.blobIndex, blob.account, blob.status))
+		}
+	})
+	return batchBlobKeys
+}
+
+func storeBatchBlob(t *testing.T, blobstore disperser.BlobStore, batchId uint64, blobIndex uint32, account core.AccountID, status disperser.BlobStatus) string {
+	blob := makeTestBlob(core.QuorumID(blobIndex%2), 80)
+	blob.RequestHeader.AccountID = account
+	key := queueBlob(t, &blob, blobstore)
+	metadata, err := blobstore.GetBlobMetadata(context.Background(), key)
+	assert.NoError(t, err)
+
+	batchHeaderHash := batchInfoHeaderHash(batchId)
+	blobCount := uint32(1)
+	if batchId == 12 {
+		blobCount = 3
+	}
+	_, err = blobstore.MarkBlobConfirmed(context.Background(), metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            blobIndex,
+		BlobCount:            blobCount,
+		ReferenceBlockNumber: uint32(100 + batchId),
+		BatchID:              uint32(batchId),
+		QuorumResults: map[core.QuorumID]*core.QuorumResult{
+			0: {QuorumID: 0, PercentSigned: 80, NonSigners: []*core.NonSigner{{OperatorID: core.OperatorID{0xab}, Reason: core.NonSignerTimeout}}},
+			1: {QuorumID: 1, PercentSigned: 70},
+		},
+	})
+	assert.NoError(t, err)
+	if status == disperser.Finalized {
+		assert.NoError(t, blobstore.MarkBlobFinalized(context.Background(), key))
+	}
+	return key.String()
+}
+
 func setUpRouter() *gin.Engine {
 	return gin.Default()
 }
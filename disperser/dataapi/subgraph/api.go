@@ -17,8 +17,13 @@ var (
 type (
 	Api interface {
 		QueryBatches(ctx context.Context, descending bool, orderByField string, first, skip int) ([]*Batches, error)
+		QueryBatchesInRange(ctx context.Context, beforeBatchId uint64, startSeconds, endSeconds int64, first int) ([]*BatchInfo, error)
 		QueryOperators(ctx context.Context, first int) ([]*OperatorRegistered, error)
 		QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) ([]*BatchNonSigningOperatorIds, error)
+		QueryOperatorRegisteredsByOperatorId(ctx context.Context, operatorId string) ([]*OperatorRegistered, error)
+		QueryOperatorQuorumEvents(ctx context.Context, operator string) (*OperatorQuorumEvents, error)
+		QueryBatchSigningInfoInInterval(ctx context.Context, intervalSeconds int64) ([]*BatchSigningInfo, error)
+		QueryBatchAttestationsInRange(ctx context.Context, startSeconds, endSeconds int64) ([]*BatchAttestation, error)
 	}
 
 	api struct {
@@ -61,6 +66,24 @@ func (a *api) QueryBatches(ctx context.Context, descending bool, orderByField st
 	return result.Batches, nil
 }
 
+// QueryBatchesInRange returns the first batches confirmed between the unix timestamps, in seconds and inclusive, whose
+// ID is below beforeBatchId, by descending ID
+func (a *api) QueryBatchesInRange(ctx context.Context, beforeBatchId uint64, startSeconds, endSeconds int64, first int) ([]*BatchInfo, error) {
+	variables := map[string]any{
+		"first":              graphql.Int(first),
+		"batchId_lt":         graphql.Int(beforeBatchId),
+		"blockTimestamp_gte": graphql.Int(startSeconds),
+		"blockTimestamp_lte": graphql.Int(endSeconds),
+	}
+	result := new(queryBatchesInRange)
+	err := a.uiMonitoringGgl.Query(ctx, result, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Batches, nil
+}
+
 func (a *api) QueryOperators(ctx context.Context, first int) ([]*OperatorRegistered, error) {
 	variables := map[string]any{
 		"first": graphql.Int(first),
@@ -103,3 +126,92 @@ func (a *api) QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, int
 	result.BatchNonSigningOperatorIds = batchNonSigningOperatorIds
 	return result.BatchNonSigningOperatorIds, nil
 }
+
+func (a *api) QueryOperatorRegisteredsByOperatorId(ctx context.Context, operatorId string) ([]*OperatorRegistered, error) {
+	variables := map[string]any{
+		"first":      graphql.Int(MAX_ENTITIES_PER_QUERY),
+		"operatorId": Bytes(operatorId),
+	}
+	result := new(queryOperatorRegisteredsByOperatorId)
+	err := a.operatorStateGql.Query(ctx, result, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.OperatorRegistereds, nil
+}
+
+// QueryOperatorQuorumEvents returns the additions and removals of the operator to and from quorums, ordered by block
+func (a *api) QueryOperatorQuorumEvents(ctx context.Context, operator string) (*OperatorQuorumEvents, error) {
+	variables := map[string]any{
+		"first":    graphql.Int(MAX_ENTITIES_PER_QUERY),
+		"operator": Bytes(operator),
+	}
+	result := new(OperatorQuorumEvents)
+	err := a.operatorStateGql.Query(ctx, result, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *api) QueryBatchSigningInfoInInterval(ctx context.Context, intervalSeconds int64) ([]*BatchSigningInfo, error) {
+	signingAfter := time.Now().Add(-time.Duration(intervalSeconds) * time.Second).Unix()
+	variables := map[string]any{
+		"blockTimestamp_gt": graphql.Int(signingAfter),
+	}
+	skip := 0
+
+	batchSigningInfo := make([]*BatchSigningInfo, 0)
+	for {
+		variables["first"] = graphql.Int(MAX_ENTITIES_PER_QUERY)
+		variables["skip"] = graphql.Int(skip)
+
+		result := new(queryBatchSigningInfoInInterval)
+		err := a.uiMonitoringGgl.Query(ctx, result, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result.BatchSigningInfo) == 0 {
+			break
+		}
+		batchSigningInfo = append(batchSigningInfo, result.BatchSigningInfo...)
+
+		skip += MAX_ENTITIES_PER_QUERY
+	}
+
+	return batchSigningInfo, nil
+}
+
+// QueryBatchAttestationsInRange returns the batches confirmed between the unix timestamps, in seconds and inclusive,
+// with their non signers, by ascending ID
+func (a *api) QueryBatchAttestationsInRange(ctx context.Context, startSeconds, endSeconds int64) ([]*BatchAttestation, error) {
+	variables := map[string]any{
+		"blockTimestamp_gte": graphql.Int(startSeconds),
+		"blockTimestamp_lte": graphql.Int(endSeconds),
+	}
+	skip := 0
+
+	attestations := make([]*BatchAttestation, 0)
+	for {
+		variables["first"] = graphql.Int(MAX_ENTITIES_PER_QUERY)
+		variables["skip"] = graphql.Int(skip)
+
+		result := new(queryBatchAttestationsInRange)
+		err := a.uiMonitoringGgl.Query(ctx, result, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result.BatchAttestations) == 0 {
+			break
+		}
+		attestations = append(attestations, result.BatchAttestations...)
+
+		skip += MAX_ENTITIES_PER_QUERY
+	}
+
+	return attestations, nil
+}
@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"context"
 	"slices"
+	"strconv"
 
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
 	"github.com/stretchr/testify/mock"
@@ -41,6 +42,38 @@ func (m *MockSubgraphApi) QueryBatches(ctx context.Context, descending bool, ord
 	return value, args.Error(1)
 }
 
+// QueryBatchesInRange filters and orders the batches it is set up to return, as the subgraph does
+func (m *MockSubgraphApi) QueryBatchesInRange(ctx context.Context, beforeBatchId uint64, startSeconds, endSeconds int64, first int) ([]*subgraph.BatchInfo, error) {
+	args := m.Called()
+
+	var value []*subgraph.BatchInfo
+	if args.Get(0) != nil {
+		for _, batch := range args.Get(0).([]*subgraph.BatchInfo) {
+			batchId, err := strconv.ParseUint(string(batch.BatchId), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			timestamp, err := strconv.ParseInt(string(batch.BlockTimestamp), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if batchId < beforeBatchId && timestamp >= startSeconds && timestamp <= endSeconds {
+				value = append(value, batch)
+			}
+		}
+		slices.SortStableFunc(value, func(a, b *subgraph.BatchInfo) int {
+			idA, _ := strconv.ParseUint(string(a.BatchId), 10, 64)
+			idB, _ := strconv.ParseUint(string(b.BatchId), 10, 64)
+			return cmp.Compare(idB, idA)
+		})
+		if first > 0 && len(value) > first {
+			value = value[:first]
+		}
+	}
+
+	return value, args.Error(1)
+}
+
 func (m *MockSubgraphApi) QueryOperators(ctx context.Context, first int) ([]*subgraph.OperatorRegistered, error) {
 	args := m.Called()
 
@@ -70,3 +103,61 @@ func (m *MockSubgraphApi) QueryBatchNonSigningOperatorIdsInInterval(ctx context.
 
 	return value, args.Error(1)
 }
+
+func (m *MockSubgraphApi) QueryOperatorRegisteredsByOperatorId(ctx context.Context, operatorId string) ([]*subgraph.OperatorRegistered, error) {
+	args := m.Called(operatorId)
+
+	var value []*subgraph.OperatorRegistered
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.OperatorRegistered)
+	}
+
+	return value, args.Error(1)
+}
+
+func (m *MockSubgraphApi) QueryOperatorQuorumEvents(ctx context.Context, operator string) (*subgraph.OperatorQuorumEvents, error) {
+	args := m.Called(operator)
+
+	var value *subgraph.OperatorQuorumEvents
+	if args.Get(0) != nil {
+		value = args.Get(0).(*subgraph.OperatorQuorumEvents)
+	}
+
+	return value, args.Error(1)
+}
+
+func (m *MockSubgraphApi) QueryBatchSigningInfoInInterval(ctx context.Context, intervalSeconds int64) ([]*subgraph.BatchSigningInfo, error) {
+	args := m.Called(intervalSeconds)
+
+	var value []*subgraph.BatchSigningInfo
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.BatchSigningInfo)
+	}
+
+	return value, args.Error(1)
+}
+
+// QueryBatchAttestationsInRange filters and orders the batches it is set up to return, as the subgraph does
+func (m *MockSubgraphApi) QueryBatchAttestationsInRange(ctx context.Context, startSeconds, endSeconds int64) ([]*subgraph.BatchAttestation, error) {
+	args := m.Called()
+
+	var value []*subgraph.BatchAttestation
+	if args.Get(0) != nil {
+		for _, batch := range args.Get(0).([]*subgraph.BatchAttestation) {
+			timestamp, err := strconv.ParseInt(string(batch.BlockTimestamp), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if timestamp >= startSeconds && timestamp <= endSeconds {
+				value = append(value, batch)
+			}
+		}
+		slices.SortStableFunc(value, func(a, b *subgraph.BatchAttestation) int {
+			idA, _ := strconv.ParseUint(string(a.BatchId), 10, 64)
+			idB, _ := strconv.ParseUint(string(b.BatchId), 10, 64)
+			return cmp.Compare(idA, idB)
+		})
+	}
+
+	return value, args.Error(1)
+}
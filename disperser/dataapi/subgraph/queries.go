@@ -33,13 +33,77 @@ type (
 			} `graphql:"nonSigners"`
 		} `graphql:"nonSigning"`
 	}
+	BatchSigningInfo struct {
+		BatchId        graphql.String
+		BlockTimestamp graphql.String
+		BatchHeader    struct {
+			QuorumNumbers        []graphql.String `graphql:"quorumNumbers"`
+			ReferenceBlockNumber graphql.String   `graphql:"referenceBlockNumber"`
+		} `graphql:"batchHeader"`
+		NonSigning struct {
+			NonSigners []struct {
+				OperatorId graphql.String `graphql:"operatorId"`
+			} `graphql:"nonSigners"`
+		} `graphql:"nonSigning"`
+	}
+	BatchInfo struct {
+		BatchId         graphql.String
+		BatchHeaderHash graphql.String
+		BlockTimestamp  graphql.String
+		BlockNumber     graphql.String
+		TxHash          graphql.String
+		BatchHeader     struct {
+			QuorumNumbers        []graphql.String `graphql:"quorumNumbers"`
+			ReferenceBlockNumber graphql.String   `graphql:"referenceBlockNumber"`
+		} `graphql:"batchHeader"`
+	}
+	// BatchAttestation is a confirmed batch along with the operators that didn't sign it
+	BatchAttestation struct {
+		BatchId         graphql.String
+		BatchHeaderHash graphql.String
+		BlockTimestamp  graphql.String
+		BlockNumber     graphql.String
+		TxHash          graphql.String
+		BatchHeader     struct {
+			QuorumNumbers        []graphql.String `graphql:"quorumNumbers"`
+			ReferenceBlockNumber graphql.String   `graphql:"referenceBlockNumber"`
+		} `graphql:"batchHeader"`
+		NonSigning struct {
+			NonSigners []struct {
+				OperatorId graphql.String `graphql:"operatorId"`
+			} `graphql:"nonSigners"`
+		} `graphql:"nonSigning"`
+	}
+	OperatorQuorum struct {
+		Operator      graphql.String
+		QuorumNumbers graphql.String
+		BlockNumber   graphql.String
+	}
+	OperatorQuorumEvents struct {
+		AddedToQuorum     []*OperatorQuorum `graphql:"operatorAddedToQuorums(first: $first, orderBy: blockNumber, where: {operator: $operator})"`
+		RemovedFromQuorum []*OperatorQuorum `graphql:"operatorRemovedFromQuorums(first: $first, orderBy: blockNumber, where: {operator: $operator})"`
+	}
+	// Bytes is the type of the variables compared to Bytes fields of the subgraphs
+	Bytes        string
 	queryBatches struct {
 		Batches []*Batches `graphql:"batches(orderDirection: $orderDirection, orderBy: $orderBy, first: $first, skip: $skip)"`
 	}
+	queryBatchesInRange struct {
+		Batches []*BatchInfo `graphql:"batches(first: $first, orderBy: batchId, orderDirection: desc, where: {batchId_lt: $batchId_lt, blockTimestamp_gte: $blockTimestamp_gte, blockTimestamp_lte: $blockTimestamp_lte})"`
+	}
+	queryBatchAttestationsInRange struct {
+		BatchAttestations []*BatchAttestation `graphql:"batches(first: $first, skip: $skip, orderBy: batchId, where: {blockTimestamp_gte: $blockTimestamp_gte, blockTimestamp_lte: $blockTimestamp_lte})"`
+	}
 	queryOperatorRegistereds struct {
 		OperatorRegistereds []*OperatorRegistered `graphql:"operatorRegistereds(first: $first)"`
 	}
+	queryOperatorRegisteredsByOperatorId struct {
+		OperatorRegistereds []*OperatorRegistered `graphql:"operatorRegistereds(first: $first, where: {operatorId: $operatorId})"`
+	}
 	queryBatchNonSigningOperatorIdsInInterval struct {
 		BatchNonSigningOperatorIds []*BatchNonSigningOperatorIds `graphql:"batches(first: $first, skip: $skip, where: {blockTimestamp_gt: $blockTimestamp_gt})"`
 	}
+	queryBatchSigningInfoInInterval struct {
+		BatchSigningInfo []*BatchSigningInfo `graphql:"batches(first: $first, skip: $skip, orderBy: blockTimestamp, where: {blockTimestamp_gt: $blockTimestamp_gt})"`
+	}
 )
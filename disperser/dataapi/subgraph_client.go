@@ -2,16 +2,26 @@ package dataapi
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/shurcooL/graphql"
 )
 
 type (
 	SubgraphClient interface {
 		QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*Batch, error)
+		QueryBatchesInRange(ctx context.Context, beforeBatchId uint64, startSeconds, endSeconds int64, limit int) ([]*BatchInfo, error)
 		QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error)
 		QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) (map[string]int, error)
+		QueryBatchSigningInfoInInterval(ctx context.Context, intervalSeconds int64) ([]*BatchSigningInfo, error)
+		QueryOperatorQuorumEvents(ctx context.Context, operatorId string) ([]*OperatorQuorumEvent, error)
+		QueryBatchAttestationsInRange(ctx context.Context, startSeconds, endSeconds int64) ([]*BatchAttestation, error)
 	}
 	Batch struct {
 		Id              []byte
@@ -22,6 +32,17 @@ type (
 		TxHash          []byte
 		GasFees         *GasFees
 	}
+	// BatchInfo is a confirmed batch, with the header the retrievals of its blobs need
+	BatchInfo struct {
+		BatchId              uint64
+		BatchHeaderHash      [32]byte
+		ReferenceBlockNumber uint64
+		QuorumNumbers        []core.QuorumID
+		// BlockTimestamp is the unix timestamp, in seconds, of the block of the confirmation of the batch
+		BlockTimestamp uint64
+		BlockNumber    uint64
+		TxHash         string
+	}
 	GasFees struct {
 		Id       []byte
 		GasUsed  uint64
@@ -36,6 +57,29 @@ type (
 		BlockNumber     uint64
 		TransactionHash []byte
 	}
+	BatchSigningInfo struct {
+		BatchId              uint64
+		ReferenceBlockNumber uint64
+		QuorumNumbers        []core.QuorumID
+		// NonSigners are the IDs of the operators that didn't sign the batch, as lower case hex strings prefixed
+		// with 0x
+		NonSigners map[string]struct{}
+		// BlockTimestamp is the unix timestamp, in seconds, of the block of the confirmation of the batch
+		BlockTimestamp uint64
+	}
+	// BatchAttestation is a confirmed batch along with the operators that didn't sign it
+	BatchAttestation struct {
+		BatchInfo
+		// NonSigners are the IDs of the operators that didn't sign the batch, as lower case hex strings prefixed
+		// with 0x
+		NonSigners map[string]struct{}
+	}
+	// OperatorQuorumEvent is the addition of an operator to quorums, or its removal from them
+	OperatorQuorumEvent struct {
+		BlockNumber   uint64
+		QuorumNumbers []core.QuorumID
+		Added         bool
+	}
 	subgraphClient struct {
 		api subgraph.Api
 	}
@@ -63,6 +107,24 @@ func (sc *subgraphClient) QueryBatchesWithLimit(ctx context.Context, limit, skip
 	return batches, nil
 }
 
+// QueryBatchesInRange returns up to limit batches confirmed between the unix timestamps, in seconds and inclusive,
+// whose ID is below beforeBatchId, by descending ID
+func (sc *subgraphClient) QueryBatchesInRange(ctx context.Context, beforeBatchId uint64, startSeconds, endSeconds int64, limit int) ([]*BatchInfo, error) {
+	batchesGql, err := sc.api.QueryBatchesInRange(ctx, beforeBatchId, startSeconds, endSeconds, limit)
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]*BatchInfo, len(batchesGql))
+	for i, batchGql := range batchesGql {
+		batch, err := convertBatchInfo(batchGql)
+		if err != nil {
+			return nil, err
+		}
+		batches[i] = batch
+	}
+	return batches, nil
+}
+
 func (sc *subgraphClient) QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error) {
 	operatorsGql, err := sc.api.QueryOperators(ctx, limit)
 	if err != nil {
@@ -93,6 +155,76 @@ func (sc *subgraphClient) QueryBatchNonSigningOperatorIdsInInterval(ctx context.
 	return batchNonSigningOperatorIds, nil
 }
 
+func (sc *subgraphClient) QueryBatchSigningInfoInInterval(ctx context.Context, intervalSeconds int64) ([]*BatchSigningInfo, error) {
+	batchesGql, err := sc.api.QueryBatchSigningInfoInInterval(ctx, intervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]*BatchSigningInfo, len(batchesGql))
+	for i, batchGql := range batchesGql {
+		batch, err := convertBatchSigningInfo(batchGql)
+		if err != nil {
+			return nil, err
+		}
+		batches[i] = batch
+	}
+	return batches, nil
+}
+
+// QueryBatchAttestationsInRange returns the batches confirmed between the unix timestamps, in seconds and inclusive,
+// with their non signers, by ascending ID
+func (sc *subgraphClient) QueryBatchAttestationsInRange(ctx context.Context, startSeconds, endSeconds int64) ([]*BatchAttestation, error) {
+	attestationsGql, err := sc.api.QueryBatchAttestationsInRange(ctx, startSeconds, endSeconds)
+	if err != nil {
+		return nil, err
+	}
+	attestations := make([]*BatchAttestation, len(attestationsGql))
+	for i, attestationGql := range attestationsGql {
+		attestation, err := convertBatchAttestation(attestationGql)
+		if err != nil {
+			return nil, err
+		}
+		attestations[i] = attestation
+	}
+	return attestations, nil
+}
+
+// QueryOperatorQuorumEvents returns the additions and removals of the operator to and from quorums, ordered by block.
+// It returns errNotFound if the operator never registered.
+func (sc *subgraphClient) QueryOperatorQuorumEvents(ctx context.Context, operatorId string) ([]*OperatorQuorumEvent, error) {
+	registereds, err := sc.api.QueryOperatorRegisteredsByOperatorId(ctx, operatorId)
+	if err != nil {
+		return nil, err
+	}
+	if len(registereds) == 0 {
+		return nil, fmt.Errorf("operator %s: %w", operatorId, errNotFound)
+	}
+	eventsGql, err := sc.api.QueryOperatorQuorumEvents(ctx, string(registereds[0].Operator))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*OperatorQuorumEvent, 0, len(eventsGql.AddedToQuorum)+len(eventsGql.RemovedFromQuorum))
+	for _, eventGql := range eventsGql.AddedToQuorum {
+		event, err := convertOperatorQuorumEvent(eventGql, true)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	for _, eventGql := range eventsGql.RemovedFromQuorum {
+		event, err := convertOperatorQuorumEvent(eventGql, false)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].BlockNumber < events[j].BlockNumber
+	})
+	return events, nil
+}
+
 func convertBatches(subgraphBatches []*subgraph.Batches) ([]*Batch, error) {
 	batches := make([]*Batch, len(subgraphBatches))
 	for i, batch := range subgraphBatches {
@@ -126,6 +258,54 @@ func convertBatches(subgraphBatches []*subgraph.Batches) ([]*Batch, error) {
 	return batches, nil
 }
 
+func convertBatchInfo(batch *subgraph.BatchInfo) (*BatchInfo, error) {
+	batchId, err := strconv.ParseUint(string(batch.BatchId), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(batch.BatchHeaderHash))
+	if err != nil {
+		return nil, err
+	}
+	referenceBlockNumber, err := strconv.ParseUint(string(batch.BatchHeader.ReferenceBlockNumber), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	quorumNumbers, err := convertQuorumNumbers(batch.BatchHeader.QuorumNumbers)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := strconv.ParseUint(string(batch.BlockTimestamp), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	blockNum, err := strconv.ParseUint(string(batch.BlockNumber), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchInfo{
+		BatchId:              batchId,
+		BatchHeaderHash:      batchHeaderHash,
+		ReferenceBlockNumber: referenceBlockNumber,
+		QuorumNumbers:        quorumNumbers,
+		BlockTimestamp:       timestamp,
+		BlockNumber:          blockNum,
+		TxHash:               string(batch.TxHash),
+	}, nil
+}
+
+func convertQuorumNumbers(quorumNumbersGql []graphql.String) ([]core.QuorumID, error) {
+	quorumNumbers := make([]core.QuorumID, len(quorumNumbersGql))
+	for i, quorumNumber := range quorumNumbersGql {
+		quorum, err := strconv.ParseUint(string(quorumNumber), 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		quorumNumbers[i] = core.QuorumID(quorum)
+	}
+	return quorumNumbers, nil
+}
+
 func convertGasFees(gasFees subgraph.GasFees) (*GasFees, error) {
 	gasUsed, err := strconv.ParseUint(string(gasFees.GasUsed), 10, 64)
 	if err != nil {
@@ -165,3 +345,76 @@ func convertOperator(operator *subgraph.OperatorRegistered) (*Operator, error) {
 		TransactionHash: []byte(operator.TransactionHash),
 	}, nil
 }
+
+func convertBatchSigningInfo(batch *subgraph.BatchSigningInfo) (*BatchSigningInfo, error) {
+	batchId, err := strconv.ParseUint(string(batch.BatchId), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	referenceBlockNumber, err := strconv.ParseUint(string(batch.BatchHeader.ReferenceBlockNumber), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	quorumNumbers, err := convertQuorumNumbers(batch.BatchHeader.QuorumNumbers)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := strconv.ParseUint(string(batch.BlockTimestamp), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	nonSigners := make(map[string]struct{}, len(batch.NonSigning.NonSigners))
+	for _, nonSigner := range batch.NonSigning.NonSigners {
+		nonSigners[strings.ToLower(string(nonSigner.OperatorId))] = struct{}{}
+	}
+	return &BatchSigningInfo{
+		BatchId:              batchId,
+		ReferenceBlockNumber: referenceBlockNumber,
+		QuorumNumbers:        quorumNumbers,
+		NonSigners:           nonSigners,
+		BlockTimestamp:       timestamp,
+	}, nil
+}
+
+func convertBatchAttestation(attestation *subgraph.BatchAttestation) (*BatchAttestation, error) {
+	batch, err := convertBatchInfo(&subgraph.BatchInfo{
+		BatchId:         attestation.BatchId,
+		BatchHeaderHash: attestation.BatchHeaderHash,
+		BlockTimestamp:  attestation.BlockTimestamp,
+		BlockNumber:     attestation.BlockNumber,
+		TxHash:          attestation.TxHash,
+		BatchHeader:     attestation.BatchHeader,
+	})
+	if err != nil {
+		return nil, err
+	}
+	nonSigners := make(map[string]struct{}, len(attestation.NonSigning.NonSigners))
+	for _, nonSigner := range attestation.NonSigning.NonSigners {
+		nonSigners[strings.ToLower(string(nonSigner.OperatorId))] = struct{}{}
+	}
+	return &BatchAttestation{
+		BatchInfo:  *batch,
+		NonSigners: nonSigners,
+	}, nil
+}
+
+func convertOperatorQuorumEvent(event *subgraph.OperatorQuorum, added bool) (*OperatorQuorumEvent, error) {
+	blockNum, err := strconv.ParseUint(string(event.BlockNumber), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	// The quorum numbers are the bytes of the event, one quorum per byte
+	quorumNumbers, err := hex.DecodeString(strings.TrimPrefix(string(event.QuorumNumbers), "0x"))
+	if err != nil {
+		return nil, err
+	}
+	quorums := make([]core.QuorumID, len(quorumNumbers))
+	for i, quorum := range quorumNumbers {
+		quorums[i] = core.QuorumID(quorum)
+	}
+	return &OperatorQuorumEvent{
+		BlockNumber:   blockNum,
+		QuorumNumbers: quorums,
+		Added:         added,
+	}, nil
+}
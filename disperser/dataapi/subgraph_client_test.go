@@ -2,12 +2,19 @@ package dataapi_test
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
 	subgraphmock "github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph/mock"
+	"github.com/shurcooL/graphql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -30,6 +37,34 @@ var (
 		},
 	}
 
+	nonSigningOperatorId      = "0xe1cdae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568312"
+	nonSigningOperatorAddress = "0x000563fb86a79eda47c891d8826474d80b6a935ad2a2b5de921933e05c67f320f213"
+
+	// The operator registers in quorum 0 at block 100, joins quorum 1 at block 150 and leaves quorum 0 at block 200
+	subgraphOperatorQuorumEvents = &subgraph.OperatorQuorumEvents{
+		AddedToQuorum: []*subgraph.OperatorQuorum{
+			{Operator: graphql.String(nonSigningOperatorAddress), QuorumNumbers: "0x00", BlockNumber: "100"},
+			{Operator: graphql.String(nonSigningOperatorAddress), QuorumNumbers: "0x01", BlockNumber: "150"},
+		},
+		RemovedFromQuorum: []*subgraph.OperatorQuorum{
+			{Operator: graphql.String(nonSigningOperatorAddress), QuorumNumbers: "0x00", BlockNumber: "200"},
+		},
+	}
+
+	// The first two batches were confirmed days ago, and the others in the last hour
+	subgraphBatchSigningInfo = []*subgraph.BatchSigningInfo{
+		makeBatchSigningInfo("1", confirmedAgo(72*time.Hour), "90"),
+		makeBatchSigningInfo("2", confirmedAgo(48*time.Hour), "120"),
+		makeBatchSigningInfo("3", confirmedAgo(30*time.Minute), "160", nonSigningOperatorId),
+		makeBatchSigningInfo("4", confirmedAgo(20*time.Minute), "210"),
+		makeBatchSigningInfo("5", confirmedAgo(10*time.Minute), "220", "0xe1cdae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568311"),
+	}
+
+	subgraphBatchInfos = []*subgraph.BatchInfo{
+		makeBatchInfo("10", "1700000000"),
+		makeBatchInfo("11", "1700000100"),
+		makeBatchInfo("12", "1700000200"),
+	}
 	subgraphBatches = []*subgraph.Batches{
 		{
 			Id:              "0x000763fb86a79eda47c891d8826474d80b6a935ad2a2b5de921933e05c67f320f207",
@@ -102,6 +137,26 @@ func TestQueryBatchesWithLimit(t *testing.T) {
 	assertGasFees(t, batches[1].GasFees)
 }
 
+func TestQueryBatchesInRange(t *testing.T) {
+	mockSubgraphApi := &subgraphmock.MockSubgraphApi{}
+	mockSubgraphApi.On("QueryBatchesInRange").Return(subgraphBatchInfos, nil)
+	subgraphClient := dataapi.NewSubgraphClient(mockSubgraphApi)
+	batches, err := subgraphClient.QueryBatchesInRange(context.Background(), 12, 0, 1700000200, 10)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, len(batches))
+	assert.Equal(t, &dataapi.BatchInfo{
+		BatchId:              11,
+		BatchHeaderHash:      batchInfoHeaderHash(11),
+		ReferenceBlockNumber: 111,
+		QuorumNumbers:        []core.QuorumID{0, 1},
+		BlockTimestamp:       1700000100,
+		BlockNumber:          211,
+		TxHash:               "0x0000000000000000000000000000000000000000000000000000000000000b0b",
+	}, batches[0])
+	assert.Equal(t, uint64(10), batches[1].BatchId)
+}
+
 func TestQueryOperators(t *testing.T) {
 	mockSubgraphApi := &subgraphmock.MockSubgraphApi{}
 	mockSubgraphApi.On("QueryOperators").Return(subgraphOperatorRegistereds, nil)
@@ -128,6 +183,115 @@ func TestQueryOperators(t *testing.T) {
 	assert.Equal(t, []byte("0x000163fb86a79eda47c891d8826474d80b6a935ad2a2b5de921933e05c67f320f212"), operators[1].TransactionHash)
 }
 
+func TestQueryBatchSigningInfoInInterval(t *testing.T) {
+	mockSubgraphApi := &subgraphmock.MockSubgraphApi{}
+	mockSubgraphApi.On("QueryBatchSigningInfoInInterval", int64(3600)).Return(subgraphBatchSigningInfo, nil)
+	subgraphClient := dataapi.NewSubgraphClient(mockSubgraphApi)
+	batches, err := subgraphClient.QueryBatchSigningInfoInInterval(context.Background(), 3600)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 5, len(batches))
+	assert.Equal(t, uint64(3), batches[2].BatchId)
+	assert.Equal(t, uint64(160), batches[2].ReferenceBlockNumber)
+	assert.InDelta(t, time.Now().Add(-30*time.Minute).Unix(), int64(batches[2].BlockTimestamp), 60)
+	assert.Equal(t, []core.QuorumID{0, 1}, batches[2].QuorumNumbers)
+	assert.Equal(t, map[string]struct{}{nonSigningOperatorId: {}}, batches[2].NonSigners)
+}
+
+func TestQueryBatchAttestationsInRange(t *testing.T) {
+	attestations := make([]*subgraph.BatchAttestation, len(subgraphBatchInfos))
+	for i, batch := range subgraphBatchInfos {
+		attestations[len(attestations)-1-i] = &subgraph.BatchAttestation{
+			BatchId:         batch.BatchId,
+			BatchHeaderHash: batch.BatchHeaderHash,
+			BlockTimestamp:  batch.BlockTimestamp,
+			BlockNumber:     batch.BlockNumber,
+			TxHash:          batch.TxHash,
+			BatchHeader:     batch.BatchHeader,
+		}
+	}
+	attestations[1].NonSigning.NonSigners = append(attestations[1].NonSigning.NonSigners, struct {
+		OperatorId graphql.String `graphql:"operatorId"`
+	}{OperatorId: "0xE1CDAE12A0074F20B8FC96A0489376DB34075E545EF60C4845D264A732568312"})
+	mockSubgraphApi := &subgraphmock.MockSubgraphApi{}
+	mockSubgraphApi.On("QueryBatchAttestationsInRange").Return(attestations, nil)
+	subgraphClient := dataapi.NewSubgraphClient(mockSubgraphApi)
+
+	batches, err := subgraphClient.QueryBatchAttestationsInRange(context.Background(), 1700000100, 1700000200)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(batches))
+	assert.Equal(t, &dataapi.BatchAttestation{
+		BatchInfo: dataapi.BatchInfo{
+			BatchId:              11,
+			BatchHeaderHash:      batchInfoHeaderHash(11),
+			ReferenceBlockNumber: 111,
+			QuorumNumbers:        []core.QuorumID{0, 1},
+			BlockTimestamp:       1700000100,
+			BlockNumber:          211,
+			TxHash:               "0x0000000000000000000000000000000000000000000000000000000000000b0b",
+		},
+		NonSigners: map[string]struct{}{nonSigningOperatorId: {}},
+	}, batches[0])
+	assert.Equal(t, uint64(12), batches[1].BatchId)
+	assert.Empty(t, batches[1].NonSigners)
+}
+
+func TestQueryOperatorQuorumEvents(t *testing.T) {
+	mockSubgraphApi := &subgraphmock.MockSubgraphApi{}
+	mockSubgraphApi.On("QueryOperatorRegisteredsByOperatorId", nonSigningOperatorId).Return([]*subgraph.OperatorRegistered{{OperatorId: graphql.String(nonSigningOperatorId), Operator: graphql.String(nonSigningOperatorAddress)}}, nil)
+	mockSubgraphApi.On("QueryOperatorRegisteredsByOperatorId", mock.Anything).Return(nil, nil)
+	mockSubgraphApi.On("QueryOperatorQuorumEvents", nonSigningOperatorAddress).Return(subgraphOperatorQuorumEvents, nil)
+	subgraphClient := dataapi.NewSubgraphClient(mockSubgraphApi)
+
+	events, err := subgraphClient.QueryOperatorQuorumEvents(context.Background(), nonSigningOperatorId)
+	assert.NoError(t, err)
+	assert.Equal(t, []*dataapi.OperatorQuorumEvent{
+		{BlockNumber: 100, QuorumNumbers: []core.QuorumID{0}, Added: true},
+		{BlockNumber: 150, QuorumNumbers: []core.QuorumID{1}, Added: true},
+		{BlockNumber: 200, QuorumNumbers: []core.QuorumID{0}, Added: false},
+	}, events)
+
+	_, err = subgraphClient.QueryOperatorQuorumEvents(context.Background(), "0xe1cdae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568313")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func makeBatchSigningInfo(batchId string, blockTimestamp string, referenceBlockNumber string, nonSigners ...string) *subgraph.BatchSigningInfo {
+	batch := &subgraph.BatchSigningInfo{BatchId: graphql.String(batchId), BlockTimestamp: graphql.String(blockTimestamp)}
+	batch.BatchHeader.QuorumNumbers = []graphql.String{"0", "1"}
+	batch.BatchHeader.ReferenceBlockNumber = graphql.String(referenceBlockNumber)
+	for _, nonSigner := range nonSigners {
+		batch.NonSigning.NonSigners = append(batch.NonSigning.NonSigners, struct {
+			OperatorId graphql.String `graphql:"operatorId"`
+		}{OperatorId: graphql.String(nonSigner)})
+	}
+	return batch
+}
+
+// confirmedAgo returns the unix timestamp of the block confirming a batch the duration ago
+func confirmedAgo(d time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(-d).Unix(), 10)
+}
+
+// makeBatchInfo makes a batch whose hashes and blocks derive from its ID
+func makeBatchInfo(batchId string, blockTimestamp string) *subgraph.BatchInfo {
+	id, _ := strconv.ParseUint(batchId, 10, 8)
+	batchHeaderHash := batchInfoHeaderHash(id)
+	batch := &subgraph.BatchInfo{
+		BatchId:         graphql.String(batchId),
+		BatchHeaderHash: graphql.String("0x" + hex.EncodeToString(batchHeaderHash[:])),
+		BlockTimestamp:  graphql.String(blockTimestamp),
+		BlockNumber:     graphql.String(strconv.FormatUint(200+id, 10)),
+		TxHash:          graphql.String(fmt.Sprintf("0x%064x", 0xb00+id)),
+	}
+	batch.BatchHeader.QuorumNumbers = []graphql.String{"0", "1"}
+	batch.BatchHeader.ReferenceBlockNumber = graphql.String(strconv.FormatUint(100+id, 10))
+	return batch
+}
+
+func batchInfoHeaderHash(batchId uint64) [32]byte {
+	return [32]byte{0xba, byte(batchId)}
+}
+
 func assertGasFees(t *testing.T, gasFees *dataapi.GasFees) {
 	assert.NotNil(t, gasFees)
 	assert.Equal(t, []byte("0x0006afd9ce41ba0f3414ba2650a9cd2f47c0e22af21651f7fd902f71df678c5d9942"), gasFees.Id)
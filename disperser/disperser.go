@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -25,6 +27,9 @@ const (
 	Failed
 	Finalized
 	InsufficientSignatures
+	// Deduplicated is the status of the blobs linked to an identical blob dispersed before them, whose status they
+	// report
+	Deduplicated
 )
 
 var enumStrings = map[BlobStatus]string{
@@ -33,6 +38,7 @@ var enumStrings = map[BlobStatus]string{
 	Failed:                 "Failed",
 	Finalized:              "Finalized",
 	InsufficientSignatures: "InsufficientSignatures",
+	Deduplicated:           "Deduplicated",
 }
 
 func (bs BlobStatus) String() string {
@@ -81,6 +87,12 @@ type BlobMetadata struct {
 	// This field is nil if the blob has not been confirmed
 	// This field is omitted when marshalling to DynamoDB attributevalue as this field will be flattened
 	ConfirmationInfo *ConfirmationInfo `json:"blob_confirmation_info" dynamodbav:"-"`
+	// LinkedBlobKey is the key of the blob dispersed before this identical one, which is dispersed in its place
+	// This field is nil unless the blob was deduplicated
+	LinkedBlobKey *BlobKey `json:"linked_blob_key,omitempty" dynamodbav:",omitempty"`
+	// SchemaVersion is the version of the schema the metadata was written with, see blobstore.SchemaVersion
+	// The metadata written before the schema was versioned is of version 0
+	SchemaVersion uint32 `json:"schema_version"`
 }
 
 func (m *BlobMetadata) GetBlobKey() BlobKey {
@@ -127,8 +139,9 @@ type ConfirmationInfo struct {
 type BlobStore interface {
 	// StoreBlob adds a blob to the queue and returns a key that can be used to retrieve the blob later
 	StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (BlobKey, error)
-	// GetBlobContent retrieves a blob's content
-	GetBlobContent(ctx context.Context, blobHash BlobHash) ([]byte, error)
+	// GetBlobContent returns a reader of a blob's content, which the caller must close. See ReadBlobContent to read
+	// it whole.
+	GetBlobContent(ctx context.Context, blobHash BlobHash) (io.ReadCloser, error)
 	// MarkBlobConfirmed updates blob metadata to Confirmed status with confirmation info
 	// Returns the updated metadata and error
 	MarkBlobConfirmed(ctx context.Context, existingMetadata *BlobMetadata, confirmationInfo *ConfirmationInfo) (*BlobMetadata, error)
@@ -141,6 +154,8 @@ type BlobStore interface {
 	MarkBlobProcessing(ctx context.Context, blobKey BlobKey) error
 	// MarkBlobFailed marks a blob as failed
 	MarkBlobFailed(ctx context.Context, blobKey BlobKey) error
+	// MarkBlobDeduplicated links a blob to an identical blob dispersed in its place, see ResolveBlobMetadata
+	MarkBlobDeduplicated(ctx context.Context, existingMetadata *BlobMetadata, linkedBlobKey BlobKey) error
 	// IncrementBlobRetryCount increments the retry count of a blob
 	IncrementBlobRetryCount(ctx context.Context, existingMetadata *BlobMetadata) error
 	// GetBlobsByMetadata retrieves a list of blobs given a list of metadata
@@ -155,6 +170,42 @@ type BlobStore interface {
 	GetBlobMetadata(ctx context.Context, blobKey BlobKey) (*BlobMetadata, error)
 }
 
+// ResolveBlobMetadata returns the metadata of the blob, or the one of the blob it's linked to if it was deduplicated,
+// so that the requests of identical blobs report the same status and confirmation
+func ResolveBlobMetadata(ctx context.Context, store BlobStore, blobKey BlobKey) (*BlobMetadata, error) {
+	metadata, err := store.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.BlobStatus != Deduplicated || metadata.LinkedBlobKey == nil {
+		return metadata, nil
+	}
+	linked, err := store.GetBlobMetadata(ctx, *metadata.LinkedBlobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the blob %s that %s is linked to: %w", metadata.LinkedBlobKey.String(), blobKey.String(), err)
+	}
+	return linked, nil
+}
+
+// ReadBlobContent reads and closes the content of a blob of the size of its metadata, into a buffer of that size. It
+// fails if the content doesn't have that size.
+func ReadBlobContent(content io.ReadCloser, size uint) ([]byte, error) {
+	defer content.Close()
+	data := make([]byte, size)
+	n, err := io.ReadFull(content, data)
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("blob content of %d bytes is shorter than its size of %d bytes", n, size)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var extra [1]byte
+	if n, _ := content.Read(extra[:]); n > 0 {
+		return nil, fmt.Errorf("blob content is longer than its size of %d bytes", size)
+	}
+	return data, nil
+}
+
 type Dispatcher interface {
 	DisperseBatch(context.Context, *core.IndexedOperatorState, []core.EncodedBlob, *core.BatchHeader) chan core.SignerMessage
 }
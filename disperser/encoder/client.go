@@ -5,31 +5,39 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
+// maxEncoderReplySize is the default maximum size of the replies of the encoder, which hold the encoded chunks
+const maxEncoderReplySize = 1024 * 1024 * 1024 // 1 GiB
+
 type client struct {
-	addr    string
-	timeout time.Duration
+	addr        string
+	timeout     time.Duration
+	dialOptions []grpc.DialOption
 }
 
-func NewEncoderClient(addr string, timeout time.Duration) (disperser.EncoderClient, error) {
+// NewEncoderClient creates a client of the encoder at the address. The gRPC options are optional, and the maximum
+// size of the replies defaults to maxEncoderReplySize. The requests carry the trace context of their callers.
+func NewEncoderClient(addr string, timeout time.Duration, grpcOptions *common.GRPCClientOptions) (disperser.EncoderClient, error) {
+	options := grpcOptions.WithDefaults(common.GRPCClientOptions{
+		MaxRecvMsgSize:    maxEncoderReplySize,
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{tracing.UnaryClientInterceptor()},
+	})
 	return client{
-		addr:    addr,
-		timeout: timeout,
+		addr:        addr,
+		timeout:     timeout,
+		dialOptions: options.DialOptions(),
 	}, nil
 }
 
 func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams core.EncodingParams) (*core.BlobCommitments, []*core.Chunk, error) {
-	conn, err := grpc.Dial(
-		c.addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // 1 GiB
-	)
+	conn, err := grpc.Dial(c.addr, c.dialOptions...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to dial encoder: %w", err)
 	}
@@ -0,0 +1,46 @@
+package encoder
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEncoderClientGRPCOptions(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	pb.RegisterEncoderServer(server, &pb.UnimplementedEncoderServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	var methods []string
+	client, err := NewEncoderClient(listener.Addr().String(), time.Second, &common.GRPCClientOptions{
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				methods = append(methods, method)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	_, _, err = client.EncodeBlob(context.Background(), []byte("data"), core.EncodingParams{ChunkLength: 1, NumChunks: 1})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.Equal(t, []string{"/encoder.Encoder/EncodeBlob"}, methods)
+
+	// The request doesn't fit in the send limit
+	client, err = NewEncoderClient(listener.Addr().String(), time.Second, &common.GRPCClientOptions{MaxSendMsgSize: 1})
+	assert.NoError(t, err)
+	_, _, err = client.EncodeBlob(context.Background(), []byte("data"), core.EncodingParams{ChunkLength: 1, NumChunks: 1})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
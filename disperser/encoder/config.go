@@ -1,5 +1,7 @@
 package encoder
 
+import "github.com/Layr-Labs/eigenda/common/grpcsec"
+
 const (
 	Localhost = "0.0.0.0"
 )
@@ -8,4 +10,6 @@ type ServerConfig struct {
 	GrpcPort              string
 	MaxConcurrentRequests int
 	RequestPoolSize       int
+	// TLSConfig is nil if the gRPC server is plaintext
+	TLSConfig *grpcsec.Config
 }
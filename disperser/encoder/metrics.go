@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -19,9 +22,10 @@ type MetrisConfig struct {
 }
 
 type Metrics struct {
-	logger   common.Logger
-	registry *prometheus.Registry
-	httpPort string
+	logger    common.Logger
+	registry  *prometheus.Registry
+	httpPort  string
+	profiling profiling.Config
 
 	NumEncodeBlobRequests *prometheus.CounterVec
 	Latency               *prometheus.SummaryVec
@@ -31,6 +35,8 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(version.NewCollector("eigenda_encoder"))
+	reg.MustRegister(logging.NewLevelCollector("eigenda_encoder", logger))
 
 	return &Metrics{
 		logger:   logger,
@@ -85,6 +91,10 @@ func (m *Metrics) TakeLatency(encoding, total time.Duration) {
 	m.Latency.WithLabelValues("total").Observe(float64(total.Milliseconds()))
 }
 
+func (m *Metrics) EnableProfiling(config profiling.Config) {
+	m.profiling = config
+}
+
 func (m *Metrics) Start(ctx context.Context) {
 	m.logger.Info("Starting metrics server at ", "port", m.httpPort)
 
@@ -93,6 +103,8 @@ func (m *Metrics) Start(ctx context.Context) {
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	profiling.RegisterHandlers(mux, m.profiling)
+	logging.RegisterHandlers(mux, m.logger)
 
 	server := &http.Server{Addr: addr, Handler: mux}
 	errc := make(chan error, 1)
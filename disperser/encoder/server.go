@@ -8,10 +8,13 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -81,6 +84,11 @@ func (s *Server) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest)
 		NumChunks:   uint(req.EncodingParams.NumChunks),
 	}
 
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.BlobSizeKey.Int(len(req.Data)),
+		tracing.NumChunksKey.Int(int(encodingParams.NumChunks)),
+		tracing.ChunkLengthKey.Int(int(encodingParams.ChunkLength)),
+	)
 	commits, chunks, err := s.coreEncoder.Encode(req.Data, encodingParams)
 
 	if err != nil {
@@ -134,8 +142,12 @@ func (s *Server) Start() error {
 		log.Fatalf("Could not start tcp listener: %v", err)
 	}
 
+	creds, err := grpcsec.ServerOption(context.Background(), s.config.TLSConfig, s.logger)
+	if err != nil {
+		return err
+	}
 	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(opt, creds, grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()))
 	reflection.Register(gs)
 	pb.RegisterEncoderServer(gs, s)
 
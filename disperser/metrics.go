@@ -6,6 +6,9 @@ import (
 	"net/http"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -23,9 +26,11 @@ type Metrics struct {
 	NumBlobRequests *prometheus.CounterVec
 	BlobSize        *prometheus.GaugeVec
 	Latency         *prometheus.SummaryVec
+	ReadOnly        prometheus.Gauge
 
-	httpPort string
-	logger   common.Logger
+	httpPort  string
+	profiling profiling.Config
+	logger    common.Logger
 }
 
 func NewMetrics(httpPort string, logger common.Logger) *Metrics {
@@ -33,6 +38,8 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(version.NewCollector(namespace))
+	reg.MustRegister(logging.NewLevelCollector(namespace, logger))
 
 	metrics := &Metrics{
 		NumBlobRequests: promauto.With(reg).NewCounterVec(
@@ -60,6 +67,13 @@ func NewMetrics(httpPort string, logger common.Logger) *Metrics {
 			},
 			[]string{"method"},
 		),
+		ReadOnly: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "read_only",
+				Help:      "1 if the server is in read-only mode and rejects the dispersals, 0 otherwise",
+			},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger,
@@ -138,7 +152,20 @@ func (g *Metrics) HandleAccountRateLimitedRequest(quorum string, blobBytes int,
 	}).Add(float64(blobBytes))
 }
 
+// SetReadOnly records whether the server is in read-only mode
+func (g *Metrics) SetReadOnly(readOnly bool) {
+	if readOnly {
+		g.ReadOnly.Set(1)
+	} else {
+		g.ReadOnly.Set(0)
+	}
+}
+
 // Start starts the metrics server
+func (g *Metrics) EnableProfiling(config profiling.Config) {
+	g.profiling = config
+}
+
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
@@ -149,6 +176,8 @@ func (g *Metrics) Start(ctx context.Context) {
 			g.registry,
 			promhttp.HandlerOpts{},
 		))
+		profiling.RegisterHandlers(mux, g.profiling)
+		logging.RegisterHandlers(mux, g.logger)
 		err := http.ListenAndServe(addr, mux)
 		log.Error("Prometheus server failed", "err", err)
 	}()
@@ -1,9 +1,32 @@
 package disperser
 
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
 const (
 	Localhost = "0.0.0.0"
 )
 
 type ServerConfig struct {
 	GrpcPort string
+	// TLSConfig is nil if the gRPC server is plaintext
+	TLSConfig *grpcsec.Config
+	// ThresholdMargins are the margins by which the quorum thresholds of the dispersals must exceed their adversary
+	// thresholds, which are core.DefaultThresholdMargin in all the quorums if it's nil
+	ThresholdMargins *core.ThresholdMargins
+	// ReadOnly starts the server in read-only mode, where the dispersals are rejected with the ReadOnlyMessage and a
+	// hint to retry after ReadOnlyRetryAfter
+	ReadOnly           bool
+	ReadOnlyMessage    string
+	ReadOnlyRetryAfter time.Duration
+	// AdminAddress is the address of the admin server, which serves the admin endpoints such as ReadOnlyPath with
+	// the TLS configuration of the gRPC server. The admin server is disabled if it's empty.
+	AdminAddress string
+	// AdminTokens are the bearer tokens of the admin endpoints that change the state of the server, by the name of
+	// the admin each identifies in the logs. These endpoints are forbidden if there are none.
+	AdminTokens map[string]string
 }
@@ -60,6 +60,10 @@ func getFlag(flag cli.Flag) Flag {
 	if ok {
 		return Flag{intFlag.Name, intFlag.EnvVar}
 	}
+	int64Flag, ok := flag.(cli.Int64Flag)
+	if ok {
+		return Flag{int64Flag.Name, int64Flag.EnvVar}
+	}
 	uint64Flag, ok := flag.(cli.Uint64Flag)
 	if ok {
 		return Flag{uint64Flag.Name, uint64Flag.EnvVar}
@@ -68,6 +72,10 @@ func getFlag(flag cli.Flag) Flag {
 	if ok {
 		return Flag{uintFlag.Name, uintFlag.EnvVar}
 	}
+	float64Flag, ok := flag.(cli.Float64Flag)
+	if ok {
+		return Flag{float64Flag.Name, float64Flag.EnvVar}
+	}
 	durationFlag, ok := flag.(cli.DurationFlag)
 	if ok {
 		return Flag{durationFlag.Name, durationFlag.EnvVar}
@@ -262,6 +262,9 @@ func (env *Config) generateOperatorVars(ind int, name, key, churnerUrl, logPath,
 	blsPassword := env.Pks.BlsMap[name].Password
 	ecdsaKeyFile := env.Pks.EcdsaMap[name].KeyFile
 	ecdsaPassword := env.Pks.EcdsaMap[name].Password
+	// The batcher signs the requests to store chunks with the key of its chain client. The local environment doesn't
+	// authenticate them, but authorizes the batcher so that the authentication can be enforced.
+	_, disperser := env.getKey("batcher0")
 
 	v := OperatorVars{
 		NODE_HOSTNAME:                    "",
@@ -301,6 +304,8 @@ func (env *Config) generateOperatorVars(ind int, name, key, churnerUrl, logPath,
 		NODE_NUM_BATCH_VALIDATORS:        "128",
 		NODE_PUBLIC_IP_PROVIDER:          "mockip",
 		NODE_PUBLIC_IP_CHECK_INTERVAL:    "10s",
+		NODE_DISPERSAL_AUTHENTICATION:    "disabled",
+		NODE_AUTHORIZED_DISPERSERS:       disperser,
 	}
 
 	env.applyDefaults(&v, "NODE", "opr", ind)
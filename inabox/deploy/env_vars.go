@@ -23,6 +23,20 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_RATE_BUCKET_STORE_SIZE string
 
+	DISPERSER_SERVER_THRESHOLD_MARGIN string
+
+	DISPERSER_SERVER_QUORUM_THRESHOLD_MARGINS string
+
+	DISPERSER_SERVER_READ_ONLY string
+
+	DISPERSER_SERVER_READ_ONLY_MESSAGE string
+
+	DISPERSER_SERVER_READ_ONLY_RETRY_AFTER string
+
+	DISPERSER_SERVER_ADMIN_ADDRESS string
+
+	DISPERSER_SERVER_ADMIN_TOKENS string
+
 	DISPERSER_SERVER_CHAIN_RPC string
 
 	DISPERSER_SERVER_PRIVATE_KEY string
@@ -33,6 +47,30 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_LOG_PATH string
 
+	DISPERSER_SERVER_LOG_ADMIN_TOKEN string
+
+	DISPERSER_SERVER_ENABLE_PPROF string
+
+	DISPERSER_SERVER_PPROF_ADDRESS string
+
+	DISPERSER_SERVER_TRACING_ENDPOINT string
+
+	DISPERSER_SERVER_TRACING_SAMPLE_RATIO string
+
+	DISPERSER_SERVER_TRACING_INSECURE string
+
+	DISPERSER_SERVER_TLS_CERT_FILE string
+
+	DISPERSER_SERVER_TLS_KEY_FILE string
+
+	DISPERSER_SERVER_TLS_CLIENT_CA_FILE string
+
+	DISPERSER_SERVER_TLS_MIN_VERSION string
+
+	DISPERSER_SERVER_TLS_CIPHER_SUITES string
+
+	DISPERSER_SERVER_TLS_RELOAD_INTERVAL string
+
 	DISPERSER_SERVER_BUCKET_SIZES string
 
 	DISPERSER_SERVER_BUCKET_MULTIPLIERS string
@@ -49,6 +87,14 @@ type DisperserVars struct {
 
 	DISPERSER_SERVER_AWS_ENDPOINT_URL string
 
+	DISPERSER_SERVER_BLOBSTORE_BLOB_BACKEND string
+
+	DISPERSER_SERVER_BLOBSTORE_METADATA_BACKEND string
+
+	DISPERSER_SERVER_BLOBSTORE_DATA_DIR string
+
+	DISPERSER_SERVER_BLOBSTORE_MIGRATION_RATE string
+
 	DISPERSER_SERVER_REGISTERED_QUORUM_ID string
 
 	DISPERSER_SERVER_TOTAL_UNAUTH_THROUGHPUT string
@@ -56,6 +102,8 @@ type DisperserVars struct {
 	DISPERSER_SERVER_PER_USER_UNAUTH_THROUGHPUT string
 
 	DISPERSER_SERVER_CLIENT_IP_HEADER string
+
+	DISPERSER_SERVER_CONFIG string
 }
 
 func (vars DisperserVars) getEnvMap() map[string]string {
@@ -106,10 +154,30 @@ type BatcherVars struct {
 
 	BATCHER_FINALIZER_INTERVAL string
 
+	BATCHER_APK_CHECK_INTERVAL string
+
 	BATCHER_ENCODING_REQUEST_QUEUE_SIZE string
 
 	BATCHER_MAX_NUM_RETRIES_PER_BLOB string
 
+	BATCHER_NODE_COMPRESSION string
+
+	BATCHER_NODE_COMPRESSION_THRESHOLD string
+
+	BATCHER_ENCODING_STALL_THRESHOLD string
+
+	BATCHER_BATCH_STALL_THRESHOLD string
+
+	BATCHER_STATE_CONSISTENCY_RETRIES string
+
+	BATCHER_DISPERSAL_BUDGET string
+
+	BATCHER_DISPERSAL_SIGNING_KEY string
+
+	BATCHER_BLOB_DEDUPLICATION string
+
+	BATCHER_DEDUPLICATION_WINDOW string
+
 	BATCHER_CHAIN_RPC string
 
 	BATCHER_PRIVATE_KEY string
@@ -120,8 +188,44 @@ type BatcherVars struct {
 
 	BATCHER_LOG_PATH string
 
+	BATCHER_LOG_ADMIN_TOKEN string
+
+	BATCHER_NODE_CONNECT_BACKOFF_BASE_DELAY string
+
+	BATCHER_NODE_CONNECT_BACKOFF_MAX_DELAY string
+
+	BATCHER_NODE_CONNECT_BACKOFF_MULTIPLIER string
+
+	BATCHER_ENABLE_PPROF string
+
+	BATCHER_PPROF_ADDRESS string
+
+	BATCHER_TRACING_ENDPOINT string
+
+	BATCHER_TRACING_SAMPLE_RATIO string
+
+	BATCHER_TRACING_INSECURE string
+
 	BATCHER_INDEXER_PULL_INTERVAL string
 
+	BATCHER_INDEXER_RETENTION_BLOCKS string
+
+	BATCHER_INDEXER_MAX_ENTRIES string
+
+	BATCHER_INDEXER_COMPACTION_INTERVAL string
+
+	BATCHER_STATE_CACHE_SIZE string
+
+	BATCHER_STATE_CACHE_FINALIZATION_DEPTH string
+
+	BATCHER_STATE_CACHE_UNFINALIZED_TTL string
+
+	BATCHER_STATE_CACHE_FINALIZED_TTL string
+
+	BATCHER_MAX_CHUNKS_PER_OPERATOR string
+
+	BATCHER_MAX_CHUNKS_ACTIVATION_BLOCK string
+
 	BATCHER_AWS_REGION string
 
 	BATCHER_AWS_ACCESS_KEY_ID string
@@ -129,6 +233,16 @@ type BatcherVars struct {
 	BATCHER_AWS_SECRET_ACCESS_KEY string
 
 	BATCHER_AWS_ENDPOINT_URL string
+
+	BATCHER_BLOBSTORE_BLOB_BACKEND string
+
+	BATCHER_BLOBSTORE_METADATA_BACKEND string
+
+	BATCHER_BLOBSTORE_DATA_DIR string
+
+	BATCHER_BLOBSTORE_MIGRATION_RATE string
+
+	BATCHER_CONFIG string
 }
 
 func (vars BatcherVars) getEnvMap() map[string]string {
@@ -161,6 +275,12 @@ type EncoderVars struct {
 
 	DISPERSER_ENCODER_NUM_WORKERS string
 
+	DISPERSER_ENCODER_NUM_DECODE_WORKERS string
+
+	DISPERSER_ENCODER_DECODE_BUFFER_POOL_MAX_BLOB_SIZE string
+
+	DISPERSER_ENCODER_DISABLE_DECODE_BUFFER_POOL string
+
 	DISPERSER_ENCODER_VERBOSE string
 
 	DISPERSER_ENCODER_CACHE_ENCODED_BLOBS string
@@ -172,6 +292,32 @@ type EncoderVars struct {
 	DISPERSER_ENCODER_FILE_LOG_LEVEL string
 
 	DISPERSER_ENCODER_LOG_PATH string
+
+	DISPERSER_ENCODER_LOG_ADMIN_TOKEN string
+
+	DISPERSER_ENCODER_ENABLE_PPROF string
+
+	DISPERSER_ENCODER_PPROF_ADDRESS string
+
+	DISPERSER_ENCODER_TRACING_ENDPOINT string
+
+	DISPERSER_ENCODER_TRACING_SAMPLE_RATIO string
+
+	DISPERSER_ENCODER_TRACING_INSECURE string
+
+	DISPERSER_ENCODER_TLS_CERT_FILE string
+
+	DISPERSER_ENCODER_TLS_KEY_FILE string
+
+	DISPERSER_ENCODER_TLS_CLIENT_CA_FILE string
+
+	DISPERSER_ENCODER_TLS_MIN_VERSION string
+
+	DISPERSER_ENCODER_TLS_CIPHER_SUITES string
+
+	DISPERSER_ENCODER_TLS_RELOAD_INTERVAL string
+
+	DISPERSER_ENCODER_CONFIG string
 }
 
 func (vars EncoderVars) getEnvMap() map[string]string {
@@ -242,6 +388,28 @@ type OperatorVars struct {
 
 	NODE_CLIENT_IP_HEADER string
 
+	NODE_GRPC_COMPRESSION_THRESHOLD string
+
+	NODE_DISPERSAL_AUTHENTICATION string
+
+	NODE_AUTHORIZED_DISPERSERS string
+
+	NODE_DISPERSER_REGISTRY string
+
+	NODE_DISPERSER_REGISTRY_METHOD string
+
+	NODE_DISPERSER_REFRESH_INTERVAL string
+
+	NODE_CHUNK_CACHE_SIZE string
+
+	NODE_CHUNK_CACHE_BATCHES string
+
+	NODE_AUDIT_INTERVAL string
+
+	NODE_AUDIT_MAX_READ_BYTES_PER_SECOND string
+
+	NODE_AUDIT_REMOVE_CORRUPT string
+
 	NODE_G1_PATH string
 
 	NODE_G2_PATH string
@@ -252,6 +420,12 @@ type OperatorVars struct {
 
 	NODE_NUM_WORKERS string
 
+	NODE_NUM_DECODE_WORKERS string
+
+	NODE_DECODE_BUFFER_POOL_MAX_BLOB_SIZE string
+
+	NODE_DISABLE_DECODE_BUFFER_POOL string
+
 	NODE_VERBOSE string
 
 	NODE_CACHE_ENCODED_BLOBS string
@@ -267,6 +441,36 @@ type OperatorVars struct {
 	NODE_FILE_LOG_LEVEL string
 
 	NODE_LOG_PATH string
+
+	NODE_LOG_ADMIN_TOKEN string
+
+	NODE_ENABLE_PPROF string
+
+	NODE_PPROF_ADDRESS string
+
+	NODE_TRACING_ENDPOINT string
+
+	NODE_TRACING_SAMPLE_RATIO string
+
+	NODE_TRACING_INSECURE string
+
+	NODE_TLS_CERT_FILE string
+
+	NODE_TLS_KEY_FILE string
+
+	NODE_TLS_CLIENT_CA_FILE string
+
+	NODE_TLS_MIN_VERSION string
+
+	NODE_TLS_CIPHER_SUITES string
+
+	NODE_TLS_RELOAD_INTERVAL string
+
+	NODE_MAX_CHUNKS_PER_OPERATOR string
+
+	NODE_MAX_CHUNKS_ACTIVATION_BLOCK string
+
+	NODE_CONFIG string
 }
 
 func (vars OperatorVars) getEnvMap() map[string]string {
@@ -279,22 +483,140 @@ func (vars OperatorVars) getEnvMap() map[string]string {
 }
 
 type RetrieverVars struct {
-	RETRIEVER_HOSTNAME string
-
-	RETRIEVER_GRPC_PORT string
-
 	RETRIEVER_TIMEOUT string
 
 	RETRIEVER_BLS_OPERATOR_STATE_RETRIVER string
 
 	RETRIEVER_EIGENDA_SERVICE_MANAGER string
 
+	RETRIEVER_HOSTNAME string
+
+	RETRIEVER_GRPC_PORT string
+
+	RETRIEVER_LISTEN_ADDRESSES string
+
 	RETRIEVER_NUM_CONNECTIONS string
 
 	RETRIEVER_DATA_DIR string
 
+	RETRIEVER_INDEXER_POLL_INTERVAL string
+
+	RETRIEVER_INDEXER_REORG_DEPTH string
+
+	RETRIEVER_CHAIN_STATE_BACKEND string
+
+	RETRIEVER_CHAIN_STATE_START_BLOCK string
+
+	RETRIEVER_GRAPH_URL string
+
+	RETRIEVER_GRAPH_RETRIES string
+
+	RETRIEVER_GRAPH_BACKOFF string
+
+	RETRIEVER_CHAIN_READ_RETRIES string
+
+	RETRIEVER_CHAIN_READ_RETRY_BACKOFF string
+
+	RETRIEVER_RECONSTRUCTION_MEMORY_BUDGET string
+
+	RETRIEVER_REJECT_OVER_MEMORY_BUDGET string
+
+	RETRIEVER_RECONSTRUCTION_CAPTURE_DIR string
+
+	RETRIEVER_RECONSTRUCTION_CAPTURE_MAX_BYTES string
+
+	RETRIEVER_CHUNK_VERIFY_FAILURE_MODE string
+
+	RETRIEVER_COMMITMENT_VERIFICATION string
+
+	RETRIEVER_COMMITMENT_VERIFICATION_SAMPLES string
+
+	RETRIEVER_COMMITMENT_MISMATCH_RETRY string
+
+	RETRIEVER_BLACKLIST_UNASSIGNED_CHUNK_OPERATORS string
+
+	RETRIEVER_SEQUENTIAL_FETCH string
+
+	RETRIEVER_VERBOSE_RETRIEVALS string
+
+	RETRIEVER_HIGH_PRIORITY_RETRIEVALS string
+
+	RETRIEVER_BLOB_SINK_BUCKET string
+
+	RETRIEVER_BLOB_SINK_ENDPOINT_URL string
+
+	RETRIEVER_BLOB_SINK_REGION string
+
+	RETRIEVER_BLOB_SINK_ACCESS_KEY_ID string
+
+	RETRIEVER_BLOB_SINK_SECRET_ACCESS_KEY string
+
+	RETRIEVER_BLOB_SINK_SYNC string
+
+	RETRIEVER_BLOB_SINK_TIMEOUT string
+
+	RETRIEVER_BLOB_SINK_WORKERS string
+
+	RETRIEVER_BLOB_SINK_QUEUE_SIZE string
+
+	RETRIEVER_SKIP_SRS_VALIDATION string
+
+	RETRIEVER_PROXY_URL string
+
+	RETRIEVER_EXPECTED_CHAIN_ID string
+
+	RETRIEVER_CORRELATION_ID_KEY string
+
+	RETRIEVER_MAINTENANCE_MESSAGE string
+
+	RETRIEVER_ENDPOINT_REFRESH_FAILURES string
+
+	RETRIEVER_ENDPOINT_REFRESH_INTERVAL string
+
+	RETRIEVER_NODE_CONNECTION_IDLE_TIMEOUT string
+
+	RETRIEVER_NODE_INITIAL_WINDOW_SIZE string
+
+	RETRIEVER_NODE_INITIAL_CONN_WINDOW_SIZE string
+
+	RETRIEVER_MAX_OPERATORS_PER_RETRIEVAL string
+
+	RETRIEVER_FAN_OUT_ORDER string
+
+	RETRIEVER_FAN_OUT_STAKE_WEIGHT string
+
+	RETRIEVER_CHUNK_CACHE_SIZE string
+
+	RETRIEVER_PEER_FALLBACK string
+
+	RETRIEVER_PEERS string
+
+	RETRIEVER_PEER_TLS string
+
+	RETRIEVER_PEER_TLS_CA_FILE string
+
+	RETRIEVER_MAX_PEERS_PER_RETRIEVAL string
+
+	RETRIEVER_MAX_BLOBS_PER_REQUEST string
+
+	RETRIEVER_TOMBSTONE_TTL string
+
+	RETRIEVER_RESPONSE_SIZE_BUCKETS string
+
+	RETRIEVER_LARGE_RESPONSE_THRESHOLD string
+
+	RETRIEVER_UNSAFE_OVERRIDES string
+
+	RETRIEVER_RECONSTRUCTION_THRESHOLD_OVERRIDES string
+
+	RETRIEVER_METRICS_NAMESPACE string
+
+	RETRIEVER_METRICS_SUBSYSTEM string
+
 	RETRIEVER_METRICS_HTTP_PORT string
 
+	RETRIEVER_CONTINUE_WITHOUT_METRICS string
+
 	RETRIEVER_G1_PATH string
 
 	RETRIEVER_G2_PATH string
@@ -305,6 +627,12 @@ type RetrieverVars struct {
 
 	RETRIEVER_NUM_WORKERS string
 
+	RETRIEVER_NUM_DECODE_WORKERS string
+
+	RETRIEVER_DECODE_BUFFER_POOL_MAX_BLOB_SIZE string
+
+	RETRIEVER_DISABLE_DECODE_BUFFER_POOL string
+
 	RETRIEVER_VERBOSE string
 
 	RETRIEVER_CACHE_ENCODED_BLOBS string
@@ -321,7 +649,57 @@ type RetrieverVars struct {
 
 	RETRIEVER_LOG_PATH string
 
+	RETRIEVER_LOG_ADMIN_TOKEN string
+
+	RETRIEVER_NODE_CONNECT_BACKOFF_BASE_DELAY string
+
+	RETRIEVER_NODE_CONNECT_BACKOFF_MAX_DELAY string
+
+	RETRIEVER_NODE_CONNECT_BACKOFF_MULTIPLIER string
+
+	RETRIEVER_TLS_CERT_FILE string
+
+	RETRIEVER_TLS_KEY_FILE string
+
+	RETRIEVER_TLS_CLIENT_CA_FILE string
+
+	RETRIEVER_TLS_MIN_VERSION string
+
+	RETRIEVER_TLS_CIPHER_SUITES string
+
+	RETRIEVER_TLS_RELOAD_INTERVAL string
+
+	RETRIEVER_ENABLE_PPROF string
+
+	RETRIEVER_PPROF_ADDRESS string
+
+	RETRIEVER_METRICS_BACKEND string
+
+	RETRIEVER_METRICS_STATSD_ADDRESS string
+
+	RETRIEVER_METRICS_LISTEN_TIMEOUT string
+
 	RETRIEVER_INDEXER_PULL_INTERVAL string
+
+	RETRIEVER_INDEXER_RETENTION_BLOCKS string
+
+	RETRIEVER_INDEXER_MAX_ENTRIES string
+
+	RETRIEVER_INDEXER_COMPACTION_INTERVAL string
+
+	RETRIEVER_STATE_CACHE_SIZE string
+
+	RETRIEVER_STATE_CACHE_FINALIZATION_DEPTH string
+
+	RETRIEVER_STATE_CACHE_UNFINALIZED_TTL string
+
+	RETRIEVER_STATE_CACHE_FINALIZED_TTL string
+
+	RETRIEVER_MAX_CHUNKS_PER_OPERATOR string
+
+	RETRIEVER_MAX_CHUNKS_ACTIVATION_BLOCK string
+
+	RETRIEVER_CONFIG string
 }
 
 func (vars RetrieverVars) getEnvMap() map[string]string {
@@ -344,8 +722,12 @@ type ChurnerVars struct {
 
 	CHURNER_EIGENDA_SERVICE_MANAGER string
 
+	CHURNER_ENABLE_METRICS string
+
 	CHURNER_PER_PUBLIC_KEY_RATE_LIMIT string
 
+	CHURNER_METRICS_HTTP_PORT string
+
 	CHURNER_CHAIN_RPC string
 
 	CHURNER_PRIVATE_KEY string
@@ -356,11 +738,33 @@ type ChurnerVars struct {
 
 	CHURNER_LOG_PATH string
 
+	CHURNER_LOG_ADMIN_TOKEN string
+
+	CHURNER_ENABLE_PPROF string
+
+	CHURNER_PPROF_ADDRESS string
+
+	CHURNER_TLS_CERT_FILE string
+
+	CHURNER_TLS_KEY_FILE string
+
+	CHURNER_TLS_CLIENT_CA_FILE string
+
+	CHURNER_TLS_MIN_VERSION string
+
+	CHURNER_TLS_CIPHER_SUITES string
+
+	CHURNER_TLS_RELOAD_INTERVAL string
+
 	CHURNER_INDEXER_PULL_INTERVAL string
 
-	CHURNER_ENABLE_METRICS string
+	CHURNER_INDEXER_RETENTION_BLOCKS string
 
-	CHURNER_METRICS_HTTP_PORT string
+	CHURNER_INDEXER_MAX_ENTRIES string
+
+	CHURNER_INDEXER_COMPACTION_INTERVAL string
+
+	CHURNER_CONFIG string
 }
 
 func (vars ChurnerVars) getEnvMap() map[string]string {
@@ -8,7 +8,10 @@ import (
 )
 
 const (
-	PullIntervalFlagName = "indexer-pull-interval"
+	PullIntervalFlagName       = "indexer-pull-interval"
+	RetentionBlocksFlagName    = "indexer-retention-blocks"
+	MaxEntriesFlagName         = "indexer-max-entries"
+	CompactionIntervalFlagName = "indexer-compaction-interval"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -20,11 +23,33 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_PULL_INTERVAL"),
 			Value:    1 * time.Second,
 		},
+		cli.Uint64Flag{
+			Name:     RetentionBlocksFlagName,
+			Usage:    "Number of blocks behind the latest finalized block whose index entries are kept, which bounds the age of the reference blocks of the blobs that can be served. 0 keeps all the entries",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_RETENTION_BLOCKS"),
+		},
+		cli.IntFlag{
+			Name:     MaxEntriesFlagName,
+			Usage:    "Maximum number of index entries, beyond which the oldest finalized entries are evicted. It must exceed the retention blocks. 0 doesn't cap the entries",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_MAX_ENTRIES"),
+		},
+		cli.DurationFlag{
+			Name:     CompactionIntervalFlagName,
+			Usage:    "Interval at which the index entries beyond the retention blocks and the max entries are pruned",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_COMPACTION_INTERVAL"),
+			Value:    1 * time.Minute,
+		},
 	}
 }
 
 func ReadIndexerConfig(ctx *cli.Context) Config {
 	return Config{
-		PullInterval: ctx.GlobalDuration(PullIntervalFlagName),
+		PullInterval:       ctx.GlobalDuration(PullIntervalFlagName),
+		RetentionBlocks:    ctx.GlobalUint64(RetentionBlocksFlagName),
+		MaxEntries:         ctx.GlobalInt(MaxEntriesFlagName),
+		CompactionInterval: ctx.GlobalDuration(CompactionIntervalFlagName),
 	}
 }
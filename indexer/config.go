@@ -1,7 +1,40 @@
 package indexer
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 type Config struct {
 	PullInterval time.Duration
+
+	// RetentionBlocks is the number of blocks behind the latest finalized header whose headers are kept by the
+	// compactions of the header store. It bounds the age of the reference blocks the indexed state can be read at,
+	// and 0 keeps all the headers.
+	RetentionBlocks uint64
+	// MaxEntries caps the number of headers of the header store, the oldest finalized ones being pruned beyond it.
+	// 0 doesn't cap the store.
+	MaxEntries int
+	// CompactionInterval is the interval between the compactions of the header store
+	CompactionInterval time.Duration
+
+	// MaxReorgDepth is the number of headers a reorg can replace before the indexing stops, as deeper reorgs are
+	// likely faults of the chain or of its provider. 0 doesn't limit the reorgs.
+	MaxReorgDepth uint64
+}
+
+// Validate checks that the cap on the headers leaves room for the headers of the retention, which would otherwise be
+// pruned while the blobs referencing them are still retrievable
+func (c Config) Validate() error {
+	if c.MaxEntries < 0 {
+		return errors.New("indexer max entries must not be negative")
+	}
+	if c.MaxEntries > 0 && c.RetentionBlocks > 0 && uint64(c.MaxEntries) <= c.RetentionBlocks {
+		return fmt.Errorf("indexer max entries %d must exceed the retention of %d blocks", c.MaxEntries, c.RetentionBlocks)
+	}
+	if (c.MaxEntries > 0 || c.RetentionBlocks > 0) && c.CompactionInterval <= 0 {
+		return errors.New("indexer compaction interval must be positive when the retention or max entries is set")
+	}
+	return nil
 }
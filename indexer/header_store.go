@@ -27,3 +27,16 @@ type HeaderStore interface {
 
 	FastForward()
 }
+
+// CompactableHeaderStore is a HeaderStore whose oldest finalized headers can be pruned to bound its size
+type CompactableHeaderStore interface {
+	HeaderStore
+
+	// Prune removes the finalized headers numbered below beforeNumber and then, if maxEntries is positive, the
+	// oldest finalized headers until the store has at most maxEntries headers. The latest finalized header is
+	// always kept. It returns the number of headers removed.
+	Prune(beforeNumber uint64, maxEntries int) int
+
+	// Size returns the number of headers of the store
+	Size() int
+}
@@ -3,7 +3,9 @@ package indexer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -27,6 +29,10 @@ const (
 	maxSyncBlocks        = 10
 )
 
+// ErrReorgTooDeep is the error the indexing stops on after a reorg deeper than the max reorg depth, which likely
+// comes from a problem of the chain or of its provider
+var ErrReorgTooDeep = errors.New("reorg deeper than the max reorg depth")
+
 type Indexer struct {
 	Logger common.Logger
 
@@ -36,6 +42,37 @@ type Indexer struct {
 	UpgradeForkWatcher UpgradeForkWatcher
 
 	PullInterval time.Duration
+
+	RetentionBlocks    uint64
+	MaxEntries         int
+	CompactionInterval time.Duration
+	// CompactionObserver, if set, is notified of the compactions of the header store
+	CompactionObserver CompactionObserver
+
+	// MaxReorgDepth is the depth of the deepest reorg the indexing goes on after, 0 not limiting it
+	MaxReorgDepth uint64
+	// ReorgObserver, if set, is notified of the reorgs handled by the indexer
+	ReorgObserver ReorgObserver
+
+	// failure is the error the indexing stopped on, shared by the copies of the indexer
+	failure *failure
+}
+
+// CompactionObserver is notified of the size of the header store and of the number of headers pruned by each of
+// its compactions
+type CompactionObserver interface {
+	ObserveCompaction(size int, pruned int)
+}
+
+// ReorgObserver is notified of the depth of each reorg handled by the indexer, i.e. of the number of headers it
+// replaced, and of the depth of the deepest one so far
+type ReorgObserver interface {
+	ObserveReorg(depth uint64, maxDepth uint64)
+}
+
+type failure struct {
+	mu  sync.Mutex
+	err error
 }
 
 func NewIndexer(
@@ -57,10 +94,34 @@ func NewIndexer(
 		HeaderStore:        headerStore,
 		UpgradeForkWatcher: upgradeForkWatcher,
 		PullInterval:       config.PullInterval,
+		RetentionBlocks:    config.RetentionBlocks,
+		MaxEntries:         config.MaxEntries,
+		CompactionInterval: config.CompactionInterval,
+		MaxReorgDepth:      config.MaxReorgDepth,
 		Logger:             logger,
+		failure:            &failure{},
 	}
 }
 
+// Err returns the error the indexing stopped on, e.g. ErrReorgTooDeep, or nil if it's still indexing
+func (i Indexer) Err() error {
+	if i.failure == nil {
+		return nil
+	}
+	i.failure.mu.Lock()
+	defer i.failure.mu.Unlock()
+	return i.failure.err
+}
+
+func (i Indexer) fail(err error) {
+	if i.failure == nil {
+		return
+	}
+	i.failure.mu.Lock()
+	defer i.failure.mu.Unlock()
+	i.failure.err = err
+}
+
 func (i Indexer) Index(ctx context.Context) error {
 
 	// Check if any of the accumulators are uninitialized
@@ -117,12 +178,20 @@ func (i Indexer) Index(ctx context.Context) error {
 	}
 
 	go func() {
+		// The store is compacted by the goroutine adding to it
+		lastCompaction := time.Now()
+		var maxReorgDepth uint64
 	loop:
 		for {
 			select {
 			case <-ctx.Done():
 				break loop // returning not to leak the goroutine
 			default:
+				if i.CompactionInterval > 0 && time.Since(lastCompaction) >= i.CompactionInterval {
+					i.compact()
+					lastCompaction = time.Now()
+				}
+
 				latestFinalizedHeader, err := i.HeaderStore.GetLatestHeader(true)
 				if errors.Is(err, ErrNoHeaders) {
 					// TODO: Set the latestFinalized to a config value reflecting the point at which the contract was deployed
@@ -145,6 +214,11 @@ func (i Indexer) Index(ctx context.Context) error {
 				if len(headers) > 0 {
 					headers = i.UpgradeForkWatcher.DetectUpgrade(headers)
 
+					// The headers replaced by a reorg are the ones from the first new header to the previous latest one
+					previousHeader, err := i.HeaderStore.GetLatestHeader(false)
+					if err != nil {
+						previousHeader = nil
+					}
 					newHeaders, err := i.HeaderStore.AddHeaders(headers)
 					if err != nil {
 						i.Logger.Error("Error adding headers", "err", err)
@@ -152,6 +226,20 @@ func (i Indexer) Index(ctx context.Context) error {
 						continue loop
 					}
 
+					if depth := reorgDepth(previousHeader, newHeaders); depth > 0 {
+						maxReorgDepth = max(maxReorgDepth, depth)
+						if i.ReorgObserver != nil {
+							i.ReorgObserver.ObserveReorg(depth, maxReorgDepth)
+						}
+						if i.MaxReorgDepth > 0 && depth > i.MaxReorgDepth {
+							err := fmt.Errorf("%w: %d blocks replaced from block %d, max %d", ErrReorgTooDeep, depth, newHeaders.First().Number, i.MaxReorgDepth)
+							i.Logger.Error("Stopping the indexing after a reorg deeper than the max reorg depth, the chain or its provider may be faulty", "depth", depth, "maxReorgDepth", i.MaxReorgDepth, "fromBlock", newHeaders.First().Number, "replacedHead", previousHeader.BlockHash, "newHead", newHeaders.Last().BlockHash)
+							i.fail(err)
+							break loop
+						}
+						i.Logger.Warn("Handled a reorg", "depth", depth, "fromBlock", newHeaders.First().Number, "replacedHead", previousHeader.BlockHash, "newHead", newHeaders.Last().BlockHash)
+					}
+
 					for _, h := range i.Handlers {
 						if h.Status == Good {
 							err := i.HandleAccumulator(h.Acc, h.Filterer, newHeaders)
@@ -175,6 +263,47 @@ func (i Indexer) Index(ctx context.Context) error {
 	return nil
 }
 
+// reorgDepth returns the number of headers up to the previous latest header that were replaced by the new headers,
+// which is 0 if they extend the chain
+func reorgDepth(previousHeader *Header, newHeaders Headers) uint64 {
+	if previousHeader == nil || len(newHeaders) == 0 || newHeaders.First().Number > previousHeader.Number {
+		return 0
+	}
+	return previousHeader.Number - newHeaders.First().Number + 1
+}
+
+// compact prunes the headers of the store older than the retention, and the oldest ones beyond the max entries, if
+// the store supports it
+func (i Indexer) compact() {
+	store, ok := i.HeaderStore.(CompactableHeaderStore)
+	if !ok || (i.RetentionBlocks == 0 && i.MaxEntries <= 0) {
+		return
+	}
+
+	beforeNumber := uint64(0)
+	if i.RetentionBlocks > 0 {
+		latestFinalizedHeader, err := store.GetLatestHeader(true)
+		if errors.Is(err, ErrNoHeaders) {
+			return
+		} else if err != nil {
+			i.Logger.Error("Error getting latest header", "err", err)
+			return
+		}
+		if latestFinalizedHeader.Number > i.RetentionBlocks {
+			beforeNumber = latestFinalizedHeader.Number - i.RetentionBlocks
+		}
+	}
+
+	pruned := store.Prune(beforeNumber, i.MaxEntries)
+	size := store.Size()
+	if pruned > 0 {
+		i.Logger.Debug("Compacted header store", "pruned", pruned, "size", size)
+	}
+	if i.CompactionObserver != nil {
+		i.CompactionObserver.ObserveCompaction(size, pruned)
+	}
+}
+
 func (i Indexer) HandleAccumulator(acc Accumulator, f Filterer, headers Headers) error {
 
 	// Handle fast mode
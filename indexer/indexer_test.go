@@ -0,0 +1,141 @@
+package indexer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigenda/indexer/inmem"
+	"github.com/stretchr/testify/assert"
+)
+
+// reorgingHeaderService serves the headers of a chain whose blocks can be replaced from a number
+type reorgingHeaderService struct {
+	mu    sync.Mutex
+	chain indexer.Headers
+	fork  byte
+}
+
+func newReorgingHeaderService(length int) *reorgingHeaderService {
+	s := &reorgingHeaderService{}
+	s.reorg(1, length)
+	return s
+}
+
+// reorg replaces the blocks from the number by a fork of the length
+func (s *reorgingHeaderService) reorg(from uint64, length int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fork++
+	s.chain = s.chain[:from-1]
+	for number := from; number < from+uint64(length); number++ {
+		header := &indexer.Header{BlockHash: [32]byte{s.fork, byte(number)}, Number: number}
+		if number > 1 {
+			header.PrevBlockHash = s.chain.Last().BlockHash
+		}
+		s.chain = append(s.chain, header)
+	}
+}
+
+func (s *reorgingHeaderService) PullNewHeaders(lastHeader *indexer.Header) (indexer.Headers, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lastHeader.Number >= s.chain.Last().Number {
+		return indexer.Headers{lastHeader}, true, nil
+	}
+	return append(indexer.Headers{}, s.chain[lastHeader.Number:]...), false, nil
+}
+
+func (s *reorgingHeaderService) PullLatestHeader(finalized bool) (*indexer.Header, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chain.Last(), nil
+}
+
+type noUpgrades struct{}
+
+func (noUpgrades) DetectUpgrade(headers indexer.Headers) indexer.Headers { return headers }
+
+func (noUpgrades) GetLatestUpgrade(header *indexer.Header) uint64 { return 0 }
+
+type reorgObserver struct {
+	mu     sync.Mutex
+	depths []uint64
+	max    uint64
+}
+
+func (o *reorgObserver) ObserveReorg(depth uint64, maxDepth uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.depths = append(o.depths, depth)
+	o.max = maxDepth
+}
+
+func (o *reorgObserver) observed() ([]uint64, uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]uint64{}, o.depths...), o.max
+}
+
+func startIndexer(t *testing.T, headerService indexer.HeaderService, headerStore indexer.HeaderStore, maxReorgDepth uint64) (*indexer.Indexer, *reorgObserver) {
+	config := &indexer.Config{PullInterval: time.Millisecond, MaxReorgDepth: maxReorgDepth}
+	i := indexer.NewIndexer(config, nil, headerService, headerStore, noUpgrades{}, &commock.Logger{})
+	observer := &reorgObserver{}
+	i.ReorgObserver = observer
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	assert.NoError(t, i.Index(ctx))
+	return i, observer
+}
+
+func hasLatestHeader(store indexer.HeaderStore, hash [32]byte) func() bool {
+	return func() bool {
+		header, err := store.GetLatestHeader(false)
+		return err == nil && header.BlockHash == hash
+	}
+}
+
+func TestIndexReorg(t *testing.T) {
+	headerService := newReorgingHeaderService(5)
+	headerStore := inmem.NewHeaderStore()
+	i, observer := startIndexer(t, headerService, headerStore, 3)
+	assert.Eventually(t, hasLatestHeader(headerStore, [32]byte{1, 5}), time.Second, time.Millisecond)
+
+	// The blocks 3 to 5 are replaced, and the chain extended to 6
+	headerService.reorg(3, 4)
+	assert.Eventually(t, hasLatestHeader(headerStore, [32]byte{2, 6}), time.Second, time.Millisecond)
+	// The chain is extended without a reorg
+	headerService.mu.Lock()
+	headerService.chain = append(headerService.chain, &indexer.Header{BlockHash: [32]byte{2, 7}, PrevBlockHash: [32]byte{2, 6}, Number: 7})
+	headerService.mu.Unlock()
+	assert.Eventually(t, hasLatestHeader(headerStore, [32]byte{2, 7}), time.Second, time.Millisecond)
+	headerService.reorg(7, 1)
+	assert.Eventually(t, hasLatestHeader(headerStore, [32]byte{3, 7}), time.Second, time.Millisecond)
+
+	depths, maxDepth := observer.observed()
+	assert.Equal(t, []uint64{3, 1}, depths)
+	assert.Equal(t, uint64(3), maxDepth)
+	assert.NoError(t, i.Err())
+}
+
+func TestIndexReorgTooDeep(t *testing.T) {
+	headerService := newReorgingHeaderService(5)
+	headerStore := inmem.NewHeaderStore()
+	i, observer := startIndexer(t, headerService, headerStore, 2)
+	assert.Eventually(t, hasLatestHeader(headerStore, [32]byte{1, 5}), time.Second, time.Millisecond)
+
+	headerService.reorg(3, 3)
+	assert.Eventually(t, func() bool { return i.Err() != nil }, time.Second, time.Millisecond)
+	assert.ErrorIs(t, i.Err(), indexer.ErrReorgTooDeep)
+	assert.ErrorContains(t, i.Err(), "3 blocks replaced from block 3, max 2")
+	depths, _ := observer.observed()
+	assert.Equal(t, []uint64{3}, depths)
+
+	// The indexing stopped, so the blocks after the reorg aren't indexed
+	headerService.reorg(6, 1)
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, hasLatestHeader(headerStore, [32]byte{2, 5})())
+}
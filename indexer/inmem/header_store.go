@@ -2,6 +2,9 @@ package inmem
 
 import (
 	"errors"
+	"maps"
+	"slices"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/indexer"
 )
@@ -18,6 +21,8 @@ type Payloads map[indexer.Accumulator][]byte
 type Header struct {
 	*indexer.Header
 	Payloads Payloads
+	// Origins are the headers the payloads carried forward by a prune were attached at, by accumulator
+	Origins map[indexer.Accumulator]*indexer.Header
 }
 
 func AddPayloads(headers indexer.Headers, payloads Payloads) []*Header {
@@ -43,9 +48,12 @@ type HeaderStore struct {
 	Chain          []*Header
 	IndOffset      int
 	FinalizedIndex int
+
+	// mu guards the chain, which the indexer compacts while it is read
+	mu sync.RWMutex
 }
 
-var _ indexer.HeaderStore = (*HeaderStore)(nil)
+var _ indexer.CompactableHeaderStore = (*HeaderStore)(nil)
 
 func NewHeaderStore() *HeaderStore {
 	return &HeaderStore{
@@ -95,6 +103,8 @@ func (h *HeaderStore) updateFinalizedIndex() {
 
 // Addheaders finds the header  It then crawls along this list of headers until it finds the point of divergence with its existing chain. All new headers from this point of divergence onward are returned.
 func (h *HeaderStore) AddHeaders(headers indexer.Headers) (indexer.Headers, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
 	if len(headers) == 0 {
 		return headers, nil
@@ -133,6 +143,11 @@ func (h *HeaderStore) AddHeaders(headers indexer.Headers) (indexer.Headers, erro
 	}
 
 	newHeaders := AddPayloads(headers[ind:], h.Chain[myInd].Payloads)
+	if origins := h.Chain[myInd].Origins; len(origins) > 0 {
+		for _, newHeader := range newHeaders {
+			newHeader.Origins = maps.Clone(origins)
+		}
+	}
 	h.Chain = append(h.Chain[:myInd+1], newHeaders...)
 	h.updateFinalizedIndex()
 
@@ -142,6 +157,12 @@ func (h *HeaderStore) AddHeaders(headers indexer.Headers) (indexer.Headers, erro
 
 // GetLatestHeader returns the most recent header that the HeaderService has previously pulled
 func (h *HeaderStore) GetLatestHeader(finalized bool) (*indexer.Header, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.getLatestHeader(finalized)
+}
+
+func (h *HeaderStore) getLatestHeader(finalized bool) (*indexer.Header, error) {
 	if len(h.Chain) == 0 {
 		return nil, indexer.ErrNoHeaders
 	}
@@ -160,6 +181,8 @@ func (h *HeaderStore) GetLatestHeader(finalized bool) (*indexer.Header, error) {
 // AttachObject takes an accumulator object and attaches it to a header so that it can be retrieved using GetObject
 func (h *HeaderStore) AttachObject(object indexer.AccumulatorObject, header *indexer.Header, acc indexer.Accumulator,
 ) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 
 	_, ind, err := h.getHeader(header)
 	if err != nil {
@@ -172,13 +195,19 @@ func (h *HeaderStore) AttachObject(object indexer.AccumulatorObject, header *ind
 	}
 
 	h.Chain[ind].Payloads[acc] = data
+	delete(h.Chain[ind].Origins, acc)
 
 	return nil
 }
 
 // GetObject takes in a header and retrieves the accumulator object attached to the latest header prior to the supplied header having the requested object type.
 func (h *HeaderStore) GetObject(header *indexer.Header, acc indexer.Accumulator) (indexer.AccumulatorObject, *indexer.Header, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.getObject(header, acc)
+}
 
+func (h *HeaderStore) getObject(header *indexer.Header, acc indexer.Accumulator) (indexer.AccumulatorObject, *indexer.Header, error) {
 	data, myHeader, found := func() (data []byte, myHeader *Header, found bool) {
 		for ind := int(header.Number); ind >= 0; ind-- {
 
@@ -204,24 +233,93 @@ func (h *HeaderStore) GetObject(header *indexer.Header, acc indexer.Accumulator)
 		return nil, nil, ErrObjectNotFound
 	}
 
-	obj, err := acc.DeserializeObject(data, indexer.UpgradeFork(myHeader.CurrentFork))
+	// An object carried forward by a prune is returned with the header it was attached at
+	origin := myHeader.Header
+	if o, ok := myHeader.Origins[acc]; ok {
+		origin = o
+	}
+	obj, err := acc.DeserializeObject(data, indexer.UpgradeFork(origin.CurrentFork))
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return obj, myHeader.Header, nil
+	return obj, origin, nil
 }
 
 // GetObject retrieves the accumulator object attached to the latest header having the requested object type.
 func (h *HeaderStore) GetLatestObject(acc indexer.Accumulator, finalized bool) (indexer.AccumulatorObject, *indexer.Header, error) {
-	header, err := h.GetLatestHeader(finalized)
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	header, err := h.getLatestHeader(finalized)
 	if err != nil {
 		return nil, nil, err
 	}
-	return h.GetObject(header, acc)
+	return h.getObject(header, acc)
 }
 
 // GetObject retrieves the accumulator object attached to the latest header having the requested object type.
 func (h *HeaderStore) FastForward() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.Chain = make([]*Header, 0)
 }
+
+// Prune removes the finalized headers numbered below beforeNumber and then, if maxEntries is positive, the oldest
+// finalized headers until the chain has at most maxEntries headers. The latest finalized header is always kept. The
+// objects are only attached at the headers of their events, so the latest object of each accumulator attached at a
+// removed header is carried forward onto the oldest remaining header, unless it has its own, and is still found along
+// with the header it was attached at. It returns the number of headers removed.
+func (h *HeaderStore) Prune(beforeNumber uint64, maxEntries int) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.FinalizedIndex >= len(h.Chain) || !h.Chain[h.FinalizedIndex].Finalized {
+		return 0
+	}
+
+	pruned := 0
+	if int(beforeNumber) > h.IndOffset {
+		pruned = int(beforeNumber) - h.IndOffset
+	}
+	if maxEntries > 0 && len(h.Chain)-pruned > maxEntries {
+		pruned = len(h.Chain) - maxEntries
+	}
+	if pruned > h.FinalizedIndex {
+		pruned = h.FinalizedIndex
+	}
+	if pruned == 0 {
+		return 0
+	}
+
+	oldest := h.Chain[pruned]
+	for ind := pruned - 1; ind >= 0; ind-- {
+		for acc, data := range h.Chain[ind].Payloads {
+			if _, ok := oldest.Payloads[acc]; ok {
+				continue
+			}
+			origin, ok := h.Chain[ind].Origins[acc]
+			if !ok {
+				origin = h.Chain[ind].Header
+			}
+			if oldest.Origins == nil {
+				oldest.Origins = make(map[indexer.Accumulator]*indexer.Header)
+			}
+			oldest.Payloads[acc] = data
+			oldest.Origins[acc] = origin
+		}
+	}
+
+	// The chain is copied for the memory of the pruned headers to be released
+	h.Chain = slices.Clone(h.Chain[pruned:])
+	h.IndOffset += pruned
+	h.FinalizedIndex -= pruned
+	return pruned
+}
+
+// Size returns the number of headers of the chain
+func (h *HeaderStore) Size() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.Chain)
+}
@@ -328,3 +328,90 @@ func TestHeaderStore_GetObject(t *testing.T) {
 		})
 	}
 }
+
+func TestHeaderStore_Prune(t *testing.T) {
+	accum := mockAccumulator{}
+	object1 := object{ID: 1000, Name: "object-1"}
+
+	tests := []struct {
+		name           string
+		finalized      int
+		beforeNumber   uint64
+		maxEntries     int
+		expectedPruned int
+	}{
+		{
+			name:           "prune headers before number",
+			finalized:      6,
+			beforeNumber:   17336718,
+			expectedPruned: 3,
+		},
+		{
+			name:           "keep latest finalized header",
+			finalized:      6,
+			beforeNumber:   17336730,
+			expectedPruned: 5,
+		},
+		{
+			name:           "cap entries",
+			finalized:      6,
+			maxEntries:     8,
+			expectedPruned: 2,
+		},
+		{
+			name:           "cap entries keeps unfinalized headers",
+			finalized:      6,
+			beforeNumber:   17336716,
+			maxEntries:     2,
+			expectedPruned: 5,
+		},
+		{
+			name:           "no finalized headers",
+			finalized:      0,
+			beforeNumber:   17336730,
+			maxEntries:     2,
+			expectedPruned: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := newTestHeaders(t, 1)
+			for i := 0; i < tt.finalized; i++ {
+				headers[i].Finalized = true
+			}
+			store := newTestStore(t)
+			_, err := store.AddHeaders(headers)
+			assert.NoError(t, err)
+			// The object is only attached at the header of its event, as the indexer does
+			assert.NoError(t, store.AttachObject(object1, headers[0], accum))
+
+			assert.Equal(t, tt.expectedPruned, store.Prune(tt.beforeNumber, tt.maxEntries))
+			assert.Equal(t, len(headers)-tt.expectedPruned, store.Size())
+			assert.Equal(t, headers[tt.expectedPruned], store.Chain[0].Header)
+
+			// The remaining headers are still found by number
+			latest, err := store.GetLatestHeader(false)
+			assert.NoError(t, err)
+			assert.Equal(t, headers[len(headers)-1], latest)
+			if tt.finalized > 0 {
+				latestFinalized, err := store.GetLatestHeader(true)
+				assert.NoError(t, err)
+				assert.Equal(t, headers[tt.finalized-1], latestFinalized)
+			}
+			// The object attached at a pruned header is still found, with the header it was attached at
+			got, gotHeader, err := store.GetObject(headers[tt.expectedPruned], accum)
+			assert.NoError(t, err)
+			assert.Equal(t, object1, got)
+			assert.Equal(t, headers[0], gotHeader)
+			got, gotHeader, err = store.GetLatestObject(accum, false)
+			assert.NoError(t, err)
+			assert.Equal(t, object1, got)
+			assert.Equal(t, headers[0], gotHeader)
+			if tt.expectedPruned > 0 {
+				_, _, err = store.GetObject(headers[tt.expectedPruned-1], accum)
+				assert.Equal(t, ErrObjectNotFound, err)
+			}
+		})
+	}
+}
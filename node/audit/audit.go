@@ -0,0 +1,343 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/grpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// The kinds of the entries of a batch in the store
+const (
+	EntryBatchHeader = "batch_header"
+	EntryBlobHeader  = "blob_header"
+	// EntryChunks is the bundle of the chunks of a blob in a quorum
+	EntryChunks = "chunks"
+)
+
+// Scope limits the batches an audit goes through
+type Scope struct {
+	// Start and End bound the times the batches were stored at, inclusive. Either is unbounded if it's zero. The
+	// batches without an expiration are only audited if both are unbounded, since their store time is unknown.
+	Start time.Time
+	End   time.Time
+	// BatchHeaderHash limits the audit to the batch, unless it's nil
+	BatchHeaderHash *[32]byte
+	// MaxReadBytesPerSecond bounds the rate the store is read at, unless it's 0
+	MaxReadBytesPerSecond int64
+	// RemoveCorrupt removes the batches with corrupt entries from the store
+	RemoveCorrupt bool
+}
+
+// CorruptEntry is an entry of the store that failed the audit
+type CorruptEntry struct {
+	BatchHeaderHash string
+	// Kind is one of EntryBatchHeader, EntryBlobHeader and EntryChunks. The blob index is only set for the blob
+	// headers and the chunks, and the quorum only for the chunks.
+	Kind      string
+	BlobIndex int
+	QuorumID  core.QuorumID
+	Reason    string
+}
+
+// BatchResult is the audit of the entries of a batch. The passed and failed entries are the bundles of the chunks,
+// along with the headers that failed to be read.
+type BatchResult struct {
+	BatchHeaderHash string
+	// StoredAt is when the batch was stored in seconds since the Unix epoch, or 0 if it has no expiration
+	StoredAt int64
+	Passed   int
+	Failed   int
+	// Removed tells whether the batch was removed from the store
+	Removed bool
+}
+
+// Report summarizes an audit of the store
+type Report struct {
+	Batches []*BatchResult
+	Corrupt []*CorruptEntry
+	// BytesRead is the size of the entries read from the store
+	BytesRead int64
+	// Interrupted tells whether the audit stopped before going through all the batches of its scope
+	Interrupted bool
+}
+
+// Auditor re-verifies the chunks stored by the node against the commitments of their blob headers, and their
+// assignments at the reference blocks of their batches, like they were validated when they were stored
+type Auditor struct {
+	store      *node.Store
+	chainState core.ChainState
+	validator  core.ChunkValidator
+	operatorID core.OperatorID
+	logger     common.Logger
+}
+
+func NewAuditor(store *node.Store, chainState core.ChainState, validator core.ChunkValidator, operatorID core.OperatorID, logger common.Logger) *Auditor {
+	return &Auditor{
+		store:      store,
+		chainState: chainState,
+		validator:  validator,
+		operatorID: operatorID,
+		logger:     logger,
+	}
+}
+
+// Audit goes through the batches of the scope in the order they expire. The corrupt entries are reported rather
+// than failing the audit, which only fails if the store or the chain can't be read.
+func (a *Auditor) Audit(ctx context.Context, scope Scope) (*Report, error) {
+	batches, err := a.store.ListBatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the batches of the store: %w", err)
+	}
+	report := &Report{
+		Batches: make([]*BatchResult, 0),
+		Corrupt: make([]*CorruptEntry, 0),
+	}
+	throttle := newThrottle(scope.MaxReadBytesPerSecond)
+	states := make(map[uint]*core.OperatorState)
+	for _, batch := range batches {
+		if !scope.includes(batch) {
+			continue
+		}
+		if ctx.Err() != nil {
+			report.Interrupted = true
+			break
+		}
+		result, corrupt, err := a.auditBatch(ctx, batch, states, throttle)
+		report.BytesRead = throttle.read
+		if err != nil {
+			if ctx.Err() != nil {
+				report.Interrupted = true
+				break
+			}
+			return nil, err
+		}
+		if len(corrupt) > 0 && scope.RemoveCorrupt {
+			if err := a.store.DeleteBatch(ctx, batch); err != nil {
+				return nil, fmt.Errorf("failed to remove batch %s: %w", result.BatchHeaderHash, err)
+			}
+			result.Removed = true
+			a.logger.Info("Removed the corrupt batch", "batchHeaderHash", result.BatchHeaderHash, "corruptEntries", len(corrupt))
+		}
+		report.Batches = append(report.Batches, result)
+		report.Corrupt = append(report.Corrupt, corrupt...)
+	}
+	if scope.BatchHeaderHash != nil && len(report.Batches) == 0 && !report.Interrupted {
+		return nil, fmt.Errorf("batch %s isn't in the store within the time range", hexutil.Encode(scope.BatchHeaderHash[:]))
+	}
+	return report, nil
+}
+
+func (s Scope) includes(batch node.StoredBatch) bool {
+	if s.BatchHeaderHash != nil && *s.BatchHeaderHash != batch.BatchHeaderHash {
+		return false
+	}
+	if (!s.Start.IsZero() || !s.End.IsZero()) && batch.ExpirationTime == 0 {
+		return false
+	}
+	if !s.Start.IsZero() && batch.StoredAt < s.Start.Unix() {
+		return false
+	}
+	return s.End.IsZero() || batch.StoredAt <= s.End.Unix()
+}
+
+// auditBatch returns the audit of the batch and its corrupt entries. The operator states are cached by reference
+// block across the batches.
+func (a *Auditor) auditBatch(ctx context.Context, batch node.StoredBatch, states map[uint]*core.OperatorState, throttle *throttle) (*BatchResult, []*CorruptEntry, error) {
+	hash := hexutil.Encode(batch.BatchHeaderHash[:])
+	result := &BatchResult{BatchHeaderHash: hash, StoredAt: batch.StoredAt}
+	corrupt := make([]*CorruptEntry, 0)
+	fail := func(entry *CorruptEntry) {
+		entry.BatchHeaderHash = hash
+		corrupt = append(corrupt, entry)
+		result.Failed++
+	}
+
+	headerBytes, err := a.store.GetBatchHeader(ctx, batch.BatchHeaderHash)
+	if err != nil && !errors.Is(err, node.ErrKeyNotFound) {
+		return nil, nil, err
+	}
+	if err := throttle.wait(ctx, len(headerBytes)); err != nil {
+		return nil, nil, err
+	}
+	header, err := decodeBatchHeader(headerBytes, batch.BatchHeaderHash)
+	if err != nil {
+		// The assignments of the chunks can't be checked without the reference block of the batch
+		fail(&CorruptEntry{Kind: EntryBatchHeader, Reason: err.Error()})
+		return result, corrupt, nil
+	}
+
+	state, ok := states[header.ReferenceBlockNumber]
+	if !ok {
+		state, err = a.chainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, a.operatorID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get the operator state at block %d of batch %s: %w", header.ReferenceBlockNumber, hash, err)
+		}
+		states[header.ReferenceBlockNumber] = state
+	}
+
+	for blobIndex := 0; ; blobIndex++ {
+		blobHeaderBytes, err := a.store.GetBlobHeader(ctx, batch.BatchHeaderHash, blobIndex)
+		if errors.Is(err, node.ErrKeyNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := throttle.wait(ctx, len(blobHeaderBytes)); err != nil {
+			return nil, nil, err
+		}
+		blobHeader, err := decodeBlobHeader(blobHeaderBytes)
+		if err != nil {
+			fail(&CorruptEntry{Kind: EntryBlobHeader, BlobIndex: blobIndex, Reason: err.Error()})
+			continue
+		}
+
+		for _, quorumInfo := range blobHeader.QuorumInfos {
+			chunks, ok := a.store.GetChunks(ctx, batch.BatchHeaderHash, blobIndex, quorumInfo.QuorumID)
+			if !ok {
+				fail(&CorruptEntry{Kind: EntryChunks, BlobIndex: blobIndex, QuorumID: quorumInfo.QuorumID, Reason: "missing or unreadable chunks"})
+				continue
+			}
+			size := 0
+			for _, chunk := range chunks {
+				size += 8 + len(chunk)
+			}
+			if err := throttle.wait(ctx, size); err != nil {
+				return nil, nil, err
+			}
+			if err := a.verifyChunks(blobHeader, quorumInfo, chunks, state); err != nil {
+				fail(&CorruptEntry{Kind: EntryChunks, BlobIndex: blobIndex, QuorumID: quorumInfo.QuorumID, Reason: err.Error()})
+				continue
+			}
+			result.Passed++
+		}
+	}
+	return result, corrupt, nil
+}
+
+// verifyChunks validates the chunks of the quorum of the blob like they were validated when stored, by validating
+// the blob restricted to the quorum
+func (a *Auditor) verifyChunks(blobHeader *core.BlobHeader, quorumInfo *core.BlobQuorumInfo, chunks [][]byte, state *core.OperatorState) error {
+	bundle := make(core.Bundle, len(chunks))
+	for i, data := range chunks {
+		chunk, err := new(core.Chunk).Deserialize(data)
+		if err != nil {
+			return fmt.Errorf("failed to decode chunk %d: %w", i, err)
+		}
+		bundle[i] = chunk
+	}
+	quorumHeader := *blobHeader
+	quorumHeader.QuorumInfos = []*core.BlobQuorumInfo{quorumInfo}
+	return a.validator.ValidateBlob(&core.BlobMessage{
+		BlobHeader: &quorumHeader,
+		Bundles:    core.Bundles{quorumInfo.QuorumID: bundle},
+	}, state)
+}
+
+// decodeBatchHeader decodes the stored batch header, and checks that it's the header of the hash it's stored at
+func decodeBatchHeader(data []byte, batchHeaderHash [32]byte) (*core.BatchHeader, error) {
+	if data == nil {
+		return nil, errors.New("missing batch header")
+	}
+	header, err := new(core.BatchHeader).Deserialize(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the batch header: %w", err)
+	}
+	hash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	if hash != batchHeaderHash {
+		return nil, fmt.Errorf("the batch header hashes to %s", hexutil.Encode(hash[:]))
+	}
+	return header, nil
+}
+
+func decodeBlobHeader(data []byte) (*core.BlobHeader, error) {
+	var protoBlobHeader pb.BlobHeader
+	if err := proto.Unmarshal(data, &protoBlobHeader); err != nil {
+		return nil, fmt.Errorf("failed to decode the blob header: %w", err)
+	}
+	blobHeader, err := grpc.GetBlobHeaderFromProto(&protoBlobHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the blob header: %w", err)
+	}
+	return blobHeader, nil
+}
+
+// throttle bounds the rate of the reads from the store, so that the audit leaves IO to the node sharing the disk
+type throttle struct {
+	bytesPerSecond int64
+	start          time.Time
+	read           int64
+}
+
+func newThrottle(bytesPerSecond int64) *throttle {
+	return &throttle{bytesPerSecond: bytesPerSecond, start: time.Now()}
+}
+
+// wait counts the bytes read, and blocks until they are within the rate since the start of the audit
+func (t *throttle) wait(ctx context.Context, n int) error {
+	t.read += int64(n)
+	if t.bytesPerSecond <= 0 {
+		return nil
+	}
+	due := t.start.Add(time.Duration(float64(t.read) / float64(t.bytesPerSecond) * float64(time.Second)))
+	delay := time.Until(due)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Run audits the scope at every interval until the context is done, from within the node so that the store is
+// audited while the node runs. The corrupt entries of each audit are logged.
+func (a *Auditor) Run(ctx context.Context, interval time.Duration, scope Scope) {
+	a.logger.Info("Auditing the store periodically", "interval", interval, "maxReadBytesPerSecond", scope.MaxReadBytesPerSecond, "removeCorrupt", scope.RemoveCorrupt)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		start := time.Now()
+		report, err := a.Audit(ctx, scope)
+		if err != nil {
+			a.logger.Error("Failed to audit the store", "err", err)
+			continue
+		}
+		for _, entry := range report.Corrupt {
+			a.logger.Warn("Found a corrupt entry in the store", "batchHeaderHash", entry.BatchHeaderHash, "kind", entry.Kind, "blobIndex", entry.BlobIndex, "quorum", entry.QuorumID, "reason", entry.Reason)
+		}
+		passed, failed := report.Totals()
+		a.logger.Info("Audited the store", "batches", len(report.Batches), "passed", passed, "failed", failed, "bytesRead", report.BytesRead, "interrupted", report.Interrupted, "duration", time.Since(start))
+	}
+}
+
+// Totals returns the numbers of the passed and failed entries across the batches
+func (r *Report) Totals() (int, int) {
+	passed, failed := 0, 0
+	for _, batch := range r.Batches {
+		passed += batch.Passed
+		failed += batch.Failed
+	}
+	return passed, failed
+}
@@ -0,0 +1,242 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/audit"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+var operatorID = core.OperatorID{3}
+
+// chainState returns a state of the operator for each block it's set up with
+type chainState struct {
+	core.ChainState
+	states map[uint]*core.OperatorState
+}
+
+func (s *chainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	state, ok := s.states[blockNumber]
+	if !ok {
+		return nil, fmt.Errorf("no state at block %d", blockNumber)
+	}
+	return state, nil
+}
+
+// validator fails the blobs of the lengths it's set up with, and records the blobs it validates
+type validator struct {
+	core.ChunkValidator
+	invalidLengths map[uint]bool
+	validated      []*core.BlobMessage
+}
+
+func (v *validator) ValidateBlob(blob *core.BlobMessage, state *core.OperatorState) error {
+	v.validated = append(v.validated, blob)
+	if v.invalidLengths[blob.BlobHeader.BlobCommitments.Length] {
+		return errors.New("invalid chunks")
+	}
+	return nil
+}
+
+func newTestStore(t *testing.T) *node.Store {
+	reg := prometheus.NewRegistry()
+	store, err := node.NewLevelDBStore(t.TempDir(), &mock.Logger{}, node.NewMetrics(metrics.NewNoopMetrics(), reg, &mock.Logger{}, ":9090"), 1, 1)
+	assert.NoError(t, err)
+	return store
+}
+
+// storeBatch stores a batch of the reference block with a blob of each of the lengths, each with a chunk in
+// quorums 0 and 1, and returns its batch header hash
+func storeBatch(t *testing.T, store *node.Store, referenceBlockNumber uint, lengths ...uint) [32]byte {
+	var commitment bn254.G1Point
+	serializedCommitment, err := core.Commitment{G1Point: &commitment}.Serialize()
+	assert.NoError(t, err)
+
+	header := &core.BatchHeader{ReferenceBlockNumber: referenceBlockNumber}
+	blobs := make([]*core.BlobMessage, 0)
+	blobsProto := make([]*pb.Blob, 0)
+	for _, length := range lengths {
+		blob := &core.BlobMessage{
+			BlobHeader: &core.BlobHeader{
+				BlobCommitments: core.BlobCommitments{
+					Commitment:  &core.Commitment{G1Point: &commitment},
+					LengthProof: &core.Commitment{G1Point: &commitment},
+					Length:      length,
+				},
+			},
+			Bundles: core.Bundles{},
+		}
+		blobProto := &pb.Blob{Header: &pb.BlobHeader{Commitment: serializedCommitment, LengthProof: serializedCommitment, Length: uint32(length)}}
+		for _, quorum := range []core.QuorumID{0, 1} {
+			blob.BlobHeader.QuorumInfos = append(blob.BlobHeader.QuorumInfos, &core.BlobQuorumInfo{
+				SecurityParam:      core.SecurityParam{QuorumID: quorum, AdversaryThreshold: 50, QuorumThreshold: 80},
+				QuantizationFactor: 1,
+			})
+			blob.Bundles[quorum] = []*core.Chunk{{Proof: commitment, Coeffs: []core.Symbol{}}}
+			blobProto.Header.QuorumHeaders = append(blobProto.Header.QuorumHeaders, &pb.BlobQuorumInfo{
+				QuorumId:           uint32(quorum),
+				AdversaryThreshold: 50,
+				QuorumThreshold:    80,
+				QuantizationFactor: 1,
+			})
+		}
+		blobs = append(blobs, blob)
+		blobsProto = append(blobsProto, blobProto)
+	}
+	_, err = store.StoreBatch(context.Background(), header, blobs, blobsProto)
+	assert.NoError(t, err)
+	hash, err := header.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	return hash
+}
+
+// resultsByHash returns the results of the batches of the report by batch header hash
+func resultsByHash(report *audit.Report) map[string]*audit.BatchResult {
+	results := make(map[string]*audit.BatchResult)
+	for _, result := range report.Batches {
+		results[result.BatchHeaderHash] = result
+	}
+	return results
+}
+
+func newTestAuditor(store *node.Store) (*audit.Auditor, *validator) {
+	states := map[uint]*core.OperatorState{10: {BlockNumber: 10}, 20: {BlockNumber: 20}, 30: {BlockNumber: 30}}
+	chunkValidator := &validator{invalidLengths: map[uint]bool{50: true}}
+	return audit.NewAuditor(store, &chainState{states: states}, chunkValidator, operatorID, &mock.Logger{}), chunkValidator
+}
+
+func TestAudit(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	valid := storeBatch(t, store, 10, 48, 49)
+	corrupt := storeBatch(t, store, 20, 48, 50)
+	missing := storeBatch(t, store, 30, 48)
+	// The chunks of the quorum 1 of the blob are lost
+	missingKey, err := node.EncodeBlobKey(missing, 0, 1)
+	assert.NoError(t, err)
+	assert.True(t, store.DeleteKeys(ctx, &[][]byte{missingKey}))
+
+	auditor, chunkValidator := newTestAuditor(store)
+	report, err := auditor.Audit(ctx, audit.Scope{})
+	assert.NoError(t, err)
+	assert.False(t, report.Interrupted)
+	assert.Greater(t, report.BytesRead, int64(0))
+
+	// The batches stored in the same second share an expiration key, and the ones it leaves out of the expiration
+	// index are audited as well
+	assert.Len(t, report.Batches, 3)
+	results := resultsByHash(report)
+	assert.Equal(t, 4, results[hexutil.Encode(valid[:])].Passed)
+	assert.Equal(t, 0, results[hexutil.Encode(valid[:])].Failed)
+	assert.Equal(t, 2, results[hexutil.Encode(corrupt[:])].Passed)
+	assert.Equal(t, 2, results[hexutil.Encode(corrupt[:])].Failed)
+	assert.Equal(t, 1, results[hexutil.Encode(missing[:])].Passed)
+	assert.Equal(t, 1, results[hexutil.Encode(missing[:])].Failed)
+	passed, failed := report.Totals()
+	assert.Equal(t, 7, passed)
+	assert.Equal(t, 3, failed)
+	assert.ElementsMatch(t, []*audit.CorruptEntry{
+		{BatchHeaderHash: hexutil.Encode(corrupt[:]), Kind: audit.EntryChunks, BlobIndex: 1, QuorumID: 0, Reason: "invalid chunks"},
+		{BatchHeaderHash: hexutil.Encode(corrupt[:]), Kind: audit.EntryChunks, BlobIndex: 1, QuorumID: 1, Reason: "invalid chunks"},
+		{BatchHeaderHash: hexutil.Encode(missing[:]), Kind: audit.EntryChunks, BlobIndex: 0, QuorumID: 1, Reason: "missing or unreadable chunks"},
+	}, report.Corrupt)
+
+	// The chunks are validated one quorum at a time
+	assert.Len(t, chunkValidator.validated, 9)
+	for _, blob := range chunkValidator.validated {
+		assert.Len(t, blob.BlobHeader.QuorumInfos, 1)
+		assert.Len(t, blob.Bundles, 1)
+		assert.Len(t, blob.Bundles[blob.BlobHeader.QuorumInfos[0].QuorumID], 1)
+	}
+
+	// Nothing is removed unless asked
+	for _, result := range report.Batches {
+		assert.False(t, result.Removed)
+	}
+	batches, err := store.ListBatches(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+}
+
+func TestAuditScope(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	valid := storeBatch(t, store, 10, 48)
+	// The batches are stored in different seconds so that they all have an expiration
+	time.Sleep(time.Second)
+	corrupt := storeBatch(t, store, 20, 50)
+	auditor, _ := newTestAuditor(store)
+
+	// The batch of the hash
+	report, err := auditor.Audit(ctx, audit.Scope{BatchHeaderHash: &valid})
+	assert.NoError(t, err)
+	assert.Len(t, report.Batches, 1)
+	assert.Equal(t, hexutil.Encode(valid[:]), report.Batches[0].BatchHeaderHash)
+	_, err = auditor.Audit(ctx, audit.Scope{BatchHeaderHash: &[32]byte{1}})
+	assert.ErrorContains(t, err, "isn't in the store")
+
+	// The batches stored within the time range, in the order they were stored
+	report, err = auditor.Audit(ctx, audit.Scope{Start: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+	assert.Len(t, report.Batches, 2)
+	assert.Equal(t, hexutil.Encode(valid[:]), report.Batches[0].BatchHeaderHash)
+	assert.Equal(t, hexutil.Encode(corrupt[:]), report.Batches[1].BatchHeaderHash)
+	report, err = auditor.Audit(ctx, audit.Scope{End: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+	assert.Empty(t, report.Batches)
+
+	// The corrupt batch is removed along with its expiration
+	report, err = auditor.Audit(ctx, audit.Scope{RemoveCorrupt: true})
+	assert.NoError(t, err)
+	results := resultsByHash(report)
+	assert.False(t, results[hexutil.Encode(valid[:])].Removed)
+	assert.True(t, results[hexutil.Encode(corrupt[:])].Removed)
+	assert.False(t, store.HasKey(ctx, node.EncodeBatchHeaderKey(corrupt)))
+	chunksKey, err := node.EncodeBlobKey(corrupt, 0, 0)
+	assert.NoError(t, err)
+	assert.False(t, store.HasKey(ctx, chunksKey))
+	batches, err := store.ListBatches(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, valid, batches[0].BatchHeaderHash)
+	assert.NotZero(t, batches[0].ExpirationTime)
+
+	// A bounded read rate slows the audit down
+	start := time.Now()
+	report, err = auditor.Audit(ctx, audit.Scope{MaxReadBytesPerSecond: 1000})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Duration(float64(report.BytesRead)/1000*float64(time.Second))-100*time.Millisecond)
+}
+
+func TestAuditRun(t *testing.T) {
+	store := newTestStore(t)
+	valid := storeBatch(t, store, 10, 48)
+	storeBatch(t, store, 20, 50)
+	auditor, _ := newTestAuditor(store)
+
+	// The corrupt batch is removed by the periodic audits, which stop with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		auditor.Run(ctx, 10*time.Millisecond, audit.Scope{RemoveCorrupt: true})
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		batches, err := store.ListBatches(context.Background())
+		return err == nil && len(batches) == 1 && batches[0].BatchHeaderHash == valid
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+}
@@ -0,0 +1,187 @@
+package node
+
+import (
+	"math"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// chunkCache holds the encoded chunks of the blob quorums of the validated batches read or stored last, up to a total
+// size in bytes, so that the retrievals of the hot batches aren't served from the database. The entries are keyed by
+// the database keys of the chunks, i.e. by batch header hash, blob index and quorum, and the least recently used ones
+// are evicted first.
+//
+// The chunks of a batch are staged while the batch is being validated, and are only cached once it's validated, so
+// that the chunks of a batch that fails the validation and is rolled back are never cached, including the ones read
+// while it's being validated. The chunks of the last numBatches validated batches are cached as soon as they're
+// validated, and the ones of the other batches as they're read.
+type chunkCache struct {
+	mu    sync.Mutex
+	cache *simplelru.LRU[string, *cachedChunks]
+	// size is the total size in bytes of the cached chunks
+	size    uint64
+	maxSize uint64
+
+	// staged are the batches being validated, by batch header hash
+	staged map[[32]byte]*stagedBatch
+	// warmBatches are the keys of the chunks cached on the validation of the last batches, oldest first
+	warmBatches [][]string
+	numBatches  int
+
+	metrics *Metrics
+}
+
+type cachedChunks struct {
+	chunks [][]byte
+	size   uint64
+	// read tells whether the chunks were read since they were cached
+	read bool
+}
+
+// stagedBatch holds the chunks of a batch being validated, which are cached once it's validated. They are only held
+// if the batch is cached on validation.
+type stagedBatch struct {
+	keys   []string
+	chunks [][][]byte
+}
+
+func newChunkCache(maxSize uint64, numBatches int, metrics *Metrics) *chunkCache {
+	c := &chunkCache{
+		maxSize:    maxSize,
+		staged:     make(map[[32]byte]*stagedBatch),
+		numBatches: numBatches,
+		metrics:    metrics,
+	}
+	// The cache is bounded by the size of the chunks rather than by their number of blob quorums
+	c.cache, _ = simplelru.NewLRU[string, *cachedChunks](math.MaxInt, func(key string, entry *cachedChunks) {
+		c.size -= entry.size
+	})
+	return c
+}
+
+func encodedChunksSize(chunks [][]byte) uint64 {
+	size := uint64(0)
+	for _, chunk := range chunks {
+		size += uint64(len(chunk))
+	}
+	return size
+}
+
+// batchOf returns the batch header hash of the key of the chunks of a blob quorum
+func batchOf(key []byte) ([32]byte, bool) {
+	var batchHeaderHash [32]byte
+	if len(key) < len(batchHeaderHash) {
+		return batchHeaderHash, false
+	}
+	copy(batchHeaderHash[:], key)
+	return batchHeaderHash, true
+}
+
+// get returns the cached chunks of the key, which must not be modified
+func (c *chunkCache) get(key []byte) ([][]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache.Get(string(key))
+	if !ok {
+		return nil, false
+	}
+	entry.read = true
+	return entry.chunks, true
+}
+
+// add caches the chunks of the key read from the database, unless their batch is being validated
+func (c *chunkCache) add(key []byte, chunks [][]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if batchHeaderHash, ok := batchOf(key); ok {
+		if _, ok := c.staged[batchHeaderHash]; ok {
+			return
+		}
+	}
+	c.insert(string(key), chunks, true)
+}
+
+// insert caches the chunks of the key, unless they're larger than the cache. The cache must be locked.
+func (c *chunkCache) insert(key string, chunks [][]byte, read bool) {
+	size := encodedChunksSize(chunks)
+	if size > c.maxSize {
+		return
+	}
+	// The entry is replaced, which evicts the chunks cached before
+	c.cache.Remove(key)
+	c.cache.Add(key, &cachedChunks{chunks: chunks, size: size, read: read})
+	c.size += size
+	for c.size > c.maxSize {
+		c.cache.RemoveOldest()
+	}
+	c.metrics.SetChunkCacheSize(c.size)
+}
+
+// stage holds the chunks of the batch until it's validated, and keeps its chunks from being cached as they're read
+// until then
+func (c *chunkCache) stage(batchHeaderHash [32]byte, keys [][]byte, chunks [][][]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	batch := &stagedBatch{}
+	size := uint64(0)
+	for _, bundle := range chunks {
+		size += encodedChunksSize(bundle)
+	}
+	if c.numBatches > 0 && size <= c.maxSize {
+		batch.chunks = chunks
+		for _, key := range keys {
+			batch.keys = append(batch.keys, string(key))
+		}
+	}
+	c.staged[batchHeaderHash] = batch
+}
+
+// unstage drops the chunks of a batch that failed to be stored or validated
+func (c *chunkCache) unstage(batchHeaderHash [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.staged, batchHeaderHash)
+}
+
+// commit caches the chunks of the batch once it's validated, along with the ones of the previous validated batches.
+// The chunks of the batch that leaves the last numBatches ones are evicted, unless they were read since.
+func (c *chunkCache) commit(batchHeaderHash [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	batch, ok := c.staged[batchHeaderHash]
+	if !ok {
+		return
+	}
+	delete(c.staged, batchHeaderHash)
+	if len(batch.keys) == 0 {
+		return
+	}
+
+	for i, key := range batch.keys {
+		c.insert(key, batch.chunks[i], false)
+	}
+	c.warmBatches = append(c.warmBatches, batch.keys)
+	for len(c.warmBatches) > c.numBatches {
+		for _, key := range c.warmBatches[0] {
+			if entry, ok := c.cache.Peek(key); ok && !entry.read {
+				c.cache.Remove(key)
+			}
+		}
+		c.warmBatches = c.warmBatches[1:]
+	}
+	c.metrics.SetChunkCacheSize(c.size)
+}
+
+// remove evicts the chunks of the keys deleted from the database, and drops the batches of the keys being validated
+func (c *chunkCache) remove(keys [][]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		c.cache.Remove(string(key))
+		if batchHeaderHash, ok := batchOf(key); ok {
+			delete(c.staged, batchHeaderHash)
+		}
+	}
+	c.metrics.SetChunkCacheSize(c.size)
+}
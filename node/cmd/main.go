@@ -2,20 +2,24 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/pubip"
 
 	"github.com/urfave/cli"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/audit"
 	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigenda/node/grpc"
 )
@@ -29,7 +33,11 @@ var (
 func main() {
 	app := cli.NewApp()
 	app.Flags = flags.Flags
-	app.Version = fmt.Sprintf("%s-%s-%s", node.SemVer, node.GitCommit, node.GitDate)
+	configfile.Enable(app)
+	version.Configure(app)
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return node.NewConfig(ctx)
+	})
 	app.Name = node.AppName
 	app.Usage = "EigenDA Node"
 	app.Description = "Service for receiving and storing encoded blobs from disperser"
@@ -39,8 +47,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func NodeMain(ctx *cli.Context) error {
@@ -54,6 +60,29 @@ func NodeMain(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	logging.CycleLevelOnSignal(logger)
+
+	// The servers of the node run in the background until the process exits, so the traces are exported as
+	// they are batched rather than flushed on shutdown
+	if _, err := tracing.Start(context.Background(), config.TracingConfig, "node"); err != nil {
+		return err
+	}
+
+	logger.Info("Starting node", version.LogFields()...)
+	profiling.PublishBuildInfo()
+	profiling.Publish("config", map[string]any{
+		"hostname":                   config.Hostname,
+		"dispersal_port":             config.DispersalPort,
+		"retrieval_port":             config.RetrievalPort,
+		"quorum_id_list":             config.QuorumIDList,
+		"num_batch_validators":       config.NumBatchValidators,
+		"grpc_compression_threshold": config.GrpcCompressionThreshold,
+		"expiration_poll_interval":   config.ExpirationPollIntervalSec,
+		"audit_interval":             config.AuditInterval.String(),
+	})
+	if err := profiling.Start(context.Background(), config.ProfilingConfig, logger); err != nil {
+		return err
+	}
 
 	pubIPProvider := pubip.ProviderOrDefault(config.PubIPProvider)
 
@@ -69,6 +98,15 @@ func NodeMain(ctx *cli.Context) error {
 		return err
 	}
 
+	// The store is audited from within the node, which holds the lock of the database
+	if config.AuditInterval > 0 {
+		auditor := audit.NewAuditor(node.Store, node.ChainState, node.Validator, config.ID, logger)
+		go auditor.Run(context.Background(), config.AuditInterval, audit.Scope{
+			MaxReadBytesPerSecond: config.AuditMaxReadBytesPerSecond,
+			RemoveCorrupt:         config.AuditRemoveCorrupt,
+		})
+	}
+
 	globalParams := common.GlobalRateParams{
 		BucketSizes: []time.Duration{bucketDuration},
 		Multipliers: []float32{bucketMultiplier},
@@ -84,7 +122,10 @@ func NodeMain(ctx *cli.Context) error {
 
 	// Creates the GRPC server.
 	server := grpc.NewServer(config, node, logger, ratelimiter)
-	server.Start()
+	if err := server.Start(); err != nil {
+		return err
+	}
 
-	return nil
+	// The servers run in the background until the process exits
+	select {}
 }
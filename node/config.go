@@ -9,12 +9,17 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/common/validation"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli"
 )
@@ -23,9 +28,12 @@ const (
 	// Min number of seconds for the ExpirationPollIntervalSecFlag.
 	minExpirationPollIntervalSec = 3
 	AppName                      = "da-node"
-	SemVer                       = "0.0.1"
-	GitCommit                    = ""
-	GitDate                      = ""
+)
+
+// Bounds of the timeout of the gRPC requests
+const (
+	minTimeout = 100 * time.Millisecond
+	maxTimeout = time.Hour
 )
 
 var (
@@ -66,16 +74,44 @@ type Config struct {
 	ChurnerUrl                    string
 	NumBatchValidators            int
 	ClientIPHeader                string
+	GrpcCompressionThreshold      int
 	UseSecureGrpc                 bool
+	// ChunkCacheSize is the size in bytes of the cache of the chunks served to the retrievals, which is disabled if
+	// it's 0. The chunks of the last ChunkCacheBatches batches are cached once they're validated.
+	ChunkCacheSize    uint64
+	ChunkCacheBatches int
+	// AuditInterval is the interval at which the store is audited, or 0 if it isn't. The audits read the store at up
+	// to AuditMaxReadBytesPerSecond unless it's 0, and remove the corrupt batches if AuditRemoveCorrupt is set.
+	AuditInterval              time.Duration
+	AuditMaxReadBytesPerSecond int64
+	AuditRemoveCorrupt         bool
+	// ChunkCaps caps the chunks assigned to the operators, which must be the same as the ones of the batcher
+	ChunkCaps core.ChunkCaps
+	// TLSConfig is nil if the dispersal and retrieval servers are plaintext
+	TLSConfig *grpcsec.Config
+
+	// The requests to store chunks are accepted if they are signed by one of the AuthorizedDispersers, or by the
+	// disperser the DisperserRegistryAddr returns, as enforced by the DispersalAuthentication mode
+	DispersalAuthentication  DispersalAuthenticationMode
+	AuthorizedDispersers     []gethcommon.Address
+	DisperserRegistryAddr    string
+	DisperserRegistryMethod  string
+	DisperserRefreshInterval time.Duration
 
 	EthClientConfig geth.EthClientConfig
 	LoggingConfig   logging.Config
+	TracingConfig   tracing.Config
+	ProfilingConfig profiling.Config
 	EncoderConfig   encoding.EncoderConfig
 }
 
 // NewConfig parses the Config from the provided flags or environment variables and
 // returns a Config.
 func NewConfig(ctx *cli.Context) (*Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return nil, err
+	}
+
 	timeout, err := time.ParseDuration(ctx.GlobalString(flags.TimeoutFlag.Name))
 	if err != nil {
 		return &Config{}, err
@@ -136,6 +172,16 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		internalRetrievalFlag = ctx.GlobalString(flags.RetrievalPortFlag.Name)
 	}
 
+	tlsConfig, err := grpcsec.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var authorizedDispersers []gethcommon.Address
+	for _, address := range splitList(ctx.GlobalString(flags.AuthorizedDispersersFlag.Name)) {
+		authorizedDispersers = append(authorizedDispersers, gethcommon.HexToAddress(address))
+	}
+
 	return &Config{
 		Hostname:                      ctx.GlobalString(flags.HostnameFlag.Name),
 		DispersalPort:                 ctx.GlobalString(flags.DispersalPortFlag.Name),
@@ -158,6 +204,9 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		EthClientConfig:               ethClientConfig,
 		EncoderConfig:                 encoding.ReadCLIConfig(ctx),
 		LoggingConfig:                 logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+		TracingConfig:                 tracing.ReadCLIConfig(ctx, flags.FlagPrefix),
+		ProfilingConfig:               profiling.ReadCLIConfig(ctx, flags.FlagPrefix),
+		ChunkCaps:                     core.ReadChunkCapsCLIConfig(ctx, flags.FlagPrefix),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		PubIPProvider:                 ctx.GlobalString(flags.PubIPProviderFlag.Name),
@@ -165,6 +214,105 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		ChurnerUrl:                    ctx.GlobalString(flags.ChurnerUrlFlag.Name),
 		NumBatchValidators:            ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name),
 		ClientIPHeader:                ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
+		GrpcCompressionThreshold:      ctx.GlobalInt(flags.GrpcCompressionThresholdFlag.Name),
 		UseSecureGrpc:                 !testMode,
+		TLSConfig:                     tlsConfig,
+		ChunkCacheSize:                ctx.GlobalUint64(flags.ChunkCacheSizeFlag.Name),
+		ChunkCacheBatches:             ctx.GlobalInt(flags.ChunkCacheBatchesFlag.Name),
+		AuditInterval:                 ctx.GlobalDuration(flags.AuditIntervalFlag.Name),
+		AuditMaxReadBytesPerSecond:    ctx.GlobalInt64(flags.AuditMaxReadRateFlag.Name),
+		AuditRemoveCorrupt:            ctx.GlobalBool(flags.AuditRemoveCorruptFlag.Name),
+
+		DispersalAuthentication:  DispersalAuthenticationMode(ctx.GlobalString(flags.DispersalAuthenticationFlag.Name)),
+		AuthorizedDispersers:     authorizedDispersers,
+		DisperserRegistryAddr:    ctx.GlobalString(flags.DisperserRegistryFlag.Name),
+		DisperserRegistryMethod:  ctx.GlobalString(flags.DisperserRegistryMethodFlag.Name),
+		DisperserRefreshInterval: ctx.GlobalDuration(flags.DisperserRefreshIntervalFlag.Name),
 	}, nil
 }
+
+// validateFlags checks the ports, contracts, timeouts, key files and dispersal authentication before the keys are read
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	ports := []cli.StringFlag{flags.DispersalPortFlag, flags.RetrievalPortFlag}
+	for _, flag := range []cli.StringFlag{flags.InternalDispersalPortFlag, flags.InternalRetrievalPortFlag} {
+		if ctx.GlobalString(flag.Name) != "" {
+			ports = append(ports, flag)
+		}
+	}
+	if ctx.GlobalBool(flags.EnableNodeApiFlag.Name) {
+		ports = append(ports, flags.NodeApiPortFlag)
+	}
+	if ctx.GlobalBool(flags.EnableMetricsFlag.Name) {
+		ports = append(ports, flags.MetricsPortFlag)
+	}
+	for _, flag := range ports {
+		v.Add(validation.Port(flag.Name, ctx.GlobalString(flag.Name)))
+	}
+
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+
+	if timeout, err := time.ParseDuration(ctx.GlobalString(flags.TimeoutFlag.Name)); err != nil {
+		v.Addf("%s: %q is not a duration", flags.TimeoutFlag.Name, ctx.GlobalString(flags.TimeoutFlag.Name))
+	} else {
+		v.Add(validation.Range(flags.TimeoutFlag.Name, timeout, minTimeout, maxTimeout))
+	}
+	v.Add(validation.AtLeast(flags.PubIPCheckIntervalFlag.Name, ctx.GlobalDuration(flags.PubIPCheckIntervalFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.NumBatchValidatorsFlag.Name, ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name), 1))
+	v.Add(validation.AtLeast(flags.GrpcCompressionThresholdFlag.Name, ctx.GlobalInt(flags.GrpcCompressionThresholdFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.ChunkCacheBatchesFlag.Name, ctx.GlobalInt(flags.ChunkCacheBatchesFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.AuditIntervalFlag.Name, ctx.GlobalDuration(flags.AuditIntervalFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.AuditMaxReadRateFlag.Name, ctx.GlobalInt64(flags.AuditMaxReadRateFlag.Name), 0))
+
+	if !ctx.GlobalBool(flags.EnableTestModeFlag.Name) {
+		v.Add(validation.ReadableFile(flags.EcdsaKeyFileFlag.Name, ctx.GlobalString(flags.EcdsaKeyFileFlag.Name)))
+		v.Add(validation.ReadableFile(flags.BlsKeyFileFlag.Name, ctx.GlobalString(flags.BlsKeyFileFlag.Name)))
+	}
+	switch mode := DispersalAuthenticationMode(ctx.GlobalString(flags.DispersalAuthenticationFlag.Name)); mode {
+	case DispersalAuthenticationDisabled:
+	case DispersalAuthenticationWarn, DispersalAuthenticationEnforce:
+		v.Add(validateDisperserFlags(ctx))
+	default:
+		v.Addf("%s: %q is not one of %s, %s or %s", flags.DispersalAuthenticationFlag.Name, mode, DispersalAuthenticationEnforce, DispersalAuthenticationWarn, DispersalAuthenticationDisabled)
+	}
+	v.Add(grpcsec.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(core.ValidateChunkCapsCLIFlags(ctx, flags.FlagPrefix))
+	if err := encoding.ValidateConfig(encoding.ReadCLIConfig(ctx)); err != nil {
+		v.Add(fmt.Errorf("invalid encoding config: %w", err))
+	}
+	return v.Err()
+}
+
+// validateDisperserFlags checks that the dispersal requests can be authenticated with the authorized dispersers or
+// the disperser registry
+func validateDisperserFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	dispersers := splitList(ctx.GlobalString(flags.AuthorizedDispersersFlag.Name))
+	for _, address := range dispersers {
+		v.Add(validation.Address(flags.AuthorizedDispersersFlag.Name, address))
+	}
+	registry := ctx.GlobalString(flags.DisperserRegistryFlag.Name)
+	if registry != "" {
+		v.Add(validation.Address(flags.DisperserRegistryFlag.Name, registry))
+		if ctx.GlobalString(flags.DisperserRegistryMethodFlag.Name) == "" {
+			v.Addf("%s: is required with %s", flags.DisperserRegistryMethodFlag.Name, flags.DisperserRegistryFlag.Name)
+		}
+		v.Add(validation.AtLeast(flags.DisperserRefreshIntervalFlag.Name, ctx.GlobalDuration(flags.DisperserRefreshIntervalFlag.Name), time.Second))
+	}
+	if len(dispersers) == 0 && registry == "" {
+		v.Addf("%s or %s: is required to authenticate the dispersers, unless %s is %s", flags.AuthorizedDispersersFlag.Name, flags.DisperserRegistryFlag.Name, flags.DispersalAuthenticationFlag.Name, DispersalAuthenticationDisabled)
+	}
+	return v.Err()
+}
+
+// splitList returns the trimmed non-empty values of the comma-separated list
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,129 @@
+package node_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const nodeConfigFile = `
+chain:
+  rpc: http://localhost:8545
+  private-key: 0123456789abcdef
+kzg:
+  g1-path: ../inabox/resources/kzg/g1.point
+  g2-path: ../inabox/resources/kzg/g2.point
+  cache-path: SRSTables
+  srs-order: 3000
+node:
+  hostname: localhost
+  dispersal-port: "32003"
+  retrieval-port: "32004"
+  enable-node-api: true
+  node-api-port: "9091"
+  enable-metrics: true
+  metrics-port: "9092"
+  timeout: 10s
+  quorum-id-list: "0,1"
+  db-path: /data/operator/db
+  bls-key-file: /keys/bls.json
+  ecdsa-key-file: /keys/ecdsa.json
+  bls-key-password: ""
+  ecdsa-key-password: ""
+  bls-operator-state-retriever: "0x0000000000000000000000000000000000000001"
+  eigenda-service-manager: "0x0000000000000000000000000000000000000002"
+  churner-url: churner:32002
+  public-ip-provider: ipify
+  public-ip-check-interval: 10s
+  enable-test-mode: true
+  test-private-bls: "3"
+  expiration-poll-interval: 60
+  authorized-dispersers: "0x0000000000000000000000000000000000000003, 0x0000000000000000000000000000000000000004"
+num-batch-validators: 32
+`
+
+// newConfig returns the config of the node run with nodeConfigFile and the arguments
+func newConfig(t *testing.T, args ...string) (*node.Config, error) {
+	path := filepath.Join(t.TempDir(), "node.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(nodeConfigFile), 0600))
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	configfile.Enable(app)
+	var config *node.Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = node.NewConfig(ctx)
+		return err
+	}
+	err := app.Run(append([]string{"node", "--config", path}, args...))
+	return config, err
+}
+
+func TestConfigFile(t *testing.T) {
+	config, err := newConfig(t, "--node.timeout", "20s")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "localhost", config.Hostname)
+	assert.Equal(t, "32004", config.RetrievalPort)
+	// The internal ports default to the public ones
+	assert.Equal(t, "32003", config.InternalDispersalPort)
+	assert.Equal(t, []core.QuorumID{0, 1}, config.QuorumIDList)
+	assert.Equal(t, uint64(60), config.ExpirationPollIntervalSec)
+	assert.Equal(t, 10*time.Second, config.PubIPCheckInterval)
+	assert.Equal(t, 32, config.NumBatchValidators)
+	assert.Equal(t, "3", config.PrivateBls)
+	assert.True(t, config.EnableTestMode)
+	assert.Equal(t, 20*time.Second, config.Timeout)
+	// The dispersers are authenticated by default
+	assert.Equal(t, node.DispersalAuthenticationEnforce, config.DispersalAuthentication)
+	assert.Equal(t, []gethcommon.Address{gethcommon.HexToAddress("0x3"), gethcommon.HexToAddress("0x4")}, config.AuthorizedDispersers)
+	assert.Equal(t, "disperser()", config.DisperserRegistryMethod)
+}
+
+func TestInvalidFlags(t *testing.T) {
+	_, err := newConfig(t,
+		"--node.enable-test-mode=false",
+		"--node.retrieval-port", "0",
+		"--node.eigenda-service-manager", "0x0000000000000000000000000000000000000000",
+		"--node.timeout", "0s",
+		"--kzg.g1-path", "missing.point",
+	)
+
+	// All the invalid flags are reported at once, before the keys are read
+	assert.ErrorContains(t, err, "invalid configuration (6 errors)")
+	assert.ErrorContains(t, err, `node.retrieval-port: "0" is not a port between 1 and 65535`)
+	assert.ErrorContains(t, err, "node.eigenda-service-manager: is the zero address")
+	assert.ErrorContains(t, err, "node.timeout: 0s is not between 100ms and 1h0m0s")
+	assert.ErrorContains(t, err, "node.ecdsa-key-file: /keys/ecdsa.json does not exist")
+	assert.ErrorContains(t, err, "node.bls-key-file: /keys/bls.json does not exist")
+	assert.ErrorContains(t, err, "invalid encoding config: failed to open G1 SRS file")
+}
+
+func TestDisperserFlags(t *testing.T) {
+	// The dispersers can't be authenticated without an authorized disperser or a registry
+	for _, mode := range []string{"enforce", "warn"} {
+		_, err := newConfig(t, "--node.dispersal-authentication", mode, "--node.authorized-dispersers", "")
+		assert.ErrorContains(t, err, "node.authorized-dispersers or node.disperser-registry: is required")
+		_, err = newConfig(t, "--node.dispersal-authentication", mode, "--node.authorized-dispersers", "", "--node.disperser-registry", "0x0000000000000000000000000000000000000005")
+		assert.NoError(t, err)
+	}
+	_, err := newConfig(t, "--node.dispersal-authentication", "disabled", "--node.authorized-dispersers", "")
+	assert.NoError(t, err)
+	_, err = newConfig(t, "--node.dispersal-authentication", "strict")
+	assert.ErrorContains(t, err, `node.dispersal-authentication: "strict" is not one of enforce, warn or disabled`)
+
+	_, err = newConfig(t, "--node.dispersal-authentication", "enforce", "--node.authorized-dispersers", "0x3,0x0000000000000000000000000000000000000004",
+		"--node.disperser-registry", "0x0000000000000000000000000000000000000005", "--node.disperser-refresh-interval", "0s")
+	assert.ErrorContains(t, err, "invalid configuration (2 errors)")
+	assert.ErrorContains(t, err, `node.authorized-dispersers: "0x3" is not a 0x-prefixed hex address of 20 bytes`)
+	assert.ErrorContains(t, err, "node.disperser-refresh-interval: 0s is less than 1s")
+}
@@ -0,0 +1,149 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DispersalAuthenticationMode is how the requests to store chunks that aren't signed by an authorized disperser are
+// handled
+type DispersalAuthenticationMode string
+
+const (
+	// DispersalAuthenticationEnforce rejects the requests, and is the mode of a Config without one
+	DispersalAuthenticationEnforce DispersalAuthenticationMode = "enforce"
+	// DispersalAuthenticationWarn logs the requests but stores their chunks, to check the authorized dispersers
+	// before they are enforced
+	DispersalAuthenticationWarn DispersalAuthenticationMode = "warn"
+	// DispersalAuthenticationDisabled doesn't check the requests
+	DispersalAuthenticationDisabled DispersalAuthenticationMode = "disabled"
+)
+
+// ErrUnauthorizedDisperser is returned for the requests to store chunks that aren't signed by an authorized disperser
+var ErrUnauthorizedDisperser = errors.New("the request isn't signed by an authorized disperser")
+
+// DispersalAuthenticator checks that the requests to store the chunks of a batch are signed by an authorized
+// disperser. The dispersers are the ones of the config, and the one returned by the disperser registry if it's set,
+// which is read again at the refresh interval. The previous address of the registry is still accepted until the next
+// refresh after a rotation, so that the batches signed with the previous key before the rotation are stored.
+type DispersalAuthenticator struct {
+	static map[gethcommon.Address]struct{}
+	// warnOnly accepts the requests of the unauthorized dispersers after they're reported
+	warnOnly bool
+
+	caller          ethereum.ContractCaller
+	registry        gethcommon.Address
+	selector        []byte
+	refreshInterval time.Duration
+	logger          common.Logger
+
+	mu       sync.RWMutex
+	current  gethcommon.Address
+	previous gethcommon.Address
+}
+
+// NewDispersalAuthenticator creates the authenticator of the config, reading the disperser from the registry if it's
+// set, and returns nil if the authentication is disabled
+func NewDispersalAuthenticator(ctx context.Context, config *Config, caller ethereum.ContractCaller, logger common.Logger) (*DispersalAuthenticator, error) {
+	if config.DispersalAuthentication == DispersalAuthenticationDisabled {
+		logger.Warn("Accepting the requests to store chunks of any disperser, as the dispersal authentication is disabled")
+		return nil, nil
+	}
+	a := &DispersalAuthenticator{
+		static:          make(map[gethcommon.Address]struct{}, len(config.AuthorizedDispersers)),
+		warnOnly:        config.DispersalAuthentication == DispersalAuthenticationWarn,
+		caller:          caller,
+		refreshInterval: config.DisperserRefreshInterval,
+		logger:          logger,
+	}
+	for _, address := range config.AuthorizedDispersers {
+		a.static[address] = struct{}{}
+	}
+	if config.DisperserRegistryAddr != "" {
+		a.registry = gethcommon.HexToAddress(config.DisperserRegistryAddr)
+		a.selector = crypto.Keccak256([]byte(config.DisperserRegistryMethod))[:4]
+		if err := a.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+	logger.Info("Authenticating the requests to store chunks", "authorizedDispersers", config.AuthorizedDispersers, "registry", config.DisperserRegistryAddr, "warnOnly", a.warnOnly)
+	return a, nil
+}
+
+// WarnOnly tells whether the requests that fail the authentication are still accepted
+func (a *DispersalAuthenticator) WarnOnly() bool {
+	return a.warnOnly
+}
+
+// Authenticate returns ErrUnauthorizedDisperser, wrapped, if the signature of the batch isn't one of an authorized
+// disperser
+func (a *DispersalAuthenticator) Authenticate(batchHeaderHash [32]byte, signature []byte) error {
+	if len(signature) == 0 {
+		return fmt.Errorf("%w: missing disperser signature", ErrUnauthorizedDisperser)
+	}
+	signer, err := auth.RecoverBatchSigner(batchHeaderHash, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnauthorizedDisperser, err)
+	}
+	if _, ok := a.static[signer]; ok {
+		return nil
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if signer != (gethcommon.Address{}) && (signer == a.current || signer == a.previous) {
+		return nil
+	}
+	return fmt.Errorf("%w: signed by %s", ErrUnauthorizedDisperser, signer.Hex())
+}
+
+// Start reads the disperser from the registry at the refresh interval until the context is done, if the registry
+// is set
+func (a *DispersalAuthenticator) Start(ctx context.Context) {
+	if a == nil || a.registry == (gethcommon.Address{}) {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			// The last addresses are kept if the registry can't be read
+			if err := a.refresh(ctx); err != nil {
+				a.logger.Warn("Failed to refresh the disperser, keeping the last one", "registry", a.registry.Hex(), "err", err)
+			}
+		}
+	}()
+}
+
+// refresh reads the disperser from the registry, and keeps the previous one if it was rotated
+func (a *DispersalAuthenticator) refresh(ctx context.Context) error {
+	result, err := a.caller.CallContract(ctx, ethereum.CallMsg{To: &a.registry, Data: a.selector}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read the disperser from the registry %s: %w", a.registry.Hex(), err)
+	}
+	if len(result) != 32 {
+		return fmt.Errorf("the registry %s returned %d bytes, expected an address", a.registry.Hex(), len(result))
+	}
+	disperser := gethcommon.BytesToAddress(result[12:])
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if disperser != a.current && a.current != (gethcommon.Address{}) {
+		a.logger.Info("The disperser was rotated", "previous", a.current.Hex(), "current", disperser.Hex())
+	}
+	// The previous disperser is only accepted until the next refresh after the rotation
+	a.previous, a.current = a.current, disperser
+	return nil
+}
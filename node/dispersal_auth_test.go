@@ -0,0 +1,140 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// disperserRegistry returns the address of its disperser to the calls of disperser()
+type disperserRegistry struct {
+	mu        sync.Mutex
+	disperser gethcommon.Address
+	err       error
+}
+
+func (r *disperserRegistry) set(disperser gethcommon.Address, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.disperser, r.err = disperser, err
+}
+
+func (r *disperserRegistry) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if string(call.Data) != string(crypto.Keccak256([]byte("disperser()"))[:4]) {
+		return nil, errors.New("unknown method")
+	}
+	return gethcommon.LeftPadBytes(r.disperser.Bytes(), 32), nil
+}
+
+func newBatchSigner(t *testing.T) *auth.LocalBatchSigner {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signer, err := auth.NewLocalBatchSigner(gethcommon.Bytes2Hex(crypto.FromECDSA(key)))
+	assert.NoError(t, err)
+	return signer
+}
+
+func signBatch(t *testing.T, signer auth.BatchSigner, batchHeaderHash [32]byte) []byte {
+	signature, err := signer.SignBatch(batchHeaderHash)
+	assert.NoError(t, err)
+	return signature
+}
+
+func TestDispersalAuthenticator(t *testing.T) {
+	authorized, unauthorized := newBatchSigner(t), newBatchSigner(t)
+	config := &node.Config{AuthorizedDispersers: []gethcommon.Address{authorized.Address()}}
+	authenticator, err := node.NewDispersalAuthenticator(context.Background(), config, nil, &commock.Logger{})
+	assert.NoError(t, err)
+
+	batchHeaderHash := [32]byte{1}
+	assert.NoError(t, authenticator.Authenticate(batchHeaderHash, signBatch(t, authorized, batchHeaderHash)))
+	// The signature is bound to the batch
+	err = authenticator.Authenticate([32]byte{2}, signBatch(t, authorized, batchHeaderHash))
+	assert.ErrorIs(t, err, node.ErrUnauthorizedDisperser)
+	err = authenticator.Authenticate(batchHeaderHash, signBatch(t, unauthorized, batchHeaderHash))
+	assert.ErrorIs(t, err, node.ErrUnauthorizedDisperser)
+	assert.ErrorContains(t, err, unauthorized.Address().Hex())
+	err = authenticator.Authenticate(batchHeaderHash, nil)
+	assert.ErrorContains(t, err, "missing disperser signature")
+	err = authenticator.Authenticate(batchHeaderHash, []byte{1, 2, 3})
+	assert.ErrorContains(t, err, "invalid signature length")
+
+	// The check is skipped if the authentication is disabled
+	authenticator, err = node.NewDispersalAuthenticator(context.Background(), &node.Config{DispersalAuthentication: node.DispersalAuthenticationDisabled}, nil, &commock.Logger{})
+	assert.NoError(t, err)
+	assert.Nil(t, authenticator)
+}
+
+func TestDispersalAuthenticatorRotation(t *testing.T) {
+	first, second := newBatchSigner(t), newBatchSigner(t)
+	registry := &disperserRegistry{disperser: first.Address()}
+	config := &node.Config{
+		DisperserRegistryAddr:    "0x0000000000000000000000000000000000000005",
+		DisperserRegistryMethod:  "disperser()",
+		DisperserRefreshInterval: time.Millisecond,
+	}
+	authenticator, err := node.NewDispersalAuthenticator(context.Background(), config, registry, &commock.Logger{})
+	assert.NoError(t, err)
+
+	batchHeaderHash := [32]byte{1}
+	assert.NoError(t, authenticator.Authenticate(batchHeaderHash, signBatch(t, first, batchHeaderHash)))
+	assert.ErrorIs(t, authenticator.Authenticate(batchHeaderHash, signBatch(t, second, batchHeaderHash)), node.ErrUnauthorizedDisperser)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	authenticator.Start(ctx)
+	registry.set(second.Address(), nil)
+	assert.Eventually(t, func() bool {
+		return authenticator.Authenticate(batchHeaderHash, signBatch(t, second, batchHeaderHash)) == nil
+	}, time.Second, time.Millisecond)
+	// The previous disperser is dropped at the next refresh after the rotation
+	assert.Eventually(t, func() bool {
+		return authenticator.Authenticate(batchHeaderHash, signBatch(t, first, batchHeaderHash)) != nil
+	}, time.Second, time.Millisecond)
+
+	// The last disperser is kept if the registry can't be read
+	registry.set(gethcommon.Address{}, errors.New("connection refused"))
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, authenticator.Authenticate(batchHeaderHash, signBatch(t, second, batchHeaderHash)))
+}
+
+func TestDispersalAuthenticatorRegistryUnavailable(t *testing.T) {
+	config := &node.Config{
+		DisperserRegistryAddr:   "0x0000000000000000000000000000000000000005",
+		DisperserRegistryMethod: "disperser()",
+	}
+	registry := &disperserRegistry{err: errors.New("connection refused")}
+	_, err := node.NewDispersalAuthenticator(context.Background(), config, registry, &commock.Logger{})
+	assert.ErrorContains(t, err, "failed to read the disperser from the registry")
+
+	config.DisperserRegistryMethod = "batchConfirmer()"
+	_, err = node.NewDispersalAuthenticator(context.Background(), config, &disperserRegistry{}, &commock.Logger{})
+	assert.ErrorContains(t, err, "unknown method")
+}
+
+func TestPeerBucket(t *testing.T) {
+	// The peers of the unauthenticated dispersals are hashed into a bounded number of buckets
+	buckets := make(map[string]struct{})
+	for i := 0; i < 1000; i++ {
+		buckets[node.PeerBucket(fmt.Sprintf("10.0.%d.%d", i/256, i%256))] = struct{}{}
+	}
+	assert.Len(t, buckets, 16)
+	assert.Equal(t, node.PeerBucket("10.0.0.1"), node.PeerBucket("10.0.0.1"))
+}
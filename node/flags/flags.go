@@ -4,8 +4,13 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/urfave/cli"
 )
@@ -225,6 +230,83 @@ var (
 		Value:    "",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CLIENT_IP_HEADER"),
 	}
+	GrpcCompressionThresholdFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "grpc-compression-threshold"),
+		Usage:    "Size in bytes of the replies at or above which they are compressed with gzip if the client accepts it, while the smaller ones are sent uncompressed. If 0, the replies are compressed only if the requests are.",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "GRPC_COMPRESSION_THRESHOLD"),
+	}
+	DispersalAuthenticationFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-authentication"),
+		Usage:    "How the requests to store chunks that aren't signed by an authorized disperser are handled: enforce rejects them, warn logs and counts them but stores their chunks, so that the authorized dispersers can be checked before they are enforced, and disabled doesn't check them, e.g. in local test environments.",
+		Required: false,
+		Value:    "enforce",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_AUTHENTICATION"),
+	}
+	AuthorizedDispersersFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "authorized-dispersers"),
+		Usage:    "Comma-separated addresses of the keys of the dispersers whose requests to store chunks are accepted, besides the one of the disperser registry.",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "AUTHORIZED_DISPERSERS"),
+	}
+	DisperserRegistryFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-registry"),
+		Usage:    "Address of the contract the address of the key of the disperser is read from, so that the key is rotated on chain. If empty, only the authorized dispersers are accepted.",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_REGISTRY"),
+	}
+	DisperserRegistryMethodFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-registry-method"),
+		Usage:    "Signature of the view method without arguments of the disperser registry that returns the address of the key of the disperser.",
+		Required: false,
+		Value:    "disperser()",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_REGISTRY_METHOD"),
+	}
+	DisperserRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-refresh-interval"),
+		Usage:    "Interval at which the address of the disperser is read again from the disperser registry. The previous address is still accepted for an interval after it's rotated.",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_REFRESH_INTERVAL"),
+	}
+	ChunkCacheSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-cache-size"),
+		Usage:    "Size in bytes of the in-memory cache of the chunks of the validated batches the retrievals are served from before the database. If 0, the chunks are always read from the database.",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_CACHE_SIZE"),
+	}
+	ChunkCacheBatchesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-cache-batches"),
+		Usage:    "Number of the batches stored last whose chunks are cached as soon as they're validated rather than on their first retrieval, if the chunk cache is enabled.",
+		Required: false,
+		Value:    4,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_CACHE_BATCHES"),
+	}
+
+	AuditIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-interval"),
+		Usage:    "Interval at which the stored chunks are re-verified against their blob headers and assignments, the corrupt entries being logged. If 0, the store isn't audited.",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "AUDIT_INTERVAL"),
+	}
+	AuditMaxReadRateFlag = cli.Int64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-max-read-bytes-per-second"),
+		Usage:    "The rate the audits read the store at is bounded to this many bytes per second, unless it's 0.",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "AUDIT_MAX_READ_BYTES_PER_SECOND"),
+	}
+	AuditRemoveCorruptFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "audit-remove-corrupt"),
+		Usage:    "Remove the batches with corrupt entries found by the audits from the store.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "AUDIT_REMOVE_CORRUPT"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -260,6 +342,17 @@ var optionalFlags = []cli.Flag{
 	InternalDispersalPortFlag,
 	InternalRetrievalPortFlag,
 	ClientIPHeaderFlag,
+	GrpcCompressionThresholdFlag,
+	DispersalAuthenticationFlag,
+	AuthorizedDispersersFlag,
+	DisperserRegistryFlag,
+	DisperserRegistryMethodFlag,
+	DisperserRefreshIntervalFlag,
+	ChunkCacheSizeFlag,
+	ChunkCacheBatchesFlag,
+	AuditIntervalFlag,
+	AuditMaxReadRateFlag,
+	AuditRemoveCorruptFlag,
 }
 
 func init() {
@@ -267,6 +360,11 @@ func init() {
 	Flags = append(Flags, encoding.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(EnvVarPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, profiling.CLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, tracing.CLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpcsec.CLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, core.ChunkCapsCLIFlags(EnvVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, configfile.CLIFlag(EnvVarPrefix))
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -2,6 +2,8 @@ package grpc
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -9,12 +11,17 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -30,6 +37,8 @@ type Server struct {
 	logger common.Logger
 
 	ratelimiter common.RateLimiter
+	// creds are the TLS credentials of the servers, and are empty if they are plaintext
+	creds grpc.ServerOption
 
 	mu *sync.Mutex
 }
@@ -48,7 +57,12 @@ func NewServer(config *node.Config, node *node.Node, logger common.Logger, ratel
 	}
 }
 
-func (s *Server) Start() {
+func (s *Server) Start() error {
+	creds, err := grpcsec.ServerOption(context.Background(), s.config.TLSConfig, s.logger)
+	if err != nil {
+		return err
+	}
+	s.creds = creds
 
 	// TODO: In order to facilitate integration testing with multiple nodes, we need to be able to set the port.
 	// TODO: Properly implement the health check.
@@ -73,6 +87,16 @@ func (s *Server) Start() {
 		}
 	}()
 
+	return nil
+}
+
+// unaryInterceptors are the interceptors of the requests to the dispersal and retrieval servers
+func (s *Server) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor()}
+	if s.config.GrpcCompressionThreshold > 0 {
+		interceptors = append(interceptors, common.CompressionThresholdServerInterceptor(s.config.GrpcCompressionThreshold, s.node.Metrics))
+	}
+	return interceptors
 }
 
 func (s *Server) serveDispersal() error {
@@ -84,7 +108,7 @@ func (s *Server) serveDispersal() error {
 	}
 
 	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 1024) // 1 GiB
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(opt, s.creds, grpc.ChainUnaryInterceptor(s.unaryInterceptors()...))
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -108,7 +132,7 @@ func (s *Server) serveRetrieval() error {
 	}
 
 	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(opt, s.creds, grpc.ChainUnaryInterceptor(s.unaryInterceptors()...))
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -131,11 +155,24 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 		return nil, err
 	}
 
+	// The disperser is authenticated before the chunks are deserialized and validated
+	if err := s.authenticateDisperser(ctx, batchHeader, in.GetDisperserSignature()); err != nil {
+		return nil, err
+	}
+
 	blobs, err := GetBlobMessages(in)
 	if err != nil {
 		return nil, err
 	}
 
+	// The hash of the batch header is only computed for the sampled requests
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetAttributes(tracing.NumBlobsKey.Int(len(blobs)), tracing.BatchSizeKey.Int(proto.Size(in)))
+		if batchHeaderHash, err := batchHeader.GetBatchHeaderHash(); err == nil {
+			span.SetAttributes(tracing.BatchHeaderHashKey.String(hex.EncodeToString(batchHeaderHash[:])))
+		}
+	}
+
 	sig, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
 	if err != nil {
 		return nil, err
@@ -146,6 +183,39 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 	return &pb.StoreChunksReply{Signature: sigData[:]}, nil
 }
 
+// authenticateDisperser returns an Unauthenticated error if the authentication of the dispersers is enabled and the
+// batch isn't signed by an authorized one
+func (s *Server) authenticateDisperser(ctx context.Context, batchHeader *core.BatchHeader, signature []byte) error {
+	if s.node.DispersalAuthenticator == nil {
+		return nil
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return err
+	}
+	if err := s.node.DispersalAuthenticator.Authenticate(batchHeaderHash, signature); err != nil {
+		peerAddr, addrErr := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, true)
+		if addrErr != nil {
+			peerAddr = "unknown"
+		}
+		// The metrics bucket the address of the connection rather than the one of the client IP header, which the
+		// client sets
+		connAddr, addrErr := common.GetClientAddress(ctx, "", 0, true)
+		if addrErr != nil {
+			connAddr = "unknown"
+		}
+		peerBucket := node.PeerBucket(connAddr)
+		s.node.Metrics.RecordUnauthenticatedDispersal(peerBucket)
+		if s.node.DispersalAuthenticator.WarnOnly() {
+			s.logger.Warn("Accepted a request to store chunks that failed the authentication, which is only warned about", "peer", peerAddr, "peerBucket", peerBucket, "err", err)
+			return nil
+		}
+		s.logger.Warn("Rejected a request to store chunks", "peer", peerAddr, "peerBucket", peerBucket, "err", err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
 // StoreChunks is called by dispersers to store data.
 func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(sec float64) {
@@ -248,6 +318,10 @@ func (s *Server) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest)
 func (s *Server) getBlobHeader(ctx context.Context, batchHeaderHash [32]byte, blobIndex int, quorumId uint8) (*core.BlobHeader, *pb.BlobHeader, error) {
 
 	blobHeaderBytes, err := s.node.Store.GetBlobHeader(ctx, batchHeaderHash, blobIndex)
+	if errors.Is(err, node.ErrKeyNotFound) {
+		// The retrievers tell the blobs that no operator stores apart from the failures of the operators
+		return nil, nil, status.Error(codes.NotFound, "blob header not found")
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get the blob header from Store")
 	}
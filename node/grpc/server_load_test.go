@@ -3,16 +3,22 @@ package grpc_test
 import (
 	"context"
 	"crypto/rand"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
 )
 
-func makeBatch(t *testing.T, blobSize int, numBlobs int, advThreshold, quorumThreshold int, refBlockNumber uint) (*core.BatchHeader, map[core.OperatorID][]*core.BlobMessage) {
+func makeBatch(t testing.TB, blobSize int, numBlobs int, advThreshold, quorumThreshold int, refBlockNumber uint) (*core.BatchHeader, map[core.OperatorID][]*core.BlobMessage) {
 	encoder, err := makeTestEncoder()
 	assert.NoError(t, err)
 	asn := &core.StdAssignmentCoordinator{}
@@ -103,3 +109,66 @@ func TestStoreChunks(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, reply.GetSignature())
 }
+
+// BenchmarkRetrieveChunks measures the latencies of ten concurrent retrievers of the chunks of the same batch, when
+// the chunks are read from the database and when they're served from the chunk cache
+func BenchmarkRetrieveChunks(b *testing.B) {
+	const numRetrievers = 10
+	const numBlobs = 10
+	// 10 X 200 KiB blobs
+	batchHeader, blobMessagesByOp := makeBatch(b, 200*1024, numBlobs, 80, 100, 0)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(b, err)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 3000,
+		},
+	})
+
+	for _, test := range []struct {
+		name           string
+		chunkCacheSize uint64
+	}{
+		{name: "db", chunkCacheSize: 0},
+		{name: "cache", chunkCacheSize: 1 << 30},
+	} {
+		b.Run(test.name, func(b *testing.B) {
+			server := newCachingTestServer(b, true, nil, test.chunkCacheSize)
+			// The operator of the server is only set once it's created
+			req, _, err := dispatcher.GetStoreChunksRequest(blobMessagesByOp[opID], batchHeader)
+			assert.NoError(b, err)
+			_, err = server.StoreChunks(context.Background(), req)
+			assert.NoError(b, err)
+
+			latencies := make([]time.Duration, b.N)
+			var next atomic.Int64
+			var wg sync.WaitGroup
+			b.ResetTimer()
+			for r := 0; r < numRetrievers; r++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := int(next.Add(1) - 1); i < b.N; i = int(next.Add(1) - 1) {
+						start := time.Now()
+						_, err := server.RetrieveChunks(ctx, &pb.RetrieveChunksRequest{
+							BatchHeaderHash: batchHeaderHash[:],
+							BlobIndex:       uint32(i % numBlobs),
+							QuorumId:        0,
+						})
+						latencies[i] = time.Since(start)
+						if err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			b.StopTimer()
+
+			slices.Sort(latencies)
+			b.ReportMetric(float64(latencies[len(latencies)*99/100].Microseconds()), "p99-µs")
+		})
+	}
+}
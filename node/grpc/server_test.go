@@ -13,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/logging"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	core_mock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/node"
@@ -21,12 +22,16 @@ import (
 	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/wealdtech/go-merkletree"
 	"github.com/wealdtech/go-merkletree/keccak256"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -54,7 +59,19 @@ func makeTestEncoder() (core.Encoder, error) {
 	return encoding.NewEncoder(encoding.EncoderConfig{KzgConfig: config})
 }
 
-func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
+func newTestServer(t testing.TB, mockValidator bool) *grpc.Server {
+	return newAuthenticatingTestServer(t, mockValidator, nil)
+}
+
+// newAuthenticatingTestServer makes a test server that authenticates the dispersers with the authenticator, unless
+// it's nil
+func newAuthenticatingTestServer(t testing.TB, mockValidator bool, authenticator *node.DispersalAuthenticator) *grpc.Server {
+	return newCachingTestServer(t, mockValidator, authenticator, 0)
+}
+
+// newCachingTestServer makes a test server whose store caches the chunks of the last batch and the ones read, up to
+// chunkCacheSize bytes, unless it's 0
+func newCachingTestServer(t testing.TB, mockValidator bool, authenticator *node.DispersalAuthenticator, chunkCacheSize uint64) *grpc.Server {
 	dbPath := t.TempDir()
 	keyPair, err := core.GenRandomBlsKeys()
 	if err != nil {
@@ -85,6 +102,9 @@ func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
 	if err != nil {
 		panic("failed to create a new levelDB store")
 	}
+	if chunkCacheSize > 0 {
+		store.EnableChunkCache(chunkCacheSize, 1)
+	}
 	defer os.Remove(dbPath)
 
 	ratelimiter := &commonmock.NoopRatelimiter{}
@@ -120,6 +140,8 @@ func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
 		Store:      store,
 		ChainState: chainState,
 		Validator:  val,
+
+		DispersalAuthenticator: authenticator,
 	}
 	return grpc.NewServer(config, node, logger, ratelimiter)
 }
@@ -318,3 +340,69 @@ func blobHeaderToProto(t *testing.T, blobHeader *core.BlobHeader) *pb.BlobHeader
 		QuorumHeaders: []*pb.BlobQuorumInfo{quorumHeader},
 	}
 }
+
+func TestStoreChunksAuthentication(t *testing.T) {
+	disperserKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	disperser, err := auth.NewLocalBatchSigner(gethcommon.Bytes2Hex(crypto.FromECDSA(disperserKey)))
+	assert.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	other, err := auth.NewLocalBatchSigner(gethcommon.Bytes2Hex(crypto.FromECDSA(otherKey)))
+	assert.NoError(t, err)
+
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	config := &node.Config{AuthorizedDispersers: []gethcommon.Address{disperser.Address()}}
+	authenticator, err := node.NewDispersalAuthenticator(context.Background(), config, nil, logger)
+	assert.NoError(t, err)
+	server := newAuthenticatingTestServer(t, true, authenticator)
+
+	p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 3000}}
+	ctx := peer.NewContext(context.Background(), p)
+
+	// The unsigned requests and the ones of other keys are rejected before the chunks are deserialized
+	req, batchHeaderHash, _, _, _ := makeStoreChunksRequest(t, 90)
+	req.Blobs[0].Bundles[0].Chunks[0] = []byte{1, 2, 3}
+	_, err = server.StoreChunks(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	req.DisperserSignature, err = other.SignBatch(batchHeaderHash)
+	assert.NoError(t, err)
+	_, err = server.StoreChunks(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.ErrorContains(t, err, other.Address().Hex())
+
+	req, batchHeaderHash, _, _, _ = makeStoreChunksRequest(t, 90)
+	req.DisperserSignature, err = disperser.SignBatch(batchHeaderHash)
+	assert.NoError(t, err)
+	reply, err := server.StoreChunks(ctx, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, reply.GetSignature())
+}
+
+func TestStoreChunksAuthenticationWarn(t *testing.T) {
+	disperserKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	disperser, err := auth.NewLocalBatchSigner(gethcommon.Bytes2Hex(crypto.FromECDSA(disperserKey)))
+	assert.NoError(t, err)
+
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	config := &node.Config{
+		DispersalAuthentication: node.DispersalAuthenticationWarn,
+		AuthorizedDispersers:    []gethcommon.Address{disperser.Address()},
+	}
+	authenticator, err := node.NewDispersalAuthenticator(context.Background(), config, nil, logger)
+	assert.NoError(t, err)
+	assert.True(t, authenticator.WarnOnly())
+	server := newAuthenticatingTestServer(t, true, authenticator)
+
+	p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 3000}}
+	ctx := peer.NewContext(context.Background(), p)
+
+	// The unsigned requests are only warned about
+	req, _, _, _, _ := makeStoreChunksRequest(t, 90)
+	reply, err := server.StoreChunks(ctx, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, reply.GetSignature())
+}
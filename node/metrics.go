@@ -1,18 +1,28 @@
 package node
 
 import (
-	"context"
+	"hash/fnv"
+	"net/http"
+	"strconv"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
 	eigenmetrics "github.com/Layr-Labs/eigensdk-go/metrics"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const (
 	Namespace = "node"
+
+	// numPeerBuckets is the number of buckets the peers of the unauthenticated dispersals are hashed into, which bounds
+	// the values of their label
+	numPeerBuckets = 16
 )
 
 type Metrics struct {
@@ -30,6 +40,15 @@ type Metrics struct {
 	CurrBatches *prometheus.GaugeVec
 	// Total number of changes in the node's socket address.
 	AccuSocketUpdates prometheus.Counter
+	// Accumulated number of replies sent compressed and uncompressed under the compression threshold.
+	AccuReplies *prometheus.CounterVec
+	// Accumulated number of requests to store chunks that failed the authentication of their disperser, by the bucket
+	// of their peer.
+	AccuUnauthenticatedDispersals *prometheus.CounterVec
+	// Accumulated number of reads of chunks served from the chunk cache and from the database.
+	AccuChunkCacheLookups *prometheus.CounterVec
+	// Total size in bytes of the chunks in the chunk cache.
+	ChunkCacheSize prometheus.Gauge
 	// avs node spec eigen_ metrics: https://eigen.nethermind.io/docs/spec/metrics/metrics-prom-spec
 	EigenMetrics eigenmetrics.Metrics
 
@@ -37,6 +56,8 @@ type Metrics struct {
 	// socketAddr is the address at which the metrics server will be listening.
 	// should be in format ip:port
 	socketAddr string
+	// profiling configures the pprof and expvar endpoints of the metrics server.
+	profiling profiling.Config
 }
 
 func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, logger common.Logger, socketAddr string) *Metrics {
@@ -44,6 +65,8 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 	// Add Go module collectors
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(version.NewCollector(Namespace))
+	reg.MustRegister(logging.NewLevelCollector(Namespace, logger))
 
 	metrics := &Metrics{
 		Registered: promauto.With(reg).NewGauge(
@@ -96,6 +119,42 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the total number of node's socket address updates",
 			},
 		),
+		// The "compression" label has values: compressed, uncompressed.
+		AccuReplies: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_rpc_replies_total",
+				Help:      "the total number of replies sent by the DA node under the compression threshold, by whether they were compressed",
+			},
+			[]string{"method", "compression"},
+		),
+		// The "peer_bucket" label has values: 0 to 15, the hash bucket of the address of the connection. It stands for
+		// the peer, which any client can choose, so that the values of the label are bounded, and the peers are logged
+		// with their bucket.
+		AccuUnauthenticatedDispersals: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_unauthenticated_dispersals_total",
+				Help:      "the total number of requests to store chunks that failed the authentication of their disperser, which are rejected unless the authentication only warns about them",
+			},
+			[]string{"peer_bucket"},
+		),
+		// The "result" label has values: hit, miss.
+		AccuChunkCacheLookups: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_chunk_cache_lookups_total",
+				Help:      "the total number of reads of chunks by whether they were served from the chunk cache",
+			},
+			[]string{"result"},
+		),
+		ChunkCacheSize: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_chunk_cache_size_bytes",
+				Help:      "the total size in bytes of the chunks in the chunk cache",
+			},
+		),
 		EigenMetrics: eigenMetrics,
 		logger:       logger,
 		registry:     reg,
@@ -105,14 +164,66 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 	return metrics
 }
 
+func (g *Metrics) EnableProfiling(config profiling.Config) {
+	g.profiling = config
+}
+
+// Start serves the metrics of the registry, which include the eigen_ metrics of the sdk, at /metrics. The server
+// has its own mux rather than the default one of EigenMetrics.Start, so that pprof is only served when enabled.
 func (g *Metrics) Start() {
-	_ = g.EigenMetrics.Start(context.Background(), g.registry)
+	g.logger.Info("Starting metrics server at ", "socket", g.socketAddr)
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(
+			g.registry,
+			promhttp.HandlerOpts{},
+		))
+		profiling.RegisterHandlers(mux, g.profiling)
+		logging.RegisterHandlers(mux, g.logger)
+		err := http.ListenAndServe(g.socketAddr, mux)
+		g.logger.Error("Prometheus server failed", "err", err)
+	}()
 }
 
 func (g *Metrics) RecordRPCRequest(method string, status string) {
 	g.AccNumRequests.WithLabelValues(method, status).Inc()
 }
 
+// ObserveCompression counts the replies sent compressed and uncompressed
+func (g *Metrics) ObserveCompression(method string, compressed bool) {
+	compression := "uncompressed"
+	if compressed {
+		compression = "compressed"
+	}
+	g.AccuReplies.WithLabelValues(method, compression).Inc()
+}
+
+// RecordUnauthenticatedDispersal counts a request to store chunks that failed the authentication, rejected or not, by
+// the bucket of its peer
+func (g *Metrics) RecordUnauthenticatedDispersal(peerBucket string) {
+	g.AccuUnauthenticatedDispersals.WithLabelValues(peerBucket).Inc()
+}
+
+// PeerBucket returns the bucket the address of a peer is hashed into, one of numPeerBuckets
+func PeerBucket(peer string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(peer))
+	return strconv.Itoa(int(h.Sum32() % numPeerBuckets))
+}
+
+// RecordChunkCacheLookup counts a read of chunks by whether it was served from the chunk cache
+func (g *Metrics) RecordChunkCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	g.AccuChunkCacheLookups.WithLabelValues(result).Inc()
+}
+
+func (g *Metrics) SetChunkCacheSize(size uint64) {
+	g.ChunkCacheSize.Set(float64(size))
+}
+
 func (g *Metrics) RecordSocketAddressChange() {
 	g.AccuSocketUpdates.Inc()
 }
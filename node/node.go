@@ -15,6 +15,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	"github.com/Layr-Labs/eigenda/core/eth"
@@ -45,6 +46,8 @@ type Node struct {
 	Transactor              core.Transactor
 	PubIPProvider           pubip.Provider
 	OperatorSocketsFilterer indexer.OperatorSocketsFilterer
+	// DispersalAuthenticator is nil if the requests to store chunks aren't authenticated
+	DispersalAuthenticator *DispersalAuthenticator
 
 	mu            sync.Mutex
 	CurrentSocket string
@@ -58,6 +61,7 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 		return nil, err
 	}
 	metrics := NewMetrics(sdkClients.Metrics, sdkClients.PrometheusRegistry, logger, ":"+config.MetricsPort)
+	metrics.EnableProfiling(config.ProfilingConfig)
 	rpcCallsCollector := rpccalls.NewCollector(AppName, sdkClients.PrometheusRegistry)
 
 	// Generate BLS keys
@@ -89,14 +93,14 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 	cst := eth.NewChainState(tx, client)
 
 	// Setup Node Api
-	nodeApi := nodeapi.NewNodeApi(AppName, SemVer, "localhost:"+config.NodeApiPort, logger)
+	nodeApi := nodeapi.NewNodeApi(AppName, version.String(), "localhost:"+config.NodeApiPort, logger)
 
 	// Make validator
 	enc, err := encoding.NewEncoder(config.EncoderConfig)
 	if err != nil {
 		return nil, err
 	}
-	asgn := &core.StdAssignmentCoordinator{}
+	asgn := &core.StdAssignmentCoordinator{Caps: config.ChunkCaps}
 	validator := core.NewChunkValidator(enc, asgn, cst, config.ID)
 
 	// Create new store
@@ -125,6 +129,9 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
+	if config.ChunkCacheSize > 0 {
+		store.EnableChunkCache(config.ChunkCacheSize, config.ChunkCacheBatches)
+	}
 
 	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
 	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(eigenDAServiceManagerAddr, client)
@@ -132,6 +139,11 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 		return nil, fmt.Errorf("failed to create new operator sockets filterer: %w", err)
 	}
 
+	dispersalAuthenticator, err := NewDispersalAuthenticator(context.Background(), config, client, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the dispersal authenticator: %w", err)
+	}
+
 	return &Node{
 		Config:                  config,
 		Logger:                  logger,
@@ -144,6 +156,7 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger common.Logger)
 		Validator:               validator,
 		PubIPProvider:           pubIPProvider,
 		OperatorSocketsFilterer: socketsFilterer,
+		DispersalAuthenticator:  dispersalAuthenticator,
 	}, nil
 }
 
@@ -160,6 +173,7 @@ func (n *Node) Start(ctx context.Context) error {
 	}
 
 	go n.expireLoop()
+	n.DispersalAuthenticator.Start(ctx)
 
 	// Build the socket based on the hostname/IP provided in the CLI
 	socket := string(core.MakeOperatorSocket(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort))
@@ -179,6 +193,7 @@ func (n *Node) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to register the operator: %w", err)
 		}
+		n.Logger.Info("The node is registered on chain", "operatorId", n.Config.ID, "quorumIds", n.Config.QuorumIDList)
 	} else {
 		n.Logger.Info("The node has successfully started. Note it's not opt-in to EigenDA yet (it's not receiving or validating data in EigenDA). To register, please follow the EigenDA operator guide section in docs.eigenlayer.xyz")
 	}
@@ -301,6 +316,9 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 	if result.err != nil {
 		return nil, err
 	}
+	if result.keys != nil {
+		n.Store.MarkValidated(batchHeaderHash)
+	}
 
 	// Sign batch header hash if all validation checks pass and data items are writen to database.
 	stageTimer = time.Now()
@@ -334,7 +352,8 @@ func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blob
 	for i := 0; i < len(blobs); i++ {
 		err := <-out
 		if err != nil {
-			return err
+			// The digests tell whether the node and the disperser assigned the chunks from the same operator set
+			return fmt.Errorf("%w (operator state digests at block %d: %s)", err, header.ReferenceBlockNumber, operatorState.Digests())
 		}
 	}
 
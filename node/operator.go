@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	grpcchurner "github.com/Layr-Labs/eigenda/api/grpc/churner"
@@ -25,34 +26,41 @@ type Operator struct {
 	QuorumIDs  []core.QuorumID
 }
 
-// Register operator registers the operator with the given public key for the given quorum IDs.
+// RegisterOperator registers the operator with the given public key for the given quorum IDs. It's idempotent: the
+// quorums the operator is already registered for are skipped, so that a partial registration is resumed with the
+// remaining quorums, and the registration is checked on-chain once its transaction is confirmed.
 func RegisterOperator(ctx context.Context, operator *Operator, transactor core.Transactor, churnerUrl string, useSecureGrpc bool, logger common.Logger) error {
+	if len(operator.QuorumIDs) == 0 {
+		return errors.New("an operator should be in at least one quorum to be useful")
+	}
+
 	registeredQuorumIds, err := transactor.GetRegisteredQuorumIdsForOperator(ctx, operator.OperatorId)
 	if err != nil {
 		return fmt.Errorf("failed to get registered quorum ids for an operator: %w", err)
 	}
 
 	logger.Debug("Registered quorum ids", "registeredQuorumIds", registeredQuorumIds)
-	if len(registeredQuorumIds) != 0 {
+	quorumIDs := unregisteredQuorums(operator.QuorumIDs, registeredQuorumIds)
+	if len(quorumIDs) == 0 {
+		logger.Info("The operator is already registered for all its quorums", "quorums", operator.QuorumIDs)
 		return nil
 	}
+	if len(registeredQuorumIds) != 0 {
+		logger.Info("Resuming the registration of the operator", "registeredQuorums", registeredQuorumIds, "remainingQuorums", quorumIDs)
+	}
 
-	// if the operator is not registered, we may need to register the BLSPublicKey
+	// if the operator is not registered, we may need to register the BLSPublicKey, which is skipped if it already is
 	err = transactor.RegisterBLSPublicKey(ctx, operator.KeyPair)
 	if err != nil {
 		return fmt.Errorf("failed to register the nodes bls public key: %w", err)
 	}
 
-	logger.Info("Quorums to register for", "quorums", operator.QuorumIDs)
-
-	if len(operator.QuorumIDs) == 0 {
-		return errors.New("an operator should be in at least one quorum to be useful")
-	}
+	logger.Info("Quorums to register for", "quorums", quorumIDs)
 
 	// register for quorums
 	shouldCallChurner := false
 	// check if one of the quorums to register for is full
-	for _, quorumID := range operator.QuorumIDs {
+	for _, quorumID := range quorumIDs {
 		operatorSetParams, err := transactor.GetOperatorSetParams(ctx, quorumID)
 		if err != nil {
 			return err
@@ -74,16 +82,41 @@ func RegisterOperator(ctx context.Context, operator *Operator, transactor core.T
 
 	// if we should call the churner, call it
 	if shouldCallChurner {
-		churnReply, err := requestChurnApproval(ctx, operator, churnerUrl, useSecureGrpc, logger)
+		churnReply, err := requestChurnApproval(ctx, operator, quorumIDs, churnerUrl, useSecureGrpc, logger)
 		if err != nil {
 			return fmt.Errorf("failed to request churn approval: %w", err)
 		}
 
-		return transactor.RegisterOperatorWithChurn(ctx, operator.KeyPair.PubKey, operator.Socket, operator.QuorumIDs, churnReply)
+		err = transactor.RegisterOperatorWithChurn(ctx, operator.KeyPair.PubKey, operator.Socket, quorumIDs, churnReply)
 	} else {
 		// other wise just register normally
-		return transactor.RegisterOperator(ctx, operator.KeyPair.PubKey, operator.Socket, operator.QuorumIDs)
+		err = transactor.RegisterOperator(ctx, operator.KeyPair.PubKey, operator.Socket, quorumIDs)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The transactions are confirmed by then, so that the registration is visible on-chain
+	registeredQuorumIds, err = transactor.GetRegisteredQuorumIdsForOperator(ctx, operator.OperatorId)
+	if err != nil {
+		return fmt.Errorf("failed to check the registration of the operator: %w", err)
+	}
+	if missing := unregisteredQuorums(operator.QuorumIDs, registeredQuorumIds); len(missing) != 0 {
+		return fmt.Errorf("the operator isn't registered for quorums %v after its registration", missing)
+	}
+	logger.Info("Registered the operator", "quorums", operator.QuorumIDs)
+	return nil
+}
+
+// unregisteredQuorums returns the quorums, in order, that aren't among the registered ones
+func unregisteredQuorums(quorumIDs, registered []core.QuorumID) []core.QuorumID {
+	var unregistered []core.QuorumID
+	for _, quorumID := range quorumIDs {
+		if !slices.Contains(registered, quorumID) {
+			unregistered = append(unregistered, quorumID)
+		}
 	}
+	return unregistered
 }
 
 // DeregisterOperator deregisters the operator with the given public key from the all the quorums that it is registered with at the supplied block number.
@@ -95,7 +128,7 @@ func DeregisterOperator(ctx context.Context, KeyPair *core.KeyPair, transactor c
 	return transactor.DeregisterOperator(ctx, KeyPair.GetPubKeyG1(), blockNumber)
 }
 
-func requestChurnApproval(ctx context.Context, operator *Operator, churnerUrl string, useSecureGrpc bool, logger common.Logger) (*grpcchurner.ChurnReply, error) {
+func requestChurnApproval(ctx context.Context, operator *Operator, quorumIDs []core.QuorumID, churnerUrl string, useSecureGrpc bool, logger common.Logger) (*grpcchurner.ChurnReply, error) {
 	logger.Info("churner url", "url", churnerUrl)
 
 	var credential = insecure.NewCredentials()
@@ -118,7 +151,7 @@ func requestChurnApproval(ctx context.Context, operator *Operator, churnerUrl st
 	ctx, cancel := context.WithTimeout(ctx, operator.Timeout)
 	defer cancel()
 
-	request := newChurnRequest(operator.KeyPair, operator.QuorumIDs)
+	request := newChurnRequest(operator.KeyPair, quorumIDs)
 	opt := grpc.MaxCallSendMsgSize(1024 * 1024 * 300)
 
 	return gc.Churn(ctx, request, opt)
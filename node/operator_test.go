@@ -0,0 +1,89 @@
+package node_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+)
+
+// registryTransactor keeps the quorums of the operator on the registry, which the registrations add to unless drop
+// is set
+type registryTransactor struct {
+	coremock.MockTransactor
+	registered    []core.QuorumID
+	registrations [][]core.QuorumID
+	blsKeys       int
+	drop          bool
+}
+
+func (t *registryTransactor) GetRegisteredQuorumIdsForOperator(ctx context.Context, operator core.OperatorID) ([]core.QuorumID, error) {
+	return slices.Clone(t.registered), nil
+}
+
+func (t *registryTransactor) RegisterBLSPublicKey(ctx context.Context, keypair *core.KeyPair) error {
+	t.blsKeys++
+	return nil
+}
+
+func (t *registryTransactor) GetOperatorSetParams(ctx context.Context, quorumID core.QuorumID) (*core.OperatorSetParam, error) {
+	return &core.OperatorSetParam{MaxOperatorCount: 10}, nil
+}
+
+func (t *registryTransactor) GetNumberOfRegisteredOperatorForQuorum(ctx context.Context, quorumID core.QuorumID) (uint32, error) {
+	return 1, nil
+}
+
+func (t *registryTransactor) RegisterOperator(ctx context.Context, pubkeyG1 *core.G1Point, socket string, quorumIds []core.QuorumID) error {
+	t.registrations = append(t.registrations, quorumIds)
+	if !t.drop {
+		t.registered = append(t.registered, quorumIds...)
+	}
+	return nil
+}
+
+func newTestOperator(t *testing.T, quorumIDs ...core.QuorumID) *node.Operator {
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	return &node.Operator{
+		Socket:     "localhost:32003;32004",
+		KeyPair:    keyPair,
+		OperatorId: keyPair.GetPubKeyG1().GetOperatorID(),
+		QuorumIDs:  quorumIDs,
+	}
+}
+
+func TestRegisterOperator(t *testing.T) {
+	logger := &commock.Logger{}
+	operator := newTestOperator(t, 0, 1)
+
+	transactor := &registryTransactor{}
+	assert.NoError(t, node.RegisterOperator(context.Background(), operator, transactor, "", false, logger))
+	assert.Equal(t, [][]core.QuorumID{{0, 1}}, transactor.registrations)
+	assert.Equal(t, 1, transactor.blsKeys)
+
+	// Registering again is a no-op
+	assert.NoError(t, node.RegisterOperator(context.Background(), operator, transactor, "", false, logger))
+	assert.Len(t, transactor.registrations, 1)
+	assert.Equal(t, 1, transactor.blsKeys)
+
+	// A partial registration is resumed with the remaining quorums
+	transactor = &registryTransactor{registered: []core.QuorumID{1}}
+	operator = newTestOperator(t, 0, 1, 2)
+	assert.NoError(t, node.RegisterOperator(context.Background(), operator, transactor, "", false, logger))
+	assert.Equal(t, [][]core.QuorumID{{0, 2}}, transactor.registrations)
+	assert.ElementsMatch(t, []core.QuorumID{0, 1, 2}, transactor.registered)
+
+	// The registration fails if it doesn't show on-chain
+	transactor = &registryTransactor{drop: true}
+	err := node.RegisterOperator(context.Background(), operator, transactor, "", false, logger)
+	assert.ErrorContains(t, err, "isn't registered for quorums [0 1 2]")
+
+	err = node.RegisterOperator(context.Background(), newTestOperator(t), &registryTransactor{}, "", false, logger)
+	assert.Error(t, err)
+}
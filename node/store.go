@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
@@ -34,6 +36,12 @@ type Store struct {
 
 	// The DA Node's metrics.
 	metrics *Metrics
+
+	// chunkCache is nil unless EnableChunkCache was called. cacheMu is held for reading while the chunks read from the
+	// db are cached, and for writing while entries are deleted from the db, so that the chunks of the deleted entries
+	// aren't cached once they're evicted.
+	chunkCache *chunkCache
+	cacheMu    sync.RWMutex
 }
 
 // NewLevelDBStore creates a new Store object with a db at the provided path and the given logger.
@@ -56,6 +64,25 @@ func NewLevelDBStore(path string, logger common.Logger, metrics *Metrics, blockS
 	}, nil
 }
 
+// EnableChunkCache serves the chunks of the validated batches from an in-memory cache of up to maxSize bytes, which
+// caches the chunks of the last numBatches batches as soon as they're validated, see MarkValidated, and the ones of
+// the other batches as they're read. It must be called before the store is used.
+func (s *Store) EnableChunkCache(maxSize uint64, numBatches int) {
+	s.chunkCache = newChunkCache(maxSize, numBatches, s.metrics)
+}
+
+// deleteKeys removes the keys from the db atomically, and evicts their chunks from the chunk cache.
+func (s *Store) deleteKeys(keys [][]byte) error {
+	if s.chunkCache == nil {
+		return s.db.DeleteBatch(keys)
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	// The chunks are evicted even if the deletion fails, as it may have been partial
+	defer s.chunkCache.remove(keys)
+	return s.db.DeleteBatch(keys)
+}
+
 // Delete expired entries in the store.
 // An entry is expired if its expiry <= currentTimeUnixSec, where expiry and
 // currentTimeUnixSec are time since Unix epoch (in seconds).
@@ -124,35 +151,13 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 	for _, hash := range expiredBatches {
 		var batchHeaderHash [32]byte
 		copy(batchHeaderHash[:], hash)
-
-		// Batch header.
-		expiredKeys = append(expiredKeys, EncodeBatchHeaderKey(batchHeaderHash))
-
-		// Blob headers.
-		blobHeaderIter := s.db.NewIterator(EncodeBlobHeaderKeyPrefix(batchHeaderHash))
-		for blobHeaderIter.Next() {
-			expiredKeys = append(expiredKeys, copyBytes(blobHeaderIter.Key()))
-
-			// Collect the size in bytes for all the quorums of the blob.
-			var protoBlobHeader node.BlobHeader
-			if proto.Unmarshal(blobHeaderIter.Value(), &protoBlobHeader) == nil {
-				for _, qh := range protoBlobHeader.GetQuorumHeaders() {
-					size += int(qh.GetEncodedBlobLength() * bn254.BYTES_PER_COEFFICIENT)
-				}
-			}
-		}
-		blobHeaderIter.Release()
-
-		// Blob chunks.
-		blobIter := s.db.NewIterator(bytes.NewBuffer(hash).Bytes())
-		for blobIter.Next() {
-			expiredKeys = append(expiredKeys, copyBytes(blobIter.Key()))
-		}
-		blobIter.Release()
+		keys, batchSize := s.batchKeys(batchHeaderHash)
+		expiredKeys = append(expiredKeys, keys...)
+		size += batchSize
 	}
 
 	// Perform the removal.
-	err := s.db.DeleteBatch(expiredKeys)
+	err := s.deleteKeys(expiredKeys)
 	if err != nil {
 		s.logger.Error("Failed to delete the expired keys in batch", "keys:", expiredKeys, "error:", err)
 		return -1, err
@@ -164,6 +169,117 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 	return len(expiredBatches), nil
 }
 
+// batchKeys returns the keys of the batch header, blob headers and chunks of the batch, along with the size in bytes of
+// the chunks of all the quorums of its blobs. The expiration key of the batch isn't included.
+func (s *Store) batchKeys(batchHeaderHash [32]byte) ([][]byte, int) {
+	// Batch header.
+	keys := [][]byte{EncodeBatchHeaderKey(batchHeaderHash)}
+	size := 0
+
+	// Blob headers.
+	blobHeaderIter := s.db.NewIterator(EncodeBlobHeaderKeyPrefix(batchHeaderHash))
+	for blobHeaderIter.Next() {
+		keys = append(keys, copyBytes(blobHeaderIter.Key()))
+
+		// Collect the size in bytes for all the quorums of the blob.
+		var protoBlobHeader node.BlobHeader
+		if proto.Unmarshal(blobHeaderIter.Value(), &protoBlobHeader) == nil {
+			for _, qh := range protoBlobHeader.GetQuorumHeaders() {
+				size += int(qh.GetEncodedBlobLength() * bn254.BYTES_PER_COEFFICIENT)
+			}
+		}
+	}
+	blobHeaderIter.Release()
+
+	// Blob chunks.
+	blobIter := s.db.NewIterator(bytes.NewBuffer(batchHeaderHash[:]).Bytes())
+	for blobIter.Next() {
+		keys = append(keys, copyBytes(blobIter.Key()))
+	}
+	blobIter.Release()
+	return keys, size
+}
+
+// StoredBatch is a batch in the store.
+type StoredBatch struct {
+	BatchHeaderHash [32]byte
+	// ExpirationTime is when the batch expires, in seconds since the Unix epoch. It's 0 if the batch is missing from
+	// the expiration index, e.g. because another batch was stored in the same second.
+	ExpirationTime int64
+	// StoredAt is when the batch was stored, in seconds since the Unix epoch, or 0 if the batch has no expiration.
+	// It's derived from the expiration time with the store duration of the store, so it's off if the batch was
+	// stored with another store duration.
+	StoredAt int64
+}
+
+// ListBatches returns the batches in the store, whether they have a batch header or an expiration, in the order they
+// expire after the ones without an expiration.
+func (s *Store) ListBatches(ctx context.Context) ([]StoredBatch, error) {
+	batches := make([]StoredBatch, 0)
+	// The positions of the batches in the list, by batch header hash
+	positions := make(map[[32]byte]int)
+
+	headerIter := s.db.NewIterator(EncodeBatchHeaderKeyPrefix())
+	for headerIter.Next() {
+		batch := StoredBatch{}
+		copy(batch.BatchHeaderHash[:], headerIter.Key()[len(EncodeBatchHeaderKeyPrefix()):])
+		positions[batch.BatchHeaderHash] = len(batches)
+		batches = append(batches, batch)
+	}
+	headerIter.Release()
+	if err := headerIter.Error(); err != nil {
+		return nil, err
+	}
+
+	expirationIter := s.db.NewIterator(EncodeBatchExpirationKeyPrefix())
+	defer expirationIter.Release()
+	for expirationIter.Next() {
+		ts, err := DecodeBatchExpirationKey(expirationIter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the expiration key", "key:", expirationIter.Key(), "error:", err)
+			continue
+		}
+		var batchHeaderHash [32]byte
+		copy(batchHeaderHash[:], expirationIter.Value())
+		position, ok := positions[batchHeaderHash]
+		if !ok {
+			// The batch header of the batch is missing
+			position = len(batches)
+			positions[batchHeaderHash] = position
+			batches = append(batches, StoredBatch{BatchHeaderHash: batchHeaderHash})
+		}
+		batches[position].ExpirationTime = ts
+		batches[position].StoredAt = ts - s.timeToExpire()
+	}
+	if err := expirationIter.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(batches, func(i, j int) bool {
+		return batches[i].ExpirationTime < batches[j].ExpirationTime
+	})
+	return batches, nil
+}
+
+// DeleteBatch removes all the entries of the batch atomically, including its expiration, so that the expiration
+// garbage collection doesn't count the batch again once it has expired.
+func (s *Store) DeleteBatch(ctx context.Context, batch StoredBatch) error {
+	keys, size := s.batchKeys(batch.BatchHeaderHash)
+	if batch.ExpirationTime != 0 {
+		keys = append(keys, EncodeBatchExpirationKey(batch.ExpirationTime))
+	}
+	if err := s.deleteKeys(keys); err != nil {
+		return err
+	}
+	s.metrics.RemoveNCurrentBatch(1, size)
+	return nil
+}
+
+// timeToExpire returns how long the batches are stored for, in seconds.
+func (s *Store) timeToExpire() int64 {
+	return int64(s.blockStaleMeasure+s.storeDurationBlocks) * 12 // 12s per block
+}
+
 // Store the batch into the store.
 //
 // The batch will be itemized into multiple entries when it's stored:
@@ -204,7 +320,7 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Setting the expiration time for the batch.
 	curr := time.Now().Unix()
-	timeToExpire := (s.blockStaleMeasure + s.storeDurationBlocks) * 12 // 12s per block
+	timeToExpire := s.timeToExpire()
 	// Why this expiration time is safe?
 	//
 	// The batch must be confirmed before referenceBlockNumber+blockStaleMeasure, otherwise
@@ -218,13 +334,16 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 	//
 	// Note if a batch is unconfirmed, it could be removed even earlier; here we treat its
 	// lifecycle the same as confirmed batches for simplicity.
-	expirationTime := curr + int64(timeToExpire)
+	expirationTime := curr + timeToExpire
 	expirationKey := EncodeBatchExpirationKey(expirationTime)
 	keys = append(keys, expirationKey)
 	values = append(values, batchHeaderHash[:])
 
 	// Generate key/value pairs for all blob headers and blob chunks .
 	size := 0
+	// The keys and chunks of the blob quorums, which are staged in the chunk cache until the batch is validated
+	chunkKeys := make([][]byte, 0)
+	bundles := make([][][]byte, 0)
 	for idx, blob := range blobs {
 		// blob header
 		blobHeaderKey, err := EncodeBlobHeaderKey(batchHeaderHash, idx)
@@ -265,14 +384,23 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 			keys = append(keys, key)
 			values = append(values, chunkBytes)
-
+			chunkKeys = append(chunkKeys, key)
+			bundles = append(bundles, bundleRaw)
 		}
 	}
 
+	// The batch is staged before it's written, so that its chunks aren't cached as they're read until it's validated
+	if s.chunkCache != nil {
+		s.chunkCache.stage(batchHeaderHash, chunkKeys, bundles)
+	}
+
 	// Write all the key/value pairs to the local database atomically.
 	err = s.db.WriteBatch(keys, values)
 	if err != nil {
 		log.Error("Failed to write the batch into local database:", "err", err)
+		if s.chunkCache != nil {
+			s.chunkCache.unstage(batchHeaderHash)
+		}
 		return nil, err
 	}
 	s.metrics.AddCurrentBatch(size)
@@ -280,6 +408,15 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 	return &keys, nil
 }
 
+// MarkValidated tells the store that the batch it stored last was validated, so that its chunks are cached if the
+// chunk cache is enabled. Until then, the chunks of the batch are only read from the db, and they're never cached if
+// the batch is rolled back with DeleteKeys.
+func (s *Store) MarkValidated(batchHeaderHash [32]byte) {
+	if s.chunkCache != nil {
+		s.chunkCache.commit(batchHeaderHash)
+	}
+}
+
 // GetBatchHeader returns the batch header for the given batchHeaderHash.
 func (s *Store) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) ([]byte, error) {
 	batchHeaderKey := EncodeBatchHeaderKey(batchHeaderHash)
@@ -311,6 +448,7 @@ func (s *Store) GetBlobHeader(ctx context.Context, batchHeaderHash [32]byte, blo
 
 // GetChunks returns the list of byte arrays stored for given blobKey along with a boolean
 // indicating if the read was usuccessful or the chunks were serialized correctly
+// The chunks may be served from the chunk cache, so they must not be modified.
 func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobIndex int, quorumID core.QuorumID) ([][]byte, bool) {
 	log := s.logger
 
@@ -318,6 +456,15 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 	if err != nil {
 		return nil, false
 	}
+	if s.chunkCache != nil {
+		if chunks, ok := s.chunkCache.get(blobKey); ok {
+			s.metrics.RecordChunkCacheLookup(true)
+			return chunks, true
+		}
+		s.metrics.RecordChunkCacheLookup(false)
+		s.cacheMu.RLock()
+		defer s.cacheMu.RUnlock()
+	}
 	data, err := s.db.Get(blobKey)
 	if err != nil {
 		return nil, false
@@ -328,6 +475,9 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 	if err != nil {
 		return nil, false
 	}
+	if s.chunkCache != nil {
+		s.chunkCache.add(blobKey, chunks)
+	}
 	return chunks, true
 }
 
@@ -342,7 +492,7 @@ func (s *Store) HasKey(ctx context.Context, key []byte) bool {
 // Note: caller should ensure these keys are exactly all the data items for a single batch
 // to maintain the integrity of the store.
 func (s *Store) DeleteKeys(ctx context.Context, keys *[][]byte) bool {
-	return s.db.DeleteBatch(*keys) == nil
+	return s.deleteKeys(*keys) == nil
 }
 
 // Flattens an array of byte arrays (chunks) into a single byte array
@@ -14,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 )
@@ -199,3 +200,82 @@ func TestStoringBlob(t *testing.T) {
 	assert.False(t, s.HasKey(ctx, blobKey1))
 	assert.False(t, s.HasKey(ctx, blobKey2))
 }
+
+func TestChunkCache(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := node.NewMetrics(metrics.NewNoopMetrics(), reg, &mock.Logger{}, ":9090")
+	s, err := node.NewLevelDBStore(t.TempDir(), &mock.Logger{}, m, 1, 1)
+	assert.NoError(t, err)
+	s.EnableChunkCache(1<<20, 1)
+	ctx := context.Background()
+	hits := func() float64 { return testutil.ToFloat64(m.AccuChunkCacheLookups.WithLabelValues("hit")) }
+
+	// The chunks of a batch being validated are read from the db, but aren't cached
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, ok := s.GetChunks(ctx, batchHeaderHash, 0, 0)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 0.0, hits())
+
+	// They're cached once it's validated
+	s.MarkValidated(batchHeaderHash)
+	chunks, ok := s.GetChunks(ctx, batchHeaderHash, 1, 0)
+	assert.True(t, ok)
+	expected, err := blobs[1].Bundles[0][0].Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{expected}, chunks)
+	assert.Equal(t, 1.0, hits())
+
+	// The chunks of a rolled back batch are never served, even if it's marked as validated afterwards
+	batchHeader.ReferenceBlockNumber = 1
+	invalidHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	keys, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.NoError(t, err)
+	_, ok = s.GetChunks(ctx, invalidHash, 0, 0)
+	assert.True(t, ok)
+	assert.True(t, s.DeleteKeys(ctx, keys))
+	s.MarkValidated(invalidHash)
+	_, ok = s.GetChunks(ctx, invalidHash, 0, 0)
+	assert.False(t, ok)
+
+	// The chunks of the validated batches that weren't read are evicted as the next batches are validated, and
+	// cached again as they're read
+	batchHeader.ReferenceBlockNumber = 2
+	nextHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.NoError(t, err)
+	s.MarkValidated(nextHash)
+	hitsBefore := hits()
+	_, ok = s.GetChunks(ctx, batchHeaderHash, 0, 0)
+	assert.True(t, ok)
+	_, ok = s.GetChunks(ctx, batchHeaderHash, 1, 0)
+	assert.True(t, ok)
+	assert.Equal(t, hitsBefore+1, hits())
+	_, ok = s.GetChunks(ctx, batchHeaderHash, 0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, hitsBefore+2, hits())
+
+	// The chunks of the expired and deleted batches are evicted. The batches are stored in the same second, so only
+	// one of them is in the expiration index.
+	numDeleted, err := s.DeleteExpiredEntries(time.Now().Unix()+100, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numDeleted)
+	batches, err := s.ListBatches(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.NoError(t, s.DeleteBatch(ctx, batches[0]))
+	for _, hash := range [][32]byte{batchHeaderHash, nextHash} {
+		for blobIndex := 0; blobIndex < 2; blobIndex++ {
+			_, ok = s.GetChunks(ctx, hash, blobIndex, 0)
+			assert.False(t, ok)
+		}
+	}
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.ChunkCacheSize))
+}
@@ -48,6 +48,11 @@ func EncodeBlobHeaderKeyPrefix(batchHeaderHash [32]byte) []byte {
 	return buf.Bytes()
 }
 
+// Returns the encoded prefix for batch header key.
+func EncodeBatchHeaderKeyPrefix() []byte {
+	return []byte(batchHeaderPrefix)
+}
+
 // EncodeBatchHeaderKey returns an encoded key as batch header identification.
 func EncodeBatchHeaderKey(batchHeaderHash [32]byte) []byte {
 	prefix := []byte(batchHeaderPrefix)
@@ -2,6 +2,8 @@ package encoder
 
 import (
 	"errors"
+	"runtime"
+	"sync"
 
 	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 )
@@ -16,46 +18,49 @@ import (
 // the frames and indices don't encode the length of the original data. If maxInputSize
 // is smaller than the original input size, decoded data will be trimmed to fit the maxInputSize.
 func (g *Encoder) Decode(frames []Frame, indices []uint64, maxInputSize uint64) ([]byte, error) {
+	return g.DecodeWithWorkers(frames, indices, maxInputSize, uint64(runtime.NumCPU()))
+}
+
+// DecodeWithWorkers is like Decode, where the interpolation of the frames is spread across numWorker goroutines.
+// A numWorker of 0 uses a goroutine per CPU.
+func (g *Encoder) DecodeWithWorkers(frames []Frame, indices []uint64, maxInputSize uint64, numWorker uint64) ([]byte, error) {
 	numSys := GetNumSys(maxInputSize, g.ChunkLen)
 
 	if uint64(len(frames)) < numSys {
 		return nil, errors.New("number of frame must be sufficient")
 	}
 
-	samples := make([]*bls.Fr, g.NumEvaluations())
-	// copy evals based on frame coeffs into samples
-	for i, d := range indices {
-		f := frames[i]
-		e, err := GetLeadingCosetIndex(d, g.NumChunks)
-		if err != nil {
-			return nil, err
-		}
-
-		evals, err := g.GetInterpolationPolyEval(f.Coeffs, uint32(e))
-		if err != nil {
-			return nil, err
-		}
+	cosets, evals, err := g.interpolateFrames(frames, indices, numWorker)
+	if err != nil {
+		return nil, err
+	}
 
+	// The samples point to the evaluations of the frames in a single buffer, nil for the missing ones
+	numEvaluations := g.NumEvaluations()
+	sampleValues := g.BufferPool.getBuffer(numEvaluations, maxInputSize)
+	defer g.BufferPool.putBuffer(sampleValues, maxInputSize)
+	samples := make([]*bls.Fr, numEvaluations)
+	// copy evals based on frame coeffs into samples
+	for i, e := range cosets {
 		// Some pattern i butterfly swap. Find the leading coset, then increment by number of coset
 		for j := uint64(0); j < g.ChunkLen; j++ {
 			p := j*g.NumChunks + uint64(e)
-			samples[p] = new(bls.Fr)
-			bls.CopyFr(samples[p], &evals[j])
+			samples[p] = &sampleValues[p]
+			bls.CopyFr(samples[p], &evals[i][j])
 		}
 	}
 
-	reconstructedData := make([]bls.Fr, g.NumEvaluations())
 	missingIndices := false
-	for i, s := range samples {
+	for _, s := range samples {
 		if s == nil {
 			missingIndices = true
 			break
 		}
-		reconstructedData[i] = *s
 	}
 
+	// The buffer of the samples holds all the evaluations if none is missing
+	reconstructedData := sampleValues
 	if missingIndices {
-		var err error
 		reconstructedData, err = g.Fs.RecoverPolyFromSamples(
 			samples,
 			g.Fs.ZeroPolyViaMultiplication,
@@ -65,8 +70,10 @@ func (g *Encoder) Decode(frames []Frame, indices []uint64, maxInputSize uint64)
 		}
 	}
 
-	reconstructedPoly, err := g.Fs.FFT(reconstructedData, true)
-	if err != nil {
+	// The number of evaluations is a power of two, so the FFT doesn't pad them to a copy
+	reconstructedPoly := g.BufferPool.getBuffer(uint64(len(reconstructedData)), maxInputSize)
+	defer g.BufferPool.putBuffer(reconstructedPoly, maxInputSize)
+	if err := g.Fs.InplaceFFT(reconstructedData, reconstructedPoly, true); err != nil {
 		return nil, err
 	}
 
@@ -74,3 +81,50 @@ func (g *Encoder) Decode(frames []Frame, indices []uint64, maxInputSize uint64)
 
 	return data, nil
 }
+
+// interpolateFrames returns the leading coset of each frame along with the evaluations of its interpolation
+// polynomial on the coset. The frames are interpolated by numWorker goroutines.
+func (g *Encoder) interpolateFrames(frames []Frame, indices []uint64, numWorker uint64) ([]uint32, [][]bls.Fr, error) {
+	if numWorker == 0 {
+		numWorker = uint64(runtime.NumCPU())
+	}
+	if numWorker > uint64(len(indices)) {
+		numWorker = uint64(len(indices))
+	}
+
+	cosets := make([]uint32, len(indices))
+	evals := make([][]bls.Fr, len(indices))
+	errs := make([]error, numWorker)
+	jobs := make(chan int, len(indices))
+	for i := range indices {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for w := uint64(0); w < numWorker; w++ {
+		wg.Add(1)
+		go func(w uint64) {
+			defer wg.Done()
+			for i := range jobs {
+				e, err := GetLeadingCosetIndex(indices[i], g.NumChunks)
+				if err == nil {
+					cosets[i] = e
+					evals[i], err = g.GetInterpolationPolyEval(frames[i].Coeffs, e)
+				}
+				if err != nil {
+					errs[w] = err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return cosets, evals, nil
+}
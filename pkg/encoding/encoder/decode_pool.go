@@ -0,0 +1,62 @@
+package encoder
+
+import (
+	"sync"
+
+	bls "github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+)
+
+// DecodeBufferPool recycles the buffers of the evaluations of the decodings across them, so that the decodings
+// under load don't allocate buffers as large as the encoded blobs. Only the decodings of the blobs of up to
+// maxBlobSize bytes use the pool, the others allocate their own buffers. The buffers are zeroed as they're returned
+// to the pool, so that no data of a blob is kept in it.
+type DecodeBufferPool struct {
+	maxBlobSize uint64
+
+	mu sync.Mutex
+	// pools are the pools of the buffers by their lengths, which are the numbers of evaluations of the encodings
+	pools map[uint64]*sync.Pool
+}
+
+func NewDecodeBufferPool(maxBlobSize uint64) *DecodeBufferPool {
+	return &DecodeBufferPool{
+		maxBlobSize: maxBlobSize,
+		pools:       make(map[uint64]*sync.Pool),
+	}
+}
+
+// getBuffer returns a zeroed buffer of the length for the decoding of a blob of the size. It's allocated if the pool
+// is nil or the blob is larger than the max blob size of the pool.
+func (p *DecodeBufferPool) getBuffer(length uint64, blobSize uint64) []bls.Fr {
+	if p == nil || blobSize > p.maxBlobSize {
+		return make([]bls.Fr, length)
+	}
+	buffer := p.pool(length).Get().(*[]bls.Fr)
+	return *buffer
+}
+
+// putBuffer zeroes the buffer and returns it to the pool of its length. The buffers of the decodings that didn't use
+// the pool are dropped.
+func (p *DecodeBufferPool) putBuffer(buffer []bls.Fr, blobSize uint64) {
+	if p == nil || blobSize > p.maxBlobSize {
+		return
+	}
+	clear(buffer)
+	p.pool(uint64(len(buffer))).Put(&buffer)
+}
+
+func (p *DecodeBufferPool) pool(length uint64) *sync.Pool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pool, ok := p.pools[length]
+	if !ok {
+		pool = &sync.Pool{
+			New: func() any {
+				buffer := make([]bls.Fr, length)
+				return &buffer
+			},
+		}
+		p.pools[length] = pool
+	}
+	return pool
+}
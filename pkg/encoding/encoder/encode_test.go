@@ -78,3 +78,53 @@ func TestEncodeDecode_ErrorsWhenNotEnoughSampledFrames(t *testing.T) {
 
 	assert.EqualError(t, err, "number of frame must be sufficient")
 }
+
+func TestEncodeDecode_InvertsWithAnyNumberOfWorkers(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := rs.GetEncodingParams(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	enc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, enc)
+
+	inputFr := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	_, frames, _, err := enc.Encode(inputFr)
+	assert.Nil(t, err)
+
+	// A worker count of 0 uses a worker per CPU, and the workers beyond the number of frames are idle
+	for _, numWorker := range []uint64{0, 1, 2, uint64(len(frames)) + 1} {
+		samples, indices := sampleFrames(frames, uint64(len(frames)-1))
+		data, err := enc.DecodeWithWorkers(samples, indices, uint64(len(GETTYSBURG_ADDRESS_BYTES)), numWorker)
+		require.Nil(t, err)
+		assert.Equal(t, GETTYSBURG_ADDRESS_BYTES, data, "numWorker=%d", numWorker)
+	}
+}
+
+func TestEncodeDecode_RecyclesBuffers(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := rs.GetEncodingParams(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	enc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, enc)
+
+	// The blobs of the same params alternate, so that the buffers of a blob are reused for the other one
+	inputs := [][]byte{GETTYSBURG_ADDRESS_BYTES, []byte("The world will little note, nor long remember what we say here")}
+	allFrames := make([][]rs.Frame, len(inputs))
+	for i, input := range inputs {
+		_, allFrames[i], _, _ = enc.Encode(rs.ToFrArray(input))
+	}
+
+	for _, maxBlobSize := range []uint64{uint64(len(GETTYSBURG_ADDRESS_BYTES)), 1} {
+		enc.BufferPool = rs.NewDecodeBufferPool(maxBlobSize)
+		for iteration := 0; iteration < 4; iteration++ {
+			for i, input := range inputs {
+				// With and without the recovery of the missing frames
+				samples, indices := sampleFrames(allFrames[i], uint64(len(allFrames[i])-iteration%2))
+				data, err := enc.Decode(samples, indices, uint64(len(input)))
+				require.Nil(t, err)
+				assert.Equal(t, input, data, "maxBlobSize=%d", maxBlobSize)
+			}
+		}
+	}
+}
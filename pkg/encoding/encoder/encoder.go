@@ -10,6 +10,8 @@ type Encoder struct {
 	EncodingParams
 
 	Fs *kzg.FFTSettings
+	// BufferPool recycles the buffers of the decodings, which allocate their own if it's nil
+	BufferPool *DecodeBufferPool
 
 	verbose bool
 }
@@ -27,6 +27,12 @@ type KzgConfig struct {
 	SRSOrder       uint64 // Order is the total size of SRS
 	Verbose        bool
 	PreloadEncoder bool
+
+	// NumDecodeWorker is the number of goroutines of a decode, or one per CPU if it is 0
+	NumDecodeWorker uint64
+	// DecodeBufferPoolMaxBlobSize is the size in bytes of the largest blobs whose decodings recycle their buffers,
+	// or 0 if the buffers aren't recycled
+	DecodeBufferPoolMaxBlobSize uint64
 }
 
 type KzgEncoderGroup struct {
@@ -36,6 +42,8 @@ type KzgEncoderGroup struct {
 
 	Encoders  map[rs.EncodingParams]*KzgEncoder
 	Verifiers map[rs.EncodingParams]*KzgVerifier
+	// DecodeBufferPool is shared by the decodings of the encoders, and is nil if the buffers aren't recycled
+	DecodeBufferPool *rs.DecodeBufferPool
 }
 
 type KzgEncoder struct {
@@ -78,6 +86,9 @@ func NewKzgEncoderGroup(config *KzgConfig) (*KzgEncoderGroup, error) {
 		Encoders:  make(map[rs.EncodingParams]*KzgEncoder),
 		Verifiers: make(map[rs.EncodingParams]*KzgVerifier),
 	}
+	if config.DecodeBufferPoolMaxBlobSize > 0 {
+		encoderGroup.DecodeBufferPool = rs.NewDecodeBufferPool(config.DecodeBufferPoolMaxBlobSize)
+	}
 
 	if config.PreloadEncoder {
 		// create table dir if not exist
@@ -158,6 +169,7 @@ func (g *KzgEncoderGroup) newKzgEncoder(params rs.EncodingParams) (*KzgEncoder,
 		log.Println("Could not create encoder: ", err)
 		return nil, err
 	}
+	encoder.BufferPool = g.DecodeBufferPool
 
 	subTable, err := NewSRSTable(g.CacheDir, g.Srs.G1, g.NumWorker)
 	if err != nil {
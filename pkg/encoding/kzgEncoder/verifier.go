@@ -2,6 +2,7 @@ package kzgEncoder
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	rs "github.com/Layr-Labs/eigenda/pkg/encoding/encoder"
@@ -69,6 +70,15 @@ func (g *KzgEncoderGroup) newKzgVerifier(params rs.EncodingParams) (*KzgVerifier
 	}, nil
 }
 
+// Commit computes the commitment to the polynomial with the given coefficients. Like the low degree proof, it
+// doesn't depend on the encoding parameters.
+func (v *KzgEncoderGroup) Commit(polyFr []wbls.Fr) (*wbls.G1Point, error) {
+	if len(polyFr) > len(v.Srs.G1) {
+		return nil, fmt.Errorf("polynomial of %d coefficients exceeds the %d loaded SRS points", len(polyFr), len(v.Srs.G1))
+	}
+	return wbls.LinCombG1(v.Srs.G1[:len(polyFr)], polyFr), nil
+}
+
 // VerifyCommit verifies the low degree proof; since it doesn't depend on the encoding parameters
 // we leave it as a method of the KzgEncoderGroup
 func (v *KzgEncoderGroup) VerifyCommit(commit, lowDegreeProof *wbls.G1Point, degree uint64) error {
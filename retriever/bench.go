@@ -0,0 +1,313 @@
+package retriever
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever/eth"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/wealdtech/go-merkletree"
+)
+
+// The phases of the retrievals timed by the bench
+const (
+	// PhaseBatchHeader is the lookup of the batch header confirmed on-chain
+	PhaseBatchHeader = "batch_header"
+	// PhaseOperatorState is the lookup of the operator state at the reference block
+	PhaseOperatorState = "operator_state"
+	// PhaseBlobHeader is the download of the blob header from the operators, until one serves a valid one
+	PhaseBlobHeader = "blob_header"
+	// PhaseChunks is the download of the chunks, from the first request to an operator to the last reply
+	PhaseChunks = "chunks"
+	// PhaseVerification is the verification of the chunks and of the reconstructed blob against the commitment.
+	// The chunks of the operators are verified concurrently, so it's their total verification time rather than
+	// the time they add to the retrieval.
+	PhaseVerification = "verification"
+	// PhaseDecode is the reconstruction of the blob from the chunks
+	PhaseDecode = "decode"
+	// PhaseTotal is the whole retrieval, including the lookup of the batch header
+	PhaseTotal = "total"
+)
+
+var benchPhases = []string{PhaseBatchHeader, PhaseOperatorState, PhaseBlobHeader, PhaseChunks, PhaseVerification, PhaseDecode, PhaseTotal}
+
+// BenchRequest is the blob the bench retrieves, and how many times
+type BenchRequest struct {
+	BatchHeaderHash [32]byte
+	BlobIndex       uint32
+	QuorumID        core.QuorumID
+	Iterations      int
+}
+
+// BenchTimer times the phases of the retrievals of the bench, by wrapping the dependencies of the retrieval client
+// so that the client times what it does in production. The retrievals it times must not be concurrent.
+type BenchTimer struct {
+	mu         sync.Mutex
+	phases     map[string]time.Duration
+	operators  map[core.OperatorID]time.Duration
+	chunkStart time.Time
+	chunkEnd   time.Time
+}
+
+func NewBenchTimer() *BenchTimer {
+	t := &BenchTimer{}
+	t.reset()
+	return t
+}
+
+// reset starts the timing of a new retrieval
+func (t *BenchTimer) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases = make(map[string]time.Duration)
+	t.operators = make(map[core.OperatorID]time.Duration)
+	t.chunkStart = time.Time{}
+	t.chunkEnd = time.Time{}
+}
+
+func (t *BenchTimer) add(phase string, start time.Time) {
+	elapsed := time.Since(start)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases[phase] += elapsed
+}
+
+func (t *BenchTimer) addChunks(operatorID core.OperatorID, start time.Time) {
+	end := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.operators[operatorID] = end.Sub(start)
+	if t.chunkStart.IsZero() || start.Before(t.chunkStart) {
+		t.chunkStart = start
+	}
+	if end.After(t.chunkEnd) {
+		t.chunkEnd = end
+	}
+}
+
+// timings returns the phases and the chunk downloads of the operators timed since the last reset
+func (t *BenchTimer) timings() (map[string]time.Duration, map[core.OperatorID]time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	phases := make(map[string]time.Duration, len(t.phases)+1)
+	for phase, elapsed := range t.phases {
+		phases[phase] = elapsed
+	}
+	if !t.chunkStart.IsZero() {
+		phases[PhaseChunks] = t.chunkEnd.Sub(t.chunkStart)
+	}
+	operators := make(map[core.OperatorID]time.Duration, len(t.operators))
+	for operatorID, elapsed := range t.operators {
+		operators[operatorID] = elapsed
+	}
+	return phases, operators
+}
+
+// WrapIndexedChainState returns the chain state with the operator state lookups timed
+func (t *BenchTimer) WrapIndexedChainState(state core.IndexedChainState) core.IndexedChainState {
+	return &timedIndexedChainState{IndexedChainState: state, timer: t}
+}
+
+// WrapChainClient returns the chain client with the batch header lookups timed
+func (t *BenchTimer) WrapChainClient(client eth.ChainClient) eth.ChainClient {
+	return &timedChainClient{ChainClient: client, timer: t}
+}
+
+// WrapNodeClient returns the node client with the downloads of the blob headers and of the chunks timed
+func (t *BenchTimer) WrapNodeClient(client clients.NodeClient) clients.NodeClient {
+	return &timedNodeClient{NodeClient: client, timer: t}
+}
+
+// WrapEncoder returns the encoder with the verifications and the decoding of the blobs timed
+func (t *BenchTimer) WrapEncoder(encoder core.Encoder) core.Encoder {
+	return &timedEncoder{Encoder: encoder, timer: t}
+}
+
+type timedIndexedChainState struct {
+	core.IndexedChainState
+	timer *BenchTimer
+}
+
+func (s *timedIndexedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	defer s.timer.add(PhaseOperatorState, time.Now())
+	return s.IndexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+}
+
+type timedChainClient struct {
+	eth.ChainClient
+	timer *BenchTimer
+}
+
+func (c *timedChainClient) FetchBatchHeader(ctx context.Context, serviceManagerAddress gcommon.Address, batchHeaderHash []byte) (*binding.IEigenDAServiceManagerBatchHeader, error) {
+	defer c.timer.add(PhaseBatchHeader, time.Now())
+	return c.ChainClient.FetchBatchHeader(ctx, serviceManagerAddress, batchHeaderHash)
+}
+
+type timedNodeClient struct {
+	clients.NodeClient
+	timer *BenchTimer
+}
+
+func (c *timedNodeClient) GetBlobHeader(ctx context.Context, socket string, batchHeaderHash [32]byte, blobIndex uint32) (*core.BlobHeader, *merkletree.Proof, error) {
+	defer c.timer.add(PhaseBlobHeader, time.Now())
+	return c.NodeClient.GetBlobHeader(ctx, socket, batchHeaderHash, blobIndex)
+}
+
+func (c *timedNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	start := time.Now()
+	replyChan := make(chan clients.RetrievedChunks, 1)
+	c.NodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, replyChan)
+	reply := <-replyChan
+	c.timer.addChunks(opID, start)
+	chunksChan <- reply
+}
+
+type timedEncoder struct {
+	core.Encoder
+	timer *BenchTimer
+}
+
+func (e *timedEncoder) VerifyChunks(chunks []*core.Chunk, indices []core.ChunkNumber, commitments core.BlobCommitments, params core.EncodingParams) error {
+	defer e.timer.add(PhaseVerification, time.Now())
+	return e.Encoder.VerifyChunks(chunks, indices, commitments, params)
+}
+
+func (e *timedEncoder) VerifyCommitment(data []byte, commitments core.BlobCommitments) error {
+	defer e.timer.add(PhaseVerification, time.Now())
+	return e.Encoder.VerifyCommitment(data, commitments)
+}
+
+func (e *timedEncoder) Decode(chunks []*core.Chunk, indices []core.ChunkNumber, params core.EncodingParams, inputSize uint64) ([]byte, error) {
+	defer e.timer.add(PhaseDecode, time.Now())
+	return e.Encoder.Decode(chunks, indices, params, inputSize)
+}
+
+// Bench retrieves a blob repeatedly with the retrieval client of the server, outside of the server, and reports the
+// timings of the phases of the retrievals. The retrieval client and the chain client must be built with the
+// dependencies wrapped by the timer.
+type Bench struct {
+	RetrievalClient           clients.RetrievalClient
+	ChainClient               eth.ChainClient
+	EigenDAServiceManagerAddr string
+	Timer                     *BenchTimer
+}
+
+// BenchSummary is the distribution of the durations of a phase, or of the chunk downloads from an operator, in
+// milliseconds across the iterations it was timed in
+type BenchSummary struct {
+	Name  string  `json:"name"`
+	Count int     `json:"count"`
+	MinMs float64 `json:"min_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+// BenchReport summarizes the iterations of the bench
+type BenchReport struct {
+	// Iterations is the number of iterations run, which is fewer than requested if the bench was interrupted
+	Iterations int `json:"iterations"`
+	Failures   int `json:"failures"`
+	// Errors are the errors of the failed iterations, in order
+	Errors []string `json:"errors,omitempty"`
+	// Phases are the timings of the phases of the successful iterations
+	Phases []BenchSummary `json:"phases"`
+	// Operators are the timings of the chunk downloads from each operator, named by operator ID
+	Operators []BenchSummary `json:"operators"`
+}
+
+// Run retrieves the blob of the request for its number of iterations, one after the other, and reports their
+// timings. The failed iterations are counted in the report rather than stopping the bench.
+func (b *Bench) Run(ctx context.Context, req BenchRequest) *BenchReport {
+	report := &BenchReport{}
+	phases := make(map[string][]time.Duration)
+	operators := make(map[core.OperatorID][]time.Duration)
+	// The bench stops early once the context is done, e.g. on an interrupt
+	for ; report.Iterations < req.Iterations && ctx.Err() == nil; report.Iterations++ {
+		b.Timer.reset()
+		start := time.Now()
+		err := b.retrieve(ctx, req)
+		if err != nil {
+			report.Failures++
+			report.Errors = append(report.Errors, fmt.Sprintf("iteration %d: %v", report.Iterations+1, err))
+			continue
+		}
+		iterationPhases, iterationOperators := b.Timer.timings()
+		iterationPhases[PhaseTotal] = time.Since(start)
+		for phase, elapsed := range iterationPhases {
+			phases[phase] = append(phases[phase], elapsed)
+		}
+		for operatorID, elapsed := range iterationOperators {
+			operators[operatorID] = append(operators[operatorID], elapsed)
+		}
+	}
+
+	for _, phase := range benchPhases {
+		if len(phases[phase]) > 0 {
+			report.Phases = append(report.Phases, summarize(phase, phases[phase]))
+		}
+	}
+	for operatorID, durations := range operators {
+		report.Operators = append(report.Operators, summarize(hex.EncodeToString(operatorID[:]), durations))
+	}
+	sort.Slice(report.Operators, func(i, j int) bool { return report.Operators[i].Name < report.Operators[j].Name })
+	return report
+}
+
+func (b *Bench) retrieve(ctx context.Context, req BenchRequest) error {
+	batchHeader, err := b.ChainClient.FetchBatchHeader(ctx, gcommon.HexToAddress(b.EigenDAServiceManagerAddr), req.BatchHeaderHash[:])
+	if err != nil {
+		return fmt.Errorf("failed to fetch the batch header: %w", err)
+	}
+	_, err = b.RetrievalClient.RetrieveBlob(ctx, req.BatchHeaderHash, req.BlobIndex, uint(batchHeader.ReferenceBlockNumber), batchHeader.BlobHeadersRoot, req.QuorumID)
+	return err
+}
+
+// summarize returns the nearest-rank percentiles of the durations
+func summarize(name string, durations []time.Duration) BenchSummary {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p*float64(len(durations)))) - 1
+		return float64(durations[max(rank, 0)]) / float64(time.Millisecond)
+	}
+	return BenchSummary{
+		Name:  name,
+		Count: len(durations),
+		MinMs: float64(durations[0]) / float64(time.Millisecond),
+		P50Ms: percentile(0.5),
+		P99Ms: percentile(0.99),
+	}
+}
+
+// Print writes the report to w in a human-readable form
+func (r *BenchReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "iterations: %d, failures: %d\n", r.Iterations, r.Failures)
+	for _, err := range r.Errors {
+		fmt.Fprintf(w, "  %s\n", err)
+	}
+	printSummaries := func(title string, summaries []BenchSummary) {
+		if len(summaries) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s:\n", title)
+		for _, s := range summaries {
+			fmt.Fprintf(w, "  %-20s n=%-4d min %9.1fms  p50 %9.1fms  p99 %9.1fms\n", s.Name, s.Count, s.MinMs, s.P50Ms, s.P99Ms)
+		}
+	}
+	printSummaries("phases", r.Phases)
+	printSummaries("chunk downloads by operator", r.Operators)
+}
+
+// WriteJSON writes the report to w as a single line of JSON
+func (r *BenchReport) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
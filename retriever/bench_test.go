@@ -0,0 +1,189 @@
+package retriever_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigenda/retriever/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// benchBlob is a blob encoded for the operators of the mocked chain state, in a batch of its own
+type benchBlob struct {
+	header          *core.BlobHeader
+	encoded         core.EncodedBlob
+	batchRoot       [32]byte
+	batchHeaderHash [32]byte
+}
+
+func encodeBenchBlob(t *testing.T, encoder core.Encoder, state core.IndexedChainState) *benchBlob {
+	const quantizationFactor = 2
+	const adversaryThreshold, quorumThreshold = 80, 90
+	operatorState, err := state.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	assert.NoError(t, err)
+	coordinator := &core.StdAssignmentCoordinator{}
+	assignments, info, err := coordinator.GetAssignments(operatorState, 0, quantizationFactor)
+	assert.NoError(t, err)
+	numOperators := uint(len(operatorState.Operators[0]))
+	chunkLength, err := coordinator.GetMinimumChunkLength(numOperators, core.GetBlobLength(uint(len(gettysburgAddressBytes))), quantizationFactor, quorumThreshold, adversaryThreshold)
+	assert.NoError(t, err)
+	params, err := core.GetEncodingParams(chunkLength, info.TotalChunks)
+	assert.NoError(t, err)
+	commitments, chunks, err := encoder.Encode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	blob := &benchBlob{
+		header: &core.BlobHeader{
+			BlobCommitments: commitments,
+			QuorumInfos: []*core.BlobQuorumInfo{{
+				SecurityParam:      core.SecurityParam{QuorumID: 0, AdversaryThreshold: adversaryThreshold},
+				QuantizationFactor: quantizationFactor,
+				EncodedBlobLength:  quantizationFactor * chunkLength * numOperators,
+			}},
+		},
+		encoded: make(core.EncodedBlob),
+	}
+	blobHeaderHash, err := blob.header.GetBlobHeaderHash()
+	assert.NoError(t, err)
+	tree, err := merkletree.NewTree(merkletree.WithData([][]byte{blobHeaderHash[:]}), merkletree.WithHashType(keccak256.New()))
+	assert.NoError(t, err)
+	copy(blob.batchRoot[:], tree.Root())
+	blob.batchHeaderHash, err = core.BatchHeader{BatchRoot: blob.batchRoot}.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	for id, assignment := range assignments {
+		blob.encoded[id] = &core.BlobMessage{
+			BlobHeader: blob.header,
+			Bundles:    map[core.QuorumID]core.Bundle{0: chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks]},
+		}
+	}
+	return blob
+}
+
+// benchNodeClient serves the blob header and the chunks of the blob after a delay, and fails to serve the chunks of
+// the retrievals after the first ones
+type benchNodeClient struct {
+	delay   time.Duration
+	blob    *benchBlob
+	succeed int32
+	// retrievals counts the blob header requests, of which there is one per retrieval as the first operator serves it
+	retrievals atomic.Int32
+}
+
+func (c *benchNodeClient) GetBlobHeader(ctx context.Context, socket string, batchHeaderHash [32]byte, blobIndex uint32) (*core.BlobHeader, *merkletree.Proof, error) {
+	c.retrievals.Add(1)
+	time.Sleep(c.delay)
+	return c.blob.header, &merkletree.Proof{}, nil
+}
+
+func (c *benchNodeClient) Close() error {
+	return nil
+}
+
+func (c *benchNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	time.Sleep(c.delay)
+	if c.retrievals.Load() > c.succeed {
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: errors.New("connection refused")}
+		return
+	}
+	chunksChan <- clients.RetrievedChunks{OperatorID: opID, Chunks: c.blob.encoded[opID].Bundles[quorumID]}
+}
+
+func TestBench(t *testing.T) {
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(numOperators))
+	assert.NoError(t, err)
+	encoder, err := makeTestEncoder()
+	assert.NoError(t, err)
+	blob := encodeBenchBlob(t, encoder, chainState)
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{BlobHeadersRoot: blob.batchRoot}, nil)
+
+	// The retrieval client times its phases through its dependencies wrapped by the timer, as the bench command
+	// builds it
+	const delay = 5 * time.Millisecond
+	timer := retriever.NewBenchTimer()
+	nodeClient := &benchNodeClient{delay: delay, blob: blob, succeed: 3}
+	retrievalClient := clients.NewRetrievalClient(
+		&commock.Logger{},
+		timer.WrapIndexedChainState(chainState),
+		&core.StdAssignmentCoordinator{},
+		timer.WrapNodeClient(nodeClient),
+		timer.WrapEncoder(encoder),
+		numOperators)
+	bench := &retriever.Bench{
+		RetrievalClient: retrievalClient,
+		ChainClient:     timer.WrapChainClient(chainClient),
+		Timer:           timer,
+	}
+
+	report := bench.Run(context.Background(), retriever.BenchRequest{BatchHeaderHash: blob.batchHeaderHash, Iterations: 4})
+	assert.Equal(t, 4, report.Iterations)
+	assert.Equal(t, 1, report.Failures)
+	if assert.Len(t, report.Errors, 1) {
+		assert.Contains(t, report.Errors[0], "iteration 4:")
+	}
+
+	// The phases are timed across the successful iterations only
+	phases := make(map[string]retriever.BenchSummary)
+	for _, summary := range report.Phases {
+		phases[summary.Name] = summary
+	}
+	for _, phase := range []string{retriever.PhaseBatchHeader, retriever.PhaseOperatorState, retriever.PhaseBlobHeader, retriever.PhaseChunks, retriever.PhaseVerification, retriever.PhaseDecode, retriever.PhaseTotal} {
+		assert.Equal(t, 3, phases[phase].Count, phase)
+	}
+	minMs := float64(delay) / float64(time.Millisecond)
+	assert.GreaterOrEqual(t, phases[retriever.PhaseBlobHeader].MinMs, minMs)
+	assert.GreaterOrEqual(t, phases[retriever.PhaseChunks].MinMs, minMs)
+	assert.Positive(t, phases[retriever.PhaseVerification].MinMs)
+	assert.Positive(t, phases[retriever.PhaseDecode].MinMs)
+	assert.GreaterOrEqual(t, phases[retriever.PhaseTotal].MinMs, 2*minMs)
+	assert.LessOrEqual(t, phases[retriever.PhaseTotal].MinMs, phases[retriever.PhaseTotal].P50Ms)
+	assert.LessOrEqual(t, phases[retriever.PhaseTotal].P50Ms, phases[retriever.PhaseTotal].P99Ms)
+
+	// The chunk downloads are timed by operator, for each operator the retrieval client fetched chunks from
+	if assert.Len(t, report.Operators, numOperators) {
+		for i := 1; i < len(report.Operators); i++ {
+			assert.Less(t, report.Operators[i-1].Name, report.Operators[i].Name)
+		}
+		assert.Equal(t, 3, report.Operators[0].Count)
+		assert.GreaterOrEqual(t, report.Operators[0].MinMs, minMs)
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, report.WriteJSON(&out))
+	var decoded retriever.BenchReport
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, *report, decoded)
+	out.Reset()
+	report.Print(&out)
+	assert.Contains(t, out.String(), "iterations: 4, failures: 1")
+	assert.Contains(t, out.String(), "chunk downloads by operator")
+}
+
+func TestBenchInterrupted(t *testing.T) {
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{}, nil)
+	bench := &retriever.Bench{
+		RetrievalClient: &clientsmock.MockRetrievalClient{},
+		ChainClient:     chainClient,
+		Timer:           retriever.NewBenchTimer(),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := bench.Run(ctx, retriever.BenchRequest{Iterations: 3})
+	assert.Zero(t, report.Iterations)
+	assert.Empty(t, report.Phases)
+}
@@ -0,0 +1,248 @@
+package retriever
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// BlobSink stores the blobs retrieved by the retriever, e.g. for archival
+type BlobSink interface {
+	StoreBlob(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, data []byte) error
+}
+
+// S3BlobSink stores the blobs in an S3-compatible bucket, under the keys returned by BlobKey
+type S3BlobSink struct {
+	client s3.Client
+	bucket string
+}
+
+var _ BlobSink = (*S3BlobSink)(nil)
+
+func NewS3BlobSink(client s3.Client, bucket string) *S3BlobSink {
+	return &S3BlobSink{client: client, bucket: bucket}
+}
+
+func (s *S3BlobSink) StoreBlob(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, data []byte) error {
+	return s.client.UploadObject(ctx, s.bucket, BlobKey(batchHeaderHash, blobIndex), bytes.NewReader(data))
+}
+
+// BlobKey returns the key of a blob in the bucket, <hex batch header hash>/<blob index>, so that the blobs of a
+// batch share a prefix
+func BlobKey(batchHeaderHash [32]byte, blobIndex uint32) string {
+	return fmt.Sprintf("%x/%d", batchHeaderHash, blobIndex)
+}
+
+// BlobArchiver writes the blobs the retriever retrieves to a sink. The writes are best-effort: they are queued
+// for a fixed number of workers, and their failures are logged and metered without failing the retrieval. The
+// writes are dropped, and counted, when the queue is full, so that a slow sink doesn't pile up the blobs in memory.
+// With synchronous writes, the retrieval instead waits for the write and fails if it fails, so that the blobs
+// returned to the clients are the archived ones.
+type BlobArchiver struct {
+	sink    BlobSink
+	sync    bool
+	timeout time.Duration
+	metrics *Metrics
+	logger  common.Logger
+
+	// writes is the queue of the writes in the background, which the workers take from
+	writes  chan blobWrite
+	workers sync.WaitGroup
+}
+
+type blobWrite struct {
+	logger          common.Logger
+	batchHeaderHash [32]byte
+	blobIndex       uint32
+	data            []byte
+}
+
+// NewBlobArchiver creates an archiver whose writes time out after the timeout of the config, or never if it is 0.
+// Unless the writes are synchronous, it starts the workers of the config, at least one, which write the queued
+// blobs until the archiver is closed.
+func NewBlobArchiver(sink BlobSink, config *BlobSinkConfig, metrics *Metrics, logger common.Logger) *BlobArchiver {
+	a := &BlobArchiver{
+		sink:    sink,
+		sync:    config.Sync,
+		timeout: config.Timeout,
+		metrics: metrics,
+		logger:  logger,
+	}
+	if a.sync {
+		return a
+	}
+	a.writes = make(chan blobWrite, config.QueueSize)
+	for i := 0; i < max(config.Workers, 1); i++ {
+		a.workers.Add(1)
+		go func() {
+			defer a.workers.Done()
+			for w := range a.writes {
+				_ = a.store(common.ContextWithLogger(context.Background(), w.logger), w.batchHeaderHash, w.blobIndex, w.data)
+			}
+		}()
+	}
+	return a
+}
+
+// Archive writes the blob to the sink, in the background unless the writes are synchronous
+func (a *BlobArchiver) Archive(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, data []byte) error {
+	if a.sync {
+		return a.store(ctx, batchHeaderHash, blobIndex, data)
+	}
+
+	// The write outlives the request, so it doesn't inherit its cancellation, only its logger
+	logger := common.LoggerFromContext(ctx, a.logger)
+	select {
+	case a.writes <- blobWrite{logger: logger, batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, data: data}:
+	default:
+		logger.Warn("dropped the write of a blob to the sink, as the queue of the writes is full", "batchHeaderHash", batchHeaderHash, "blobIndex", blobIndex)
+		a.metrics.IncrementBlobSinkDropCounter()
+	}
+	return nil
+}
+
+// Close stops taking writes and waits for the queued ones to finish, or for the context to be done, in which case
+// the remaining writes are abandoned. The archiver must not be used after it's closed.
+func (a *BlobArchiver) Close(ctx context.Context) error {
+	if a.sync {
+		return nil
+	}
+	close(a.writes)
+	drained := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain the writes to the blob sink: %w", ctx.Err())
+	}
+}
+
+func (a *BlobArchiver) store(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, data []byte) error {
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+	err := a.sink.StoreBlob(ctx, batchHeaderHash, blobIndex, data)
+	if err != nil {
+		common.LoggerFromContext(ctx, a.logger).Error("failed to write blob to the sink", "batchHeaderHash", batchHeaderHash, "blobIndex", blobIndex, "err", err)
+		a.metrics.IncrementBlobSinkWriteCounter(false)
+		return fmt.Errorf("failed to write blob to the sink: %w", err)
+	}
+	a.metrics.IncrementBlobSinkWriteCounter(true)
+	return nil
+}
+
+// WrapRetrievalClient returns the retrieval client with the retrieved blobs archived
+func (a *BlobArchiver) WrapRetrievalClient(client clients.RetrievalClient) clients.RetrievalClient {
+	return &archivingRetrievalClient{
+		RetrievalClient: client,
+		archiver:        a,
+	}
+}
+
+type archivingRetrievalClient struct {
+	clients.RetrievalClient
+	archiver *BlobArchiver
+}
+
+func (c *archivingRetrievalClient) RetrieveBlob(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, error) {
+	data, err := c.RetrievalClient.RetrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.archiver.Archive(ctx, batchHeaderHash, blobIndex, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *archivingRetrievalClient) RetrieveBlobWithContributions(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, error) {
+	data, contributions, err := c.RetrievalClient.RetrieveBlobWithContributions(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.archiver.Archive(ctx, batchHeaderHash, blobIndex, data); err != nil {
+		return nil, nil, err
+	}
+	return data, contributions, nil
+}
+
+func (c *archivingRetrievalClient) RetrieveBlobWithInclusionProof(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, *clients.BlobInclusionProof, error) {
+	data, contributions, proof, err := c.RetrievalClient.RetrieveBlobWithInclusionProof(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := c.archiver.Archive(ctx, batchHeaderHash, blobIndex, data); err != nil {
+		return nil, nil, nil, err
+	}
+	return data, contributions, proof, nil
+}
+
+func (c *archivingRetrievalClient) RetrieveBlobWithDiagnostics(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, *clients.BlobInclusionProof, *clients.RetrievalDiagnostics, error) {
+	data, contributions, proof, diagnostics, err := c.RetrievalClient.RetrieveBlobWithDiagnostics(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, nil, diagnostics, err
+	}
+	if err := c.archiver.Archive(ctx, batchHeaderHash, blobIndex, data); err != nil {
+		return nil, nil, nil, diagnostics, err
+	}
+	return data, contributions, proof, diagnostics, nil
+}
+
+// RetrieveBlobs archives each blob as it's retrieved, and fails the blobs that fail to be archived as RetrieveBlob does
+func (c *archivingRetrievalClient) RetrieveBlobs(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	blobs []clients.BlobRetrieval) <-chan clients.BlobRetrievalResult {
+	results := make(chan clients.BlobRetrievalResult, len(blobs))
+	go func() {
+		defer close(results)
+		for result := range c.RetrievalClient.RetrieveBlobs(ctx, batchHeaderHash, referenceBlockNumber, batchRoot, blobs) {
+			if result.Err == nil {
+				if err := c.archiver.Archive(ctx, batchHeaderHash, blobs[result.Index].BlobIndex, result.Data); err != nil {
+					result = clients.BlobRetrievalResult{Index: result.Index, Err: err}
+				}
+			}
+			results <- result
+		}
+	}()
+	return results
+}
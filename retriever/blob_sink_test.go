@@ -0,0 +1,166 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+var errSink = errors.New("bucket unavailable")
+
+// failingSink fails the writes, after blocking them until unblock is closed if it is set
+type failingSink struct {
+	unblock  chan struct{}
+	numCalls atomic.Int32
+}
+
+func (s *failingSink) StoreBlob(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, data []byte) error {
+	s.numCalls.Add(1)
+	if s.unblock != nil {
+		<-s.unblock
+	}
+	return errSink
+}
+
+func TestBlobKey(t *testing.T) {
+	hash := [32]byte{0xab, 0xcd}
+	assert.Equal(t, "abcd000000000000000000000000000000000000000000000000000000000000/7", retriever.BlobKey(hash, 7))
+}
+
+func TestBlobArchiverAsync(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	s3Client := commock.NewS3Client()
+	archiver := retriever.NewBlobArchiver(retriever.NewS3BlobSink(s3Client, "archive"), &retriever.BlobSinkConfig{Timeout: time.Second, Workers: 2, QueueSize: 10}, metrics, logger)
+
+	mockClient := clientsmock.NewRetrievalClient()
+	mockClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	mockClient.On("RetrieveBlobWithContributions").Return([]byte("other blob"), []clients.OperatorContribution{{NumChunks: 1}}, nil)
+	mockClient.On("RetrieveBlobWithInclusionProof").Return([]byte("proven blob"), nil, &clients.BlobInclusionProof{}, nil)
+	client := archiver.WrapRetrievalClient(mockClient)
+
+	data, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, data)
+	data, contributions, err := client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 1, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("other blob"), data)
+	assert.Len(t, contributions, 1)
+	data, _, proof, err := client.RetrieveBlobWithInclusionProof(context.Background(), batchHeaderHash, 2, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("proven blob"), data)
+	assert.NotNil(t, proof)
+
+	assert.NoError(t, archiver.Close(context.Background()))
+	assert.Equal(t, gettysburgAddressBytes, downloadObject(t, s3Client, "archive", retriever.BlobKey(batchHeaderHash, 0)))
+	assert.Equal(t, []byte("other blob"), downloadObject(t, s3Client, "archive", retriever.BlobKey(batchHeaderHash, 1)))
+	assert.Equal(t, []byte("proven blob"), downloadObject(t, s3Client, "archive", retriever.BlobKey(batchHeaderHash, 2)))
+	assert.Equal(t, 3.0, counterValue(metrics.NumBlobSinkWrites, "success"))
+}
+
+func TestBlobArchiverAsyncFailure(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	sink := &failingSink{unblock: make(chan struct{})}
+	archiver := retriever.NewBlobArchiver(sink, &retriever.BlobSinkConfig{Timeout: time.Second, Workers: 1, QueueSize: 10}, metrics, logger)
+	mockClient := clientsmock.NewRetrievalClient()
+	mockClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+
+	// The reply doesn't wait for the write, whose failure doesn't fail the request
+	data, err := archiver.WrapRetrievalClient(mockClient).RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, data)
+
+	close(sink.unblock)
+	assert.NoError(t, archiver.Close(context.Background()))
+	assert.Equal(t, 1.0, counterValue(metrics.NumBlobSinkWrites, "failure"))
+	assert.Equal(t, 0.0, counterValue(metrics.NumBlobSinkWrites, "success"))
+}
+
+func TestBlobArchiverQueueFull(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	sink := &failingSink{unblock: make(chan struct{})}
+	archiver := retriever.NewBlobArchiver(sink, &retriever.BlobSinkConfig{Workers: 1, QueueSize: 1}, metrics, logger)
+	mockClient := clientsmock.NewRetrievalClient()
+	mockClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	client := archiver.WrapRetrievalClient(mockClient)
+
+	// The worker is blocked on the first write and the second one is queued, so the others are dropped once the
+	// worker took the first
+	for i := 0; i < 4; i++ {
+		_, err := client.RetrieveBlob(context.Background(), batchHeaderHash, uint32(i), 0, batchRoot, 0)
+		assert.NoError(t, err)
+		if i == 0 {
+			assert.Eventually(t, func() bool { return sink.numCalls.Load() == 1 }, time.Second, time.Millisecond)
+		}
+	}
+	assert.Equal(t, 2.0, counterValue(metrics.NumBlobSinkWrites, "dropped"))
+
+	// The writes that are still blocked when the archiver is closed are abandoned
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, archiver.Close(ctx), context.DeadlineExceeded)
+
+	// And the queued ones are drained otherwise
+	close(sink.unblock)
+	assert.Eventually(t, func() bool { return counterValue(metrics.NumBlobSinkWrites, "failure") == 2 }, time.Second, time.Millisecond)
+}
+
+func TestBlobArchiverSync(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	s3Client := commock.NewS3Client()
+	mockClient := clientsmock.NewRetrievalClient()
+	mockClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+
+	// The blob is written by the time the request returns
+	archiver := retriever.NewBlobArchiver(retriever.NewS3BlobSink(s3Client, "archive"), &retriever.BlobSinkConfig{Sync: true, Timeout: time.Second}, metrics, logger)
+	_, err := archiver.WrapRetrievalClient(mockClient).RetrieveBlob(context.Background(), batchHeaderHash, 3, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, downloadObject(t, s3Client, "archive", retriever.BlobKey(batchHeaderHash, 3)))
+
+	// A failed write fails the request
+	archiver = retriever.NewBlobArchiver(&failingSink{}, &retriever.BlobSinkConfig{Sync: true, Timeout: time.Second}, metrics, logger)
+	_, err = archiver.WrapRetrievalClient(mockClient).RetrieveBlob(context.Background(), batchHeaderHash, 3, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, errSink)
+	assert.Equal(t, 1.0, counterValue(metrics.NumBlobSinkWrites, "success"))
+	assert.Equal(t, 1.0, counterValue(metrics.NumBlobSinkWrites, "failure"))
+}
+
+func TestBlobArchiverRetrievalFailure(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	s3Client := commock.NewS3Client()
+	archiver := retriever.NewBlobArchiver(retriever.NewS3BlobSink(s3Client, "archive"), &retriever.BlobSinkConfig{Sync: true, Timeout: time.Second}, metrics, logger)
+	mockClient := clientsmock.NewRetrievalClient()
+	mockClient.On("RetrieveBlob").Return([]byte(nil), errors.New("not enough chunks"))
+
+	_, err := archiver.WrapRetrievalClient(mockClient).RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorContains(t, err, "not enough chunks")
+	objects, err := s3Client.ListObjects(context.Background(), "archive", "")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+// downloadObject returns the content of the object of the store
+func downloadObject(t *testing.T, client s3.Client, bucket string, key string) []byte {
+	content, err := client.DownloadObject(context.Background(), bucket, key)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	return data
+}
@@ -0,0 +1,107 @@
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+)
+
+// captureExtension is the extension of the files of the reconstruction captures
+const captureExtension = ".capture"
+
+// CaptureDir writes the captures of the failed reconstructions to a directory, one file per failure, until the files
+// reach the size cap. The captures hold all the chunks a blob was reconstructed from, i.e. more than the size of the
+// blob, so failures that repeat fill the cap quickly: the directory is meant to be emptied once the captures are
+// replayed, which resumes the capture.
+type CaptureDir struct {
+	dir      string
+	maxBytes uint64
+	logger   common.Logger
+
+	mu sync.Mutex
+	// size is the size of the captures in the directory, including those of the previous runs
+	size uint64
+}
+
+var _ clients.ReconstructionCapturer = (*CaptureDir)(nil)
+
+// NewCaptureDir creates the directory of the captures if it doesn't exist, and counts the captures already in it
+// towards the size cap
+func NewCaptureDir(dir string, maxBytes uint64, logger common.Logger) (*CaptureDir, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the capture directory: %w", err)
+	}
+	size, err := capturesSize(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &CaptureDir{dir: dir, maxBytes: maxBytes, logger: logger, size: size}, nil
+}
+
+// CaptureReconstruction writes the capture to <hex batch header hash>-<blob index>-<quorum>-<unix nanos>.capture,
+// unless it would exceed the size cap. The failures to write are logged, as the retrieval failed already.
+func (d *CaptureDir) CaptureReconstruction(ctx context.Context, capture *clients.ReconstructionCapture) {
+	logger := common.LoggerFromContext(ctx, d.logger)
+	data, err := capture.Encode()
+	if err != nil {
+		logger.Warn("Failed to encode the capture of the failed reconstruction", "err", err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size+uint64(len(data)) > d.maxBytes {
+		// The captures may have been removed since
+		if size, err := capturesSize(d.dir); err == nil {
+			d.size = size
+		}
+	}
+	if d.size+uint64(len(data)) > d.maxBytes {
+		logger.Warn("Not capturing the failed reconstruction, as the capture directory is full", "dir", d.dir, "size", d.size, "captureSize", len(data), "maxBytes", d.maxBytes)
+		return
+	}
+	name := fmt.Sprintf("%x-%d-%d-%d%s", capture.BatchHeaderHash, capture.BlobIndex, capture.QuorumID, time.Now().UnixNano(), captureExtension)
+	path := filepath.Join(d.dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logger.Warn("Failed to write the capture of the failed reconstruction", "path", path, "err", err)
+		return
+	}
+	d.size += uint64(len(data))
+	logger.Info("Captured the failed reconstruction", "path", path, "size", len(data), "numChunks", len(capture.Chunks))
+}
+
+// capturesSize returns the total size of the captures in the directory
+func capturesSize(dir string) (uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the capture directory: %w", err)
+	}
+	var size uint64
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), captureExtension) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		size += uint64(info.Size())
+	}
+	return size, nil
+}
+
+// ReadCapture reads a capture written by CaptureReconstruction, which clients.ReplayReconstruction replays
+func ReadCapture(path string) (*clients.ReconstructionCapture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return clients.DecodeReconstructionCapture(data)
+}
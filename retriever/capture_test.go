@@ -0,0 +1,58 @@
+package retriever_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "captures")
+	capture := &clients.ReconstructionCapture{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       3,
+		QuorumID:        1,
+		Commitments:     core.BlobCommitments{Length: 16},
+		Params:          core.EncodingParams{ChunkLength: 2, NumChunks: 16},
+		Indices:         []core.ChunkNumber{0, 5},
+		Err:             "commitment mismatch",
+	}
+	data, err := capture.Encode()
+	assert.NoError(t, err)
+
+	// The cap fits two captures
+	captureDir, err := retriever.NewCaptureDir(dir, uint64(2*len(data)), &commock.Logger{})
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		captureDir.CaptureReconstruction(context.Background(), capture)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.capture"))
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.Contains(t, filepath.Base(paths[0]), "-3-1-")
+
+	read, err := retriever.ReadCapture(paths[0])
+	assert.NoError(t, err)
+	assert.Equal(t, capture, read)
+
+	// The captures of the previous runs count towards the cap, until they are removed
+	captureDir, err = retriever.NewCaptureDir(dir, uint64(2*len(data)), &commock.Logger{})
+	assert.NoError(t, err)
+	captureDir.CaptureReconstruction(context.Background(), capture)
+	paths, err = filepath.Glob(filepath.Join(dir, "*.capture"))
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+
+	assert.NoError(t, os.Remove(paths[0]))
+	captureDir.CaptureReconstruction(context.Background(), capture)
+	paths, err = filepath.Glob(filepath.Join(dir, "*.capture"))
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+}
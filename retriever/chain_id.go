@@ -0,0 +1,27 @@
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+)
+
+// CheckChainID logs the chain ID of the eth RPC and checks that it's the expected one, so that the retriever doesn't
+// read the contracts of its config from another network. The chain ID isn't checked if the expected one is 0, and the
+// check fails if the RPC doesn't reply within the timeout, so that an unreachable RPC doesn't hang the startup.
+func CheckChainID(ctx context.Context, ethClient common.EthClient, expected uint64, timeout time.Duration, logger common.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the chain ID of the eth RPC: %w", err)
+	}
+	logger.Info("Detected the chain ID of the eth RPC", "chainID", chainID)
+	if expected != 0 && chainID.Cmp(new(big.Int).SetUint64(expected)) != 0 {
+		return fmt.Errorf("the eth RPC is on chain %s, not on the expected chain %d", chainID, expected)
+	}
+	return nil
+}
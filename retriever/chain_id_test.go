@@ -0,0 +1,44 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckChainID(t *testing.T) {
+	ethClient := &commock.MockEthClient{}
+	ethClient.On("ChainID").Return(big.NewInt(17000), nil)
+	logger := &commock.Logger{}
+
+	assert.NoError(t, retriever.CheckChainID(context.Background(), ethClient, 17000, time.Second, logger))
+	// The chain ID is only logged without an expected one
+	assert.NoError(t, retriever.CheckChainID(context.Background(), ethClient, 0, time.Second, logger))
+	err := retriever.CheckChainID(context.Background(), ethClient, 1, time.Second, logger)
+	assert.ErrorContains(t, err, "the eth RPC is on chain 17000, not on the expected chain 1")
+
+	ethClient = &commock.MockEthClient{}
+	ethClient.On("ChainID").Return((*big.Int)(nil), errors.New("connection refused"))
+	err = retriever.CheckChainID(context.Background(), ethClient, 17000, time.Second, logger)
+	assert.ErrorContains(t, err, "connection refused")
+
+	// An RPC that doesn't reply fails the check once the timeout expires
+	err = retriever.CheckChainID(context.Background(), &hangingEthClient{}, 17000, 50*time.Millisecond, logger)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// hangingEthClient never replies to the requests of the chain ID
+type hangingEthClient struct {
+	commock.MockEthClient
+}
+
+func (c *hangingEthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -0,0 +1,136 @@
+package retriever
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever/eth"
+	gcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	operatorStateRead = "operator_state"
+	batchHeaderRead   = "batch_header"
+	blobCertRead      = "blob_cert"
+)
+
+// ChainReadRetrier retries the on-chain reads of the retrieval path, i.e. the lookups of the operator state and of
+// the batch header and the verifications of the certs, so that transient eth RPC failures don't fail the whole
+// retrieval. These retries are separate from the requests to the DA nodes.
+// The backoff doubles after every attempt. A retry is not attempted if the context of the read would be done
+// before the backoff elapses.
+type ChainReadRetrier struct {
+	numRetries     int
+	initialBackoff time.Duration
+	metrics        *Metrics
+	logger         common.Logger
+}
+
+func NewChainReadRetrier(numRetries int, initialBackoff time.Duration, metrics *Metrics, logger common.Logger) *ChainReadRetrier {
+	return &ChainReadRetrier{
+		numRetries:     numRetries,
+		initialBackoff: initialBackoff,
+		metrics:        metrics,
+		logger:         logger,
+	}
+}
+
+// Do calls read until it succeeds, the retries are exhausted or the context is done, and returns the last error
+func (r *ChainReadRetrier) Do(ctx context.Context, name string, read func() error) error {
+	backoff := r.initialBackoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = read()
+		if err == nil || attempt >= r.numRetries || ctx.Err() != nil {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return err
+		}
+
+		common.LoggerFromContext(ctx, r.logger).Warn("on-chain read failed, retrying", "read", name, "attempt", attempt+1, "backoff", backoff, "err", err)
+		r.metrics.IncrementChainReadRetryCounter(name)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// WrapIndexedChainState returns the chain state with the operator state lookups retried
+func (r *ChainReadRetrier) WrapIndexedChainState(state core.IndexedChainState) core.IndexedChainState {
+	return &retryingIndexedChainState{
+		IndexedChainState: state,
+		retrier:           r,
+	}
+}
+
+// WrapChainClient returns the chain client with the batch header lookups and the cert verifications retried
+func (r *ChainReadRetrier) WrapChainClient(client eth.ChainClient) eth.ChainClient {
+	return &retryingChainClient{
+		ChainClient: client,
+		retrier:     r,
+	}
+}
+
+type retryingIndexedChainState struct {
+	core.IndexedChainState
+	retrier *ChainReadRetrier
+}
+
+func (s *retryingIndexedChainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	var state *core.OperatorState
+	err := s.retrier.Do(ctx, operatorStateRead, func() error {
+		var err error
+		state, err = s.IndexedChainState.GetOperatorState(ctx, blockNumber, quorums)
+		return err
+	})
+	return state, err
+}
+
+func (s *retryingIndexedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	var state *core.IndexedOperatorState
+	err := s.retrier.Do(ctx, operatorStateRead, func() error {
+		var err error
+		state, err = s.IndexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+		return err
+	})
+	return state, err
+}
+
+type retryingChainClient struct {
+	eth.ChainClient
+	retrier *ChainReadRetrier
+}
+
+func (c *retryingChainClient) FetchBatchHeader(ctx context.Context, serviceManagerAddress gcommon.Address, batchHeaderHash []byte) (*binding.IEigenDAServiceManagerBatchHeader, error) {
+	var batchHeader *binding.IEigenDAServiceManagerBatchHeader
+	err := c.retrier.Do(ctx, batchHeaderRead, func() error {
+		var err error
+		batchHeader, err = c.ChainClient.FetchBatchHeader(ctx, serviceManagerAddress, batchHeaderHash)
+		return err
+	})
+	return batchHeader, err
+}
+
+// VerifyBlobCert retries the verification of the cert, except if the cert was found invalid
+func (c *retryingChainClient) VerifyBlobCert(ctx context.Context, serviceManagerAddress gcommon.Address, cert *clients.BlobCert) error {
+	var verifyErr error
+	err := c.retrier.Do(ctx, blobCertRead, func() error {
+		verifyErr = c.ChainClient.VerifyBlobCert(ctx, serviceManagerAddress, cert)
+		if invalidBlobCert(verifyErr) {
+			return nil
+		}
+		return verifyErr
+	})
+	if err != nil {
+		return err
+	}
+	return verifyErr
+}
@@ -0,0 +1,83 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigenda/retriever/mock"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var errRPC = errors.New("transient rpc error")
+
+func TestChainReadRetries(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	retrier := retriever.NewChainReadRetrier(2, time.Millisecond, metrics, logger)
+
+	batchHeader := &binding.IEigenDAServiceManagerBatchHeader{ReferenceBlockNumber: 10}
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return((*binding.IEigenDAServiceManagerBatchHeader)(nil), errRPC).Twice()
+	chainClient.On("FetchBatchHeader").Return(batchHeader, nil).Once()
+
+	fetched, err := retrier.WrapChainClient(chainClient).FetchBatchHeader(context.Background(), gcommon.Address{}, batchHeaderHash[:])
+	assert.NoError(t, err)
+	assert.Equal(t, batchHeader, fetched)
+	chainClient.AssertNumberOfCalls(t, "FetchBatchHeader", 3)
+	assert.Equal(t, 2.0, counterValue(metrics.NumChainReadRetries, "batch_header"))
+
+	// The read fails once the retries are exhausted
+	chainClient = mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return((*binding.IEigenDAServiceManagerBatchHeader)(nil), errRPC)
+	_, err = retrier.WrapChainClient(chainClient).FetchBatchHeader(context.Background(), gcommon.Address{}, batchHeaderHash[:])
+	assert.ErrorIs(t, err, errRPC)
+	chainClient.AssertNumberOfCalls(t, "FetchBatchHeader", 3)
+}
+
+func TestChainReadRetriesRespectDeadline(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	retrier := retriever.NewChainReadRetrier(5, time.Minute, metrics, logger)
+
+	// The backoff is longer than the time left before the deadline, so the read isn't retried
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	numReads := 0
+	start := time.Now()
+	err := retrier.Do(ctx, "operator_state", func() error {
+		numReads++
+		return errRPC
+	})
+	assert.ErrorIs(t, err, errRPC)
+	assert.Equal(t, 1, numReads)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 0.0, counterValue(metrics.NumChainReadRetries, "operator_state"))
+}
+
+func TestBlobCertVerificationRetries(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	retrier := retriever.NewChainReadRetrier(2, time.Millisecond, metrics, logger)
+
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("VerifyBlobCert").Return(errRPC).Once()
+	chainClient.On("VerifyBlobCert").Return(nil).Once()
+	assert.NoError(t, retrier.WrapChainClient(chainClient).VerifyBlobCert(context.Background(), gcommon.Address{}, &clients.BlobCert{}))
+	chainClient.AssertNumberOfCalls(t, "VerifyBlobCert", 2)
+	assert.Equal(t, 1.0, counterValue(metrics.NumChainReadRetries, "blob_cert"))
+
+	// An invalid cert isn't verified again
+	chainClient = mock.NewMockChainClient()
+	chainClient.On("VerifyBlobCert").Return(fmt.Errorf("%w: batch 37", clients.ErrBatchNotConfirmed))
+	err := retrier.WrapChainClient(chainClient).VerifyBlobCert(context.Background(), gcommon.Address{}, &clients.BlobCert{})
+	assert.ErrorIs(t, err, clients.ErrBatchNotConfirmed)
+	chainClient.AssertNumberOfCalls(t, "VerifyBlobCert", 1)
+}
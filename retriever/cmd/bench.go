@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigenda/retriever/flags"
+	"github.com/urfave/cli"
+)
+
+// benchCommand retrieves a blob repeatedly with the retrieval path of the retriever, without serving it, and reports
+// the timings of the phases of the retrievals. It fails if any retrieval fails, so that it can run as a canary.
+var benchCommand = cli.Command{
+	Name:   "bench",
+	Usage:  "time the retrievals of a blob by the retriever, without running the gRPC server",
+	Flags:  flags.BenchFlags,
+	Action: BenchMain,
+}
+
+func BenchMain(ctx *cli.Context) error {
+	hash, err := hex.DecodeString(strings.TrimPrefix(ctx.String(flags.BenchBatchHeaderHashFlag.Name), "0x"))
+	if err != nil || len(hash) != 32 {
+		return fmt.Errorf("invalid %s: must be 32 hex-encoded bytes", flags.BenchBatchHeaderHashFlag.Name)
+	}
+	if ctx.Uint(flags.BenchQuorumIDFlag.Name) > 255 {
+		return fmt.Errorf("invalid %s: must be below 256", flags.BenchQuorumIDFlag.Name)
+	}
+	if ctx.Int(flags.BenchIterationsFlag.Name) < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", flags.BenchIterationsFlag.Name)
+	}
+	request := retriever.BenchRequest{
+		BlobIndex:  uint32(ctx.Uint(flags.BenchBlobIndexFlag.Name)),
+		QuorumID:   core.QuorumID(ctx.Uint(flags.BenchQuorumIDFlag.Name)),
+		Iterations: ctx.Int(flags.BenchIterationsFlag.Name),
+	}
+	copy(request.BatchHeaderHash[:], hash)
+
+	// The flags of the retriever belong to the context of the app rather than the one of the command
+	config, err := retriever.NewConfig(ctx.Parent())
+	if err != nil {
+		return err
+	}
+	logger, err := logging.GetLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+	timer := retriever.NewBenchTimer()
+	path, err := newRetrievalPath(config, logger, timer)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := path.close(); err != nil {
+			logger.Error("Failed to close the connections of the retrieval path", "err", err)
+		}
+	}()
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := path.indexedState.Start(runCtx); err != nil {
+		return fmt.Errorf("failed to start the chain state: %w", err)
+	}
+	bench := &retriever.Bench{
+		RetrievalClient:           path.retrievalClient,
+		ChainClient:               path.chainClient,
+		EigenDAServiceManagerAddr: config.EigenDAServiceManagerAddr,
+		Timer:                     timer,
+	}
+	logger.Info("Benchmarking the retrieval", "batchHeaderHash", hex.EncodeToString(hash), "blobIndex", request.BlobIndex, "quorum", request.QuorumID, "iterations", request.Iterations)
+	report := bench.Run(runCtx, request)
+
+	if ctx.Bool(flags.BenchJSONFlag.Name) {
+		if err := report.WriteJSON(ctx.App.Writer); err != nil {
+			return err
+		}
+	} else {
+		report.Print(ctx.App.Writer)
+	}
+	if report.Failures > 0 {
+		return fmt.Errorf("%d of the %d retrievals failed", report.Failures, report.Iterations)
+	}
+	if report.Iterations < request.Iterations {
+		return fmt.Errorf("interrupted after %d of the %d retrievals", report.Iterations, request.Iterations)
+	}
+	return nil
+}
@@ -2,114 +2,139 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
-	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
-	"github.com/Layr-Labs/eigenda/clients"
-	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/logging"
-	"github.com/Layr-Labs/eigenda/core"
-	"github.com/Layr-Labs/eigenda/core/encoding"
-	"github.com/Layr-Labs/eigenda/core/eth"
-	"github.com/Layr-Labs/eigenda/core/indexer"
-	"github.com/Layr-Labs/eigenda/indexer/inmem"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/retriever"
-	retrivereth "github.com/Layr-Labs/eigenda/retriever/eth"
 	"github.com/Layr-Labs/eigenda/retriever/flags"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-var (
-	Version   = ""
-	GitCommit = ""
-	GitDate   = ""
-)
+// archiveDrainTimeout bounds the wait for the queued writes to the blob sink on shutdown
+const archiveDrainTimeout = time.Minute
 
 func main() {
 	app := cli.NewApp()
-	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
 	app.Name = "retriever"
 	app.Usage = "EigenDA Retriever"
 	app.Description = "Service for collecting coded chunks and decode the original data"
 	app.Flags = flags.Flags
+	configfile.Enable(app)
+	version.Configure(app)
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return retriever.NewConfig(ctx)
+	})
+	app.Commands = append(app.Commands, benchCommand)
 	app.Action = RetrieverMain
 	if err := app.Run(os.Args); err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RetrieverMain(ctx *cli.Context) error {
 	log.Println("Initializing Retriever")
-	hostname := ctx.String(flags.HostnameFlag.Name)
-	port := ctx.String(flags.GrpcPortFlag.Name)
-	addr := fmt.Sprintf("%s:%s", hostname, port)
-	listener, err := net.Listen("tcp", addr)
+	config, err := retriever.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+	listeners, err := retriever.Listen(config.ListenAddresses)
 	if err != nil {
 		log.Fatalln("could not start tcp listener", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300)
-	gs := grpc.NewServer(
-		opt,
-		grpc.ChainUnaryInterceptor(
-		// TODO(ian-shim): Add interceptors
-		// correlation.UnaryServerInterceptor(),
-		// logger.UnaryServerInterceptor(*s.logger.Logger),
-		),
-	)
-
-	config := retriever.NewConfig(ctx)
 	logger, err := logging.GetLogger(config.LoggerConfig)
 	if err != nil {
 		return err
 	}
 
-	nodeClient := clients.NewNodeClient(config.Timeout)
-	encoder, err := encoding.NewEncoder(config.EncoderConfig)
-	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
-	}
-	gethClient, err := geth.NewClient(config.EthClientConfig, logger)
-	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+	logger.Info("Starting retriever", version.LogFields()...)
+	profiling.PublishBuildInfo()
+	profiling.Publish("config", map[string]any{
+		"listen_addresses":             config.ListenAddresses,
+		"num_connections":              config.NumConnections,
+		"timeout":                      config.Timeout.String(),
+		"metrics_backend":              config.MetricsConfig.Backend,
+		"metrics_prefix":               config.MetricsPrefix,
+		"continue_without_metrics":     config.ContinueWithoutMetrics,
+		"reconstruction_memory_budget": config.ReconstructionMemoryBudget,
+		"node_connect_backoff":         config.NodeConnectBackoff,
+		"node_connection_idle_timeout": config.NodeConnectionIdleTimeout.String(),
+		"max_operators_per_retrieval":  config.MaxOperatorsPerRetrieval,
+		"fan_out_weights":              config.FanOutWeights,
+		"unassigned_chunk_blacklist":   config.UnassignedChunkBlacklist,
+		"sequential_fetch":             config.SequentialFetch,
+		"tombstone_ttl":                config.TombstoneTTL.String(),
+		"response_size_buckets":        config.ResponseSizeBuckets,
+		"large_response_threshold":     config.LargeResponseThreshold,
+		"chain_state_backend":          config.ChainStateBackend,
+		"state_cache":                  config.StateCacheConfig,
+		"chunk_caps":                   config.ChunkCaps,
+		"reconstruction_thresholds":    config.ReconstructionThresholds,
+		"chunk_cache_size":             config.ChunkCacheSize,
+		"peers":                        config.Peers,
+		"max_peers_per_retrieval":      config.MaxPeersPerRetrieval,
+		"max_blobs_per_request":        config.MaxBlobsPerRequest,
+	})
+	if err := profiling.Start(context.Background(), config.MetricsConfig.Profiling, logger); err != nil {
+		return err
 	}
 
-	// TODO(ian-shim): uncomment when https://github.com/Layr-Labs/eigenda-internal/issues/77 is done
-	// store, err := leveldb.NewHeaderStore(config.IndexerDataDir)
-	// if err != nil {
-	// 	return err
-	// }
-	store := inmem.NewHeaderStore()
-
-	tx, err := eth.NewTransactor(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
-	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+	maintenance := retriever.NewMaintenance(config.MaintenanceMessage, logger)
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(1024 * 1024 * 300),
+		grpc.ChainUnaryInterceptor(
+			common.CorrelationIDUnaryServerInterceptor(config.CorrelationIDKey, logger),
+			maintenance.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			common.CorrelationIDStreamServerInterceptor(config.CorrelationIDKey, logger),
+			maintenance.StreamServerInterceptor(),
+		),
 	}
-	cs := eth.NewChainState(tx, gethClient)
-	rpcClient, err := rpc.Dial(config.EthClientConfig.RPCURL)
+	creds, err := grpcsec.ServerOption(context.Background(), config.TLSConfig, logger)
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		return err
 	}
-	indexedState, err := indexer.NewIndexedChainState(&config.IndexerConfig, common.HexToAddress(config.EigenDAServiceManagerAddr), cs, store, gethClient, rpcClient, logger)
+	gs := grpc.NewServer(append(opts, creds)...)
+
+	path, err := newRetrievalPath(config, logger, nil)
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		log.Fatalln("failed to create the retrieval path", err)
+	}
+	retrievalClient := path.retrievalClient
+
+	var archiver *retriever.BlobArchiver
+	if config.BlobSinkConfig != nil {
+		s3Client, err := s3.NewClient(context.Background(), config.BlobSinkConfig.ClientConfig, logger)
+		if err != nil {
+			log.Fatalln("failed to create the s3 client of the blob sink", err)
+		}
+		sink := retriever.NewS3BlobSink(s3Client, config.BlobSinkConfig.Bucket)
+		archiver = retriever.NewBlobArchiver(sink, config.BlobSinkConfig, path.metrics, logger)
+		retrievalClient = archiver.WrapRetrievalClient(retrievalClient)
 	}
 
-	agn := &core.StdAssignmentCoordinator{}
-	retrievalClient := clients.NewRetrievalClient(logger, indexedState, agn, nodeClient, encoder, config.NumConnections)
+	// The blobs that no operator stores fail fast for a while, as the operators would all be contacted in vain
+	if config.TombstoneTTL > 0 {
+		retrievalClient = retriever.NewTombstones(config.TombstoneTTL, path.metrics, logger).WrapRetrievalClient(retrievalClient)
+	}
 
-	chainClient := retrivereth.NewChainClient(gethClient, logger)
-	retrieverServiceServer := retriever.NewServer(config, logger, retrievalClient, encoder, indexedState, chainClient)
+	retrieverServiceServer := retriever.NewServer(config, logger, path.metrics, retrievalClient, path.encoder, path.indexedState, path.chainClient, path.chunkCache)
 	if err = retrieverServiceServer.Start(context.Background()); err != nil {
 		log.Fatalln("failed to start retriever service server", err)
 	}
@@ -120,9 +145,47 @@ func RetrieverMain(ctx *cli.Context) error {
 
 	pb.RegisterRetrieverServer(gs, retrieverServiceServer)
 
-	// Register Server for Health Checks
-	healthcheck.RegisterHealthServer(gs)
+	// Register Server for Health Checks, which report NOT_SERVING in maintenance mode, and once the indexer stopped
+	// on a reorg deeper than the max reorg depth
+	healthcheck.RegisterHealthServerWithStatus(gs, func() grpc_health_v1.HealthCheckResponse_ServingStatus {
+		if path.indexer != nil && path.indexer.Indexer.Err() != nil {
+			return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		return maintenance.HealthStatus()
+	})
+
+	// The maintenance mode is toggled with signals during rolling operations, ahead of a graceful shutdown. As
+	// SIGUSR1 enters the maintenance mode, it doesn't cycle the log level as in the other services, which is only
+	// changed over HTTP.
+	maintenanceSignals := make(chan os.Signal, 1)
+	signal.Notify(maintenanceSignals, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for sig := range maintenanceSignals {
+			if sig == syscall.SIGUSR1 {
+				maintenance.Enter()
+			} else {
+				maintenance.Leave()
+			}
+		}
+	}()
+
+	// The listeners are all closed gracefully on shutdown
+	serveCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = retriever.Serve(serveCtx, gs, listeners, logger)
+
+	// The blobs retrieved before the shutdown are still archived
+	if archiver != nil {
+		drainCtx, cancel := context.WithTimeout(context.Background(), archiveDrainTimeout)
+		defer cancel()
+		if drainErr := archiver.Close(drainCtx); drainErr != nil {
+			logger.Error("Some retrieved blobs weren't archived", "err", drainErr)
+		}
+	}
 
-	log.Printf("server listening at %s", addr)
-	return gs.Serve(listener)
+	// And the connections to the nodes and to the peers are closed once nothing retrieves any more
+	if closeErr := path.close(); closeErr != nil {
+		logger.Error("Failed to close the connections of the retrieval path", "err", closeErr)
+	}
+	return err
 }
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	commetrics "github.com/Layr-Labs/eigenda/common/metrics"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/indexer/inmem"
+	"github.com/Layr-Labs/eigenda/retriever"
+	retrivereth "github.com/Layr-Labs/eigenda/retriever/eth"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shurcooL/graphql"
+	"google.golang.org/grpc/credentials"
+)
+
+// chainIDTimeout bounds the check of the chain ID of the eth RPC on startup
+const chainIDTimeout = 30 * time.Second
+
+// retrievalPath holds the clients the blobs are retrieved with, from the chain and from the nodes, which the
+// server and the bench share
+type retrievalPath struct {
+	encoder core.Encoder
+	// indexedState is the operator state of the chain, without the retries of the retrieval client
+	indexedState    core.IndexedChainState
+	metrics         *retriever.Metrics
+	retrievalClient clients.RetrievalClient
+	chainClient     retrivereth.ChainClient
+	// indexer indexes the operator state with the indexer backend, and is nil with the others
+	indexer *indexer.IndexedChainState
+	// chunkCache holds the verified chunks of the retrievals for the peers, and is nil if they aren't cached
+	chunkCache *clients.ChunkCache
+	// nodeClient and peerClient hold the connections to the nodes and to the peers, and peerClient is nil without
+	// peers
+	nodeClient clients.NodeClient
+	peerClient clients.PeerClient
+}
+
+// close closes the connections of the retrieval path to the nodes and to the peers
+func (p *retrievalPath) close() error {
+	err := p.nodeClient.Close()
+	if p.peerClient != nil {
+		err = errors.Join(err, p.peerClient.Close())
+	}
+	return err
+}
+
+// newRetrievalPath builds the retrieval path of the config. If the timer isn't nil, the dependencies of the
+// retrieval client and the chain client are wrapped by it, so that the bench times the phases of the retrievals.
+func newRetrievalPath(config *retriever.Config, logger common.Logger, timer *retriever.BenchTimer) (*retrievalPath, error) {
+	encoder, err := encoding.NewEncoder(config.EncoderConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the encoder: %w", err)
+	}
+	gethClient, err := geth.NewClient(config.EthClientConfig, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the eth client: %w", err)
+	}
+	if err := retriever.CheckChainID(context.Background(), gethClient, config.ExpectedChainID, chainIDTimeout, logger); err != nil {
+		return nil, err
+	}
+
+	// TODO(ian-shim): uncomment when https://github.com/Layr-Labs/eigenda-internal/issues/77 is done
+	// store, err := leveldb.NewHeaderStore(config.IndexerDataDir)
+	// if err != nil {
+	// 	return err
+	// }
+	store := inmem.NewHeaderStore()
+
+	tx, err := eth.NewTransactor(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the transactor: %w", err)
+	}
+	cs := eth.NewChainState(tx, gethClient)
+	var indexedState core.IndexedChainState
+	var indexerState *indexer.IndexedChainState
+	switch config.ChainStateBackend {
+	case retriever.ChainStateBackendChain:
+		indexedState, err = retrivereth.NewIndexedChainState(cs, gethClient, tx.Bindings.RegCoordinatorAddr, config.ChainStateStartBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the chain state: %w", err)
+		}
+	case retriever.ChainStateBackendGraph:
+		querier := thegraph.NewRetryingQuerier(graphql.NewClient(config.GraphUrl, nil), config.GraphRetries, config.GraphBackoff)
+		indexedState = thegraph.NewIndexedChainState(cs, querier, logger)
+	default:
+		rpcClient, err := geth.DialRPC(context.Background(), config.EthClientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial the eth RPC: %w", err)
+		}
+		indexerState, err = indexer.NewIndexedChainState(&config.IndexerConfig, gethcommon.HexToAddress(config.EigenDAServiceManagerAddr), cs, store, gethClient, rpcClient, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the indexer: %w", err)
+		}
+		indexedState = indexerState
+	}
+	logger.Info("Reading the operator state", "backend", config.ChainStateBackend)
+	if config.StateCacheConfig.Size > 0 {
+		cachedState, err := statecache.NewCachedIndexedChainState(indexedState, config.StateCacheConfig, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the operator state cache: %w", err)
+		}
+		if err := cachedState.WatchEvents(context.Background(), gethClient, tx.Bindings.RegCoordinatorAddr); err != nil {
+			logger.Warn("Not invalidating the cached operator states on the operator events, the unfinalized states only expire", "err", err)
+		}
+		indexedState = cachedState
+	}
+
+	metricsBackend, err := commetrics.NewBackend(config.MetricsConfig, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics backend: %w", err)
+	}
+	metrics := retriever.NewMetrics(metricsBackend, config.MetricsPrefix, config.ResponseSizeBuckets, logger)
+	metrics.SetBuildInfo()
+	if indexerState != nil {
+		indexerState.Indexer.CompactionObserver = metrics
+		indexerState.Indexer.ReorgObserver = metrics
+	}
+	// The connections to the nodes go through the proxy of the config or of the environment
+	nodeDialer, err := config.ProxyConfig.ContextDialer()
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy config: %w", err)
+	}
+	nodeClientOpts := []clients.NodeClientOption{clients.WithNodeMetricsCollector(metrics)}
+	// The requests to a node, e.g. for the chunks of the blobs of a batch, are multiplexed over a single connection
+	if config.NodeConnectionIdleTimeout > 0 {
+		nodeClientOpts = append(nodeClientOpts, clients.WithConnectionReuse(config.NodeConnectionIdleTimeout, metrics))
+	}
+	nodeClient := clients.NewNodeClient(config.Timeout, &common.GRPCClientOptions{
+		ContextDialer:         nodeDialer,
+		ConnectBackoff:        &config.NodeConnectBackoff,
+		InitialWindowSize:     config.NodeInitialWindowSize,
+		InitialConnWindowSize: config.NodeInitialConnWindowSize,
+	}, nodeClientOpts...)
+	// The on-chain reads of the retrieval path are retried on transient RPC failures
+	chainReadRetrier := retriever.NewChainReadRetrier(config.ChainReadRetries, config.ChainReadRetryBackoff, metrics, logger)
+
+	// The chunks are verified before the reconstruction, so that the operators serving bad chunks are identified,
+	// unless they're only verified to retry the reconstructions that don't match the commitment
+	var retrievalClientOpts []clients.RetrievalClientOption
+	if config.CommitmentMismatchRetry {
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithCommitmentMismatchRetry(metrics))
+	} else {
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithChunkVerification(config.ChunkVerifyFailureMode, metrics))
+	}
+	if config.CommitmentSamples > 0 {
+		logger.Warn("Checking the reconstructed blobs against their commitment with a sample of their chunks, which is only probabilistic", "samples", config.CommitmentSamples)
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithSampledCommitmentVerification(config.CommitmentSamples))
+	}
+	if config.UnassignedChunkBlacklist {
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithUnassignedChunkBlacklist())
+	}
+	if config.SequentialFetch {
+		logger.Warn("Fetching the chunks sequentially, which is slow and only meant for testing")
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithSequentialFetch())
+	}
+	if config.ReconstructionMemoryBudget > 0 {
+		memoryBudget := retriever.NewMemoryBudget(config.ReconstructionMemoryBudget, config.RejectOverMemoryBudget, metrics)
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithMemoryBudget(memoryBudget))
+	}
+	// The operators that moved since the reference block of a retrieval are reached at their current socket
+	if config.EndpointRefreshFailures > 0 {
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithEndpointRefresh(config.EndpointRefreshFailures, config.EndpointRefreshInterval))
+	}
+	retrievalClientOpts = append(retrievalClientOpts, clients.WithMaxOperators(config.MaxOperatorsPerRetrieval, metrics))
+	if config.FanOutWeights != nil {
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithFanOutWeights(*config.FanOutWeights))
+	}
+	if config.ReconstructionCaptureDir != "" {
+		captureDir, err := retriever.NewCaptureDir(config.ReconstructionCaptureDir, config.ReconstructionCaptureMaxBytes, logger)
+		if err != nil {
+			return nil, err
+		}
+		logger.Warn("Capturing the chunks of the failed reconstructions, which is meant for debugging", "dir", config.ReconstructionCaptureDir, "maxBytes", config.ReconstructionCaptureMaxBytes)
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithReconstructionCapture(captureDir))
+	}
+	var chunkCache *clients.ChunkCache
+	var peerClient clients.PeerClient
+	if config.ChunkCacheSize > 0 {
+		chunkCache = clients.NewChunkCache(config.ChunkCacheSize)
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithChunkCache(chunkCache))
+	}
+	if len(config.Peers) > 0 {
+		logger.Info("Fetching the chunks the operators fail to return from the peers", "peers", config.Peers, "maxPeersPerRetrieval", config.MaxPeersPerRetrieval)
+		peerOptions := &common.GRPCClientOptions{}
+		if config.PeerTLS {
+			creds, err := peerTransportCredentials(config.PeerTLSCAFile)
+			if err != nil {
+				return nil, err
+			}
+			peerOptions.TransportCredentials = creds
+		}
+		peerClient = clients.NewPeerClient(config.Timeout, peerOptions)
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithPeerFallback(peerClient, config.Peers, config.MaxPeersPerRetrieval))
+	}
+	if len(config.ReconstructionThresholds) > 0 {
+		logger.Warn("Overriding the reconstruction thresholds of the quorums, which is meant for testing", "thresholds", config.ReconstructionThresholds)
+		retrievalClientOpts = append(retrievalClientOpts, clients.WithReconstructionThresholds(config.ReconstructionThresholds))
+	}
+
+	retrievalState := chainReadRetrier.WrapIndexedChainState(indexedState)
+	chainClient := chainReadRetrier.WrapChainClient(retrivereth.NewChainClient(gethClient, logger))
+	var retrievalNodeClient clients.NodeClient = nodeClient
+	var retrievalEncoder core.Encoder = encoder
+	if timer != nil {
+		retrievalState = timer.WrapIndexedChainState(retrievalState)
+		chainClient = timer.WrapChainClient(chainClient)
+		retrievalNodeClient = timer.WrapNodeClient(retrievalNodeClient)
+		retrievalEncoder = timer.WrapEncoder(retrievalEncoder)
+	}
+
+	agn := &core.StdAssignmentCoordinator{Caps: config.ChunkCaps}
+	return &retrievalPath{
+		encoder:         encoder,
+		indexedState:    indexedState,
+		metrics:         metrics,
+		retrievalClient: clients.NewRetrievalClient(logger, retrievalState, agn, retrievalNodeClient, retrievalEncoder, config.NumConnections, retrievalClientOpts...),
+		chainClient:     chainClient,
+		indexer:         indexerState,
+		chunkCache:      chunkCache,
+		nodeClient:      nodeClient,
+		peerClient:      peerClient,
+	}, nil
+}
+
+// peerTransportCredentials returns the TLS credentials of the connections to the peers, whose certificates are
+// verified against the CAs of the file, or against the system roots if it's empty
+func peerTransportCredentials(caFile string) (credentials.TransportCredentials, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA file of the peers: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("the CA file of the peers %s contains no PEM certificate", caFile)
+		}
+	}
+	return credentials.NewTLS(config), nil
+}
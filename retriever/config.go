@@ -1,43 +1,556 @@
 package retriever
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/metrics"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/indexer"
+	indexereth "github.com/Layr-Labs/eigenda/indexer/eth"
 	"github.com/Layr-Labs/eigenda/retriever/flags"
 	"github.com/urfave/cli"
 )
 
+// The sources of the operator state of the retrievals
+const (
+	ChainStateBackendIndexer = "indexer"
+	ChainStateBackendChain   = "chain"
+	ChainStateBackendGraph   = "graph"
+)
+
+// The orders in which the operators are contacted for the chunks of a blob
+const (
+	FanOutOrderAssignment = "assignment"
+	FanOutOrderStake      = "stake"
+	FanOutOrderLatency    = "latency"
+	FanOutOrderWeighted   = "weighted"
+)
+
+// The ways the reconstructed blobs are checked against their commitment
+const (
+	CommitmentVerificationStrict  = "strict"
+	CommitmentVerificationSampled = "sampled"
+)
+
+// metricNamePrefix matches the valid namespaces and subsystems of the names of the Prometheus metrics
+var metricNamePrefix = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+const (
+	minIndexerPollInterval = 100 * time.Millisecond
+	maxIndexerPollInterval = 10 * time.Minute
+
+	// The bounds of the timeouts of the requests and of the writes of the blob sink
+	minTimeout = 100 * time.Millisecond
+	maxTimeout = time.Hour
+
+	// maxTombstoneTTL bounds the time a blob is known as unretrievable, as the operators that didn't store it may
+	// still receive it, e.g. after recovering their stores
+	maxTombstoneTTL = 24 * time.Hour
+)
+
 type Config struct {
 	EncoderConfig   encoding.EncoderConfig
 	EthClientConfig geth.EthClientConfig
 	LoggerConfig    logging.Config
 	IndexerConfig   indexer.Config
-	MetricsConfig   MetricsConfig
+	MetricsConfig   metrics.Config
+	// TLSConfig is nil if the gRPC listener doesn't serve TLS
+	TLSConfig *grpcsec.Config
+	// BlobSinkConfig is nil if the retrieved blobs are not written to a sink
+	BlobSinkConfig *BlobSinkConfig
+	// StateCacheConfig is the cache of the operator states, which is disabled if its size is 0
+	StateCacheConfig statecache.Config
+	// ChunkCaps caps the chunks assigned to the operators, which must be the same as the ones of the batcher
+	ChunkCaps core.ChunkCaps
+	// ProxyConfig is the proxy of the connections to the chain RPC and to the nodes
+	ProxyConfig common.ProxyConfig
+	// ExpectedChainID is the chain ID the eth RPC must be on at startup, or 0 if it isn't checked
+	ExpectedChainID uint64
+	// NodeConnectBackoff is the backoff of the reconnections to the nodes
+	NodeConnectBackoff common.ConnectBackoff
+	// NodeConnectionIdleTimeout is how long the unused connections to the nodes are kept open, or 0 if every
+	// request dials its own
+	NodeConnectionIdleTimeout time.Duration
+	// NodeInitialWindowSize and NodeInitialConnWindowSize are the flow-control windows of the streams and of the
+	// connections to the nodes, or 0 for the windows of grpc, see common.GRPCClientOptions
+	NodeInitialWindowSize     int32
+	NodeInitialConnWindowSize int32
+	// MaxOperatorsPerRetrieval is the number of operators first asked for the chunks of a blob, or 0 if all of them are
+	MaxOperatorsPerRetrieval int
+	// FanOutWeights order the operators contacted for the chunks of a blob by their stakes and latencies, or are nil
+	// if they are contacted in the order of their assignments
+	FanOutWeights *clients.FanOutWeights
+	// ChunkCacheSize is the size in bytes of the cache of the verified chunks served to the peer retrievers, or 0 if
+	// the chunks aren't cached
+	ChunkCacheSize uint64
+	// Peers are the addresses of the peer retrievers the chunks that the operators fail to return are fetched from,
+	// up to MaxPeersPerRetrieval of them at once, or nil if they aren't
+	Peers                []string
+	MaxPeersPerRetrieval int
+	// PeerTLS connects to the peers over TLS, verifying their certificates against the CAs of PeerTLSCAFile, or
+	// against the system roots if it's empty
+	PeerTLS       bool
+	PeerTLSCAFile string
+	// MaxBlobsPerRequest is the maximum number of blobs of a RetrieveBlobs request, or 0 if it's unbounded
+	MaxBlobsPerRequest int
+	// TombstoneTTL is how long the blobs that no operator stores are known as unretrievable, or 0 if they aren't
+	TombstoneTTL time.Duration
+	// ResponseSizeBuckets are the buckets of the sizes of the retrieved blobs, or nil for DefaultResponseSizeBuckets
+	ResponseSizeBuckets []float64
+	// LargeResponseThreshold is the size of the retrieved blobs above which a warning is logged, or 0 if it isn't
+	LargeResponseThreshold uint64
+	// ChainStateBackend is the source of the operator state, one of the ChainStateBackend constants. The
+	// IndexerConfig is only read with the indexer backend.
+	ChainStateBackend string
+	// ChainStateStartBlock is the block from which the chain backend reads the socket update events
+	ChainStateStartBlock uint64
+	// GraphUrl is the URL of the subgraph of the graph backend, whose failed queries are retried GraphRetries times
+	// after GraphBackoff, doubling after every retry
+	GraphUrl     string
+	GraphRetries int
+	GraphBackoff time.Duration
+	// ReconstructionThresholds override the numbers of chunks the blobs of the quorums are reconstructed from, for
+	// testing changes of the coding parameters. It's nil unless the unsafe overrides are enabled.
+	ReconstructionThresholds map[core.QuorumID]uint
+
+	// MetricsPrefix is the namespace and subsystem of the names of the metrics
+	MetricsPrefix MetricsPrefix
+	// ContinueWithoutMetrics serves the retrievals without metrics if they fail to start, instead of failing
+	ContinueWithoutMetrics bool
 
-	IndexerDataDir                string
-	Timeout                       time.Duration
-	NumConnections                int
+	// ListenAddresses are the addresses the gRPC server listens on
+	ListenAddresses []string
+	// CorrelationIDKey is the gRPC metadata key of the correlation IDs of the requests
+	CorrelationIDKey string
+	// MaintenanceMessage is the message of the errors returned in maintenance mode
+	MaintenanceMessage         string
+	IndexerDataDir             string
+	Timeout                    time.Duration
+	NumConnections             int
+	ChainReadRetries           int
+	ChainReadRetryBackoff      time.Duration
+	ReconstructionMemoryBudget uint64
+	RejectOverMemoryBudget     bool
+	// ReconstructionCaptureDir is the directory the failed reconstructions are captured to, up to
+	// ReconstructionCaptureMaxBytes, or empty if they aren't
+	ReconstructionCaptureDir      string
+	ReconstructionCaptureMaxBytes uint64
+	ChunkVerifyFailureMode        clients.ChunkVerificationFailureMode
+	// CommitmentSamples is the number of chunks whose proofs are checked instead of the reconstructed blobs against
+	// their commitment, which are checked in full if it's 0
+	CommitmentSamples       int
+	CommitmentMismatchRetry bool
+	// UnassignedChunkBlacklist excludes the operators returning chunks outside of their assignment from
+	// the rest of the retrieval
+	UnassignedChunkBlacklist      bool
+	SequentialFetch               bool
+	EndpointRefreshFailures       int
+	EndpointRefreshInterval       time.Duration
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
+	// VerboseRetrievals serves the retrievals requesting the diagnostics of their chunks
+	VerboseRetrievals bool
+	// HighPriorityRetrievals admits the retrievals requesting the high priority ahead of the others, which are
+	// retrieved at the normal priority otherwise
+	HighPriorityRetrievals bool
 }
 
-func NewConfig(ctx *cli.Context) *Config {
-	return &Config{
-		EncoderConfig:   encoding.ReadCLIConfig(ctx),
-		EthClientConfig: geth.ReadEthClientConfig(ctx),
-		LoggerConfig:    logging.ReadCLIConfig(ctx, flags.FlagPrefix),
-		IndexerConfig:   indexer.ReadIndexerConfig(ctx),
-		MetricsConfig: MetricsConfig{
-			HTTPPort: ctx.GlobalString(flags.MetricsHTTPPortFlag.Name),
+// BlobSinkConfig is the configuration of the bucket the retrieved blobs are written to
+type BlobSinkConfig struct {
+	Bucket       string
+	ClientConfig aws.ClientConfig
+	// Sync makes the requests wait for the writes
+	Sync    bool
+	Timeout time.Duration
+	// Workers is the number of writes in the background at a time, and QueueSize the number of writes waiting for
+	// a worker, past which the writes are dropped
+	Workers   int
+	QueueSize int
+}
+
+func readBlobSinkConfig(ctx *cli.Context) *BlobSinkConfig {
+	bucket := ctx.GlobalString(flags.BlobSinkBucketFlag.Name)
+	if bucket == "" {
+		return nil
+	}
+	return &BlobSinkConfig{
+		Bucket: bucket,
+		ClientConfig: aws.ClientConfig{
+			Region:          ctx.GlobalString(flags.BlobSinkRegionFlag.Name),
+			AccessKey:       ctx.GlobalString(flags.BlobSinkAccessKeyIdFlag.Name),
+			SecretAccessKey: ctx.GlobalString(flags.BlobSinkSecretAccessKeyFlag.Name),
+			EndpointURL:     ctx.GlobalString(flags.BlobSinkEndpointURLFlag.Name),
 		},
+		Sync:      ctx.GlobalBool(flags.BlobSinkSyncFlag.Name),
+		Timeout:   ctx.GlobalDuration(flags.BlobSinkTimeoutFlag.Name),
+		Workers:   ctx.GlobalInt(flags.BlobSinkWorkersFlag.Name),
+		QueueSize: ctx.GlobalInt(flags.BlobSinkQueueSizeFlag.Name),
+	}
+}
+
+// ParseReconstructionThresholds parses the quorum:threshold pairs of the reconstruction threshold overrides
+func ParseReconstructionThresholds(values []string) (map[core.QuorumID]uint, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	thresholds := make(map[core.QuorumID]uint, len(values))
+	for _, value := range values {
+		quorum, threshold, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid reconstruction threshold %q: must be quorum:threshold", value)
+		}
+		quorumID, err := strconv.ParseUint(quorum, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reconstruction threshold %q: the quorum must be a number below 256", value)
+		}
+		numChunks, err := strconv.ParseUint(threshold, 10, 32)
+		if err != nil || numChunks == 0 {
+			return nil, fmt.Errorf("invalid reconstruction threshold %q: the threshold must be a positive number of chunks", value)
+		}
+		if _, ok := thresholds[core.QuorumID(quorumID)]; ok {
+			return nil, fmt.Errorf("duplicate reconstruction threshold of quorum %d", quorumID)
+		}
+		thresholds[core.QuorumID(quorumID)] = uint(numChunks)
+	}
+	return thresholds, nil
+}
+
+// ParseResponseSizeBuckets parses the upper bounds of the buckets of the response sizes, which are positive numbers of
+// bytes in increasing order. It returns nil if there are none, for the default buckets.
+func ParseResponseSizeBuckets(values []string) ([]float64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	buckets := make([]float64, len(values))
+	for i, value := range values {
+		numBytes, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || numBytes == 0 {
+			return nil, fmt.Errorf("invalid response size bucket %q: must be a positive number of bytes", value)
+		}
+		buckets[i] = float64(numBytes)
+		if i > 0 && buckets[i] <= buckets[i-1] {
+			return nil, fmt.Errorf("invalid response size buckets: %s is not above %s", value, values[i-1])
+		}
+	}
+	return buckets, nil
+}
+
+// ParseFanOutOrder returns the fan-out weights of the order, the stake weighing stakeWeight and the latency the rest
+// in the weighted order. It returns nil for the order of the assignments.
+func ParseFanOutOrder(order string, stakeWeight float64) (*clients.FanOutWeights, error) {
+	switch order {
+	case FanOutOrderAssignment, "":
+		return nil, nil
+	case FanOutOrderStake:
+		return &clients.FanOutWeights{Stake: 1}, nil
+	case FanOutOrderLatency:
+		return &clients.FanOutWeights{Latency: 1}, nil
+	case FanOutOrderWeighted:
+		if stakeWeight < 0 || stakeWeight > 1 {
+			return nil, fmt.Errorf("invalid stake weight %v: must be between 0 and 1", stakeWeight)
+		}
+		return &clients.FanOutWeights{Stake: stakeWeight, Latency: 1 - stakeWeight}, nil
+	default:
+		return nil, fmt.Errorf("invalid fan-out order %q: must be %s, %s, %s or %s", order, FanOutOrderAssignment, FanOutOrderStake, FanOutOrderLatency, FanOutOrderWeighted)
+	}
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return nil, err
+	}
+
+	// The indexer flags are ignored with the other backends, which don't index the chain
+	var indexerConfig indexer.Config
+	chainStateBackend := ctx.GlobalString(flags.ChainStateBackendFlag.Name)
+	if chainStateBackend == ChainStateBackendIndexer {
+		indexerConfig = indexer.ReadIndexerConfig(ctx)
+		if ctx.GlobalIsSet(flags.IndexerPollIntervalFlag.Name) {
+			indexerConfig.PullInterval = ctx.GlobalDuration(flags.IndexerPollIntervalFlag.Name)
+		}
+		if indexerConfig.PullInterval < minIndexerPollInterval || indexerConfig.PullInterval > maxIndexerPollInterval {
+			return nil, fmt.Errorf("indexer poll interval must be between %s and %s, got %s", minIndexerPollInterval, maxIndexerPollInterval, indexerConfig.PullInterval)
+		}
+		indexerConfig.MaxReorgDepth = ctx.GlobalUint64(flags.IndexerReorgDepthFlag.Name)
+		if err := indexerConfig.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	encoderConfig := encoding.ReadCLIConfig(ctx)
+	if !ctx.GlobalBool(flags.SkipSRSValidationFlag.Name) {
+		if err := encoding.ValidateConfig(encoderConfig); err != nil {
+			return nil, fmt.Errorf("invalid encoding config: %w", err)
+		}
+	}
+
+	tlsConfig, err := grpcsec.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	reconstructionThresholds, err := ParseReconstructionThresholds(ctx.GlobalStringSlice(flags.ReconstructionThresholdOverridesFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	responseSizeBuckets, err := ParseResponseSizeBuckets(ctx.GlobalStringSlice(flags.ResponseSizeBucketsFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	commitmentSamples := 0
+	if ctx.GlobalString(flags.CommitmentVerificationFlag.Name) == CommitmentVerificationSampled {
+		commitmentSamples = ctx.GlobalInt(flags.CommitmentVerificationSamplesFlag.Name)
+	}
+
+	var chunkVerifyFailureMode clients.ChunkVerificationFailureMode
+	switch mode := ctx.GlobalString(flags.ChunkVerifyFailureModeFlag.Name); mode {
+	case "lenient", "":
+		chunkVerifyFailureMode = clients.ChunkVerificationLenient
+	case "strict":
+		chunkVerifyFailureMode = clients.ChunkVerificationStrict
+	default:
+		return nil, fmt.Errorf("invalid chunk verification failure mode %q: must be lenient or strict", mode)
+	}
+
+	fanOutWeights, err := ParseFanOutOrder(ctx.GlobalString(flags.FanOutOrderFlag.Name), ctx.GlobalFloat64(flags.FanOutStakeWeightFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []string
+	if ctx.GlobalBool(flags.PeerFallbackFlag.Name) {
+		peers = ctx.GlobalStringSlice(flags.PeersFlag.Name)
+	}
+
+	listenAddresses := ctx.GlobalStringSlice(flags.ListenAddressesFlag.Name)
+	if len(listenAddresses) == 0 {
+		port := ctx.GlobalString(flags.GrpcPortFlag.Name)
+		if port == "" {
+			return nil, errors.New("either the listen addresses or the gRPC port must be set")
+		}
+		listenAddresses = []string{net.JoinHostPort(ctx.GlobalString(flags.HostnameFlag.Name), port)}
+	}
+	listenAddresses, err = ParseListenAddresses(listenAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyConfig := common.ProxyConfig{URL: ctx.GlobalString(flags.ProxyURLFlag.Name)}
+	if _, err := proxyConfig.ContextDialer(); err != nil {
+		return nil, err
+	}
+	ethClientConfig := geth.ReadEthClientConfig(ctx)
+	ethClientConfig.Proxy = proxyConfig
+
+	metricsConfig := metrics.ReadCLIConfig(ctx, flags.FlagPrefix)
+	metricsConfig.HTTPPort = ctx.GlobalString(flags.MetricsHTTPPortFlag.Name)
+	metricsConfig.Profiling = profiling.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if metricsConfig.Profiling.Enabled && metricsConfig.Profiling.Address == "" && metricsConfig.Backend == metrics.StatsDBackendName {
+		return nil, fmt.Errorf("the %s backend has no HTTP server: the pprof address must be set to enable pprof", metrics.StatsDBackendName)
+	}
+
+	metricsPrefix := MetricsPrefix{
+		Namespace: ctx.GlobalString(flags.MetricsNamespaceFlag.Name),
+		Subsystem: ctx.GlobalString(flags.MetricsSubsystemFlag.Name),
+	}
+
+	return &Config{
+		EncoderConfig:                 encoderConfig,
+		EthClientConfig:               ethClientConfig,
+		LoggerConfig:                  logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+		IndexerConfig:                 indexerConfig,
+		StateCacheConfig:              statecache.ReadCLIConfig(ctx, flags.FlagPrefix),
+		ChunkCaps:                     core.ReadChunkCapsCLIConfig(ctx, flags.FlagPrefix),
+		MetricsConfig:                 metricsConfig,
+		MetricsPrefix:                 metricsPrefix,
+		TLSConfig:                     tlsConfig,
+		BlobSinkConfig:                readBlobSinkConfig(ctx),
+		ProxyConfig:                   proxyConfig,
+		ExpectedChainID:               ctx.GlobalUint64(flags.ExpectedChainIDFlag.Name),
+		NodeConnectBackoff:            common.ReadConnectBackoffCLIConfig(ctx, flags.FlagPrefix),
+		NodeConnectionIdleTimeout:     ctx.GlobalDuration(flags.NodeConnectionIdleTimeoutFlag.Name),
+		NodeInitialWindowSize:         int32(ctx.GlobalInt(flags.NodeInitialWindowSizeFlag.Name)),
+		NodeInitialConnWindowSize:     int32(ctx.GlobalInt(flags.NodeInitialConnWindowSizeFlag.Name)),
+		ListenAddresses:               listenAddresses,
+		CorrelationIDKey:              strings.ToLower(ctx.GlobalString(flags.CorrelationIDKeyFlag.Name)),
+		MaintenanceMessage:            ctx.GlobalString(flags.MaintenanceMessageFlag.Name),
+		ContinueWithoutMetrics:        ctx.GlobalBool(flags.ContinueWithoutMetricsFlag.Name),
 		IndexerDataDir:                ctx.GlobalString(flags.IndexerDataDirFlag.Name),
 		Timeout:                       ctx.Duration(flags.TimeoutFlag.Name),
 		NumConnections:                ctx.Int(flags.NumConnectionsFlag.Name),
+		ChainReadRetries:              ctx.GlobalInt(flags.ChainReadRetriesFlag.Name),
+		ChainReadRetryBackoff:         ctx.GlobalDuration(flags.ChainReadRetryBackoffFlag.Name),
+		ReconstructionMemoryBudget:    ctx.GlobalUint64(flags.ReconstructionMemoryBudgetFlag.Name),
+		RejectOverMemoryBudget:        ctx.GlobalBool(flags.RejectOverMemoryBudgetFlag.Name),
+		ReconstructionCaptureDir:      ctx.GlobalString(flags.ReconstructionCaptureDirFlag.Name),
+		ReconstructionCaptureMaxBytes: ctx.GlobalUint64(flags.ReconstructionCaptureMaxBytesFlag.Name),
+		ChunkVerifyFailureMode:        chunkVerifyFailureMode,
+		CommitmentSamples:             commitmentSamples,
+		CommitmentMismatchRetry:       ctx.GlobalBool(flags.CommitmentMismatchRetryFlag.Name),
+		UnassignedChunkBlacklist:      ctx.GlobalBool(flags.BlacklistUnassignedChunkOperatorsFlag.Name),
+		SequentialFetch:               ctx.GlobalBool(flags.SequentialFetchFlag.Name),
+		VerboseRetrievals:             ctx.GlobalBool(flags.VerboseRetrievalsFlag.Name),
+		HighPriorityRetrievals:        ctx.GlobalBool(flags.HighPriorityRetrievalsFlag.Name),
+		EndpointRefreshFailures:       ctx.GlobalInt(flags.EndpointRefreshFailuresFlag.Name),
+		EndpointRefreshInterval:       ctx.GlobalDuration(flags.EndpointRefreshIntervalFlag.Name),
+		MaxOperatorsPerRetrieval:      ctx.GlobalInt(flags.MaxOperatorsPerRetrievalFlag.Name),
+		FanOutWeights:                 fanOutWeights,
+		ChunkCacheSize:                ctx.GlobalUint64(flags.ChunkCacheSizeFlag.Name),
+		Peers:                         peers,
+		MaxPeersPerRetrieval:          ctx.GlobalInt(flags.MaxPeersPerRetrievalFlag.Name),
+		PeerTLS:                       ctx.GlobalBool(flags.PeerTLSFlag.Name),
+		PeerTLSCAFile:                 ctx.GlobalString(flags.PeerTLSCAFileFlag.Name),
+		MaxBlobsPerRequest:            ctx.GlobalInt(flags.MaxBlobsPerRequestFlag.Name),
+		TombstoneTTL:                  ctx.GlobalDuration(flags.TombstoneTTLFlag.Name),
+		ResponseSizeBuckets:           responseSizeBuckets,
+		LargeResponseThreshold:        ctx.GlobalUint64(flags.LargeResponseThresholdFlag.Name),
+		ChainStateBackend:             chainStateBackend,
+		ChainStateStartBlock:          ctx.GlobalUint64(flags.ChainStateStartBlockFlag.Name),
+		GraphUrl:                      ctx.GlobalString(flags.GraphUrlFlag.Name),
+		GraphRetries:                  ctx.GlobalInt(flags.GraphRetriesFlag.Name),
+		GraphBackoff:                  ctx.GlobalDuration(flags.GraphBackoffFlag.Name),
+		ReconstructionThresholds:      reconstructionThresholds,
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+	}, nil
+}
+
+// validateFlags checks the contracts, ports, chain state backend, fan-out, peers and limits of the retrievals. The
+// checks that depend on several flags, such as the listen addresses, are left to NewConfig.
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+	if port := ctx.GlobalString(flags.GrpcPortFlag.Name); port != "" {
+		v.Add(validation.Port(flags.GrpcPortFlag.Name, port))
+	}
+	v.Add(validation.Port(flags.MetricsHTTPPortFlag.Name, ctx.GlobalString(flags.MetricsHTTPPortFlag.Name)))
+	for _, f := range []cli.StringFlag{flags.MetricsNamespaceFlag, flags.MetricsSubsystemFlag} {
+		if prefix := ctx.GlobalString(f.Name); prefix != "" && !metricNamePrefix.MatchString(prefix) {
+			v.Addf("%s: %q must start with a letter or an underscore followed by letters, digits and underscores", f.Name, prefix)
+		}
+	}
+	v.Add(validation.Range(flags.TimeoutFlag.Name, ctx.GlobalDuration(flags.TimeoutFlag.Name), minTimeout, maxTimeout))
+	v.Add(validation.AtLeast(flags.NumConnectionsFlag.Name, ctx.GlobalInt(flags.NumConnectionsFlag.Name), 1))
+	switch backend := ctx.GlobalString(flags.ChainStateBackendFlag.Name); backend {
+	case ChainStateBackendIndexer:
+		// The blocks further from the head are finalized, so they aren't reorged
+		v.Add(validation.Range(flags.IndexerReorgDepthFlag.Name, ctx.GlobalUint64(flags.IndexerReorgDepthFlag.Name), 0, indexereth.DistanceFromHead))
+	case ChainStateBackendChain:
+	case ChainStateBackendGraph:
+		if ctx.GlobalString(flags.GraphUrlFlag.Name) == "" {
+			v.Addf("%s: must be set when %s is %s", flags.GraphUrlFlag.Name, flags.ChainStateBackendFlag.Name, ChainStateBackendGraph)
+		}
+		v.Add(validation.AtLeast(flags.GraphRetriesFlag.Name, ctx.GlobalInt(flags.GraphRetriesFlag.Name), 0))
+		v.Add(validation.Range(flags.GraphBackoffFlag.Name, ctx.GlobalDuration(flags.GraphBackoffFlag.Name), 0, time.Minute))
+	default:
+		v.Addf("%s: must be %s, %s or %s, got %q", flags.ChainStateBackendFlag.Name, ChainStateBackendIndexer, ChainStateBackendChain, ChainStateBackendGraph, backend)
+	}
+	v.Add(validation.AtLeast(flags.ChainReadRetriesFlag.Name, ctx.GlobalInt(flags.ChainReadRetriesFlag.Name), 0))
+	v.Add(validation.Range(flags.ChainReadRetryBackoffFlag.Name, ctx.GlobalDuration(flags.ChainReadRetryBackoffFlag.Name), 0, time.Minute))
+	v.Add(validation.AtLeast(flags.EndpointRefreshFailuresFlag.Name, ctx.GlobalInt(flags.EndpointRefreshFailuresFlag.Name), 0))
+	if ctx.GlobalInt(flags.EndpointRefreshFailuresFlag.Name) > 0 {
+		v.Add(validation.Range(flags.EndpointRefreshIntervalFlag.Name, ctx.GlobalDuration(flags.EndpointRefreshIntervalFlag.Name), 0, time.Hour))
+	}
+	v.Add(validation.Range(flags.NodeConnectionIdleTimeoutFlag.Name, ctx.GlobalDuration(flags.NodeConnectionIdleTimeoutFlag.Name), 0, time.Hour))
+	for _, flag := range []cli.IntFlag{flags.NodeInitialWindowSizeFlag, flags.NodeInitialConnWindowSizeFlag} {
+		// grpc ignores the windows below the minimum rather than failing
+		if size := ctx.GlobalInt(flag.Name); size != 0 {
+			v.Add(validation.Range(flag.Name, size, common.MinWindowSize, math.MaxInt32))
+		}
+	}
+	v.Add(validation.AtLeast(flags.MaxOperatorsPerRetrievalFlag.Name, ctx.GlobalInt(flags.MaxOperatorsPerRetrievalFlag.Name), 0))
+	switch order := ctx.GlobalString(flags.FanOutOrderFlag.Name); order {
+	case FanOutOrderAssignment, FanOutOrderStake, FanOutOrderLatency:
+	case FanOutOrderWeighted:
+		v.Add(validation.Range(flags.FanOutStakeWeightFlag.Name, ctx.GlobalFloat64(flags.FanOutStakeWeightFlag.Name), 0, 1))
+	default:
+		v.Addf("%s: must be %s, %s, %s or %s, got %q", flags.FanOutOrderFlag.Name, FanOutOrderAssignment, FanOutOrderStake, FanOutOrderLatency, FanOutOrderWeighted, order)
+	}
+	// The chunks are only verified on a mismatch with the retry, so they can't be served as verified
+	if ctx.GlobalUint64(flags.ChunkCacheSizeFlag.Name) > 0 && ctx.GlobalBool(flags.CommitmentMismatchRetryFlag.Name) {
+		v.Addf("%s: the cache requires the chunks to be verified upfront, which %s disables", flags.ChunkCacheSizeFlag.Name, flags.CommitmentMismatchRetryFlag.Name)
+	}
+	if ctx.GlobalBool(flags.PeerFallbackFlag.Name) {
+		peers := ctx.GlobalStringSlice(flags.PeersFlag.Name)
+		if len(peers) == 0 {
+			v.Addf("%s: must be set with %s", flags.PeersFlag.Name, flags.PeerFallbackFlag.Name)
+		}
+		for _, peer := range peers {
+			if _, _, err := net.SplitHostPort(peer); err != nil {
+				v.Addf("%s: %q must be host:port", flags.PeersFlag.Name, peer)
+			}
+		}
+		v.Add(validation.AtLeast(flags.MaxPeersPerRetrievalFlag.Name, ctx.GlobalInt(flags.MaxPeersPerRetrievalFlag.Name), 1))
+	}
+	if caFile := ctx.GlobalString(flags.PeerTLSCAFileFlag.Name); caFile != "" {
+		if !ctx.GlobalBool(flags.PeerTLSFlag.Name) {
+			v.Addf("%s: must be set with %s", flags.PeerTLSCAFileFlag.Name, flags.PeerTLSFlag.Name)
+		}
+		v.Add(validation.ReadableFile(flags.PeerTLSCAFileFlag.Name, caFile))
+	}
+	v.Add(validation.AtLeast(flags.MaxBlobsPerRequestFlag.Name, ctx.GlobalInt(flags.MaxBlobsPerRequestFlag.Name), 0))
+	v.Add(validation.Range(flags.TombstoneTTLFlag.Name, ctx.GlobalDuration(flags.TombstoneTTLFlag.Name), 0, maxTombstoneTTL))
+	if _, err := ParseResponseSizeBuckets(ctx.GlobalStringSlice(flags.ResponseSizeBucketsFlag.Name)); err != nil {
+		v.Addf("%s: %v", flags.ResponseSizeBucketsFlag.Name, err)
+	}
+	if thresholds := ctx.GlobalStringSlice(flags.ReconstructionThresholdOverridesFlag.Name); len(thresholds) > 0 {
+		if !ctx.GlobalBool(flags.UnsafeOverridesFlag.Name) {
+			v.Addf("%s: the overrides require %s", flags.ReconstructionThresholdOverridesFlag.Name, flags.UnsafeOverridesFlag.Name)
+		}
+		overrides, err := ParseReconstructionThresholds(thresholds)
+		v.Add(err)
+		// An operator may be assigned a single chunk, so a cap below the threshold may never reconstruct the blobs
+		if maxOperators := ctx.GlobalInt(flags.MaxOperatorsPerRetrievalFlag.Name); maxOperators > 0 {
+			var maxThreshold uint
+			for _, threshold := range overrides {
+				maxThreshold = max(maxThreshold, threshold)
+			}
+			if uint(maxOperators) < maxThreshold {
+				v.Addf("%s: %d is below the reconstruction threshold of %d chunks", flags.MaxOperatorsPerRetrievalFlag.Name, maxOperators, maxThreshold)
+			}
+		}
+	}
+	switch verification := ctx.GlobalString(flags.CommitmentVerificationFlag.Name); verification {
+	case CommitmentVerificationStrict:
+	case CommitmentVerificationSampled:
+		v.Add(validation.AtLeast(flags.CommitmentVerificationSamplesFlag.Name, ctx.GlobalInt(flags.CommitmentVerificationSamplesFlag.Name), 1))
+	default:
+		v.Addf("%s: must be %s or %s, got %q", flags.CommitmentVerificationFlag.Name, CommitmentVerificationStrict, CommitmentVerificationSampled, verification)
+	}
+	if ctx.GlobalBool(flags.CommitmentMismatchRetryFlag.Name) && ctx.GlobalString(flags.ChunkVerifyFailureModeFlag.Name) == "strict" {
+		v.Addf("%s: the retry requires the lenient %s", flags.CommitmentMismatchRetryFlag.Name, flags.ChunkVerifyFailureModeFlag.Name)
+	}
+	if ctx.GlobalString(flags.ReconstructionCaptureDirFlag.Name) != "" {
+		v.Add(validation.AtLeast(flags.ReconstructionCaptureMaxBytesFlag.Name, ctx.GlobalUint64(flags.ReconstructionCaptureMaxBytesFlag.Name), 1))
+	}
+	if ctx.GlobalString(flags.BlobSinkBucketFlag.Name) != "" {
+		v.Add(validation.Range(flags.BlobSinkTimeoutFlag.Name, ctx.GlobalDuration(flags.BlobSinkTimeoutFlag.Name), minTimeout, maxTimeout))
+		v.Add(validation.AtLeast(flags.BlobSinkWorkersFlag.Name, ctx.GlobalInt(flags.BlobSinkWorkersFlag.Name), 1))
+		v.Add(validation.AtLeast(flags.BlobSinkQueueSizeFlag.Name, ctx.GlobalInt(flags.BlobSinkQueueSizeFlag.Name), 0))
 	}
+	v.Add(grpcsec.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(common.ValidateConnectBackoffCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(statecache.ValidateCLIFlags(ctx, flags.FlagPrefix))
+	v.Add(core.ValidateChunkCapsCLIFlags(ctx, flags.FlagPrefix))
+	return v.Err()
 }
@@ -0,0 +1,329 @@
+package retriever_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/statecache"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigenda/retriever/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const retrieverConfigFile = `
+"indexer-pull-interval" = "2s"
+
+[chain]
+rpc = "http://localhost:8545"
+private-key = "0123456789abcdef"
+
+[kzg]
+g1-path = "g1.point"
+g2-path = "g2.point"
+cache-path = "SRSTables"
+srs-order = 3000
+
+[retriever]
+timeout = "10s"
+bls-operator-state-retriever = "0x0000000000000000000000000000000000000001"
+eigenda-service-manager = "0x0000000000000000000000000000000000000002"
+grpc-port = "32011"
+num-connections = 8
+chunk-verify-failure-mode = "strict"
+skip-srs-validation = true
+listen-addresses = ["127.0.0.1:32011", "[::1]:32011"]
+node-connect-backoff-max-delay = "30s"
+expected-chain-id = 17000
+
+[retriever.log]
+level-std = "debug"
+`
+
+// newConfig returns the config of the retriever run with retrieverConfigFile and the arguments
+func newConfig(t *testing.T, args ...string) (*retriever.Config, error) {
+	path := filepath.Join(t.TempDir(), "retriever.toml")
+	assert.NoError(t, os.WriteFile(path, []byte(retrieverConfigFile), 0600))
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	configfile.Enable(app)
+	var config *retriever.Config
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		config, err = retriever.NewConfig(ctx)
+		return err
+	}
+	err := app.Run(append([]string{"retriever", "--config", path}, args...))
+	return config, err
+}
+
+func TestConfigFile(t *testing.T) {
+	t.Setenv("RETRIEVER_NUM_CONNECTIONS", "16")
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 10*time.Second, config.Timeout)
+	assert.Equal(t, "http://localhost:8545", config.EthClientConfig.RPCURL)
+	assert.Equal(t, uint64(3000), config.EncoderConfig.KzgConfig.SRSOrder)
+	assert.Equal(t, "0x0000000000000000000000000000000000000002", config.EigenDAServiceManagerAddr)
+	assert.Equal(t, []string{"127.0.0.1:32011", "[::1]:32011"}, config.ListenAddresses)
+	assert.Equal(t, clients.ChunkVerificationStrict, config.ChunkVerifyFailureMode)
+	assert.Equal(t, 2*time.Second, config.IndexerConfig.PullInterval)
+	assert.Equal(t, "debug", config.LoggerConfig.StdLevel)
+	assert.Equal(t, uint64(17000), config.ExpectedChainID)
+	// The backoff keeps the defaults of grpc for the flags that aren't set
+	assert.Equal(t, common.ConnectBackoff{BaseDelay: time.Second, MaxDelay: 30 * time.Second, Multiplier: 1.6}, config.NodeConnectBackoff)
+	// The environment takes precedence over the file
+	assert.Equal(t, 16, config.NumConnections)
+}
+
+func TestReconstructionThresholdOverrides(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Nil(t, config.ReconstructionThresholds)
+
+	config, err = newConfig(t, "--retriever.unsafe-overrides", "--retriever.reconstruction-threshold-overrides", "0:16", "--retriever.reconstruction-threshold-overrides", "2:8")
+	assert.NoError(t, err)
+	assert.Equal(t, map[core.QuorumID]uint{0: 16, 2: 8}, config.ReconstructionThresholds)
+
+	// The overrides are rejected unless the unsafe overrides are enabled
+	_, err = newConfig(t, "--retriever.reconstruction-threshold-overrides", "0:16")
+	assert.ErrorContains(t, err, "retriever.reconstruction-threshold-overrides: the overrides require retriever.unsafe-overrides")
+
+	for value, message := range map[string]string{
+		"16":     `invalid reconstruction threshold "16": must be quorum:threshold`,
+		"256:16": "the quorum must be a number below 256",
+		"0:0":    "the threshold must be a positive number of chunks",
+		"0:-1":   "the threshold must be a positive number of chunks",
+	} {
+		_, err = newConfig(t, "--retriever.unsafe-overrides", "--retriever.reconstruction-threshold-overrides", value)
+		assert.ErrorContains(t, err, message)
+	}
+	_, err = newConfig(t, "--retriever.unsafe-overrides", "--retriever.reconstruction-threshold-overrides", "0:1", "--retriever.reconstruction-threshold-overrides", "0:2")
+	assert.ErrorContains(t, err, "duplicate reconstruction threshold of quorum 0")
+}
+
+func TestChainStateBackend(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Equal(t, retriever.ChainStateBackendIndexer, config.ChainStateBackend)
+
+	config, err = newConfig(t, "--retriever.chain-state-backend", "graph", "--retriever.graph-url", "http://localhost:8000/subgraphs/name/eigenda", "--retriever.graph-retries", "5")
+	assert.NoError(t, err)
+	assert.Equal(t, retriever.ChainStateBackendGraph, config.ChainStateBackend)
+	assert.Equal(t, "http://localhost:8000/subgraphs/name/eigenda", config.GraphUrl)
+	assert.Equal(t, 5, config.GraphRetries)
+	assert.Equal(t, time.Second, config.GraphBackoff)
+
+	_, err = newConfig(t, "--retriever.chain-state-backend", "graph")
+	assert.ErrorContains(t, err, "retriever.graph-url: must be set when retriever.chain-state-backend is graph")
+	_, err = newConfig(t, "--retriever.chain-state-backend", "subgraph")
+	assert.ErrorContains(t, err, `retriever.chain-state-backend: must be indexer, chain or graph, got "subgraph"`)
+
+	// The indexer flags only apply to the indexer backend
+	_, err = newConfig(t, "--indexer-max-entries", "-1")
+	assert.ErrorContains(t, err, "indexer max entries must not be negative")
+	config, err = newConfig(t, "--retriever.chain-state-backend", "chain", "--indexer-max-entries", "-1")
+	assert.NoError(t, err)
+	assert.Equal(t, retriever.ChainStateBackendChain, config.ChainStateBackend)
+	assert.Equal(t, uint64(0), config.ChainStateStartBlock)
+	config, err = newConfig(t, "--retriever.chain-state-backend", "chain", "--retriever.chain-state-start-block", "1000")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), config.ChainStateStartBlock)
+
+	config, err = newConfig(t, "--retriever.indexer-reorg-depth", "12")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), config.IndexerConfig.MaxReorgDepth)
+	_, err = newConfig(t, "--retriever.indexer-reorg-depth", "200")
+	assert.ErrorContains(t, err, "retriever.indexer-reorg-depth: 200 is not between 0 and 100")
+}
+
+func TestStateCacheConfig(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Equal(t, statecache.Config{FinalizationDepth: 64, UnfinalizedTTL: 12 * time.Second, FinalizedTTL: time.Hour}, config.StateCacheConfig)
+
+	config, err = newConfig(t, "--retriever.state-cache-size", "256", "--retriever.state-cache-unfinalized-ttl", "0", "--retriever.state-cache-finalized-ttl", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, statecache.Config{Size: 256, FinalizationDepth: 64}, config.StateCacheConfig)
+
+	_, err = newConfig(t, "--retriever.state-cache-size", "-1")
+	assert.ErrorContains(t, err, "retriever.state-cache-size")
+	_, err = newConfig(t, "--retriever.state-cache-size", "256", "--retriever.state-cache-unfinalized-ttl", "2h")
+	assert.ErrorContains(t, err, "retriever.state-cache-unfinalized-ttl")
+	_, err = newConfig(t, "--retriever.state-cache-size", "256", "--retriever.state-cache-finalized-ttl", "48h")
+	assert.ErrorContains(t, err, "retriever.state-cache-finalized-ttl")
+}
+
+func TestCommitmentMismatchRetryConfig(t *testing.T) {
+
+	// The config file sets the strict mode, which fails the retrievals the retry is meant for
+	_, err := newConfig(t, "--retriever.commitment-mismatch-retry")
+	assert.ErrorContains(t, err, "retriever.commitment-mismatch-retry: the retry requires the lenient retriever.chunk-verify-failure-mode")
+
+	config, err := newConfig(t, "--retriever.commitment-mismatch-retry", "--retriever.chunk-verify-failure-mode", "lenient")
+	assert.NoError(t, err)
+	assert.True(t, config.CommitmentMismatchRetry)
+}
+
+func TestMetricsPrefixConfig(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Equal(t, retriever.DefaultMetricsPrefix, config.MetricsPrefix)
+
+	config, err = newConfig(t, "--retriever.metrics-namespace", "eigenda", "--retriever.metrics-subsystem", "retriever_holesky")
+	assert.NoError(t, err)
+	assert.Equal(t, retriever.MetricsPrefix{Namespace: "eigenda", Subsystem: "retriever_holesky"}, config.MetricsPrefix)
+
+	_, err = newConfig(t, "--retriever.metrics-subsystem", "retriever-holesky")
+	assert.ErrorContains(t, err, `retriever.metrics-subsystem: "retriever-holesky" must start with a letter`)
+}
+
+func TestMaxOperatorsPerRetrievalConfig(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, config.MaxOperatorsPerRetrieval)
+
+	config, err = newConfig(t, "--retriever.max-operators-per-retrieval", "16", "--retriever.unsafe-overrides", "--retriever.reconstruction-threshold-overrides", "0:16")
+	assert.NoError(t, err)
+	assert.Equal(t, 16, config.MaxOperatorsPerRetrieval)
+
+	_, err = newConfig(t, "--retriever.max-operators-per-retrieval", "-1")
+	assert.ErrorContains(t, err, "retriever.max-operators-per-retrieval: -1 is less than 0")
+
+	// An operator may hold a single chunk, so fewer operators than the threshold may not supply enough chunks
+	_, err = newConfig(t, "--retriever.max-operators-per-retrieval", "8", "--retriever.unsafe-overrides", "--retriever.reconstruction-threshold-overrides", "0:4", "--retriever.reconstruction-threshold-overrides", "1:16")
+	assert.ErrorContains(t, err, "retriever.max-operators-per-retrieval: 8 is below the reconstruction threshold of 16 chunks")
+}
+
+func TestMaxBlobsPerRequestConfig(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 64, config.MaxBlobsPerRequest)
+
+	// The requests are unbounded with 0
+	config, err = newConfig(t, "--retriever.max-blobs-per-request", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, config.MaxBlobsPerRequest)
+
+	_, err = newConfig(t, "--retriever.max-blobs-per-request", "-1")
+	assert.ErrorContains(t, err, "retriever.max-blobs-per-request: -1 is less than 0")
+}
+
+func TestNodeWindowSizeConfig(t *testing.T) {
+
+	// The windows of grpc are kept by default
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), config.NodeInitialWindowSize)
+	assert.Equal(t, int32(0), config.NodeInitialConnWindowSize)
+
+	config, err = newConfig(t, "--retriever.node-initial-window-size", "16777216", "--retriever.node-initial-conn-window-size", "67108864")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(16777216), config.NodeInitialWindowSize)
+	assert.Equal(t, int32(67108864), config.NodeInitialConnWindowSize)
+
+	_, err = newConfig(t, "--retriever.node-initial-window-size", "1024", "--retriever.node-initial-conn-window-size", "4294967296")
+	assert.ErrorContains(t, err, "retriever.node-initial-window-size: 1024 is not between 65535 and 2147483647")
+	assert.ErrorContains(t, err, "retriever.node-initial-conn-window-size: 4294967296 is not between 65535 and 2147483647")
+}
+
+func TestPeerFallbackConfig(t *testing.T) {
+
+	// The chunks aren't cached nor fetched from the peers by default, even if the peers are set
+	config, err := newConfig(t, "--retriever.peers", "retriever-1:32011")
+	assert.NoError(t, err)
+	assert.Zero(t, config.ChunkCacheSize)
+	assert.Nil(t, config.Peers)
+
+	config, err = newConfig(t, "--retriever.chunk-cache-size", "1073741824", "--retriever.peer-fallback", "--retriever.peers", "retriever-1:32011", "--retriever.peers", "retriever-2:32011", "--retriever.max-peers-per-retrieval", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1073741824), config.ChunkCacheSize)
+	assert.Equal(t, []string{"retriever-1:32011", "retriever-2:32011"}, config.Peers)
+	assert.Equal(t, 1, config.MaxPeersPerRetrieval)
+
+	_, err = newConfig(t, "--retriever.peer-fallback", "--retriever.max-peers-per-retrieval", "0")
+	assert.ErrorContains(t, err, "retriever.peers: must be set with retriever.peer-fallback")
+	assert.ErrorContains(t, err, "retriever.max-peers-per-retrieval: 0 is less than 1")
+	_, err = newConfig(t, "--retriever.peer-fallback", "--retriever.peers", "retriever-1")
+	assert.ErrorContains(t, err, `retriever.peers: "retriever-1" must be host:port`)
+	_, err = newConfig(t, "--retriever.peer-tls-ca-file", "/nonexistent/ca.pem")
+	assert.ErrorContains(t, err, "retriever.peer-tls-ca-file: must be set with retriever.peer-tls")
+
+	// The chunks are only cached if they're verified upfront
+	_, err = newConfig(t, "--retriever.chunk-cache-size", "1073741824", "--retriever.commitment-mismatch-retry")
+	assert.ErrorContains(t, err, "retriever.chunk-cache-size: the cache requires the chunks to be verified upfront, which retriever.commitment-mismatch-retry disables")
+}
+
+func TestFanOutOrderConfig(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Nil(t, config.FanOutWeights)
+
+	config, err = newConfig(t, "--retriever.fan-out-order", "stake")
+	assert.NoError(t, err)
+	assert.Equal(t, &clients.FanOutWeights{Stake: 1}, config.FanOutWeights)
+
+	config, err = newConfig(t, "--retriever.fan-out-order", "weighted", "--retriever.fan-out-stake-weight", "0.75")
+	assert.NoError(t, err)
+	assert.Equal(t, &clients.FanOutWeights{Stake: 0.75, Latency: 0.25}, config.FanOutWeights)
+
+	_, err = newConfig(t, "--retriever.fan-out-order", "weighted", "--retriever.fan-out-stake-weight", "1.5")
+	assert.ErrorContains(t, err, "retriever.fan-out-stake-weight: 1.5 is not between 0 and 1")
+
+	_, err = newConfig(t, "--retriever.fan-out-order", "random")
+	assert.ErrorContains(t, err, `retriever.fan-out-order: must be assignment, stake, latency or weighted, got "random"`)
+}
+
+func TestCommitmentVerificationConfig(t *testing.T) {
+
+	// The blobs are checked in full by default, whatever the number of samples
+	config, err := newConfig(t, "--retriever.commitment-verification-samples", "4")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, config.CommitmentSamples)
+
+	config, err = newConfig(t, "--retriever.commitment-verification", "sampled")
+	assert.NoError(t, err)
+	assert.Equal(t, 16, config.CommitmentSamples)
+
+	config, err = newConfig(t, "--retriever.commitment-verification", "sampled", "--retriever.commitment-verification-samples", "4")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, config.CommitmentSamples)
+
+	_, err = newConfig(t, "--retriever.commitment-verification", "sampled", "--retriever.commitment-verification-samples", "0")
+	assert.ErrorContains(t, err, "retriever.commitment-verification-samples: 0 is less than 1")
+
+	_, err = newConfig(t, "--retriever.commitment-verification", "none")
+	assert.ErrorContains(t, err, `retriever.commitment-verification: must be strict or sampled, got "none"`)
+}
+
+func TestResponseSizeConfig(t *testing.T) {
+
+	config, err := newConfig(t)
+	assert.NoError(t, err)
+	assert.Nil(t, config.ResponseSizeBuckets)
+	assert.Equal(t, uint64(0), config.LargeResponseThreshold)
+
+	config, err = newConfig(t, "--retriever.response-size-buckets", "1024", "--retriever.response-size-buckets", "1048576", "--retriever.large-response-threshold", "4194304")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1024, 1048576}, config.ResponseSizeBuckets)
+	assert.Equal(t, uint64(4194304), config.LargeResponseThreshold)
+
+	_, err = newConfig(t, "--retriever.response-size-buckets", "1048576", "--retriever.response-size-buckets", "1024")
+	assert.ErrorContains(t, err, "retriever.response-size-buckets: invalid response size buckets: 1024 is not above 1048576")
+	_, err = newConfig(t, "--retriever.response-size-buckets", "1KiB")
+	assert.ErrorContains(t, err, `invalid response size bucket "1KiB": must be a positive number of bytes`)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
 	"github.com/ethereum/go-ethereum"
@@ -14,6 +15,7 @@ import (
 
 type ChainClient interface {
 	FetchBatchHeader(ctx context.Context, serviceManagerAddress gcommon.Address, batchHeaderHash []byte) (*binding.IEigenDAServiceManagerBatchHeader, error)
+	VerifyBlobCert(ctx context.Context, serviceManagerAddress gcommon.Address, cert *clients.BlobCert) error
 }
 
 type chainClient struct {
@@ -84,3 +86,9 @@ func (c *chainClient) FetchBatchHeader(ctx context.Context, serviceManagerAddres
 
 	return (*binding.IEigenDAServiceManagerBatchHeader)(&batchHeaderInput), nil
 }
+
+// VerifyBlobCert checks that the batch of the cert was confirmed by the service manager contract with the metadata of
+// the cert, and that the blob header of the cert is included in the batch
+func (c *chainClient) VerifyBlobCert(ctx context.Context, serviceManagerAddress gcommon.Address, cert *clients.BlobCert) error {
+	return clients.VerifyBlobCert(ctx, c.ethClient, serviceManagerAddress, cert)
+}
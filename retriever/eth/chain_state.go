@@ -0,0 +1,117 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	blsregcoord "github.com/Layr-Labs/eigenda/contracts/bindings/BLSRegistryCoordinatorWithIndices"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// chainIndexedChainState reads the indexed operator state from the chain alone, without an index or a subgraph: the
+// sockets of the operators are the last ones of their socket update events from the start block up to the block of
+// the state. As every state reads all these events, it suits the deployments with few retrievals, such as the test
+// networks. The public keys of the operators are not served, as the retriever doesn't need them.
+type chainIndexedChainState struct {
+	core.ChainState
+	filterer *blsregcoord.ContractBLSRegistryCoordinatorWithIndicesFilterer
+	// startBlock is the block from which the events are read, usually the deployment of the registry coordinator
+	startBlock uint64
+}
+
+var _ core.IndexedChainState = (*chainIndexedChainState)(nil)
+
+// NewIndexedChainState creates the indexed chain state reading the socket update events of the registry coordinator
+// from the start block
+func NewIndexedChainState(cs core.ChainState, ethClient common.EthClient, registryCoordinatorAddr gcommon.Address, startBlock uint64) (*chainIndexedChainState, error) {
+	filterer, err := blsregcoord.NewContractBLSRegistryCoordinatorWithIndicesFilterer(registryCoordinatorAddr, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	return &chainIndexedChainState{
+		ChainState: cs,
+		filterer:   filterer,
+		startBlock: startBlock,
+	}, nil
+}
+
+func (s *chainIndexedChainState) Start(ctx context.Context) error {
+	return nil
+}
+
+func (s *chainIndexedChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	operatorState, err := s.ChainState.GetOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, err
+	}
+
+	var operatorIDs [][32]byte
+	seen := make(map[core.OperatorID]bool)
+	for _, operators := range operatorState.Operators {
+		for id := range operators {
+			if !seen[id] {
+				seen[id] = true
+				operatorIDs = append(operatorIDs, id)
+			}
+		}
+	}
+	sockets, err := s.getSockets(ctx, uint64(blockNumber), operatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	indexedOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo, len(operatorIDs))
+	for _, id := range operatorIDs {
+		socket, ok := sockets[id]
+		if !ok {
+			return nil, fmt.Errorf("operator %x has no socket update event up to block %d", id, blockNumber)
+		}
+		indexedOperators[id] = &core.IndexedOperatorInfo{Socket: socket}
+	}
+	return &core.IndexedOperatorState{
+		OperatorState:    operatorState,
+		IndexedOperators: indexedOperators,
+		AggKeys:          make(map[core.QuorumID]*core.G1Point),
+	}, nil
+}
+
+// getSockets returns the last sockets the operators registered up to the block
+func (s *chainIndexedChainState) getSockets(ctx context.Context, blockNumber uint64, operatorIDs [][32]byte) (map[core.OperatorID]string, error) {
+	sockets := make(map[core.OperatorID]string, len(operatorIDs))
+	if len(operatorIDs) == 0 {
+		return sockets, nil
+	}
+	if blockNumber < s.startBlock {
+		return nil, fmt.Errorf("block %d is before the start block %d of the socket update events", blockNumber, s.startBlock)
+	}
+	it, err := s.filterer.FilterOperatorSocketUpdate(&bind.FilterOpts{
+		Start:   s.startBlock,
+		End:     &blockNumber,
+		Context: ctx,
+	}, operatorIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	// The events are sorted by the RPC, but the positions are compared so that the last event of each operator wins
+	// regardless
+	type position struct{ block, index uint64 }
+	last := make(map[core.OperatorID]position, len(operatorIDs))
+	for it.Next() {
+		event := it.Event
+		pos := position{block: event.Raw.BlockNumber, index: uint64(event.Raw.Index)}
+		if prev, ok := last[event.OperatorId]; ok && (pos.block < prev.block || (pos.block == prev.block && pos.index < prev.index)) {
+			continue
+		}
+		last[event.OperatorId] = pos
+		sockets[event.OperatorId] = event.Socket
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return sockets, nil
+}
@@ -0,0 +1,84 @@
+package eth_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	damock "github.com/Layr-Labs/eigenda/common/mock"
+	blsregcoord "github.com/Layr-Labs/eigenda/contracts/bindings/BLSRegistryCoordinatorWithIndices"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/retriever/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// socketUpdateLog returns the log of the socket update event of the operator
+func socketUpdateLog(t *testing.T, address gcommon.Address, operatorID core.OperatorID, socket string, blockNumber uint64, index uint) types.Log {
+	parsed, err := abi.JSON(strings.NewReader(blsregcoord.ContractBLSRegistryCoordinatorWithIndicesMetaData.ABI))
+	assert.NoError(t, err)
+	event := parsed.Events["OperatorSocketUpdate"]
+	data, err := event.Inputs.NonIndexed().Pack(socket)
+	assert.NoError(t, err)
+	return types.Log{
+		Address:     address,
+		Topics:      []gcommon.Hash{event.ID, gcommon.Hash(operatorID)},
+		Data:        data,
+		BlockNumber: blockNumber,
+		Index:       index,
+	}
+}
+
+func TestIndexedChainState(t *testing.T) {
+	cs, err := coremock.NewChainDataMock(2)
+	assert.NoError(t, err)
+	quorums := []core.QuorumID{0}
+	operatorState, err := cs.GetOperatorState(context.Background(), 100, quorums)
+	assert.NoError(t, err)
+	var operatorIDs []core.OperatorID
+	for id := range operatorState.Operators[0] {
+		operatorIDs = append(operatorIDs, id)
+	}
+	assert.Len(t, operatorIDs, 2)
+
+	address := gcommon.HexToAddress("0x0000000000000000000000000000000000000001")
+	ethClient := &damock.MockEthClient{}
+	// The events are returned out of order, and the last socket of each operator wins
+	ethClient.On("FilterLogs", mock.Anything).Return([]types.Log{
+		socketUpdateLog(t, address, operatorIDs[0], "new-host:32005;32006", 50, 1),
+		socketUpdateLog(t, address, operatorIDs[0], "old-host:32005;32006", 50, 0),
+		socketUpdateLog(t, address, operatorIDs[1], "host:32005;32006", 10, 0),
+	}, nil).Once()
+	state, err := eth.NewIndexedChainState(cs, ethClient, address, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, state.Start(context.Background()))
+
+	indexedState, err := state.GetIndexedOperatorState(context.Background(), 100, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, operatorState.Operators, indexedState.Operators)
+	assert.Equal(t, "new-host:32005;32006", indexedState.IndexedOperators[operatorIDs[0]].Socket)
+	assert.Equal(t, "host:32005;32006", indexedState.IndexedOperators[operatorIDs[1]].Socket)
+
+	// The events are read from the start block up to the block of the state, from the registry coordinator
+	query := ethClient.Calls[0].Arguments.Get(0).(ethereum.FilterQuery)
+	assert.Equal(t, []gcommon.Address{address}, query.Addresses)
+	assert.Equal(t, uint64(5), query.FromBlock.Uint64())
+	assert.Equal(t, uint64(100), query.ToBlock.Uint64())
+
+	// An operator without a socket can't be reached, which fails the state rather than the retrievals
+	ethClient.On("FilterLogs", mock.Anything).Return([]types.Log{
+		socketUpdateLog(t, address, operatorIDs[0], "host:32005;32006", 10, 0),
+	}, nil).Once()
+	_, err = state.GetIndexedOperatorState(context.Background(), 100, quorums)
+	assert.ErrorContains(t, err, "has no socket update event up to block 100")
+
+	// And the blocks before the start block have no events to read
+	_, err = state.GetIndexedOperatorState(context.Background(), 4, quorums)
+	assert.ErrorContains(t, err, "block 4 is before the start block 5 of the socket update events")
+	ethClient.AssertNumberOfCalls(t, "FilterLogs", 2)
+}
@@ -1,10 +1,18 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/configfile"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpcsec"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/metrics"
+	"github.com/Layr-Labs/eigenda/common/profiling"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
+	"github.com/Layr-Labs/eigenda/core/statecache"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -16,18 +24,6 @@ const (
 
 var (
 	/* Required Flags */
-	HostnameFlag = cli.StringFlag{
-		Name:     common.PrefixFlag(FlagPrefix, "hostname"),
-		Usage:    "Hostname at which retriever service is available",
-		Required: true,
-		EnvVar:   common.PrefixEnvVar(envPrefix, "HOSTNAME"),
-	}
-	GrpcPortFlag = cli.StringFlag{
-		Name:     common.PrefixFlag(FlagPrefix, "grpc-port"),
-		Usage:    "Port at which a retriever listens for grpc calls",
-		Required: true,
-		EnvVar:   common.PrefixEnvVar(envPrefix, "GRPC_PORT"),
-	}
 	TimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "timeout"),
 		Usage:    "Amount of time to wait for GPRC",
@@ -48,6 +44,24 @@ var (
 	}
 
 	/* Optional Flags*/
+	HostnameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "hostname"),
+		Usage:    "Hostname at which retriever service is available. Ignored if listen-addresses is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HOSTNAME"),
+	}
+	GrpcPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "grpc-port"),
+		Usage:    "Port at which a retriever listens for grpc calls. Required unless listen-addresses is set, which it is ignored with",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GRPC_PORT"),
+	}
+	ListenAddressesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "listen-addresses"),
+		Usage:    "addresses the gRPC server listens on, all serving the same service, e.g. 0.0.0.0:32011 and [::]:32011 for dual-stack. Defaults to hostname:grpc-port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "LISTEN_ADDRESSES"),
+	}
 	NumConnectionsFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "num-connections"),
 		Usage:    "maximum number of connections to DA nodes (defaults to 20)",
@@ -61,6 +75,376 @@ var (
 		EnvVar: common.PrefixEnvVar(envPrefix, "DATA_DIR"),
 		Value:  "./data/retriever",
 	}
+	IndexerPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "indexer-poll-interval"),
+		Usage:    "Interval at which the indexer polls the chain for new blocks and events. Shorter intervals keep the index fresher at the cost of more RPC load. If not set, indexer-pull-interval is used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_POLL_INTERVAL"),
+	}
+	IndexerReorgDepthFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "indexer-reorg-depth"),
+		Usage:    "Maximum number of blocks a reorg can replace before the indexer stops and the health checks report NOT_SERVING, as deeper reorgs likely come from a faulty chain or provider. The reorgs up to it are handled, logged and counted in the metrics. 0 doesn't limit the reorgs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_REORG_DEPTH"),
+	}
+	ChainStateBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-state-backend"),
+		Usage:    "source of the operator state of the retrievals: indexer indexes the chain in memory, chain reads the socket update events of the operators from the chain on every retrieval, which suits the deployments with few retrievals, and graph queries the subgraph at graph-url. The indexer flags only apply to the indexer",
+		Required: false,
+		Value:    "indexer",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_STATE_BACKEND"),
+	}
+	ChainStateStartBlockFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-state-start-block"),
+		Usage:    "block from which the chain backend reads the socket update events of the operators, such as the block of the deployment of the registry coordinator. The events before it are ignored, so it must not be later than the registrations of the operators",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_STATE_START_BLOCK"),
+	}
+	GraphUrlFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "graph-url"),
+		Usage:    "URL of the subgraph of the graph chain state backend",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GRAPH_URL"),
+	}
+	GraphRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "graph-retries"),
+		Usage:    "number of times the failed queries to the subgraph are retried",
+		Required: false,
+		Value:    3,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GRAPH_RETRIES"),
+	}
+	GraphBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "graph-backoff"),
+		Usage:    "backoff before the first retry of a query to the subgraph, doubling after every retry",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GRAPH_BACKOFF"),
+	}
+	ChainReadRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-read-retries"),
+		Usage:    "number of times the on-chain reads of the retrieval path (operator state and batch header) are retried on failure",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_READ_RETRIES"),
+		Value:    3,
+	}
+	ChainReadRetryBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-read-retry-backoff"),
+		Usage:    "backoff before the first retry of an on-chain read, doubling after every retry",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_READ_RETRY_BACKOFF"),
+		Value:    500 * time.Millisecond,
+	}
+	ReconstructionMemoryBudgetFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "reconstruction-memory-budget"),
+		Usage:    "maximum total memory in bytes reserved by concurrent blob reconstructions, each of which reserves an estimate based on the blob size (0 disables the budget)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RECONSTRUCTION_MEMORY_BUDGET"),
+	}
+	ReconstructionCaptureDirFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reconstruction-capture-dir"),
+		Usage:    "directory the chunks and the commitment of the failed reconstructions are written to, for debugging. Each capture holds all the chunks the blob was reconstructed from, unredacted, i.e. more than the size of the blob; the captures stop once they fill reconstruction-capture-max-bytes until the directory is emptied. Empty disables the capture",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RECONSTRUCTION_CAPTURE_DIR"),
+	}
+	ReconstructionCaptureMaxBytesFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "reconstruction-capture-max-bytes"),
+		Usage:    "maximum total size in bytes of the captures of the failed reconstructions in reconstruction-capture-dir",
+		Required: false,
+		Value:    1 << 30,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RECONSTRUCTION_CAPTURE_MAX_BYTES"),
+	}
+	RejectOverMemoryBudgetFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reject-over-memory-budget"),
+		Usage:    "reject the requests whose reconstruction doesn't fit in the remaining memory budget with ResourceExhausted, instead of queuing them",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "REJECT_OVER_MEMORY_BUDGET"),
+	}
+	ChunkVerifyFailureModeFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-verify-failure-mode"),
+		Usage:    "what a retrieval does with the chunks of an operator that fail their proofs: lenient drops them and reconstructs the blob from the other operators, strict fails the retrieval",
+		Required: false,
+		Value:    "lenient",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHUNK_VERIFY_FAILURE_MODE"),
+	}
+	CommitmentVerificationFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "commitment-verification"),
+		Usage:    "how the reconstructed blobs are checked against their commitment: strict commits to the whole decoded blob again, sampled only checks the proofs of commitment-verification-samples chunks drawn at random among the ones the blob is reconstructed from. Sampled is much cheaper but only probabilistic, as a blob reconstructed with few wrong chunks passes unless one of them is sampled, so it's meant for integrity monitoring at high throughput. The blobs whose chunks were all verified upfront, i.e. without commitment-mismatch-retry, aren't checked again with sampled, as all their proofs passed",
+		Required: false,
+		Value:    "strict",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_VERIFICATION"),
+	}
+	CommitmentVerificationSamplesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "commitment-verification-samples"),
+		Usage:    "number of chunks whose proofs are checked with the sampled commitment-verification. A blob reconstructed from n chunks of which m are wrong passes with a probability of about (1-m/n)^samples",
+		Required: false,
+		Value:    16,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_VERIFICATION_SAMPLES"),
+	}
+	CommitmentMismatchRetryFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "commitment-mismatch-retry"),
+		Usage:    "retry once a reconstruction whose blob doesn't match its commitment, without the operators whose chunks fail their proofs and with the chunks of the operators that weren't used. The chunks are then only checked on a mismatch rather than upfront. Requires the lenient chunk-verify-failure-mode",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_MISMATCH_RETRY"),
+	}
+	BlacklistUnassignedChunkOperatorsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blacklist-unassigned-chunk-operators"),
+		Usage:    "drop all the chunks of an operator that returns chunks outside of its assignment for the rest of the retrieval, instead of only the chunks outside of it",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLACKLIST_UNASSIGNED_CHUNK_OPERATORS"),
+	}
+	SequentialFetchFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "sequential-fetch"),
+		Usage:    "fetch the chunks from one operator at a time in a deterministic order, to reproduce ordering-dependent bugs. For testing only: it makes each retrieval as slow as the sum of the latencies of the operators",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SEQUENTIAL_FETCH"),
+	}
+	VerboseRetrievalsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "verbose-retrievals"),
+		Usage:    "serve the retrievals that request the diagnostics of their chunks, which are rejected with FailedPrecondition otherwise. Every chunk of a verbose retrieval is verified on its own, which makes it much heavier",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "VERBOSE_RETRIEVALS"),
+	}
+	HighPriorityRetrievalsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "high-priority-retrievals"),
+		Usage:    "admit the retrievals requesting the HIGH priority ahead of the others, which are retrieved at the NORMAL priority otherwise. The priority of a request isn't authenticated, so it's only meant for the retrievers whose clients are trusted",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HIGH_PRIORITY_RETRIEVALS"),
+	}
+	BlobSinkBucketFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-bucket"),
+		Usage:    "S3-compatible bucket the retrieved blobs are also written to, keyed by batch header hash and blob index (disabled if empty)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_BUCKET"),
+	}
+	BlobSinkEndpointURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-endpoint-url"),
+		Usage:    "endpoint URL of the S3-compatible service of the blob sink (defaults to AWS S3)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_ENDPOINT_URL"),
+	}
+	BlobSinkRegionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-region"),
+		Usage:    "region of the bucket of the blob sink",
+		Required: false,
+		Value:    "us-east-1",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_REGION"),
+	}
+	BlobSinkAccessKeyIdFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-access-key-id"),
+		Usage:    "access key id of the blob sink (defaults to the AWS default credentials if empty)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_ACCESS_KEY_ID"),
+	}
+	BlobSinkSecretAccessKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-secret-access-key"),
+		Usage:    "secret access key of the blob sink",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_SECRET_ACCESS_KEY"),
+	}
+	BlobSinkSyncFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-sync"),
+		Usage:    "wait for the blob to be written to the sink before replying, and fail the request if the write fails, instead of writing it in the background",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_SYNC"),
+	}
+	BlobSinkTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-timeout"),
+		Usage:    "timeout of the writes to the blob sink",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_TIMEOUT"),
+	}
+	BlobSinkWorkersFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-workers"),
+		Usage:    "number of writes to the blob sink made at a time in the background",
+		Required: false,
+		Value:    8,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_WORKERS"),
+	}
+	BlobSinkQueueSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-sink-queue-size"),
+		Usage:    "number of writes to the blob sink waiting for a worker, past which the writes are dropped",
+		Required: false,
+		Value:    1000,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_SINK_QUEUE_SIZE"),
+	}
+	SkipSRSValidationFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "skip-srs-validation"),
+		Usage:    "skip checking at startup that the SRS files hold the points of the SRS order, e.g. for fast local iteration",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SKIP_SRS_VALIDATION"),
+	}
+	ProxyURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "proxy-url"),
+		Usage:    "URL of the HTTP or SOCKS5 proxy of the connections to the chain RPC and to the nodes, e.g. socks5://proxy:1080. Overrides the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables, which apply otherwise",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PROXY_URL"),
+	}
+	ExpectedChainIDFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "expected-chain-id"),
+		Usage:    "chain ID the eth RPC must be on, checked at startup so that the contracts aren't read from another network, e.g. with the config of mainnet against the RPC of a testnet. 0 only logs the chain ID of the RPC",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EXPECTED_CHAIN_ID"),
+	}
+	CorrelationIDKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "correlation-id-key"),
+		Usage:    "gRPC metadata key of the correlation IDs the clients tag their requests with, which are generated if absent and appear in the logs of the requests",
+		Required: false,
+		Value:    common.DefaultCorrelationIDKey,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CORRELATION_ID_KEY"),
+	}
+	MaintenanceMessageFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "maintenance-message"),
+		Usage:    "message of the Unavailable errors returned to the clients in maintenance mode, which the retriever enters on SIGUSR1 and leaves on SIGUSR2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAINTENANCE_MESSAGE"),
+	}
+	EndpointRefreshFailuresFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "endpoint-refresh-failures"),
+		Usage:    "number of consecutive connection failures to an operator after which its socket is re-fetched from the current operator state (0 disables the refresh)",
+		Required: false,
+		Value:    3,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "ENDPOINT_REFRESH_FAILURES"),
+	}
+	EndpointRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "endpoint-refresh-interval"),
+		Usage:    "minimum interval between the refreshes of the socket of an operator",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "ENDPOINT_REFRESH_INTERVAL"),
+	}
+	NodeConnectionIdleTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-connection-idle-timeout"),
+		Usage:    "time after which an unused connection to a node is closed. The requests to a node share a single connection until then. 0 dials a connection per request",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NODE_CONNECTION_IDLE_TIMEOUT"),
+	}
+	NodeInitialWindowSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-initial-window-size"),
+		Usage:    "HTTP/2 flow-control window in bytes of each stream to a node, e.g. 16777216 for links with a large bandwidth-delay product. 0 keeps the windows of grpc, which are sized from its estimate of the bandwidth-delay product up to 16 MiB. A set window is fixed, which disables the estimate, and up to a window of chunks is buffered per stream",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NODE_INITIAL_WINDOW_SIZE"),
+	}
+	NodeInitialConnWindowSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-initial-conn-window-size"),
+		Usage:    "HTTP/2 flow-control window in bytes of each connection to a node, shared by its streams, which should be a multiple of node-initial-window-size when the connections are reused. 0 keeps the window of grpc, like node-initial-window-size. Up to a window of chunks is buffered per connection",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NODE_INITIAL_CONN_WINDOW_SIZE"),
+	}
+	MaxOperatorsPerRetrievalFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-operators-per-retrieval"),
+		Usage:    "number of operators first asked for the chunks of a blob, those assigned the most chunks, the next ones being asked only if their chunks are too few to reconstruct the blob. 0 asks all the operators at once",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_OPERATORS_PER_RETRIEVAL"),
+	}
+	FanOutOrderFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fan-out-order"),
+		Usage:    "order in which the operators are contacted for the chunks of a blob, which with max-operators-per-retrieval chooses the ones first contacted: assignment contacts those assigned the most chunks first, stake the most-staked ones, latency the fastest ones as observed by the past retrievals, and weighted orders them by the combination of their stake and speed of fan-out-stake-weight",
+		Required: false,
+		Value:    "assignment",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "FAN_OUT_ORDER"),
+	}
+	FanOutStakeWeightFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "fan-out-stake-weight"),
+		Usage:    "weight between 0 and 1 of the stake of the operators in the weighted fan-out-order, the speed of the operators weighing the rest",
+		Required: false,
+		Value:    0.5,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "FAN_OUT_STAKE_WEIGHT"),
+	}
+	ChunkCacheSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-cache-size"),
+		Usage:    "size in bytes of the cache of the verified chunks of the blobs last retrieved, which are served to the peer retrievers over GetChunks. Requires the chunks to be verified upfront, which commitment-mismatch-retry disables. 0 disables the cache",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHUNK_CACHE_SIZE"),
+	}
+	PeerFallbackFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "peer-fallback"),
+		Usage:    "fetch the chunks that the operators fail to return from the peer retrievers, which cache them with chunk-cache-size. The chunks of the peers are verified against their proofs before they're used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PEER_FALLBACK"),
+	}
+	PeersFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "peers"),
+		Usage:    "gRPC addresses host:port of the peer retrievers the chunks are fetched from with peer-fallback",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PEERS"),
+	}
+	PeerTLSFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "peer-tls"),
+		Usage:    "connect to the peers over TLS, verifying their certificates against the system roots, or against peer-tls-ca-file if it's set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PEER_TLS"),
+	}
+	PeerTLSCAFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "peer-tls-ca-file"),
+		Usage:    "PEM bundle of the CAs that sign the certificates of the peers with peer-tls",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PEER_TLS_CA_FILE"),
+	}
+	MaxPeersPerRetrievalFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-peers-per-retrieval"),
+		Usage:    "number of peers asked at once for the chunks a retrieval misses with peer-fallback, starting from a different peer for each retrieval",
+		Required: false,
+		Value:    2,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_PEERS_PER_RETRIEVAL"),
+	}
+	MaxBlobsPerRequestFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blobs-per-request"),
+		Usage:    "maximum number of blobs requested at once with RetrieveBlobs, larger requests being rejected with InvalidArgument. If 0, the requests are unbounded",
+		Required: false,
+		Value:    64,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_BLOBS_PER_REQUEST"),
+	}
+	TombstoneTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tombstone-ttl"),
+		Usage:    "duration for which the retrievals of a blob that no operator stores fail fast with NotFound instead of contacting the operators again. 0 disables the tombstones",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "TOMBSTONE_TTL"),
+	}
+	ResponseSizeBucketsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "response-size-buckets"),
+		Usage:    "upper bounds in bytes of the buckets of the histogram of the sizes of the retrieved blobs, in increasing order. Defaults to powers of 4 from 1 KiB to 16 MiB",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RESPONSE_SIZE_BUCKETS"),
+	}
+	LargeResponseThresholdFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "large-response-threshold"),
+		Usage:    "size in bytes of the retrieved blobs above which a warning naming the blob is logged, e.g. to catch the clients pulling huge blobs repeatedly. 0 disables the warnings",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "LARGE_RESPONSE_THRESHOLD"),
+	}
+	UnsafeOverridesFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "unsafe-overrides"),
+		Usage:    "allow the overrides of the parameters otherwise derived from the chain, such as the reconstruction thresholds. The target use case is testing",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "UNSAFE_OVERRIDES"),
+	}
+	ReconstructionThresholdOverridesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reconstruction-threshold-overrides"),
+		Usage:    "numbers of chunks the blobs of the quorums are reconstructed from instead of the minimum derived from the chain, as quorum:threshold pairs such as 0:16, for testing changes of the coding parameters. The reconstructed blobs are always checked against their commitment. Requires unsafe-overrides",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RECONSTRUCTION_THRESHOLD_OVERRIDES"),
+	}
+	MetricsNamespaceFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-namespace"),
+		Usage:    "namespace the names of the metrics start with, e.g. to tell apart the retrievers of several deployments sharing a Prometheus",
+		Required: false,
+		Value:    "eigenda_retriever",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "METRICS_NAMESPACE"),
+	}
+	MetricsSubsystemFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-subsystem"),
+		Usage:    "subsystem the names of the metrics continue with after the namespace, which is omitted if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "METRICS_SUBSYSTEM"),
+	}
 	MetricsHTTPPortFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "metrics-http-port"),
 		Usage:    "the http port which the metrics prometheus server is listening",
@@ -68,20 +452,85 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envPrefix, "METRICS_HTTP_PORT"),
 	}
+	ContinueWithoutMetricsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "continue-without-metrics"),
+		Usage:    "log a warning and serve the retrievals without metrics if the metrics fail to start, e.g. as the metrics port is in use, instead of failing to start the retriever",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CONTINUE_WITHOUT_METRICS"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
-	HostnameFlag,
-	GrpcPortFlag,
 	TimeoutFlag,
 	BlsOperatorStateRetrieverFlag,
 	EigenDAServiceManagerFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	HostnameFlag,
+	GrpcPortFlag,
+	ListenAddressesFlag,
 	NumConnectionsFlag,
 	IndexerDataDirFlag,
+	IndexerPollIntervalFlag,
+	IndexerReorgDepthFlag,
+	ChainStateBackendFlag,
+	ChainStateStartBlockFlag,
+	GraphUrlFlag,
+	GraphRetriesFlag,
+	GraphBackoffFlag,
+	ChainReadRetriesFlag,
+	ChainReadRetryBackoffFlag,
+	ReconstructionMemoryBudgetFlag,
+	RejectOverMemoryBudgetFlag,
+	ReconstructionCaptureDirFlag,
+	ReconstructionCaptureMaxBytesFlag,
+	ChunkVerifyFailureModeFlag,
+	CommitmentVerificationFlag,
+	CommitmentVerificationSamplesFlag,
+	CommitmentMismatchRetryFlag,
+	BlacklistUnassignedChunkOperatorsFlag,
+	SequentialFetchFlag,
+	VerboseRetrievalsFlag,
+	HighPriorityRetrievalsFlag,
+	BlobSinkBucketFlag,
+	BlobSinkEndpointURLFlag,
+	BlobSinkRegionFlag,
+	BlobSinkAccessKeyIdFlag,
+	BlobSinkSecretAccessKeyFlag,
+	BlobSinkSyncFlag,
+	BlobSinkTimeoutFlag,
+	BlobSinkWorkersFlag,
+	BlobSinkQueueSizeFlag,
+	SkipSRSValidationFlag,
+	ProxyURLFlag,
+	ExpectedChainIDFlag,
+	CorrelationIDKeyFlag,
+	MaintenanceMessageFlag,
+	EndpointRefreshFailuresFlag,
+	EndpointRefreshIntervalFlag,
+	NodeConnectionIdleTimeoutFlag,
+	NodeInitialWindowSizeFlag,
+	NodeInitialConnWindowSizeFlag,
+	MaxOperatorsPerRetrievalFlag,
+	FanOutOrderFlag,
+	FanOutStakeWeightFlag,
+	ChunkCacheSizeFlag,
+	PeerFallbackFlag,
+	PeersFlag,
+	PeerTLSFlag,
+	PeerTLSCAFileFlag,
+	MaxPeersPerRetrievalFlag,
+	MaxBlobsPerRequestFlag,
+	TombstoneTTLFlag,
+	ResponseSizeBucketsFlag,
+	LargeResponseThresholdFlag,
+	UnsafeOverridesFlag,
+	ReconstructionThresholdOverridesFlag,
+	MetricsNamespaceFlag,
+	MetricsSubsystemFlag,
 	MetricsHTTPPortFlag,
+	ContinueWithoutMetricsFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -92,5 +541,52 @@ func init() {
 	Flags = append(Flags, encoding.CLIFlags(envPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
 	Flags = append(Flags, logging.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, common.ConnectBackoffCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpcsec.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, profiling.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, metrics.CLIFlags(envPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envPrefix)...)
+	Flags = append(Flags, statecache.CLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, core.ChunkCapsCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, configfile.CLIFlag(envPrefix))
+}
+
+/* Flags of the bench command */
+var (
+	BenchBatchHeaderHashFlag = cli.StringFlag{
+		Name:     "batch-header-hash",
+		Usage:    "the hex-encoded hash of the batch header of the blob to retrieve",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BENCH_BATCH_HEADER_HASH"),
+	}
+	BenchBlobIndexFlag = cli.UintFlag{
+		Name:   "blob-index",
+		Usage:  "the index of the blob to retrieve in its batch",
+		EnvVar: common.PrefixEnvVar(envPrefix, "BENCH_BLOB_INDEX"),
+	}
+	BenchQuorumIDFlag = cli.UintFlag{
+		Name:   "quorum-id",
+		Usage:  "the quorum of the blob to retrieve the chunks from",
+		EnvVar: common.PrefixEnvVar(envPrefix, "BENCH_QUORUM_ID"),
+	}
+	BenchIterationsFlag = cli.IntFlag{
+		Name:   "iterations",
+		Usage:  "the number of times the blob is retrieved, one after the other",
+		Value:  10,
+		EnvVar: common.PrefixEnvVar(envPrefix, "BENCH_ITERATIONS"),
+	}
+	BenchJSONFlag = cli.BoolFlag{
+		Name:   "json",
+		Usage:  "print the report as a single line of JSON, after the logs",
+		EnvVar: common.PrefixEnvVar(envPrefix, "BENCH_JSON"),
+	}
+)
+
+// BenchFlags are the flags of the bench command, which also reads the flags of the retriever
+var BenchFlags = []cli.Flag{
+	BenchBatchHeaderHashFlag,
+	BenchBlobIndexFlag,
+	BenchQuorumIDFlag,
+	BenchIterationsFlag,
+	BenchJSONFlag,
 }
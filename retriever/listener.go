@@ -0,0 +1,94 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"google.golang.org/grpc"
+)
+
+// ParseListenAddresses validates the addresses of the gRPC listeners, each being a host and a port such as
+// 0.0.0.0:32011 or [::]:32011. The host is an IP or a hostname, or empty to listen on all the interfaces.
+func ParseListenAddresses(addresses []string) ([]string, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("at least one listen address must be set")
+	}
+	seen := make(map[string]bool)
+	for _, address := range addresses {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid listen address %q: %w", address, err)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return nil, fmt.Errorf("invalid listen address %q: the port must be a number below 65536", address)
+		}
+		if host != "" && net.ParseIP(host) == nil && !validHostname(host) {
+			return nil, fmt.Errorf("invalid listen address %q: the host must be an IP or a hostname", address)
+		}
+		if seen[address] {
+			return nil, fmt.Errorf("duplicate listen address %q", address)
+		}
+		seen[address] = true
+	}
+	return addresses, nil
+}
+
+// validHostname accepts the hostnames of letters, digits, hyphens and dots
+func validHostname(host string) bool {
+	if len(host) > 253 {
+		return false
+	}
+	for i := 0; i < len(host); i++ {
+		c := host[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// Listen listens on all the addresses, or on none of them if it fails to listen on any
+func Listen(addresses []string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(addresses))
+	for _, address := range addresses {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return nil, fmt.Errorf("could not listen on %s: %w", address, err)
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+// Serve serves the gRPC server on all the listeners until the context is done, or until serving on any of them
+// fails. The server is then stopped gracefully, which closes all the listeners once the pending requests are done.
+// It returns the error of the listener that failed, if any.
+func Serve(ctx context.Context, gs *grpc.Server, listeners []net.Listener, logger common.Logger) error {
+	errs := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		listener := listener
+		logger.Info("gRPC server listening", "address", listener.Addr().String())
+		go func() {
+			if err := gs.Serve(listener); err != nil {
+				errs <- fmt.Errorf("failed to serve on %s: %w", listener.Addr(), err)
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+		logger.Info("stopping the gRPC server")
+	case err = <-errs:
+		logger.Error("stopping the gRPC server", "err", err)
+	}
+	gs.GracefulStop()
+	return err
+}
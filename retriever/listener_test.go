@@ -0,0 +1,94 @@
+package retriever_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestParseListenAddresses(t *testing.T) {
+	addresses := []string{"0.0.0.0:32011", "[::]:32011", ":32012", "retriever.internal:32013"}
+	parsed, err := retriever.ParseListenAddresses(addresses)
+	assert.NoError(t, err)
+	assert.Equal(t, addresses, parsed)
+
+	for _, invalid := range [][]string{
+		nil,
+		{"0.0.0.0"},
+		{"::1:32011"},
+		{"0.0.0.0:port"},
+		{"0.0.0.0:65536"},
+		{"retriever_internal:32011"},
+		{"0.0.0.0:32011", "0.0.0.0:32011"},
+	} {
+		_, err := retriever.ParseListenAddresses(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestServeListeners(t *testing.T) {
+	addresses := []string{"127.0.0.1:0", "[::1]:0"}
+	if listener, err := net.Listen("tcp", "[::1]:0"); err != nil {
+		// The loopback interface has no IPv6 address in some sandboxes
+		addresses = []string{"127.0.0.1:0", "127.0.0.1:0"}
+	} else {
+		assert.NoError(t, listener.Close())
+	}
+	listeners, err := retriever.Listen(addresses)
+	assert.NoError(t, err)
+
+	gs := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(gs, health.NewServer())
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- retriever.Serve(ctx, gs, listeners, &commock.Logger{}) }()
+
+	// Every listener serves the same service
+	for _, listener := range listeners {
+		conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Close())
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server didn't stop")
+	}
+	// All the listeners are closed
+	for _, listener := range listeners {
+		_, err := net.DialTimeout("tcp", listener.Addr().String(), time.Second)
+		assert.Error(t, err)
+	}
+}
+
+func TestListenClosesListenersOnFailure(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer taken.Close()
+
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := free.Addr().String()
+	assert.NoError(t, free.Close())
+
+	_, err = retriever.Listen([]string{address, taken.Addr().String()})
+	assert.ErrorContains(t, err, taken.Addr().String())
+	// The first address was released
+	listener, err := net.Listen("tcp", address)
+	assert.NoError(t, err)
+	assert.NoError(t, listener.Close())
+}
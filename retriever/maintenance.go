@@ -0,0 +1,92 @@
+package retriever
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const DefaultMaintenanceMessage = "the retriever is down for maintenance"
+
+// Maintenance is the maintenance mode of the retriever. In maintenance, the new requests are rejected with
+// Unavailable and the message of the maintenance, and the health checks report NOT_SERVING so that the load
+// balancers drain the retriever. The requests in flight when the maintenance starts are allowed to finish, and the
+// health checks and metrics are still served.
+type Maintenance struct {
+	mu      sync.RWMutex
+	enabled bool
+	message string
+	logger  common.Logger
+}
+
+func NewMaintenance(message string, logger common.Logger) *Maintenance {
+	if message == "" {
+		message = DefaultMaintenanceMessage
+	}
+	return &Maintenance{
+		message: message,
+		logger:  logger,
+	}
+}
+
+// Enter starts the maintenance. It does nothing if the retriever is already in maintenance.
+func (m *Maintenance) Enter() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.enabled {
+		m.logger.Info("Entering maintenance mode", "message", m.message)
+	}
+	m.enabled = true
+}
+
+// Leave ends the maintenance. It does nothing if the retriever is not in maintenance.
+func (m *Maintenance) Leave() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.enabled {
+		m.logger.Info("Leaving maintenance mode")
+	}
+	m.enabled = false
+}
+
+func (m *Maintenance) Enabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// HealthStatus is the serving status of the retriever for the health checks
+func (m *Maintenance) HealthStatus() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if m.Enabled() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// UnaryServerInterceptor rejects the requests other than the health checks during the maintenance
+func (m *Maintenance) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	healthService := "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/"
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if m.Enabled() && !strings.HasPrefix(info.FullMethod, healthService) {
+			return nil, status.Error(codes.Unavailable, m.message)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streams other than the health watches during the maintenance
+func (m *Maintenance) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	healthService := "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/"
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if m.Enabled() && !strings.HasPrefix(info.FullMethod, healthService) {
+			return status.Error(codes.Unavailable, m.message)
+		}
+		return handler(srv, ss)
+	}
+}
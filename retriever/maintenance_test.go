@@ -0,0 +1,91 @@
+package retriever_test
+
+import (
+	"context"
+	"testing"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestMaintenance(t *testing.T) {
+	maintenance := retriever.NewMaintenance("back at noon", &commock.Logger{})
+	interceptor := maintenance.UnaryServerInterceptor()
+	retrieve := &grpc.UnaryServerInfo{FullMethod: "/retriever.Retriever/RetrieveBlob"}
+	healthCheck := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	handler := func(ctx context.Context, req any) (any, error) { return "reply", nil }
+
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, maintenance.HealthStatus())
+	_, err := interceptor(context.Background(), nil, retrieve, handler)
+	assert.NoError(t, err)
+
+	// A request in flight when the maintenance starts is allowed to finish
+	started, release := make(chan struct{}), make(chan struct{})
+	inFlight := make(chan error)
+	go func() {
+		_, err := interceptor(context.Background(), nil, retrieve, func(ctx context.Context, req any) (any, error) {
+			close(started)
+			<-release
+			return "reply", nil
+		})
+		inFlight <- err
+	}()
+	<-started
+	maintenance.Enter()
+	close(release)
+	assert.NoError(t, <-inFlight)
+
+	// The new requests are rejected, but the health checks are served
+	_, err = interceptor(context.Background(), nil, retrieve, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "back at noon", status.Convert(err).Message())
+	reply, err := interceptor(context.Background(), nil, healthCheck, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "reply", reply)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, maintenance.HealthStatus())
+
+	maintenance.Leave()
+	_, err = interceptor(context.Background(), nil, retrieve, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, maintenance.HealthStatus())
+}
+
+func TestMaintenanceDefaultMessage(t *testing.T) {
+	maintenance := retriever.NewMaintenance("", &commock.Logger{})
+	maintenance.Enter()
+	_, err := maintenance.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/retriever.Retriever/GetVersion"}, nil)
+	assert.Equal(t, retriever.DefaultMaintenanceMessage, status.Convert(err).Message())
+}
+
+func TestMaintenanceStream(t *testing.T) {
+	maintenance := retriever.NewMaintenance("back at noon", &commock.Logger{})
+	interceptor := maintenance.StreamServerInterceptor()
+	retrieve := &grpc.StreamServerInfo{FullMethod: "/retriever.Retriever/RetrieveBlobs", IsServerStream: true}
+	healthWatch := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+	handled := 0
+	handler := func(srv any, ss grpc.ServerStream) error {
+		handled++
+		return nil
+	}
+
+	assert.NoError(t, interceptor(nil, nil, retrieve, handler))
+	assert.Equal(t, 1, handled)
+
+	// The new streams are rejected, but the health watches are served
+	maintenance.Enter()
+	err := interceptor(nil, nil, retrieve, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "back at noon", status.Convert(err).Message())
+	assert.Equal(t, 1, handled)
+	assert.NoError(t, interceptor(nil, nil, healthWatch, handler))
+	assert.Equal(t, 2, handled)
+
+	maintenance.Leave()
+	assert.NoError(t, interceptor(nil, nil, retrieve, handler))
+	assert.Equal(t, 3, handled)
+}
@@ -0,0 +1,137 @@
+package retriever
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MemoryBudget bounds the total memory reserved by the concurrent reconstructions, each of which reserves an
+// estimate based on the size of its blob. A reconstruction that doesn't fit in the remaining budget waits for
+// the budget to be released, or is rejected with ResourceExhausted if rejectOverBudget is set. A reconstruction
+// larger than the whole budget is always rejected.
+//
+// The waiting reconstructions are admitted by the priority of their retrieval, see PriorityFromContext, and in the
+// order of arrival within a priority. A reconstruction doesn't overtake the ones waiting at its priority or above,
+// so that the large reconstructions of a priority aren't starved by the smaller ones. The head of the queue thus
+// blocks the smaller reconstructions behind it until enough of the budget is released for it, which the budget
+// bounds as no reconstruction reserves more than all of it; and as long as reconstructions of a priority keep
+// waiting, the ones of the lower priorities wait too. The high priority is granted by the server only if its config
+// allows it, as the priorities of the requests aren't authenticated.
+type MemoryBudget struct {
+	mu       sync.Mutex
+	size     int64
+	reserved int64
+	// waiters are the reconstructions waiting for the budget, by priority
+	waiters [numPriorities]list.List
+
+	rejectOverBudget bool
+	metrics          *Metrics
+}
+
+// budgetWaiter is a reconstruction waiting for its reservation, whose ready channel is closed once it's admitted
+type budgetWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+var _ clients.MemoryBudget = (*MemoryBudget)(nil)
+
+// NewMemoryBudget returns a budget of size bytes, which is capped at math.MaxInt64, beyond any memory to reserve
+func NewMemoryBudget(size uint64, rejectOverBudget bool, metrics *Metrics) *MemoryBudget {
+	return &MemoryBudget{
+		size:             int64(min(size, math.MaxInt64)),
+		rejectOverBudget: rejectOverBudget,
+		metrics:          metrics,
+	}
+}
+
+func (b *MemoryBudget) Reserve(ctx context.Context, numBytes uint64) (func(), error) {
+	if numBytes > uint64(b.size) {
+		return nil, status.Errorf(codes.ResourceExhausted, "reconstruction needs an estimated %d bytes, more than the memory budget of %d bytes", numBytes, b.size)
+	}
+	// The reservation is at most the size of the budget, so it doesn't overflow
+	n := int64(numBytes)
+
+	priority := PriorityFromContext(ctx)
+	start := time.Now()
+	b.mu.Lock()
+	if b.reserved+n <= b.size && !b.waiting(priority) {
+		b.reserve(n)
+		b.mu.Unlock()
+	} else if b.rejectOverBudget {
+		reserved := b.reserved
+		b.mu.Unlock()
+		return nil, status.Errorf(codes.ResourceExhausted, "memory budget exhausted: %d of %d bytes reserved, reconstruction needs an estimated %d bytes", reserved, b.size, numBytes)
+	} else {
+		waiter := &budgetWaiter{n: n, ready: make(chan struct{})}
+		elem := b.waiters[priority].PushBack(waiter)
+		b.mu.Unlock()
+
+		select {
+		case <-waiter.ready:
+		case <-ctx.Done():
+			b.mu.Lock()
+			select {
+			case <-waiter.ready:
+				// The reservation was admitted as the context was done, so it's released
+				b.reserve(-n)
+			default:
+				b.waiters[priority].Remove(elem)
+			}
+			// The reservations queued behind this one may fit now
+			b.admit()
+			b.mu.Unlock()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	b.metrics.ObserveMemoryBudgetWait(priority, time.Since(start))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.reserve(-n)
+			b.admit()
+			b.mu.Unlock()
+		})
+	}, nil
+}
+
+// waiting tells whether reconstructions of the priority or above are waiting for the budget, which must be locked
+func (b *MemoryBudget) waiting(priority Priority) bool {
+	for p := PriorityHigh; p <= priority; p++ {
+		if b.waiters[p].Len() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// admit admits the waiting reconstructions that fit in the remaining budget, by priority and in the order of
+// arrival, up to the first one that doesn't fit. The budget must be locked.
+func (b *MemoryBudget) admit() {
+	for p := range b.waiters {
+		for elem := b.waiters[p].Front(); elem != nil; elem = b.waiters[p].Front() {
+			waiter := elem.Value.(*budgetWaiter)
+			if b.reserved+waiter.n > b.size {
+				return
+			}
+			b.reserve(waiter.n)
+			b.waiters[p].Remove(elem)
+			close(waiter.ready)
+		}
+	}
+}
+
+// reserve adds the bytes to the reserved memory and its metric. The budget must be locked.
+func (b *MemoryBudget) reserve(n int64) {
+	b.reserved += n
+	b.metrics.SetReservedReconstructionBytes(b.reserved)
+}
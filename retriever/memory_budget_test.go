@@ -0,0 +1,150 @@
+package retriever_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMemoryBudgetQueues(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+	budget := retriever.NewMemoryBudget(100, false, metrics)
+
+	release, err := budget.Reserve(context.Background(), 60)
+	assert.NoError(t, err)
+	assert.Equal(t, 60.0, gaugeValue(metrics.ReservedMemory))
+
+	// The second reservation waits until the first one is released
+	reserved := make(chan func())
+	go func() {
+		release, err := budget.Reserve(context.Background(), 50)
+		assert.NoError(t, err)
+		reserved <- release
+	}()
+	select {
+	case <-reserved:
+		t.Fatal("reservation exceeded the budget")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	// Releasing twice has no effect
+	release()
+	var secondRelease func()
+	select {
+	case secondRelease = <-reserved:
+	case <-time.After(time.Second):
+		t.Fatal("reservation was not admitted after the budget was released")
+	}
+	assert.Equal(t, 50.0, gaugeValue(metrics.ReservedMemory))
+
+	secondRelease()
+	assert.Equal(t, 0.0, gaugeValue(metrics.ReservedMemory))
+
+	// A queued reservation gives up when its context is done
+	release, err = budget.Reserve(context.Background(), 100)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = budget.Reserve(ctx, 1)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	release()
+}
+
+func TestMemoryBudgetOverflow(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+	budget := retriever.NewMemoryBudget(math.MaxUint64, true, metrics)
+
+	// The budget is capped at the largest reservation that doesn't overflow
+	release, err := budget.Reserve(context.Background(), math.MaxInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(math.MaxInt64), gaugeValue(metrics.ReservedMemory))
+	release()
+	_, err = budget.Reserve(context.Background(), math.MaxInt64+1)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 0.0, gaugeValue(metrics.ReservedMemory))
+}
+
+func TestMemoryBudgetPriorities(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+	budget := retriever.NewMemoryBudget(100, false, metrics)
+
+	release, err := budget.Reserve(context.Background(), 60)
+	assert.NoError(t, err)
+
+	// The reservations are admitted in the order of their priorities, each one waiting for the previous one to be
+	// released as they don't fit together
+	admitted := make(chan retriever.Priority, 3)
+	releases := make(chan func(), 3)
+	queue := func(priority retriever.Priority) {
+		go func() {
+			release, err := budget.Reserve(retriever.WithPriority(context.Background(), priority), 60)
+			assert.NoError(t, err)
+			admitted <- priority
+			releases <- release
+		}()
+		time.Sleep(20 * time.Millisecond)
+	}
+	queue(retriever.PriorityLow)
+
+	// A reservation that fits isn't held back by the waiting ones of a lower priority
+	for _, priority := range []retriever.Priority{retriever.PriorityHigh, retriever.PriorityNormal} {
+		release, err := budget.Reserve(retriever.WithPriority(context.Background(), priority), 40)
+		assert.NoError(t, err)
+		release()
+	}
+
+	queue(retriever.PriorityNormal)
+	queue(retriever.PriorityHigh)
+	// But it is by the ones of its priority or above
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = budget.Reserve(ctx, 40)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	release()
+	for _, expected := range []retriever.Priority{retriever.PriorityHigh, retriever.PriorityNormal, retriever.PriorityLow} {
+		select {
+		case priority := <-admitted:
+			assert.Equal(t, expected, priority)
+		case <-time.After(time.Second):
+			t.Fatalf("%s reservation was not admitted", expected)
+		}
+		(<-releases)()
+	}
+	assert.Equal(t, 0.0, gaugeValue(metrics.ReservedMemory))
+}
+
+func TestMemoryBudgetRejects(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+	budget := retriever.NewMemoryBudget(100, true, metrics)
+
+	release, err := budget.Reserve(context.Background(), 60)
+	assert.NoError(t, err)
+
+	_, err = budget.Reserve(context.Background(), 50)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 60.0, gaugeValue(metrics.ReservedMemory))
+
+	release()
+	release, err = budget.Reserve(context.Background(), 50)
+	assert.NoError(t, err)
+	release()
+}
+
+func TestMemoryBudgetRejectsLargerThanBudget(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+	for _, reject := range []bool{false, true} {
+		budget := retriever.NewMemoryBudget(100, reject, metrics)
+		_, err := budget.Reserve(context.Background(), 101)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	}
+	assert.Equal(t, 0.0, gaugeValue(metrics.ReservedMemory))
+}
@@ -2,70 +2,356 @@ package retriever
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
-	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/collectors"
-	"github.com/prometheus/client_golang/prometheus/promauto"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	commetrics "github.com/Layr-Labs/eigenda/common/metrics"
+	"github.com/Layr-Labs/eigenda/common/version"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 const (
 	Namespace = "eigenda_retriever"
 )
 
-type MetricsConfig struct {
-	HTTPPort string
+// MetricsPrefix is the namespace and subsystem the names of the metrics of the retriever start with, e.g. to group
+// the metrics of several deployments sharing a Prometheus
+type MetricsPrefix struct {
+	Namespace string
+	Subsystem string
 }
 
+// DefaultMetricsPrefix names the metrics eigenda_retriever_<name>
+var DefaultMetricsPrefix = MetricsPrefix{Namespace: Namespace}
+
+// DefaultResponseSizeBuckets are the upper bounds in bytes of the buckets of the sizes of the retrieved blobs, by
+// powers of 4 from 1 KiB to 16 MiB
+var DefaultResponseSizeBuckets = []float64{1 << 10, 1 << 12, 1 << 14, 1 << 16, 1 << 18, 1 << 20, 1 << 22, 1 << 24}
+
 type Metrics struct {
-	registry *prometheus.Registry
+	backend commetrics.Backend
 
-	NumRetrievalRequest prometheus.Counter
+	NumRetrievalRequest commetrics.Counter
+	ResponseSize        commetrics.Histogram
+	NumChainReadRetries commetrics.Counter
+	ReservedMemory      commetrics.Gauge
+	NumBlobSinkWrites   commetrics.Counter
+	NumNodeRequests     commetrics.Counter
+	NodeRequestLatency  commetrics.Histogram
+	NodeReplyBytes      commetrics.Counter
+	NodeConnections     commetrics.Gauge
+	OperatorsContacted  commetrics.Histogram
+	IndexSize           commetrics.Gauge
+	NumIndexPruned      commetrics.Counter
+	NumIndexReorgs      commetrics.Counter
+	MaxIndexReorgDepth  commetrics.Gauge
+	NumBadChunks        commetrics.Counter
+	NumTombstoneHits    commetrics.Counter
+	NumIntegrityChecks  commetrics.Counter
+	NumPeerChunkServes  commetrics.Counter
+	NumPriorityRequests commetrics.Counter
+	AdmissionWait       commetrics.Histogram
+	BuildInfo           commetrics.Gauge
+	LogLevel            commetrics.Gauge
 
-	httpPort string
-	logger   common.Logger
+	logger common.Logger
 }
 
-func NewMetrics(httpPort string, logger common.Logger) *Metrics {
-	reg := prometheus.NewRegistry()
-	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
-	reg.MustRegister(collectors.NewGoCollector())
+var _ clients.MetricsCollector = (*Metrics)(nil)
+var _ indexer.CompactionObserver = (*Metrics)(nil)
+var _ indexer.ReorgObserver = (*Metrics)(nil)
+var _ clients.ChunkVerificationObserver = (*Metrics)(nil)
+var _ clients.ConnectionObserver = (*Metrics)(nil)
+var _ clients.OperatorContactObserver = (*Metrics)(nil)
 
+// NewMetrics creates the metrics of the retriever with the backend, which is Prometheus unless the
+// deployment selects another one. All the metrics are named with the prefix. The sizes of the responses are bucketed
+// by responseSizeBuckets, or DefaultResponseSizeBuckets if it's empty.
+func NewMetrics(backend commetrics.Backend, prefix MetricsPrefix, responseSizeBuckets []float64, logger common.Logger) *Metrics {
+	if len(responseSizeBuckets) == 0 {
+		responseSizeBuckets = DefaultResponseSizeBuckets
+	}
 	metrics := &Metrics{
-		registry: reg,
-		NumRetrievalRequest: promauto.With(reg).NewCounter(
-			prometheus.CounterOpts{
-				Namespace: Namespace,
-				Name:      "request",
-				Help:      "the number of retrieval requests",
-			},
-		),
-		httpPort: httpPort,
-		logger:   logger,
+		backend: backend,
+		NumRetrievalRequest: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "request",
+			Help:      "the number of retrieval requests",
+		}),
+		ResponseSize: backend.NewHistogram(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "response_size_bytes",
+			Help:      "the size in bytes of the blob data returned by the retrievals, by quorum",
+			Labels:    []string{"quorum"},
+			Buckets:   responseSizeBuckets,
+		}),
+		NumChainReadRetries: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "chain_read_retries",
+			Help:      "the number of retries of on-chain reads on the retrieval path",
+			Labels:    []string{"read"},
+		}),
+		ReservedMemory: backend.NewGauge(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "reconstruction_reserved_bytes",
+			Help:      "the estimated memory in bytes reserved by the reconstructions in progress",
+		}),
+		NumBlobSinkWrites: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "blob_sink_writes",
+			Help:      "the number of writes of retrieved blobs to the blob sink, including the ones dropped as the queue was full",
+			Labels:    []string{"status"},
+		}),
+		NumNodeRequests: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "node_requests",
+			Help:      "the number of requests to the retrieval API of the nodes, by node address and gRPC status code",
+			Labels:    []string{"address", "method", "code"},
+		}),
+		NodeRequestLatency: backend.NewHistogram(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "node_request_latency_ms",
+			Help:      "the latency in milliseconds of the requests to the retrieval API of the nodes",
+			Labels:    []string{"address", "method"},
+			Buckets:   []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000},
+		}),
+		NodeReplyBytes: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "node_reply_bytes",
+			Help:      "the number of bytes received from the retrieval API of the nodes",
+			Labels:    []string{"address", "method"},
+		}),
+		NodeConnections: backend.NewGauge(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "node_connections",
+			Help:      "the number of connections open to each node, which the requests to the node share",
+			Labels:    []string{"address"},
+		}),
+		OperatorsContacted: backend.NewHistogram(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "operators_contacted",
+			Help:      "the number of operators asked for the chunks of each retrieval, by quorum",
+			Labels:    []string{"quorum"},
+			Buckets:   []float64{1, 2, 4, 8, 16, 32, 64, 128, 256},
+		}),
+		IndexSize: backend.NewGauge(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "index_headers",
+			Help:      "the number of headers of the indexer store",
+		}),
+		NumIndexPruned: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "index_pruned_headers",
+			Help:      "the number of headers pruned from the indexer store by its compactions",
+		}),
+		NumIndexReorgs: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "index_reorgs",
+			Help:      "the number of reorgs handled by the indexer",
+		}),
+		MaxIndexReorgDepth: backend.NewGauge(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "index_max_reorg_depth",
+			Help:      "the number of blocks replaced by the deepest reorg handled by the indexer",
+		}),
+		NumBadChunks: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "chunk_verification_failures",
+			Help:      "the number of replies of the nodes whose chunks failed their proofs, by operator",
+			Labels:    []string{"operator"},
+		}),
+		NumTombstoneHits: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "tombstone_hits",
+			Help:      "the number of retrievals that failed fast as their blob is known to be stored by no operator",
+		}),
+		NumIntegrityChecks: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "integrity_checks",
+			Help:      "the number of integrity checks of blobs, by whether the blob was retrieved and verified",
+			Labels:    []string{"status"},
+		}),
+		NumPeerChunkServes: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "peer_chunk_requests",
+			Help:      "the number of requests of the peer retrievers for cached chunks, by whether chunks of the blob were cached",
+			Labels:    []string{"status"},
+		}),
+		NumPriorityRequests: backend.NewCounter(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "requests_by_priority",
+			Help:      "the number of retrieval requests, by priority",
+			Labels:    []string{"priority"},
+		}),
+		AdmissionWait: backend.NewHistogram(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "reconstruction_admission_wait_ms",
+			Help:      "the time in milliseconds the reconstructions waited for the memory budget, by priority",
+			Labels:    []string{"priority"},
+			Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000},
+		}),
+		BuildInfo: backend.NewGauge(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "build_info",
+			Help:      "the build info of the retriever, as labels of a gauge that is always 1",
+			Labels:    []string{"version", "git_commit", "git_date", "build_time"},
+		}),
+		LogLevel: backend.NewGauge(commetrics.Opts{
+			Namespace: prefix.Namespace,
+			Subsystem: prefix.Subsystem,
+			Name:      "log_level",
+			Help:      "the level of the logs, from 1 (error) to 5 (trace)",
+		}),
+		logger: logger,
 	}
+	logging.WatchLevel(logger, func(level log.Lvl) {
+		metrics.LogLevel.Set(float64(level))
+	})
 	return metrics
 }
 
 // IncrementRetrievalRequestCounter increments the number of retrieval requests
 func (g *Metrics) IncrementRetrievalRequestCounter() {
-	// if anyone wants to add new metrics type and use "Add" for adding float,
-	// please add the lock, since that ops is not atomic
 	g.NumRetrievalRequest.Inc()
 }
 
-func (g *Metrics) Start(ctx context.Context) {
-	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
-	addr := fmt.Sprintf(":%s", g.httpPort)
-	go func() {
-		log := g.logger
-		http.Handle("/metrics", promhttp.HandlerFor(
-			g.registry,
-			promhttp.HandlerOpts{},
-		))
-		err := http.ListenAndServe(addr, nil)
-		log.Error("Prometheus server failed", "err", err)
-	}()
+// ObserveResponseSize records the size of the blob data returned by a retrieval
+func (g *Metrics) ObserveResponseSize(quorumID core.QuorumID, numBytes int) {
+	g.ResponseSize.Observe(float64(numBytes), strconv.Itoa(int(quorumID)))
+}
+
+// IncrementChainReadRetryCounter increments the number of retries of the given on-chain read
+func (g *Metrics) IncrementChainReadRetryCounter(read string) {
+	g.NumChainReadRetries.Inc(read)
+}
+
+// SetReservedReconstructionBytes sets the memory reserved by the reconstructions in progress
+func (g *Metrics) SetReservedReconstructionBytes(numBytes int64) {
+	g.ReservedMemory.Set(float64(numBytes))
+}
+
+// IncrementBlobSinkWriteCounter increments the number of successful or failed writes to the blob sink
+func (g *Metrics) IncrementBlobSinkWriteCounter(success bool) {
+	if success {
+		g.NumBlobSinkWrites.Inc("success")
+	} else {
+		g.NumBlobSinkWrites.Inc("failure")
+	}
+}
+
+// IncrementBlobSinkDropCounter increments the number of writes to the blob sink dropped as the queue was full
+func (g *Metrics) IncrementBlobSinkDropCounter() {
+	g.NumBlobSinkWrites.Inc("dropped")
+}
+
+// ObserveRPC records the requests of the node client to the nodes. The retrievals themselves are already
+// counted by the server.
+func (g *Metrics) ObserveRPC(observation clients.RPCObservation) {
+	if observation.Client != clients.NodeClientName {
+		return
+	}
+	g.NumNodeRequests.Inc(observation.Address, observation.Method, observation.Code.String())
+	g.NodeRequestLatency.Observe(float64(observation.Latency.Milliseconds()), observation.Address, observation.Method)
+	g.NodeReplyBytes.Add(float64(observation.ReplySize), observation.Address, observation.Method)
+}
+
+// ObserveNodeConnections records the number of connections open to a node
+func (g *Metrics) ObserveNodeConnections(address string, numConnections int) {
+	g.NodeConnections.Set(float64(numConnections), address)
+}
+
+// ObserveOperatorsContacted records the number of operators asked for the chunks of a retrieval
+func (g *Metrics) ObserveOperatorsContacted(quorumID core.QuorumID, numOperators int) {
+	g.OperatorsContacted.Observe(float64(numOperators), strconv.Itoa(int(quorumID)))
+}
+
+// ObserveCompaction records the size of the indexer store and the headers pruned by its compactions
+func (g *Metrics) ObserveCompaction(size int, pruned int) {
+	g.IndexSize.Set(float64(size))
+	g.NumIndexPruned.Add(float64(pruned))
+}
+
+// ObserveReorg records the reorgs handled by the indexer and the depth of the deepest one
+func (g *Metrics) ObserveReorg(depth uint64, maxDepth uint64) {
+	g.NumIndexReorgs.Inc()
+	g.MaxIndexReorgDepth.Set(float64(maxDepth))
+}
+
+// ObserveChunkVerificationFailure records the operators whose chunks fail their proofs
+func (g *Metrics) ObserveChunkVerificationFailure(operatorID core.OperatorID) {
+	g.NumBadChunks.Inc(hex.EncodeToString(operatorID[:]))
+}
+
+// IncrementTombstoneHitCounter increments the number of retrievals of blobs known to be unretrievable
+func (g *Metrics) IncrementTombstoneHitCounter() {
+	g.NumTombstoneHits.Inc()
+}
+
+// IncrementIntegrityCheckCounter increments the number of integrity checks that verified their blob or failed
+func (g *Metrics) IncrementIntegrityCheckCounter(verified bool) {
+	if verified {
+		g.NumIntegrityChecks.Inc("success")
+	} else {
+		g.NumIntegrityChecks.Inc("failure")
+	}
+}
+
+// IncrementPeerChunkRequestCounter increments the number of requests of the peers for chunks that were cached or not
+func (g *Metrics) IncrementPeerChunkRequestCounter(hit bool) {
+	if hit {
+		g.NumPeerChunkServes.Inc("hit")
+	} else {
+		g.NumPeerChunkServes.Inc("miss")
+	}
+}
+
+// IncrementPriorityRequestCounter increments the number of retrieval requests of the priority
+func (g *Metrics) IncrementPriorityRequestCounter(priority Priority) {
+	g.NumPriorityRequests.Inc(priority.String())
+}
+
+// ObserveMemoryBudgetWait records the time a reconstruction of the priority waited for the memory budget
+func (g *Metrics) ObserveMemoryBudgetWait(priority Priority, wait time.Duration) {
+	g.AdmissionWait.Observe(float64(wait.Milliseconds()), priority.String())
+}
+
+// SetBuildInfo exports the build info of the retriever, see the version package
+func (g *Metrics) SetBuildInfo() {
+	g.BuildInfo.Set(1, version.Version, version.GitCommit, version.GitDate, version.BuildTime)
+}
+
+// Start exports the metrics until the context is done, or fails if the backend can't export them
+func (g *Metrics) Start(ctx context.Context) error {
+	if err := g.backend.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the metrics: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,189 @@
+package retriever_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	commetrics "github.com/Layr-Labs/eigenda/common/metrics"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/common/version"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newTestMetrics(logger common.Logger) *retriever.Metrics {
+	return retriever.NewMetrics(commetrics.NewPrometheusBackend("9100", logger), retriever.DefaultMetricsPrefix, nil, logger)
+}
+
+// counterValue and gaugeValue read the metrics of the Prometheus backend
+
+func counterValue(counter commetrics.Counter, labelValues ...string) float64 {
+	return testutil.ToFloat64(counter.(*commetrics.PrometheusCounter).WithLabelValues(labelValues...))
+}
+
+func gaugeValue(gauge commetrics.Gauge, labelValues ...string) float64 {
+	return testutil.ToFloat64(gauge.(*commetrics.PrometheusGauge).WithLabelValues(labelValues...))
+}
+
+func TestMetricsStatsDBackend(t *testing.T) {
+	agent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer agent.Close()
+
+	logger := &commock.Logger{}
+	backend, err := commetrics.NewBackend(commetrics.Config{Backend: commetrics.StatsDBackendName, StatsDAddress: agent.LocalAddr().String()}, logger)
+	assert.NoError(t, err)
+	metrics := retriever.NewMetrics(backend, retriever.DefaultMetricsPrefix, nil, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, metrics.Start(ctx))
+
+	metrics.IncrementRetrievalRequestCounter()
+	metrics.IncrementChainReadRetryCounter("batch_header")
+	metrics.SetReservedReconstructionBytes(1024)
+
+	buf := make([]byte, 1024)
+	var packets []string
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, agent.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := agent.ReadFrom(buf)
+		assert.NoError(t, err)
+		packets = append(packets, string(buf[:n]))
+	}
+	assert.Equal(t, []string{
+		"eigenda_retriever.request:1|c",
+		"eigenda_retriever.chain_read_retries:1|c|#read:batch_header",
+		"eigenda_retriever.reconstruction_reserved_bytes:1024|g",
+	}, packets)
+}
+
+func TestMetricsObserveNodeRPC(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+
+	metrics.ObserveRPC(clients.RPCObservation{Client: clients.NodeClientName, Address: "node0:32002", Method: "RetrieveChunks", Code: codes.OK, Latency: 20 * time.Millisecond, ReplySize: 4096})
+	metrics.ObserveRPC(clients.RPCObservation{Client: clients.NodeClientName, Address: "node0:32002", Method: "RetrieveChunks", Code: codes.DeadlineExceeded, Latency: time.Second})
+	metrics.ObserveRPC(clients.RPCObservation{Client: clients.NodeClientName, Address: "node1:32002", Method: "GetBlobHeader", Code: codes.OK, ReplySize: 512})
+	// The retrievals are left to the retriever's own request counter
+	metrics.ObserveRPC(clients.RPCObservation{Client: clients.RetrievalClientName, Method: "RetrieveBlob", Code: codes.OK})
+
+	assert.Equal(t, 1.0, counterValue(metrics.NumNodeRequests, "node0:32002", "RetrieveChunks", "OK"))
+	assert.Equal(t, 1.0, counterValue(metrics.NumNodeRequests, "node0:32002", "RetrieveChunks", "DeadlineExceeded"))
+	assert.Equal(t, 1.0, counterValue(metrics.NumNodeRequests, "node1:32002", "GetBlobHeader", "OK"))
+	assert.Equal(t, 4096.0, counterValue(metrics.NodeReplyBytes, "node0:32002", "RetrieveChunks"))
+	assert.Equal(t, 512.0, counterValue(metrics.NodeReplyBytes, "node1:32002", "GetBlobHeader"))
+	assert.Equal(t, 0.0, counterValue(metrics.NumNodeRequests, "", "RetrieveBlob", "OK"))
+}
+
+func TestMetricsObserveNodeConnections(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+
+	metrics.ObserveNodeConnections("node0:32002", 1)
+	metrics.ObserveNodeConnections("node1:32002", 2)
+	metrics.ObserveNodeConnections("node1:32002", 1)
+
+	assert.Equal(t, 1.0, gaugeValue(metrics.NodeConnections, "node0:32002"))
+	assert.Equal(t, 1.0, gaugeValue(metrics.NodeConnections, "node1:32002"))
+}
+
+func TestMetricsObserveCompaction(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+
+	metrics.ObserveCompaction(120, 30)
+	metrics.ObserveCompaction(100, 0)
+
+	assert.Equal(t, 100.0, gaugeValue(metrics.IndexSize))
+	assert.Equal(t, 30.0, counterValue(metrics.NumIndexPruned))
+}
+
+func TestMetricsObserveReorg(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+
+	metrics.ObserveReorg(3, 3)
+	metrics.ObserveReorg(1, 3)
+
+	assert.Equal(t, 2.0, counterValue(metrics.NumIndexReorgs))
+	assert.Equal(t, 3.0, gaugeValue(metrics.MaxIndexReorgDepth))
+}
+
+func TestMetricsObserveChunkVerificationFailure(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+
+	metrics.ObserveChunkVerificationFailure(core.OperatorID{1})
+	metrics.ObserveChunkVerificationFailure(core.OperatorID{1})
+
+	assert.Equal(t, 2.0, counterValue(metrics.NumBadChunks, "01"+strings.Repeat("00", 31)))
+}
+
+func TestMetricsBuildInfo(t *testing.T) {
+	metrics := newTestMetrics(&commock.Logger{})
+	setBuildInfo(t, "v0.5.0", "abc123", "1704164645", "2024-01-02T03:04:05Z")
+	metrics.SetBuildInfo()
+
+	assert.Equal(t, 1.0, gaugeValue(metrics.BuildInfo, "v0.5.0", "abc123", "1704164645", "2024-01-02T03:04:05Z"))
+}
+
+// setBuildInfo sets the build info of the version package for the duration of the test
+func setBuildInfo(t *testing.T, v, gitCommit, gitDate, buildTime string) {
+	old := []string{version.Version, version.GitCommit, version.GitDate, version.BuildTime}
+	t.Cleanup(func() {
+		version.Version, version.GitCommit, version.GitDate, version.BuildTime = old[0], old[1], old[2], old[3]
+	})
+	version.Version, version.GitCommit, version.GitDate, version.BuildTime = v, gitCommit, gitDate, buildTime
+}
+
+func TestMetricsPrefix(t *testing.T) {
+	logger := &commock.Logger{}
+	backend := commetrics.NewPrometheusBackend("9100", logger)
+	metrics := retriever.NewMetrics(backend, retriever.MetricsPrefix{Namespace: "eigenda", Subsystem: "retriever_holesky"}, nil, logger)
+	metrics.IncrementRetrievalRequestCounter()
+	metrics.SetBuildInfo()
+
+	families, err := backend.Registry().Gather()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, families)
+	// The metrics of the runtime keep their standard names
+	for _, family := range families {
+		if strings.HasPrefix(family.GetName(), "go_") || strings.HasPrefix(family.GetName(), "process_") {
+			continue
+		}
+		assert.True(t, strings.HasPrefix(family.GetName(), "eigenda_retriever_holesky_"), family.GetName())
+	}
+	assert.Equal(t, 1.0, counterValue(metrics.NumRetrievalRequest))
+}
+
+func TestResponseSizeBuckets(t *testing.T) {
+	logger := &commock.Logger{}
+	backend := commetrics.NewPrometheusBackend("9100", logger)
+	metrics := retriever.NewMetrics(backend, retriever.DefaultMetricsPrefix, []float64{1024, 1 << 20}, logger)
+	metrics.ObserveResponseSize(0, 2048)
+	metrics.ObserveResponseSize(0, 1<<21)
+
+	families, err := backend.Registry().Gather()
+	assert.NoError(t, err)
+	var buckets []float64
+	var counts []uint64
+	for _, family := range families {
+		if family.GetName() != "eigenda_retriever_response_size_bytes" {
+			continue
+		}
+		for _, bucket := range family.GetMetric()[0].GetHistogram().GetBucket() {
+			buckets = append(buckets, bucket.GetUpperBound())
+			counts = append(counts, bucket.GetCumulativeCount())
+		}
+		assert.Equal(t, uint64(2), family.GetMetric()[0].GetHistogram().GetSampleCount())
+	}
+	assert.Equal(t, []float64{1024, 1 << 20}, buckets)
+	assert.Equal(t, []uint64{0, 1}, counts)
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 
+	"github.com/Layr-Labs/eigenda/clients"
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
 	"github.com/Layr-Labs/eigenda/retriever/eth"
 	gcommon "github.com/ethereum/go-ethereum/common"
@@ -23,3 +24,8 @@ func (c *MockChainClient) FetchBatchHeader(ctx context.Context, serviceManagerAd
 	args := c.Called()
 	return args.Get(0).(*binding.IEigenDAServiceManagerBatchHeader), args.Error(1)
 }
+
+func (c *MockChainClient) VerifyBlobCert(ctx context.Context, serviceManagerAddress gcommon.Address, cert *clients.BlobCert) error {
+	args := c.Called()
+	return args.Error(0)
+}
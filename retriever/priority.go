@@ -0,0 +1,61 @@
+package retriever
+
+import (
+	"context"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+)
+
+// Priority is the class of a retrieval, which orders the admission of the retrievals under load
+type Priority int
+
+// The priorities in the order they're admitted
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityLow
+
+	numPriorities = 3
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// priorityOf returns the class of the priority of a request, the normal one for the unknown priorities. The
+// priority of a request isn't authenticated, so the high one is only granted if the config of the server allows it.
+func (s *Server) priorityOf(priority pb.RetrievalPriority) Priority {
+	switch priority {
+	case pb.RetrievalPriority_HIGH:
+		if !s.config.HighPriorityRetrievals {
+			return PriorityNormal
+		}
+		return PriorityHigh
+	case pb.RetrievalPriority_LOW:
+		return PriorityLow
+	default:
+		return PriorityNormal
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns the context of a retrieval of the priority
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority of the retrieval of the context, the normal one if it has none
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
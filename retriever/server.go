@@ -1,15 +1,24 @@
 package retriever
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
 	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/version"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/retriever/eth"
 	gcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -21,18 +30,20 @@ type Server struct {
 	indexedState    core.IndexedChainState
 	logger          common.Logger
 	metrics         *Metrics
+	// chunkCache holds the verified chunks served to the peer retrievers, and is nil if they aren't served
+	chunkCache *clients.ChunkCache
 }
 
 func NewServer(
 	config *Config,
 	logger common.Logger,
+	metrics *Metrics,
 	retrievalClient clients.RetrievalClient,
 	encoder core.Encoder,
 	indexedState core.IndexedChainState,
 	chainClient eth.ChainClient,
+	chunkCache *clients.ChunkCache,
 ) *Server {
-	metrics := NewMetrics(config.MetricsConfig.HTTPPort, logger)
-
 	return &Server{
 		config:          config,
 		retrievalClient: retrievalClient,
@@ -40,39 +51,447 @@ func NewServer(
 		indexedState:    indexedState,
 		logger:          logger,
 		metrics:         metrics,
+		chunkCache:      chunkCache,
 	}
 }
 
+// Start starts the metrics and the chain state. The retriever fails to start if the metrics do, e.g. as their port is
+// in use, unless it's configured to continue without metrics.
 func (s *Server) Start(ctx context.Context) error {
-	s.metrics.Start(ctx)
+	if err := s.metrics.Start(ctx); err != nil {
+		if !s.config.ContinueWithoutMetrics {
+			return err
+		}
+		s.logger.Warn("Serving retrievals without metrics", "err", err)
+	}
 	return s.indexedState.Start(ctx)
 }
 
 func (s *Server) RetrieveBlob(ctx context.Context, req *pb.BlobRequest) (*pb.BlobReply, error) {
-	s.logger.Info("Received request: ", "BatchHeaderHash", req.GetBatchHeaderHash(), "BlobIndex", req.GetBlobIndex())
+	priority := s.priorityOf(req.GetPriority())
+	common.LoggerFromContext(ctx, s.logger).Info("Received request: ", "BatchHeaderHash", req.GetBatchHeaderHash(), "BlobIndex", req.GetBlobIndex(), "priority", priority)
 	s.metrics.IncrementRetrievalRequestCounter()
+	s.metrics.IncrementPriorityRequestCounter(priority)
+	if req.GetVerbose() && !s.config.VerboseRetrievals {
+		return nil, status.Error(codes.FailedPrecondition, "verbose retrievals are disabled on this retriever")
+	}
+	ctx = WithPriority(ctx, priority)
+	batchHeaderHash, batchHeader, referenceBlockNumber, err := s.lookupBatch(ctx, req.GetBatchHeaderHash(), req.GetReferenceBlockNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var contributions []clients.OperatorContribution
+	var inclusionProof *pb.BlobInclusionProof
+	var diagnostics *pb.RetrievalDiagnostics
+	if req.GetVerbose() {
+		var proof *clients.BlobInclusionProof
+		var chunkDiagnostics *clients.RetrievalDiagnostics
+		data, contributions, proof, chunkDiagnostics, err = s.retrievalClient.RetrieveBlobWithDiagnostics(
+			ctx,
+			batchHeaderHash,
+			req.GetBlobIndex(),
+			referenceBlockNumber,
+			batchHeader.BlobHeadersRoot,
+			core.QuorumID(req.GetQuorumId()))
+		diagnostics = toRetrievalDiagnostics(chunkDiagnostics)
+		if err != nil {
+			return nil, withDiagnostics(err, diagnostics)
+		}
+		if req.GetIncludeInclusionProof() {
+			inclusionProof, err = toBlobInclusionProof(proof, batchHeader.BlobHeadersRoot, batchHeader.ReferenceBlockNumber)
+		}
+		if !req.GetIncludeOperators() {
+			contributions = nil
+		}
+	} else if req.GetIncludeInclusionProof() {
+		var proof *clients.BlobInclusionProof
+		data, contributions, proof, err = s.retrievalClient.RetrieveBlobWithInclusionProof(
+			ctx,
+			batchHeaderHash,
+			req.GetBlobIndex(),
+			referenceBlockNumber,
+			batchHeader.BlobHeadersRoot,
+			core.QuorumID(req.GetQuorumId()))
+		if err == nil {
+			inclusionProof, err = toBlobInclusionProof(proof, batchHeader.BlobHeadersRoot, batchHeader.ReferenceBlockNumber)
+		}
+		if !req.GetIncludeOperators() {
+			contributions = nil
+		}
+	} else if req.GetIncludeOperators() {
+		data, contributions, err = s.retrievalClient.RetrieveBlobWithContributions(
+			ctx,
+			batchHeaderHash,
+			req.GetBlobIndex(),
+			referenceBlockNumber,
+			batchHeader.BlobHeadersRoot,
+			core.QuorumID(req.GetQuorumId()))
+	} else {
+		data, err = s.retrievalClient.RetrieveBlob(
+			ctx,
+			batchHeaderHash,
+			req.GetBlobIndex(),
+			referenceBlockNumber,
+			batchHeader.BlobHeadersRoot,
+			core.QuorumID(req.GetQuorumId()))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = blobRange(data, req.GetOffset(), req.GetLength())
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.ObserveResponseSize(core.QuorumID(req.GetQuorumId()), len(data))
+	if threshold := s.config.LargeResponseThreshold; threshold > 0 && uint64(len(data)) > threshold {
+		common.LoggerFromContext(ctx, s.logger).Warn("Returning a blob above the large response threshold", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "blobIndex", req.GetBlobIndex(), "quorum", req.GetQuorumId(), "size", len(data), "threshold", threshold)
+	}
+	return &pb.BlobReply{
+		Data:           data,
+		Operators:      toOperatorContributions(contributions),
+		InclusionProof: inclusionProof,
+		Diagnostics:    diagnostics,
+	}, nil
+}
+
+// RetrieveBlobs retrieves the blobs of the batch together and streams each one as it's retrieved. The failure of a
+// blob is reported in its reply, while the invalid requests fail as they do for RetrieveBlob.
+func (s *Server) RetrieveBlobs(req *pb.BlobsRequest, stream pb.Retriever_RetrieveBlobsServer) error {
+	ctx := stream.Context()
+	logger := common.LoggerFromContext(ctx, s.logger)
+	priority := s.priorityOf(req.GetPriority())
+	logger.Info("Received blobs request: ", "BatchHeaderHash", req.GetBatchHeaderHash(), "numBlobs", len(req.GetBlobs()), "priority", priority)
+	if len(req.GetBlobs()) == 0 {
+		return status.Error(codes.InvalidArgument, "no blob requested")
+	}
+	if max := s.config.MaxBlobsPerRequest; max > 0 && len(req.GetBlobs()) > max {
+		return status.Errorf(codes.InvalidArgument, "%d blobs requested, above the maximum of %d", len(req.GetBlobs()), max)
+	}
+	ctx = WithPriority(ctx, priority)
+	batchHeaderHash, batchHeader, referenceBlockNumber, err := s.lookupBatch(ctx, req.GetBatchHeaderHash(), req.GetReferenceBlockNumber())
+	if err != nil {
+		return err
+	}
+
+	blobs := make([]clients.BlobRetrieval, len(req.GetBlobs()))
+	for i, blob := range req.GetBlobs() {
+		blobs[i] = clients.BlobRetrieval{BlobIndex: blob.GetBlobIndex(), QuorumID: core.QuorumID(blob.GetQuorumId())}
+		s.metrics.IncrementRetrievalRequestCounter()
+		s.metrics.IncrementPriorityRequestCounter(priority)
+	}
+	for result := range s.retrievalClient.RetrieveBlobs(ctx, batchHeaderHash, referenceBlockNumber, batchHeader.BlobHeadersRoot, blobs) {
+		blob := blobs[result.Index]
+		reply := &pb.BlobsReply{
+			Index:     uint32(result.Index),
+			BlobIndex: blob.BlobIndex,
+			QuorumId:  uint32(blob.QuorumID),
+		}
+		if result.Err != nil {
+			logger.Warn("Failed to retrieve a blob of the batch", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "blobIndex", blob.BlobIndex, "quorum", blob.QuorumID, "err", result.Err)
+			st := status.Convert(result.Err)
+			reply.Code = uint32(st.Code())
+			reply.Error = st.Message()
+		} else {
+			reply.Data = result.Data
+			s.metrics.ObserveResponseSize(blob.QuorumID, len(result.Data))
+		}
+		// The retrievals of the blobs not sent yet are canceled with the context of the stream
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckBlobIntegrity retrieves and reconstructs the blob the same way as RetrieveBlob, which verifies it against its
+// commitment, and replies with the outcome and the latency of the retrieval, discarding the blob. The invalid
+// requests and the failures to look up the batch fail as they do for RetrieveBlob, and so do the checks that are
+// canceled or can't read the operator state, which say nothing of the blob. Only the failures of the retrieval
+// itself are reported in the reply and counted as failed checks.
+func (s *Server) CheckBlobIntegrity(ctx context.Context, req *pb.IntegrityCheckRequest) (*pb.IntegrityCheckReply, error) {
+	logger := common.LoggerFromContext(ctx, s.logger)
+	logger.Info("Received integrity check request: ", "BatchHeaderHash", req.GetBatchHeaderHash(), "BlobIndex", req.GetBlobIndex())
+	// The checks are monitoring, which yields to the retrievals of the clients
+	ctx = WithPriority(ctx, PriorityLow)
+	start := time.Now()
+	batchHeaderHash, batchHeader, referenceBlockNumber, err := s.lookupBatch(ctx, req.GetBatchHeaderHash(), req.GetReferenceBlockNumber())
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.retrievalClient.RetrieveBlob(
+		ctx,
+		batchHeaderHash,
+		req.GetBlobIndex(),
+		referenceBlockNumber,
+		batchHeader.BlobHeadersRoot,
+		core.QuorumID(req.GetQuorumId()))
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if errors.Is(err, clients.ErrOperatorStateUnavailable) {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	reply := &pb.IntegrityCheckReply{LatencyMs: uint64(time.Since(start).Milliseconds())}
+	s.metrics.IncrementIntegrityCheckCounter(err == nil)
+	if err != nil {
+		logger.Warn("Blob failed its integrity check", "batchHeaderHash", hex.EncodeToString(req.GetBatchHeaderHash()), "blobIndex", req.GetBlobIndex(), "quorum", req.GetQuorumId(), "err", err)
+		reply.Failure = err.Error()
+		return reply, nil
+	}
+	reply.Verified = true
+	reply.BlobLength = uint32(len(data))
+	return reply, nil
+}
+
+// RetrieveBlobFromCert retrieves the blob of the cert from the first quorum of its blob header, at the reference block
+// of its batch. The batch root of the cert is only trusted once the cert is verified against the batch metadata
+// confirmed on-chain, so the batch header isn't looked up from the confirmation transaction as for RetrieveBlob.
+func (s *Server) RetrieveBlobFromCert(ctx context.Context, req *pb.BlobCertRequest) (*pb.BlobReply, error) {
+	logger := common.LoggerFromContext(ctx, s.logger)
+	priority := s.priorityOf(req.GetPriority())
+	s.metrics.IncrementRetrievalRequestCounter()
+	s.metrics.IncrementPriorityRequestCounter(priority)
+	ctx = WithPriority(ctx, priority)
+	cert, err := clients.DecodeBlobCert(req.GetCert())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(cert.BlobHeader.QuorumBlobParams) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the blob header of the cert has no quorum")
+	}
+	batchHeaderHash, err := cert.BatchHeaderHash()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	proof := cert.BlobVerificationProof
+	logger.Info("Received cert request: ", "BatchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "BatchId", proof.BatchId, "BlobIndex", proof.BlobIndex)
+	err = s.chainClient.VerifyBlobCert(ctx, gcommon.HexToAddress(s.config.EigenDAServiceManagerAddr), cert)
+	if invalidBlobCert(err) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quorumID := core.QuorumID(cert.BlobHeader.QuorumBlobParams[0].QuorumNumber)
+	data, err := s.retrievalClient.RetrieveBlob(
+		ctx,
+		batchHeaderHash,
+		uint32(proof.BlobIndex),
+		uint(proof.BatchMetadata.BatchHeader.ReferenceBlockNumber),
+		proof.BatchMetadata.BatchHeader.BlobHeadersRoot,
+		quorumID)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.ObserveResponseSize(quorumID, len(data))
+	return &pb.BlobReply{Data: data}, nil
+}
+
+// invalidBlobCert returns whether the verification of a cert failed because the cert is invalid, rather than
+// because the chain couldn't be read
+func invalidBlobCert(err error) bool {
+	return errors.Is(err, clients.ErrInvalidBlobInfo) ||
+		errors.Is(err, clients.ErrBatchNotConfirmed) ||
+		errors.Is(err, clients.ErrBatchMetadataMismatch) ||
+		errors.Is(err, clients.ErrBlobNotIncluded)
+}
+
+// lookupBatch returns the header of the batch confirmed on-chain with the hash, and the block the operator state
+// is read at, which is the reference block of the batch. The block of the request, if it's set, must be the same:
+// the operators that held the blob are the ones at the reference block of the batch, and any other block would
+// contact the wrong ones.
+func (s *Server) lookupBatch(ctx context.Context, hash []byte, requestBlockNumber uint32) ([32]byte, *binding.IEigenDAServiceManagerBatchHeader, uint, error) {
+	var batchHeaderHash [32]byte
+	if len(hash) != 32 {
+		return batchHeaderHash, nil, 0, status.Error(codes.InvalidArgument, "got invalid batch header hash")
+	}
+	copy(batchHeaderHash[:], hash)
+
+	batchHeader, err := s.chainClient.FetchBatchHeader(ctx, gcommon.HexToAddress(s.config.EigenDAServiceManagerAddr), hash)
+	if err != nil {
+		return batchHeaderHash, nil, 0, err
+	}
+	if requestBlockNumber != 0 && requestBlockNumber != batchHeader.ReferenceBlockNumber {
+		return batchHeaderHash, nil, 0, status.Errorf(codes.InvalidArgument, "reference block number %d differs from the reference block number %d of the batch", requestBlockNumber, batchHeader.ReferenceBlockNumber)
+	}
+	return batchHeaderHash, batchHeader, uint(batchHeader.ReferenceBlockNumber), nil
+}
+
+// GetVersion returns the build info of the retriever. The fields that weren't injected at build time are empty.
+func (s *Server) GetVersion(ctx context.Context, req *pb.GetVersionRequest) (*pb.GetVersionReply, error) {
+	return &pb.GetVersionReply{
+		Version:          version.Version,
+		GitCommit:        version.GitCommit,
+		BuildTime:        version.BuildTime,
+		EncodingVersions: SupportedEncodingVersions,
+	}, nil
+}
+
+// GetChunks returns the cached chunks of the blob quorum to a peer retriever. It doesn't contact the operators, so
+// that the peers falling back to each other don't fan out further.
+func (s *Server) GetChunks(ctx context.Context, req *pb.ChunksRequest) (*pb.ChunksReply, error) {
+	if s.chunkCache == nil {
+		return nil, status.Error(codes.Unimplemented, "the retriever doesn't cache chunks")
+	}
 	if len(req.GetBatchHeaderHash()) != 32 {
-		return nil, fmt.Errorf("got invalid batch header hash")
+		return nil, status.Error(codes.InvalidArgument, "got invalid batch header hash")
 	}
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], req.GetBatchHeaderHash())
+	cached, ok := s.chunkCache.Get(batchHeaderHash, req.GetBlobIndex(), core.QuorumID(req.GetQuorumId()))
+	s.metrics.IncrementPeerChunkRequestCounter(ok)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no chunk of quorum %d of blob %d of batch %s is cached", req.GetQuorumId(), req.GetBlobIndex(), hex.EncodeToString(batchHeaderHash[:]))
+	}
+	reply := &pb.ChunksReply{
+		Chunks:  make([][]byte, len(cached.Chunks)),
+		Indices: make([]uint32, len(cached.Indices)),
+	}
+	for i, chunk := range cached.Chunks {
+		data, err := chunk.Serialize()
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize chunk %d: %w", cached.Indices[i], err)
+		}
+		reply.Chunks[i] = data
+		reply.Indices[i] = uint32(cached.Indices[i])
+	}
+	return reply, nil
+}
 
-	batchHeader, err := s.chainClient.FetchBatchHeader(ctx, gcommon.HexToAddress(s.config.EigenDAServiceManagerAddr), req.GetBatchHeaderHash())
+// GetBlobAssignments returns the assignment of the chunks of the blob quorum to the operators, as computed by the
+// retrieval client for the retrievals of the blob, whose batch is looked up as for RetrieveBlob
+func (s *Server) GetBlobAssignments(ctx context.Context, req *pb.BlobAssignmentsRequest) (*pb.BlobAssignmentsReply, error) {
+	common.LoggerFromContext(ctx, s.logger).Info("Received assignments request: ", "BatchHeaderHash", req.GetBatchHeaderHash(), "BlobIndex", req.GetBlobIndex())
+	batchHeaderHash, batchHeader, referenceBlockNumber, err := s.lookupBatch(ctx, req.GetBatchHeaderHash(), req.GetReferenceBlockNumber())
 	if err != nil {
 		return nil, err
 	}
-
-	data, err := s.retrievalClient.RetrieveBlob(
+	assignments, err := s.retrievalClient.GetBlobAssignments(
 		ctx,
 		batchHeaderHash,
 		req.GetBlobIndex(),
-		uint(batchHeader.ReferenceBlockNumber),
+		referenceBlockNumber,
 		batchHeader.BlobHeadersRoot,
 		core.QuorumID(req.GetQuorumId()))
 	if err != nil {
 		return nil, err
 	}
-	return &pb.BlobReply{
-		Data: data,
+
+	reply := &pb.BlobAssignmentsReply{
+		Operators:            make([]*pb.OperatorAssignment, 0, len(assignments.Assignments)),
+		TotalChunks:          uint32(assignments.Info.TotalChunks),
+		ChunkLength:          uint32(assignments.EncodingParams.ChunkLength),
+		BlobLength:           uint32(assignments.BlobHeader.Length),
+		NumChunksNeeded:      uint32(assignments.NumChunksNeeded()),
+		ReferenceBlockNumber: uint32(referenceBlockNumber),
+	}
+	for opID, assignment := range assignments.Assignments {
+		opID := opID
+		reply.Operators = append(reply.Operators, &pb.OperatorAssignment{
+			OperatorId: opID[:],
+			StartIndex: uint32(assignment.StartIndex),
+			NumChunks:  uint32(assignment.NumChunks),
+		})
+	}
+	// The operators assigned no chunk may share the start index of another, so the ties are ordered by ID
+	sort.Slice(reply.Operators, func(i, j int) bool {
+		a, b := reply.Operators[i], reply.Operators[j]
+		if a.StartIndex != b.StartIndex {
+			return a.StartIndex < b.StartIndex
+		}
+		return bytes.Compare(a.OperatorId, b.OperatorId) < 0
+	})
+	return reply, nil
+}
+
+func toOperatorContributions(contributions []clients.OperatorContribution) []*pb.OperatorContribution {
+	if len(contributions) == 0 {
+		return nil
+	}
+	operators := make([]*pb.OperatorContribution, len(contributions))
+	for i := range contributions {
+		operators[i] = &pb.OperatorContribution{
+			OperatorId: contributions[i].OperatorID[:],
+			NumChunks:  uint32(contributions[i].NumChunks),
+			LatencyMs:  uint64(contributions[i].Latency.Milliseconds()),
+		}
+	}
+	return operators
+}
+
+func toRetrievalDiagnostics(diagnostics *clients.RetrievalDiagnostics) *pb.RetrievalDiagnostics {
+	if diagnostics == nil {
+		return nil
+	}
+	reply := &pb.RetrievalDiagnostics{ReconstructionLatencyMs: uint64(diagnostics.ReconstructionLatency.Milliseconds())}
+	for i := range diagnostics.Chunks {
+		chunk := &diagnostics.Chunks[i]
+		reply.Chunks = append(reply.Chunks, &pb.ChunkDiagnostic{
+			OperatorId:    chunk.OperatorID[:],
+			Index:         uint32(chunk.Index),
+			LatencyMs:     uint64(chunk.Latency.Milliseconds()),
+			ProofVerified: chunk.ProofVerified,
+			Used:          chunk.Used,
+		})
+	}
+	for i := range diagnostics.OperatorFailures {
+		failure := &diagnostics.OperatorFailures[i]
+		reply.OperatorFailures = append(reply.OperatorFailures, &pb.OperatorFailure{
+			OperatorId: failure.OperatorID[:],
+			LatencyMs:  uint64(failure.Latency.Milliseconds()),
+			Error:      failure.Err,
+		})
+	}
+	return reply
+}
+
+// withDiagnostics returns the error of a failed retrieval with the diagnostics in the details of its status, keeping
+// its code
+func withDiagnostics(err error, diagnostics *pb.RetrievalDiagnostics) error {
+	if diagnostics == nil {
+		return err
+	}
+	st, detailsErr := status.New(status.Code(err), err.Error()).WithDetails(diagnostics)
+	if detailsErr != nil {
+		return err
+	}
+	return st.Err()
+}
+
+// toBlobInclusionProof returns the proof of the blob header against the batch root confirmed on-chain, with the
+// blob header in the ABI encoding that the leaves of the Merkle tree are the hashes of
+func toBlobInclusionProof(proof *clients.BlobInclusionProof, batchRoot [32]byte, referenceBlockNumber uint32) (*pb.BlobInclusionProof, error) {
+	blobHeader, err := proof.BlobHeader.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the blob header: %w", err)
+	}
+	return &pb.BlobInclusionProof{
+		BlobHeader:           blobHeader,
+		Hashes:               proof.Proof.Hashes,
+		Index:                uint32(proof.Proof.Index),
+		BatchRoot:            batchRoot[:],
+		ReferenceBlockNumber: referenceBlockNumber,
 	}, nil
 }
+
+// blobRange returns the range of the blob requested by the offset and length, where a length of 0
+// means the rest of the blob
+func blobRange(data []byte, offset, length uint32) ([]byte, error) {
+	blobLength := uint64(len(data))
+	start := uint64(offset)
+	if start > blobLength {
+		return nil, status.Errorf(codes.InvalidArgument, "offset %d is out of range for blob of length %d", offset, blobLength)
+	}
+	if length == 0 {
+		return data[start:], nil
+	}
+	end := start + uint64(length)
+	if end > blobLength {
+		return nil, status.Errorf(codes.InvalidArgument, "range [%d, %d) is out of range for blob of length %d", start, end, blobLength)
+	}
+	return data[start:end], nil
+}
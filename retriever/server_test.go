@@ -2,21 +2,41 @@ package retriever_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"math"
+	"net"
+	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/Layr-Labs/eigenda/clients"
 	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	"github.com/Layr-Labs/eigenda/common"
+	commetrics "github.com/Layr-Labs/eigenda/common/metrics"
 	commock "github.com/Layr-Labs/eigenda/common/mock"
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/pkg/encoding/kzgEncoder"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
 	"github.com/Layr-Labs/eigenda/retriever"
 	"github.com/Layr-Labs/eigenda/retriever/mock"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/wealdtech/go-merkletree"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 )
 
 const numOperators = 10
@@ -49,8 +69,11 @@ func makeTestEncoder() (core.Encoder, error) {
 	}, nil
 }
 func newTestServer(t *testing.T) *retriever.Server {
+	return newTestServerWithConfig(t, &retriever.Config{})
+}
+
+func newTestServerWithConfig(t *testing.T, config *retriever.Config) *retriever.Server {
 	var err error
-	config := &retriever.Config{}
 
 	logger := &commock.Logger{}
 
@@ -66,7 +89,30 @@ func newTestServer(t *testing.T) *retriever.Server {
 
 	retrievalClient = &clientsmock.MockRetrievalClient{}
 	chainClient = mock.NewMockChainClient()
-	return retriever.NewServer(config, logger, retrievalClient, encoder, indexedChainState, chainClient)
+	metrics := newTestMetrics(logger)
+	return retriever.NewServer(config, logger, metrics, retrievalClient, encoder, indexedChainState, chainClient, nil)
+}
+
+func TestStartMetricsPortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+	logger := &commock.Logger{}
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(numOperators))
+	assert.NoError(t, err)
+	for _, continueWithoutMetrics := range []bool{false, true} {
+		metrics := retriever.NewMetrics(commetrics.NewPrometheusBackend(port, logger), retriever.DefaultMetricsPrefix, nil, logger)
+		config := &retriever.Config{ContinueWithoutMetrics: continueWithoutMetrics}
+		server := retriever.NewServer(config, logger, metrics, &clientsmock.MockRetrievalClient{}, nil, chainState, mock.NewMockChainClient(), nil)
+		err = server.Start(context.Background())
+		if continueWithoutMetrics {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorContains(t, err, "metrics port "+port+" is already in use")
+		}
+	}
 }
 
 func TestRetrieveBlob(t *testing.T) {
@@ -89,3 +135,590 @@ func TestRetrieveBlob(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
 }
+
+func TestRetrieveBlobRange(t *testing.T) {
+	server := newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       0,
+	}, nil)
+
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+
+	blobLength := uint32(len(gettysburgAddressBytes))
+	retrieveRange := func(offset, length uint32) (*pb.BlobReply, error) {
+		return server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+			BatchHeaderHash:      batchHeaderHash[:],
+			BlobIndex:            0,
+			ReferenceBlockNumber: 0,
+			QuorumId:             0,
+			Offset:               offset,
+			Length:               length,
+		})
+	}
+
+	retrievalReply, err := retrieveRange(10, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes[10:30], retrievalReply.Data)
+
+	// A length of 0 returns the rest of the blob
+	retrievalReply, err = retrieveRange(100, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes[100:], retrievalReply.Data)
+
+	retrievalReply, err = retrieveRange(blobLength-5, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes[blobLength-5:], retrievalReply.Data)
+
+	_, err = retrieveRange(blobLength+1, 0)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = retrieveRange(blobLength-5, 6)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The end of the range must not overflow
+	_, err = retrieveRange(10, math.MaxUint32)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRetrieveBlobIncludeOperators(t *testing.T) {
+	server := newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       0,
+	}, nil)
+
+	contributions := []clients.OperatorContribution{
+		{OperatorID: core.OperatorID{1}, NumChunks: 3, Latency: 15 * time.Millisecond},
+		{OperatorID: core.OperatorID{2}, NumChunks: 2, Latency: 40 * time.Millisecond},
+	}
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	retrievalClient.On("RetrieveBlobWithContributions").Return(gettysburgAddressBytes, contributions, nil)
+
+	// The operators are only listed on request
+	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+	assert.Empty(t, retrievalReply.Operators)
+	retrievalClient.AssertNotCalled(t, "RetrieveBlobWithContributions")
+
+	retrievalReply, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash:  batchHeaderHash[:],
+		IncludeOperators: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+	if assert.Len(t, retrievalReply.Operators, 2) {
+		assert.Equal(t, contributions[0].OperatorID[:], retrievalReply.Operators[0].OperatorId)
+		assert.Equal(t, uint32(3), retrievalReply.Operators[0].NumChunks)
+		assert.Equal(t, uint64(15), retrievalReply.Operators[0].LatencyMs)
+		assert.Equal(t, contributions[1].OperatorID[:], retrievalReply.Operators[1].OperatorId)
+		assert.Equal(t, uint32(2), retrievalReply.Operators[1].NumChunks)
+		assert.Equal(t, uint64(40), retrievalReply.Operators[1].LatencyMs)
+	}
+}
+
+func TestRetrieveBlobIncludeInclusionProof(t *testing.T) {
+	server := newTestServer(t)
+	blobHeader := &core.BlobHeader{
+		BlobCommitments: core.BlobCommitments{Commitment: &core.Commitment{G1Point: &bn254.GenG1}, Length: 48},
+		QuorumInfos: []*core.BlobQuorumInfo{{
+			SecurityParam:      core.SecurityParam{QuorumID: 0, AdversaryThreshold: 50, QuorumThreshold: 90},
+			QuantizationFactor: 1,
+		}},
+	}
+	batchHeader := core.BatchHeader{ReferenceBlockNumber: 7}
+	tree, err := batchHeader.SetBatchRoot([]*core.BlobHeader{blobHeader})
+	assert.NoError(t, err)
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	assert.NoError(t, err)
+	proof, err := tree.GenerateProof(blobHeaderHash[:], 0)
+	assert.NoError(t, err)
+	requestedHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchHeader.BatchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       7,
+	}, nil)
+	contributions := []clients.OperatorContribution{{OperatorID: core.OperatorID{1}, NumChunks: 3}}
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	retrievalClient.On("RetrieveBlobWithInclusionProof").Return(gettysburgAddressBytes, contributions, &clients.BlobInclusionProof{
+		BlobHeader: blobHeader,
+		Proof:      &merkletree.Proof{Hashes: proof.Hashes, Index: proof.Index},
+	}, nil)
+
+	// The proof is only assembled on request
+	retrievalReply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: requestedHash[:],
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, retrievalReply.InclusionProof)
+	retrievalClient.AssertNotCalled(t, "RetrieveBlobWithInclusionProof")
+
+	retrievalReply, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash:       requestedHash[:],
+		IncludeInclusionProof: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
+	assert.Empty(t, retrievalReply.Operators)
+	assert.Equal(t, uint32(7), retrievalReply.InclusionProof.GetReferenceBlockNumber())
+	// The client verifies the proof against the batch header hash it requested
+	assert.NoError(t, clients.VerifyRetrievedBlobInclusion(requestedHash, retrievalReply.InclusionProof))
+
+	retrievalReply, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash:       requestedHash[:],
+		IncludeInclusionProof: true,
+		IncludeOperators:      true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, retrievalReply.Operators, 1)
+	assert.NotNil(t, retrievalReply.InclusionProof)
+}
+
+// blockRecordingClient records the reference block numbers the blobs are retrieved at
+type blockRecordingClient struct {
+	*clientsmock.MockRetrievalClient
+	blockNumbers []uint
+}
+
+func (c *blockRecordingClient) RetrieveBlob(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, referenceBlockNumber uint, batchRoot [32]byte, quorumID core.QuorumID) ([]byte, error) {
+	c.blockNumbers = append(c.blockNumbers, referenceBlockNumber)
+	return c.MockRetrievalClient.RetrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+}
+
+func TestRetrieveBlobVerbose(t *testing.T) {
+	server := newTestServerWithConfig(t, &retriever.Config{VerboseRetrievals: true})
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       0,
+	}, nil)
+	diagnostics := &clients.RetrievalDiagnostics{
+		Chunks: []clients.ChunkDiagnostic{
+			{OperatorID: core.OperatorID{1}, Index: 0, Latency: 20 * time.Millisecond, ProofVerified: true, Used: true},
+			{OperatorID: core.OperatorID{2}, Index: 1, Latency: 30 * time.Millisecond},
+		},
+		OperatorFailures:      []clients.OperatorFailure{{OperatorID: core.OperatorID{3}, Latency: time.Second, Err: "operator unavailable"}},
+		ReconstructionLatency: 5 * time.Millisecond,
+	}
+	contributions := []clients.OperatorContribution{{OperatorID: core.OperatorID{1}, NumChunks: 1, Latency: 20 * time.Millisecond}}
+	retrievalClient.On("RetrieveBlobWithDiagnostics").Return(gettysburgAddressBytes, contributions, nil, diagnostics, nil).Once()
+	retrievalClient.On("RetrieveBlobWithDiagnostics").Return(nil, nil, nil, diagnostics, clients.ErrCommitmentMismatch).Once()
+
+	reply, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Verbose:         true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, reply.GetData())
+	// The operators are only listed if they're requested
+	assert.Empty(t, reply.GetOperators())
+	expected := &pb.RetrievalDiagnostics{
+		Chunks: []*pb.ChunkDiagnostic{
+			{OperatorId: []byte{1, 31: 0}, Index: 0, LatencyMs: 20, ProofVerified: true, Used: true},
+			{OperatorId: []byte{2, 31: 0}, Index: 1, LatencyMs: 30},
+		},
+		OperatorFailures:        []*pb.OperatorFailure{{OperatorId: []byte{3, 31: 0}, LatencyMs: 1000, Error: "operator unavailable"}},
+		ReconstructionLatencyMs: 5,
+	}
+	assert.True(t, proto.Equal(expected, reply.GetDiagnostics()))
+
+	// The diagnostics of a failed retrieval are in the details of its error
+	_, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Verbose:         true,
+	})
+	assert.ErrorContains(t, err, clients.ErrCommitmentMismatch.Error())
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+	assert.True(t, proto.Equal(expected, details[0].(*pb.RetrievalDiagnostics)))
+
+	// The diagnostics aren't collected by default
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	reply, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{BatchHeaderHash: batchHeaderHash[:]})
+	assert.NoError(t, err)
+	assert.Nil(t, reply.GetDiagnostics())
+	retrievalClient.AssertNumberOfCalls(t, "RetrieveBlobWithDiagnostics", 2)
+
+	// Nor served unless they're enabled
+	server = newTestServer(t)
+	_, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Verbose:         true,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	retrievalClient.AssertNotCalled(t, "RetrieveBlobWithDiagnostics")
+}
+
+func TestRetrieveBlobReferenceBlockNumber(t *testing.T) {
+	logger := &commock.Logger{}
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(numOperators))
+	assert.NoError(t, err)
+	client := &blockRecordingClient{MockRetrievalClient: &clientsmock.MockRetrievalClient{}}
+	client.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:      batchRoot,
+		ReferenceBlockNumber: 90,
+	}, nil)
+	server := retriever.NewServer(&retriever.Config{}, logger, newTestMetrics(logger), client, nil, chainState, chainClient, nil)
+
+	retrieve := func(referenceBlockNumber uint32) error {
+		_, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+			BatchHeaderHash:      batchHeaderHash[:],
+			ReferenceBlockNumber: referenceBlockNumber,
+		})
+		return err
+	}
+
+	// The operator state is read at the reference block of the batch, which the request may set
+	assert.NoError(t, retrieve(0))
+	assert.NoError(t, retrieve(90))
+	assert.Equal(t, []uint{90, 90}, client.blockNumbers)
+
+	// Any other block is rejected before the operators are contacted
+	for _, referenceBlockNumber := range []uint32{80, 101} {
+		err = retrieve(referenceBlockNumber)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.ErrorContains(t, err, fmt.Sprintf("reference block number %d differs from the reference block number 90 of the batch", referenceBlockNumber))
+	}
+	client.AssertNumberOfCalls(t, "RetrieveBlob", 2)
+}
+
+// priorityRecordingClient records the priorities of the contexts the blobs are retrieved with
+type priorityRecordingClient struct {
+	*clientsmock.MockRetrievalClient
+	priorities []retriever.Priority
+}
+
+func (c *priorityRecordingClient) RetrieveBlob(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, referenceBlockNumber uint, batchRoot [32]byte, quorumID core.QuorumID) ([]byte, error) {
+	c.priorities = append(c.priorities, retriever.PriorityFromContext(ctx))
+	return c.MockRetrievalClient.RetrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+}
+
+func TestRetrieveBlobPriority(t *testing.T) {
+	logger := &commock.Logger{}
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(numOperators))
+	assert.NoError(t, err)
+	client := &priorityRecordingClient{MockRetrievalClient: &clientsmock.MockRetrievalClient{}}
+	client.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{BlobHeadersRoot: batchRoot}, nil)
+	metrics := newTestMetrics(logger)
+	server := retriever.NewServer(&retriever.Config{HighPriorityRetrievals: true}, logger, metrics, client, nil, chainState, chainClient, nil)
+
+	for _, priority := range []pb.RetrievalPriority{pb.RetrievalPriority_NORMAL, pb.RetrievalPriority_HIGH, pb.RetrievalPriority_LOW, pb.RetrievalPriority_HIGH} {
+		_, err := server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+			BatchHeaderHash: batchHeaderHash[:],
+			Priority:        priority,
+		})
+		assert.NoError(t, err)
+	}
+	// The unknown priorities are normal
+	_, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Priority:        pb.RetrievalPriority(7),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []retriever.Priority{retriever.PriorityNormal, retriever.PriorityHigh, retriever.PriorityLow, retriever.PriorityHigh, retriever.PriorityNormal}, client.priorities)
+	assert.Equal(t, 2.0, counterValue(metrics.NumPriorityRequests, "high"))
+	assert.Equal(t, 2.0, counterValue(metrics.NumPriorityRequests, "normal"))
+	assert.Equal(t, 1.0, counterValue(metrics.NumPriorityRequests, "low"))
+
+	// The high priority isn't granted unless the config allows it, as the priority of a request isn't authenticated
+	client.priorities = nil
+	server = retriever.NewServer(&retriever.Config{}, logger, newTestMetrics(logger), client, nil, chainState, chainClient, nil)
+	_, err = server.RetrieveBlob(context.Background(), &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Priority:        pb.RetrievalPriority_HIGH,
+	})
+	assert.NoError(t, err)
+
+	// And the integrity checks are retrieved at the low priority
+	_, err = server.CheckBlobIntegrity(context.Background(), &pb.IntegrityCheckRequest{BatchHeaderHash: batchHeaderHash[:]})
+	assert.NoError(t, err)
+	assert.Equal(t, []retriever.Priority{retriever.PriorityNormal, retriever.PriorityLow}, client.priorities)
+}
+
+// blobsStream records the replies of RetrieveBlobs
+type blobsStream struct {
+	grpc.ServerStream
+	replies []*pb.BlobsReply
+}
+
+func (s *blobsStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *blobsStream) Send(reply *pb.BlobsReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func TestRetrieveBlobs(t *testing.T) {
+	server := newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       0,
+	}, nil)
+
+	blobs := []clients.BlobRetrieval{{BlobIndex: 3, QuorumID: 0}, {BlobIndex: 5, QuorumID: 1}}
+	retrievalClient.On("RetrieveBlobs", blobs).Return([]clients.BlobRetrievalResult{
+		{Index: 1, Err: status.Error(codes.NotFound, "blob is not stored by any operator")},
+		{Index: 0, Data: gettysburgAddressBytes},
+	})
+
+	// Each blob is replied with its own status, in the order they're retrieved
+	stream := &blobsStream{}
+	err := server.RetrieveBlobs(&pb.BlobsRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Blobs:           []*pb.BatchBlob{{BlobIndex: 3, QuorumId: 0}, {BlobIndex: 5, QuorumId: 1}},
+	}, stream)
+	assert.NoError(t, err)
+	assert.Len(t, stream.replies, 2)
+	assert.True(t, proto.Equal(&pb.BlobsReply{
+		Index:     1,
+		BlobIndex: 5,
+		QuorumId:  1,
+		Code:      uint32(codes.NotFound),
+		Error:     "blob is not stored by any operator",
+	}, stream.replies[0]))
+	assert.True(t, proto.Equal(&pb.BlobsReply{
+		Index:     0,
+		BlobIndex: 3,
+		QuorumId:  0,
+		Data:      gettysburgAddressBytes,
+	}, stream.replies[1]))
+
+	err = server.RetrieveBlobs(&pb.BlobsRequest{BatchHeaderHash: batchHeaderHash[:]}, &blobsStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = server.RetrieveBlobs(&pb.BlobsRequest{BatchHeaderHash: []byte{1}, Blobs: []*pb.BatchBlob{{}}}, &blobsStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRetrieveBlobsMaxBlobs(t *testing.T) {
+	logger := &commock.Logger{}
+	client := &clientsmock.MockRetrievalClient{}
+	server := retriever.NewServer(&retriever.Config{MaxBlobsPerRequest: 1}, logger, newTestMetrics(logger), client, nil, nil, mock.NewMockChainClient(), nil)
+
+	// The request is rejected before the batch is looked up or any blob is retrieved
+	err := server.RetrieveBlobs(&pb.BlobsRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Blobs:           []*pb.BatchBlob{{BlobIndex: 3}, {BlobIndex: 5}},
+	}, &blobsStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "above the maximum of 1")
+	client.AssertNotCalled(t, "RetrieveBlobs")
+}
+
+func TestCheckBlobIntegrity(t *testing.T) {
+	server := newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       0,
+	}, nil).Times(4)
+	chainClient.On("FetchBatchHeader").Return((*binding.IEigenDAServiceManagerBatchHeader)(nil), errors.New("connection refused")).Once()
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil).Once()
+	retrievalClient.On("RetrieveBlob").Return([]byte(nil), clients.ErrCommitmentMismatch).Once()
+	retrievalClient.On("RetrieveBlob").Return([]byte(nil), fmt.Errorf("%w at block 0: connection refused", clients.ErrOperatorStateUnavailable)).Once()
+	retrievalClient.On("RetrieveBlob").Return([]byte(nil), context.Canceled).Once()
+
+	// The blob is retrieved through the normal path, but only its length is returned
+	reply, err := server.CheckBlobIntegrity(context.Background(), &pb.IntegrityCheckRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.NoError(t, err)
+	assert.True(t, reply.GetVerified())
+	assert.Empty(t, reply.GetFailure())
+	assert.Equal(t, uint32(len(gettysburgAddressBytes)), reply.GetBlobLength())
+
+	// A blob that fails its verification is reported in the reply rather than as an error of the RPC
+	reply, err = server.CheckBlobIntegrity(context.Background(), &pb.IntegrityCheckRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.NoError(t, err)
+	assert.False(t, reply.GetVerified())
+	assert.Contains(t, reply.GetFailure(), clients.ErrCommitmentMismatch.Error())
+	assert.Zero(t, reply.GetBlobLength())
+
+	// The checks that can't read the operator state or that are canceled fail the RPC, as they say nothing of the blob
+	_, err = server.CheckBlobIntegrity(context.Background(), &pb.IntegrityCheckRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = server.CheckBlobIntegrity(ctx, &pb.IntegrityCheckRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	// And so do the failures to look up the batch, before any retrieval
+	_, err = server.CheckBlobIntegrity(context.Background(), &pb.IntegrityCheckRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	})
+	assert.ErrorContains(t, err, "connection refused")
+
+	// The invalid requests are rejected before any retrieval
+	_, err = server.CheckBlobIntegrity(context.Background(), &pb.IntegrityCheckRequest{
+		BatchHeaderHash: batchHeaderHash[:16],
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	retrievalClient.AssertNumberOfCalls(t, "RetrieveBlob", 4)
+}
+
+func TestRetrieveBlobFromCert(t *testing.T) {
+	server := newTestServer(t)
+	data, err := os.ReadFile("../clients/tests/testdata/blob_cert.hex")
+	assert.NoError(t, err)
+	cert, err := hexutil.Decode(strings.TrimSpace(string(data)))
+	assert.NoError(t, err)
+	chainClient.On("VerifyBlobCert").Return(nil).Once()
+	chainClient.On("VerifyBlobCert").Return(fmt.Errorf("%w: batch 37", clients.ErrBatchNotConfirmed)).Once()
+	chainClient.On("VerifyBlobCert").Return(errors.New("connection refused")).Once()
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+
+	// The batch of the cert is looked up from the cert rather than from the confirmation transaction
+	reply, err := server.RetrieveBlobFromCert(context.Background(), &pb.BlobCertRequest{Cert: cert})
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, reply.GetData())
+	chainClient.AssertNotCalled(t, "FetchBatchHeader")
+
+	// The certs whose batch isn't confirmed are rejected, while the failures to read the chain are not
+	_, err = server.RetrieveBlobFromCert(context.Background(), &pb.BlobCertRequest{Cert: cert})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "batch is not confirmed on-chain")
+	_, err = server.RetrieveBlobFromCert(context.Background(), &pb.BlobCertRequest{Cert: cert})
+	assert.ErrorContains(t, err, "connection refused")
+	assert.NotEqual(t, codes.InvalidArgument, status.Code(err))
+
+	// The certs that aren't in the ABI encoding of the contracts are rejected before the chain is read
+	_, err = server.RetrieveBlobFromCert(context.Background(), &pb.BlobCertRequest{Cert: cert[:len(cert)-1]})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	chainClient.AssertNumberOfCalls(t, "VerifyBlobCert", 3)
+	retrievalClient.AssertNumberOfCalls(t, "RetrieveBlob", 1)
+}
+
+func TestGetBlobAssignments(t *testing.T) {
+	server := newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:            batchRoot,
+		QuorumNumbers:              []byte{0},
+		QuorumThresholdPercentages: []byte{90},
+		ReferenceBlockNumber:       12,
+	}, nil)
+
+	first, second, unassigned := core.OperatorID{1}, core.OperatorID{2}, core.OperatorID{0}
+	retrievalClient.On("GetBlobAssignments").Return(&clients.BlobAssignments{
+		BlobHeader: &core.BlobHeader{BlobCommitments: core.BlobCommitments{Length: 10}},
+		Assignments: map[core.OperatorID]core.Assignment{
+			second:     {StartIndex: 3, NumChunks: 5},
+			unassigned: {StartIndex: 3, NumChunks: 0},
+			first:      {StartIndex: 0, NumChunks: 3},
+		},
+		Info:           core.AssignmentInfo{TotalChunks: 8},
+		EncodingParams: core.EncodingParams{ChunkLength: 4, NumChunks: 8},
+	}, nil)
+
+	reply, err := server.GetBlobAssignments(context.Background(), &pb.BlobAssignmentsRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+		QuorumId:        0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(8), reply.TotalChunks)
+	assert.Equal(t, uint32(4), reply.ChunkLength)
+	assert.Equal(t, uint32(10), reply.BlobLength)
+	assert.Equal(t, uint32(3), reply.NumChunksNeeded)
+	assert.Equal(t, uint32(12), reply.ReferenceBlockNumber)
+
+	// The operators are in the order of their chunks
+	assert.Len(t, reply.Operators, 3)
+	for i, expected := range []*pb.OperatorAssignment{
+		{OperatorId: first[:], StartIndex: 0, NumChunks: 3},
+		{OperatorId: unassigned[:], StartIndex: 3, NumChunks: 0},
+		{OperatorId: second[:], StartIndex: 3, NumChunks: 5},
+	} {
+		assert.True(t, proto.Equal(expected, reply.Operators[i]), "operator %d", i)
+	}
+
+	_, err = server.GetBlobAssignments(context.Background(), &pb.BlobAssignmentsRequest{BatchHeaderHash: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetVersion(t *testing.T) {
+	setBuildInfo(t, "v0.5.0", "abc123", "1704164645", "2024-01-02T03:04:05Z")
+
+	server := newTestServer(t)
+	reply, err := server.GetVersion(context.Background(), &pb.GetVersionRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.5.0", reply.GetVersion())
+	assert.Equal(t, "abc123", reply.GetGitCommit())
+	assert.Equal(t, "2024-01-02T03:04:05Z", reply.GetBuildTime())
+	assert.Equal(t, []uint32{0}, reply.GetEncodingVersions())
+}
+
+func TestGetChunks(t *testing.T) {
+	logger := &commock.Logger{}
+	chainState, err := coremock.NewChainDataMock(core.OperatorIndex(numOperators))
+	assert.NoError(t, err)
+	cache := clients.NewChunkCache(1 << 20)
+	server := retriever.NewServer(&retriever.Config{}, logger, newTestMetrics(logger), &clientsmock.MockRetrievalClient{}, nil, chainState, mock.NewMockChainClient(), cache)
+
+	// The chunks are served to the peers over gRPC
+	listener := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	pb.RegisterRetrieverServer(gs, server)
+	go func() { _ = gs.Serve(listener) }()
+	defer gs.Stop()
+	var numDials atomic.Int32
+	peerClient := clients.NewPeerClient(time.Second, &common.GRPCClientOptions{
+		ContextDialer: func(ctx context.Context, address string) (net.Conn, error) {
+			numDials.Add(1)
+			return listener.DialContext(ctx)
+		},
+	})
+	defer peerClient.Close()
+
+	// Nothing is served until the chunks are cached
+	_, err = peerClient.GetPeerChunks(context.Background(), "bufconn", batchHeaderHash, 0, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	chunks := []*core.Chunk{
+		{Coeffs: []core.Symbol{bn254.ONE, bn254.ONE}, Proof: bn254.GenG1},
+		{Coeffs: []core.Symbol{bn254.ONE, bn254.ZERO}, Proof: bn254.GenG1},
+	}
+	cache.Add(batchHeaderHash, 0, 0, chunks, []core.ChunkNumber{4, 7})
+	served, err := peerClient.GetPeerChunks(context.Background(), "bufconn", batchHeaderHash, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.ChunkNumber{4, 7}, served.Indices)
+	assert.Equal(t, chunks, served.Chunks)
+	_, err = peerClient.GetPeerChunks(context.Background(), "bufconn", batchHeaderHash, 0, 1)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	// The requests share the connection to the peer
+	assert.Equal(t, int32(1), numDials.Load())
+
+	_, err = server.GetChunks(context.Background(), &pb.ChunksRequest{BatchHeaderHash: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The retrievers that don't cache chunks don't serve them
+	server = retriever.NewServer(&retriever.Config{}, logger, newTestMetrics(logger), &clientsmock.MockRetrievalClient{}, nil, chainState, mock.NewMockChainClient(), nil)
+	_, err = server.GetChunks(context.Background(), &pb.ChunksRequest{BatchHeaderHash: batchHeaderHash[:]})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
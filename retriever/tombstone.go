@@ -0,0 +1,207 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTombstones bounds the memory of the tombstones. The blobs found unretrievable once the store is full are not
+// recorded until the expired tombstones are dropped.
+const maxTombstones = 100_000
+
+type tombstoneKey struct {
+	batchHeaderHash [32]byte
+	blobIndex       uint32
+	quorumID        core.QuorumID
+}
+
+// Tombstones records the blobs that no operator stores, so that the retrievals of a blob that is known to be
+// unretrievable fail fast with NotFound for a while instead of contacting all the operators again
+type Tombstones struct {
+	ttl     time.Duration
+	metrics *Metrics
+	logger  common.Logger
+
+	mu sync.Mutex
+	// expiries are the times at which the tombstones expire
+	expiries map[tombstoneKey]time.Time
+}
+
+// NewTombstones creates the tombstones of the blobs found unretrievable, which expire after the TTL
+func NewTombstones(ttl time.Duration, metrics *Metrics, logger common.Logger) *Tombstones {
+	return &Tombstones{
+		ttl:      ttl,
+		metrics:  metrics,
+		logger:   logger,
+		expiries: make(map[tombstoneKey]time.Time),
+	}
+}
+
+// check returns the NotFound error of the blob if it has a tombstone that hasn't expired
+func (t *Tombstones) check(key tombstoneKey) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	expiry, ok := t.expiries[key]
+	if !ok {
+		return nil
+	}
+	if !time.Now().Before(expiry) {
+		delete(t.expiries, key)
+		return nil
+	}
+	t.metrics.IncrementTombstoneHitCounter()
+	return status.Errorf(codes.NotFound, "blob %x/%d of quorum %d is not stored by any operator (known until %s)",
+		key.batchHeaderHash, key.blobIndex, key.quorumID, expiry.UTC().Format(time.RFC3339))
+}
+
+// observe records a tombstone for the blob if the retrieval failed as unretrievable, and returns the error of the
+// retrieval, with the NotFound status in that case
+func (t *Tombstones) observe(ctx context.Context, key tombstoneKey, err error) error {
+	if !errors.Is(err, clients.ErrBlobNotFound) {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if len(t.expiries) >= maxTombstones {
+		for k, expiry := range t.expiries {
+			if !now.Before(expiry) {
+				delete(t.expiries, k)
+			}
+		}
+	}
+	if len(t.expiries) < maxTombstones {
+		t.expiries[key] = now.Add(t.ttl)
+	} else {
+		common.LoggerFromContext(ctx, t.logger).Warn("Tombstones are full, not recording the unretrievable blob", "batchHeaderHash", key.batchHeaderHash, "blobIndex", key.blobIndex)
+	}
+	return status.Error(codes.NotFound, err.Error())
+}
+
+// WrapRetrievalClient returns the retrieval client with the retrievals of the blobs with a tombstone short-circuited
+func (t *Tombstones) WrapRetrievalClient(client clients.RetrievalClient) clients.RetrievalClient {
+	return &tombstoningRetrievalClient{
+		RetrievalClient: client,
+		tombstones:      t,
+	}
+}
+
+type tombstoningRetrievalClient struct {
+	clients.RetrievalClient
+	tombstones *Tombstones
+}
+
+func (c *tombstoningRetrievalClient) RetrieveBlob(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, error) {
+	key := tombstoneKey{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, quorumID: quorumID}
+	if err := c.tombstones.check(key); err != nil {
+		return nil, err
+	}
+	data, err := c.RetrievalClient.RetrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, c.tombstones.observe(ctx, key, err)
+	}
+	return data, nil
+}
+
+func (c *tombstoningRetrievalClient) RetrieveBlobWithContributions(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, error) {
+	key := tombstoneKey{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, quorumID: quorumID}
+	if err := c.tombstones.check(key); err != nil {
+		return nil, nil, err
+	}
+	data, contributions, err := c.RetrievalClient.RetrieveBlobWithContributions(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, c.tombstones.observe(ctx, key, err)
+	}
+	return data, contributions, nil
+}
+
+func (c *tombstoningRetrievalClient) RetrieveBlobWithInclusionProof(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, *clients.BlobInclusionProof, error) {
+	key := tombstoneKey{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, quorumID: quorumID}
+	if err := c.tombstones.check(key); err != nil {
+		return nil, nil, nil, err
+	}
+	data, contributions, proof, err := c.RetrievalClient.RetrieveBlobWithInclusionProof(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, nil, c.tombstones.observe(ctx, key, err)
+	}
+	return data, contributions, proof, nil
+}
+
+func (c *tombstoningRetrievalClient) RetrieveBlobWithDiagnostics(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, []clients.OperatorContribution, *clients.BlobInclusionProof, *clients.RetrievalDiagnostics, error) {
+	key := tombstoneKey{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex, quorumID: quorumID}
+	if err := c.tombstones.check(key); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	data, contributions, proof, diagnostics, err := c.RetrievalClient.RetrieveBlobWithDiagnostics(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, nil, nil, diagnostics, c.tombstones.observe(ctx, key, err)
+	}
+	return data, contributions, proof, diagnostics, nil
+}
+
+// RetrieveBlobs only retrieves the blobs without a tombstone, whose failures are observed as for RetrieveBlob
+func (c *tombstoningRetrievalClient) RetrieveBlobs(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	blobs []clients.BlobRetrieval) <-chan clients.BlobRetrievalResult {
+	results := make(chan clients.BlobRetrievalResult, len(blobs))
+	// indices are the positions in the blobs of the ones retrieved
+	retrieved := make([]clients.BlobRetrieval, 0, len(blobs))
+	indices := make([]int, 0, len(blobs))
+	for i, blob := range blobs {
+		key := tombstoneKey{batchHeaderHash: batchHeaderHash, blobIndex: blob.BlobIndex, quorumID: blob.QuorumID}
+		if err := c.tombstones.check(key); err != nil {
+			results <- clients.BlobRetrievalResult{Index: i, Err: err}
+			continue
+		}
+		retrieved = append(retrieved, blob)
+		indices = append(indices, i)
+	}
+	go func() {
+		defer close(results)
+		for result := range c.RetrievalClient.RetrieveBlobs(ctx, batchHeaderHash, referenceBlockNumber, batchRoot, retrieved) {
+			if result.Err != nil {
+				blob := retrieved[result.Index]
+				key := tombstoneKey{batchHeaderHash: batchHeaderHash, blobIndex: blob.BlobIndex, quorumID: blob.QuorumID}
+				result.Err = c.tombstones.observe(ctx, key, result.Err)
+			}
+			result.Index = indices[result.Index]
+			results <- result
+		}
+	}()
+	return results
+}
@@ -0,0 +1,120 @@
+package retriever_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	commock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTombstones(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	tombstones := retriever.NewTombstones(200*time.Millisecond, metrics, logger)
+
+	mockClient := clientsmock.NewRetrievalClient()
+	unretrievable := fmt.Errorf("%w (index: 0)", clients.ErrBlobNotFound)
+	mockClient.On("RetrieveBlob").Return([]byte(nil), unretrievable)
+	mockClient.On("RetrieveBlobWithContributions").Return([]byte(nil), nil, unretrievable)
+	mockClient.On("RetrieveBlobWithInclusionProof").Return([]byte(nil), nil, nil, unretrievable)
+	client := tombstones.WrapRetrievalClient(mockClient)
+
+	_, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.ErrorContains(t, err, "blob is not stored by any operator")
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlob", 1)
+
+	// The retrievals of the blob fail fast until the tombstone expires, whatever their kind
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, _, err = client.RetrieveBlobWithContributions(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, _, _, err = client.RetrieveBlobWithInclusionProof(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlob", 1)
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlobWithContributions", 0)
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlobWithInclusionProof", 0)
+	assert.Equal(t, 3.0, counterValue(metrics.NumTombstoneHits))
+
+	// The other blobs and quorums of the batch are retrieved
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 1, 0, batchRoot, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 1)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlob", 3)
+
+	time.Sleep(250 * time.Millisecond)
+	_, err = client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlob", 4)
+	assert.Equal(t, 3.0, counterValue(metrics.NumTombstoneHits))
+}
+
+func TestTombstonesRetrieveBlobs(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	tombstones := retriever.NewTombstones(time.Hour, metrics, logger)
+
+	mockClient := clientsmock.NewRetrievalClient()
+	unretrievable := fmt.Errorf("%w (index: 0)", clients.ErrBlobNotFound)
+	blobs := []clients.BlobRetrieval{{BlobIndex: 0}, {BlobIndex: 1}}
+	mockClient.On("RetrieveBlobs", blobs).Return([]clients.BlobRetrievalResult{
+		{Index: 1, Data: gettysburgAddressBytes},
+		{Index: 0, Err: unretrievable},
+	}).Once()
+	client := tombstones.WrapRetrievalClient(mockClient)
+
+	results := collectResults(client.RetrieveBlobs(context.Background(), batchHeaderHash, 0, batchRoot, blobs))
+	assert.Len(t, results, 2)
+	assert.Equal(t, codes.NotFound, status.Code(results[0].Err))
+	assert.NoError(t, results[1].Err)
+
+	// Only the blob without a tombstone is retrieved, and its result keeps its position in the blobs
+	mockClient.On("RetrieveBlobs", blobs[1:]).Return([]clients.BlobRetrievalResult{
+		{Index: 0, Data: gettysburgAddressBytes},
+	}).Once()
+	results = collectResults(client.RetrieveBlobs(context.Background(), batchHeaderHash, 0, batchRoot, blobs))
+	assert.Len(t, results, 2)
+	assert.Equal(t, codes.NotFound, status.Code(results[0].Err))
+	assert.Equal(t, gettysburgAddressBytes, results[1].Data)
+	assert.Equal(t, 1.0, counterValue(metrics.NumTombstoneHits))
+	mockClient.AssertExpectations(t)
+}
+
+// collectResults returns the results of the blobs of RetrieveBlobs in the order of the blobs
+func collectResults(results <-chan clients.BlobRetrievalResult) []clients.BlobRetrievalResult {
+	var collected []clients.BlobRetrievalResult
+	for result := range results {
+		collected = append(collected, result)
+	}
+	sort.Slice(collected, func(i, j int) bool { return collected[i].Index < collected[j].Index })
+	return collected
+}
+
+func TestTombstonesTransientFailure(t *testing.T) {
+	logger := &commock.Logger{}
+	metrics := newTestMetrics(logger)
+	tombstones := retriever.NewTombstones(time.Hour, metrics, logger)
+
+	mockClient := clientsmock.NewRetrievalClient()
+	unreachable := fmt.Errorf("failed to get blob header from all operators: %w", clients.ErrNodeConnectionFailed)
+	mockClient.On("RetrieveBlob").Return([]byte(nil), unreachable)
+	client := tombstones.WrapRetrievalClient(mockClient)
+
+	// The failures that may not happen again are returned as they are, and retried on the next requests
+	for i := 0; i < 2; i++ {
+		_, err := client.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+		assert.ErrorIs(t, err, clients.ErrNodeConnectionFailed)
+	}
+	mockClient.AssertNumberOfCalls(t, "RetrieveBlob", 2)
+	assert.Equal(t, 0.0, counterValue(metrics.NumTombstoneHits))
+}
@@ -0,0 +1,5 @@
+package retriever
+
+// SupportedEncodingVersions are the versions of the encoding of the blobs that the retriever can decode. The blobs
+// have had a single encoding so far, the KZG encoding of core/encoding, which is version 0.
+var SupportedEncodingVersions = []uint32{0}
@@ -0,0 +1,168 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// faultTestData returns the data of the i-th blob of a scenario, each blob having distinct data
+func faultTestData(i int) []byte {
+	return append(bytes.Clone(gettysburgAddressBytes), fmt.Sprintf(" (%d)", i)...)
+}
+
+// chunkFailureRecorder records the operators whose chunks fail their proofs
+type chunkFailureRecorder struct {
+	mu        sync.Mutex
+	operators []core.OperatorID
+}
+
+func (r *chunkFailureRecorder) ObserveChunkVerificationFailure(operatorID core.OperatorID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operators = append(r.operators, operatorID)
+}
+
+func TestFaultEncoderKilledMidBatch(t *testing.T) {
+	h := newHarness(t, harnessConfig{})
+
+	first := h.disperse(t, faultTestData(0), uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	require.NoError(t, h.encode())
+
+	// The encoder is killed while it's encoding the second blob of the batch
+	held := h.encoder.holdNextEncoding()
+	second := h.disperse(t, faultTestData(1), uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	go func() {
+		<-held
+		h.encoder.kill()
+	}()
+	assert.Error(t, h.encode())
+
+	// The batch is cut without the second blob, which is requeued without counting as a failed dispersal
+	require.NoError(t, h.batch())
+	assert.Equal(t, disperserpb.BlobStatus_CONFIRMED, h.status(t, first))
+	assert.Equal(t, disperserpb.BlobStatus_PROCESSING, h.status(t, second))
+	assert.Equal(t, uint(0), h.metadata(t, second).NumRetries)
+
+	// And it's encoded again once the encoder is restarted
+	h.encoder.restart()
+	require.NoError(t, h.encode())
+	require.NoError(t, h.batch())
+	assert.Equal(t, disperserpb.BlobStatus_CONFIRMED, h.status(t, second))
+	assert.NotEqual(t, h.metadata(t, first).ConfirmationInfo.BatchHeaderHash, h.metadata(t, second).ConfirmationInfo.BatchHeaderHash)
+}
+
+func TestFaultNodesPastAttestationDeadline(t *testing.T) {
+	h := newHarness(t, harnessConfig{})
+
+	// The two operators with the least stake, 3 of 55, reply after the batcher stops waiting for them
+	for _, id := range h.operatorsByStake()[:2] {
+		h.nodes[id].setDelay(2 * attestationTimeout)
+	}
+	tolerant := h.disperse(t, faultTestData(0), 50, 90)
+	strict := h.disperse(t, faultTestData(1), uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	require.NoError(t, h.encode())
+	require.NoError(t, h.batch())
+
+	// The batch is confirmed with the signatures of the others, which are enough for the blob requiring 90% of the
+	// stake but not for the one requiring all of it
+	assert.Equal(t, disperserpb.BlobStatus_CONFIRMED, h.status(t, tolerant))
+	assert.Equal(t, disperserpb.BlobStatus_INSUFFICIENT_SIGNATURES, h.status(t, strict))
+	assert.Equal(t, uint8(52*100/55), h.metadata(t, tolerant).ConfirmationInfo.QuorumResults[0].PercentSigned)
+
+	client := h.retrievalClient()
+	data, err := h.retrieve(t, client, tolerant)
+	require.NoError(t, err)
+	assert.Equal(t, faultTestData(0), bytes.TrimRight(data, "\x00"))
+}
+
+func TestFaultQuorumPastAttestationDeadline(t *testing.T) {
+	h := newHarness(t, harnessConfig{MaxNumRetriesPerBlob: 1})
+
+	// The five operators with the most stake, 40 of 55, reply after the batcher stops waiting for them
+	for _, id := range h.operatorsByStake()[numOperators-5:] {
+		h.nodes[id].setDelay(2 * attestationTimeout)
+	}
+	requestID := h.disperse(t, faultTestData(0), uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	require.NoError(t, h.encode())
+
+	// The batch isn't confirmed, and its blob is requeued for the next batch
+	assert.ErrorContains(t, h.batch(), "no blobs received sufficient signatures")
+	assert.Equal(t, disperserpb.BlobStatus_PROCESSING, h.status(t, requestID))
+	assert.Equal(t, uint(1), h.metadata(t, requestID).NumRetries)
+	assert.Equal(t, 0, h.chain.confirmationAttempts())
+
+	// Until it runs out of retries
+	require.NoError(t, h.encode())
+	assert.ErrorContains(t, h.batch(), "no blobs received sufficient signatures")
+	assert.Equal(t, disperserpb.BlobStatus_FAILED, h.status(t, requestID))
+	assert.Equal(t, 0, h.chain.confirmationAttempts())
+}
+
+func TestFaultCorruptChunksFromOneNode(t *testing.T) {
+	h := newHarness(t, harnessConfig{})
+
+	requestID := h.disperse(t, gettysburgAddressBytes, uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	require.NoError(t, h.encode())
+	require.NoError(t, h.batch())
+	require.Equal(t, disperserpb.BlobStatus_CONFIRMED, h.status(t, requestID))
+
+	// The operator with the most stake, and so the most chunks, returns chunks that fail their proofs
+	corrupt := h.operatorsByStake()[numOperators-1]
+	h.nodes[corrupt].setCorrupt(true)
+
+	// The blob is reconstructed from the chunks of the other operators
+	recorder := &chunkFailureRecorder{}
+	lenient := h.retrievalClient(clients.WithChunkVerification(clients.ChunkVerificationLenient, recorder))
+	data, err := h.retrieve(t, lenient, requestID)
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, bytes.TrimRight(data, "\x00"))
+	assert.Equal(t, []core.OperatorID{corrupt}, recorder.operators)
+
+	// Or the retrieval fails if any chunk fails
+	strict := h.retrievalClient(clients.WithChunkVerification(clients.ChunkVerificationStrict, nil))
+	_, err = h.retrieve(t, strict, requestID)
+	assert.ErrorIs(t, err, clients.ErrChunkVerificationFailed)
+}
+
+func TestFaultEthRPCFlapDuringConfirmation(t *testing.T) {
+	h := newHarness(t, harnessConfig{})
+
+	// The RPC drops the first confirmation, which the confirmer retries
+	h.chain.drop(1)
+	requestID := h.disperse(t, faultTestData(0), uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	require.NoError(t, h.encode())
+	require.NoError(t, h.batch())
+
+	assert.Equal(t, disperserpb.BlobStatus_CONFIRMED, h.status(t, requestID))
+	assert.Equal(t, 2, h.chain.confirmationAttempts())
+	assert.Equal(t, uint(0), h.metadata(t, requestID).NumRetries)
+}
+
+func TestFaultEthRPCDownDuringConfirmation(t *testing.T) {
+	h := newHarness(t, harnessConfig{MaxNumRetriesPerBlob: 1, ChainWriteTimeout: attestationTimeout / 2})
+
+	// The RPC stays down past the timeout of the confirmation
+	h.chain.drop(math.MaxInt)
+	requestID := h.disperse(t, faultTestData(0), uint32(q0AdversaryThreshold), uint32(q0QuorumThreshold))
+	require.NoError(t, h.encode())
+	err := h.batch()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, disperserpb.BlobStatus_PROCESSING, h.status(t, requestID))
+	assert.Equal(t, uint(1), h.metadata(t, requestID).NumRetries)
+
+	// The blob is dispersed again in the next batch, which the nodes sign again, and confirmed once the RPC is back
+	h.chain.drop(0)
+	require.NoError(t, h.encode())
+	require.NoError(t, h.batch())
+	assert.Equal(t, disperserpb.BlobStatus_CONFIRMED, h.status(t, requestID))
+}
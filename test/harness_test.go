@@ -0,0 +1,613 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	nodepb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	retrieverpb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	commonmetrics "github.com/Layr-Labs/eigenda/common/metrics"
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/common/pubip"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	encoderpb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/batcher/eth"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
+	batchermock "github.com/Layr-Labs/eigenda/disperser/batcher/mock"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/node"
+	nodegrpc "github.com/Layr-Labs/eigenda/node/grpc"
+	"github.com/Layr-Labs/eigenda/pkg/kzg/bn254"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// harnessPortOffset moves the ports of the harness away from the ones of TestDispersalAndRetrieval, whose
+	// servers are never stopped
+	harnessPortOffset = 20000
+	// attestationTimeout is the time the batcher waits for the signatures of the nodes
+	attestationTimeout = time.Second
+)
+
+var (
+	// errRPCDropped imitates the error of the requests to the chain while its RPC is dropped, which the mock
+	// transactor returns
+	errRPCDropped = errors.New("dial tcp 127.0.0.1:8545: connect: connection refused")
+	// errEncoderKilled is the error of the encodings held when the encoder is killed, in case their connections
+	// aren't closed yet
+	errEncoderKilled = errors.New("encoder killed")
+)
+
+// harnessConfig configures the disperser of the harness
+type harnessConfig struct {
+	// MaxNumRetriesPerBlob is the number of failed batches a blob is requeued after before it's marked failed
+	MaxNumRetriesPerBlob uint
+	// ChainWriteTimeout bounds the confirmation of a batch along with its retries, 10s if it's 0
+	ChainWriteTimeout time.Duration
+}
+
+// harness wires a disperser, i.e. an apiserver, a batcher and an encoder, the DA nodes of the operators of the mock
+// chain, each with its BLS keys, and retrievers of the blobs from the nodes, all in-process and over gRPC. The batches
+// are driven by the scenarios, with encode and batch, rather than by the timers of the batcher.
+//
+// The components fail through the hooks of the harness: the encoder is killed and restarted as its process would,
+// the nodes delay their dispersals or return corrupt chunks, and the RPC the batches are confirmed through is
+// dropped. The servers are stopped once the test completes.
+//
+// The harness isn't backed by an anvil chain: the chain is the mock chain state of the operators, and the batches are
+// confirmed through a mock transactor, which the retrievers read the confirmed batches from. Dropping the RPC fails the
+// confirmations with the error of a refused connection rather than cutting a real endpoint. The harness against an
+// anvil chain, whose RPC is cut during the confirmation, is left to a request of its own, as it needs the contracts of
+// inabox deployed.
+type harness struct {
+	ctx        context.Context
+	chainState *harnessChainState
+	store      disperser.BlobStore
+	server     *apiserver.DispersalServer
+	batcher    *batcher.Batcher
+	// encoded receives the encoding results of the batcher
+	encoded chan batcher.EncodingResultOrStatus
+	encoder *testEncoder
+	nodes   map[core.OperatorID]*testNode
+	chain   *testTransactor
+	logger  common.Logger
+}
+
+func newHarness(t *testing.T, config harnessConfig) *harness {
+	if config.ChainWriteTimeout == 0 {
+		config.ChainWriteTimeout = 10 * time.Second
+	}
+	logger := &commonmock.Logger{}
+
+	cst, err := coremock.NewChainDataMock(numOperators)
+	require.NoError(t, err)
+	cst.On("GetCurrentBlockNumber").Return(uint(10), nil)
+
+	h := &harness{
+		// the apiserver rate limits the requests by the address of their peer
+		ctx: peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 3000},
+		}),
+		chainState: &harnessChainState{ChainDataMock: cst},
+		store:      inmem.NewBlobStore(),
+		encoded:    make(chan batcher.EncodingResultOrStatus),
+		logger:     logger,
+	}
+
+	h.encoder = newTestEncoder(t, harnessPort(encoderPort), logger)
+	h.startNodes(t)
+
+	signer, err := auth.NewLocalBatchSigner(disperserSigningKey)
+	require.NoError(t, err)
+	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
+		Timeout: attestationTimeout,
+		Signer:  signer,
+	}, logger)
+
+	h.chain = newTestTransactor()
+	confirmer, err := eth.NewBatchConfirmer(h.chain, config.ChainWriteTimeout)
+	require.NoError(t, err)
+
+	batcherConfig := batcher.Config{
+		PullInterval:             5 * time.Second,
+		NumConnections:           1,
+		EncoderSocket:            fmt.Sprintf("localhost:%s", harnessPort(encoderPort)),
+		EncodingRequestQueueSize: 100,
+		SRSOrder:                 3000,
+		MaxNumRetriesPerBlob:     config.MaxNumRetriesPerBlob,
+	}
+	timeoutConfig := batcher.TimeoutConfig{
+		EncodingTimeout:    10 * time.Second,
+		AttestationTimeout: attestationTimeout,
+		ChainReadTimeout:   10 * time.Second,
+		ChainWriteTimeout:  config.ChainWriteTimeout,
+	}
+	encoderClient, err := encoder.NewEncoderClient(batcherConfig.EncoderSocket, 10*time.Second, nil)
+	require.NoError(t, err)
+	h.batcher, err = batcher.NewBatcher(batcherConfig, timeoutConfig, h.store, dispatcher, confirmer, h.chainState, asn, encoderClient, core.NewStdSignatureAggregator(logger), &commonmock.MockEthClient{}, batchermock.NewFinalizer(), logger, batcher.NewMetrics("9100", logger))
+	require.NoError(t, err)
+	t.Cleanup(h.batcher.EncodingStreamer.Pool.StopWait)
+
+	tx := &coremock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	tx.On("GetQuorumCount").Return(uint16(1), nil)
+	rateConfig := apiserver.RateConfig{
+		QuorumRateInfos: map[core.QuorumID]apiserver.QuorumRateInfo{
+			0: {},
+		},
+	}
+	h.server = apiserver.NewDispersalServer(disperser.ServerConfig{GrpcPort: harnessPort(fmt.Sprint(disperserGrpcPort))}, h.store, tx, logger, disperser.NewMetrics("9100", logger), &commonmock.NoopRatelimiter{}, rateConfig)
+
+	return h
+}
+
+// harnessPort returns the port of the harness that stands for the port of the mock chain
+func harnessPort(port string) string {
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		panic(err)
+	}
+	return strconv.Itoa(p + harnessPortOffset)
+}
+
+// startNodes starts a node serving dispersals and retrievals for each operator of the chain
+func (h *harness) startNodes(t *testing.T) {
+	state := h.chainState.GetTotalOperatorState(context.Background(), 0)
+	signer, err := auth.NewLocalBatchSigner(disperserSigningKey)
+	require.NoError(t, err)
+
+	h.nodes = make(map[core.OperatorID]*testNode, len(state.PrivateOperators))
+	for id, op := range state.PrivateOperators {
+		dir := filepath.Join(t.TempDir(), fmt.Sprintf("%x", id[:1]))
+		config := &node.Config{
+			Hostname:                  op.Host,
+			DispersalPort:             harnessPort(op.DispersalPort),
+			RetrievalPort:             harnessPort(op.RetrievalPort),
+			InternalDispersalPort:     harnessPort(op.DispersalPort),
+			InternalRetrievalPort:     harnessPort(op.RetrievalPort),
+			Timeout:                   10,
+			ExpirationPollIntervalSec: 10,
+			DbPath:                    filepath.Join(dir, "db"),
+			LogPath:                   filepath.Join(dir, "log"),
+			PrivateBls:                string(op.KeyPair.GetPubKeyG1().Serialize()),
+			ID:                        id,
+			QuorumIDList:              []core.QuorumID{0},
+			AuthorizedDispersers:      []gethcommon.Address{signer.Address()},
+		}
+
+		nodeMetrics := node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), h.logger, ":9090")
+		store, err := node.NewLevelDBStore(config.DbPath+"/chunk", h.logger, nodeMetrics, 1e9, 1e9)
+		require.NoError(t, err)
+
+		tx := &coremock.MockTransactor{}
+		tx.On("GetRegisteredQuorumIdsForOperator").Return(config.QuorumIDList, nil)
+		socketsFilterer := &coremock.MockOperatorSocketsFilterer{}
+		socketsFilterer.On("WatchOperatorSocketUpdate").Return(make(chan string), nil)
+		authenticator, err := node.NewDispersalAuthenticator(context.Background(), config, nil, h.logger)
+		require.NoError(t, err)
+
+		n := &node.Node{
+			Config:     config,
+			Logger:     h.logger,
+			KeyPair:    op.KeyPair,
+			Metrics:    nodeMetrics,
+			Store:      store,
+			ChainState: h.chainState,
+			// the encoders aren't thread safe, so each node has its own
+			Validator:  core.NewChunkValidator(mustMakeTestEncoder(), asn, h.chainState, id),
+			Transactor: tx,
+			PubIPProvider: &pubip.SimpleProvider{
+				RequestDoer: pubip.RequestDoerFunc(func(req *http.Request) (*http.Response, error) {
+					w := httptest.NewRecorder()
+					_, _ = w.WriteString("8.8.8.8")
+					return w.Result(), nil
+				}),
+			},
+			OperatorSocketsFilterer: socketsFilterer,
+			DispersalAuthenticator:  authenticator,
+		}
+		require.NoError(t, n.Start(context.Background()))
+
+		testNode := &testNode{
+			Server: nodegrpc.NewServer(config, n, h.logger, &commonmock.NoopRatelimiter{}),
+		}
+		testNode.dispersal = grpc.NewServer(grpc.MaxRecvMsgSize(1024*1024*1024), grpc.UnaryInterceptor(testNode.delayDispersal))
+		nodepb.RegisterDispersalServer(testNode.dispersal, testNode.Server)
+		testNode.retrieval = grpc.NewServer()
+		nodepb.RegisterRetrievalServer(testNode.retrieval, testNode)
+		serve(t, testNode.dispersal, config.InternalDispersalPort)
+		serve(t, testNode.retrieval, config.InternalRetrievalPort)
+		h.nodes[id] = testNode
+	}
+}
+
+// serve serves the gRPC server on the port until the test completes
+func serve(t *testing.T, server *grpc.Server, port string) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", disperser.Localhost, port))
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+}
+
+// operatorsByStake returns the operators of the chain from the one with the least stake
+func (h *harness) operatorsByStake() []core.OperatorID {
+	state := h.chainState.GetTotalOperatorState(context.Background(), 0)
+	ids := make([]core.OperatorID, 0, len(state.PrivateOperators))
+	for id := range state.PrivateOperators {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return (*big.Int)(state.PrivateOperators[ids[i]].Stake).Cmp(state.PrivateOperators[ids[j]].Stake) < 0
+	})
+	return ids
+}
+
+// disperse disperses the data to quorum 0 through the apiserver, and returns the request ID of the blob
+func (h *harness) disperse(t *testing.T, data []byte, adversaryThreshold, quorumThreshold uint32) []byte {
+	reply, err := h.server.DisperseBlob(h.ctx, &disperserpb.DisperseBlobRequest{
+		Data: data,
+		SecurityParams: []*disperserpb.SecurityParams{
+			{
+				QuorumId:           0,
+				AdversaryThreshold: adversaryThreshold,
+				QuorumThreshold:    quorumThreshold,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, disperserpb.BlobStatus_PROCESSING, reply.GetResult())
+	return reply.GetRequestId()
+}
+
+// encode requests the encodings of the processing blobs that aren't encoded yet, and processes their results. It
+// returns the errors of the encodings that failed.
+func (h *harness) encode() error {
+	streamer := h.batcher.EncodingStreamer
+	if err := streamer.RequestEncoding(h.ctx, h.encoded); err != nil {
+		return err
+	}
+	var errs []error
+	for streamer.EncodedBlobstore.GetRequestedCount() > 0 {
+		if err := streamer.ProcessEncodedBlobs(h.ctx, <-h.encoded); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// batch disperses and confirms a batch of the encoded blobs
+func (h *harness) batch() error {
+	return h.batcher.HandleSingleBatch(h.ctx)
+}
+
+// status returns the status of the blob reported by the apiserver
+func (h *harness) status(t *testing.T, requestID []byte) disperserpb.BlobStatus {
+	reply, err := h.server.GetBlobStatus(h.ctx, &disperserpb.BlobStatusRequest{RequestId: requestID})
+	require.NoError(t, err)
+	return reply.GetStatus()
+}
+
+// metadata returns the metadata of the blob in the blob store
+func (h *harness) metadata(t *testing.T, requestID []byte) *disperser.BlobMetadata {
+	key, err := disperser.ParseBlobKey(string(requestID))
+	require.NoError(t, err)
+	metadata, err := h.store.GetBlobMetadata(h.ctx, key)
+	require.NoError(t, err)
+	return metadata
+}
+
+// retrievalClient returns a client retrieving the blobs from the nodes. Its encoder, which verifies the chunks and
+// the blobs, isn't shared with the nodes.
+func (h *harness) retrievalClient(opts ...clients.RetrievalClientOption) clients.RetrievalClient {
+	return clients.NewRetrievalClient(h.logger, h.chainState, asn, clients.NewNodeClient(5*time.Second, nil), mustMakeTestEncoder(), numOperators, opts...)
+}
+
+// retrieve retrieves the confirmed blob from the nodes through a retriever with the retrieval client, which looks
+// the batch up from its confirmation
+func (h *harness) retrieve(t *testing.T, client clients.RetrievalClient, requestID []byte) ([]byte, error) {
+	info := h.metadata(t, requestID).ConfirmationInfo
+	require.NotNil(t, info)
+	metrics := retriever.NewMetrics(commonmetrics.NewPrometheusBackend("9100", h.logger), retriever.DefaultMetricsPrefix, nil, h.logger)
+	server := retriever.NewServer(&retriever.Config{}, h.logger, metrics, client, nil, h.chainState, h.chain, nil)
+	reply, err := server.RetrieveBlob(h.ctx, &retrieverpb.BlobRequest{
+		BatchHeaderHash:      info.BatchHeaderHash[:],
+		BlobIndex:            info.BlobIndex,
+		ReferenceBlockNumber: info.ReferenceBlockNumber,
+		QuorumId:             0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetData(), nil
+}
+
+// harnessChainState is the chain state of the mock operators, whose sockets are moved to the ports of the harness
+type harnessChainState struct {
+	*coremock.ChainDataMock
+}
+
+func (s *harnessChainState) GetIndexedOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.IndexedOperatorState, error) {
+	state := s.GetTotalOperatorStateWithQuorums(ctx, blockNumber, quorums)
+	for id, op := range state.PrivateOperators {
+		state.IndexedOperatorState.IndexedOperators[id].Socket = string(core.MakeOperatorSocket(op.Host, harnessPort(op.DispersalPort), harnessPort(op.RetrievalPort)))
+	}
+	return state.IndexedOperatorState, nil
+}
+
+// testEncoder is the encoder server of the harness. It's killed as its process would crash, failing the requests
+// it's encoding, and restarted as a new process. Its next encoding can be held until it's killed, so that it's
+// killed mid-request.
+type testEncoder struct {
+	core.Encoder
+
+	t      *testing.T
+	port   string
+	logger common.Logger
+
+	mu      sync.Mutex
+	server  *grpc.Server
+	hold    bool
+	held    chan struct{}
+	release chan struct{}
+}
+
+func newTestEncoder(t *testing.T, port string, logger common.Logger) *testEncoder {
+	e := &testEncoder{
+		Encoder: mustMakeTestEncoder(),
+		t:       t,
+		port:    port,
+		logger:  logger,
+		release: make(chan struct{}),
+	}
+	e.start()
+	t.Cleanup(e.kill)
+	return e
+}
+
+func (e *testEncoder) start() {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%s", disperser.Localhost, e.port))
+	require.NoError(e.t, err)
+	server := grpc.NewServer()
+	encoderpb.RegisterEncoderServer(server, encoder.NewServer(encoder.ServerConfig{
+		GrpcPort:              e.port,
+		MaxConcurrentRequests: 16,
+		RequestPoolSize:       32,
+	}, e.logger, e, encoder.NewMetrics("9000", e.logger)))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	e.mu.Lock()
+	e.server = server
+	e.mu.Unlock()
+}
+
+// holdNextEncoding holds the next encoding until the encoder is killed or restarted. The returned channel is
+// closed once the encoding is held.
+func (e *testEncoder) holdNextEncoding() <-chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.hold = true
+	e.held = make(chan struct{})
+	return e.held
+}
+
+func (e *testEncoder) Encode(data []byte, params core.EncodingParams) (core.BlobCommitments, []*core.Chunk, error) {
+	e.mu.Lock()
+	hold, held, release := e.hold, e.held, e.release
+	e.hold = false
+	e.mu.Unlock()
+	if hold {
+		close(held)
+		<-release
+		return core.BlobCommitments{}, nil, errEncoderKilled
+	}
+	return e.Encoder.Encode(data, params)
+}
+
+// kill stops the encoder at once, closing its connections, so that the requests it's encoding fail
+func (e *testEncoder) kill() {
+	e.mu.Lock()
+	server := e.server
+	e.server = nil
+	e.mu.Unlock()
+	if server == nil {
+		return
+	}
+	stopped := make(chan struct{})
+	// The server waits for the held encodings to return once its connections are closed, so they're released
+	go func() {
+		server.Stop()
+		close(stopped)
+	}()
+	e.mu.Lock()
+	close(e.release)
+	e.release = make(chan struct{})
+	e.mu.Unlock()
+	<-stopped
+}
+
+// restart starts a new encoder, killing the running one if it isn't killed yet
+func (e *testEncoder) restart() {
+	e.kill()
+	e.start()
+}
+
+// testNode is a DA node of the harness, served by the gRPC servers of the harness so that its requests can be
+// delayed, and its chunks corrupted
+type testNode struct {
+	*nodegrpc.Server
+	dispersal *grpc.Server
+	retrieval *grpc.Server
+
+	mu      sync.Mutex
+	delay   time.Duration
+	corrupt bool
+}
+
+// setDelay delays the dispersals to the node, which are abandoned if the disperser stops waiting for them
+func (n *testNode) setDelay(delay time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.delay = delay
+}
+
+// setCorrupt makes the node return chunks that fail their proofs
+func (n *testNode) setCorrupt(corrupt bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.corrupt = corrupt
+}
+
+func (n *testNode) delayDispersal(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	n.mu.Lock()
+	delay := n.delay
+	n.mu.Unlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return handler(ctx, req)
+}
+
+func (n *testNode) RetrieveChunks(ctx context.Context, in *nodepb.RetrieveChunksRequest) (*nodepb.RetrieveChunksReply, error) {
+	reply, err := n.Server.RetrieveChunks(ctx, in)
+	n.mu.Lock()
+	corrupt := n.corrupt
+	n.mu.Unlock()
+	if err != nil || !corrupt {
+		return reply, err
+	}
+
+	chunks := make([][]byte, len(reply.GetChunks()))
+	for i, data := range reply.GetChunks() {
+		chunk, err := new(core.Chunk).Deserialize(data)
+		if err != nil {
+			return nil, err
+		}
+		bn254.AddModFr(&chunk.Coeffs[0], &chunk.Coeffs[0], &bn254.ONE)
+		if chunks[i], err = chunk.Serialize(); err != nil {
+			return nil, err
+		}
+	}
+	return &nodepb.RetrieveChunksReply{Chunks: chunks}, nil
+}
+
+// testTransactor is the mock transactor the batches are confirmed through, which fails a number of confirmations as if
+// its RPC was dropped. It's also the chain client of the retrievers, which look the confirmed batches up from it.
+type testTransactor struct {
+	*coremock.MockTransactor
+
+	mu sync.Mutex
+	// drops is the number of the next confirmations that fail as the RPC is dropped
+	drops int
+	// attempts is the number of confirmations sent, including the failed ones
+	attempts int
+	// confirmed are the headers of the confirmed batches by their hash
+	confirmed map[[32]byte]core.BatchHeader
+}
+
+func newTestTransactor() *testTransactor {
+	return &testTransactor{MockTransactor: &coremock.MockTransactor{}, confirmed: make(map[[32]byte]core.BatchHeader)}
+}
+
+// drop drops the RPC for the next n confirmations
+func (tx *testTransactor) drop(n int) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.drops = n
+}
+
+func (tx *testTransactor) confirmationAttempts() int {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	return tx.attempts
+}
+
+func (tx *testTransactor) ConfirmBatch(ctx context.Context, batchHeader core.BatchHeader, quorums map[core.QuorumID]*core.QuorumResult, signatureAggregation core.SignatureAggregation) (*types.Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.attempts++
+	if tx.drops > 0 {
+		tx.drops--
+		return nil, errRPCDropped
+	}
+	hash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	tx.confirmed[hash] = batchHeader
+	return confirmationReceipt(), nil
+}
+
+// FetchBatchHeader returns the header of the confirmed batch with the hash, with the quorums of the harness
+func (tx *testTransactor) FetchBatchHeader(ctx context.Context, serviceManagerAddress gethcommon.Address, batchHeaderHash []byte) (*binding.IEigenDAServiceManagerBatchHeader, error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	batchHeader, ok := tx.confirmed[[32]byte(batchHeaderHash)]
+	if !ok {
+		return nil, fmt.Errorf("batch %x is not confirmed", batchHeaderHash)
+	}
+	return &binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:      batchHeader.BatchRoot,
+		QuorumNumbers:        []byte{0},
+		ReferenceBlockNumber: uint32(batchHeader.ReferenceBlockNumber),
+	}, nil
+}
+
+// VerifyBlobCert fails, as the harness doesn't confirm the batches in the contracts the certs are verified with
+func (tx *testTransactor) VerifyBlobCert(ctx context.Context, serviceManagerAddress gethcommon.Address, cert *clients.BlobCert) error {
+	return errors.New("the certs can't be verified without the contracts")
+}
+
+// confirmationReceipt is the receipt of a confirmation transaction, with the BatchConfirmed event of batch 3
+func confirmationReceipt() *types.Receipt {
+	batchID := make([]byte, 64)
+	batchID[31] = 3
+	return &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+				Data:   batchID,
+			},
+		},
+		BlockNumber: big.NewInt(123),
+	}
+}
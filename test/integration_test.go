@@ -29,8 +29,10 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	commonmetrics "github.com/Layr-Labs/eigenda/common/metrics"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/core/encoding"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -68,6 +70,8 @@ const (
 	encoderPort          = "3100"
 	q0AdversaryThreshold = uint8(80)
 	q0QuorumThreshold    = uint8(100)
+	// disperserSigningKey signs the requests of the disperser to the operators, which authenticate it
+	disperserSigningKey = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
 )
 
 func init() {
@@ -118,8 +122,13 @@ type TestDisperser struct {
 }
 
 func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser.BlobStore, logger common.Logger) TestDisperser {
+	signer, err := auth.NewLocalBatchSigner(disperserSigningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
 	dispatcherConfig := &dispatcher.Config{
 		Timeout: time.Second,
+		Signer:  signer,
 	}
 	dispatcher := dispatcher.NewDispatcher(dispatcherConfig, logger)
 
@@ -165,7 +174,7 @@ func mustMakeDisperser(t *testing.T, cst core.IndexedChainState, store disperser
 		RequestPoolSize:       32,
 	}, logger, enc0, metrics)
 
-	encoderClient, err := encoder.NewEncoderClient(batcherConfig.EncoderSocket, 10*time.Second)
+	encoderClient, err := encoder.NewEncoderClient(batcherConfig.EncoderSocket, 10*time.Second, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -215,6 +224,11 @@ func mustMakeOperators(t *testing.T, cst *coremock.ChainDataMock, logger common.
 
 	ops := make(map[core.OperatorID]TestOperator, len(state.IndexedOperators))
 
+	disperser, err := auth.NewLocalBatchSigner(disperserSigningKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	setRegisteredQuorums := true
 	for id, op := range state.PrivateOperators {
 
@@ -261,6 +275,7 @@ func mustMakeOperators(t *testing.T, cst *coremock.ChainDataMock, logger common.
 			PrivateBls:                string(op.KeyPair.GetPubKeyG1().Serialize()),
 			ID:                        id,
 			QuorumIDList:              registeredQuorums,
+			AuthorizedDispersers:      []gethcommon.Address{disperser.Address()},
 		}
 
 		// creating a new instance of encoder instead of sharing enc because enc is not thread safe
@@ -298,6 +313,11 @@ func mustMakeOperators(t *testing.T, cst *coremock.ChainDataMock, logger common.
 			URL:  "",
 		}
 
+		authenticator, err := node.NewDispersalAuthenticator(context.Background(), config, nil, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+
 		n := &node.Node{
 			Config:                  config,
 			Logger:                  logger,
@@ -309,6 +329,7 @@ func mustMakeOperators(t *testing.T, cst *coremock.ChainDataMock, logger common.
 			Transactor:              tx,
 			PubIPProvider:           pubIPProvider,
 			OperatorSocketsFilterer: mockOperatorSocketsFilterer,
+			DispersalAuthenticator:  authenticator,
 		}
 
 		if err != nil {
@@ -339,7 +360,8 @@ func mustMakeRetriever(cst core.IndexedChainState, logger common.Logger) (*commo
 	gethClient := &commonmock.MockEthClient{}
 	retrievalClient := &clientsmock.MockRetrievalClient{}
 	chainClient := retrievermock.NewMockChainClient()
-	server := retriever.NewServer(config, logger, retrievalClient, enc, cst, chainClient)
+	metrics := retriever.NewMetrics(commonmetrics.NewPrometheusBackend("9100", logger), retriever.DefaultMetricsPrefix, nil, logger)
+	server := retriever.NewServer(config, logger, metrics, retrievalClient, enc, cst, chainClient, nil)
 
 	return gethClient, TestRetriever{
 		Server: server,
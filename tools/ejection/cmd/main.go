@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/version"
+	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/Layr-Labs/eigenda/tools/ejection"
+	"github.com/Layr-Labs/eigenda/tools/ejection/flags"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/shurcooL/graphql"
+	"github.com/urfave/cli"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = version.String()
+	app.Name = "da-ejection-report"
+	app.Usage = "EigenDA Operator Ejection Report"
+	app.Description = "Tool compiling the signing record of an operator into a signed report backing its ejection"
+	app.Flags = flags.Flags
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return ejection.NewConfig(ctx)
+	})
+	app.Action = ejectionReportMain
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+func ejectionReportMain(ctx *cli.Context) error {
+	config, err := ejection.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+	logger, err := logging.GetLogger(config.LoggingConfig)
+	if err != nil {
+		return err
+	}
+
+	client, err := geth.NewClient(geth.EthClientConfig{RPCURL: config.ChainRPC}, logger)
+	if err != nil {
+		return err
+	}
+	tx, err := coreeth.NewTransactor(logger, client, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return err
+	}
+	var (
+		chainState     = coreeth.NewChainState(tx, client)
+		indexedState   = thegraph.NewIndexedChainState(chainState, graphql.NewClient(config.OperatorStateGraphUrl, nil), logger)
+		subgraphClient = dataapi.NewSubgraphClient(subgraph.NewApi(config.BatchMetadataGraphUrl, config.OperatorStateGraphUrl))
+		compiler       = ejection.NewCompiler(subgraphClient, chainState, indexedState, tx, tx.Bindings.RegCoordinatorAddr, logger)
+	)
+	report, err := compiler.Compile(context.Background(), config.OperatorId, config.StartTime, config.EndTime, config.Quorums)
+	if err != nil {
+		return err
+	}
+
+	signerKey, err := crypto.HexToECDSA(config.SignerPrivateKey)
+	if err != nil {
+		return err
+	}
+	signed, err := ejection.SignReport(report, signerKey)
+	if err != nil {
+		return err
+	}
+	output := os.Stdout
+	if config.Output != "" {
+		output, err = os.Create(config.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create the report file: %w", err)
+		}
+		defer output.Close()
+	}
+	if err := signed.Write(output); err != nil {
+		return fmt.Errorf("failed to write the report: %w", err)
+	}
+	logger.Info("Wrote the signed report", "operatorId", report.OperatorId, "batches", len(report.Batches), "signer", signed.Signer)
+
+	if !config.Submit {
+		return nil
+	}
+	ejector, err := geth.NewClient(geth.EthClientConfig{RPCURL: config.ChainRPC, PrivateKeyString: config.EjectorPrivateKey}, logger)
+	if err != nil {
+		return err
+	}
+	receipt, err := ejection.SubmitEjection(context.Background(), ejector, report.Ejection)
+	if err != nil {
+		return fmt.Errorf("failed to submit the ejection: %w", err)
+	}
+	logger.Info("Ejected the operator", "operatorId", report.OperatorId, "quorums", report.Ejection.QuorumNumbers, "txHash", receipt.TxHash.Hex())
+	return nil
+}
@@ -0,0 +1,206 @@
+package ejection
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Compiler compiles the reports of the operators from the attestations of the confirmed batches indexed by the
+// subgraph, and from the stakes of the operators at the reference blocks of the batches
+type Compiler struct {
+	subgraphClient dataapi.SubgraphClient
+	chainState     core.ChainState
+	indexedState   thegraph.IndexedChainState
+	transactor     core.Transactor
+	// registryCoordinator is the address of the registry coordinator the operators are ejected from
+	registryCoordinator gethcommon.Address
+	logger              common.Logger
+}
+
+func NewCompiler(
+	subgraphClient dataapi.SubgraphClient,
+	chainState core.ChainState,
+	indexedState thegraph.IndexedChainState,
+	transactor core.Transactor,
+	registryCoordinator gethcommon.Address,
+	logger common.Logger,
+) *Compiler {
+	return &Compiler{
+		subgraphClient:      subgraphClient,
+		chainState:          chainState,
+		indexedState:        indexedState,
+		transactor:          transactor,
+		registryCoordinator: registryCoordinator,
+		logger:              logger,
+	}
+}
+
+// Compile returns the report of the operator over the batches confirmed between the times, inclusive. The operator is
+// ejected from the quorums, or from all the quorums it is currently registered in if there are none.
+func (c *Compiler) Compile(ctx context.Context, operatorId core.OperatorID, start, end time.Time, quorums []core.QuorumID) (*Report, error) {
+	id := "0x" + hex.EncodeToString(operatorId[:])
+	attestations, err := c.subgraphClient.QueryBatchAttestationsInRange(ctx, start.Unix(), end.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the batches confirmed between %s and %s: %w", start.Format(time.RFC3339), end.Format(time.RFC3339), err)
+	}
+	c.logger.Info("Compiling the report of the operator", "operatorId", id, "batches", len(attestations))
+
+	report := &Report{
+		Version:    ReportVersion,
+		OperatorId: id,
+		StartTime:  start.Unix(),
+		EndTime:    end.Unix(),
+		Quorums:    make([]*QuorumSigningRate, 0),
+		Batches:    make([]*BatchEvidence, 0),
+	}
+	operatorAddress, err := c.transactor.OperatorIDToAddress(ctx, operatorId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the address of operator %s: %w", id, err)
+	}
+	report.OperatorAddress = hexutil.Encode(operatorAddress.Bytes())
+
+	// The batches of a reference block share the operator state at that block
+	states := make(map[string]*core.OperatorState)
+	rates := make(map[core.QuorumID]*QuorumSigningRate)
+	for _, attestation := range attestations {
+		stateKey := operatorStateKey(attestation.ReferenceBlockNumber, attestation.QuorumNumbers)
+		state, ok := states[stateKey]
+		if !ok {
+			state, err = c.chainState.GetOperatorState(ctx, uint(attestation.ReferenceBlockNumber), attestation.QuorumNumbers)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the operator state at block %d of batch %d: %w", attestation.ReferenceBlockNumber, attestation.BatchId, err)
+			}
+			states[stateKey] = state
+		}
+
+		evidence := batchEvidence(operatorId, attestation, state)
+		if len(evidence.Quorums) == 0 {
+			continue
+		}
+		_, nonSigner := attestation.NonSigners[id]
+		evidence.Signed = !nonSigner
+		report.Batches = append(report.Batches, evidence)
+		for _, stake := range evidence.Quorums {
+			rate, ok := rates[stake.QuorumId]
+			if !ok {
+				rate = &QuorumSigningRate{QuorumId: stake.QuorumId}
+				rates[stake.QuorumId] = rate
+			}
+			rate.TotalBatches++
+			if evidence.Signed {
+				rate.SignedBatches++
+			}
+		}
+	}
+	for _, rate := range rates {
+		rate.NonSigningPercentage = math.Round(float64(rate.TotalBatches-rate.SignedBatches)/float64(rate.TotalBatches)*10000) / 100
+		report.Quorums = append(report.Quorums, rate)
+	}
+	sort.Slice(report.Quorums, func(i, j int) bool {
+		return report.Quorums[i].QuorumId < report.Quorums[j].QuorumId
+	})
+	sort.Slice(report.Batches, func(i, j int) bool {
+		return report.Batches[i].BatchId < report.Batches[j].BatchId
+	})
+
+	report.Ejection, err = c.ejectionCall(ctx, operatorId, id, operatorAddress, quorums)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ejectionCall returns the ejection of the operator from the quorums, or from all the quorums it is currently
+// registered in if there are none
+func (c *Compiler) ejectionCall(ctx context.Context, operatorId core.OperatorID, id string, operatorAddress gethcommon.Address, quorums []core.QuorumID) (*EjectionCall, error) {
+	if len(quorums) == 0 {
+		registered, err := c.transactor.GetRegisteredQuorumIdsForOperator(ctx, operatorId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the quorums operator %s is registered in: %w", id, err)
+		}
+		if len(registered) == 0 {
+			return nil, fmt.Errorf("operator %s isn't registered in any quorum", id)
+		}
+		quorums = registered
+	}
+	blockNumber, err := c.transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, err := c.indexedState.GetIndexedOperatorInfoByOperatorId(ctx, operatorId, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the public key of operator %s: %w", id, err)
+	}
+	calldata, err := EjectionCalldata(operatorAddress, quorums, info.PubkeyG1)
+	if err != nil {
+		return nil, err
+	}
+
+	quorumNumbers := make([]uint8, len(quorums))
+	for i, quorum := range quorums {
+		quorumNumbers[i] = uint8(quorum)
+	}
+	return &EjectionCall{
+		RegistryCoordinator: hexutil.Encode(c.registryCoordinator.Bytes()),
+		QuorumNumbers:       quorumNumbers,
+		Calldata:            hexutil.Encode(calldata),
+	}, nil
+}
+
+// batchEvidence returns the evidence of the batch for the operator, with the stakes of the quorums of the batch the
+// operator was registered in at the reference block. The operator wasn't responsible for the batch if there are none.
+func batchEvidence(operatorId core.OperatorID, attestation *dataapi.BatchAttestation, state *core.OperatorState) *BatchEvidence {
+	evidence := &BatchEvidence{
+		BatchId:                 attestation.BatchId,
+		BatchHeaderHash:         hexutil.Encode(attestation.BatchHeaderHash[:]),
+		ReferenceBlockNumber:    attestation.ReferenceBlockNumber,
+		ConfirmationBlockNumber: attestation.BlockNumber,
+		ConfirmationTxHash:      strings.ToLower(attestation.TxHash),
+		ConfirmationTime:        int64(attestation.BlockTimestamp),
+		Quorums:                 make([]*QuorumStake, 0),
+	}
+	for _, quorum := range attestation.QuorumNumbers {
+		operator, ok := state.Operators[quorum][operatorId]
+		if !ok {
+			continue
+		}
+		operatorStake := (*big.Int)(operator.Stake)
+		stake := &QuorumStake{
+			QuorumId:   uint8(quorum),
+			Stake:      operatorStake.String(),
+			TotalStake: "0",
+		}
+		if total, ok := state.Totals[quorum]; ok && (*big.Int)(total.Stake).Sign() > 0 {
+			stake.TotalStake = (*big.Int)(total.Stake).String()
+			percentage, _ := new(big.Rat).SetFrac(new(big.Int).Mul(operatorStake, big.NewInt(100)), total.Stake).Float64()
+			stake.StakePercentage = math.Round(percentage*100) / 100
+		}
+		evidence.Quorums = append(evidence.Quorums, stake)
+	}
+	sort.Slice(evidence.Quorums, func(i, j int) bool {
+		return evidence.Quorums[i].QuorumId < evidence.Quorums[j].QuorumId
+	})
+	return evidence
+}
+
+func operatorStateKey(blockNumber uint64, quorums []core.QuorumID) string {
+	key := strconv.FormatUint(blockNumber, 10)
+	for _, quorum := range quorums {
+		key += "/" + strconv.Itoa(int(quorum))
+	}
+	return key
+}
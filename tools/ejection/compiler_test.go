@@ -0,0 +1,200 @@
+package ejection_test
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/logging"
+	regcoordinator "github.com/Layr-Labs/eigenda/contracts/bindings/BLSRegistryCoordinatorWithIndices"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	thegraphmock "github.com/Layr-Labs/eigenda/core/thegraph/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	subgraphmock "github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph/mock"
+	"github.com/Layr-Labs/eigenda/tools/ejection"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	operatorAddress     = gethcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+	registryCoordinator = gethcommon.HexToAddress("0x00000000000000000000000000000000000000cc")
+	otherOperatorId     = core.OperatorID{0x0f}
+)
+
+// chainState returns the states of the blocks it's set up with
+type chainState struct {
+	core.ChainState
+	states map[uint]*core.OperatorState
+}
+
+func (s *chainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	state, ok := s.states[blockNumber]
+	if !ok {
+		return nil, fmt.Errorf("no state at block %d", blockNumber)
+	}
+	return state, nil
+}
+
+// operatorState returns the state where the operator holds the stakes in the quorums, and another operator holds 300
+// in each of quorums 0 and 1
+func operatorState(operatorId core.OperatorID, stakes map[core.QuorumID]int64) *core.OperatorState {
+	state := &core.OperatorState{
+		Operators: make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo),
+		Totals:    make(map[core.QuorumID]*core.OperatorInfo),
+	}
+	for _, quorum := range []core.QuorumID{0, 1} {
+		state.Operators[quorum] = map[core.OperatorID]*core.OperatorInfo{otherOperatorId: {Stake: big.NewInt(300)}}
+		state.Totals[quorum] = &core.OperatorInfo{Stake: big.NewInt(300), Index: 1}
+	}
+	for quorum, stake := range stakes {
+		state.Operators[quorum][operatorId] = &core.OperatorInfo{Stake: big.NewInt(stake)}
+		state.Totals[quorum] = &core.OperatorInfo{Stake: big.NewInt(300 + stake), Index: 2}
+	}
+	return state
+}
+
+func makeAttestation(batchId uint64, timestamp int64, referenceBlockNumber uint64, nonSigners ...core.OperatorID) *subgraph.BatchAttestation {
+	attestation := &subgraph.BatchAttestation{
+		BatchId:         graphql.String(strconv.FormatUint(batchId, 10)),
+		BatchHeaderHash: graphql.String(fmt.Sprintf("0x%064x", 0xba00+batchId)),
+		BlockTimestamp:  graphql.String(strconv.FormatInt(timestamp, 10)),
+		BlockNumber:     graphql.String(strconv.FormatUint(referenceBlockNumber+10, 10)),
+		TxHash:          graphql.String(fmt.Sprintf("0x%064X", 0xb00+batchId)),
+	}
+	attestation.BatchHeader.QuorumNumbers = []graphql.String{"0", "1"}
+	attestation.BatchHeader.ReferenceBlockNumber = graphql.String(strconv.FormatUint(referenceBlockNumber, 10))
+	for _, nonSigner := range nonSigners {
+		attestation.NonSigning.NonSigners = append(attestation.NonSigning.NonSigners, struct {
+			OperatorId graphql.String `graphql:"operatorId"`
+		}{OperatorId: graphql.String("0x" + hex.EncodeToString(nonSigner[:]))})
+	}
+	return attestation
+}
+
+func newTestCompiler(t *testing.T, keyPair *core.KeyPair, attestations []*subgraph.BatchAttestation, states map[uint]*core.OperatorState) (*ejection.Compiler, *coremock.MockTransactor) {
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	subgraphApi := &subgraphmock.MockSubgraphApi{}
+	subgraphApi.On("QueryBatchAttestationsInRange").Return(attestations, nil)
+	indexedState := &thegraphmock.MockIndexedChainState{}
+	indexedState.On("GetIndexedOperatorInfoByOperatorId").Return(&core.IndexedOperatorInfo{PubkeyG1: keyPair.GetPubKeyG1()}, nil)
+	transactor := &coremock.MockTransactor{}
+	transactor.On("OperatorIDToAddress").Return(operatorAddress, nil)
+	transactor.On("GetCurrentBlockNumber").Return(uint32(1000), nil)
+	compiler := ejection.NewCompiler(dataapi.NewSubgraphClient(subgraphApi), &chainState{states: states}, indexedState, transactor, registryCoordinator, logger)
+	return compiler, transactor
+}
+
+func TestCompileReport(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	operatorId := keyPair.GetPubKeyG1().GetOperatorID()
+
+	// The operator is in quorum 0 at block 100, in both quorums at block 200, and deregistered at block 300
+	states := map[uint]*core.OperatorState{
+		100: operatorState(operatorId, map[core.QuorumID]int64{0: 100}),
+		200: operatorState(operatorId, map[core.QuorumID]int64{0: 100, 1: 700}),
+		300: operatorState(operatorId, nil),
+	}
+	attestations := []*subgraph.BatchAttestation{
+		makeAttestation(4, 1700000400, 300),
+		makeAttestation(3, 1700000300, 200, operatorId, otherOperatorId),
+		makeAttestation(2, 1700000200, 200),
+		makeAttestation(1, 1700000100, 100, operatorId),
+		// Outside of the time range
+		makeAttestation(0, 1699999999, 100, operatorId),
+	}
+	compiler, transactor := newTestCompiler(t, keyPair, attestations, states)
+	transactor.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{0, 1}, nil)
+
+	report, err := compiler.Compile(context.Background(), operatorId, time.Unix(1700000000, 0), time.Unix(1700000400, 0), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ejection.ReportVersion, report.Version)
+	assert.Equal(t, "0x"+hex.EncodeToString(operatorId[:]), report.OperatorId)
+	assert.Equal(t, "0x00000000000000000000000000000000000000aa", report.OperatorAddress)
+	assert.Equal(t, int64(1700000000), report.StartTime)
+	assert.Equal(t, int64(1700000400), report.EndTime)
+
+	// The operator wasn't responsible for the batch of block 300
+	assert.Equal(t, []*ejection.QuorumSigningRate{
+		{QuorumId: 0, TotalBatches: 3, SignedBatches: 1, NonSigningPercentage: 66.67},
+		{QuorumId: 1, TotalBatches: 2, SignedBatches: 1, NonSigningPercentage: 50},
+	}, report.Quorums)
+	assert.Len(t, report.Batches, 3)
+	assert.Equal(t, &ejection.BatchEvidence{
+		BatchId:                 1,
+		BatchHeaderHash:         fmt.Sprintf("0x%064x", 0xba01),
+		ReferenceBlockNumber:    100,
+		ConfirmationBlockNumber: 110,
+		ConfirmationTxHash:      fmt.Sprintf("0x%064x", 0xb01),
+		ConfirmationTime:        1700000100,
+		Signed:                  false,
+		Quorums: []*ejection.QuorumStake{
+			{QuorumId: 0, Stake: "100", TotalStake: "400", StakePercentage: 25},
+		},
+	}, report.Batches[0])
+	assert.Equal(t, uint64(2), report.Batches[1].BatchId)
+	assert.True(t, report.Batches[1].Signed)
+	assert.Equal(t, []*ejection.QuorumStake{
+		{QuorumId: 0, Stake: "100", TotalStake: "400", StakePercentage: 25},
+		{QuorumId: 1, Stake: "700", TotalStake: "1000", StakePercentage: 70},
+	}, report.Batches[1].Quorums)
+	assert.Equal(t, uint64(3), report.Batches[2].BatchId)
+	assert.False(t, report.Batches[2].Signed)
+
+	// The ejection is from all the quorums the operator is registered in
+	assert.Equal(t, "0x00000000000000000000000000000000000000cc", report.Ejection.RegistryCoordinator)
+	assert.Equal(t, []uint8{0, 1}, report.Ejection.QuorumNumbers)
+	assertEjectionCalldata(t, report.Ejection.Calldata, []byte{0, 1}, keyPair.GetPubKeyG1())
+}
+
+func TestCompileReportQuorums(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	operatorId := keyPair.GetPubKeyG1().GetOperatorID()
+	compiler, transactor := newTestCompiler(t, keyPair, nil, nil)
+
+	// The ejection is from the quorums it's asked for
+	report, err := compiler.Compile(context.Background(), operatorId, time.Unix(1700000000, 0), time.Unix(1700000400, 0), []core.QuorumID{1})
+	assert.NoError(t, err)
+	assert.Empty(t, report.Quorums)
+	assert.Empty(t, report.Batches)
+	assert.Equal(t, []uint8{1}, report.Ejection.QuorumNumbers)
+	assertEjectionCalldata(t, report.Ejection.Calldata, []byte{1}, keyPair.GetPubKeyG1())
+	transactor.AssertNotCalled(t, "GetRegisteredQuorumIdsForOperator")
+
+	// An operator that isn't registered can't be ejected
+	transactor.On("GetRegisteredQuorumIdsForOperator").Return([]core.QuorumID{}, nil)
+	_, err = compiler.Compile(context.Background(), operatorId, time.Unix(1700000000, 0), time.Unix(1700000400, 0), nil)
+	assert.ErrorContains(t, err, "isn't registered in any quorum")
+}
+
+// assertEjectionCalldata checks that the calldata ejects the operator from the quorums
+func assertEjectionCalldata(t *testing.T, calldata string, quorumNumbers []byte, pubkey *core.G1Point) {
+	data, err := hexutil.Decode(calldata)
+	assert.NoError(t, err)
+	coordinatorABI, err := regcoordinator.ContractBLSRegistryCoordinatorWithIndicesMetaData.GetAbi()
+	assert.NoError(t, err)
+	method, err := coordinatorABI.MethodById(data[:4])
+	assert.NoError(t, err)
+	assert.Equal(t, "ejectOperatorFromCoordinator", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	assert.NoError(t, err)
+	assert.Equal(t, operatorAddress, args[0])
+	assert.Equal(t, quorumNumbers, args[1])
+	point := args[2].(struct {
+		X *big.Int `json:"X"`
+		Y *big.Int `json:"Y"`
+	})
+	assert.Equal(t, pubkey.X.BigInt(new(big.Int)), point.X)
+	assert.Equal(t, pubkey.Y.BigInt(new(big.Int)), point.Y)
+}
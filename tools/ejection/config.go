@@ -0,0 +1,116 @@
+package ejection
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/validation"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/tools/ejection/flags"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	OperatorId core.OperatorID
+	StartTime  time.Time
+	EndTime    time.Time
+	// Quorums are the quorums the operator is ejected from, all the quorums it is registered in if there are none
+	Quorums []core.QuorumID
+
+	ChainRPC                      string
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+	BatchMetadataGraphUrl         string
+	OperatorStateGraphUrl         string
+	LoggingConfig                 logging.Config
+
+	// SignerPrivateKey is the key the report is signed with
+	SignerPrivateKey string
+	// Output is the path the signed report is written to, the standard output if it's empty
+	Output string
+	// Submit sends the ejection from the account of EjectorPrivateKey
+	Submit            bool
+	EjectorPrivateKey string
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return nil, err
+	}
+	operatorId, _ := parseOperatorId(ctx.GlobalString(flags.OperatorIdFlag.Name))
+	startTime, _ := time.Parse(time.RFC3339, ctx.GlobalString(flags.StartTimeFlag.Name))
+	endTime, _ := time.Parse(time.RFC3339, ctx.GlobalString(flags.EndTimeFlag.Name))
+	quorums := make([]core.QuorumID, 0)
+	for _, quorum := range ctx.GlobalIntSlice(flags.QuorumsFlag.Name) {
+		quorums = append(quorums, core.QuorumID(quorum))
+	}
+	return &Config{
+		OperatorId: operatorId,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Quorums:    quorums,
+
+		ChainRPC:                      ctx.GlobalString(flags.ChainRPCFlag.Name),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+		BatchMetadataGraphUrl:         ctx.GlobalString(flags.BatchMetadataGraphUrlFlag.Name),
+		OperatorStateGraphUrl:         ctx.GlobalString(flags.OperatorStateGraphUrlFlag.Name),
+		LoggingConfig:                 logging.ReadCLIConfig(ctx, flags.FlagPrefix),
+
+		SignerPrivateKey:  strings.TrimPrefix(ctx.GlobalString(flags.SignerPrivateKeyFlag.Name), "0x"),
+		Output:            ctx.GlobalString(flags.OutputFlag.Name),
+		Submit:            ctx.GlobalBool(flags.SubmitFlag.Name),
+		EjectorPrivateKey: strings.TrimPrefix(ctx.GlobalString(flags.EjectorPrivateKeyFlag.Name), "0x"),
+	}, nil
+}
+
+// parseOperatorId parses the operator ID as 32 hex encoded bytes, optionally prefixed with 0x
+func parseOperatorId(operatorId string) (core.OperatorID, error) {
+	var id core.OperatorID
+	decoded, err := hex.DecodeString(strings.TrimPrefix(operatorId, "0x"))
+	if err != nil {
+		return id, err
+	}
+	if len(decoded) != len(id) {
+		return id, hex.ErrLength
+	}
+	copy(id[:], decoded)
+	return id, nil
+}
+
+// validateFlags checks the operator, the time window, the contracts, the quorums and the keys of the ejection
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	if _, err := parseOperatorId(ctx.GlobalString(flags.OperatorIdFlag.Name)); err != nil {
+		v.Addf("%s: %q is not 32 hex encoded bytes", flags.OperatorIdFlag.Name, ctx.GlobalString(flags.OperatorIdFlag.Name))
+	}
+	startTime, startErr := time.Parse(time.RFC3339, ctx.GlobalString(flags.StartTimeFlag.Name))
+	if startErr != nil {
+		v.Addf("%s: %q is not an RFC 3339 time", flags.StartTimeFlag.Name, ctx.GlobalString(flags.StartTimeFlag.Name))
+	}
+	endTime, endErr := time.Parse(time.RFC3339, ctx.GlobalString(flags.EndTimeFlag.Name))
+	if endErr != nil {
+		v.Addf("%s: %q is not an RFC 3339 time", flags.EndTimeFlag.Name, ctx.GlobalString(flags.EndTimeFlag.Name))
+	}
+	if startErr == nil && endErr == nil && !endTime.After(startTime) {
+		v.Addf("%s: %s must be after the %s of %s", flags.EndTimeFlag.Name, endTime.Format(time.RFC3339), flags.StartTimeFlag.Name, startTime.Format(time.RFC3339))
+	}
+	v.Add(validation.Address(flags.BlsOperatorStateRetrieverFlag.Name, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name)))
+	v.Add(validation.Address(flags.EigenDAServiceManagerFlag.Name, ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name)))
+	for _, quorum := range ctx.GlobalIntSlice(flags.QuorumsFlag.Name) {
+		v.Add(validation.Range(flags.QuorumsFlag.Name, quorum, 0, 255))
+	}
+
+	if _, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.GlobalString(flags.SignerPrivateKeyFlag.Name), "0x")); err != nil {
+		v.Addf("%s: not a hex ECDSA private key", flags.SignerPrivateKeyFlag.Name)
+	}
+	if ctx.GlobalBool(flags.SubmitFlag.Name) {
+		if _, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.GlobalString(flags.EjectorPrivateKeyFlag.Name), "0x")); err != nil {
+			v.Addf("%s: the ejection is only submitted with the hex ECDSA private key of the ejector", flags.EjectorPrivateKeyFlag.Name)
+		}
+	}
+	return v.Err()
+}
@@ -0,0 +1,53 @@
+package ejection
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/common"
+	regcoordinator "github.com/Layr-Labs/eigenda/contracts/bindings/BLSRegistryCoordinatorWithIndices"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const ejectionMethod = "ejectOperatorFromCoordinator"
+
+// EjectionCalldata returns the calldata of the ejection of the operator from the quorums by the registry coordinator
+func EjectionCalldata(operator gethcommon.Address, quorums []core.QuorumID, pubkey *core.G1Point) ([]byte, error) {
+	if pubkey == nil {
+		return nil, fmt.Errorf("operator %s has no public key", operator.Hex())
+	}
+	coordinatorABI, err := regcoordinator.ContractBLSRegistryCoordinatorWithIndicesMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	quorumNumbers := make([]byte, len(quorums))
+	for i, quorum := range quorums {
+		quorumNumbers[i] = byte(quorum)
+	}
+	return coordinatorABI.Pack(ejectionMethod, operator, quorumNumbers, regcoordinator.BN254G1Point{
+		X: pubkey.X.BigInt(new(big.Int)),
+		Y: pubkey.Y.BigInt(new(big.Int)),
+	})
+}
+
+// SubmitEjection sends the ejection of the report from the account of the client, which must be the ejector of the
+// registry coordinator, and waits for its receipt
+func SubmitEjection(ctx context.Context, client common.EthClient, ejection *EjectionCall) (*types.Receipt, error) {
+	calldata, err := hexutil.Decode(ejection.Calldata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ejection calldata: %w", err)
+	}
+	// The calldata of the report is sent as is, rather than packed again from its arguments
+	coordinator := bind.NewBoundContract(gethcommon.HexToAddress(ejection.RegistryCoordinator), abi.ABI{}, client, client, client)
+	tx, err := coordinator.RawTransact(client.GetNoSendTransactOpts(), calldata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the ejection transaction: %w", err)
+	}
+	return client.EstimateGasPriceAndLimitAndSendTx(ctx, tx, "EjectOperatorFromCoordinator", nil)
+}
@@ -0,0 +1,125 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = "ejection"
+	envPrefix  = "EJECTION"
+)
+
+var (
+	/* Required Flags */
+
+	OperatorIdFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-id"),
+		Usage:    "ID of the operator the report is compiled for, as 32 hex encoded bytes",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_ID"),
+	}
+	StartTimeFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "start-time"),
+		Usage:    "Start of the time range of the batches of the report, in RFC 3339 format such as 2024-01-02T15:04:05Z",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "START_TIME"),
+	}
+	EndTimeFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "end-time"),
+		Usage:    "End of the time range of the batches of the report, in RFC 3339 format",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "END_TIME"),
+	}
+	ChainRPCFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-rpc"),
+		Usage:    "URL of the eth RPC the stakes of the operators are read from",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHAIN_RPC"),
+	}
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+	BatchMetadataGraphUrlFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-metadata-graph-url"),
+		Usage:    "URL of the subgraph indexing the confirmed batches and their non signers",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BATCH_METADATA_GRAPH_URL"),
+	}
+	OperatorStateGraphUrlFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-state-graph-url"),
+		Usage:    "URL of the subgraph indexing the registrations and the public keys of the operators",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_STATE_GRAPH_URL"),
+	}
+	SignerPrivateKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signer-private-key"),
+		Usage:    "Hex ECDSA private key the report is signed with",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIGNER_PRIVATE_KEY"),
+	}
+
+	/* Optional Flags */
+
+	QuorumsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorums"),
+		Usage:    "Quorums the operator is ejected from. Defaults to all the quorums the operator is currently registered in",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "QUORUMS"),
+	}
+	OutputFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "output"),
+		Usage:    "Path of the file the signed report is written to. The report is written to the standard output if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OUTPUT"),
+	}
+	SubmitFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "submit"),
+		Usage:    "Whether to send the ejection transaction of the report from the account of ejector-private-key. The transaction is only written to the report otherwise",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SUBMIT"),
+	}
+	EjectorPrivateKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ejector-private-key"),
+		Usage:    "Hex ECDSA private key of the ejector of the registry coordinator, which the ejection is sent from. Required by submit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EJECTOR_PRIVATE_KEY"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	OperatorIdFlag,
+	StartTimeFlag,
+	EndTimeFlag,
+	ChainRPCFlag,
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+	BatchMetadataGraphUrlFlag,
+	OperatorStateGraphUrlFlag,
+	SignerPrivateKeyFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	QuorumsFlag,
+	OutputFlag,
+	SubmitFlag,
+	EjectorPrivateKeyFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, logging.CLIFlags(envPrefix, FlagPrefix)...)
+}
@@ -0,0 +1,149 @@
+package ejection
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ReportVersion is the version of the format of the reports. The fields of a version are never renamed, retyped or
+// removed, so that the reports attached to the governance proposals can be checked by the later versions of the tool.
+const ReportVersion = 1
+
+var ErrInvalidSignature = errors.New("invalid report signature")
+
+// Report is the signing record of an operator over a time range, as attested by the confirmed batches, along with
+// the ejection of the operator that it supports. The hashes, addresses and calldata are lower case hex prefixed with
+// 0x, the stakes are decimal strings, and the times are unix timestamps in seconds.
+type Report struct {
+	Version    int    `json:"version"`
+	OperatorId string `json:"operator_id"`
+	// OperatorAddress is the address the operator registered with
+	OperatorAddress string `json:"operator_address"`
+	// StartTime and EndTime bound, inclusively, the confirmation times of the batches of the report
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+	// Quorums are the signing rates of the operator in each quorum it was responsible for batches of, by quorum ID
+	Quorums []*QuorumSigningRate `json:"quorums"`
+	// Batches are the batches the operator was responsible for, by batch ID
+	Batches  []*BatchEvidence `json:"batches"`
+	Ejection *EjectionCall    `json:"ejection"`
+}
+
+type QuorumSigningRate struct {
+	QuorumId             uint8   `json:"quorum_id"`
+	TotalBatches         int     `json:"total_batches"`
+	SignedBatches        int     `json:"signed_batches"`
+	NonSigningPercentage float64 `json:"nonsigning_percentage"`
+}
+
+// BatchEvidence is a batch the operator was responsible for, i.e. that had a quorum it was registered in at the
+// reference block of the batch, and whether the operator signed it
+type BatchEvidence struct {
+	BatchId              uint64 `json:"batch_id"`
+	BatchHeaderHash      string `json:"batch_header_hash"`
+	ReferenceBlockNumber uint64 `json:"reference_block_number"`
+	// ConfirmationBlockNumber and ConfirmationTxHash are the block and the transaction the batch was confirmed in
+	ConfirmationBlockNumber uint64 `json:"confirmation_block_number"`
+	ConfirmationTxHash      string `json:"confirmation_tx_hash"`
+	ConfirmationTime        int64  `json:"confirmation_time"`
+	Signed                  bool   `json:"signed"`
+	// Quorums are the stakes at the reference block of the quorums of the batch the operator was registered in
+	Quorums []*QuorumStake `json:"quorums"`
+}
+
+type QuorumStake struct {
+	QuorumId        uint8   `json:"quorum_id"`
+	Stake           string  `json:"stake"`
+	TotalStake      string  `json:"total_stake"`
+	StakePercentage float64 `json:"stake_percentage"`
+}
+
+// EjectionCall is the transaction ejecting the operator from quorums, to be sent by the ejector of the registry
+// coordinator
+type EjectionCall struct {
+	RegistryCoordinator string  `json:"registry_coordinator"`
+	QuorumNumbers       []uint8 `json:"quorum_numbers"`
+	Calldata            string  `json:"calldata"`
+}
+
+// SignedReport is a report along with the signature of its signer. The signature is the EIP-191 personal signature
+// of the compact JSON encoding of the report, in the order of its fields, so that it can be checked with the usual
+// wallets and tools.
+type SignedReport struct {
+	Report    json.RawMessage `json:"report"`
+	Signer    string          `json:"signer"`
+	Signature string          `json:"signature"`
+}
+
+// SignReport signs the report with the key
+func SignReport(report *Report, key *ecdsa.PrivateKey) (*SignedReport, error) {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(accounts.TextHash(encoded), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the report: %w", err)
+	}
+	// The recovery ID is offset as the wallets do
+	signature[crypto.RecoveryIDOffset] += 27
+	return &SignedReport{
+		Report:    encoded,
+		Signer:    hexutil.Encode(crypto.PubkeyToAddress(key.PublicKey).Bytes()),
+		Signature: hexutil.Encode(signature),
+	}, nil
+}
+
+// Verify checks the signature of the report against its signer, and returns the report
+func (r *SignedReport) Verify() (*Report, error) {
+	var encoded bytes.Buffer
+	if err := json.Compact(&encoded, r.Report); err != nil {
+		return nil, fmt.Errorf("%w: the report isn't JSON: %w", ErrInvalidSignature, err)
+	}
+	signature, err := hexutil.Decode(r.Signature)
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%w: the signature must be %d hex encoded bytes", ErrInvalidSignature, crypto.SignatureLength)
+	}
+	signature[crypto.RecoveryIDOffset] -= 27
+	pubkey, err := crypto.SigToPub(accounts.TextHash(encoded.Bytes()), signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != gethcommon.HexToAddress(r.Signer) {
+		return nil, fmt.Errorf("%w: the report is signed by %s, not by %s", ErrInvalidSignature, signer.Hex(), r.Signer)
+	}
+
+	report := new(Report)
+	if err := json.Unmarshal(encoded.Bytes(), report); err != nil {
+		return nil, err
+	}
+	if report.Version > ReportVersion {
+		return nil, fmt.Errorf("the report is of version %d, above %d", report.Version, ReportVersion)
+	}
+	return report, nil
+}
+
+// Write writes the signed report as indented JSON
+func (r *SignedReport) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// ReadSignedReport reads a signed report written by Write, without verifying it
+func ReadSignedReport(r io.Reader) (*SignedReport, error) {
+	report := new(SignedReport)
+	if err := json.NewDecoder(r).Decode(report); err != nil {
+		return nil, fmt.Errorf("failed to read the signed report: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,54 @@
+package ejection_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/Layr-Labs/eigenda/tools/ejection"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedReport(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	operatorId := keyPair.GetPubKeyG1().GetOperatorID()
+	states := map[uint]*core.OperatorState{100: operatorState(operatorId, map[core.QuorumID]int64{0: 100})}
+	compiler, _ := newTestCompiler(t, keyPair, []*subgraph.BatchAttestation{makeAttestation(1, 1700000100, 100, operatorId)}, states)
+	report, err := compiler.Compile(context.Background(), operatorId, time.Unix(1700000000, 0), time.Unix(1700000400, 0), []core.QuorumID{0})
+	assert.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signed, err := ejection.SignReport(report, key)
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(crypto.PubkeyToAddress(key.PublicKey).Bytes()), signed.Signer)
+
+	// The written report reads back, and verifies, as signed
+	var written bytes.Buffer
+	assert.NoError(t, signed.Write(&written))
+	read, err := ejection.ReadSignedReport(bytes.NewReader(written.Bytes()))
+	assert.NoError(t, err)
+	verified, err := read.Verify()
+	assert.NoError(t, err)
+	assert.Equal(t, report, verified)
+
+	// A changed report, or a report claimed by another signer, doesn't verify
+	tampered := *read
+	tampered.Report = bytes.Replace(read.Report, []byte(`"signed": false`), []byte(`"signed": true`), 1)
+	assert.NotEqual(t, read.Report, tampered.Report)
+	_, err = tampered.Verify()
+	assert.ErrorIs(t, err, ejection.ErrInvalidSignature)
+	impersonated := *read
+	impersonated.Signer = "0x00000000000000000000000000000000000000aa"
+	_, err = impersonated.Verify()
+	assert.ErrorIs(t, err, ejection.ErrInvalidSignature)
+	impersonated.Signature = "0x00"
+	_, err = impersonated.Verify()
+	assert.ErrorIs(t, err, ejection.ErrInvalidSignature)
+}
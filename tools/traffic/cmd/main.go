@@ -5,24 +5,23 @@ import (
 	"log"
 	"os"
 
+	"github.com/Layr-Labs/eigenda/common/configfile"
+	"github.com/Layr-Labs/eigenda/common/version"
 	"github.com/Layr-Labs/eigenda/tools/traffic"
 	"github.com/Layr-Labs/eigenda/tools/traffic/flags"
 	"github.com/urfave/cli"
 )
 
-var (
-	version   = ""
-	gitCommit = ""
-	gitDate   = ""
-)
-
 func main() {
 	app := cli.NewApp()
-	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	app.Version = version.String()
 	app.Name = "da-traffic-generator"
 	app.Usage = "EigenDA Traffic Generator"
 	app.Description = "Service for generating traffic to EigenDA disperser"
 	app.Flags = flags.Flags
+	configfile.AddDumpCommand(app, func(ctx *cli.Context) (any, error) {
+		return traffic.NewConfig(ctx)
+	})
 	app.Action = trafficGeneratorMain
 	if err := app.Run(os.Args); err != nil {
 		log.Fatalf("application failed: %v", err)
@@ -30,10 +29,13 @@ func main() {
 }
 
 func trafficGeneratorMain(ctx *cli.Context) error {
-	config := traffic.NewConfig(ctx)
+	config, err := traffic.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
 	generator, err := traffic.NewTrafficGenerator(config)
 	if err != nil {
-		panic("failed to create new traffic generator")
+		return fmt.Errorf("failed to create new traffic generator: %w", err)
 	}
 
 	return generator.Run()
@@ -1,9 +1,11 @@
 package traffic
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/common/validation"
 	"github.com/Layr-Labs/eigenda/tools/traffic/flags"
 	"github.com/urfave/cli"
 )
@@ -21,9 +23,42 @@ type Config struct {
 	RandomizeBlobs         bool
 	InstanceLaunchInterval time.Duration
 	UseSecureGrpcFlag      bool
+
+	// SizeDistribution is the distribution of the sizes of the blobs, see FixedSize, UniformSize and LogNormalSize
+	SizeDistribution string
+	MaxDataSize      uint64
+	LogNormalMu      float64
+	LogNormalSigma   float64
+	// TargetRate is the number of requests per second of all the instances, which replaces RequestInterval if it's
+	// set. The rate ramps up linearly from 0 over RampUp.
+	TargetRate float64
+	RampUp     time.Duration
+	// SignerPrivateKeys are the keys the instances authenticate their dispersals with, in turn. The dispersals
+	// aren't authenticated if there are none.
+	SignerPrivateKeys []string
+	// StatusPollInterval is how often the statuses of the dispersed blobs are polled to measure their latencies, or
+	// 0 to not track them
+	StatusPollInterval time.Duration
+	StatusTimeout      time.Duration
+	// RetrieveSampleRate is the share of the confirmed blobs retrieved to check that they round-trip
+	RetrieveSampleRate float64
+	// Duration is how long the traffic is sent for, or 0 until the generator is interrupted
+	Duration time.Duration
+	// MaxErrorRate is the share of the requests that may fail before the run fails
+	MaxErrorRate      float64
+	LatencyCSV        string
+	PrometheusPushURL string
+	// MaxInFlight is the number of workers each instance sends its requests with, DefaultMaxInFlight if 0
+	MaxInFlight uint
 }
 
-func NewConfig(ctx *cli.Context) *Config {
+// DefaultMaxInFlight is the number of requests of an instance in flight if the config doesn't set it
+const DefaultMaxInFlight = 1000
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	if err := validateFlags(ctx); err != nil {
+		return nil, err
+	}
 	return &Config{
 		Hostname:               ctx.GlobalString(flags.HostnameFlag.Name),
 		GrpcPort:               ctx.GlobalString(flags.GrpcPortFlag.Name),
@@ -37,5 +72,72 @@ func NewConfig(ctx *cli.Context) *Config {
 		RandomizeBlobs:         ctx.GlobalBool(flags.RandomizeBlobsFlag.Name),
 		InstanceLaunchInterval: ctx.Duration(flags.InstanceLaunchIntervalFlag.Name),
 		UseSecureGrpcFlag:      ctx.GlobalBool(flags.UseSecureGrpcFlag.Name),
+
+		SizeDistribution:   ctx.GlobalString(flags.SizeDistributionFlag.Name),
+		MaxDataSize:        ctx.GlobalUint64(flags.MaxDataSizeFlag.Name),
+		LogNormalMu:        ctx.GlobalFloat64(flags.LogNormalMuFlag.Name),
+		LogNormalSigma:     ctx.GlobalFloat64(flags.LogNormalSigmaFlag.Name),
+		TargetRate:         ctx.GlobalFloat64(flags.TargetRateFlag.Name),
+		RampUp:             ctx.GlobalDuration(flags.RampUpFlag.Name),
+		SignerPrivateKeys:  ctx.GlobalStringSlice(flags.SignerPrivateKeysFlag.Name),
+		StatusPollInterval: ctx.GlobalDuration(flags.StatusPollIntervalFlag.Name),
+		StatusTimeout:      ctx.GlobalDuration(flags.StatusTimeoutFlag.Name),
+		RetrieveSampleRate: ctx.GlobalFloat64(flags.RetrieveSampleRateFlag.Name),
+		Duration:           ctx.GlobalDuration(flags.DurationFlag.Name),
+		MaxErrorRate:       ctx.GlobalFloat64(flags.MaxErrorRateFlag.Name),
+		LatencyCSV:         ctx.GlobalString(flags.LatencyCSVFlag.Name),
+		PrometheusPushURL:  ctx.GlobalString(flags.PrometheusPushURLFlag.Name),
+		MaxInFlight:        ctx.GlobalUint(flags.MaxInFlightFlag.Name),
+	}, nil
+}
+
+// validateFlags checks the rate, the thresholds and the sizes of the blobs the generator disperses
+func validateFlags(ctx *cli.Context) error {
+	var v validation.Violations
+	v.Add(validation.Port(flags.GrpcPortFlag.Name, ctx.GlobalString(flags.GrpcPortFlag.Name)))
+	v.Add(validation.AtLeast(flags.TimeoutFlag.Name, ctx.GlobalDuration(flags.TimeoutFlag.Name), time.Millisecond))
+	v.Add(validation.AtLeast(flags.RequestIntervalFlag.Name, ctx.GlobalDuration(flags.RequestIntervalFlag.Name), time.Millisecond))
+	v.Add(validation.AtLeast(flags.NumInstancesFlag.Name, ctx.GlobalUint(flags.NumInstancesFlag.Name), 1))
+	v.Add(validation.AtLeast(flags.DataSizeFlag.Name, ctx.GlobalUint64(flags.DataSizeFlag.Name), 1))
+
+	// A blob can only be confirmed by a share of the stake larger than the share an adversary may hold
+	thresholds := make(map[string]uint64)
+	for _, flag := range []cli.StringFlag{flags.QuorumThresholdFlag, flags.AdversarialThresholdFlag} {
+		threshold, err := strconv.ParseUint(ctx.GlobalString(flag.Name), 10, 8)
+		if err != nil {
+			v.Addf("%s: %q is not a percentage", flag.Name, ctx.GlobalString(flag.Name))
+			continue
+		}
+		v.Add(validation.Range(flag.Name, threshold, 0, 100))
+		thresholds[flag.Name] = threshold
+	}
+	quorumThreshold, hasQuorumThreshold := thresholds[flags.QuorumThresholdFlag.Name]
+	adversarialThreshold, hasAdversarialThreshold := thresholds[flags.AdversarialThresholdFlag.Name]
+	if hasQuorumThreshold && hasAdversarialThreshold && quorumThreshold <= adversarialThreshold {
+		v.Addf("%s: %d must exceed the %s of %d", flags.QuorumThresholdFlag.Name, quorumThreshold, flags.AdversarialThresholdFlag.Name, adversarialThreshold)
+	}
+
+	switch distribution := ctx.GlobalString(flags.SizeDistributionFlag.Name); distribution {
+	case FixedSize:
+	case UniformSize:
+		v.Add(validation.AtLeast(flags.MaxDataSizeFlag.Name, ctx.GlobalUint64(flags.MaxDataSizeFlag.Name), ctx.GlobalUint64(flags.DataSizeFlag.Name)))
+	case LogNormalSize:
+		v.Add(validation.AtLeast(flags.MaxDataSizeFlag.Name, ctx.GlobalUint64(flags.MaxDataSizeFlag.Name), 1))
+		v.Add(validation.AtLeast(flags.LogNormalSigmaFlag.Name, ctx.GlobalFloat64(flags.LogNormalSigmaFlag.Name), 0))
+	default:
+		v.Addf("%s: unknown distribution %q, must be %s, %s or %s", flags.SizeDistributionFlag.Name, distribution, FixedSize, UniformSize, LogNormalSize)
+	}
+	v.Add(validation.AtLeast(flags.TargetRateFlag.Name, ctx.GlobalFloat64(flags.TargetRateFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.RampUpFlag.Name, ctx.GlobalDuration(flags.RampUpFlag.Name), 0))
+	v.Add(validation.AtLeast(flags.DurationFlag.Name, ctx.GlobalDuration(flags.DurationFlag.Name), 0))
+	v.Add(validation.Range(flags.RetrieveSampleRateFlag.Name, ctx.GlobalFloat64(flags.RetrieveSampleRateFlag.Name), 0, 1))
+	v.Add(validation.Range(flags.MaxErrorRateFlag.Name, ctx.GlobalFloat64(flags.MaxErrorRateFlag.Name), 0, 1))
+	v.Add(validation.AtLeast(flags.MaxInFlightFlag.Name, ctx.GlobalUint(flags.MaxInFlightFlag.Name), 1))
+	if ctx.GlobalDuration(flags.StatusPollIntervalFlag.Name) > 0 {
+		v.Add(validation.AtLeast(flags.StatusTimeoutFlag.Name, ctx.GlobalDuration(flags.StatusTimeoutFlag.Name), ctx.GlobalDuration(flags.StatusPollIntervalFlag.Name)))
+	} else if ctx.GlobalFloat64(flags.RetrieveSampleRateFlag.Name) > 0 {
+		// The blobs can only be retrieved once they are confirmed
+		v.Addf("%s: the blobs can only be retrieved if %s is set", flags.RetrieveSampleRateFlag.Name, flags.StatusPollIntervalFlag.Name)
 	}
+	return v.Err()
 }
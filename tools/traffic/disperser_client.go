@@ -4,9 +4,10 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
-	"time"
 
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -15,7 +16,12 @@ import (
 
 type DisperserClient interface {
 	DisperseBlob(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8) (*disperser.BlobStatus, []byte, error)
+	// DisperseBlobAuthenticated is like DisperseBlob, except that the dispersal is authenticated as the account of
+	// the signer
+	DisperseBlobAuthenticated(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8, signer auth.BlobRequestSigner) (*disperser.BlobStatus, []byte, error)
 	GetBlobStatus(ctx context.Context, key []byte) (*disperser_rpc.BlobStatusReply, error)
+	// RetrieveBlob retrieves the data of a confirmed blob from the disperser
+	RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error)
 }
 
 type client struct {
@@ -39,6 +45,15 @@ func (c *client) getDialOptions() []grpc.DialOption {
 }
 
 func (c *client) DisperseBlob(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8) (*disperser.BlobStatus, []byte, error) {
+	return c.disperse(ctx, data, quorumID, quorumThreshold, adversityThreshold, nil)
+}
+
+func (c *client) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8, signer auth.BlobRequestSigner) (*disperser.BlobStatus, []byte, error) {
+	return c.disperse(ctx, data, quorumID, quorumThreshold, adversityThreshold, signer)
+}
+
+// disperse disperses the blob, authenticated with the signer unless it's nil
+func (c *client) disperse(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8, signer auth.BlobRequestSigner) (*disperser.BlobStatus, []byte, error) {
 	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.GrpcPort)
 
 	dialOptions := c.getDialOptions()
@@ -63,7 +78,12 @@ func (c *client) DisperseBlob(ctx context.Context, data []byte, quorumID, quorum
 		},
 	}
 
-	reply, err := disperserClient.DisperseBlob(ctxTimeout, request)
+	var reply *disperser_rpc.DisperseBlobReply
+	if signer != nil {
+		reply, err = clients.DisperseBlobAuthenticated(ctxTimeout, disperserClient, signer, request)
+	} else {
+		reply, err = disperserClient.DisperseBlob(ctxTimeout, request)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,16 +98,14 @@ func (c *client) DisperseBlob(ctx context.Context, data []byte, quorumID, quorum
 
 func (c *client) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
 	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.GrpcPort)
-	conn, err := grpc.Dial(
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = conn.Close() }()
 
 	disperserClient := disperser_rpc.NewDisperserClient(conn)
-	ctxTimeout, cancel := context.WithTimeout(ctx, time.Second*60)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
 	defer cancel()
 
 	request := &disperser_rpc.BlobStatusRequest{
@@ -101,3 +119,25 @@ func (c *client) GetBlobStatus(ctx context.Context, requestID []byte) (*disperse
 
 	return reply, nil
 }
+
+func (c *client) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.GrpcPort)
+	conn, err := grpc.Dial(addr, c.getDialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	disperserClient := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	reply, err := disperserClient.RetrieveBlob(ctxTimeout, &disperser_rpc.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       blobIndex,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetData(), nil
+}
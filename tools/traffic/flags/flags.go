@@ -85,6 +85,102 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "USE_SECURE_GRPC"),
 	}
+	SizeDistributionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "size-distribution"),
+		Usage:    "Distribution of the blob sizes: fixed at data-size, uniform between data-size and max-data-size, or lognormal capped at max-data-size",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIZE_DISTRIBUTION"),
+		Value:    "fixed",
+	}
+	MaxDataSizeFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-data-size"),
+		Usage:    "Largest size of the data blobs of the uniform and lognormal size distributions",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_DATA_SIZE"),
+	}
+	LogNormalMuFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "size-lognormal-mu"),
+		Usage:    "Mean of the natural log of the blob sizes in bytes of the lognormal size distribution",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIZE_LOGNORMAL_MU"),
+		Value:    11,
+	}
+	LogNormalSigmaFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "size-lognormal-sigma"),
+		Usage:    "Standard deviation of the natural log of the blob sizes in bytes of the lognormal size distribution",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIZE_LOGNORMAL_SIGMA"),
+		Value:    1,
+	}
+	TargetRateFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "target-rate"),
+		Usage:    "Requests per second of all the instances together, which replaces request-interval if set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "TARGET_RATE"),
+	}
+	RampUpFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ramp-up"),
+		Usage:    "Duration over which the request rate ramps up linearly to target-rate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RAMP_UP"),
+	}
+	SignerPrivateKeysFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signer-private-keys"),
+		Usage:    "Hex private keys the instances authenticate their dispersals with, in turn. The dispersals are unauthenticated if none is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIGNER_PRIVATE_KEYS"),
+	}
+	StatusPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-poll-interval"),
+		Usage:    "Interval at which the statuses of the dispersed blobs are polled to measure their latencies. The statuses aren't tracked if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "STATUS_POLL_INTERVAL"),
+	}
+	StatusTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-timeout"),
+		Usage:    "Duration after which a dispersed blob that isn't finalized counts as an error",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "STATUS_TIMEOUT"),
+		Value:    30 * time.Minute,
+	}
+	RetrieveSampleRateFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieve-sample-rate"),
+		Usage:    "Share between 0 and 1 of the confirmed blobs that are retrieved to check that they round-trip",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RETRIEVE_SAMPLE_RATE"),
+	}
+	DurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "duration"),
+		Usage:    "Duration of the run, which lasts until interrupted if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DURATION"),
+	}
+	MaxErrorRateFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-error-rate"),
+		Usage:    "Share between 0 and 1 of the requests that may fail before the run exits with an error",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_ERROR_RATE"),
+		Value:    1,
+	}
+	LatencyCSVFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "latency-csv"),
+		Usage:    "Path of the CSV file the latency percentiles of the run are written to",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "LATENCY_CSV"),
+	}
+	PrometheusPushURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "prometheus-push-url"),
+		Usage:    "URL of the Prometheus push gateway the report of the run is pushed to",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PROMETHEUS_PUSH_URL"),
+	}
+	MaxInFlightFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-in-flight"),
+		Usage:    "Maximum number of requests of each instance in flight, including the tracking of their statuses. An instance falls behind its schedule while it has that many",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_IN_FLIGHT"),
+		Value:    1000,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -102,6 +198,21 @@ var optionalFlags = []cli.Flag{
 	RandomizeBlobsFlag,
 	InstanceLaunchIntervalFlag,
 	UseSecureGrpcFlag,
+	SizeDistributionFlag,
+	MaxDataSizeFlag,
+	LogNormalMuFlag,
+	LogNormalSigmaFlag,
+	TargetRateFlag,
+	RampUpFlag,
+	SignerPrivateKeysFlag,
+	StatusPollIntervalFlag,
+	StatusTimeoutFlag,
+	RetrieveSampleRateFlag,
+	DurationFlag,
+	MaxErrorRateFlag,
+	LatencyCSVFlag,
+	PrometheusPushURLFlag,
+	MaxInFlightFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
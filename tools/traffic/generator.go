@@ -1,23 +1,34 @@
 package traffic
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	mrand "math/rand"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
 	"time"
 
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/logging"
+	"github.com/Layr-Labs/eigenda/core/auth"
 )
 
 type TrafficGenerator struct {
 	Logger          common.Logger
 	DisperserClient DisperserClient
 	Config          *Config
+	// Stats collects the outcomes of the requests, which Run reports. The outcomes aren't collected if it's nil.
+	Stats *Stats
+	// Signers are the identities the writers disperse as, the i-th writer using the (i mod len)-th signer. The
+	// dispersals aren't authenticated if there are none.
+	Signers []auth.BlobRequestSigner
 }
 
 func NewTrafficGenerator(config *Config) (*TrafficGenerator, error) {
@@ -26,68 +37,253 @@ func NewTrafficGenerator(config *Config) (*TrafficGenerator, error) {
 		return nil, err
 	}
 
+	signers := make([]auth.BlobRequestSigner, 0, len(config.SignerPrivateKeys))
+	for i, key := range config.SignerPrivateKeys {
+		signer, err := auth.NewLocalBlobRequestSigner(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer private key %d: %w", i, err)
+		}
+		signers = append(signers, signer)
+	}
+
 	return &TrafficGenerator{
 		Logger:          logger,
 		DisperserClient: NewDisperserClient(config),
 		Config:          config,
+		Stats:           NewStats(),
+		Signers:         signers,
 	}, nil
 }
 
+// Run sends the traffic of all the writers until it's interrupted, or for the duration of the config if it's set,
+// and waits for the statuses of the blobs in flight. It then prints and exports the report of the run, and fails if
+// the error rate exceeds the maximum of the config.
 func (g *TrafficGenerator) Run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	var wg sync.WaitGroup
 	for i := 0; i < int(g.Config.NumInstances); i++ {
 		wg.Add(1)
-		go func() {
+		go func(writer int) {
 			defer wg.Done()
-			_ = g.StartTraffic(ctx)
-		}()
+			_ = g.StartWriter(ctx, writer)
+		}(i)
 		time.Sleep(g.Config.InstanceLaunchInterval)
 	}
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
-	<-signals
+	var deadline <-chan time.Time
+	if g.Config.Duration > 0 {
+		deadline = time.After(g.Config.Duration)
+	}
+	select {
+	case <-signals:
+	case <-deadline:
+	}
 
 	cancel()
+	if g.Config.StatusPollInterval > 0 {
+		g.Logger.Info("Waiting for the statuses of the blobs in flight", "timeout", g.Config.StatusTimeout)
+	}
 	wg.Wait()
+	return g.report()
+}
+
+// report prints and exports the report of the run, and checks its error rate
+func (g *TrafficGenerator) report() error {
+	if g.Stats == nil {
+		return nil
+	}
+	report := g.Stats.Report()
+	report.Print(os.Stdout)
+	if g.Config.LatencyCSV != "" {
+		if err := report.WriteCSV(g.Config.LatencyCSV); err != nil {
+			g.Logger.Error("Failed to write the latencies", "path", g.Config.LatencyCSV, "err", err)
+		}
+	}
+	if g.Config.PrometheusPushURL != "" {
+		if err := report.Push(g.Config.PrometheusPushURL); err != nil {
+			g.Logger.Error("Failed to push the report", "url", g.Config.PrometheusPushURL, "err", err)
+		}
+	}
+	if report.Requests > 0 && report.ErrorRate > g.Config.MaxErrorRate {
+		return fmt.Errorf("the error rate of %.2f%% exceeds the maximum of %.2f%%", 100*report.ErrorRate, 100*g.Config.MaxErrorRate)
+	}
 	return nil
 }
 
+// StartTraffic sends the requests of the first writer, see StartWriter
 func (g *TrafficGenerator) StartTraffic(ctx context.Context) error {
-	data := make([]byte, g.Config.DataSize)
-	_, err := rand.Read(data)
+	return g.StartWriter(ctx, 0)
+}
+
+// sendJob is a request of a writer, which one of its workers sends
+type sendJob struct {
+	blob     []byte
+	retrieve bool
+}
+
+// StartWriter sends the requests of the writer on its schedule until the context is done, and then waits for the
+// statuses of the blobs in flight, which are tracked up to the status timeout of the config. The requests are sent
+// by a fixed pool of workers, so that a slow disperser bounds the requests in flight rather than piling them up, and
+// the writer falls behind its schedule while all its workers are busy.
+func (g *TrafficGenerator) StartWriter(ctx context.Context, writer int) error {
+	seed := time.Now().UnixNano() + int64(writer)
+	sizer, err := newBlobSizer(g.Config, seed)
+	if err != nil {
+		return err
+	}
+	rng := mrand.New(mrand.NewSource(seed))
+	data := make([]byte, sizer.maxSize())
+	_, err = rand.Read(data)
 	if err != nil {
 		return err
 	}
 
-	ticker := time.NewTicker(g.Config.RequestInterval)
-	for {
+	numWorkers := int(g.Config.MaxInFlight)
+	if numWorkers == 0 {
+		numWorkers = DefaultMaxInFlight
+	}
+	jobs := make(chan sendJob)
+	var workers sync.WaitGroup
+	defer func() {
+		close(jobs)
+		workers.Wait()
+	}()
+	for i := 0; i < numWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range jobs {
+				// The blobs in flight are tracked once the writer stops sending
+				if err := g.sendRequest(context.WithoutCancel(ctx), job.blob, writer, job.retrieve); err != nil {
+					g.Stats.recordError()
+					g.Logger.Error("failed to send blob request", "writer", writer, "err:", err)
+				}
+			}
+		}()
+	}
+
+	behind := false
+	schedule := newSendSchedule(g.Config)
+	start := time.Now()
+	for n := 1; ; n++ {
+		timer := time.NewTimer(time.Until(start.Add(schedule.at(n))))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return nil
+		case <-timer.C:
+		}
+
+		blob := data[:sizer.next()]
+		if g.Config.RandomizeBlobs {
+			blob = make([]byte, len(blob))
+			if _, err := rand.Read(blob); err != nil {
+				return err
+			}
+		}
+		job := sendJob{blob: blob, retrieve: g.Config.RetrieveSampleRate > 0 && rng.Float64() < g.Config.RetrieveSampleRate}
+		select {
+		case jobs <- job:
+			continue
+		default:
+		}
+		if !behind {
+			behind = true
+			g.Logger.Warn("All the workers of the writer are busy, falling behind its schedule", "writer", writer, "maxInFlight", numWorkers)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case jobs <- job:
+		}
+	}
+}
+
+func (g *TrafficGenerator) sendRequest(ctx context.Context, data []byte, writer int, retrieve bool) error {
+	start := time.Now()
+	g.Stats.recordRequest()
+	ctxTimeout, cancel := context.WithTimeout(ctx, g.Config.Timeout)
+	defer cancel()
+	var blobStatus fmt.Stringer
+	var key []byte
+	var err error
+	if len(g.Signers) > 0 {
+		signer := g.Signers[writer%len(g.Signers)]
+		blobStatus, key, err = g.DisperserClient.DisperseBlobAuthenticated(ctxTimeout, data, 0, g.Config.QuorumThreshold, g.Config.AdversarialThreshold, signer)
+	} else {
+		blobStatus, key, err = g.DisperserClient.DisperseBlob(ctxTimeout, data, 0, g.Config.QuorumThreshold, g.Config.AdversarialThreshold)
+	}
+	if err != nil {
+		return err
+	}
+
+	g.Logger.Info("successfully dispersed new blob,", "key", hex.EncodeToString(key), "status", blobStatus.String(), "size", len(data))
+	if g.Config.StatusPollInterval == 0 {
+		return nil
+	}
+	return g.trackStatus(ctx, key, data, start, retrieve)
+}
+
+// trackStatus polls the status of the blob until it's finalized, recording the latencies from its dispersal at
+// start to its confirmation and finalization, which are thus accurate up to the poll interval. The confirmed blob
+// is retrieved and checked against the dispersed data if retrieve is set.
+func (g *TrafficGenerator) trackStatus(ctx context.Context, key, data []byte, start time.Time, retrieve bool) error {
+	ctx, cancel := context.WithTimeout(ctx, g.Config.StatusTimeout)
+	defer cancel()
+	ticker := time.NewTicker(g.Config.StatusPollInterval)
+	defer ticker.Stop()
+	confirmed := false
+	lastStatus := disperser_rpc.BlobStatus_UNKNOWN
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for blob %x to be finalized, last status %s", key, lastStatus)
 		case <-ticker.C:
-			if g.Config.RandomizeBlobs {
-				_, err := rand.Read(data)
-				if err != nil {
-					return err
+		}
+		reply, err := g.DisperserClient.GetBlobStatus(ctx, key)
+		if err != nil {
+			g.Logger.Warn("failed to get the blob status, retrying", "key", hex.EncodeToString(key), "err", err)
+			continue
+		}
+		lastStatus = reply.GetStatus()
+		switch lastStatus {
+		case disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			return fmt.Errorf("blob %x failed with status %s", key, lastStatus)
+		case disperser_rpc.BlobStatus_CONFIRMED, disperser_rpc.BlobStatus_FINALIZED:
+			if !confirmed {
+				confirmed = true
+				g.Stats.recordLatency(PhaseConfirmed, time.Since(start))
+				if retrieve {
+					if err := g.checkRoundTrip(ctx, reply.GetInfo(), data); err != nil {
+						return fmt.Errorf("blob %x: %w", key, err)
+					}
 				}
 			}
-			err := g.sendRequest(ctx, data, 0)
-			if err != nil {
-				g.Logger.Error("failed to send blob request", "err:", err)
+			if lastStatus == disperser_rpc.BlobStatus_FINALIZED {
+				g.Stats.recordLatency(PhaseFinalized, time.Since(start))
+				return nil
 			}
 		}
 	}
 }
 
-func (g *TrafficGenerator) sendRequest(ctx context.Context, data []byte, quorumID uint8) error {
+// checkRoundTrip retrieves the confirmed blob and checks that it holds the dispersed data, followed by the zero
+// padding of the disperser if any
+func (g *TrafficGenerator) checkRoundTrip(ctx context.Context, info *disperser_rpc.BlobInfo, data []byte) error {
+	proof := info.GetBlobVerificationProof()
+	if proof == nil {
+		return errors.New("the confirmed blob has no verification proof to retrieve it with")
+	}
 	ctxTimeout, cancel := context.WithTimeout(ctx, g.Config.Timeout)
 	defer cancel()
-	blobStatus, key, err := g.DisperserClient.DisperseBlob(ctxTimeout, data, quorumID, g.Config.QuorumThreshold, g.Config.AdversarialThreshold)
+	retrieved, err := g.DisperserClient.RetrieveBlob(ctxTimeout, proof.GetBatchMetadata().GetBatchHeaderHash(), proof.GetBlobIndex())
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to retrieve the blob: %w", err)
+	}
+	g.Stats.recordRetrieval()
+	if len(retrieved) < len(data) || !bytes.Equal(retrieved[:len(data)], data) || len(bytes.Trim(retrieved[len(data):], "\x00")) > 0 {
+		return fmt.Errorf("the retrieved blob of %d bytes doesn't match the %d bytes dispersed", len(retrieved), len(data))
 	}
-
-	g.Logger.Info("successfully dispersed new blob,", "key", hex.EncodeToString(key), "status", blobStatus.String())
 	return nil
 }
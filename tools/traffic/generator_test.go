@@ -2,15 +2,25 @@ package traffic_test
 
 import (
 	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients/dispersertest"
 	"github.com/Layr-Labs/eigenda/common/logging"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/tools/traffic"
 	traffic_mock "github.com/Layr-Labs/eigenda/tools/traffic/mock"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestTrafficGenerator(t *testing.T) {
@@ -40,3 +50,153 @@ func TestTrafficGenerator(t *testing.T) {
 	cancel()
 	disperserClient.AssertNumberOfCalls(t, "DisperseBlob", 2)
 }
+
+// slowDisperserClient disperses the blobs after a delay, and records the most dispersals it had in flight
+type slowDisperserClient struct {
+	traffic.DisperserClient
+	delay       time.Duration
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *slowDisperserClient) DisperseBlob(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8) (*disperser.BlobStatus, []byte, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+	time.Sleep(c.delay)
+	return c.DisperserClient.DisperseBlob(ctx, data, quorumID, quorumThreshold, adversityThreshold)
+}
+
+func TestTrafficGeneratorMaxInFlight(t *testing.T) {
+	disperserClient := traffic_mock.NewMockDisperserClient()
+	processing := disperser.Processing
+	disperserClient.On("DisperseBlob", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&processing, []byte{1}, nil)
+	logger, err := logging.GetLogger(logging.DefaultCLIConfig())
+	assert.NoError(t, err)
+	slowClient := &slowDisperserClient{DisperserClient: disperserClient, delay: 100 * time.Millisecond}
+	generator := &traffic.TrafficGenerator{
+		Logger: logger,
+		Config: &traffic.Config{
+			DataSize:    100,
+			TargetRate:  100,
+			Timeout:     time.Second,
+			MaxInFlight: 2,
+		},
+		DisperserClient: slowClient,
+	}
+
+	// The writer sends a request every 10ms, but only has two workers to send them with
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, generator.StartTraffic(ctx))
+	assert.Equal(t, 2, slowClient.maxInFlight)
+	assert.LessOrEqual(t, len(disperserClient.Calls), 8)
+}
+
+const (
+	testSignerKey  = "0x73ae7e3a40b59caacb1cda8fa04f4e7fa5bb2b37101f9f3506290c201f57bf7b"
+	otherSignerKey = "fb1c1b3e8d1b9e2a4a0b9d5e8a7c6f5e4d3c2b1a0f9e8d7c6b5a4938271605f4"
+)
+
+func newTestGenerator(t *testing.T, server *dispersertest.Disperser, config *traffic.Config) *traffic.TrafficGenerator {
+	address, err := server.Start("127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(server.Stop)
+	config.Hostname, config.GrpcPort, err = net.SplitHostPort(address)
+	assert.NoError(t, err)
+	generator, err := traffic.NewTrafficGenerator(config)
+	assert.NoError(t, err)
+	return generator
+}
+
+func TestTrafficGeneratorLatencies(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{ProcessingPolls: 1, ConfirmedPolls: 1}})
+	generator := newTestGenerator(t, server, &traffic.Config{
+		Timeout:            time.Second,
+		NumInstances:       2,
+		SizeDistribution:   traffic.UniformSize,
+		DataSize:           100,
+		MaxDataSize:        10_000,
+		RandomizeBlobs:     true,
+		TargetRate:         40,
+		RampUp:             200 * time.Millisecond,
+		SignerPrivateKeys:  []string{testSignerKey, otherSignerKey},
+		StatusPollInterval: 10 * time.Millisecond,
+		StatusTimeout:      5 * time.Second,
+		RetrieveSampleRate: 1,
+		Duration:           time.Second,
+		MaxErrorRate:       0,
+		LatencyCSV:         filepath.Join(t.TempDir(), "latencies.csv"),
+	})
+
+	assert.NoError(t, generator.Run())
+	report := generator.Stats.Report()
+	assert.Greater(t, report.Requests, 10)
+	assert.Equal(t, 0, report.Errors)
+	assert.Equal(t, report.Requests, report.Retrievals)
+	assert.Len(t, server.Requests(), report.Requests)
+	for _, summary := range report.Latencies {
+		assert.Equal(t, report.Requests, summary.Count, summary.Phase)
+		assert.LessOrEqual(t, summary.Percentiles[0], summary.Percentiles[2])
+		assert.LessOrEqual(t, summary.Percentiles[2], summary.Max)
+	}
+	// The blobs are confirmed at the second poll and finalized at the third one
+	assert.GreaterOrEqual(t, report.Latencies[1].Percentiles[0], 30*time.Millisecond)
+
+	csv, err := os.ReadFile(generator.Config.LatencyCSV)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	assert.Equal(t, []string{"phase,count,p50_ms,p90_ms,p99_ms,max_ms,requests,errors"}, lines[:1])
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[1], "confirmed,"))
+	assert.True(t, strings.HasPrefix(lines[2], "finalized,"))
+}
+
+func TestTrafficGeneratorErrorRate(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{})
+	server.RejectNextDispersals(3, status.Error(codes.ResourceExhausted, "rate limited"))
+	generator := newTestGenerator(t, server, &traffic.Config{
+		Timeout:            time.Second,
+		NumInstances:       1,
+		DataSize:           100,
+		RequestInterval:    50 * time.Millisecond,
+		StatusPollInterval: 10 * time.Millisecond,
+		StatusTimeout:      time.Second,
+		Duration:           500 * time.Millisecond,
+		MaxErrorRate:       0.1,
+	})
+
+	err := generator.Run()
+	assert.ErrorContains(t, err, "exceeds the maximum of 10.00%")
+	report := generator.Stats.Report()
+	assert.Equal(t, 3, report.Errors)
+	assert.Equal(t, report.Requests-3, report.Latencies[1].Count)
+}
+
+func TestTrafficGeneratorFailedBlobs(t *testing.T) {
+	server := dispersertest.NewDisperser(dispersertest.Config{Schedule: dispersertest.Schedule{FailWith: disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES}})
+	generator := newTestGenerator(t, server, &traffic.Config{
+		Timeout:            time.Second,
+		NumInstances:       1,
+		DataSize:           100,
+		RequestInterval:    100 * time.Millisecond,
+		StatusPollInterval: 10 * time.Millisecond,
+		StatusTimeout:      time.Second,
+		Duration:           350 * time.Millisecond,
+		MaxErrorRate:       1,
+	})
+
+	assert.NoError(t, generator.Run())
+	report := generator.Stats.Report()
+	assert.Equal(t, 3, report.Requests)
+	assert.Equal(t, 3, report.Errors)
+	assert.Equal(t, 0, report.Latencies[0].Count)
+}
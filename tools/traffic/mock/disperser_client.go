@@ -4,6 +4,7 @@ import (
 	"context"
 
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/tools/traffic"
 	"github.com/stretchr/testify/mock"
@@ -36,6 +37,23 @@ func (c *MockDisperserClient) DisperseBlob(ctx context.Context, data []byte, quo
 	return status, key, err
 }
 
+func (c *MockDisperserClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorumID, quorumThreshold, adversityThreshold uint8, signer auth.BlobRequestSigner) (*disperser.BlobStatus, []byte, error) {
+	args := c.Called(data, quorumID, quorumThreshold, adversityThreshold, signer)
+	var status *disperser.BlobStatus
+	if args.Get(0) != nil {
+		status = (args.Get(0)).(*disperser.BlobStatus)
+	}
+	var key []byte
+	if args.Get(1) != nil {
+		key = (args.Get(1)).([]byte)
+	}
+	var err error
+	if args.Get(2) != nil {
+		err = (args.Get(2)).(error)
+	}
+	return status, key, err
+}
+
 func (c *MockDisperserClient) GetBlobStatus(ctx context.Context, key []byte) (*disperser_rpc.BlobStatusReply, error) {
 	args := c.Called(key)
 	var reply *disperser_rpc.BlobStatusReply
@@ -48,3 +66,16 @@ func (c *MockDisperserClient) GetBlobStatus(ctx context.Context, key []byte) (*d
 	}
 	return reply, err
 }
+
+func (c *MockDisperserClient) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	args := c.Called(batchHeaderHash, blobIndex)
+	var data []byte
+	if args.Get(0) != nil {
+		data = (args.Get(0)).([]byte)
+	}
+	var err error
+	if args.Get(1) != nil {
+		err = (args.Get(1)).(error)
+	}
+	return data, err
+}
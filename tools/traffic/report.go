@@ -0,0 +1,188 @@
+package traffic
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// The phases of the dispersals whose latencies are reported, from the dispersal request
+const (
+	PhaseConfirmed = "confirmed"
+	PhaseFinalized = "finalized"
+)
+
+// pushJob is the job the reports are pushed to the Prometheus push gateway as
+const pushJob = "eigenda_traffic_generator"
+
+var percentiles = []float64{0.5, 0.9, 0.99}
+
+// Stats collects the outcomes of the requests of the generator. Its methods are safe for concurrent use, and a nil
+// Stats records nothing.
+type Stats struct {
+	mu         sync.Mutex
+	requests   int
+	errors     int
+	retrievals int
+	latencies  map[string][]time.Duration
+}
+
+func NewStats() *Stats {
+	return &Stats{latencies: make(map[string][]time.Duration)}
+}
+
+func (s *Stats) recordRequest() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+}
+
+// recordError records a request that failed: the dispersal was rejected, the blob failed or timed out before it
+// was finalized, or its retrieved bytes didn't match
+func (s *Stats) recordError() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors++
+}
+
+func (s *Stats) recordRetrieval() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retrievals++
+}
+
+func (s *Stats) recordLatency(phase string, latency time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[phase] = append(s.latencies[phase], latency)
+}
+
+// LatencySummary is the distribution of the latencies of a phase of the dispersals
+type LatencySummary struct {
+	Phase string
+	Count int
+	// Percentiles are the latencies at the 50th, 90th and 99th percentiles
+	Percentiles []time.Duration
+	Max         time.Duration
+}
+
+// Report summarizes the outcomes of the requests of a run
+type Report struct {
+	Requests   int
+	Errors     int
+	Retrievals int
+	// ErrorRate is the share of the requests that failed, 0 if there were none
+	ErrorRate float64
+	Latencies []LatencySummary
+}
+
+// Report summarizes the outcomes recorded so far
+func (s *Stats) Report() Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := Report{Requests: s.requests, Errors: s.errors, Retrievals: s.retrievals}
+	if s.requests > 0 {
+		report.ErrorRate = float64(s.errors) / float64(s.requests)
+	}
+	for _, phase := range []string{PhaseConfirmed, PhaseFinalized} {
+		latencies := append([]time.Duration{}, s.latencies[phase]...)
+		summary := LatencySummary{Phase: phase, Count: len(latencies)}
+		if len(latencies) > 0 {
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			for _, p := range percentiles {
+				// The nearest-rank percentile
+				rank := int(math.Ceil(p*float64(len(latencies)))) - 1
+				summary.Percentiles = append(summary.Percentiles, latencies[max(rank, 0)])
+			}
+			summary.Max = latencies[len(latencies)-1]
+		}
+		report.Latencies = append(report.Latencies, summary)
+	}
+	return report
+}
+
+// Print writes the report to w in a human-readable form
+func (r Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "requests: %d, errors: %d (%.2f%%), round-trip retrievals: %d\n", r.Requests, r.Errors, 100*r.ErrorRate, r.Retrievals)
+	for _, summary := range r.Latencies {
+		if summary.Count == 0 {
+			fmt.Fprintf(w, "dispersal to %s: no blob\n", summary.Phase)
+			continue
+		}
+		fmt.Fprintf(w, "dispersal to %s: %d blobs, p50 %v, p90 %v, p99 %v, max %v\n", summary.Phase, summary.Count,
+			summary.Percentiles[0].Round(time.Millisecond), summary.Percentiles[1].Round(time.Millisecond),
+			summary.Percentiles[2].Round(time.Millisecond), summary.Max.Round(time.Millisecond))
+	}
+}
+
+// WriteCSV writes the latency percentiles of the report to the file, one row per phase, in milliseconds
+func (r Report) WriteCSV(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	_ = w.Write([]string{"phase", "count", "p50_ms", "p90_ms", "p99_ms", "max_ms", "requests", "errors"})
+	for _, summary := range r.Latencies {
+		row := []string{summary.Phase, strconv.Itoa(summary.Count)}
+		for _, latency := range append(append([]time.Duration{}, summary.Percentiles...), summary.Max) {
+			if summary.Count == 0 {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatInt(latency.Milliseconds(), 10))
+		}
+		row = append(row, strconv.Itoa(r.Requests), strconv.Itoa(r.Errors))
+		_ = w.Write(row)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Push pushes the report to the Prometheus push gateway at the URL, replacing the previous report of the job
+func (r Report) Push(url string) error {
+	latency := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: pushJob,
+		Name:      "latency_ms",
+		Help:      "the latency percentiles in milliseconds from the dispersal of the blobs to their confirmation and finalization",
+	}, []string{"phase", "quantile"})
+	for _, summary := range r.Latencies {
+		if summary.Count == 0 {
+			continue
+		}
+		for i, p := range percentiles {
+			latency.WithLabelValues(summary.Phase, strconv.FormatFloat(p, 'f', -1, 64)).Set(float64(summary.Percentiles[i].Milliseconds()))
+		}
+		latency.WithLabelValues(summary.Phase, "1").Set(float64(summary.Max.Milliseconds()))
+	}
+	requests := prometheus.NewGauge(prometheus.GaugeOpts{Namespace: pushJob, Name: "requests", Help: "the number of dispersals of the run"})
+	requests.Set(float64(r.Requests))
+	errors := prometheus.NewGauge(prometheus.GaugeOpts{Namespace: pushJob, Name: "errors", Help: "the number of failed dispersals of the run"})
+	errors.Set(float64(r.Errors))
+	return push.New(url, pushJob).Collector(latency).Collector(requests).Collector(errors).Push()
+}
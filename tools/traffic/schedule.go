@@ -0,0 +1,43 @@
+package traffic
+
+import (
+	"math"
+	"time"
+)
+
+// sendSchedule is when a writer sends its requests: at a fixed interval, or at a target rate reached by a linear
+// ramp-up from 0
+type sendSchedule struct {
+	interval time.Duration
+	// rate is the number of requests per second of the writer once the ramp-up is over, or 0 for the fixed interval
+	rate   float64
+	rampUp time.Duration
+}
+
+// newSendSchedule returns the schedule of each of the writers of the config, which share the target rate
+func newSendSchedule(config *Config) sendSchedule {
+	schedule := sendSchedule{interval: config.RequestInterval}
+	if config.TargetRate > 0 {
+		schedule.rate = config.TargetRate / float64(max(config.NumInstances, 1))
+		schedule.rampUp = config.RampUp
+	}
+	return schedule
+}
+
+// at returns the time after the start of the writer that its n-th request is sent at, from n = 1. During the
+// ramp-up, the rate at time t is rate * t / rampUp, so n(t) = rate * t^2 / (2 * rampUp) requests are sent by t.
+func (s sendSchedule) at(n int) time.Duration {
+	if s.rate == 0 {
+		return time.Duration(n) * s.interval
+	}
+	rampUp := s.rampUp.Seconds()
+	// rampRequests is the number of requests sent during the ramp-up
+	rampRequests := s.rate * rampUp / 2
+	var seconds float64
+	if float64(n) <= rampRequests {
+		seconds = math.Sqrt(2 * float64(n) * rampUp / s.rate)
+	} else {
+		seconds = rampUp + (float64(n)-rampRequests)/s.rate
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
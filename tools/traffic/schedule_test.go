@@ -0,0 +1,70 @@
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendSchedule(t *testing.T) {
+	fixed := newSendSchedule(&Config{RequestInterval: 2 * time.Second, NumInstances: 2})
+	assert.Equal(t, 2*time.Second, fixed.at(1))
+	assert.Equal(t, 6*time.Second, fixed.at(3))
+
+	// Each of the 2 writers sends 5 requests per second, ramping up over 2 seconds, so 5 requests are sent during
+	// the ramp-up
+	ramp := newSendSchedule(&Config{RequestInterval: time.Hour, NumInstances: 2, TargetRate: 10, RampUp: 2 * time.Second})
+	assert.Equal(t, time.Duration(0), ramp.at(0))
+	assert.InDelta(t, (2 * time.Second).Seconds(), ramp.at(5).Seconds(), 1e-6)
+	assert.Less(t, ramp.at(2)-ramp.at(1), ramp.at(1))
+	assert.InDelta(t, (2*time.Second + time.Second).Seconds(), ramp.at(10).Seconds(), 1e-6)
+	assert.InDelta(t, 0.2, (ramp.at(11) - ramp.at(10)).Seconds(), 1e-6)
+
+	steady := newSendSchedule(&Config{NumInstances: 1, TargetRate: 4})
+	assert.InDelta(t, 0.25, steady.at(1).Seconds(), 1e-6)
+	assert.InDelta(t, 2.5, steady.at(10).Seconds(), 1e-6)
+}
+
+func TestBlobSizer(t *testing.T) {
+	_, err := newBlobSizer(&Config{SizeDistribution: "pareto"}, 0)
+	assert.Error(t, err)
+
+	fixed, err := newBlobSizer(&Config{DataSize: 100, MaxDataSize: 1000}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), fixed.maxSize())
+	assert.Equal(t, uint64(100), fixed.next())
+
+	uniform, err := newBlobSizer(&Config{SizeDistribution: UniformSize, DataSize: 100, MaxDataSize: 200}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200), uniform.maxSize())
+	sizes := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		size := uniform.next()
+		assert.GreaterOrEqual(t, size, uint64(100))
+		assert.LessOrEqual(t, size, uint64(200))
+		sizes[size] = true
+	}
+	assert.Greater(t, len(sizes), 50)
+
+	logNormal, err := newBlobSizer(&Config{SizeDistribution: LogNormalSize, MaxDataSize: 1 << 20, LogNormalMu: 10, LogNormalSigma: 2}, 2)
+	assert.NoError(t, err)
+	capped := 0
+	for i := 0; i < 1000; i++ {
+		size := logNormal.next()
+		assert.GreaterOrEqual(t, size, uint64(1))
+		assert.LessOrEqual(t, size, uint64(1<<20))
+		if size == 1<<20 {
+			capped++
+		}
+	}
+	// exp(10 + 2 * 1.93) is 1MiB, so about 1 in 40 sizes is capped
+	assert.Greater(t, capped, 0)
+
+	// The same seed draws the same sizes
+	again, err := newBlobSizer(&Config{SizeDistribution: UniformSize, DataSize: 100, MaxDataSize: 200}, 1)
+	assert.NoError(t, err)
+	first, err := newBlobSizer(&Config{SizeDistribution: UniformSize, DataSize: 100, MaxDataSize: 200}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, first.next(), again.next())
+}
@@ -0,0 +1,56 @@
+package traffic
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// The distributions of the sizes of the blobs
+const (
+	// FixedSize sends blobs of DataSize bytes
+	FixedSize = "fixed"
+	// UniformSize draws the sizes uniformly between DataSize and MaxDataSize bytes
+	UniformSize = "uniform"
+	// LogNormalSize draws the sizes from a log-normal distribution, whose log of the size in bytes is normal of mean
+	// LogNormalMu and standard deviation LogNormalSigma, capped at MaxDataSize bytes
+	LogNormalSize = "lognormal"
+)
+
+// blobSizer draws the sizes of the blobs from the distribution of the config
+type blobSizer struct {
+	config *Config
+	rng    *rand.Rand
+}
+
+func newBlobSizer(config *Config, seed int64) (*blobSizer, error) {
+	switch config.SizeDistribution {
+	case "", FixedSize, UniformSize, LogNormalSize:
+	default:
+		return nil, fmt.Errorf("unknown blob size distribution %q: must be %s, %s or %s", config.SizeDistribution, FixedSize, UniformSize, LogNormalSize)
+	}
+	return &blobSizer{config: config, rng: rand.New(rand.NewSource(seed))}, nil
+}
+
+// maxSize is the largest size the sizer draws
+func (s *blobSizer) maxSize() uint64 {
+	switch s.config.SizeDistribution {
+	case UniformSize, LogNormalSize:
+		return s.config.MaxDataSize
+	default:
+		return s.config.DataSize
+	}
+}
+
+// next draws the size of the next blob, which is at least 1 byte
+func (s *blobSizer) next() uint64 {
+	switch s.config.SizeDistribution {
+	case UniformSize:
+		return s.config.DataSize + uint64(s.rng.Int63n(int64(s.config.MaxDataSize-s.config.DataSize+1)))
+	case LogNormalSize:
+		size := math.Exp(s.config.LogNormalMu + s.config.LogNormalSigma*s.rng.NormFloat64())
+		return uint64(math.Max(1, math.Min(size, float64(s.config.MaxDataSize))))
+	default:
+		return s.config.DataSize
+	}
+}